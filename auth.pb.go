@@ -30,6 +30,7 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type AccessByKeyReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	ChanID               string   `protobuf:"bytes,2,opt,name=chanID,proto3" json:"chanID,omitempty"`
+	Action               string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -82,6 +83,13 @@ func (m *AccessByKeyReq) GetChanID() string {
 	return ""
 }
 
+func (m *AccessByKeyReq) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
 type ChannelOwnerReq struct {
 	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
 	ChanID               string   `protobuf:"bytes,2,opt,name=chanID,proto3" json:"chanID,omitempty"`
@@ -137,6 +145,61 @@ func (m *ChannelOwnerReq) GetChanID() string {
 	return ""
 }
 
+type ThingOwnerReq struct {
+	Owner                string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	ThingID              string   `protobuf:"bytes,2,opt,name=thingID,proto3" json:"thingID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ThingOwnerReq) Reset()         { *m = ThingOwnerReq{} }
+func (m *ThingOwnerReq) String() string { return proto.CompactTextString(m) }
+func (*ThingOwnerReq) ProtoMessage()    {}
+func (*ThingOwnerReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{2}
+}
+func (m *ThingOwnerReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ThingOwnerReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ThingOwnerReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ThingOwnerReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ThingOwnerReq.Merge(m, src)
+}
+func (m *ThingOwnerReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *ThingOwnerReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ThingOwnerReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ThingOwnerReq proto.InternalMessageInfo
+
+func (m *ThingOwnerReq) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *ThingOwnerReq) GetThingID() string {
+	if m != nil {
+		return m.ThingID
+	}
+	return ""
+}
+
 type ThingID struct {
 	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -148,7 +211,7 @@ func (m *ThingID) Reset()         { *m = ThingID{} }
 func (m *ThingID) String() string { return proto.CompactTextString(m) }
 func (*ThingID) ProtoMessage()    {}
 func (*ThingID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{2}
+	return fileDescriptor_8bbd6f3875b0e874, []int{3}
 }
 func (m *ThingID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -195,7 +258,7 @@ func (m *ChannelID) Reset()         { *m = ChannelID{} }
 func (m *ChannelID) String() string { return proto.CompactTextString(m) }
 func (*ChannelID) ProtoMessage()    {}
 func (*ChannelID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{3}
+	return fileDescriptor_8bbd6f3875b0e874, []int{4}
 }
 func (m *ChannelID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -234,6 +297,7 @@ func (m *ChannelID) GetValue() string {
 type AccessByIDReq struct {
 	ThingID              string   `protobuf:"bytes,1,opt,name=thingID,proto3" json:"thingID,omitempty"`
 	ChanID               string   `protobuf:"bytes,2,opt,name=chanID,proto3" json:"chanID,omitempty"`
+	Action               string   `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -243,7 +307,7 @@ func (m *AccessByIDReq) Reset()         { *m = AccessByIDReq{} }
 func (m *AccessByIDReq) String() string { return proto.CompactTextString(m) }
 func (*AccessByIDReq) ProtoMessage()    {}
 func (*AccessByIDReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{4}
+	return fileDescriptor_8bbd6f3875b0e874, []int{5}
 }
 func (m *AccessByIDReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -286,6 +350,13 @@ func (m *AccessByIDReq) GetChanID() string {
 	return ""
 }
 
+func (m *AccessByIDReq) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
 // If a token is not carrying any information itself, the type
 // field can be used to determine how to validate the token.
 // Also, different tokens can be encoded in different ways.
@@ -300,7 +371,7 @@ func (m *Token) Reset()         { *m = Token{} }
 func (m *Token) String() string { return proto.CompactTextString(m) }
 func (*Token) ProtoMessage()    {}
 func (*Token) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{5}
+	return fileDescriptor_8bbd6f3875b0e874, []int{6}
 }
 func (m *Token) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -336,6 +407,61 @@ func (m *Token) GetValue() string {
 	return ""
 }
 
+type Tokens struct {
+	AccessToken          string   `protobuf:"bytes,1,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	RefreshToken         string   `protobuf:"bytes,2,opt,name=refreshToken,proto3" json:"refreshToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tokens) Reset()         { *m = Tokens{} }
+func (m *Tokens) String() string { return proto.CompactTextString(m) }
+func (*Tokens) ProtoMessage()    {}
+func (*Tokens) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{7}
+}
+func (m *Tokens) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Tokens) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Tokens.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Tokens) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tokens.Merge(m, src)
+}
+func (m *Tokens) XXX_Size() int {
+	return m.Size()
+}
+func (m *Tokens) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tokens.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tokens proto.InternalMessageInfo
+
+func (m *Tokens) GetAccessToken() string {
+	if m != nil {
+		return m.AccessToken
+	}
+	return ""
+}
+
+func (m *Tokens) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
 type UserIdentity struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
@@ -348,7 +474,7 @@ func (m *UserIdentity) Reset()         { *m = UserIdentity{} }
 func (m *UserIdentity) String() string { return proto.CompactTextString(m) }
 func (*UserIdentity) ProtoMessage()    {}
 func (*UserIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{6}
+	return fileDescriptor_8bbd6f3875b0e874, []int{8}
 }
 func (m *UserIdentity) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -391,6 +517,71 @@ func (m *UserIdentity) GetEmail() string {
 	return ""
 }
 
+// AccessReq identifies the user performing the action over the object,
+// which is required to use the scoped API key.
+type AccessReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Obj                  string   `protobuf:"bytes,2,opt,name=obj,proto3" json:"obj,omitempty"`
+	Act                  string   `protobuf:"bytes,3,opt,name=act,proto3" json:"act,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccessReq) Reset()         { *m = AccessReq{} }
+func (m *AccessReq) String() string { return proto.CompactTextString(m) }
+func (*AccessReq) ProtoMessage()    {}
+func (*AccessReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{9}
+}
+func (m *AccessReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccessReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccessReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AccessReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessReq.Merge(m, src)
+}
+func (m *AccessReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccessReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessReq proto.InternalMessageInfo
+
+func (m *AccessReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *AccessReq) GetObj() string {
+	if m != nil {
+		return m.Obj
+	}
+	return ""
+}
+
+func (m *AccessReq) GetAct() string {
+	if m != nil {
+		return m.Act
+	}
+	return ""
+}
+
 type IssueReq struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
@@ -404,7 +595,7 @@ func (m *IssueReq) Reset()         { *m = IssueReq{} }
 func (m *IssueReq) String() string { return proto.CompactTextString(m) }
 func (*IssueReq) ProtoMessage()    {}
 func (*IssueReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{7}
+	return fileDescriptor_8bbd6f3875b0e874, []int{10}
 }
 func (m *IssueReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -455,7 +646,7 @@ func (m *IssueReq) GetType() uint32 {
 }
 
 type AuthorizeReq struct {
-	Sub                  string   `protobuf:"bytes,1,opt,name=sub,proto3" json:"sub,omitempty"`
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	Obj                  string   `protobuf:"bytes,2,opt,name=obj,proto3" json:"obj,omitempty"`
 	Act                  string   `protobuf:"bytes,3,opt,name=act,proto3" json:"act,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -467,7 +658,7 @@ func (m *AuthorizeReq) Reset()         { *m = AuthorizeReq{} }
 func (m *AuthorizeReq) String() string { return proto.CompactTextString(m) }
 func (*AuthorizeReq) ProtoMessage()    {}
 func (*AuthorizeReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{8}
+	return fileDescriptor_8bbd6f3875b0e874, []int{11}
 }
 func (m *AuthorizeReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -496,9 +687,9 @@ func (m *AuthorizeReq) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AuthorizeReq proto.InternalMessageInfo
 
-func (m *AuthorizeReq) GetSub() string {
+func (m *AuthorizeReq) GetToken() string {
 	if m != nil {
-		return m.Sub
+		return m.Token
 	}
 	return ""
 }
@@ -528,7 +719,7 @@ func (m *AuthorizeRes) Reset()         { *m = AuthorizeRes{} }
 func (m *AuthorizeRes) String() string { return proto.CompactTextString(m) }
 func (*AuthorizeRes) ProtoMessage()    {}
 func (*AuthorizeRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{9}
+	return fileDescriptor_8bbd6f3875b0e874, []int{12}
 }
 func (m *AuthorizeRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -564,27 +755,28 @@ func (m *AuthorizeRes) GetAuthorized() bool {
 	return false
 }
 
-type Assignment struct {
+type PolicyReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	GroupID              string   `protobuf:"bytes,2,opt,name=groupID,proto3" json:"groupID,omitempty"`
-	MemberID             string   `protobuf:"bytes,3,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	Sub                  string   `protobuf:"bytes,2,opt,name=sub,proto3" json:"sub,omitempty"`
+	Obj                  string   `protobuf:"bytes,3,opt,name=obj,proto3" json:"obj,omitempty"`
+	Act                  string   `protobuf:"bytes,4,opt,name=act,proto3" json:"act,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Assignment) Reset()         { *m = Assignment{} }
-func (m *Assignment) String() string { return proto.CompactTextString(m) }
-func (*Assignment) ProtoMessage()    {}
-func (*Assignment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{10}
+func (m *PolicyReq) Reset()         { *m = PolicyReq{} }
+func (m *PolicyReq) String() string { return proto.CompactTextString(m) }
+func (*PolicyReq) ProtoMessage()    {}
+func (*PolicyReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{13}
 }
-func (m *Assignment) XXX_Unmarshal(b []byte) error {
+func (m *PolicyReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Assignment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PolicyReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Assignment.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PolicyReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -594,62 +786,66 @@ func (m *Assignment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Assignment) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Assignment.Merge(m, src)
+func (m *PolicyReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyReq.Merge(m, src)
 }
-func (m *Assignment) XXX_Size() int {
+func (m *PolicyReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *Assignment) XXX_DiscardUnknown() {
-	xxx_messageInfo_Assignment.DiscardUnknown(m)
+func (m *PolicyReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Assignment proto.InternalMessageInfo
+var xxx_messageInfo_PolicyReq proto.InternalMessageInfo
 
-func (m *Assignment) GetToken() string {
+func (m *PolicyReq) GetToken() string {
 	if m != nil {
 		return m.Token
 	}
 	return ""
 }
 
-func (m *Assignment) GetGroupID() string {
+func (m *PolicyReq) GetSub() string {
 	if m != nil {
-		return m.GroupID
+		return m.Sub
 	}
 	return ""
 }
 
-func (m *Assignment) GetMemberID() string {
+func (m *PolicyReq) GetObj() string {
 	if m != nil {
-		return m.MemberID
+		return m.Obj
 	}
 	return ""
 }
 
-type MembersReq struct {
+func (m *PolicyReq) GetAct() string {
+	if m != nil {
+		return m.Act
+	}
+	return ""
+}
+
+type ObjectPoliciesReq struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	GroupID              string   `protobuf:"bytes,2,opt,name=groupID,proto3" json:"groupID,omitempty"`
-	Offset               uint64   `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
-	Limit                uint64   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Type                 string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Obj                  string   `protobuf:"bytes,2,opt,name=obj,proto3" json:"obj,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MembersReq) Reset()         { *m = MembersReq{} }
-func (m *MembersReq) String() string { return proto.CompactTextString(m) }
-func (*MembersReq) ProtoMessage()    {}
-func (*MembersReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{11}
+func (m *ObjectPoliciesReq) Reset()         { *m = ObjectPoliciesReq{} }
+func (m *ObjectPoliciesReq) String() string { return proto.CompactTextString(m) }
+func (*ObjectPoliciesReq) ProtoMessage()    {}
+func (*ObjectPoliciesReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{14}
 }
-func (m *MembersReq) XXX_Unmarshal(b []byte) error {
+func (m *ObjectPoliciesReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MembersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ObjectPoliciesReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MembersReq.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ObjectPoliciesReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -659,76 +855,106 @@ func (m *MembersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *MembersReq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MembersReq.Merge(m, src)
+func (m *ObjectPoliciesReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectPoliciesReq.Merge(m, src)
 }
-func (m *MembersReq) XXX_Size() int {
+func (m *ObjectPoliciesReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *MembersReq) XXX_DiscardUnknown() {
-	xxx_messageInfo_MembersReq.DiscardUnknown(m)
+func (m *ObjectPoliciesReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectPoliciesReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MembersReq proto.InternalMessageInfo
+var xxx_messageInfo_ObjectPoliciesReq proto.InternalMessageInfo
 
-func (m *MembersReq) GetToken() string {
+func (m *ObjectPoliciesReq) GetToken() string {
 	if m != nil {
 		return m.Token
 	}
 	return ""
 }
 
-func (m *MembersReq) GetGroupID() string {
+func (m *ObjectPoliciesReq) GetObj() string {
 	if m != nil {
-		return m.GroupID
+		return m.Obj
 	}
 	return ""
 }
 
-func (m *MembersReq) GetOffset() uint64 {
-	if m != nil {
-		return m.Offset
+type ObjectsReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Act                  string   `protobuf:"bytes,2,opt,name=act,proto3" json:"act,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ObjectsReq) Reset()         { *m = ObjectsReq{} }
+func (m *ObjectsReq) String() string { return proto.CompactTextString(m) }
+func (*ObjectsReq) ProtoMessage()    {}
+func (*ObjectsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{15}
+}
+func (m *ObjectsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObjectsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObjectsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *ObjectsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectsReq.Merge(m, src)
+}
+func (m *ObjectsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObjectsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectsReq.DiscardUnknown(m)
 }
 
-func (m *MembersReq) GetLimit() uint64 {
+var xxx_messageInfo_ObjectsReq proto.InternalMessageInfo
+
+func (m *ObjectsReq) GetToken() string {
 	if m != nil {
-		return m.Limit
+		return m.Token
 	}
-	return 0
+	return ""
 }
 
-func (m *MembersReq) GetType() string {
+func (m *ObjectsReq) GetAct() string {
 	if m != nil {
-		return m.Type
+		return m.Act
 	}
 	return ""
 }
 
-type MembersRes struct {
-	Total                uint64   `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Limit                uint64   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Type                 string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	Members              []string `protobuf:"bytes,5,rep,name=members,proto3" json:"members,omitempty"`
+type ObjectsRes struct {
+	Objects              []string `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MembersRes) Reset()         { *m = MembersRes{} }
-func (m *MembersRes) String() string { return proto.CompactTextString(m) }
-func (*MembersRes) ProtoMessage()    {}
-func (*MembersRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8bbd6f3875b0e874, []int{12}
+func (m *ObjectsRes) Reset()         { *m = ObjectsRes{} }
+func (m *ObjectsRes) String() string { return proto.CompactTextString(m) }
+func (*ObjectsRes) ProtoMessage()    {}
+func (*ObjectsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{16}
 }
-func (m *MembersRes) XXX_Unmarshal(b []byte) error {
+func (m *ObjectsRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MembersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ObjectsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MembersRes.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ObjectsRes.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -738,17 +964,210 @@ func (m *MembersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *MembersRes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MembersRes.Merge(m, src)
+func (m *ObjectsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObjectsRes.Merge(m, src)
 }
-func (m *MembersRes) XXX_Size() int {
+func (m *ObjectsRes) XXX_Size() int {
 	return m.Size()
 }
-func (m *MembersRes) XXX_DiscardUnknown() {
-	xxx_messageInfo_MembersRes.DiscardUnknown(m)
+func (m *ObjectsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObjectsRes.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MembersRes proto.InternalMessageInfo
+var xxx_messageInfo_ObjectsRes proto.InternalMessageInfo
+
+func (m *ObjectsRes) GetObjects() []string {
+	if m != nil {
+		return m.Objects
+	}
+	return nil
+}
+
+type Assignment struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	GroupID              string   `protobuf:"bytes,2,opt,name=groupID,proto3" json:"groupID,omitempty"`
+	MemberID             string   `protobuf:"bytes,3,opt,name=memberID,proto3" json:"memberID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Assignment) Reset()         { *m = Assignment{} }
+func (m *Assignment) String() string { return proto.CompactTextString(m) }
+func (*Assignment) ProtoMessage()    {}
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{17}
+}
+func (m *Assignment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Assignment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Assignment.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Assignment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Assignment.Merge(m, src)
+}
+func (m *Assignment) XXX_Size() int {
+	return m.Size()
+}
+func (m *Assignment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Assignment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Assignment proto.InternalMessageInfo
+
+func (m *Assignment) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Assignment) GetGroupID() string {
+	if m != nil {
+		return m.GroupID
+	}
+	return ""
+}
+
+func (m *Assignment) GetMemberID() string {
+	if m != nil {
+		return m.MemberID
+	}
+	return ""
+}
+
+type MembersReq struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	GroupID              string   `protobuf:"bytes,2,opt,name=groupID,proto3" json:"groupID,omitempty"`
+	Offset               uint64   `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit                uint64   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Type                 string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MembersReq) Reset()         { *m = MembersReq{} }
+func (m *MembersReq) String() string { return proto.CompactTextString(m) }
+func (*MembersReq) ProtoMessage()    {}
+func (*MembersReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{18}
+}
+func (m *MembersReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MembersReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MembersReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MembersReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembersReq.Merge(m, src)
+}
+func (m *MembersReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *MembersReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembersReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MembersReq proto.InternalMessageInfo
+
+func (m *MembersReq) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *MembersReq) GetGroupID() string {
+	if m != nil {
+		return m.GroupID
+	}
+	return ""
+}
+
+func (m *MembersReq) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *MembersReq) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *MembersReq) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+type MembersRes struct {
+	Total                uint64   `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Offset               uint64   `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit                uint64   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Type                 string   `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Members              []string `protobuf:"bytes,5,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MembersRes) Reset()         { *m = MembersRes{} }
+func (m *MembersRes) String() string { return proto.CompactTextString(m) }
+func (*MembersRes) ProtoMessage()    {}
+func (*MembersRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{19}
+}
+func (m *MembersRes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MembersRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MembersRes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MembersRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembersRes.Merge(m, src)
+}
+func (m *MembersRes) XXX_Size() int {
+	return m.Size()
+}
+func (m *MembersRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembersRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MembersRes proto.InternalMessageInfo
 
 func (m *MembersRes) GetTotal() uint64 {
 	if m != nil {
@@ -788,14 +1207,21 @@ func (m *MembersRes) GetMembers() []string {
 func init() {
 	proto.RegisterType((*AccessByKeyReq)(nil), "mainflux.AccessByKeyReq")
 	proto.RegisterType((*ChannelOwnerReq)(nil), "mainflux.ChannelOwnerReq")
+	proto.RegisterType((*ThingOwnerReq)(nil), "mainflux.ThingOwnerReq")
 	proto.RegisterType((*ThingID)(nil), "mainflux.ThingID")
 	proto.RegisterType((*ChannelID)(nil), "mainflux.ChannelID")
 	proto.RegisterType((*AccessByIDReq)(nil), "mainflux.AccessByIDReq")
 	proto.RegisterType((*Token)(nil), "mainflux.Token")
+	proto.RegisterType((*Tokens)(nil), "mainflux.Tokens")
 	proto.RegisterType((*UserIdentity)(nil), "mainflux.UserIdentity")
+	proto.RegisterType((*AccessReq)(nil), "mainflux.AccessReq")
 	proto.RegisterType((*IssueReq)(nil), "mainflux.IssueReq")
 	proto.RegisterType((*AuthorizeReq)(nil), "mainflux.AuthorizeReq")
 	proto.RegisterType((*AuthorizeRes)(nil), "mainflux.AuthorizeRes")
+	proto.RegisterType((*PolicyReq)(nil), "mainflux.PolicyReq")
+	proto.RegisterType((*ObjectPoliciesReq)(nil), "mainflux.ObjectPoliciesReq")
+	proto.RegisterType((*ObjectsReq)(nil), "mainflux.ObjectsReq")
+	proto.RegisterType((*ObjectsRes)(nil), "mainflux.ObjectsRes")
 	proto.RegisterType((*Assignment)(nil), "mainflux.Assignment")
 	proto.RegisterType((*MembersReq)(nil), "mainflux.MembersReq")
 	proto.RegisterType((*MembersRes)(nil), "mainflux.MembersRes")
@@ -804,47 +1230,63 @@ func init() {
 func init() { proto.RegisterFile("auth.proto", fileDescriptor_8bbd6f3875b0e874) }
 
 var fileDescriptor_8bbd6f3875b0e874 = []byte{
-	// 634 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
-	0x14, 0x75, 0xfe, 0x93, 0xfb, 0x35, 0x69, 0xbf, 0x51, 0x15, 0x8c, 0x11, 0xa1, 0xcc, 0xaa, 0x2b,
-	0x17, 0x15, 0x10, 0x6c, 0xa0, 0x4a, 0xeb, 0x2e, 0x2c, 0x84, 0x90, 0x4c, 0x91, 0xd8, 0x3a, 0xe9,
-	0x24, 0x31, 0xf8, 0x27, 0x78, 0xc6, 0x05, 0xb3, 0xe0, 0x0d, 0xd8, 0xf3, 0x04, 0x3c, 0x0b, 0x4b,
-	0x1e, 0x01, 0x95, 0x17, 0x41, 0xf3, 0xe3, 0x78, 0x5a, 0xec, 0x8a, 0xdd, 0x3d, 0x37, 0x77, 0xce,
-	0xb9, 0xf7, 0xe6, 0x1e, 0x03, 0xf8, 0x19, 0x5b, 0xd9, 0xeb, 0x34, 0x61, 0x09, 0xea, 0x47, 0x7e,
-	0x10, 0x2f, 0xc2, 0xec, 0x93, 0x75, 0x67, 0x99, 0x24, 0xcb, 0x90, 0x1c, 0x88, 0xfc, 0x2c, 0x5b,
-	0x1c, 0x90, 0x68, 0xcd, 0x72, 0x59, 0x86, 0x9f, 0xc3, 0x68, 0x3a, 0x9f, 0x13, 0x4a, 0x8f, 0xf3,
-	0x17, 0x24, 0xf7, 0xc8, 0x07, 0xb4, 0x0b, 0x1d, 0x96, 0xbc, 0x27, 0xb1, 0xd9, 0xd8, 0x6b, 0xec,
-	0x0f, 0x3c, 0x09, 0xd0, 0x18, 0xba, 0xf3, 0x95, 0x1f, 0xbb, 0x8e, 0xd9, 0x14, 0x69, 0x85, 0xf0,
-	0x11, 0x6c, 0x9f, 0xac, 0xfc, 0x38, 0x26, 0xe1, 0xab, 0x8f, 0x31, 0x49, 0x15, 0x41, 0xc2, 0xe3,
-	0x82, 0x40, 0x80, 0x5a, 0x82, 0x7b, 0xd0, 0x3b, 0x5b, 0x05, 0xf1, 0xd2, 0x75, 0xf8, 0xc3, 0x0b,
-	0x3f, 0xcc, 0x48, 0xf1, 0x50, 0x00, 0x7c, 0x1f, 0x06, 0x4a, 0xa1, 0xb6, 0x64, 0x0a, 0xc3, 0x62,
-	0x08, 0xd7, 0xe1, 0x2d, 0x98, 0xd0, 0x63, 0x92, 0x54, 0x15, 0x16, 0xb0, 0xb6, 0x8d, 0xbb, 0xd0,
-	0x39, 0x13, 0x83, 0x56, 0x2b, 0x3c, 0x82, 0xad, 0x37, 0x94, 0xa4, 0xee, 0x39, 0x89, 0x59, 0xc0,
-	0x72, 0x34, 0x82, 0x66, 0x70, 0xae, 0x4a, 0x9a, 0xc1, 0x39, 0x7f, 0x45, 0x22, 0x3f, 0x08, 0x15,
-	0xab, 0x04, 0xd8, 0x81, 0xbe, 0x4b, 0x69, 0x46, 0x78, 0x4b, 0xff, 0xf4, 0x02, 0x21, 0x68, 0xb3,
-	0x7c, 0x4d, 0xcc, 0xd6, 0x5e, 0x63, 0x7f, 0xe8, 0x89, 0x18, 0x3b, 0xb0, 0x35, 0xcd, 0xd8, 0x2a,
-	0x49, 0x83, 0xcf, 0x82, 0x69, 0x07, 0x5a, 0x34, 0x9b, 0x29, 0x2a, 0x1e, 0xf2, 0x4c, 0x32, 0x7b,
-	0xa7, 0x98, 0x78, 0xc8, 0x33, 0xfe, 0x9c, 0x09, 0x9a, 0x81, 0xc7, 0x43, 0x6c, 0x5f, 0x61, 0xa1,
-	0x68, 0x22, 0xaf, 0x45, 0x60, 0xd9, 0x57, 0xdf, 0xd3, 0x32, 0xf8, 0x2d, 0xc0, 0x94, 0xd2, 0x60,
-	0x19, 0x47, 0x24, 0x66, 0x35, 0x47, 0x61, 0x42, 0x6f, 0x99, 0x26, 0xd9, 0x7a, 0xb3, 0xcd, 0x02,
-	0x22, 0x0b, 0xfa, 0x11, 0x89, 0x66, 0x24, 0x75, 0x1d, 0xd5, 0xc4, 0x06, 0xe3, 0x2f, 0x00, 0x2f,
-	0x45, 0x4c, 0xeb, 0xcf, 0xad, 0x9e, 0x79, 0x0c, 0xdd, 0x64, 0xb1, 0xa0, 0x44, 0x0e, 0xd7, 0xf6,
-	0x14, 0xe2, 0x3c, 0x61, 0x10, 0x05, 0xcc, 0x6c, 0x8b, 0xb4, 0x04, 0x9b, 0x7d, 0x76, 0x04, 0x89,
-	0xdc, 0xa7, 0xae, 0x4f, 0xa5, 0x3e, 0xf3, 0x43, 0xa1, 0xdf, 0xf6, 0x24, 0xd0, 0x54, 0x9a, 0xd5,
-	0x2a, 0xad, 0x2a, 0x95, 0x76, 0xa9, 0xc2, 0x27, 0x90, 0x13, 0x53, 0xb3, 0xb3, 0xd7, 0xe2, 0x13,
-	0x28, 0x78, 0xf8, 0xb5, 0x09, 0x43, 0x71, 0xf2, 0xf4, 0x35, 0x49, 0x2f, 0x82, 0x39, 0x41, 0x47,
-	0x30, 0x3a, 0xf1, 0x63, 0xcd, 0x87, 0xc8, 0xb4, 0x0b, 0xfb, 0xda, 0x57, 0xed, 0x69, 0xfd, 0x5f,
-	0xfe, 0xa2, 0x7c, 0x83, 0x0d, 0x74, 0x0a, 0x23, 0x97, 0xea, 0x3e, 0x44, 0xb7, 0xcb, 0xb2, 0x6b,
-	0xfe, 0xb4, 0xc6, 0xb6, 0xfc, 0x20, 0xd8, 0xc5, 0x07, 0xc1, 0x3e, 0xe5, 0x1f, 0x04, 0x6c, 0xa0,
-	0x63, 0x18, 0x6a, 0x7d, 0xb8, 0x0e, 0xba, 0xf5, 0x77, 0x1b, 0xc2, 0x60, 0x37, 0x70, 0x3c, 0x80,
-	0xbe, 0x74, 0xc9, 0x22, 0x47, 0xdb, 0x5a, 0xaf, 0xfc, 0x6f, 0xad, 0x6c, 0xfe, 0xf0, 0x7b, 0x13,
-	0xfe, 0xe3, 0xa7, 0x59, 0x6c, 0xc3, 0x86, 0x8e, 0x70, 0x0d, 0x42, 0x65, 0x75, 0x61, 0x23, 0xeb,
-	0x3a, 0x25, 0x36, 0xd0, 0xe3, 0x9b, 0x14, 0xc7, 0x65, 0x42, 0x37, 0x30, 0x36, 0xd0, 0x33, 0x18,
-	0x6c, 0x0c, 0x81, 0xb4, 0x32, 0xdd, 0x6b, 0x56, 0x75, 0x9e, 0x62, 0x03, 0x3d, 0x85, 0xae, 0xf4,
-	0x07, 0xda, 0xd5, 0x6a, 0x36, 0x8e, 0xb9, 0x61, 0x43, 0x4f, 0xa0, 0xa7, 0xee, 0x4f, 0x7f, 0x5a,
-	0x5a, 0xc2, 0xaa, 0xca, 0x52, 0x6c, 0x1c, 0xef, 0xfc, 0xb8, 0x9c, 0x34, 0x7e, 0x5e, 0x4e, 0x1a,
-	0xbf, 0x2e, 0x27, 0x8d, 0x6f, 0xbf, 0x27, 0xc6, 0xac, 0x2b, 0xc8, 0x1f, 0xfe, 0x09, 0x00, 0x00,
-	0xff, 0xff, 0x14, 0xf9, 0x10, 0x64, 0xf9, 0x05, 0x00, 0x00,
+	// 886 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x72, 0xdc, 0x34,
+	0x14, 0xde, 0xff, 0x9f, 0x93, 0xcd, 0x4f, 0x45, 0x26, 0x2c, 0xee, 0xb0, 0x04, 0x5d, 0x30, 0x5c,
+	0x39, 0x4c, 0x5b, 0x06, 0x86, 0xd2, 0xc9, 0x6c, 0xb2, 0x99, 0xc1, 0x43, 0x4a, 0x3b, 0x26, 0xfc,
+	0x5d, 0x7a, 0xbd, 0xda, 0x5d, 0x15, 0xdb, 0x0a, 0x96, 0x5c, 0x58, 0x2e, 0xb8, 0xe1, 0x25, 0x78,
+	0x24, 0x2e, 0xfb, 0x08, 0x4c, 0x78, 0x11, 0x46, 0x92, 0xbd, 0x96, 0x37, 0x5e, 0xd3, 0xb4, 0x77,
+	0x3a, 0xc7, 0xe7, 0x7c, 0xdf, 0xd1, 0x67, 0xe9, 0x13, 0x80, 0x97, 0x88, 0xa5, 0x7d, 0x1d, 0x33,
+	0xc1, 0x50, 0x2f, 0xf4, 0x68, 0x34, 0x0f, 0x92, 0xdf, 0xac, 0xfb, 0x0b, 0xc6, 0x16, 0x01, 0x39,
+	0x51, 0xf9, 0x69, 0x32, 0x3f, 0x21, 0xe1, 0xb5, 0x58, 0xe9, 0x32, 0xfc, 0x3d, 0xec, 0x8d, 0x7d,
+	0x9f, 0x70, 0x7e, 0xb6, 0xfa, 0x9a, 0xac, 0x5c, 0xf2, 0x0b, 0x3a, 0x84, 0xb6, 0x60, 0x3f, 0x93,
+	0x68, 0x58, 0x3f, 0xae, 0x7f, 0xdc, 0x77, 0x75, 0x80, 0x8e, 0xa0, 0xe3, 0x2f, 0xbd, 0xc8, 0x99,
+	0x0c, 0x1b, 0x2a, 0x9d, 0x46, 0x32, 0xef, 0xf9, 0x82, 0xb2, 0x68, 0xd8, 0xd4, 0x79, 0x1d, 0xe1,
+	0x53, 0xd8, 0x3f, 0x5f, 0x7a, 0x51, 0x44, 0x82, 0x67, 0xbf, 0x46, 0x24, 0x4e, 0x81, 0x99, 0x5c,
+	0x67, 0xc0, 0x2a, 0xd8, 0x06, 0x8c, 0x4f, 0x61, 0xf7, 0x6a, 0x49, 0xa3, 0xc5, 0xff, 0xb4, 0x0f,
+	0xa1, 0x2b, 0x64, 0xd9, 0xba, 0x3f, 0x0b, 0xf1, 0x07, 0xd0, 0xbd, 0xd2, 0x4b, 0xd9, 0xfa, 0xd2,
+	0x0b, 0x12, 0x92, 0xb5, 0xaa, 0x00, 0x7f, 0x08, 0xfd, 0x74, 0xc4, 0xad, 0x25, 0x3f, 0xc1, 0x6e,
+	0xa6, 0x8e, 0x33, 0x91, 0x43, 0x18, 0x74, 0xf5, 0x02, 0xdd, 0x9d, 0x05, 0x7a, 0x1f, 0xda, 0x57,
+	0x4a, 0xd9, 0x72, 0xe6, 0x6f, 0xa0, 0xa3, 0x3e, 0x73, 0x74, 0x0c, 0x3b, 0x9e, 0x9a, 0xe1, 0xca,
+	0xf8, 0x2b, 0x66, 0x0a, 0x61, 0x18, 0xc4, 0x64, 0x1e, 0x13, 0xbe, 0xd4, 0x25, 0x7a, 0x80, 0x42,
+	0x0e, 0x3f, 0x82, 0xc1, 0x77, 0x9c, 0xc4, 0xce, 0x8c, 0x44, 0x82, 0x8a, 0x15, 0xda, 0x83, 0x06,
+	0x9d, 0xa5, 0x60, 0x0d, 0x3a, 0x93, 0x53, 0x90, 0xd0, 0xa3, 0x41, 0xda, 0xac, 0x03, 0x7c, 0x01,
+	0x7d, 0xbd, 0xff, 0xed, 0x07, 0xe3, 0x00, 0x9a, 0x6c, 0xfa, 0x22, 0x6d, 0x93, 0x4b, 0x99, 0xf1,
+	0x7c, 0x91, 0x6e, 0x57, 0x2e, 0xf1, 0x04, 0x7a, 0x0e, 0xe7, 0x09, 0x91, 0x28, 0xaf, 0x45, 0x8c,
+	0x10, 0xb4, 0xc4, 0xea, 0x9a, 0x28, 0x90, 0x5d, 0x57, 0xad, 0xf1, 0x57, 0x30, 0x18, 0x27, 0x62,
+	0xc9, 0x62, 0xfa, 0x3b, 0x79, 0xbb, 0x79, 0xec, 0x02, 0x12, 0x47, 0x23, 0x7d, 0x73, 0x54, 0xac,
+	0x67, 0xeb, 0xb9, 0x46, 0x06, 0xff, 0x00, 0xfd, 0xe7, 0x2c, 0xa0, 0xfe, 0xaa, 0x92, 0x96, 0x27,
+	0xd3, 0x8c, 0x96, 0x27, 0xd3, 0x6c, 0x90, 0xe6, 0xad, 0x41, 0x5a, 0xf9, 0x20, 0x8f, 0xe1, 0xde,
+	0xb3, 0xe9, 0x0b, 0xe2, 0x0b, 0x05, 0x4f, 0xc9, 0x5d, 0x74, 0xc6, 0x8f, 0x00, 0x74, 0x73, 0x75,
+	0x97, 0xa4, 0x6c, 0xe4, 0x94, 0x1f, 0x19, 0x5d, 0x5c, 0x9e, 0x67, 0xa6, 0xa3, 0x61, 0xfd, 0xb8,
+	0x29, 0xcf, 0x73, 0x1a, 0xe2, 0x1f, 0x01, 0xc6, 0x9c, 0xd3, 0x45, 0x14, 0x92, 0x48, 0x6c, 0x41,
+	0x1f, 0x42, 0x77, 0x11, 0xb3, 0xe4, 0x3a, 0xbf, 0x7c, 0x69, 0x88, 0x2c, 0xe8, 0x85, 0x24, 0x9c,
+	0x92, 0xd8, 0x99, 0xa4, 0x0a, 0xac, 0x63, 0xfc, 0x07, 0xc0, 0x53, 0xb5, 0xae, 0x98, 0x7b, 0x3b,
+	0xf2, 0x11, 0x74, 0xd8, 0x7c, 0xce, 0x89, 0xfe, 0xa1, 0x2d, 0x37, 0x8d, 0x24, 0x4e, 0x40, 0x43,
+	0xaa, 0xe5, 0x6d, 0xb9, 0x3a, 0x58, 0x9f, 0xa3, 0xb6, 0x02, 0x51, 0xeb, 0x02, 0x3f, 0xd7, 0xfc,
+	0xc2, 0x0b, 0x14, 0x7f, 0xcb, 0xd5, 0x81, 0xc1, 0xd2, 0x28, 0x67, 0x69, 0x96, 0xb1, 0xb4, 0x72,
+	0x16, 0xb9, 0x03, 0xbd, 0x63, 0x3e, 0x6c, 0x6b, 0x65, 0xd3, 0xf0, 0xc1, 0xab, 0x46, 0x6a, 0x6d,
+	0xfc, 0x5b, 0x12, 0xbf, 0xa4, 0x3e, 0x41, 0xa7, 0xb0, 0x77, 0xee, 0x45, 0x86, 0x0f, 0xa3, 0xa1,
+	0x9d, 0xd9, 0xb7, 0x5d, 0xb4, 0x67, 0xeb, 0x5e, 0xfe, 0x25, 0xb5, 0x37, 0x5c, 0x43, 0x17, 0xb0,
+	0xe7, 0x70, 0xd3, 0x6f, 0xd1, 0x7b, 0x79, 0xd9, 0x86, 0x0f, 0x5b, 0x47, 0xb6, 0x7e, 0x10, 0xec,
+	0xec, 0x41, 0xb0, 0x2f, 0xe4, 0x83, 0x80, 0x6b, 0x68, 0x0c, 0x03, 0x87, 0xe7, 0xae, 0x8b, 0xde,
+	0xdd, 0xe0, 0x7a, 0x0d, 0x88, 0x33, 0xd8, 0x35, 0xb6, 0xe2, 0x4c, 0x4c, 0x8c, 0x82, 0x95, 0x56,
+	0x60, 0x7c, 0x02, 0x3d, 0xed, 0x53, 0xf3, 0x15, 0xda, 0x37, 0x46, 0x90, 0x27, 0xa3, 0x74, 0xff,
+	0x0f, 0xfe, 0xec, 0xc2, 0x8e, 0xbc, 0xd1, 0x99, 0xa0, 0x36, 0xb4, 0x95, 0xe1, 0x20, 0x94, 0x57,
+	0x67, 0x0e, 0x64, 0x6d, 0x42, 0xe2, 0x1a, 0x3a, 0x81, 0xf6, 0x25, 0x5b, 0xd0, 0xa8, 0xb4, 0xfe,
+	0x60, 0xa3, 0x9e, 0xe3, 0x1a, 0xb2, 0xa1, 0xeb, 0x6a, 0x7b, 0xbd, 0x3d, 0x61, 0x59, 0xfd, 0x43,
+	0xe8, 0x5c, 0xb2, 0x05, 0x4b, 0xc4, 0xed, 0xf2, 0xed, 0x3a, 0x7c, 0x5a, 0xa5, 0xc3, 0x51, 0x9e,
+	0x30, 0x8d, 0x1d, 0xd7, 0xd0, 0x17, 0xd0, 0x5f, 0xff, 0x02, 0xf4, 0xce, 0xa6, 0xfc, 0x5a, 0xfa,
+	0x6d, 0xbd, 0x5f, 0xc2, 0x7e, 0x46, 0xf9, 0x3c, 0x26, 0x52, 0xd2, 0xbb, 0x30, 0x3f, 0x81, 0xfe,
+	0xda, 0x57, 0x91, 0x51, 0x66, 0xda, 0xb6, 0x55, 0x9e, 0x97, 0x22, 0x7d, 0x0e, 0x1d, 0x6d, 0x39,
+	0xe8, 0xd0, 0xa8, 0x59, 0x9b, 0x50, 0x85, 0x52, 0x9f, 0x41, 0x37, 0xbd, 0xd2, 0x66, 0x6b, 0xee,
+	0x32, 0x56, 0x59, 0x96, 0x6b, 0xad, 0xc6, 0xb3, 0x99, 0x36, 0x77, 0x53, 0xab, 0xb5, 0xdd, 0x57,
+	0x90, 0x3e, 0x81, 0xc1, 0x84, 0x04, 0x44, 0x90, 0x37, 0x6b, 0x7f, 0x0a, 0x87, 0xba, 0xbd, 0xf8,
+	0x02, 0xa0, 0xfb, 0x39, 0xcc, 0xad, 0xb7, 0xa1, 0x02, 0x6e, 0x02, 0x3b, 0xe7, 0x81, 0x47, 0x43,
+	0xdd, 0xf3, 0xa6, 0x28, 0x8f, 0x61, 0xe7, 0x92, 0x72, 0xa1, 0x5b, 0x0a, 0x62, 0xe6, 0x4f, 0x8d,
+	0x55, 0x96, 0xe5, 0xb8, 0x76, 0x76, 0xf0, 0xf7, 0xcd, 0xa8, 0xfe, 0xea, 0x66, 0x54, 0xff, 0xe7,
+	0x66, 0x54, 0xff, 0xeb, 0xdf, 0x51, 0x6d, 0xda, 0x51, 0x04, 0x0f, 0xff, 0x1b, 0x00, 0x6b, 0xa0,
+	0xa4, 0x10, 0x99, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -861,6 +1303,7 @@ const _ = grpc.SupportPackageIsVersion4
 type ThingsServiceClient interface {
 	CanAccessByKey(ctx context.Context, in *AccessByKeyReq, opts ...grpc.CallOption) (*ThingID, error)
 	IsChannelOwner(ctx context.Context, in *ChannelOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	IsThingOwner(ctx context.Context, in *ThingOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error)
 	CanAccessByID(ctx context.Context, in *AccessByIDReq, opts ...grpc.CallOption) (*empty.Empty, error)
 	Identify(ctx context.Context, in *Token, opts ...grpc.CallOption) (*ThingID, error)
 }
@@ -891,6 +1334,15 @@ func (c *thingsServiceClient) IsChannelOwner(ctx context.Context, in *ChannelOwn
 	return out, nil
 }
 
+func (c *thingsServiceClient) IsThingOwner(ctx context.Context, in *ThingOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/mainflux.ThingsService/IsThingOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *thingsServiceClient) CanAccessByID(ctx context.Context, in *AccessByIDReq, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/mainflux.ThingsService/CanAccessByID", in, out, opts...)
@@ -913,6 +1365,7 @@ func (c *thingsServiceClient) Identify(ctx context.Context, in *Token, opts ...g
 type ThingsServiceServer interface {
 	CanAccessByKey(context.Context, *AccessByKeyReq) (*ThingID, error)
 	IsChannelOwner(context.Context, *ChannelOwnerReq) (*empty.Empty, error)
+	IsThingOwner(context.Context, *ThingOwnerReq) (*empty.Empty, error)
 	CanAccessByID(context.Context, *AccessByIDReq) (*empty.Empty, error)
 	Identify(context.Context, *Token) (*ThingID, error)
 }
@@ -927,6 +1380,9 @@ func (*UnimplementedThingsServiceServer) CanAccessByKey(ctx context.Context, req
 func (*UnimplementedThingsServiceServer) IsChannelOwner(ctx context.Context, req *ChannelOwnerReq) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsChannelOwner not implemented")
 }
+func (*UnimplementedThingsServiceServer) IsThingOwner(ctx context.Context, req *ThingOwnerReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsThingOwner not implemented")
+}
 func (*UnimplementedThingsServiceServer) CanAccessByID(ctx context.Context, req *AccessByIDReq) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CanAccessByID not implemented")
 }
@@ -974,6 +1430,24 @@ func _ThingsService_IsChannelOwner_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ThingsService_IsThingOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThingOwnerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServiceServer).IsThingOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.ThingsService/IsThingOwner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServiceServer).IsThingOwner(ctx, req.(*ThingOwnerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ThingsService_CanAccessByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AccessByIDReq)
 	if err := dec(in); err != nil {
@@ -1022,6 +1496,10 @@ var _ThingsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IsChannelOwner",
 			Handler:    _ThingsService_IsChannelOwner_Handler,
 		},
+		{
+			MethodName: "IsThingOwner",
+			Handler:    _ThingsService_IsThingOwner_Handler,
+		},
 		{
 			MethodName: "CanAccessByID",
 			Handler:    _ThingsService_CanAccessByID_Handler,
@@ -1040,10 +1518,20 @@ var _ThingsService_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type AuthServiceClient interface {
 	Issue(ctx context.Context, in *IssueReq, opts ...grpc.CallOption) (*Token, error)
+	Login(ctx context.Context, in *IssueReq, opts ...grpc.CallOption) (*Tokens, error)
+	Refresh(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Tokens, error)
+	Logout(ctx context.Context, in *Token, opts ...grpc.CallOption) (*empty.Empty, error)
 	Identify(ctx context.Context, in *Token, opts ...grpc.CallOption) (*UserIdentity, error)
+	CanAccess(ctx context.Context, in *AccessReq, opts ...grpc.CallOption) (*UserIdentity, error)
+	IdentifyPreAuth(ctx context.Context, in *Token, opts ...grpc.CallOption) (*UserIdentity, error)
 	Authorize(ctx context.Context, in *AuthorizeReq, opts ...grpc.CallOption) (*AuthorizeRes, error)
 	Assign(ctx context.Context, in *Assignment, opts ...grpc.CallOption) (*empty.Empty, error)
 	Members(ctx context.Context, in *MembersReq, opts ...grpc.CallOption) (*MembersRes, error)
+	AddPolicy(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeletePolicy(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteObjectPolicies(ctx context.Context, in *ObjectPoliciesReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	ClaimObject(ctx context.Context, in *ObjectPoliciesReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListObjects(ctx context.Context, in *ObjectsReq, opts ...grpc.CallOption) (*ObjectsRes, error)
 }
 
 type authServiceClient struct {
@@ -1063,6 +1551,33 @@ func (c *authServiceClient) Issue(ctx context.Context, in *IssueReq, opts ...grp
 	return out, nil
 }
 
+func (c *authServiceClient) Login(ctx context.Context, in *IssueReq, opts ...grpc.CallOption) (*Tokens, error) {
+	out := new(Tokens)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Refresh(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Tokens, error) {
+	out := new(Tokens)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/Refresh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Logout(ctx context.Context, in *Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) Identify(ctx context.Context, in *Token, opts ...grpc.CallOption) (*UserIdentity, error) {
 	out := new(UserIdentity)
 	err := c.cc.Invoke(ctx, "/mainflux.AuthService/Identify", in, out, opts...)
@@ -1072,6 +1587,24 @@ func (c *authServiceClient) Identify(ctx context.Context, in *Token, opts ...grp
 	return out, nil
 }
 
+func (c *authServiceClient) CanAccess(ctx context.Context, in *AccessReq, opts ...grpc.CallOption) (*UserIdentity, error) {
+	out := new(UserIdentity)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/CanAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) IdentifyPreAuth(ctx context.Context, in *Token, opts ...grpc.CallOption) (*UserIdentity, error) {
+	out := new(UserIdentity)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/IdentifyPreAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) Authorize(ctx context.Context, in *AuthorizeReq, opts ...grpc.CallOption) (*AuthorizeRes, error) {
 	out := new(AuthorizeRes)
 	err := c.cc.Invoke(ctx, "/mainflux.AuthService/Authorize", in, out, opts...)
@@ -1099,13 +1632,68 @@ func (c *authServiceClient) Members(ctx context.Context, in *MembersReq, opts ..
 	return out, nil
 }
 
-// AuthServiceServer is the server API for AuthService service.
-type AuthServiceServer interface {
-	Issue(context.Context, *IssueReq) (*Token, error)
-	Identify(context.Context, *Token) (*UserIdentity, error)
-	Authorize(context.Context, *AuthorizeReq) (*AuthorizeRes, error)
-	Assign(context.Context, *Assignment) (*empty.Empty, error)
-	Members(context.Context, *MembersReq) (*MembersRes, error)
+func (c *authServiceClient) AddPolicy(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/AddPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeletePolicy(ctx context.Context, in *PolicyReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/DeletePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeleteObjectPolicies(ctx context.Context, in *ObjectPoliciesReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/DeleteObjectPolicies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ClaimObject(ctx context.Context, in *ObjectPoliciesReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/ClaimObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListObjects(ctx context.Context, in *ObjectsReq, opts ...grpc.CallOption) (*ObjectsRes, error) {
+	out := new(ObjectsRes)
+	err := c.cc.Invoke(ctx, "/mainflux.AuthService/ListObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+type AuthServiceServer interface {
+	Issue(context.Context, *IssueReq) (*Token, error)
+	Login(context.Context, *IssueReq) (*Tokens, error)
+	Refresh(context.Context, *Token) (*Tokens, error)
+	Logout(context.Context, *Token) (*empty.Empty, error)
+	Identify(context.Context, *Token) (*UserIdentity, error)
+	CanAccess(context.Context, *AccessReq) (*UserIdentity, error)
+	IdentifyPreAuth(context.Context, *Token) (*UserIdentity, error)
+	Authorize(context.Context, *AuthorizeReq) (*AuthorizeRes, error)
+	Assign(context.Context, *Assignment) (*empty.Empty, error)
+	Members(context.Context, *MembersReq) (*MembersRes, error)
+	AddPolicy(context.Context, *PolicyReq) (*empty.Empty, error)
+	DeletePolicy(context.Context, *PolicyReq) (*empty.Empty, error)
+	DeleteObjectPolicies(context.Context, *ObjectPoliciesReq) (*empty.Empty, error)
+	ClaimObject(context.Context, *ObjectPoliciesReq) (*empty.Empty, error)
+	ListObjects(context.Context, *ObjectsReq) (*ObjectsRes, error)
 }
 
 // UnimplementedAuthServiceServer can be embedded to have forward compatible implementations.
@@ -1115,9 +1703,24 @@ type UnimplementedAuthServiceServer struct {
 func (*UnimplementedAuthServiceServer) Issue(ctx context.Context, req *IssueReq) (*Token, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Issue not implemented")
 }
+func (*UnimplementedAuthServiceServer) Login(ctx context.Context, req *IssueReq) (*Tokens, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedAuthServiceServer) Refresh(ctx context.Context, req *Token) (*Tokens, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
+}
+func (*UnimplementedAuthServiceServer) Logout(ctx context.Context, req *Token) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
 func (*UnimplementedAuthServiceServer) Identify(ctx context.Context, req *Token) (*UserIdentity, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Identify not implemented")
 }
+func (*UnimplementedAuthServiceServer) CanAccess(ctx context.Context, req *AccessReq) (*UserIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanAccess not implemented")
+}
+func (*UnimplementedAuthServiceServer) IdentifyPreAuth(ctx context.Context, req *Token) (*UserIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentifyPreAuth not implemented")
+}
 func (*UnimplementedAuthServiceServer) Authorize(ctx context.Context, req *AuthorizeReq) (*AuthorizeRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
 }
@@ -1127,6 +1730,21 @@ func (*UnimplementedAuthServiceServer) Assign(ctx context.Context, req *Assignme
 func (*UnimplementedAuthServiceServer) Members(ctx context.Context, req *MembersReq) (*MembersRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Members not implemented")
 }
+func (*UnimplementedAuthServiceServer) AddPolicy(ctx context.Context, req *PolicyReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPolicy not implemented")
+}
+func (*UnimplementedAuthServiceServer) DeletePolicy(ctx context.Context, req *PolicyReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePolicy not implemented")
+}
+func (*UnimplementedAuthServiceServer) DeleteObjectPolicies(ctx context.Context, req *ObjectPoliciesReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObjectPolicies not implemented")
+}
+func (*UnimplementedAuthServiceServer) ClaimObject(ctx context.Context, req *ObjectPoliciesReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimObject not implemented")
+}
+func (*UnimplementedAuthServiceServer) ListObjects(ctx context.Context, req *ObjectsReq) (*ObjectsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
 
 func RegisterAuthServiceServer(s *grpc.Server, srv AuthServiceServer) {
 	s.RegisterService(&_AuthService_serviceDesc, srv)
@@ -1150,6 +1768,60 @@ func _AuthService_Issue_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*IssueReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Refresh(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Logout(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_Identify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Token)
 	if err := dec(in); err != nil {
@@ -1168,6 +1840,42 @@ func _AuthService_Identify_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_CanAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccessReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CanAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/CanAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CanAccess(ctx, req.(*AccessReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_IdentifyPreAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).IdentifyPreAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/IdentifyPreAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).IdentifyPreAuth(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AuthorizeReq)
 	if err := dec(in); err != nil {
@@ -1222,6 +1930,96 @@ func _AuthService_Members_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/AddPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AddPolicy(ctx, req.(*PolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeletePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeletePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/DeletePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeletePolicy(ctx, req.(*PolicyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeleteObjectPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectPoliciesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeleteObjectPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/DeleteObjectPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeleteObjectPolicies(ctx, req.(*ObjectPoliciesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ClaimObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectPoliciesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ClaimObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/ClaimObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ClaimObject(ctx, req.(*ObjectPoliciesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mainflux.AuthService/ListObjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListObjects(ctx, req.(*ObjectsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AuthService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mainflux.AuthService",
 	HandlerType: (*AuthServiceServer)(nil),
@@ -1230,10 +2028,30 @@ var _AuthService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Issue",
 			Handler:    _AuthService_Issue_Handler,
 		},
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _AuthService_Refresh_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AuthService_Logout_Handler,
+		},
 		{
 			MethodName: "Identify",
 			Handler:    _AuthService_Identify_Handler,
 		},
+		{
+			MethodName: "CanAccess",
+			Handler:    _AuthService_CanAccess_Handler,
+		},
+		{
+			MethodName: "IdentifyPreAuth",
+			Handler:    _AuthService_IdentifyPreAuth_Handler,
+		},
 		{
 			MethodName: "Authorize",
 			Handler:    _AuthService_Authorize_Handler,
@@ -1246,6 +2064,26 @@ var _AuthService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Members",
 			Handler:    _AuthService_Members_Handler,
 		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _AuthService_AddPolicy_Handler,
+		},
+		{
+			MethodName: "DeletePolicy",
+			Handler:    _AuthService_DeletePolicy_Handler,
+		},
+		{
+			MethodName: "DeleteObjectPolicies",
+			Handler:    _AuthService_DeleteObjectPolicies_Handler,
+		},
+		{
+			MethodName: "ClaimObject",
+			Handler:    _AuthService_ClaimObject_Handler,
+		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _AuthService_ListObjects_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
@@ -1275,6 +2113,13 @@ func (m *AccessByKeyReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.ChanID) > 0 {
 		i -= len(m.ChanID)
 		copy(dAtA[i:], m.ChanID)
@@ -1333,6 +2178,47 @@ func (m *ChannelOwnerReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ThingOwnerReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ThingOwnerReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ThingOwnerReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ThingID) > 0 {
+		i -= len(m.ThingID)
+		copy(dAtA[i:], m.ThingID)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.ThingID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ThingID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1425,6 +2311,13 @@ func (m *AccessByIDReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.ChanID) > 0 {
 		i -= len(m.ChanID)
 		copy(dAtA[i:], m.ChanID)
@@ -1476,7 +2369,7 @@ func (m *Token) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *UserIdentity) Marshal() (dAtA []byte, err error) {
+func (m *Tokens) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1486,12 +2379,12 @@ func (m *UserIdentity) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UserIdentity) MarshalTo(dAtA []byte) (int, error) {
+func (m *Tokens) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *UserIdentity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Tokens) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1500,24 +2393,113 @@ func (m *UserIdentity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Email) > 0 {
-		i -= len(m.Email)
-		copy(dAtA[i:], m.Email)
-		i = encodeVarintAuth(dAtA, i, uint64(len(m.Email)))
+	if len(m.RefreshToken) > 0 {
+		i -= len(m.RefreshToken)
+		copy(dAtA[i:], m.RefreshToken)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.RefreshToken)))
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Id) > 0 {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintAuth(dAtA, i, uint64(len(m.Id)))
+	if len(m.AccessToken) > 0 {
+		i -= len(m.AccessToken)
+		copy(dAtA[i:], m.AccessToken)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.AccessToken)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *IssueReq) Marshal() (dAtA []byte, err error) {
+func (m *UserIdentity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserIdentity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserIdentity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Email) > 0 {
+		i -= len(m.Email)
+		copy(dAtA[i:], m.Email)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Email)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccessReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Act) > 0 {
+		i -= len(m.Act)
+		copy(dAtA[i:], m.Act)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Act)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Obj) > 0 {
+		i -= len(m.Obj)
+		copy(dAtA[i:], m.Obj)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Obj)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IssueReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1601,10 +2583,10 @@ func (m *AuthorizeReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if len(m.Sub) > 0 {
-		i -= len(m.Sub)
-		copy(dAtA[i:], m.Sub)
-		i = encodeVarintAuth(dAtA, i, uint64(len(m.Sub)))
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Token)))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -1648,6 +2630,179 @@ func (m *AuthorizeRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PolicyReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PolicyReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PolicyReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Act) > 0 {
+		i -= len(m.Act)
+		copy(dAtA[i:], m.Act)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Act)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Obj) > 0 {
+		i -= len(m.Obj)
+		copy(dAtA[i:], m.Obj)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Obj)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Sub) > 0 {
+		i -= len(m.Sub)
+		copy(dAtA[i:], m.Sub)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Sub)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObjectPoliciesReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectPoliciesReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObjectPoliciesReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Obj) > 0 {
+		i -= len(m.Obj)
+		copy(dAtA[i:], m.Obj)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Obj)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObjectsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectsReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObjectsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Act) > 0 {
+		i -= len(m.Act)
+		copy(dAtA[i:], m.Act)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Act)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Token) > 0 {
+		i -= len(m.Token)
+		copy(dAtA[i:], m.Token)
+		i = encodeVarintAuth(dAtA, i, uint64(len(m.Token)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObjectsRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObjectsRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObjectsRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Objects) > 0 {
+		for iNdEx := len(m.Objects) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Objects[iNdEx])
+			copy(dAtA[i:], m.Objects[iNdEx])
+			i = encodeVarintAuth(dAtA, i, uint64(len(m.Objects[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Assignment) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1837,6 +2992,10 @@ func (m *AccessByKeyReq) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAuth(uint64(l))
 	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1863,13 +3022,33 @@ func (m *ChannelOwnerReq) Size() (n int) {
 	return n
 }
 
-func (m *ThingID) Size() (n int) {
+func (m *ThingOwnerReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Value)
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.ThingID)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ThingID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
 	if l > 0 {
 		n += 1 + l + sovAuth(uint64(l))
 	}
@@ -1909,6 +3088,10 @@ func (m *AccessByIDReq) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovAuth(uint64(l))
 	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1931,6 +3114,26 @@ func (m *Token) Size() (n int) {
 	return n
 }
 
+func (m *Tokens) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AccessToken)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.RefreshToken)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *UserIdentity) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1951,6 +3154,30 @@ func (m *UserIdentity) Size() (n int) {
 	return n
 }
 
+func (m *AccessReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Obj)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Act)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *IssueReq) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1980,7 +3207,7 @@ func (m *AuthorizeReq) Size() (n int) {
 	}
 	var l int
 	_ = l
-	l = len(m.Sub)
+	l = len(m.Token)
 	if l > 0 {
 		n += 1 + l + sovAuth(uint64(l))
 	}
@@ -2013,6 +3240,92 @@ func (m *AuthorizeRes) Size() (n int) {
 	return n
 }
 
+func (m *PolicyReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Sub)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Obj)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Act)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectPoliciesReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Obj)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectsReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Token)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	l = len(m.Act)
+	if l > 0 {
+		n += 1 + l + sovAuth(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ObjectsRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Objects) > 0 {
+		for _, s := range m.Objects {
+			l = len(s)
+			n += 1 + l + sovAuth(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *Assignment) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2095,16 +3408,810 @@ func (m *MembersRes) Size() (n int) {
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
-	return n
+	return n
+}
+
+func sovAuth(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAuth(x uint64) (n int) {
+	return sovAuth(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessByKeyReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessByKeyReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChanID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChanID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelOwnerReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelOwnerReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelOwnerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChanID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChanID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThingOwnerReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThingOwnerReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThingOwnerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThingID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThingID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ThingID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ThingID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ThingID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessByIDReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessByIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ThingID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ThingID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChanID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChanID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
+func (m *Token) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuth
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Token: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Token: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuth(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func sovAuth(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozAuth(x uint64) (n int) {
-	return sovAuth(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
+func (m *Tokens) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2127,15 +4234,15 @@ func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessByKeyReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: Tokens: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessByKeyReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Tokens: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccessToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2163,11 +4270,11 @@ func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Token = string(dAtA[iNdEx:postIndex])
+			m.AccessToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChanID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RefreshToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2195,7 +4302,7 @@ func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChanID = string(dAtA[iNdEx:postIndex])
+			m.RefreshToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2222,7 +4329,7 @@ func (m *AccessByKeyReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChannelOwnerReq) Unmarshal(dAtA []byte) error {
+func (m *UserIdentity) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2245,15 +4352,15 @@ func (m *ChannelOwnerReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChannelOwnerReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: UserIdentity: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChannelOwnerReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UserIdentity: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2281,11 +4388,11 @@ func (m *ChannelOwnerReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owner = string(dAtA[iNdEx:postIndex])
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChanID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2313,7 +4420,7 @@ func (m *ChannelOwnerReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChanID = string(dAtA[iNdEx:postIndex])
+			m.Email = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2340,7 +4447,7 @@ func (m *ChannelOwnerReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ThingID) Unmarshal(dAtA []byte) error {
+func (m *AccessReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2363,15 +4470,15 @@ func (m *ThingID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ThingID: wiretype end group for non-group")
+			return fmt.Errorf("proto: AccessReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ThingID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AccessReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2399,7 +4506,71 @@ func (m *ThingID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Obj", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Obj = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Act", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Act = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2426,7 +4597,7 @@ func (m *ThingID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ChannelID) Unmarshal(dAtA []byte) error {
+func (m *IssueReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2449,15 +4620,15 @@ func (m *ChannelID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChannelID: wiretype end group for non-group")
+			return fmt.Errorf("proto: IssueReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChannelID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IssueReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2469,24 +4640,75 @@ func (m *ChannelID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Email = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAuth
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAuth
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -2512,7 +4734,7 @@ func (m *ChannelID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
+func (m *AuthorizeReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2535,15 +4757,15 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessByIDReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuthorizeReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessByIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuthorizeReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ThingID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2571,11 +4793,11 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ThingID = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChanID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Obj", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2603,7 +4825,39 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChanID = string(dAtA[iNdEx:postIndex])
+			m.Obj = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Act", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Act = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2630,7 +4884,7 @@ func (m *AccessByIDReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Token) Unmarshal(dAtA []byte) error {
+func (m *AuthorizeRes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2653,17 +4907,17 @@ func (m *Token) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Token: wiretype end group for non-group")
+			return fmt.Errorf("proto: AuthorizeRes: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Token: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AuthorizeRes: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Authorized", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAuth
@@ -2673,24 +4927,12 @@ func (m *Token) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAuth
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAuth
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Authorized = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -2716,7 +4958,7 @@ func (m *Token) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UserIdentity) Unmarshal(dAtA []byte) error {
+func (m *PolicyReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2739,15 +4981,15 @@ func (m *UserIdentity) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UserIdentity: wiretype end group for non-group")
+			return fmt.Errorf("proto: PolicyReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UserIdentity: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PolicyReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2775,11 +5017,11 @@ func (m *UserIdentity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sub", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2807,7 +5049,71 @@ func (m *UserIdentity) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.Sub = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Obj", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Obj = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Act", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuth
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Act = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2834,7 +5140,7 @@ func (m *UserIdentity) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IssueReq) Unmarshal(dAtA []byte) error {
+func (m *ObjectPoliciesReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2857,15 +5163,15 @@ func (m *IssueReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IssueReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ObjectPoliciesReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IssueReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ObjectPoliciesReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2893,11 +5199,11 @@ func (m *IssueReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Email", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Obj", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2925,27 +5231,8 @@ func (m *IssueReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Email = string(dAtA[iNdEx:postIndex])
+			m.Obj = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAuth
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
@@ -2971,7 +5258,7 @@ func (m *IssueReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AuthorizeReq) Unmarshal(dAtA []byte) error {
+func (m *ObjectsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2994,15 +5281,15 @@ func (m *AuthorizeReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AuthorizeReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ObjectsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AuthorizeReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ObjectsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3030,41 +5317,9 @@ func (m *AuthorizeReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Sub = string(dAtA[iNdEx:postIndex])
+			m.Token = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Obj", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowAuth
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthAuth
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthAuth
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Obj = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Act", wireType)
 			}
@@ -3121,7 +5376,7 @@ func (m *AuthorizeReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AuthorizeRes) Unmarshal(dAtA []byte) error {
+func (m *ObjectsRes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3144,17 +5399,17 @@ func (m *AuthorizeRes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AuthorizeRes: wiretype end group for non-group")
+			return fmt.Errorf("proto: ObjectsRes: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AuthorizeRes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ObjectsRes: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Authorized", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Objects", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowAuth
@@ -3164,12 +5419,24 @@ func (m *AuthorizeRes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Authorized = bool(v != 0)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuth
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuth
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Objects = append(m.Objects, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAuth(dAtA[iNdEx:])
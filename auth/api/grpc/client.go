@@ -23,12 +23,22 @@ const (
 var _ mainflux.AuthServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	issue     endpoint.Endpoint
-	identify  endpoint.Endpoint
-	authorize endpoint.Endpoint
-	assign    endpoint.Endpoint
-	members   endpoint.Endpoint
-	timeout   time.Duration
+	issue        endpoint.Endpoint
+	login        endpoint.Endpoint
+	refresh      endpoint.Endpoint
+	logout       endpoint.Endpoint
+	identify     endpoint.Endpoint
+	canAccess    endpoint.Endpoint
+	preAuth      endpoint.Endpoint
+	authorize    endpoint.Endpoint
+	assign       endpoint.Endpoint
+	members      endpoint.Endpoint
+	addPolicy    endpoint.Endpoint
+	deletePolicy endpoint.Endpoint
+	deleteObject endpoint.Endpoint
+	claimObject  endpoint.Endpoint
+	listObjects  endpoint.Endpoint
+	timeout      time.Duration
 }
 
 // NewClient returns new gRPC client instance.
@@ -42,6 +52,30 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeIssueResponse,
 			mainflux.UserIdentity{},
 		).Endpoint()),
+		login: kitot.TraceClient(tracer, "login")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Login",
+			encodeLoginRequest,
+			decodeTokensResponse,
+			mainflux.Tokens{},
+		).Endpoint()),
+		refresh: kitot.TraceClient(tracer, "refresh")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Refresh",
+			encodeTokenRequest,
+			decodeTokensResponse,
+			mainflux.Tokens{},
+		).Endpoint()),
+		logout: kitot.TraceClient(tracer, "logout")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"Logout",
+			encodeTokenRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
 		identify: kitot.TraceClient(tracer, "identify")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -50,6 +84,22 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeIdentifyResponse,
 			mainflux.UserIdentity{},
 		).Endpoint()),
+		canAccess: kitot.TraceClient(tracer, "can_access")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"CanAccess",
+			encodeAccessRequest,
+			decodeIdentifyResponse,
+			mainflux.UserIdentity{},
+		).Endpoint()),
+		preAuth: kitot.TraceClient(tracer, "identify_pre_auth")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"IdentifyPreAuth",
+			encodeIdentifyRequest,
+			decodeIdentifyResponse,
+			mainflux.UserIdentity{},
+		).Endpoint()),
 		authorize: kitot.TraceClient(tracer, "authorize")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -74,6 +124,46 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeMembersResponse,
 			mainflux.MembersRes{},
 		).Endpoint()),
+		addPolicy: kitot.TraceClient(tracer, "add_policy")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"AddPolicy",
+			encodePolicyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		deletePolicy: kitot.TraceClient(tracer, "delete_policy")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DeletePolicy",
+			encodePolicyRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		deleteObject: kitot.TraceClient(tracer, "delete_object_policies")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"DeleteObjectPolicies",
+			encodeObjectPoliciesRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		claimObject: kitot.TraceClient(tracer, "claim_object")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ClaimObject",
+			encodeObjectPoliciesRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		listObjects: kitot.TraceClient(tracer, "list_objects")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"ListObjects",
+			encodeObjectsRequest,
+			decodeObjectsResponse,
+			mainflux.ObjectsRes{},
+		).Endpoint()),
 
 		timeout: timeout,
 	}
@@ -102,6 +192,58 @@ func decodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, e
 	return identityRes{id: res.GetId(), email: res.GetEmail()}, nil
 }
 
+func (client grpcClient) Login(ctx context.Context, req *mainflux.IssueReq, _ ...grpc.CallOption) (*mainflux.Tokens, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.login(ctx, loginReq{id: req.GetId(), email: req.GetEmail()})
+	if err != nil {
+		return nil, err
+	}
+
+	tr := res.(tokensRes)
+	return &mainflux.Tokens{AccessToken: tr.accessToken, RefreshToken: tr.refreshToken}, nil
+}
+
+func encodeLoginRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(loginReq)
+	return &mainflux.IssueReq{Id: req.id, Email: req.email}, nil
+}
+
+func (client grpcClient) Refresh(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.Tokens, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.refresh(ctx, tokenReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	tr := res.(tokensRes)
+	return &mainflux.Tokens{AccessToken: tr.accessToken, RefreshToken: tr.refreshToken}, nil
+}
+
+func (client grpcClient) Logout(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.logout(ctx, tokenReq{token: token.GetValue()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func encodeTokenRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(tokenReq)
+	return &mainflux.Token{Value: req.token}, nil
+}
+
+func decodeTokensResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Tokens)
+	return tokensRes{accessToken: res.GetAccessToken(), refreshToken: res.GetRefreshToken()}, nil
+}
+
 func (client grpcClient) Identify(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
@@ -125,11 +267,42 @@ func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}
 	return identityRes{id: res.GetId(), email: res.GetEmail()}, nil
 }
 
+func (client grpcClient) CanAccess(ctx context.Context, req *mainflux.AccessReq, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.canAccess(ctx, accessReq{token: req.GetToken(), obj: req.GetObj(), act: req.GetAct()})
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(identityRes)
+	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, nil
+}
+
+func (client grpcClient) IdentifyPreAuth(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.preAuth(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(identityRes)
+	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, nil
+}
+
+func encodeAccessRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessReq)
+	return &mainflux.AccessReq{Token: req.token, Obj: req.obj, Act: req.act}, nil
+}
+
 func (client grpcClient) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
 
-	res, err := client.authorize(ctx, authReq{Act: req.Act, Obj: req.Obj, Sub: req.Sub})
+	res, err := client.authorize(ctx, authReq{Token: req.GetToken(), Obj: req.GetObj(), Act: req.GetAct()})
 	if err != nil {
 		return &mainflux.AuthorizeRes{Authorized: false}, err
 	}
@@ -146,9 +319,9 @@ func decodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{
 func encodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(authReq)
 	return &mainflux.AuthorizeReq{
-		Sub: req.Sub,
-		Obj: req.Obj,
-		Act: req.Act,
+		Token: req.Token,
+		Obj:   req.Obj,
+		Act:   req.Act,
 	}, nil
 }
 
@@ -219,8 +392,94 @@ func encodeAssignRequest(_ context.Context, grpcRes interface{}) (interface{}, e
 func decodeAssignResponse(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(authReq)
 	return &mainflux.AuthorizeReq{
-		Sub: req.Sub,
-		Obj: req.Obj,
-		Act: req.Act,
+		Token: req.Token,
+		Obj:   req.Obj,
+		Act:   req.Act,
 	}, nil
 }
+
+func (client grpcClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.addPolicy(ctx, policyReq{Token: req.GetToken(), Sub: req.GetSub(), Obj: req.GetObj(), Act: req.GetAct()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (client grpcClient) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.deletePolicy(ctx, policyReq{Token: req.GetToken(), Sub: req.GetSub(), Obj: req.GetObj(), Act: req.GetAct()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func encodePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(policyReq)
+	return &mainflux.PolicyReq{
+		Token: req.Token,
+		Sub:   req.Sub,
+		Obj:   req.Obj,
+		Act:   req.Act,
+	}, nil
+}
+
+func (client grpcClient) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.deleteObject(ctx, objectPoliciesReq{Token: req.GetToken(), Obj: req.GetObj()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (client grpcClient) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	if _, err := client.claimObject(ctx, objectPoliciesReq{Token: req.GetToken(), Obj: req.GetObj()}); err != nil {
+		return &empty.Empty{}, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func encodeObjectPoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(objectPoliciesReq)
+	return &mainflux.ObjectPoliciesReq{Token: req.Token, Obj: req.Obj}, nil
+}
+
+func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return emptyRes{}, nil
+}
+
+func (client grpcClient) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (*mainflux.ObjectsRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.listObjects(ctx, objectsReq{Token: req.GetToken(), Act: req.GetAct()})
+	if err != nil {
+		return &mainflux.ObjectsRes{}, err
+	}
+
+	or := res.(objectsRes)
+	return &mainflux.ObjectsRes{Objects: or.objects}, nil
+}
+
+func encodeObjectsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(objectsReq)
+	return &mainflux.ObjectsReq{Token: req.Token, Act: req.Act}, nil
+}
+
+func decodeObjectsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ObjectsRes)
+	return objectsRes{objects: res.GetObjects()}, nil
+}
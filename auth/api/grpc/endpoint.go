@@ -34,6 +34,56 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func loginEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(loginReq)
+		if err := req.validate(); err != nil {
+			return tokensRes{}, err
+		}
+
+		key := auth.Key{
+			Subject:  req.email,
+			IssuerID: req.id,
+			IssuedAt: time.Now().UTC(),
+		}
+
+		tokens, err := svc.Login(ctx, key)
+		if err != nil {
+			return tokensRes{}, err
+		}
+
+		return tokensRes{accessToken: tokens.AccessToken, refreshToken: tokens.RefreshToken}, nil
+	}
+}
+
+func refreshEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(tokenReq)
+		if err := req.validate(); err != nil {
+			return tokensRes{}, err
+		}
+
+		tokens, err := svc.Refresh(ctx, req.token)
+		if err != nil {
+			return tokensRes{}, err
+		}
+
+		return tokensRes{accessToken: tokens.AccessToken, refreshToken: tokens.RefreshToken}, nil
+	}
+}
+
+func logoutEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(tokenReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		err := svc.Logout(ctx, req.token)
+		return emptyRes{err: err}, nil
+	}
+}
+
 func identifyEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(identityReq)
@@ -54,25 +104,52 @@ func identifyEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
-func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
+func canAccessEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(authReq)
+		req := request.(accessReq)
+		if err := req.validate(); err != nil {
+			return identityRes{}, err
+		}
 
+		id, err := svc.CanAccess(ctx, req.token, req.obj, req.act)
+		if err != nil {
+			return identityRes{}, err
+		}
+
+		return identityRes{id: id.ID, email: id.Email}, nil
+	}
+}
+
+func identifyPreAuthEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
 		if err := req.validate(); err != nil {
-			return authorizeRes{}, err
+			return identityRes{}, err
 		}
 
-		_, err := svc.Identify(ctx, req.token)
+		id, err := svc.IdentifyPreAuth(ctx, req.token)
 		if err != nil {
+			return identityRes{}, err
+		}
+
+		return identityRes{id: id.ID, email: id.Email}, nil
+	}
+}
+
+func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(authReq)
+
+		if err := req.validate(); err != nil {
 			return authorizeRes{}, err
 		}
 
-		authorized, err := svc.Authorize(ctx, req.token, req.Sub, req.Obj, req.Obj)
+		authorized, err := svc.Authorize(ctx, req.Token, req.Obj, req.Act)
 		if err != nil {
 			return authorizeRes{}, err
 		}
 
-		return authorizeRes{authorized: authorized}, err
+		return authorizeRes{authorized: authorized}, nil
 	}
 }
 
@@ -125,3 +202,75 @@ func membersEndpoint(svc auth.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.AddPolicies(ctx, req.Token, auth.Policy{Subject: req.Sub, Object: req.Obj, Action: req.Act}); err != nil {
+			return emptyRes{}, err
+		}
+		return emptyRes{}, nil
+	}
+}
+
+func deletePolicyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policyReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.RemovePolicies(ctx, req.Token, auth.Policy{Subject: req.Sub, Object: req.Obj, Action: req.Act}); err != nil {
+			return emptyRes{}, err
+		}
+		return emptyRes{}, nil
+	}
+}
+
+func deleteObjectPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(objectPoliciesReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.RemoveObjectPolicies(ctx, req.Token, req.Obj); err != nil {
+			return emptyRes{}, err
+		}
+		return emptyRes{}, nil
+	}
+}
+
+func claimObjectEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(objectPoliciesReq)
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.ClaimObject(ctx, req.Token, req.Obj); err != nil {
+			return emptyRes{}, err
+		}
+		return emptyRes{}, nil
+	}
+}
+
+func listObjectsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(objectsReq)
+		if err := req.validate(); err != nil {
+			return objectsRes{}, err
+		}
+
+		objects, err := svc.ListObjects(ctx, req.Token, req.Act)
+		if err != nil {
+			return objectsRes{}, err
+		}
+
+		return objectsRes{objects: objects}, nil
+	}
+}
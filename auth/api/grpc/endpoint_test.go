@@ -18,6 +18,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -41,10 +42,11 @@ var svc auth.Service
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
 
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t)
 }
 
 func startGRPCServer(svc auth.Service, port int) {
@@ -116,6 +118,103 @@ func TestIssue(t *testing.T) {
 	}
 }
 
+func TestLogin(t *testing.T) {
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		id    string
+		email string
+		code  codes.Code
+	}{
+		{
+			desc:  "login user",
+			id:    id,
+			email: email,
+			code:  codes.OK,
+		},
+		{
+			desc:  "login user without email",
+			id:    id,
+			email: "",
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "login user without id",
+			id:    "",
+			email: email,
+			code:  codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range cases {
+		tokens, err := client.Login(context.Background(), &mainflux.IssueReq{Id: tc.id, Email: tc.email})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		if err == nil {
+			assert.NotEmpty(t, tokens.GetAccessToken(), fmt.Sprintf("%s: expected access token", tc.desc))
+			assert.NotEmpty(t, tokens.GetRefreshToken(), fmt.Sprintf("%s: expected refresh token", tc.desc))
+		}
+	}
+}
+
+func TestRefreshAndLogout(t *testing.T) {
+	tokens, err := svc.Login(context.Background(), auth.Key{IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Login expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	refreshed, err := client.Refresh(context.Background(), &mainflux.Token{Value: tokens.RefreshToken})
+	e, ok := status.FromError(err)
+	assert.True(t, ok, "gRPC status can't be extracted from the error")
+	assert.Equal(t, codes.OK, e.Code(), fmt.Sprintf("refresh session: expected %s got %s", codes.OK, e.Code()))
+
+	cases := []struct {
+		desc   string
+		token  string
+		logout bool
+		code   codes.Code
+	}{
+		{
+			desc:  "refresh with used refresh token",
+			token: tokens.RefreshToken,
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "refresh with empty token",
+			token: "",
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:   "logout with access token",
+			token:  refreshed.GetAccessToken(),
+			logout: true,
+			code:   codes.OK,
+		},
+		{
+			desc:   "logout with empty token",
+			token:  "",
+			logout: true,
+			code:   codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.Refresh(context.Background(), &mainflux.Token{Value: tc.token})
+		if tc.logout {
+			_, err = client.Logout(context.Background(), &mainflux.Token{Value: tc.token})
+		}
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
@@ -185,6 +284,133 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestCanAccess(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	scope := auth.Scope{Actions: []string{auth.ReadAction}, Objects: []string{"thing"}}
+	_, scopedSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scope: scope})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		token string
+		obj   string
+		act   string
+		idt   mainflux.UserIdentity
+		code  codes.Code
+	}{
+		{
+			desc:  "access with user token",
+			token: loginSecret,
+			obj:   "thing",
+			act:   auth.WriteAction,
+			idt:   mainflux.UserIdentity{Email: email, Id: id},
+			code:  codes.OK,
+		},
+		{
+			desc:  "access with scoped API token",
+			token: scopedSecret,
+			obj:   "thing",
+			act:   auth.ReadAction,
+			idt:   mainflux.UserIdentity{Email: email, Id: id},
+			code:  codes.OK,
+		},
+		{
+			desc:  "access out of the scope of API token",
+			token: scopedSecret,
+			obj:   "thing",
+			act:   auth.WriteAction,
+			idt:   mainflux.UserIdentity{},
+			code:  codes.PermissionDenied,
+		},
+		{
+			desc:  "access with invalid token",
+			token: "invalid",
+			obj:   "thing",
+			act:   auth.ReadAction,
+			idt:   mainflux.UserIdentity{},
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "access without object",
+			token: scopedSecret,
+			obj:   "",
+			act:   auth.ReadAction,
+			idt:   mainflux.UserIdentity{},
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := client.CanAccess(context.Background(), &mainflux.AccessReq{Token: tc.token, Obj: tc.obj, Act: tc.act})
+		if idt != nil {
+			assert.Equal(t, tc.idt, *idt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.idt, *idt))
+		}
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
+func TestIdentifyPreAuth(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	_, preAuthSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.PreAuthKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing pre-auth key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc  string
+		token string
+		idt   mainflux.UserIdentity
+		code  codes.Code
+	}{
+		{
+			desc:  "identify user with pre-auth token",
+			token: preAuthSecret,
+			idt:   mainflux.UserIdentity{Email: email, Id: id},
+			code:  codes.OK,
+		},
+		{
+			desc:  "identify user with user token",
+			token: loginSecret,
+			idt:   mainflux.UserIdentity{},
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "identify user with invalid token",
+			token: "invalid",
+			idt:   mainflux.UserIdentity{},
+			code:  codes.Unauthenticated,
+		},
+		{
+			desc:  "identify user with empty token",
+			token: "",
+			idt:   mainflux.UserIdentity{},
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := client.IdentifyPreAuth(context.Background(), &mainflux.Token{Value: tc.token})
+		if idt != nil {
+			assert.Equal(t, tc.idt, *idt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.idt, *idt))
+		}
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
 func TestMembers(t *testing.T) {
 	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
@@ -260,3 +486,165 @@ func TestMembers(t *testing.T) {
 		assert.True(t, ok, "OK expected to be true")
 	}
 }
+
+func TestAuthorize(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	_, err = client.ClaimObject(context.Background(), &mainflux.ObjectPoliciesReq{Token: loginSecret, Obj: "thingID"})
+	require.Nil(t, err, fmt.Sprintf("Claiming object expected to succeed: %s", err))
+
+	cases := []struct {
+		desc       string
+		token      string
+		obj        string
+		act        string
+		authorized bool
+		code       codes.Code
+	}{
+		{
+			desc:       "authorize user with policy",
+			token:      loginSecret,
+			obj:        "thingID",
+			act:        auth.ReadAction,
+			authorized: true,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize user without policy",
+			token:      otherSecret,
+			obj:        "thingID",
+			act:        auth.ReadAction,
+			authorized: false,
+			code:       codes.OK,
+		},
+		{
+			desc:       "authorize with invalid token",
+			token:      "invalid",
+			obj:        "thingID",
+			act:        auth.ReadAction,
+			authorized: false,
+			code:       codes.Unauthenticated,
+		},
+		{
+			desc:       "authorize with empty action",
+			token:      loginSecret,
+			obj:        "thingID",
+			act:        "",
+			authorized: false,
+			code:       codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := client.Authorize(context.Background(), &mainflux.AuthorizeReq{Token: tc.token, Obj: tc.obj, Act: tc.act})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+		assert.Equal(t, tc.authorized, res.GetAuthorized(), fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.authorized, res.GetAuthorized()))
+	}
+}
+
+func TestAddPolicy(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	_, err = client.ClaimObject(context.Background(), &mainflux.ObjectPoliciesReq{Token: loginSecret, Obj: "channelID"})
+	require.Nil(t, err, fmt.Sprintf("Claiming object expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		req  *mainflux.PolicyReq
+		code codes.Code
+	}{
+		{
+			desc: "add admin policy over object without policies",
+			req:  &mainflux.PolicyReq{Token: otherSecret, Sub: "otherID", Obj: "unclaimedID", Act: auth.AdminAction},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "add policy over administered object",
+			req:  &mainflux.PolicyReq{Token: loginSecret, Sub: "otherID", Obj: "channelID", Act: auth.ReadAction},
+			code: codes.OK,
+		},
+		{
+			desc: "add policy without admin rights",
+			req:  &mainflux.PolicyReq{Token: otherSecret, Sub: "otherID", Obj: "channelID", Act: auth.AdminAction},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "add policy without token",
+			req:  &mainflux.PolicyReq{Sub: "otherID", Obj: "channelID", Act: auth.AdminAction},
+			code: codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.AddPolicy(context.Background(), tc.req)
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
+
+func TestClaimObject(t *testing.T) {
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
+
+	authAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.Dial(authAddr, grpc.WithInsecure())
+	client := grpcapi.NewClient(mocktracer.New(), conn, time.Second)
+
+	cases := []struct {
+		desc string
+		req  *mainflux.ObjectPoliciesReq
+		code codes.Code
+	}{
+		{
+			desc: "claim object without policies",
+			req:  &mainflux.ObjectPoliciesReq{Token: loginSecret, Obj: "claimedID"},
+			code: codes.OK,
+		},
+		{
+			desc: "claim administered object",
+			req:  &mainflux.ObjectPoliciesReq{Token: loginSecret, Obj: "claimedID"},
+			code: codes.OK,
+		},
+		{
+			desc: "claim object administered by other user",
+			req:  &mainflux.ObjectPoliciesReq{Token: otherSecret, Obj: "claimedID"},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "claim object without token",
+			req:  &mainflux.ObjectPoliciesReq{Obj: "claimedID"},
+			code: codes.Unauthenticated,
+		},
+		{
+			desc: "claim object without ID",
+			req:  &mainflux.ObjectPoliciesReq{Token: loginSecret},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := client.ClaimObject(context.Background(), tc.req)
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "gRPC status can't be extracted from the error")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.code, e.Code()))
+	}
+}
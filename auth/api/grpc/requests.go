@@ -44,6 +44,33 @@ func (req issueReq) validate() error {
 	return nil
 }
 
+type loginReq struct {
+	id    string
+	email string
+}
+
+func (req loginReq) validate() error {
+	if req.id == "" || req.email == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
+// tokenReq represents a request carrying only the access or refresh token,
+// i.e. refresh and logout requests.
+type tokenReq struct {
+	token string
+}
+
+func (req tokenReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	return nil
+}
+
 type assignReq struct {
 	token     string
 	groupID   string
@@ -82,30 +109,99 @@ func (req membersReq) validate() error {
 	return nil
 }
 
+// accessReq represents a request to identify the user performing the
+// action over the object, required to use the scoped API key.
+type accessReq struct {
+	token string
+	obj   string
+	act   string
+}
+
+func (req accessReq) validate() error {
+	if req.token == "" || req.obj == "" || req.act == "" {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 // authReq represents authorization request. It contains:
-// 1. subject - an action invoker
+// 1. token - a token of the action invoker
 // 2. object - an entity over which action will be executed
-// 3. action - type of action that will be executed (read/write)
+// 3. action - type of action that will be executed (read/write/admin)
 type authReq struct {
-	token string
-	Sub   string
+	Token string
 	Obj   string
 	Act   string
 }
 
 func (req authReq) validate() error {
-	if req.token == "" {
+	if req.Token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.Obj == "" {
 		return auth.ErrMalformedEntity
 	}
 
-	if req.Sub == "" {
+	if req.Act == "" {
 		return auth.ErrMalformedEntity
 	}
 
+	return nil
+}
+
+// policyReq represents a policy that is added or removed on behalf of
+// the user identified by the token.
+type policyReq struct {
+	Token string
+	Sub   string
+	Obj   string
+	Act   string
+}
+
+func (req policyReq) validate() error {
+	if req.Token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.Sub == "" || req.Obj == "" || req.Act == "" {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+// objectPoliciesReq represents a request concerning all the policies of
+// the object, e.g. to remove them when the object is removed, or to claim
+// the object that is not covered by any policy yet.
+type objectPoliciesReq struct {
+	Token string
+	Obj   string
+}
+
+func (req objectPoliciesReq) validate() error {
+	if req.Token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
 	if req.Obj == "" {
 		return auth.ErrMalformedEntity
 	}
 
+	return nil
+}
+
+type objectsReq struct {
+	Token string
+	Act   string
+}
+
+func (req objectsReq) validate() error {
+	if req.Token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
 	if req.Act == "" {
 		return auth.ErrMalformedEntity
 	}
@@ -12,6 +12,11 @@ type issueRes struct {
 	value string
 }
 
+type tokensRes struct {
+	accessToken  string
+	refreshToken string
+}
+
 type authorizeRes struct {
 	authorized bool
 }
@@ -25,3 +30,7 @@ type membersRes struct {
 type emptyRes struct {
 	err error
 }
+
+type objectsRes struct {
+	objects []string
+}
@@ -20,11 +20,21 @@ import (
 var _ mainflux.AuthServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	issue     kitgrpc.Handler
-	identify  kitgrpc.Handler
-	authorize kitgrpc.Handler
-	assign    kitgrpc.Handler
-	members   kitgrpc.Handler
+	issue        kitgrpc.Handler
+	login        kitgrpc.Handler
+	refresh      kitgrpc.Handler
+	logout       kitgrpc.Handler
+	identify     kitgrpc.Handler
+	canAccess    kitgrpc.Handler
+	preAuth      kitgrpc.Handler
+	authorize    kitgrpc.Handler
+	assign       kitgrpc.Handler
+	members      kitgrpc.Handler
+	addPolicy    kitgrpc.Handler
+	deletePolicy kitgrpc.Handler
+	deleteObject kitgrpc.Handler
+	claimObject  kitgrpc.Handler
+	listObjects  kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -35,11 +45,36 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeIssueRequest,
 			encodeIssueResponse,
 		),
+		login: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "login")(loginEndpoint(svc)),
+			decodeLoginRequest,
+			encodeTokensResponse,
+		),
+		refresh: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "refresh")(refreshEndpoint(svc)),
+			decodeTokenRequest,
+			encodeTokensResponse,
+		),
+		logout: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "logout")(logoutEndpoint(svc)),
+			decodeTokenRequest,
+			encodeEmptyResponse,
+		),
 		identify: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "identify")(identifyEndpoint(svc)),
 			decodeIdentifyRequest,
 			encodeIdentifyResponse,
 		),
+		canAccess: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "can_access")(canAccessEndpoint(svc)),
+			decodeAccessRequest,
+			encodeIdentifyResponse,
+		),
+		preAuth: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "identify_pre_auth")(identifyPreAuthEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeIdentifyResponse,
+		),
 		authorize: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "authorize")(authorizeEndpoint(svc)),
 			decodeAuthorizeRequest,
@@ -55,6 +90,31 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeMembersRequest,
 			encodeMembersResponse,
 		),
+		addPolicy: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "add_policy")(addPolicyEndpoint(svc)),
+			decodePolicyRequest,
+			encodeEmptyResponse,
+		),
+		deletePolicy: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "delete_policy")(deletePolicyEndpoint(svc)),
+			decodePolicyRequest,
+			encodeEmptyResponse,
+		),
+		deleteObject: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "delete_object_policies")(deleteObjectPoliciesEndpoint(svc)),
+			decodeObjectPoliciesRequest,
+			encodeEmptyResponse,
+		),
+		claimObject: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "claim_object")(claimObjectEndpoint(svc)),
+			decodeObjectPoliciesRequest,
+			encodeEmptyResponse,
+		),
+		listObjects: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "list_objects")(listObjectsEndpoint(svc)),
+			decodeObjectsRequest,
+			encodeObjectsResponse,
+		),
 	}
 }
 
@@ -66,6 +126,30 @@ func (s *grpcServer) Issue(ctx context.Context, req *mainflux.IssueReq) (*mainfl
 	return res.(*mainflux.Token), nil
 }
 
+func (s *grpcServer) Login(ctx context.Context, req *mainflux.IssueReq) (*mainflux.Tokens, error) {
+	_, res, err := s.login.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.Tokens), nil
+}
+
+func (s *grpcServer) Refresh(ctx context.Context, token *mainflux.Token) (*mainflux.Tokens, error) {
+	_, res, err := s.refresh.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.Tokens), nil
+}
+
+func (s *grpcServer) Logout(ctx context.Context, token *mainflux.Token) (*empty.Empty, error) {
+	_, res, err := s.logout.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
 func (s *grpcServer) Identify(ctx context.Context, token *mainflux.Token) (*mainflux.UserIdentity, error) {
 	_, res, err := s.identify.ServeGRPC(ctx, token)
 	if err != nil {
@@ -74,6 +158,22 @@ func (s *grpcServer) Identify(ctx context.Context, token *mainflux.Token) (*main
 	return res.(*mainflux.UserIdentity), nil
 }
 
+func (s *grpcServer) CanAccess(ctx context.Context, req *mainflux.AccessReq) (*mainflux.UserIdentity, error) {
+	_, res, err := s.canAccess.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.UserIdentity), nil
+}
+
+func (s *grpcServer) IdentifyPreAuth(ctx context.Context, token *mainflux.Token) (*mainflux.UserIdentity, error) {
+	_, res, err := s.preAuth.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.UserIdentity), nil
+}
+
 func (s *grpcServer) Authorize(ctx context.Context, token *mainflux.AuthorizeReq) (*mainflux.AuthorizeRes, error) {
 	_, res, err := s.authorize.ServeGRPC(ctx, token)
 	if err != nil {
@@ -98,6 +198,46 @@ func (s *grpcServer) Members(ctx context.Context, req *mainflux.MembersReq) (*ma
 	return res.(*mainflux.MembersRes), nil
 }
 
+func (s *grpcServer) AddPolicy(ctx context.Context, req *mainflux.PolicyReq) (*empty.Empty, error) {
+	_, res, err := s.addPolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq) (*empty.Empty, error) {
+	_, res, err := s.deletePolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq) (*empty.Empty, error) {
+	_, res, err := s.deleteObject.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq) (*empty.Empty, error) {
+	_, res, err := s.claimObject.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) ListObjects(ctx context.Context, req *mainflux.ObjectsReq) (*mainflux.ObjectsRes, error) {
+	_, res, err := s.listObjects.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.ObjectsRes), nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.IssueReq)
 	return issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.GetType()}, nil
@@ -108,6 +248,21 @@ func encodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, e
 	return &mainflux.Token{Value: res.value}, nil
 }
 
+func decodeLoginRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.IssueReq)
+	return loginReq{id: req.GetId(), email: req.GetEmail()}, nil
+}
+
+func decodeTokenRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.Token)
+	return tokenReq{token: req.GetValue()}, nil
+}
+
+func encodeTokensResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(tokensRes)
+	return &mainflux.Tokens{AccessToken: res.accessToken, RefreshToken: res.refreshToken}, nil
+}
+
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.Token)
 	return identityReq{token: req.GetValue()}, nil
@@ -118,14 +273,24 @@ func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}
 	return &mainflux.UserIdentity{Id: res.id, Email: res.email}, nil
 }
 
+func decodeAccessRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.AccessReq)
+	return accessReq{token: req.GetToken(), obj: req.GetObj(), act: req.GetAct()}, nil
+}
+
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AuthorizeReq)
-	return authReq{Act: req.Act, Obj: req.Obj, Sub: req.Sub}, nil
+	return authReq{Token: req.GetToken(), Obj: req.GetObj(), Act: req.GetAct()}, nil
 }
 
 func encodeAuthorizeResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(*mainflux.AuthorizeRes)
-	return res, nil
+	res := grpcRes.(authorizeRes)
+	return &mainflux.AuthorizeRes{Authorized: res.authorized}, nil
+}
+
+func decodePolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.PolicyReq)
+	return policyReq{Token: req.GetToken(), Sub: req.GetSub(), Obj: req.GetObj(), Act: req.GetAct()}, nil
 }
 
 func decodeAssignRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -133,6 +298,21 @@ func decodeAssignRequest(_ context.Context, grpcReq interface{}) (interface{}, e
 	return assignReq{token: req.GetValue()}, nil
 }
 
+func decodeObjectPoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ObjectPoliciesReq)
+	return objectPoliciesReq{Token: req.GetToken(), Obj: req.GetObj()}, nil
+}
+
+func decodeObjectsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ObjectsReq)
+	return objectsReq{Token: req.GetToken(), Act: req.GetAct()}, nil
+}
+
+func encodeObjectsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(objectsRes)
+	return &mainflux.ObjectsRes{Objects: res.objects}, nil
+}
+
 func decodeMembersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.MembersReq)
 	return membersReq{
@@ -170,6 +350,10 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, auth.ErrKeyExpired):
 		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, auth.ErrSessionRevoked):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Contains(err, auth.ErrOutOfScope):
+		return status.Error(codes.PermissionDenied, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/jwt"
 )
 
 func issueEndpoint(svc auth.Service) endpoint.Endpoint {
@@ -23,6 +24,12 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 			IssuedAt: now,
 			Type:     req.Type,
 		}
+		if req.Scope != nil {
+			newKey.Scope = auth.Scope{
+				Actions: req.Scope.Actions,
+				Objects: req.Scope.Objects,
+			}
+		}
 
 		duration := time.Duration(req.Duration * time.Second)
 		if duration != 0 {
@@ -39,6 +46,7 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 			ID:       key.ID,
 			Value:    secret,
 			IssuedAt: key.IssuedAt,
+			Scope:    toScopeRes(key.Scope),
 		}
 		if !key.ExpiresAt.IsZero() {
 			res.ExpiresAt = &key.ExpiresAt
@@ -60,18 +68,39 @@ func retrieveEndpoint(svc auth.Service) endpoint.Endpoint {
 		if err != nil {
 			return nil, err
 		}
-		ret := retrieveKeyRes{
-			ID:       key.ID,
-			IssuerID: key.IssuerID,
-			Subject:  key.Subject,
-			Type:     key.Type,
-			IssuedAt: key.IssuedAt,
+
+		return toRetrieveKeyRes(key), nil
+	}
+}
+
+func listKeysEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
 		}
-		if !key.ExpiresAt.IsZero() {
-			ret.ExpiresAt = &key.ExpiresAt
+
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListKeys(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
 		}
 
-		return ret, nil
+		res := keysPageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+			Keys:   []retrieveKeyRes{},
+		}
+		for _, key := range page.Keys {
+			res.Keys = append(res.Keys, toRetrieveKeyRes(key))
+		}
+
+		return res, nil
 	}
 }
 
@@ -90,3 +119,53 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 		return revokeKeyRes{}, nil
 	}
 }
+
+func jwksEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		keys, err := svc.PublicKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := jwksRes{Keys: []jwt.JWK{}}
+		for _, k := range keys {
+			jwk, err := jwt.NewJWK(k)
+			if err != nil {
+				return nil, err
+			}
+			res.Keys = append(res.Keys, jwk)
+		}
+
+		return res, nil
+	}
+}
+
+func toRetrieveKeyRes(key auth.Key) retrieveKeyRes {
+	res := retrieveKeyRes{
+		ID:       key.ID,
+		IssuerID: key.IssuerID,
+		Subject:  key.Subject,
+		Type:     key.Type,
+		IssuedAt: key.IssuedAt,
+		Scope:    toScopeRes(key.Scope),
+	}
+	if !key.ExpiresAt.IsZero() {
+		res.ExpiresAt = &key.ExpiresAt
+	}
+	if !key.LastUsedAt.IsZero() {
+		res.LastUsedAt = &key.LastUsedAt
+	}
+
+	return res
+}
+
+func toScopeRes(scope auth.Scope) *scopeRes {
+	if scope.Empty() {
+		return nil
+	}
+
+	return &scopeRes{
+		Actions: scope.Actions,
+		Objects: scope.Objects,
+	}
+}
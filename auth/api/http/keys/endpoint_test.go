@@ -5,6 +5,8 @@ package keys_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +23,7 @@ import (
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -33,6 +36,23 @@ const (
 type issueRequest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	Type     uint32        `json:"type,omitempty"`
+	Scope    *scopeRequest `json:"scope,omitempty"`
+}
+
+type scopeRequest struct {
+	Actions []string `json:"actions,omitempty"`
+	Objects []string `json:"objects,omitempty"`
+}
+
+type keysPageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Keys   []struct {
+		ID         string       `json:"id"`
+		LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+		Scope      scopeRequest `json:"scope"`
+	} `json:"keys"`
 }
 
 type testRequest struct {
@@ -63,9 +83,10 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t)
 }
 
 func newServer(svc auth.Service) *httptest.Server {
@@ -90,6 +111,10 @@ func TestIssue(t *testing.T) {
 	uk := issueRequest{Type: auth.UserKey}
 	ak := issueRequest{Type: auth.APIKey, Duration: time.Hour}
 	rk := issueRequest{Type: auth.RecoveryKey}
+	sk := issueRequest{Type: auth.APIKey, Duration: time.Hour, Scope: &scopeRequest{Actions: []string{auth.ReadAction}, Objects: []string{"thing"}}}
+	suk := issueRequest{Type: auth.UserKey, Scope: &scopeRequest{Actions: []string{auth.ReadAction}}}
+	esk := issueRequest{Type: auth.APIKey, Duration: time.Hour, Scope: &scopeRequest{}}
+	bsk := issueRequest{Type: auth.APIKey, Duration: time.Hour, Scope: &scopeRequest{Actions: []string{""}}}
 
 	cases := []struct {
 		desc   string
@@ -112,6 +137,34 @@ func TestIssue(t *testing.T) {
 			token:  loginSecret,
 			status: http.StatusCreated,
 		},
+		{
+			desc:   "issue scoped API key",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "issue scoped user key",
+			req:    toJSON(suk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue API key with empty scope",
+			req:    toJSON(esk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue API key with empty scope action",
+			req:    toJSON(bsk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue recovery key",
 			req:    toJSON(rk),
@@ -237,6 +290,94 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestListKeys(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	n := 5
+	scope := auth.Scope{Actions: []string{auth.ReadAction}, Objects: []string{"thing"}}
+	for i := 0; i < n; i++ {
+		_, _, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scope: scope})
+		require.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list keys",
+			query:  "",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list keys with offset and limit",
+			query:  "?offset=1&limit=2",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list keys with limit greater than max",
+			query:  "?limit=101",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "list keys with invalid offset",
+			query:  "?offset=invalid",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "list keys unauthorized",
+			query:  "",
+			token:  "wrong",
+			status: http.StatusForbidden,
+			size:   0,
+		},
+		{
+			desc:   "list keys without token",
+			query:  "",
+			token:  "",
+			status: http.StatusForbidden,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/keys%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body keysPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.size, len(body.Keys), fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.size, len(body.Keys)))
+		for _, k := range body.Keys {
+			assert.Equal(t, scope.Actions, k.Scope.Actions, fmt.Sprintf("%s: expected scope actions %v got %v", tc.desc, scope.Actions, k.Scope.Actions))
+			assert.Equal(t, scope.Objects, k.Scope.Objects, fmt.Sprintf("%s: expected scope objects %v got %v", tc.desc, scope.Objects, k.Scope.Objects))
+		}
+	}
+}
+
 func TestRevoke(t *testing.T) {
 	svc := newService()
 	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
@@ -287,3 +428,51 @@ func TestRevoke(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
 }
+
+func TestJWKS(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	key, err := jwt.NewSigningKey(priv)
+	require.Nil(t, err, fmt.Sprintf("creating signing key expected to succeed: %s", err))
+	tokenizer, err := jwt.NewAsymmetric("", key)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	asymmetric := auth.New(mocks.NewKeyRepository(), mocks.NewGroupRepository(), mocks.NewPolicyRepository(), uuid.NewMock(), tokenizer)
+
+	cases := []struct {
+		desc string
+		svc  auth.Service
+		keys []string
+	}{
+		{
+			desc: "retrieve public keys",
+			svc:  asymmetric,
+			keys: []string{key.ID},
+		},
+		{
+			desc: "retrieve public keys of shared secret tokenizer",
+			svc:  newService(),
+			keys: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(tc.svc)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, http.StatusOK, res.StatusCode))
+
+		var body jwt.JWKS
+		json.NewDecoder(res.Body).Decode(&body)
+		ids := []string{}
+		for _, k := range body.Keys {
+			ids = append(ids, k.ID)
+		}
+		assert.Equal(t, tc.keys, ids, fmt.Sprintf("%s: expected keys %v got %v", tc.desc, tc.keys, ids))
+		ts.Close()
+	}
+}
@@ -9,20 +9,43 @@ import (
 	"github.com/mainflux/mainflux/auth"
 )
 
+const maxLimitSize = 100
+
 type issueKeyReq struct {
 	token    string
 	Type     uint32        `json:"type,omitempty"`
 	Duration time.Duration `json:"duration,omitempty"`
+	Scope    *scopeReq     `json:"scope,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
 func (req issueKeyReq) validate() error {
-	if req.Type == auth.UserKey {
+	if req.Type == auth.UserKey && req.Scope == nil {
 		return nil
 	}
 	if req.token == "" || (req.Type != auth.APIKey) {
 		return auth.ErrMalformedEntity
 	}
+	if req.Scope != nil {
+		return req.Scope.validate()
+	}
+	return nil
+}
+
+type scopeReq struct {
+	Actions []string `json:"actions,omitempty"`
+	Objects []string `json:"objects,omitempty"`
+}
+
+func (req scopeReq) validate() error {
+	if len(req.Actions) == 0 && len(req.Objects) == 0 {
+		return auth.ErrMalformedEntity
+	}
+	for _, v := range append(req.Actions, req.Objects...) {
+		if v == "" {
+			return auth.ErrMalformedEntity
+		}
+	}
 	return nil
 }
 
@@ -37,3 +60,19 @@ func (req keyReq) validate() error {
 	}
 	return nil
 }
+
+type listKeysReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listKeysReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return auth.ErrMalformedEntity
+	}
+	return nil
+}
@@ -8,18 +8,27 @@ import (
 	"time"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/auth/jwt"
 )
 
 var (
 	_ mainflux.Response = (*issueKeyRes)(nil)
 	_ mainflux.Response = (*revokeKeyRes)(nil)
+	_ mainflux.Response = (*keysPageRes)(nil)
+	_ mainflux.Response = (*jwksRes)(nil)
 )
 
+type scopeRes struct {
+	Actions []string `json:"actions,omitempty"`
+	Objects []string `json:"objects,omitempty"`
+}
+
 type issueKeyRes struct {
 	ID        string     `json:"id,omitempty"`
 	Value     string     `json:"value,omitempty"`
 	IssuedAt  time.Time  `json:"issued_at,omitempty"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Scope     *scopeRes  `json:"scope,omitempty"`
 }
 
 func (res issueKeyRes) Code() int {
@@ -35,12 +44,14 @@ func (res issueKeyRes) Empty() bool {
 }
 
 type retrieveKeyRes struct {
-	ID        string     `json:"id,omitempty"`
-	IssuerID  string     `json:"issuer_id,omitempty"`
-	Subject   string     `json:"subject,omitempty"`
-	Type      uint32     `json:"type,omitempty"`
-	IssuedAt  time.Time  `json:"issued_at,omitempty"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ID         string     `json:"id,omitempty"`
+	IssuerID   string     `json:"issuer_id,omitempty"`
+	Subject    string     `json:"subject,omitempty"`
+	Type       uint32     `json:"type,omitempty"`
+	IssuedAt   time.Time  `json:"issued_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Scope      *scopeRes  `json:"scope,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
@@ -55,6 +66,25 @@ func (res retrieveKeyRes) Empty() bool {
 	return false
 }
 
+type keysPageRes struct {
+	Total  uint64           `json:"total"`
+	Offset uint64           `json:"offset"`
+	Limit  uint64           `json:"limit"`
+	Keys   []retrieveKeyRes `json:"keys"`
+}
+
+func (res keysPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res keysPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res keysPageRes) Empty() bool {
+	return false
+}
+
 type revokeKeyRes struct {
 }
 
@@ -70,6 +100,24 @@ func (res revokeKeyRes) Empty() bool {
 	return true
 }
 
+type jwksRes jwt.JWKS
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+// Keys are cached by verifiers for a short time, so a new key has to be
+// published before it starts signing tokens.
+func (res jwksRes) Headers() map[string]string {
+	return map[string]string{
+		"Cache-Control": "public, max-age=300",
+	}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/auth"
+)
+
+func addPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policiesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.AddPolicies(ctx, req.token, req.policies()...); err != nil {
+			return nil, err
+		}
+
+		return addPoliciesRes{}, nil
+	}
+}
+
+func removePoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(policiesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemovePolicies(ctx, req.token, req.policies()...); err != nil {
+			return nil, err
+		}
+
+		return removePoliciesRes{}, nil
+	}
+}
+
+func listPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listPoliciesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		filter := auth.Policy{
+			Subject: req.subject,
+			Object:  req.object,
+			Action:  req.action,
+		}
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+
+		page, err := svc.ListPolicies(ctx, req.token, filter, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := policyPageRes{
+			Total:    page.Total,
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Policies: []viewPolicyRes{},
+		}
+		for _, p := range page.Policies {
+			res.Policies = append(res.Policies, viewPolicyRes{
+				Subject:   p.Subject,
+				Object:    p.Object,
+				Action:    p.Action,
+				CreatedAt: p.CreatedAt,
+			})
+		}
+
+		return res, nil
+	}
+}
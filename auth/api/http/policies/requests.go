@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"github.com/mainflux/mainflux/auth"
+)
+
+const maxLimitSize = 100
+
+type policiesReq struct {
+	token    string
+	Object   string   `json:"object"`
+	Subjects []string `json:"subjects"`
+	Actions  []string `json:"actions"`
+}
+
+func (req policiesReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.Object == "" || len(req.Subjects) == 0 || len(req.Actions) == 0 {
+		return auth.ErrMalformedEntity
+	}
+
+	for _, s := range req.Subjects {
+		if s == "" {
+			return auth.ErrMalformedEntity
+		}
+	}
+
+	for _, a := range req.Actions {
+		if a == "" {
+			return auth.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+func (req policiesReq) policies() []auth.Policy {
+	var ps []auth.Policy
+	for _, s := range req.Subjects {
+		for _, a := range req.Actions {
+			ps = append(ps, auth.Policy{Subject: s, Object: req.Object, Action: a})
+		}
+	}
+	return ps
+}
+
+type listPoliciesReq struct {
+	token   string
+	subject string
+	object  string
+	action  string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listPoliciesReq) validate() error {
+	if req.token == "" {
+		return auth.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return auth.ErrMalformedEntity
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*addPoliciesRes)(nil)
+	_ mainflux.Response = (*removePoliciesRes)(nil)
+	_ mainflux.Response = (*policyPageRes)(nil)
+)
+
+type addPoliciesRes struct{}
+
+func (res addPoliciesRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res addPoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res addPoliciesRes) Empty() bool {
+	return true
+}
+
+type removePoliciesRes struct{}
+
+func (res removePoliciesRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removePoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removePoliciesRes) Empty() bool {
+	return true
+}
+
+type viewPolicyRes struct {
+	Subject   string    `json:"subject"`
+	Object    string    `json:"object"`
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type policyPageRes struct {
+	Total    uint64          `json:"total"`
+	Offset   uint64          `json:"offset"`
+	Limit    uint64          `json:"limit"`
+	Policies []viewPolicyRes `json:"policies"`
+}
+
+func (res policyPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res policyPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res policyPageRes) Empty() bool {
+	return false
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -43,6 +43,58 @@ func (lm *loggingMiddleware) Issue(ctx context.Context, token string, newKey aut
 	return lm.svc.Issue(ctx, token, newKey)
 }
 
+func (lm *loggingMiddleware) Login(ctx context.Context, key auth.Key) (tokens auth.Tokens, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method login for user %s took %s to complete", key.Subject, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Login(ctx, key)
+}
+
+func (lm *loggingMiddleware) Refresh(ctx context.Context, refreshToken string) (tokens auth.Tokens, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method refresh took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Refresh(ctx, refreshToken)
+}
+
+func (lm *loggingMiddleware) Logout(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method logout took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Logout(ctx, token)
+}
+
+func (lm *loggingMiddleware) RemoveExpiredKeys(ctx context.Context) (n uint64, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_expired_keys removed %d keys and took %s to complete", n, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveExpiredKeys(ctx)
+}
+
 func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method revoke for key %s took %s to complete", id, time.Since(begin))
@@ -69,6 +121,19 @@ func (lm *loggingMiddleware) RetrieveKey(ctx context.Context, token, id string)
 	return lm.svc.RetrieveKey(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ListKeys(ctx context.Context, token string, pm auth.PageMetadata) (kp auth.KeyPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_keys took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListKeys(ctx, token, pm)
+}
+
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.Identity, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method identify took %s to complete", time.Since(begin))
@@ -82,9 +147,35 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.
 	return lm.svc.Identify(ctx, key)
 }
 
-func (lm *loggingMiddleware) Authorize(ctx context.Context, token, sub, obj, act string) (auth bool, err error) {
+func (lm *loggingMiddleware) CanAccess(ctx context.Context, token, obj, act string) (id auth.Identity, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method can_access for object %s and action %s took %s to complete", obj, act, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CanAccess(ctx, token, obj, act)
+}
+
+func (lm *loggingMiddleware) IdentifyPreAuth(ctx context.Context, token string) (id auth.Identity, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method identify_pre_auth took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.IdentifyPreAuth(ctx, token)
+}
+
+func (lm *loggingMiddleware) PublicKeys(ctx context.Context) (keys []auth.PublicKey, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method authorize took %s to complete", time.Since(begin))
+		message := fmt.Sprintf("Method public_keys took %s to complete", time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -92,7 +183,20 @@ func (lm *loggingMiddleware) Authorize(ctx context.Context, token, sub, obj, act
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Authorize(ctx, token, sub, obj, act)
+	return lm.svc.PublicKeys(ctx)
+}
+
+func (lm *loggingMiddleware) Authorize(ctx context.Context, token, obj, act string) (auth bool, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method authorize for object %s and action %s took %s to complete", obj, act, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Authorize(ctx, token, obj, act)
 }
 
 func (lm *loggingMiddleware) CreateGroup(ctx context.Context, token string, group auth.Group) (g auth.Group, err error) {
@@ -237,3 +341,81 @@ func (lm *loggingMiddleware) Unassign(ctx context.Context, token string, groupID
 
 	return lm.svc.Unassign(ctx, token, groupID, memberIDs...)
 }
+
+func (lm *loggingMiddleware) AddPolicies(ctx context.Context, token string, ps ...auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_policies for %d policies took %s to complete", len(ps), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddPolicies(ctx, token, ps...)
+}
+
+func (lm *loggingMiddleware) RemovePolicies(ctx context.Context, token string, ps ...auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_policies for %d policies took %s to complete", len(ps), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemovePolicies(ctx, token, ps...)
+}
+
+func (lm *loggingMiddleware) ClaimObject(ctx context.Context, token, obj string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method claim_object for object %s took %s to complete", obj, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ClaimObject(ctx, token, obj)
+}
+
+func (lm *loggingMiddleware) RemoveObjectPolicies(ctx context.Context, token, obj string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_object_policies for object %s took %s to complete", obj, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveObjectPolicies(ctx, token, obj)
+}
+
+func (lm *loggingMiddleware) ListPolicies(ctx context.Context, token string, filter auth.Policy, pm auth.PageMetadata) (pp auth.PolicyPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_policies took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListPolicies(ctx, token, filter, pm)
+}
+
+func (lm *loggingMiddleware) ListObjects(ctx context.Context, token, act string) (objs []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_objects for action %s took %s to complete", act, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListObjects(ctx, token, act)
+}
@@ -37,6 +37,42 @@ func (ms *metricsMiddleware) Issue(ctx context.Context, token string, key auth.K
 	return ms.svc.Issue(ctx, token, key)
 }
 
+func (ms *metricsMiddleware) Login(ctx context.Context, key auth.Key) (auth.Tokens, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "login").Add(1)
+		ms.latency.With("method", "login").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Login(ctx, key)
+}
+
+func (ms *metricsMiddleware) Refresh(ctx context.Context, refreshToken string) (auth.Tokens, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "refresh").Add(1)
+		ms.latency.With("method", "refresh").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Refresh(ctx, refreshToken)
+}
+
+func (ms *metricsMiddleware) Logout(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "logout").Add(1)
+		ms.latency.With("method", "logout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Logout(ctx, token)
+}
+
+func (ms *metricsMiddleware) RemoveExpiredKeys(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_expired_keys").Add(1)
+		ms.latency.With("method", "remove_expired_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveExpiredKeys(ctx)
+}
+
 func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "revoke_key").Add(1)
@@ -55,6 +91,15 @@ func (ms *metricsMiddleware) RetrieveKey(ctx context.Context, token, id string)
 	return ms.svc.RetrieveKey(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ListKeys(ctx context.Context, token string, pm auth.PageMetadata) (auth.KeyPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_keys").Add(1)
+		ms.latency.With("method", "list_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListKeys(ctx, token, pm)
+}
+
 func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.Identity, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "identify").Add(1)
@@ -64,13 +109,40 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.I
 	return ms.svc.Identify(ctx, token)
 }
 
-func (ms *metricsMiddleware) Authorize(ctx context.Context, token, sub, obj, act string) (auth bool, err error) {
+func (ms *metricsMiddleware) CanAccess(ctx context.Context, token, obj, act string) (auth.Identity, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "can_access").Add(1)
+		ms.latency.With("method", "can_access").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CanAccess(ctx, token, obj, act)
+}
+
+func (ms *metricsMiddleware) IdentifyPreAuth(ctx context.Context, token string) (auth.Identity, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "identify_pre_auth").Add(1)
+		ms.latency.With("method", "identify_pre_auth").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.IdentifyPreAuth(ctx, token)
+}
+
+func (ms *metricsMiddleware) PublicKeys(ctx context.Context) ([]auth.PublicKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "public_keys").Add(1)
+		ms.latency.With("method", "public_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PublicKeys(ctx)
+}
+
+func (ms *metricsMiddleware) Authorize(ctx context.Context, token, obj, act string) (auth bool, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
 		ms.latency.With("method", "authorize").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Authorize(ctx, token, sub, obj, act)
+	return ms.svc.Authorize(ctx, token, obj, act)
 }
 
 func (ms *metricsMiddleware) CreateGroup(ctx context.Context, token string, group auth.Group) (gr auth.Group, err error) {
@@ -168,3 +240,57 @@ func (ms *metricsMiddleware) Unassign(ctx context.Context, token, groupID string
 
 	return ms.svc.Unassign(ctx, token, groupID, memberIDs...)
 }
+
+func (ms *metricsMiddleware) AddPolicies(ctx context.Context, token string, ps ...auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_policies").Add(1)
+		ms.latency.With("method", "add_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddPolicies(ctx, token, ps...)
+}
+
+func (ms *metricsMiddleware) RemovePolicies(ctx context.Context, token string, ps ...auth.Policy) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_policies").Add(1)
+		ms.latency.With("method", "remove_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemovePolicies(ctx, token, ps...)
+}
+
+func (ms *metricsMiddleware) ClaimObject(ctx context.Context, token, obj string) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "claim_object").Add(1)
+		ms.latency.With("method", "claim_object").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ClaimObject(ctx, token, obj)
+}
+
+func (ms *metricsMiddleware) RemoveObjectPolicies(ctx context.Context, token, obj string) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_object_policies").Add(1)
+		ms.latency.With("method", "remove_object_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveObjectPolicies(ctx, token, obj)
+}
+
+func (ms *metricsMiddleware) ListPolicies(ctx context.Context, token string, filter auth.Policy, pm auth.PageMetadata) (pp auth.PolicyPage, err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_policies").Add(1)
+		ms.latency.With("method", "list_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListPolicies(ctx, token, filter, pm)
+}
+
+func (ms *metricsMiddleware) ListObjects(ctx context.Context, token, act string) (objs []string, err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_objects").Add(1)
+		ms.latency.With("method", "list_objects").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListObjects(ctx, token, act)
+}
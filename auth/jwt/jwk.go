@@ -0,0 +1,155 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	ktyRSA     = "RSA"
+	ktyOKP     = "OKP"
+	crvEd25519 = "Ed25519"
+	useSig     = "sig"
+)
+
+var errInvalidJWK = errors.New("invalid JSON web key")
+
+// JWK represents a public JSON Web Key (RFC 7517) used to verify tokens.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+
+	// RSA public key members.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Ed25519 public key members.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS represents a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK converts the public key to its JSON Web Key representation.
+func NewJWK(pk auth.PublicKey) (JWK, error) {
+	jwk := JWK{
+		ID:        pk.ID,
+		Algorithm: pk.Algorithm,
+		Use:       useSig,
+	}
+
+	switch k := pk.Key.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = ktyRSA
+		jwk.N = encode(k.N.Bytes())
+		jwk.E = encode(big.NewInt(int64(k.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = ktyOKP
+		jwk.Curve = crvEd25519
+		jwk.X = encode(k)
+	default:
+		return JWK{}, ErrUnsupportedKey
+	}
+
+	return jwk, nil
+}
+
+// PublicKey converts the JSON Web Key to the public key it represents.
+func (jwk JWK) PublicKey() (auth.PublicKey, error) {
+	if jwk.ID == "" || (jwk.Use != "" && jwk.Use != useSig) {
+		return auth.PublicKey{}, errInvalidJWK
+	}
+
+	pk := auth.PublicKey{
+		ID:        jwk.ID,
+		Algorithm: jwk.Algorithm,
+	}
+
+	switch jwk.KeyType {
+	case ktyRSA:
+		if pk.Algorithm != AlgRS256 {
+			return auth.PublicKey{}, ErrUnsupportedKey
+		}
+		n, err := decode(jwk.N)
+		if err != nil {
+			return auth.PublicKey{}, errors.Wrap(errInvalidJWK, err)
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return auth.PublicKey{}, errors.Wrap(errInvalidJWK, err)
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 || exp.Int64() < 3 {
+			return auth.PublicKey{}, errInvalidJWK
+		}
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exp.Int64()),
+		}
+		if key.N.BitLen() < minRSABits {
+			return auth.PublicKey{}, ErrUnsupportedKey
+		}
+		pk.Key = key
+	case ktyOKP:
+		if jwk.Curve != crvEd25519 || pk.Algorithm != AlgEdDSA {
+			return auth.PublicKey{}, ErrUnsupportedKey
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return auth.PublicKey{}, errors.Wrap(errInvalidJWK, err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return auth.PublicKey{}, errInvalidJWK
+		}
+		pk.Key = ed25519.PublicKey(x)
+	default:
+		return auth.PublicKey{}, ErrUnsupportedKey
+	}
+
+	return pk, nil
+}
+
+// thumbprint computes the RFC 7638 thumbprint of the public key, which is
+// used as the key ID.
+func thumbprint(key crypto.PublicKey) (string, error) {
+	jwk, err := NewJWK(auth.PublicKey{Key: key})
+	if err != nil {
+		return "", err
+	}
+
+	// Required members in lexicographic order, without whitespace.
+	var members string
+	switch jwk.KeyType {
+	case ktyRSA:
+		members = fmt.Sprintf(`{"e":"%s","kty":"%s","n":"%s"}`, jwk.E, jwk.KeyType, jwk.N)
+	case ktyOKP:
+		members = fmt.Sprintf(`{"crv":"%s","kty":"%s","x":"%s"}`, jwk.Curve, jwk.KeyType, jwk.X)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return encode(sum[:]), nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
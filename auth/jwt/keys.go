@@ -0,0 +1,133 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	// AlgRS256 is the algorithm used to sign tokens with RSA keys.
+	AlgRS256 = "RS256"
+
+	// AlgEdDSA is the algorithm used to sign tokens with Ed25519 keys.
+	AlgEdDSA = "EdDSA"
+
+	minRSABits = 2048
+)
+
+var (
+	// ErrUnsupportedKey indicates a key of unsupported type or size.
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+
+	errLoadKey  = errors.New("failed to load signing key")
+	errParseKey = errors.New("failed to parse signing key")
+)
+
+// SigningKey represents a private key used to sign tokens.
+type SigningKey struct {
+	// ID is the key identifier, set as "kid" header of the signed tokens.
+	// It is the RFC 7638 thumbprint of the public key.
+	ID string
+
+	// Algorithm is the algorithm used to sign tokens with this key.
+	Algorithm string
+
+	// Key is either *rsa.PrivateKey or ed25519.PrivateKey.
+	Key crypto.Signer
+}
+
+// PublicKey returns the public part of the signing key.
+func (sk SigningKey) PublicKey() auth.PublicKey {
+	return auth.PublicKey{
+		ID:        sk.ID,
+		Algorithm: sk.Algorithm,
+		Key:       sk.Key.Public(),
+	}
+}
+
+// LoadKeys reads PEM encoded private keys from the files with the provided
+// paths, preserving their order.
+func LoadKeys(paths ...string) ([]SigningKey, error) {
+	var keys []SigningKey
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(errLoadKey, err)
+		}
+		key, err := ParseKey(data)
+		if err != nil {
+			return nil, errors.Wrap(errLoadKey, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// ParseKey parses PEM encoded RSA (PKCS #1 or PKCS #8) or Ed25519 (PKCS #8)
+// private key. RSA keys shorter than 2048 bits are rejected.
+func ParseKey(data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, errParseKey
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return SigningKey{}, ErrUnsupportedKey
+	}
+	if err != nil {
+		return SigningKey{}, errors.Wrap(errParseKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return SigningKey{}, ErrUnsupportedKey
+	}
+
+	return NewSigningKey(signer)
+}
+
+// NewSigningKey wraps the provided RSA or Ed25519 private key, deriving its
+// ID and signing algorithm.
+func NewSigningKey(key crypto.Signer) (SigningKey, error) {
+	var alg string
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return SigningKey{}, ErrUnsupportedKey
+		}
+		alg = AlgRS256
+	case ed25519.PrivateKey:
+		alg = AlgEdDSA
+	default:
+		return SigningKey{}, ErrUnsupportedKey
+	}
+
+	id, err := thumbprint(key.Public())
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{
+		ID:        id,
+		Algorithm: alg,
+		Key:       key,
+	}, nil
+}
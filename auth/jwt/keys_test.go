@@ -0,0 +1,111 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/auth/jwt"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating Ed25519 key expected to succeed: %s", err))
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.Nil(t, err, fmt.Sprintf("marshaling Ed25519 key expected to succeed: %s", err))
+	rsaDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.Nil(t, err, fmt.Sprintf("marshaling RSA key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		data []byte
+		alg  string
+		err  error
+	}{
+		{
+			desc: "parse PKCS #1 RSA key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			alg:  jwt.AlgRS256,
+			err:  nil,
+		},
+		{
+			desc: "parse PKCS #8 RSA key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: rsaDER}),
+			alg:  jwt.AlgRS256,
+			err:  nil,
+		},
+		{
+			desc: "parse PKCS #8 Ed25519 key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER}),
+			alg:  jwt.AlgEdDSA,
+			err:  nil,
+		},
+		{
+			desc: "parse short RSA key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(weakKey)}),
+			err:  jwt.ErrUnsupportedKey,
+		},
+		{
+			desc: "parse public key",
+			data: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: edDER}),
+			err:  jwt.ErrUnsupportedKey,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := jwt.ParseKey(tc.data)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.alg, key.Algorithm, fmt.Sprintf("%s expected algorithm %s, got %s", tc.desc, tc.alg, key.Algorithm))
+	}
+
+	_, err = jwt.ParseKey([]byte("invalid"))
+	assert.NotNil(t, err, "parse invalid key expected to fail")
+}
+
+func TestJWK(t *testing.T) {
+	ed, rs := signingKeys(t)
+
+	for _, sk := range []jwt.SigningKey{ed, rs} {
+		jwk, err := jwt.NewJWK(sk.PublicKey())
+		require.Nil(t, err, fmt.Sprintf("%s: converting key to JWK expected to succeed: %s", sk.Algorithm, err))
+		assert.Equal(t, sk.ID, jwk.ID, fmt.Sprintf("%s: expected key ID %s, got %s", sk.Algorithm, sk.ID, jwk.ID))
+
+		pk, err := jwk.PublicKey()
+		require.Nil(t, err, fmt.Sprintf("%s: converting JWK to key expected to succeed: %s", sk.Algorithm, err))
+		assert.Equal(t, sk.PublicKey(), pk, fmt.Sprintf("%s: expected key %v, got %v", sk.Algorithm, sk.PublicKey(), pk))
+	}
+
+	// RFC 8037, Appendix A.3.
+	x, err := jwt.JWK{
+		KeyType:   "OKP",
+		ID:        "kid",
+		Algorithm: jwt.AlgEdDSA,
+		Curve:     "Ed25519",
+		X:         "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}.PublicKey()
+	require.Nil(t, err, fmt.Sprintf("converting JWK to key expected to succeed: %s", err))
+	seed, err := base64.RawURLEncoding.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+	require.Nil(t, err, fmt.Sprintf("decoding seed expected to succeed: %s", err))
+	sk, err := jwt.NewSigningKey(ed25519.NewKeyFromSeed(seed))
+	require.Nil(t, err, fmt.Sprintf("creating signing key expected to succeed: %s", err))
+	assert.Equal(t, x.Key, sk.PublicKey().Key, "expected public key of RFC 8037 example")
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", sk.ID, "expected key ID to be RFC 8037 example thumbprint")
+
+	_, err = jwt.JWK{KeyType: "OKP", ID: "kid", Algorithm: jwt.AlgRS256, Curve: "Ed25519", X: ed.ID}.PublicKey()
+	assert.True(t, errors.Contains(err, jwt.ErrUnsupportedKey), fmt.Sprintf("converting JWK with mismatched algorithm expected %s, got %s", jwt.ErrUnsupportedKey, err))
+}
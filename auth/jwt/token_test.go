@@ -4,6 +4,9 @@
 package jwt_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"testing"
 	"time"
@@ -67,6 +70,17 @@ func TestParse(t *testing.T) {
 	expToken, err := tokenizer.Issue(expKey)
 	require.Nil(t, err, fmt.Sprintf("issuing expired key expected to succeed: %s", err))
 
+	refreshKey := key()
+	refreshKey.Type = auth.RefreshKey
+	refreshKey.SessionID = "session"
+	refreshToken, err := tokenizer.Issue(refreshKey)
+	require.Nil(t, err, fmt.Sprintf("issuing refresh key expected to succeed: %s", err))
+
+	preAuthKey := key()
+	preAuthKey.Type = auth.PreAuthKey
+	preAuthToken, err := tokenizer.Issue(preAuthKey)
+	require.Nil(t, err, fmt.Sprintf("issuing pre-auth key expected to succeed: %s", err))
+
 	cases := []struct {
 		desc  string
 		key   auth.Key
@@ -79,6 +93,18 @@ func TestParse(t *testing.T) {
 			token: token,
 			err:   nil,
 		},
+		{
+			desc:  "parse valid refresh key",
+			key:   refreshKey,
+			token: refreshToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse valid pre-auth key",
+			key:   preAuthKey,
+			token: preAuthToken,
+			err:   nil,
+		},
 		{
 			desc:  "parse ivalid key",
 			key:   auth.Key{},
@@ -105,3 +131,139 @@ func TestParse(t *testing.T) {
 		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
 	}
 }
+
+func signingKeys(t *testing.T) (jwt.SigningKey, jwt.SigningKey) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating Ed25519 key expected to succeed: %s", err))
+	ed, err := jwt.NewSigningKey(edKey)
+	require.Nil(t, err, fmt.Sprintf("creating Ed25519 signing key expected to succeed: %s", err))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating RSA key expected to succeed: %s", err))
+	rs, err := jwt.NewSigningKey(rsaKey)
+	require.Nil(t, err, fmt.Sprintf("creating RSA signing key expected to succeed: %s", err))
+
+	return ed, rs
+}
+
+func TestAsymmetricParse(t *testing.T) {
+	ed, rs := signingKeys(t)
+
+	edTokenizer, err := jwt.NewAsymmetric("", ed)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	edToken, err := edTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	rsTokenizer, err := jwt.NewAsymmetric("", rs)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	rsToken, err := rsTokenizer.Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	hsToken, err := jwt.New(secret).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	expKey := key()
+	expKey.ExpiresAt = time.Now().UTC().Add(-1 * time.Minute).Round(time.Second)
+	expToken, err := edTokenizer.Issue(expKey)
+	require.Nil(t, err, fmt.Sprintf("issuing expired key expected to succeed: %s", err))
+
+	// The new RSA key signs tokens, while the old Ed25519 key still
+	// verifies tokens issued before the rotation.
+	rotated, err := jwt.NewAsymmetric("", rs, ed)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	// Tokens issued before switching from the shared secret to the
+	// signing keys are still verified using the secret.
+	switched, err := jwt.NewAsymmetric(secret, ed)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	otherToken, err := jwt.New("other").Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc      string
+		tokenizer auth.Tokenizer
+		key       auth.Key
+		token     string
+		err       error
+	}{
+		{
+			desc:      "parse valid EdDSA key",
+			tokenizer: edTokenizer,
+			key:       key(),
+			token:     edToken,
+			err:       nil,
+		},
+		{
+			desc:      "parse valid RS256 key",
+			tokenizer: rsTokenizer,
+			key:       key(),
+			token:     rsToken,
+			err:       nil,
+		},
+		{
+			desc:      "parse key signed with the old key after rotation",
+			tokenizer: rotated,
+			key:       key(),
+			token:     edToken,
+			err:       nil,
+		},
+		{
+			desc:      "parse key signed with the new key after rotation",
+			tokenizer: rotated,
+			key:       key(),
+			token:     rsToken,
+			err:       nil,
+		},
+		{
+			desc:      "parse key signed with unknown key",
+			tokenizer: rsTokenizer,
+			key:       auth.Key{},
+			token:     edToken,
+			err:       auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "parse key signed with shared secret",
+			tokenizer: edTokenizer,
+			key:       auth.Key{},
+			token:     hsToken,
+			err:       auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "parse key signed with shared secret after switching to signing keys",
+			tokenizer: switched,
+			key:       key(),
+			token:     hsToken,
+			err:       nil,
+		},
+		{
+			desc:      "parse key signed with signing key after switching to signing keys",
+			tokenizer: switched,
+			key:       key(),
+			token:     edToken,
+			err:       nil,
+		},
+		{
+			desc:      "parse key signed with other shared secret after switching to signing keys",
+			tokenizer: switched,
+			key:       auth.Key{},
+			token:     otherToken,
+			err:       auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:      "parse expired key",
+			tokenizer: edTokenizer,
+			key:       auth.Key{},
+			token:     expToken,
+			err:       auth.ErrKeyExpired,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := tc.tokenizer.Parse(tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
+	}
+
+	assert.Len(t, rotated.PublicKeys(), 2, "expected both keys of rotated tokenizer to be published")
+	assert.Empty(t, jwt.New(secret).PublicKeys(), "expected no keys of shared secret tokenizer to be published")
+}
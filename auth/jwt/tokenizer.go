@@ -15,58 +15,173 @@ const issuerName = "mainflux.auth"
 
 type claims struct {
 	jwt.StandardClaims
-	IssuerID string  `json:"issuer_id,omitempty"`
-	Type     *uint32 `json:"type,omitempty"`
+	IssuerID  string  `json:"issuer_id,omitempty"`
+	Type      *uint32 `json:"type,omitempty"`
+	SessionID string  `json:"session_id,omitempty"`
 }
 
 func (c claims) Valid() error {
-	if c.Type == nil || *c.Type > auth.APIKey || c.Issuer != issuerName {
+	if c.Type == nil || *c.Type > auth.PreAuthKey || c.Issuer != issuerName {
 		return auth.ErrMalformedEntity
 	}
 
 	return c.StandardClaims.Valid()
 }
 
+var (
+	errNoKeys       = errors.New("no signing keys provided")
+	errDuplicateKey = errors.New("duplicate signing key")
+)
+
+// KeyFunc returns the public key identified by the provided key ID.
+type KeyFunc func(id string) (auth.PublicKey, error)
+
 type tokenizer struct {
 	secret string
 }
 
-// New returns new JWT Tokenizer.
+// New returns new JWT Tokenizer that signs tokens using HMAC with the
+// provided shared secret.
 func New(secret string) auth.Tokenizer {
 	return tokenizer{secret: secret}
 }
 
 func (svc tokenizer) Issue(key auth.Key) (string, error) {
-	claims := claims{
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims(key))
+	return token.SignedString([]byte(svc.secret))
+}
+
+func (svc tokenizer) Parse(token string) (auth.Key, error) {
+	return parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, auth.ErrUnauthorizedAccess
+		}
+		return []byte(svc.secret), nil
+	})
+}
+
+func (svc tokenizer) PublicKeys() []auth.PublicKey {
+	return nil
+}
+
+type asymmetricTokenizer struct {
+	signer SigningKey
+	keys   map[string]auth.PublicKey
+	public []auth.PublicKey
+	secret string
+}
+
+// NewAsymmetric returns new JWT Tokenizer that signs tokens using the first
+// of the provided keys and verifies tokens signed with any of them. Keys
+// other than the first one are used for rotation: a new key is published
+// before it starts signing tokens, and an old key keeps verifying tokens
+// until they expire. If the secret is not empty, tokens signed using HMAC
+// with it, issued before switching to the signing keys, are verified too.
+func NewAsymmetric(secret string, keys ...SigningKey) (auth.Tokenizer, error) {
+	if len(keys) == 0 {
+		return nil, errNoKeys
+	}
+
+	tok := asymmetricTokenizer{
+		signer: keys[0],
+		keys:   make(map[string]auth.PublicKey),
+		secret: secret,
+	}
+	for _, k := range keys {
+		if _, ok := tok.keys[k.ID]; ok {
+			return nil, errDuplicateKey
+		}
+		pk := k.PublicKey()
+		tok.keys[k.ID] = pk
+		tok.public = append(tok.public, pk)
+	}
+
+	return tok, nil
+}
+
+func (svc asymmetricTokenizer) Issue(key auth.Key) (string, error) {
+	method := jwt.GetSigningMethod(svc.signer.Algorithm)
+	if method == nil {
+		return "", ErrUnsupportedKey
+	}
+
+	token := jwt.NewWithClaims(method, newClaims(key))
+	token.Header["kid"] = svc.signer.ID
+	return token.SignedString(svc.signer.Key)
+}
+
+func (svc asymmetricTokenizer) Parse(token string) (auth.Key, error) {
+	verify := verifyFunc(func(id string) (auth.PublicKey, error) {
+		pk, ok := svc.keys[id]
+		if !ok {
+			return auth.PublicKey{}, auth.ErrUnauthorizedAccess
+		}
+		return pk, nil
+	})
+
+	return parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && svc.secret != "" {
+			return []byte(svc.secret), nil
+		}
+		return verify(token)
+	})
+}
+
+func (svc asymmetricTokenizer) PublicKeys() []auth.PublicKey {
+	return svc.public
+}
+
+// Verify parses the token signed with an asymmetric key, verifying its
+// signature using the public key that the provided function returns for
+// the token "kid" header.
+func Verify(token string, keyFunc KeyFunc) (auth.Key, error) {
+	return parse(token, verifyFunc(keyFunc))
+}
+
+func verifyFunc(keyFunc KeyFunc) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		id, ok := token.Header["kid"].(string)
+		if !ok || id == "" {
+			return nil, auth.ErrUnauthorizedAccess
+		}
+		pk, err := keyFunc(id)
+		if err != nil {
+			return nil, err
+		}
+		// Prevent algorithm substitution, e.g. HMAC signature verified
+		// using the public key as a secret.
+		if pk.Algorithm == "" || token.Method.Alg() != pk.Algorithm {
+			return nil, auth.ErrUnauthorizedAccess
+		}
+		return pk.Key, nil
+	}
+}
+
+func newClaims(key auth.Key) claims {
+	c := claims{
 		StandardClaims: jwt.StandardClaims{
 			Issuer:   issuerName,
 			Subject:  key.Subject,
 			IssuedAt: key.IssuedAt.UTC().Unix(),
 		},
-		IssuerID: key.IssuerID,
-		Type:     &key.Type,
+		IssuerID:  key.IssuerID,
+		Type:      &key.Type,
+		SessionID: key.SessionID,
 	}
 
 	if !key.ExpiresAt.IsZero() {
-		claims.ExpiresAt = key.ExpiresAt.UTC().Unix()
+		c.ExpiresAt = key.ExpiresAt.UTC().Unix()
 	}
 	if key.ID != "" {
-		claims.Id = key.ID
+		c.Id = key.ID
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(svc.secret))
+	return c
 }
 
-func (svc tokenizer) Parse(token string) (auth.Key, error) {
+func parse(token string, keyFunc jwt.Keyfunc) (auth.Key, error) {
 	c := claims{}
-	_, err := jwt.ParseWithClaims(token, &c, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, auth.ErrUnauthorizedAccess
-		}
-		return []byte(svc.secret), nil
-	})
-
+	_, err := jwt.ParseWithClaims(token, &c, keyFunc)
 	if err != nil {
 		if e, ok := err.(*jwt.ValidationError); ok && e.Errors == jwt.ValidationErrorExpired {
 			// Expired User key needs to be revoked.
@@ -83,10 +198,11 @@ func (svc tokenizer) Parse(token string) (auth.Key, error) {
 
 func (c claims) toKey() auth.Key {
 	key := auth.Key{
-		ID:       c.Id,
-		IssuerID: c.IssuerID,
-		Subject:  c.Subject,
-		IssuedAt: time.Unix(c.IssuedAt, 0).UTC(),
+		ID:        c.Id,
+		IssuerID:  c.IssuerID,
+		Subject:   c.Subject,
+		IssuedAt:  time.Unix(c.IssuedAt, 0).UTC(),
+		SessionID: c.SessionID,
 	}
 	if c.ExpiresAt != 0 {
 		key.ExpiresAt = time.Unix(c.ExpiresAt, 0).UTC()
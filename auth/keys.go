@@ -19,6 +19,14 @@ var (
 	// ErrAPIKeyExpired indicates that the Key is expired
 	// and that the key type is API key.
 	ErrAPIKeyExpired = errors.New("use of expired API key")
+
+	// ErrSessionRevoked indicates that the session the Key belongs to
+	// is revoked, either by logout or by refresh token reuse.
+	ErrSessionRevoked = errors.New("use of revoked session")
+
+	// ErrOutOfScope indicates that the action is not allowed by the scope
+	// of the API key.
+	ErrOutOfScope = errors.New("action out of the key scope")
 )
 
 const (
@@ -28,6 +36,13 @@ const (
 	RecoveryKey
 	// APIKey enables the one to act on behalf of the user.
 	APIKey
+	// RefreshKey is a persisted User key used to obtain new User keys
+	// of the same session. It is rotated on every use.
+	RefreshKey
+	// PreAuthKey is a short-lived User key proving that the user passed
+	// the first login step. It can only be exchanged for the User key once
+	// the user passes the second factor (e.g. TOTP).
+	PreAuthKey
 )
 
 // Key represents API key.
@@ -38,6 +53,55 @@ type Key struct {
 	Subject   string
 	IssuedAt  time.Time
 	ExpiresAt time.Time
+	SessionID string
+
+	// Scope restricts the API key to the subset of user privileges.
+	Scope Scope
+
+	// LastUsedAt is the approximate time of the last API key use.
+	LastUsedAt time.Time
+}
+
+// Scope restricts the API key to the listed actions over the listed
+// objects (e.g. Thing or Channel IDs). Empty list of actions or objects
+// doesn't restrict them, so the empty Scope grants all user privileges.
+type Scope struct {
+	Actions []string
+	Objects []string
+}
+
+// Empty returns true if the Scope doesn't restrict anything.
+func (s Scope) Empty() bool {
+	return len(s.Actions) == 0 && len(s.Objects) == 0
+}
+
+// Allows returns true if the Scope allows the action over the object.
+func (s Scope) Allows(action, object string) bool {
+	return permits(s.Actions, action) && permits(s.Objects, object)
+}
+
+func permits(items []string, item string) bool {
+	if len(items) == 0 {
+		return true
+	}
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyPage contains a page of API keys.
+type KeyPage struct {
+	PageMetadata
+	Keys []Key
+}
+
+// Tokens contains the access and refresh token of a User session.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
 }
 
 // Identity contains ID and Email.
@@ -63,6 +127,31 @@ type KeyRepository interface {
 	// Retrieve retrieves Key by its unique identifier.
 	Retrieve(context.Context, string, string) (Key, error)
 
+	// RetrieveAll retrieves a page of API keys issued by the user
+	// identified by the provided issuer ID.
+	RetrieveAll(context.Context, string, PageMetadata) (KeyPage, error)
+
+	// UpdateLastUsed sets the time of the last use of the Key identified
+	// by the provided issuer ID and key ID.
+	UpdateLastUsed(context.Context, string, string, time.Time) error
+
 	// Remove removes Key with provided ID.
 	Remove(context.Context, string, string) error
+
+	// Rotate replaces the Key identified by the provided ID with the new
+	// Key. If the Key doesn't exist, e.g. it's already been rotated,
+	// ErrNotFound is returned and the new Key is not saved.
+	Rotate(context.Context, string, Key) error
+
+	// RetrieveSession retrieves the current Key of the session identified
+	// by the provided issuer ID and session ID.
+	RetrieveSession(context.Context, string, string) (Key, error)
+
+	// RemoveSession removes all the Keys of the session identified by the
+	// provided issuer ID and session ID.
+	RemoveSession(context.Context, string, string) error
+
+	// RemoveExpired removes the refresh Keys which expired before the
+	// provided time, returning the number of removed Keys.
+	RemoveExpired(context.Context, time.Time) (uint64, error)
 }
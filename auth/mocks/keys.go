@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/auth"
 )
@@ -45,6 +47,53 @@ func (krm *keyRepositoryMock) Retrieve(ctx context.Context, issuerID, id string)
 
 	return auth.Key{}, auth.ErrNotFound
 }
+
+func (krm *keyRepositoryMock) RetrieveAll(ctx context.Context, issuerID string, pm auth.PageMetadata) (auth.KeyPage, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	var keys []auth.Key
+	for _, key := range krm.keys {
+		if key.IssuerID == issuerID && key.Type == auth.APIKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	page := auth.KeyPage{
+		PageMetadata: auth.PageMetadata{
+			Total:  uint64(len(keys)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+	if pm.Offset >= uint64(len(keys)) {
+		return page, nil
+	}
+	end := pm.Offset + pm.Limit
+	if end > uint64(len(keys)) {
+		end = uint64(len(keys))
+	}
+	page.Keys = keys[pm.Offset:end]
+
+	return page, nil
+}
+
+func (krm *keyRepositoryMock) UpdateLastUsed(ctx context.Context, issuerID, id string, t time.Time) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	key, ok := krm.keys[id]
+	if !ok || key.IssuerID != issuerID {
+		return auth.ErrNotFound
+	}
+	key.LastUsedAt = t
+	krm.keys[id] = key
+	return nil
+}
+
 func (krm *keyRepositoryMock) Remove(ctx context.Context, issuerID, id string) error {
 	krm.mu.Lock()
 	defer krm.mu.Unlock()
@@ -53,3 +102,59 @@ func (krm *keyRepositoryMock) Remove(ctx context.Context, issuerID, id string) e
 	}
 	return nil
 }
+
+func (krm *keyRepositoryMock) Rotate(ctx context.Context, id string, key auth.Key) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	old, ok := krm.keys[id]
+	if !ok || old.IssuerID != key.IssuerID || old.SessionID != key.SessionID {
+		return auth.ErrNotFound
+	}
+	if _, ok := krm.keys[key.ID]; ok {
+		return auth.ErrConflict
+	}
+
+	delete(krm.keys, id)
+	krm.keys[key.ID] = key
+	return nil
+}
+
+func (krm *keyRepositoryMock) RetrieveSession(ctx context.Context, issuerID, sessionID string) (auth.Key, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, key := range krm.keys {
+		if key.IssuerID == issuerID && key.SessionID == sessionID {
+			return key, nil
+		}
+	}
+
+	return auth.Key{}, auth.ErrNotFound
+}
+
+func (krm *keyRepositoryMock) RemoveExpired(ctx context.Context, t time.Time) (uint64, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	var n uint64
+	for id, key := range krm.keys {
+		if key.Type == auth.RefreshKey && key.ExpiresAt.Before(t) {
+			delete(krm.keys, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (krm *keyRepositoryMock) RemoveSession(ctx context.Context, issuerID, sessionID string) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for id, key := range krm.keys {
+		if key.IssuerID == issuerID && key.SessionID == sessionID {
+			delete(krm.keys, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/auth"
+)
+
+var _ auth.PolicyRepository = (*policyRepositoryMock)(nil)
+
+type policyRepositoryMock struct {
+	mu       sync.Mutex
+	policies map[auth.Policy]auth.Policy
+}
+
+// NewPolicyRepository creates in-memory policy repository.
+func NewPolicyRepository() auth.PolicyRepository {
+	return &policyRepositoryMock{
+		policies: make(map[auth.Policy]auth.Policy),
+	}
+}
+
+func (prm *policyRepositoryMock) Save(ctx context.Context, ps ...auth.Policy) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, p := range ps {
+		if _, ok := prm.policies[policyKey(p)]; ok {
+			continue
+		}
+		prm.policies[policyKey(p)] = p
+	}
+	return nil
+}
+
+func (prm *policyRepositoryMock) Remove(ctx context.Context, ps ...auth.Policy) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, p := range ps {
+		delete(prm.policies, policyKey(p))
+	}
+	return nil
+}
+
+func (prm *policyRepositoryMock) RemoveObject(ctx context.Context, obj string) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for k, p := range prm.policies {
+		if p.Object == obj {
+			delete(prm.policies, k)
+		}
+	}
+	return nil
+}
+
+func (prm *policyRepositoryMock) RetrieveAll(ctx context.Context, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var all []auth.Policy
+	for _, p := range prm.policies {
+		if (filter.Subject == "" || filter.Subject == p.Subject) &&
+			(filter.Object == "" || filter.Object == p.Object) &&
+			(filter.Action == "" || filter.Action == p.Action) {
+			all = append(all, p)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Subject != all[j].Subject {
+			return all[i].Subject < all[j].Subject
+		}
+		if all[i].Object != all[j].Object {
+			return all[i].Object < all[j].Object
+		}
+		return all[i].Action < all[j].Action
+	})
+
+	first := pm.Offset
+	last := first + pm.Limit
+	var items []auth.Policy
+	for i, p := range all {
+		if uint64(i) >= first && uint64(i) < last {
+			items = append(items, p)
+		}
+	}
+
+	return auth.PolicyPage{
+		Policies: items,
+		PageMetadata: auth.PageMetadata{
+			Total:  uint64(len(all)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (prm *policyRepositoryMock) Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, s := range subjects {
+		for _, o := range objects {
+			for _, a := range actions {
+				if _, ok := prm.policies[auth.Policy{Subject: s, Object: o, Action: a}]; ok {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+func (prm *policyRepositoryMock) RetrieveObjects(ctx context.Context, subjects, actions []string) ([]string, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var objects []string
+	for _, s := range subjects {
+		for _, a := range actions {
+			for k := range prm.policies {
+				if k.Subject == s && k.Action == a && !seen[k.Object] {
+					seen[k.Object] = true
+					objects = append(objects, k.Object)
+				}
+			}
+		}
+	}
+	sort.Strings(objects)
+	return objects, nil
+}
+
+func policyKey(p auth.Policy) auth.Policy {
+	return auth.Policy{Subject: p.Subject, Object: p.Object, Action: p.Action}
+}
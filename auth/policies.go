@@ -0,0 +1,132 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	// ReadAction allows subject to read the object.
+	ReadAction = "read"
+
+	// WriteAction allows subject to update the object. It implies ReadAction.
+	WriteAction = "write"
+
+	// AdminAction allows subject to perform any action over the object,
+	// including management of the object policies.
+	AdminAction = "admin"
+)
+
+var (
+	// ErrSavePolicy indicates failure to persist policies.
+	ErrSavePolicy = errors.New("failed to save policies")
+
+	// ErrRemovePolicy indicates failure to remove policies.
+	ErrRemovePolicy = errors.New("failed to remove policies")
+
+	// ErrRetrievePolicies indicates failure to retrieve policies.
+	ErrRetrievePolicies = errors.New("failed to retrieve policies")
+)
+
+// impliedBy maps an action to the set of actions that grant it.
+var impliedBy = map[string][]string{
+	ReadAction:  {ReadAction, WriteAction, AdminAction},
+	WriteAction: {WriteAction, AdminAction},
+}
+
+// actions returns the action alongside with all the actions that imply it.
+func actions(act string) []string {
+	if acts, ok := impliedBy[act]; ok {
+		return acts
+	}
+	return []string{act, AdminAction}
+}
+
+// Policy represents an argument struct for making policy related
+// function calls. Policy states that Subject is allowed to perform
+// Action over the Object. Both subject and object can be auth groups,
+// in which case policy applies to all the group members.
+type Policy struct {
+	Subject   string
+	Object    string
+	Action    string
+	CreatedAt time.Time
+}
+
+// Validate returns an error if policy is missing any of its fields.
+func (p Policy) Validate() error {
+	if p.Subject == "" || p.Object == "" || p.Action == "" {
+		return ErrMalformedEntity
+	}
+	return nil
+}
+
+// PolicyPage contains a page of policies.
+type PolicyPage struct {
+	PageMetadata
+	Policies []Policy
+}
+
+// PolicyService specifies an API for policies management.
+type PolicyService interface {
+	// AddPolicies adds policies on behalf of the user identified by
+	// the provided token. User must be allowed to administer the
+	// objects of the policies.
+	AddPolicies(ctx context.Context, token string, ps ...Policy) error
+
+	// ClaimObject grants the user identified by the provided token admin
+	// rights over the object that is not covered by any policy yet. It's
+	// used by the services to register the owner of a newly created
+	// object, so it's not exposed over the public API. Claiming the object
+	// already administered by the user has no effect.
+	ClaimObject(ctx context.Context, token, obj string) error
+
+	// RemovePolicies removes policies on behalf of the user identified
+	// by the provided token. User must be allowed to administer the
+	// objects of the policies.
+	RemovePolicies(ctx context.Context, token string, ps ...Policy) error
+
+	// RemoveObjectPolicies removes all the policies of the object on behalf
+	// of the user identified by the provided token, e.g. when the object is
+	// removed. User must be allowed to administer the object.
+	RemoveObjectPolicies(ctx context.Context, token, obj string) error
+
+	// ListPolicies retrieves policies that match non-empty fields of the
+	// provided filter. If the object is not specified, only policies of
+	// the user identified by the provided token are returned.
+	ListPolicies(ctx context.Context, token string, filter Policy, pm PageMetadata) (PolicyPage, error)
+
+	// ListObjects retrieves IDs of the objects over which the user
+	// identified by the provided token is allowed to perform the action,
+	// either directly or through the groups the user belongs to.
+	ListObjects(ctx context.Context, token, act string) ([]string, error)
+}
+
+// PolicyRepository specifies policies persistence API.
+type PolicyRepository interface {
+	// Save persists policies. Already existing policies are ignored.
+	Save(ctx context.Context, ps ...Policy) error
+
+	// Remove removes policies.
+	Remove(ctx context.Context, ps ...Policy) error
+
+	// RemoveObject removes all the policies of the object.
+	RemoveObject(ctx context.Context, obj string) error
+
+	// RetrieveAll retrieves policies that match non-empty fields
+	// of the provided filter.
+	RetrieveAll(ctx context.Context, filter Policy, pm PageMetadata) (PolicyPage, error)
+
+	// Evaluate checks if any of the subjects is allowed to perform
+	// any of the actions over any of the objects.
+	Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error)
+
+	// RetrieveObjects retrieves distinct objects of the policies that allow
+	// any of the subjects to perform any of the actions.
+	RetrieveObjects(ctx context.Context, subjects, actions []string) ([]string, error)
+}
@@ -109,6 +109,57 @@ func migrateDB(db *sqlx.DB) error {
 					`DROP TRIGGER IF EXISTS inherit_group_tr ON groups`,
 				},
 			},
+			{
+				Id: "auth_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS policies (
+						subject    VARCHAR(254) NOT NULL,
+						object     VARCHAR(254) NOT NULL,
+						action     VARCHAR(64) NOT NULL,
+						created_at TIMESTAMPTZ,
+						PRIMARY KEY (subject, object, action)
+					)`,
+					`CREATE INDEX IF NOT EXISTS policies_object_idx ON policies (object)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS policies`,
+				},
+			},
+			{
+				Id: "auth_3",
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS session_id VARCHAR(254)`,
+					`CREATE INDEX IF NOT EXISTS keys_session_idx ON keys (issuer_id, session_id)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS keys_session_idx`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS session_id`,
+				},
+			},
+			{
+				Id: "auth_4",
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS scope_actions VARCHAR(64)[]`,
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS scope_objects VARCHAR(254)[]`,
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP`,
+					`CREATE INDEX IF NOT EXISTS keys_issuer_type_idx ON keys (issuer_id, type)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS keys_issuer_type_idx`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS last_used_at`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS scope_objects`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS scope_actions`,
+				},
+			},
+			{
+				Id: "auth_5",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS keys_type_expires_idx ON keys (type, expires_at)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS keys_type_expires_idx`,
+				},
+			},
 		},
 	}
 
@@ -14,6 +14,8 @@ var (
 	errSave     = errors.New("failed to save key in database")
 	errRetrieve = errors.New("failed to retrieve key from database")
 	errDelete   = errors.New("failed to delete key from database")
+	errRotate   = errors.New("failed to rotate key in database")
+	errUpdate   = errors.New("failed to update key in database")
 )
 var _ auth.KeyRepository = (*repo)(nil)
 
@@ -34,8 +36,8 @@ func New(db Database) auth.KeyRepository {
 }
 
 func (kr repo) Save(ctx context.Context, key auth.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at, session_id, scope_actions, scope_objects)
+	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at, :session_id, :scope_actions, :scope_objects)`
 
 	dbKey := toDBKey(key)
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
@@ -54,7 +56,8 @@ func (kr repo) Save(ctx context.Context, key auth.Key) (string, error) {
 }
 
 func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, error) {
-	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at FROM keys WHERE issuer_id = $1 AND id = $2`
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, session_id, scope_actions, scope_objects, last_used_at
+	      FROM keys WHERE issuer_id = $1 AND id = $2`
 	key := dbKey{}
 	if err := kr.db.QueryRowxContext(ctx, q, issuerID, id).StructScan(&key); err != nil {
 		pqErr, ok := err.(*pq.Error)
@@ -68,6 +71,77 @@ func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, err
 	return toKey(key), nil
 }
 
+func (kr repo) RetrieveAll(ctx context.Context, issuerID string, pm auth.PageMetadata) (auth.KeyPage, error) {
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, session_id, scope_actions, scope_objects, last_used_at
+	      FROM keys WHERE issuer_id = :issuer_id AND type = :type ORDER BY issued_at DESC, id LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"issuer_id": issuerID,
+		"type":      auth.APIKey,
+		"limit":     pm.Limit,
+		"offset":    pm.Offset,
+	}
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return auth.KeyPage{PageMetadata: pm}, nil
+		}
+		return auth.KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+	defer rows.Close()
+
+	var keys []auth.Key
+	for rows.Next() {
+		key := dbKey{}
+		if err := rows.StructScan(&key); err != nil {
+			return auth.KeyPage{}, errors.Wrap(errRetrieve, err)
+		}
+		keys = append(keys, toKey(key))
+	}
+
+	cq := `SELECT COUNT(*) FROM keys WHERE issuer_id = :issuer_id AND type = :type`
+	total, err := total(ctx, kr.db, cq, params)
+	if err != nil {
+		return auth.KeyPage{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return auth.KeyPage{
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Keys: keys,
+	}, nil
+}
+
+func (kr repo) UpdateLastUsed(ctx context.Context, issuerID, id string, t time.Time) error {
+	q := `UPDATE keys SET last_used_at = :last_used_at WHERE issuer_id = :issuer_id AND id = :id`
+	key := dbKey{
+		ID:         id,
+		IssuerID:   issuerID,
+		LastUsedAt: sql.NullTime{Time: t, Valid: true},
+	}
+	res, err := kr.db.NamedExecContext(ctx, q, key)
+	if err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok && errInvalid == pqErr.Code.Name() {
+			return errors.Wrap(auth.ErrNotFound, err)
+		}
+		return errors.Wrap(errUpdate, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdate, err)
+	}
+	if cnt != 1 {
+		return auth.ErrNotFound
+	}
+
+	return nil
+}
+
 func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
 	q := `DELETE FROM keys WHERE issuer_id = :issuer_id AND id = :id`
 	key := dbKey{
@@ -81,14 +155,113 @@ func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
 	return nil
 }
 
+func (kr repo) Rotate(ctx context.Context, id string, key auth.Key) error {
+	tx, err := kr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errRotate, err)
+	}
+
+	dbKey := toDBKey(key)
+	qDel := `DELETE FROM keys WHERE issuer_id = :issuer_id AND session_id = :session_id AND id = :id`
+	old := dbKey
+	old.ID = id
+	res, err := tx.NamedExecContext(ctx, qDel, old)
+	if err != nil {
+		tx.Rollback()
+		pqErr, ok := err.(*pq.Error)
+		if ok && pqErr.Code.Name() == errInvalid {
+			return errors.Wrap(auth.ErrNotFound, err)
+		}
+		return errors.Wrap(errRotate, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(errRotate, err)
+	}
+	if cnt != 1 {
+		tx.Rollback()
+		return auth.ErrNotFound
+	}
+
+	qIns := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at, session_id, scope_actions, scope_objects)
+	         VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at, :session_id, :scope_actions, :scope_objects)`
+	if _, err := tx.NamedExecContext(ctx, qIns, dbKey); err != nil {
+		tx.Rollback()
+		pqErr, ok := err.(*pq.Error)
+		if ok && pqErr.Code.Name() == errDuplicate {
+			return errors.Wrap(auth.ErrConflict, pqErr)
+		}
+		return errors.Wrap(errRotate, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errRotate, err)
+	}
+
+	return nil
+}
+
+func (kr repo) RetrieveSession(ctx context.Context, issuerID, sessionID string) (auth.Key, error) {
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, session_id, scope_actions, scope_objects, last_used_at
+	      FROM keys WHERE issuer_id = $1 AND session_id = $2 ORDER BY issued_at DESC LIMIT 1`
+	key := dbKey{}
+	if err := kr.db.QueryRowxContext(ctx, q, issuerID, sessionID).StructScan(&key); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if err == sql.ErrNoRows || ok && errInvalid == pqErr.Code.Name() {
+			return auth.Key{}, errors.Wrap(auth.ErrNotFound, err)
+		}
+
+		return auth.Key{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return toKey(key), nil
+}
+
+func (kr repo) RemoveSession(ctx context.Context, issuerID, sessionID string) error {
+	q := `DELETE FROM keys WHERE issuer_id = :issuer_id AND session_id = :session_id`
+	key := dbKey{
+		IssuerID:  issuerID,
+		SessionID: sql.NullString{String: sessionID, Valid: true},
+	}
+	if _, err := kr.db.NamedExecContext(ctx, q, key); err != nil {
+		return errors.Wrap(errDelete, err)
+	}
+
+	return nil
+}
+
+func (kr repo) RemoveExpired(ctx context.Context, t time.Time) (uint64, error) {
+	q := `DELETE FROM keys WHERE type = :type AND expires_at < :expires_at`
+	key := dbKey{
+		Type:      auth.RefreshKey,
+		ExpiresAt: sql.NullTime{Time: t, Valid: true},
+	}
+	res, err := kr.db.NamedExecContext(ctx, q, key)
+	if err != nil {
+		return 0, errors.Wrap(errDelete, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(errDelete, err)
+	}
+
+	return uint64(cnt), nil
+}
+
 type dbKey struct {
-	ID        string       `db:"id"`
-	Type      uint32       `db:"type"`
-	IssuerID  string       `db:"issuer_id"`
-	Subject   string       `db:"subject"`
-	Revoked   bool         `db:"revoked"`
-	IssuedAt  time.Time    `db:"issued_at"`
-	ExpiresAt sql.NullTime `db:"expires_at"`
+	ID        string         `db:"id"`
+	Type      uint32         `db:"type"`
+	IssuerID  string         `db:"issuer_id"`
+	Subject   string         `db:"subject"`
+	Revoked   bool           `db:"revoked"`
+	IssuedAt  time.Time      `db:"issued_at"`
+	ExpiresAt sql.NullTime   `db:"expires_at"`
+	SessionID sql.NullString `db:"session_id"`
+
+	ScopeActions pq.StringArray `db:"scope_actions"`
+	ScopeObjects pq.StringArray `db:"scope_objects"`
+	LastUsedAt   sql.NullTime   `db:"last_used_at"`
 }
 
 func toDBKey(key auth.Key) dbKey {
@@ -102,6 +275,18 @@ func toDBKey(key auth.Key) dbKey {
 	if !key.ExpiresAt.IsZero() {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
 	}
+	if key.SessionID != "" {
+		ret.SessionID = sql.NullString{String: key.SessionID, Valid: true}
+	}
+	if len(key.Scope.Actions) > 0 {
+		ret.ScopeActions = key.Scope.Actions
+	}
+	if len(key.Scope.Objects) > 0 {
+		ret.ScopeObjects = key.Scope.Objects
+	}
+	if !key.LastUsedAt.IsZero() {
+		ret.LastUsedAt = sql.NullTime{Time: key.LastUsedAt, Valid: true}
+	}
 
 	return ret
 }
@@ -117,6 +302,18 @@ func toKey(key dbKey) auth.Key {
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
 	}
+	if key.SessionID.Valid {
+		ret.SessionID = key.SessionID.String
+	}
+	if len(key.ScopeActions) > 0 {
+		ret.Scope.Actions = key.ScopeActions
+	}
+	if len(key.ScopeObjects) > 0 {
+		ret.Scope.Objects = key.ScopeObjects
+	}
+	if key.LastUsedAt.Valid {
+		ret.LastUsedAt = key.LastUsedAt.Time
+	}
 
 	return ret
 }
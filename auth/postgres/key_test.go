@@ -158,3 +158,247 @@ func TestKeyRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestKeyRotate(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	issuerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	sessionID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	newKey := func() auth.Key {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		return auth.Key{
+			ID:        id,
+			Type:      auth.RefreshKey,
+			Subject:   email,
+			IssuerID:  issuerID,
+			IssuedAt:  time.Now(),
+			ExpiresAt: expTime,
+			SessionID: sessionID,
+		}
+	}
+
+	key := newKey()
+	_, err = repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	rotated := newKey()
+	cases := []struct {
+		desc string
+		id   string
+		key  auth.Key
+		err  error
+	}{
+		{
+			desc: "rotate an existing key",
+			id:   key.ID,
+			key:  rotated,
+			err:  nil,
+		},
+		{
+			desc: "rotate already rotated key",
+			id:   key.ID,
+			key:  newKey(),
+			err:  auth.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Rotate(context.Background(), tc.id, tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	current, err := repo.RetrieveSession(context.Background(), issuerID, sessionID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve session: got unexpected error: %s", err))
+	assert.Equal(t, rotated.ID, current.ID, fmt.Sprintf("retrieve session: expected key %s got %s\n", rotated.ID, current.ID))
+}
+
+func TestKeyRemoveSession(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	key := auth.Key{
+		ID:        id,
+		Type:      auth.RefreshKey,
+		Subject:   email,
+		IssuerID:  id,
+		IssuedAt:  time.Now(),
+		ExpiresAt: expTime,
+		SessionID: id,
+	}
+	_, err = repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	err = repo.RemoveSession(context.Background(), key.IssuerID, key.SessionID)
+	assert.Nil(t, err, fmt.Sprintf("remove session: got unexpected error: %s", err))
+
+	_, err = repo.RetrieveSession(context.Background(), key.IssuerID, key.SessionID)
+	assert.True(t, errors.Contains(err, auth.ErrNotFound), fmt.Sprintf("retrieve removed session: expected %s got %s\n", auth.ErrNotFound, err))
+}
+
+func TestKeyRemoveExpired(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	issuerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	keys := map[string]auth.Key{}
+	for desc, k := range map[string]auth.Key{
+		"expired refresh key": {Type: auth.RefreshKey, ExpiresAt: now.Add(-time.Hour)},
+		"active refresh key":  {Type: auth.RefreshKey, ExpiresAt: now.Add(time.Hour)},
+		"expired API key":     {Type: auth.APIKey, ExpiresAt: now.Add(-time.Hour)},
+	} {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		k.ID = id
+		k.Subject = email
+		k.IssuerID = issuerID
+		k.IssuedAt = now.Add(-2 * time.Hour)
+		k.SessionID = id
+		_, err = repo.Save(context.Background(), k)
+		require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+		keys[desc] = k
+	}
+
+	_, err = repo.RemoveExpired(context.Background(), now)
+	assert.Nil(t, err, fmt.Sprintf("remove expired keys: got unexpected error: %s", err))
+
+	cases := map[string]error{
+		"expired refresh key": auth.ErrNotFound,
+		"active refresh key":  nil,
+		"expired API key":     nil,
+	}
+	for desc, expected := range cases {
+		_, err := repo.Retrieve(context.Background(), issuerID, keys[desc].ID)
+		assert.True(t, errors.Contains(err, expected), fmt.Sprintf("retrieve %s: expected %s got %s\n", desc, expected, err))
+	}
+}
+
+func TestKeyRetrieveAll(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	issuerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	scope := auth.Scope{Actions: []string{auth.ReadAction}, Objects: []string{issuerID}}
+	for i := uint64(0); i < n; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key := auth.Key{
+			ID:        id,
+			Type:      auth.APIKey,
+			Subject:   email,
+			IssuerID:  issuerID,
+			IssuedAt:  time.Now(),
+			ExpiresAt: expTime,
+			Scope:     scope,
+		}
+		_, err = repo.Save(context.Background(), key)
+		require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), auth.Key{ID: id, Type: auth.RefreshKey, Subject: email, IssuerID: issuerID, IssuedAt: time.Now(), ExpiresAt: expTime, SessionID: id})
+	require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		issuer string
+		pm     auth.PageMetadata
+		size   uint64
+		total  uint64
+	}{
+		{
+			desc:   "retrieve all API keys",
+			issuer: issuerID,
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   n,
+			total:  n,
+		},
+		{
+			desc:   "retrieve a page of API keys",
+			issuer: issuerID,
+			pm:     auth.PageMetadata{Offset: n / 2, Limit: n},
+			size:   n / 2,
+			total:  n,
+		},
+		{
+			desc:   "retrieve API keys of unknown issuer",
+			issuer: "unknown",
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.issuer, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, uint64(len(page.Keys)), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		for _, k := range page.Keys {
+			assert.Equal(t, scope, k.Scope, fmt.Sprintf("%s: expected scope %v got %v\n", tc.desc, scope, k.Scope))
+		}
+	}
+}
+
+func TestKeyUpdateLastUsed(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	key := auth.Key{
+		ID:        id,
+		Type:      auth.APIKey,
+		Subject:   email,
+		IssuerID:  id,
+		IssuedAt:  time.Now(),
+		ExpiresAt: expTime,
+	}
+	_, err = repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	cases := []struct {
+		desc  string
+		id    string
+		owner string
+		err   error
+	}{
+		{
+			desc:  "update last use of an existing key",
+			id:    key.ID,
+			owner: key.IssuerID,
+			err:   nil,
+		},
+		{
+			desc:  "update last use of unknown key",
+			id:    "unknown",
+			owner: key.IssuerID,
+			err:   auth.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateLastUsed(context.Background(), tc.owner, tc.id, now)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), key.IssuerID, key.ID)
+	require.Nil(t, err, fmt.Sprintf("Retrieving Key expected to succeed: %s", err))
+	assert.Equal(t, now, saved.LastUsedAt.UTC(), fmt.Sprintf("expected last used at %s got %s\n", now, saved.LastUsedAt))
+}
@@ -0,0 +1,203 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var _ auth.PolicyRepository = (*policyRepository)(nil)
+
+type policyRepository struct {
+	db Database
+}
+
+// NewPolicyRepo instantiates a PostgreSQL implementation of policy
+// repository.
+func NewPolicyRepo(db Database) auth.PolicyRepository {
+	return &policyRepository{
+		db: db,
+	}
+}
+
+func (pr policyRepository) Save(ctx context.Context, ps ...auth.Policy) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(auth.ErrSavePolicy, err)
+	}
+
+	q := `INSERT INTO policies (subject, object, action, created_at)
+		  VALUES (:subject, :object, :action, :created_at)
+		  ON CONFLICT (subject, object, action) DO NOTHING`
+
+	for _, p := range ps {
+		if _, err := tx.NamedExecContext(ctx, q, toDBPolicy(p)); err != nil {
+			tx.Rollback()
+			pqErr, ok := err.(*pq.Error)
+			if ok {
+				switch pqErr.Code.Name() {
+				case errInvalid, errTruncation:
+					return errors.Wrap(auth.ErrMalformedEntity, err)
+				}
+			}
+			return errors.Wrap(auth.ErrSavePolicy, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(auth.ErrSavePolicy, err)
+	}
+	return nil
+}
+
+func (pr policyRepository) Remove(ctx context.Context, ps ...auth.Policy) error {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(auth.ErrRemovePolicy, err)
+	}
+
+	q := `DELETE FROM policies WHERE subject = :subject AND object = :object AND action = :action`
+
+	for _, p := range ps {
+		if _, err := tx.NamedExecContext(ctx, q, toDBPolicy(p)); err != nil {
+			tx.Rollback()
+			return errors.Wrap(auth.ErrRemovePolicy, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(auth.ErrRemovePolicy, err)
+	}
+	return nil
+}
+
+func (pr policyRepository) RemoveObject(ctx context.Context, obj string) error {
+	q := `DELETE FROM policies WHERE object = :object`
+
+	if _, err := pr.db.NamedExecContext(ctx, q, map[string]interface{}{"object": obj}); err != nil {
+		return errors.Wrap(auth.ErrRemovePolicy, err)
+	}
+	return nil
+}
+
+func (pr policyRepository) RetrieveAll(ctx context.Context, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	var conds []string
+	if filter.Subject != "" {
+		conds = append(conds, "subject = :subject")
+	}
+	if filter.Object != "" {
+		conds = append(conds, "object = :object")
+	}
+	if filter.Action != "" {
+		conds = append(conds, "action = :action")
+	}
+	var wq string
+	if len(conds) > 0 {
+		wq = fmt.Sprintf("WHERE %s", strings.Join(conds, " AND "))
+	}
+
+	q := fmt.Sprintf(`SELECT subject, object, action, created_at FROM policies %s
+		  ORDER BY subject, object, action LIMIT :limit OFFSET :offset`, wq)
+
+	params := map[string]interface{}{
+		"subject": filter.Subject,
+		"object":  filter.Object,
+		"action":  filter.Action,
+		"limit":   pm.Limit,
+		"offset":  pm.Offset,
+	}
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.PolicyPage{}, errors.Wrap(auth.ErrRetrievePolicies, err)
+	}
+	defer rows.Close()
+
+	var items []auth.Policy
+	for rows.Next() {
+		dbp := dbPolicy{}
+		if err := rows.StructScan(&dbp); err != nil {
+			return auth.PolicyPage{}, errors.Wrap(auth.ErrRetrievePolicies, err)
+		}
+		items = append(items, toPolicy(dbp))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM policies %s`, wq)
+	total, err := total(ctx, pr.db, cq, params)
+	if err != nil {
+		return auth.PolicyPage{}, errors.Wrap(auth.ErrRetrievePolicies, err)
+	}
+
+	return auth.PolicyPage{
+		Policies: items,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (pr policyRepository) Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error) {
+	q := `SELECT EXISTS (SELECT 1 FROM policies
+		  WHERE subject = ANY($1) AND object = ANY($2) AND action = ANY($3))`
+
+	var allowed bool
+	if err := pr.db.QueryRowxContext(ctx, q, pq.Array(subjects), pq.Array(objects), pq.Array(actions)).Scan(&allowed); err != nil {
+		return false, errors.Wrap(auth.ErrRetrievePolicies, err)
+	}
+	return allowed, nil
+}
+
+func (pr policyRepository) RetrieveObjects(ctx context.Context, subjects, actions []string) ([]string, error) {
+	q := `SELECT DISTINCT object FROM policies WHERE subject = ANY($1) AND action = ANY($2)`
+
+	rows, err := pr.db.QueryxContext(ctx, q, pq.Array(subjects), pq.Array(actions))
+	if err != nil {
+		return nil, errors.Wrap(auth.ErrRetrievePolicies, err)
+	}
+	defer rows.Close()
+
+	var objects []string
+	for rows.Next() {
+		var obj string
+		if err := rows.Scan(&obj); err != nil {
+			return nil, errors.Wrap(auth.ErrRetrievePolicies, err)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+type dbPolicy struct {
+	Subject   string    `db:"subject"`
+	Object    string    `db:"object"`
+	Action    string    `db:"action"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func toDBPolicy(p auth.Policy) dbPolicy {
+	return dbPolicy{
+		Subject:   p.Subject,
+		Object:    p.Object,
+		Action:    p.Action,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func toPolicy(dbp dbPolicy) auth.Policy {
+	return auth.Policy{
+		Subject:   dbp.Subject,
+		Object:    dbp.Object,
+		Action:    dbp.Action,
+		CreatedAt: dbp.CreatedAt,
+	}
+}
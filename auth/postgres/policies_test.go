@@ -0,0 +1,220 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicySave(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	subject, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	object, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	policy := auth.Policy{Subject: subject, Object: object, Action: auth.ReadAction, CreatedAt: time.Now()}
+
+	cases := []struct {
+		desc   string
+		policy auth.Policy
+		err    error
+	}{
+		{
+			desc:   "save new policy",
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:   "save existing policy",
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:   "save policy with invalid action",
+			policy: auth.Policy{Subject: subject, Object: object, Action: strings.Repeat("a", 65)},
+			err:    auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.policy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPolicyRetrieveAll(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	subject, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		object, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		err = repo.Save(context.Background(),
+			auth.Policy{Subject: subject, Object: object, Action: auth.ReadAction},
+			auth.Policy{Subject: subject, Object: object, Action: auth.WriteAction})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		filter auth.Policy
+		pm     auth.PageMetadata
+		size   int
+		total  uint64
+	}{
+		{
+			desc:   "retrieve all policies",
+			filter: auth.Policy{},
+			pm:     auth.PageMetadata{Offset: 0, Limit: 2 * n},
+			size:   int(2 * n),
+			total:  2 * n,
+		},
+		{
+			desc:   "retrieve policies by subject and action",
+			filter: auth.Policy{Subject: subject, Action: auth.ReadAction},
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   int(n),
+			total:  n,
+		},
+		{
+			desc:   "retrieve last page of policies",
+			filter: auth.Policy{Subject: subject},
+			pm:     auth.PageMetadata{Offset: 2*n - 3, Limit: n},
+			size:   3,
+			total:  2 * n,
+		},
+		{
+			desc:   "retrieve policies of unknown subject",
+			filter: auth.Policy{Subject: "unknown"},
+			pm:     auth.PageMetadata{Offset: 0, Limit: n},
+			size:   0,
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.filter, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Policies), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Policies)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestPolicyRemove(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	subject, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	object, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	policy := auth.Policy{Subject: subject, Object: object, Action: auth.WriteAction}
+	err = repo.Save(context.Background(), policy)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), policy)
+	assert.Nil(t, err, fmt.Sprintf("remove policy: unexpected error: %s", err))
+
+	page, err := repo.RetrieveAll(context.Background(), auth.Policy{Subject: subject}, auth.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve policies: unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("retrieve policies: expected 0 got %d", page.Total))
+}
+
+func TestPolicyRemoveObject(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	subject, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	object, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	other, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Save(context.Background(),
+		auth.Policy{Subject: subject, Object: object, Action: auth.ReadAction},
+		auth.Policy{Subject: subject, Object: object, Action: auth.AdminAction},
+		auth.Policy{Subject: subject, Object: other, Action: auth.ReadAction})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.RemoveObject(context.Background(), object)
+	assert.Nil(t, err, fmt.Sprintf("remove object policies: unexpected error: %s", err))
+
+	page, err := repo.RetrieveAll(context.Background(), auth.Policy{Subject: subject}, auth.PageMetadata{Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("retrieve policies: unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("retrieve policies: expected 1 got %d", page.Total))
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	t.Cleanup(func() { cleanUpPolicies(t) })
+	repo := postgres.NewPolicyRepo(postgres.NewDatabase(db))
+
+	subject, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	object, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	group := generateGroupID(t)
+
+	err = repo.Save(context.Background(), auth.Policy{Subject: group, Object: object, Action: auth.WriteAction})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		subjects []string
+		objects  []string
+		actions  []string
+		allowed  bool
+	}{
+		{
+			desc:     "evaluate policy of a group subject",
+			subjects: []string{subject, group},
+			objects:  []string{object},
+			actions:  []string{auth.WriteAction},
+			allowed:  true,
+		},
+		{
+			desc:     "evaluate policy without a group subject",
+			subjects: []string{subject},
+			objects:  []string{object},
+			actions:  []string{auth.WriteAction},
+			allowed:  false,
+		},
+		{
+			desc:     "evaluate policy with other action",
+			subjects: []string{subject, group},
+			objects:  []string{object},
+			actions:  []string{auth.AdminAction},
+			allowed:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed, err := repo.Evaluate(context.Background(), tc.subjects, tc.objects, tc.actions)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
+
+func cleanUpPolicies(t *testing.T) {
+	_, err := db.Exec("delete from policies")
+	require.Nil(t, err, fmt.Sprintf("clean policies unexpected error: %s", err))
+}
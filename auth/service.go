@@ -13,8 +13,16 @@ import (
 )
 
 const (
-	loginDuration    = 10 * time.Hour
+	accessDuration   = 15 * time.Minute
+	refreshDuration  = 24 * time.Hour
 	recoveryDuration = 5 * time.Minute
+	preAuthDuration  = 5 * time.Minute
+
+	// lastUsedInterval limits how often the last use of an API key is
+	// persisted, so that every request doesn't update the key.
+	lastUsedInterval = time.Minute
+
+	membershipsLimit = 100
 )
 
 var (
@@ -51,9 +59,14 @@ var (
 
 	errIssueUser = errors.New("failed to issue new user key")
 	errIssueTmp  = errors.New("failed to issue new temporary key")
+	errLogin     = errors.New("failed to start new session")
+	errRefresh   = errors.New("failed to refresh session")
+	errLogout    = errors.New("failed to revoke session")
 	errRevoke    = errors.New("failed to remove key")
 	errRetrieve  = errors.New("failed to retrieve key data")
+	errListKeys  = errors.New("failed to list keys")
 	errIdentify  = errors.New("failed to validate token")
+	errAuthorize = errors.New("failed to evaluate policies")
 )
 
 // Authn specifies an API that must be fullfiled by the domain service
@@ -64,6 +77,24 @@ type Authn interface {
 	// Issue issues a new Key, returning its token value alongside.
 	Issue(ctx context.Context, token string, key Key) (Key, string, error)
 
+	// Login starts a new session of the user identified by the provided
+	// Key, returning a short-lived access token and a refresh token.
+	Login(ctx context.Context, key Key) (Tokens, error)
+
+	// Refresh rotates the provided refresh token, returning new tokens of
+	// the same session. A refresh token can be used only once, and its
+	// reuse revokes the whole session.
+	Refresh(ctx context.Context, refreshToken string) (Tokens, error)
+
+	// Logout revokes the session the provided access or refresh token
+	// belongs to.
+	Logout(ctx context.Context, token string) error
+
+	// RemoveExpiredKeys removes the expired refresh keys, so that the
+	// sessions which are neither refreshed nor logged out don't pile up.
+	// It returns the number of removed keys.
+	RemoveExpiredKeys(ctx context.Context) (uint64, error)
+
 	// Revoke removes the Key with the provided id that is
 	// issued by the user identified by the provided key.
 	Revoke(ctx context.Context, token, id string) error
@@ -72,17 +103,39 @@ type Authn interface {
 	// ID, that is issued by the user identified by the provided key.
 	RetrieveKey(ctx context.Context, token, id string) (Key, error)
 
+	// ListKeys retrieves a page of API keys issued by the user identified
+	// by the provided key.
+	ListKeys(ctx context.Context, token string, pm PageMetadata) (KeyPage, error)
+
 	// Identify validates token token. If token is valid, content
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
+	// Scoped API keys are rejected, since the action is unknown.
 	Identify(ctx context.Context, token string) (Identity, error)
+
+	// CanAccess validates token the same way Identify does, additionally
+	// allowing scoped API keys whose scope allows the action over the
+	// object.
+	CanAccess(ctx context.Context, token, obj, act string) (Identity, error)
+
+	// IdentifyPreAuth validates the pre-auth token issued on the first
+	// login step, returning the identity of the user completing the login.
+	// Pre-auth tokens are rejected by Identify and CanAccess.
+	IdentifyPreAuth(ctx context.Context, token string) (Identity, error)
+
+	// PublicKeys returns public keys that verify issued tokens, so they can
+	// be validated without calling the service.
+	PublicKeys(ctx context.Context) ([]PublicKey, error)
 }
 
-// Authz specifies an API for the authorization and will be implemented
+// Authz specifies an API for the authorization which is implemented
 // by evaluation of policies.
 type Authz interface {
-	// Authorize checks access rights
-	Authorize(ctx context.Context, token, sub, obj, act string) (bool, error)
+	// Authorize checks if the user identified by the provided token is
+	// allowed to perform the action over the object. Policies of the groups
+	// that user and object belong to, as well as policies of their ancestor
+	// groups, are taken into account.
+	Authorize(ctx context.Context, token, obj, act string) (bool, error)
 }
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -95,6 +148,9 @@ type Service interface {
 
 	// Implements groups API, creating groups, assigning members
 	GroupService
+
+	// Implements policies API, adding, removing and listing policies
+	PolicyService
 }
 
 var _ Service = (*service)(nil)
@@ -102,17 +158,19 @@ var _ Service = (*service)(nil)
 type service struct {
 	keys         KeyRepository
 	groups       GroupRepository
+	policies     PolicyRepository
 	idProvider   mainflux.IDProvider
 	ulidProvider mainflux.IDProvider
 	tokenizer    Tokenizer
 }
 
 // New instantiates the auth service implementation.
-func New(keys KeyRepository, groups GroupRepository, idp mainflux.IDProvider, tokenizer Tokenizer) Service {
+func New(keys KeyRepository, groups GroupRepository, policies PolicyRepository, idp mainflux.IDProvider, tokenizer Tokenizer) Service {
 	return &service{
 		tokenizer:    tokenizer,
 		keys:         keys,
 		groups:       groups,
+		policies:     policies,
 		idProvider:   idp,
 		ulidProvider: ulid.New(),
 	}
@@ -122,18 +180,118 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Key, strin
 	if key.IssuedAt.IsZero() {
 		return Key{}, "", ErrInvalidKeyIssuedAt
 	}
+	if key.Type != APIKey && !key.Scope.Empty() {
+		return Key{}, "", ErrMalformedEntity
+	}
 	switch key.Type {
 	case APIKey:
 		return svc.userKey(ctx, token, key)
 	case RecoveryKey:
 		return svc.tmpKey(recoveryDuration, key)
+	case PreAuthKey:
+		if key.IssuerID == "" || key.Subject == "" {
+			return Key{}, "", ErrMalformedEntity
+		}
+		return svc.tmpKey(preAuthDuration, key)
 	default:
-		return svc.tmpKey(loginDuration, key)
+		return svc.tmpKey(accessDuration, key)
+	}
+}
+
+func (svc service) Login(ctx context.Context, key Key) (Tokens, error) {
+	if key.IssuerID == "" || key.Subject == "" {
+		return Tokens{}, ErrMalformedEntity
+	}
+	if key.IssuedAt.IsZero() {
+		return Tokens{}, ErrInvalidKeyIssuedAt
+	}
+
+	sessionID, err := svc.idProvider.ID()
+	if err != nil {
+		return Tokens{}, errors.Wrap(errLogin, err)
+	}
+	key.SessionID = sessionID
+
+	refresh, err := svc.refreshKey(key)
+	if err != nil {
+		return Tokens{}, errors.Wrap(errLogin, err)
+	}
+	if _, err := svc.keys.Save(ctx, refresh); err != nil {
+		return Tokens{}, errors.Wrap(errLogin, err)
+	}
+
+	tokens, err := svc.tokens(key, refresh)
+	if err != nil {
+		return Tokens{}, errors.Wrap(errLogin, err)
+	}
+
+	return tokens, nil
+}
+
+func (svc service) Refresh(ctx context.Context, refreshToken string) (Tokens, error) {
+	key, err := svc.tokenizer.Parse(refreshToken)
+	if err != nil {
+		return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if key.Type != RefreshKey || key.IssuerID == "" || key.SessionID == "" {
+		return Tokens{}, ErrUnauthorizedAccess
+	}
+
+	access := Key{
+		Type:      UserKey,
+		IssuerID:  key.IssuerID,
+		Subject:   key.Subject,
+		IssuedAt:  time.Now().UTC(),
+		SessionID: key.SessionID,
+	}
+	refresh, err := svc.refreshKey(access)
+	if err != nil {
+		return Tokens{}, errors.Wrap(errRefresh, err)
+	}
+
+	err = svc.keys.Rotate(ctx, key.ID, refresh)
+	if errors.Contains(err, ErrNotFound) {
+		// The refresh token has already been used or its session has
+		// been revoked. Either way, the session is no longer trusted.
+		if err := svc.keys.RemoveSession(ctx, key.IssuerID, key.SessionID); err != nil {
+			return Tokens{}, errors.Wrap(errRefresh, err)
+		}
+		return Tokens{}, ErrSessionRevoked
+	}
+	if err != nil {
+		return Tokens{}, errors.Wrap(errRefresh, err)
+	}
+
+	tokens, err := svc.tokens(access, refresh)
+	if err != nil {
+		return Tokens{}, errors.Wrap(errRefresh, err)
+	}
+
+	return tokens, nil
+}
+
+func (svc service) Logout(ctx context.Context, token string) error {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if (key.Type != UserKey && key.Type != RefreshKey) || key.IssuerID == "" || key.SessionID == "" {
+		return ErrUnauthorizedAccess
 	}
+
+	if err := svc.keys.RemoveSession(ctx, key.IssuerID, key.SessionID); err != nil {
+		return errors.Wrap(errLogout, err)
+	}
+
+	return nil
+}
+
+func (svc service) RemoveExpiredKeys(ctx context.Context) (uint64, error) {
+	return svc.keys.RemoveExpired(ctx, time.Now().UTC())
 }
 
 func (svc service) Revoke(ctx context.Context, token, id string) error {
-	issuerID, _, err := svc.login(token)
+	issuerID, _, err := svc.login(ctx, token)
 	if err != nil {
 		return errors.Wrap(errRevoke, err)
 	}
@@ -144,7 +302,7 @@ func (svc service) Revoke(ctx context.Context, token, id string) error {
 }
 
 func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, error) {
-	issuerID, _, err := svc.login(token)
+	issuerID, _, err := svc.login(ctx, token)
 	if err != nil {
 		return Key{}, errors.Wrap(errRetrieve, err)
 	}
@@ -152,7 +310,44 @@ func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, erro
 	return svc.keys.Retrieve(ctx, issuerID, id)
 }
 
+func (svc service) ListKeys(ctx context.Context, token string, pm PageMetadata) (KeyPage, error) {
+	issuerID, _, err := svc.login(ctx, token)
+	if err != nil {
+		return KeyPage{}, errors.Wrap(errListKeys, err)
+	}
+
+	return svc.keys.RetrieveAll(ctx, issuerID, pm)
+}
+
 func (svc service) Identify(ctx context.Context, token string) (Identity, error) {
+	return svc.identify(ctx, token, Scope.Empty)
+}
+
+func (svc service) CanAccess(ctx context.Context, token, obj, act string) (Identity, error) {
+	if obj == "" || act == "" {
+		return Identity{}, ErrMalformedEntity
+	}
+
+	return svc.identify(ctx, token, func(s Scope) bool {
+		return s.Allows(act, obj)
+	})
+}
+
+func (svc service) IdentifyPreAuth(ctx context.Context, token string) (Identity, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return Identity{}, errors.Wrap(errIdentify, err)
+	}
+	if key.Type != PreAuthKey || key.IssuerID == "" {
+		return Identity{}, ErrUnauthorizedAccess
+	}
+
+	return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+}
+
+// identify validates the token, accepting API keys whose scope is allowed
+// by the provided function.
+func (svc service) identify(ctx context.Context, token string, allowed func(Scope) bool) (Identity, error) {
 	key, err := svc.tokenizer.Parse(token)
 	if err == ErrAPIKeyExpired {
 		err = svc.keys.Remove(ctx, key.IssuerID, key.ID)
@@ -163,15 +358,118 @@ func (svc service) Identify(ctx context.Context, token string) (Identity, error)
 	}
 
 	switch key.Type {
-	case APIKey, RecoveryKey, UserKey:
+	case UserKey:
+		if err := svc.checkSession(ctx, key); err != nil {
+			return Identity{}, err
+		}
+		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+	case APIKey:
+		if err := svc.useAPIKey(ctx, key, allowed); err != nil {
+			return Identity{}, err
+		}
+		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+	case RecoveryKey:
 		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
 	default:
 		return Identity{}, ErrUnauthorizedAccess
 	}
 }
 
-func (svc service) Authorize(ctx context.Context, token, sub, obj, act string) (bool, error) {
-	return true, nil
+// useAPIKey verifies that the API key is not revoked and that its scope is
+// allowed, recording its use.
+func (svc service) useAPIKey(ctx context.Context, key Key, allowed func(Scope) bool) error {
+	saved, err := svc.keys.Retrieve(ctx, key.IssuerID, key.ID)
+	if errors.Contains(err, ErrNotFound) {
+		return ErrUnauthorizedAccess
+	}
+	if err != nil {
+		return errors.Wrap(errIdentify, err)
+	}
+	if !allowed(saved.Scope) {
+		return ErrOutOfScope
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(saved.LastUsedAt) < lastUsedInterval {
+		return nil
+	}
+	if err := svc.keys.UpdateLastUsed(ctx, key.IssuerID, key.ID, now); err != nil {
+		return errors.Wrap(errIdentify, err)
+	}
+
+	return nil
+}
+
+func (svc service) PublicKeys(ctx context.Context) ([]PublicKey, error) {
+	return svc.tokenizer.PublicKeys(), nil
+}
+
+func (svc service) Authorize(ctx context.Context, token, obj, act string) (bool, error) {
+	if obj == "" || act == "" {
+		return false, ErrMalformedEntity
+	}
+
+	user, err := svc.CanAccess(ctx, token, obj, act)
+	if errors.Contains(err, ErrOutOfScope) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return svc.authorize(ctx, user.ID, obj, act)
+}
+
+// authorize evaluates policies of the subject, the object and the groups
+// they belong to.
+func (svc service) authorize(ctx context.Context, sub, obj, act string) (bool, error) {
+	if sub == "" || obj == "" || act == "" {
+		return false, ErrMalformedEntity
+	}
+
+	subjects, err := svc.expand(ctx, sub)
+	if err != nil {
+		return false, errors.Wrap(errAuthorize, err)
+	}
+	objects, err := svc.expand(ctx, obj)
+	if err != nil {
+		return false, errors.Wrap(errAuthorize, err)
+	}
+	allowed, err := svc.policies.Evaluate(ctx, subjects, objects, actions(act))
+	if err != nil {
+		return false, errors.Wrap(errAuthorize, err)
+	}
+	return allowed, nil
+}
+
+// expand returns the provided ID alongside with IDs of all the groups
+// the entity identified by the ID belongs to, including ancestor groups.
+func (svc service) expand(ctx context.Context, id string) ([]string, error) {
+	ids := []string{id}
+	seen := map[string]bool{id: true}
+	pm := PageMetadata{Limit: membershipsLimit}
+	for {
+		gp, err := svc.groups.Memberships(ctx, id, pm)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range gp.Groups {
+			parents, err := svc.groups.RetrieveAllParents(ctx, g.ID, PageMetadata{Level: MaxLevel})
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range append(parents.Groups, g) {
+				if !seen[p.ID] {
+					seen[p.ID] = true
+					ids = append(ids, p.ID)
+				}
+			}
+		}
+		pm.Offset += uint64(len(gp.Groups))
+		if len(gp.Groups) == 0 || pm.Offset >= gp.Total {
+			return ids, nil
+		}
+	}
 }
 
 func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error) {
@@ -184,8 +482,62 @@ func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error)
 	return key, secret, nil
 }
 
+// refreshKey creates a new refresh Key of the session the provided Key
+// belongs to.
+func (svc service) refreshKey(key Key) (Key, error) {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{
+		ID:        id,
+		Type:      RefreshKey,
+		IssuerID:  key.IssuerID,
+		Subject:   key.Subject,
+		IssuedAt:  key.IssuedAt,
+		ExpiresAt: key.IssuedAt.Add(refreshDuration),
+		SessionID: key.SessionID,
+	}, nil
+}
+
+// tokens issues an access token of the session the provided refresh Key
+// belongs to, returning it alongside the refresh token.
+func (svc service) tokens(key, refresh Key) (Tokens, error) {
+	key.Type = UserKey
+	key.ExpiresAt = key.IssuedAt.Add(accessDuration)
+	access, err := svc.tokenizer.Issue(key)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	secret, err := svc.tokenizer.Issue(refresh)
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{AccessToken: access, RefreshToken: secret}, nil
+}
+
+// checkSession verifies that the session the Key belongs to is not revoked.
+// Keys that don't belong to a session are not revocable.
+func (svc service) checkSession(ctx context.Context, key Key) error {
+	if key.SessionID == "" {
+		return nil
+	}
+	_, err := svc.keys.RetrieveSession(ctx, key.IssuerID, key.SessionID)
+	if errors.Contains(err, ErrNotFound) {
+		return ErrSessionRevoked
+	}
+	if err != nil {
+		return errors.Wrap(errIdentify, err)
+	}
+
+	return nil
+}
+
 func (svc service) userKey(ctx context.Context, token string, key Key) (Key, string, error) {
-	id, sub, err := svc.login(token)
+	id, sub, err := svc.login(ctx, token)
 	if err != nil {
 		return Key{}, "", errors.Wrap(errIssueUser, err)
 	}
@@ -194,6 +546,7 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Key, str
 	if key.Subject == "" {
 		key.Subject = sub
 	}
+	key.LastUsedAt = time.Time{}
 
 	keyID, err := svc.idProvider.ID()
 	if err != nil {
@@ -213,7 +566,7 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Key, str
 	return key, secret, nil
 }
 
-func (svc service) login(token string) (string, string, error) {
+func (svc service) login(ctx context.Context, token string) (string, string, error) {
 	key, err := svc.tokenizer.Parse(token)
 	if err != nil {
 		return "", "", err
@@ -222,6 +575,9 @@ func (svc service) login(token string) (string, string, error) {
 	if key.Type != UserKey || key.IssuerID == "" {
 		return "", "", ErrUnauthorizedAccess
 	}
+	if err := svc.checkSession(ctx, key); err != nil {
+		return "", "", err
+	}
 
 	return key.IssuerID, key.Subject, nil
 }
@@ -328,6 +684,148 @@ func (svc service) ListMemberships(ctx context.Context, token string, memberID s
 	return svc.groups.Memberships(ctx, memberID, pm)
 }
 
+func (svc service) AddPolicies(ctx context.Context, token string, ps ...Policy) error {
+	if err := svc.authorizeManagement(ctx, token, ps...); err != nil {
+		return err
+	}
+
+	timestamp := getTimestmap()
+	for i := range ps {
+		ps[i].CreatedAt = timestamp
+	}
+	return svc.policies.Save(ctx, ps...)
+}
+
+func (svc service) RemovePolicies(ctx context.Context, token string, ps ...Policy) error {
+	if err := svc.authorizeManagement(ctx, token, ps...); err != nil {
+		return err
+	}
+	return svc.policies.Remove(ctx, ps...)
+}
+
+func (svc service) ClaimObject(ctx context.Context, token, obj string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if obj == "" {
+		return ErrMalformedEntity
+	}
+
+	if err := svc.canManage(ctx, user.ID, obj); err == nil {
+		return nil
+	}
+	if !svc.canClaim(ctx, obj) {
+		return ErrUnauthorizedAccess
+	}
+
+	p := Policy{Subject: user.ID, Object: obj, Action: AdminAction, CreatedAt: getTimestmap()}
+	return svc.policies.Save(ctx, p)
+}
+
+func (svc service) RemoveObjectPolicies(ctx context.Context, token, obj string) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if obj == "" {
+		return ErrMalformedEntity
+	}
+
+	page, err := svc.policies.RetrieveAll(ctx, Policy{Object: obj}, PageMetadata{Limit: 1})
+	if err != nil {
+		return errors.Wrap(ErrRemovePolicy, err)
+	}
+	if page.Total == 0 {
+		return nil
+	}
+
+	if err := svc.canManage(ctx, user.ID, obj); err != nil {
+		return err
+	}
+	return svc.policies.RemoveObject(ctx, obj)
+}
+
+func (svc service) ListPolicies(ctx context.Context, token string, filter Policy, pm PageMetadata) (PolicyPage, error) {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return PolicyPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if filter.Object == "" {
+		filter.Subject = user.ID
+		return svc.policies.RetrieveAll(ctx, filter, pm)
+	}
+
+	if err := svc.canManage(ctx, user.ID, filter.Object); err != nil {
+		return PolicyPage{}, err
+	}
+	return svc.policies.RetrieveAll(ctx, filter, pm)
+}
+
+func (svc service) ListObjects(ctx context.Context, token, act string) ([]string, error) {
+	if act == "" {
+		return nil, ErrMalformedEntity
+	}
+
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	subjects, err := svc.expand(ctx, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(ErrRetrievePolicies, err)
+	}
+	return svc.policies.RetrieveObjects(ctx, subjects, actions(act))
+}
+
+func (svc service) authorizeManagement(ctx context.Context, token string, ps ...Policy) error {
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	checked := make(map[string]bool)
+	for _, p := range ps {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if checked[p.Object] {
+			continue
+		}
+		if err := svc.canManage(ctx, user.ID, p.Object); err != nil {
+			return err
+		}
+		checked[p.Object] = true
+	}
+	return nil
+}
+
+// canClaim checks if the object is not covered by any policy yet, so that
+// the claim can't grant the rights over objects that are already shared.
+func (svc service) canClaim(ctx context.Context, obj string) bool {
+	page, err := svc.policies.RetrieveAll(ctx, Policy{Object: obj}, PageMetadata{Limit: 1})
+	return err == nil && page.Total == 0
+}
+
+// canManage checks if the user owns the group identified by the object ID,
+// or if the user is allowed to administer the object.
+func (svc service) canManage(ctx context.Context, userID, obj string) error {
+	if g, err := svc.groups.RetrieveByID(ctx, obj); err == nil && g.OwnerID == userID {
+		return nil
+	}
+
+	allowed, err := svc.authorize(ctx, userID, obj, AdminAction)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUnauthorizedAccess
+	}
+	return nil
+}
+
 func getTimestmap() time.Time {
 	return time.Now().UTC().Round(time.Millisecond)
 }
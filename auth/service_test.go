@@ -31,9 +31,10 @@ const (
 func newService() auth.Service {
 	repo := mocks.NewKeyRepository()
 	groupRepo := mocks.NewGroupRepository()
+	policyRepo := mocks.NewPolicyRepository()
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
-	return auth.New(repo, groupRepo, idProvider, t)
+	return auth.New(repo, groupRepo, policyRepo, idProvider, t)
 }
 
 func TestIssue(t *testing.T) {
@@ -107,6 +108,27 @@ func TestIssue(t *testing.T) {
 			token: secret,
 			err:   auth.ErrInvalidKeyIssuedAt,
 		},
+		{
+			desc: "issue pre-auth key",
+			key: auth.Key{
+				Type:     auth.PreAuthKey,
+				IssuerID: id,
+				Subject:  email,
+				IssuedAt: time.Now(),
+			},
+			token: "",
+			err:   nil,
+		},
+		{
+			desc: "issue pre-auth key without issuer",
+			key: auth.Key{
+				Type:     auth.PreAuthKey,
+				Subject:  email,
+				IssuedAt: time.Now(),
+			},
+			token: "",
+			err:   auth.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -244,6 +266,17 @@ func TestIdentify(t *testing.T) {
 	_, invalidSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: 22, IssuedAt: time.Now()})
 	assert.Nil(t, err, fmt.Sprintf("Issuing user key expected to succeed: %s", err))
 
+	_, scopedSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scope: auth.Scope{Actions: []string{auth.ReadAction}}})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	revoked, revokedSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	err = svc.Revoke(context.Background(), loginSecret, revoked.ID)
+	assert.Nil(t, err, fmt.Sprintf("Revoking API key expected to succeed: %s", err))
+
+	_, preAuthSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.PreAuthKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing pre-auth key expected to succeed: %s", err))
+
 	cases := []struct {
 		desc string
 		key  string
@@ -274,6 +307,24 @@ func TestIdentify(t *testing.T) {
 			idt:  auth.Identity{},
 			err:  auth.ErrAPIKeyExpired,
 		},
+		{
+			desc: "identify scoped API key",
+			key:  scopedSecret,
+			idt:  auth.Identity{},
+			err:  auth.ErrOutOfScope,
+		},
+		{
+			desc: "identify revoked API key",
+			key:  revokedSecret,
+			idt:  auth.Identity{},
+			err:  auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "identify pre-auth key",
+			key:  preAuthSecret,
+			idt:  auth.Identity{},
+			err:  auth.ErrUnauthorizedAccess,
+		},
 		{
 			desc: "identify expired key",
 			key:  invalidSecret,
@@ -295,6 +346,387 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestCanAccess(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	scope := auth.Scope{Actions: []string{auth.ReadAction}, Objects: []string{"thing"}}
+	_, scopedSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scope: scope})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	_, actionSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scope: auth.Scope{Actions: []string{auth.ReadAction}}})
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		obj  string
+		act  string
+		idt  auth.Identity
+		err  error
+	}{
+		{
+			desc: "access with login key",
+			key:  loginSecret,
+			obj:  "thing",
+			act:  auth.WriteAction,
+			idt:  auth.Identity{id, email},
+			err:  nil,
+		},
+		{
+			desc: "access with API key",
+			key:  apiSecret,
+			obj:  "thing",
+			act:  auth.WriteAction,
+			idt:  auth.Identity{id, email},
+			err:  nil,
+		},
+		{
+			desc: "access within the scope",
+			key:  scopedSecret,
+			obj:  "thing",
+			act:  auth.ReadAction,
+			idt:  auth.Identity{id, email},
+			err:  nil,
+		},
+		{
+			desc: "access with action out of the scope",
+			key:  scopedSecret,
+			obj:  "thing",
+			act:  auth.WriteAction,
+			idt:  auth.Identity{},
+			err:  auth.ErrOutOfScope,
+		},
+		{
+			desc: "access with object out of the scope",
+			key:  scopedSecret,
+			obj:  "other",
+			act:  auth.ReadAction,
+			idt:  auth.Identity{},
+			err:  auth.ErrOutOfScope,
+		},
+		{
+			desc: "access any object within the scope of actions",
+			key:  actionSecret,
+			obj:  "other",
+			act:  auth.ReadAction,
+			idt:  auth.Identity{id, email},
+			err:  nil,
+		},
+		{
+			desc: "access without action",
+			key:  scopedSecret,
+			obj:  "thing",
+			act:  "",
+			idt:  auth.Identity{},
+			err:  auth.ErrMalformedEntity,
+		},
+		{
+			desc: "access with invalid key",
+			key:  "invalid",
+			obj:  "thing",
+			act:  auth.ReadAction,
+			idt:  auth.Identity{},
+			err:  auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := svc.CanAccess(context.Background(), tc.key, tc.obj, tc.act)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.idt, idt))
+	}
+}
+
+func TestIdentifyPreAuth(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, preAuthSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.PreAuthKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing pre-auth key expected to succeed: %s", err))
+
+	_, expiredSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.PreAuthKey, IssuedAt: time.Now().Add(-time.Hour), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing expired pre-auth key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		idt  auth.Identity
+		err  error
+	}{
+		{
+			desc: "identify pre-auth key",
+			key:  preAuthSecret,
+			idt:  auth.Identity{ID: id, Email: email},
+			err:  nil,
+		},
+		{
+			desc: "identify expired pre-auth key",
+			key:  expiredSecret,
+			idt:  auth.Identity{},
+			err:  auth.ErrKeyExpired,
+		},
+		{
+			desc: "identify login key as pre-auth key",
+			key:  loginSecret,
+			idt:  auth.Identity{},
+			err:  auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc: "identify invalid pre-auth key",
+			key:  "invalid",
+			idt:  auth.Identity{},
+			err:  auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := svc.IdentifyPreAuth(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.idt, idt))
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	n := uint64(10)
+	var apiSecret string
+	for i := uint64(0); i < n; i++ {
+		_, apiSecret, err = svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+		require.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	}
+
+	_, err = svc.Identify(context.Background(), apiSecret)
+	require.Nil(t, err, fmt.Sprintf("Identifying API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    auth.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all keys",
+			token: loginSecret,
+			pm:    auth.PageMetadata{Offset: 0, Limit: n},
+			size:  int(n),
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list last page of keys",
+			token: loginSecret,
+			pm:    auth.PageMetadata{Offset: n - 1, Limit: n},
+			size:  1,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list keys using API key",
+			token: apiSecret,
+			pm:    auth.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+			total: 0,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "list keys with invalid token",
+			token: "invalid",
+			pm:    auth.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+			total: 0,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListKeys(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Keys), fmt.Sprintf("%s expected size %d got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+
+	page, err := svc.ListKeys(context.Background(), loginSecret, auth.PageMetadata{Limit: n})
+	require.Nil(t, err, fmt.Sprintf("Listing keys expected to succeed: %s", err))
+	used := 0
+	for _, k := range page.Keys {
+		if !k.LastUsedAt.IsZero() {
+			used++
+		}
+	}
+	assert.Equal(t, 1, used, fmt.Sprintf("expected one used key got %d\n", used))
+}
+
+func TestLogin(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc string
+		key  auth.Key
+		err  error
+	}{
+		{
+			desc: "login user",
+			key:  auth.Key{IssuerID: id, Subject: email, IssuedAt: time.Now()},
+			err:  nil,
+		},
+		{
+			desc: "login user with no time",
+			key:  auth.Key{IssuerID: id, Subject: email},
+			err:  auth.ErrInvalidKeyIssuedAt,
+		},
+		{
+			desc: "login user with no issuer",
+			key:  auth.Key{Subject: email, IssuedAt: time.Now()},
+			err:  auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		tokens, err := svc.Login(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		idt, err := svc.Identify(context.Background(), tokens.AccessToken)
+		assert.Nil(t, err, fmt.Sprintf("%s: identify access token expected to succeed: %s", tc.desc, err))
+		assert.Equal(t, auth.Identity{ID: id, Email: email}, idt, fmt.Sprintf("%s: expected identity %v got %v\n", tc.desc, auth.Identity{ID: id, Email: email}, idt))
+		_, err = svc.Identify(context.Background(), tokens.RefreshToken)
+		assert.True(t, errors.Contains(err, auth.ErrUnauthorizedAccess), fmt.Sprintf("%s: identify refresh token expected %s got %s\n", tc.desc, auth.ErrUnauthorizedAccess, err))
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	tokens, err := svc.Login(context.Background(), auth.Key{IssuerID: id, Subject: email, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Login expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "refresh with refresh token",
+			token: tokens.RefreshToken,
+			err:   nil,
+		},
+		{
+			desc:  "refresh with access token",
+			token: tokens.AccessToken,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with invalid token",
+			token: "invalid",
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "refresh with already used refresh token",
+			token: tokens.RefreshToken,
+			err:   auth.ErrSessionRevoked,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	// Reuse of the refresh token revokes the whole session.
+	_, err = svc.Identify(context.Background(), tokens.AccessToken)
+	assert.True(t, errors.Contains(err, auth.ErrSessionRevoked), fmt.Sprintf("identify access token of revoked session expected %s got %s\n", auth.ErrSessionRevoked, err))
+}
+
+func TestLogout(t *testing.T) {
+	svc := newService()
+	tokens, err := svc.Login(context.Background(), auth.Key{IssuerID: id, Subject: email, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Login expected to succeed: %s", err))
+	other, err := svc.Login(context.Background(), auth.Key{IssuerID: id, Subject: email, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Login expected to succeed: %s", err))
+	refreshed, err := svc.Refresh(context.Background(), tokens.RefreshToken)
+	require.Nil(t, err, fmt.Sprintf("Refresh expected to succeed: %s", err))
+
+	_, apiSecret, err := svc.Issue(context.Background(), tokens.AccessToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "logout with API key",
+			token: apiSecret,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "logout with invalid token",
+			token: "invalid",
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "logout with access token",
+			token: tokens.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "logout with refresh token",
+			token: other.RefreshToken,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Logout(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	for desc, token := range map[string]string{
+		"access token":           tokens.AccessToken,
+		"refreshed access token": refreshed.AccessToken,
+		"other access token":     other.AccessToken,
+	} {
+		_, err := svc.Identify(context.Background(), token)
+		assert.True(t, errors.Contains(err, auth.ErrSessionRevoked), fmt.Sprintf("identify %s of revoked session expected %s got %s\n", desc, auth.ErrSessionRevoked, err))
+	}
+	_, err = svc.Refresh(context.Background(), refreshed.RefreshToken)
+	assert.True(t, errors.Contains(err, auth.ErrSessionRevoked), fmt.Sprintf("refresh revoked session expected %s got %s\n", auth.ErrSessionRevoked, err))
+
+	_, err = svc.Identify(context.Background(), apiSecret)
+	assert.Nil(t, err, fmt.Sprintf("identify API key issued within revoked session expected to succeed: %s", err))
+}
+
+func TestRemoveExpiredKeys(t *testing.T) {
+	svc := newService()
+	expired, err := svc.Login(context.Background(), auth.Key{IssuerID: id, Subject: email, IssuedAt: time.Now().Add(-48 * time.Hour)})
+	require.Nil(t, err, fmt.Sprintf("Login expected to succeed: %s", err))
+	tokens, err := svc.Login(context.Background(), auth.Key{IssuerID: id, Subject: email, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Login expected to succeed: %s", err))
+
+	n, err := svc.RemoveExpiredKeys(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("remove expired keys expected to succeed: %s", err))
+	assert.Equal(t, uint64(1), n, fmt.Sprintf("remove expired keys: expected 1 removed key got %d\n", n))
+
+	_, err = svc.Refresh(context.Background(), expired.RefreshToken)
+	assert.True(t, errors.Contains(err, auth.ErrUnauthorizedAccess), fmt.Sprintf("refresh expired session expected %s got %s\n", auth.ErrUnauthorizedAccess, err))
+	_, err = svc.Refresh(context.Background(), tokens.RefreshToken)
+	assert.Nil(t, err, fmt.Sprintf("refresh active session expected to succeed: %s", err))
+}
+
 func TestCreateGroup(t *testing.T) {
 	svc := newService()
 	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
@@ -981,3 +1413,412 @@ func TestUnassign(t *testing.T) {
 	err = svc.Unassign(context.Background(), apiToken, group.ID, mid)
 	assert.True(t, errors.Contains(err, auth.ErrGroupNotFound), fmt.Sprintf("Unauthorized access: expected %v got %v", nil, err))
 }
+
+func TestAuthorize(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	parent, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	child, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName, ParentID: parent.ID})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	member, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.Assign(context.Background(), secret, child.ID, "users", member)
+	require.Nil(t, err, fmt.Sprintf("member assign got unexpected error: %s", err))
+
+	user, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thing, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = svc.ClaimObject(context.Background(), secret, thing)
+	require.Nil(t, err, fmt.Sprintf("claiming object got unexpected error: %s", err))
+	err = svc.AddPolicies(context.Background(), secret,
+		auth.Policy{Subject: user, Object: thing, Action: auth.WriteAction},
+		auth.Policy{Subject: parent.ID, Object: thing, Action: auth.ReadAction})
+	require.Nil(t, err, fmt.Sprintf("adding policy got unexpected error: %s", err))
+
+	_, userSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: user, Subject: "user@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, memberSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: member, Subject: "member@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		obj     string
+		act     string
+		allowed bool
+		err     error
+	}{
+		{
+			desc:    "authorize subject with direct policy",
+			token:   userSecret,
+			obj:     thing,
+			act:     auth.WriteAction,
+			allowed: true,
+			err:     nil,
+		},
+		{
+			desc:    "authorize read action implied by write policy",
+			token:   userSecret,
+			obj:     thing,
+			act:     auth.ReadAction,
+			allowed: true,
+			err:     nil,
+		},
+		{
+			desc:    "authorize admin action not granted by write policy",
+			token:   userSecret,
+			obj:     thing,
+			act:     auth.AdminAction,
+			allowed: false,
+			err:     nil,
+		},
+		{
+			desc:    "authorize member of a group whose ancestor has policy",
+			token:   memberSecret,
+			obj:     thing,
+			act:     auth.ReadAction,
+			allowed: true,
+			err:     nil,
+		},
+		{
+			desc:    "authorize member of a group for action not granted to group",
+			token:   memberSecret,
+			obj:     thing,
+			act:     auth.WriteAction,
+			allowed: false,
+			err:     nil,
+		},
+		{
+			desc:    "authorize subject without policy",
+			token:   otherSecret,
+			obj:     thing,
+			act:     auth.ReadAction,
+			allowed: false,
+			err:     nil,
+		},
+		{
+			desc:    "authorize with invalid token",
+			token:   "invalid",
+			obj:     thing,
+			act:     auth.ReadAction,
+			allowed: false,
+			err:     auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "authorize with empty action",
+			token:   userSecret,
+			obj:     thing,
+			act:     "",
+			allowed: false,
+			err:     auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed, err := svc.Authorize(context.Background(), tc.token, tc.obj, tc.act)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
+
+func TestAddPolicies(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	group, err := svc.CreateGroup(context.Background(), secret, auth.Group{Name: groupName})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	thing, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.ClaimObject(context.Background(), secret, thing)
+	require.Nil(t, err, fmt.Sprintf("claiming object got unexpected error: %s", err))
+
+	unclaimed, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		policies []auth.Policy
+		err      error
+	}{
+		{
+			desc:     "add policies for owned group",
+			token:    secret,
+			policies: []auth.Policy{{Subject: "otherID", Object: group.ID, Action: auth.ReadAction}},
+			err:      nil,
+		},
+		{
+			desc:     "add policies for administered object",
+			token:    secret,
+			policies: []auth.Policy{{Subject: "otherID", Object: thing, Action: auth.ReadAction}},
+			err:      nil,
+		},
+		{
+			desc:     "add policies without admin rights",
+			token:    otherSecret,
+			policies: []auth.Policy{{Subject: "otherID", Object: thing, Action: auth.AdminAction}},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add admin policy over object without policies",
+			token:    otherSecret,
+			policies: []auth.Policy{{Subject: "otherID", Object: unclaimed, Action: auth.AdminAction}},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add admin policy on behalf of other subject",
+			token:    secret,
+			policies: []auth.Policy{{Subject: "otherID", Object: unclaimed, Action: auth.AdminAction}},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add policies with invalid token",
+			token:    "invalid",
+			policies: []auth.Policy{{Subject: "otherID", Object: thing, Action: auth.ReadAction}},
+			err:      auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "add policies with empty subject",
+			token:    secret,
+			policies: []auth.Policy{{Subject: "", Object: thing, Action: auth.ReadAction}},
+			err:      auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.AddPolicies(context.Background(), tc.token, tc.policies...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	allowed, err := svc.Authorize(context.Background(), otherSecret, thing, auth.ReadAction)
+	assert.Nil(t, err, fmt.Sprintf("authorize got unexpected error: %s", err))
+	assert.True(t, allowed, "authorize expected to succeed after adding policies")
+}
+
+func TestClaimObject(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	thing, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		obj   string
+		err   error
+	}{
+		{
+			desc:  "claim object without policies",
+			token: secret,
+			obj:   thing,
+			err:   nil,
+		},
+		{
+			desc:  "claim administered object",
+			token: secret,
+			obj:   thing,
+			err:   nil,
+		},
+		{
+			desc:  "claim object administered by other user",
+			token: otherSecret,
+			obj:   thing,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "claim object with invalid token",
+			token: "invalid",
+			obj:   thing,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "claim object with empty ID",
+			token: secret,
+			obj:   "",
+			err:   auth.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ClaimObject(context.Background(), tc.token, tc.obj)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	allowed, err := svc.Authorize(context.Background(), otherSecret, thing, auth.ReadAction)
+	assert.Nil(t, err, fmt.Sprintf("authorize got unexpected error: %s", err))
+	assert.False(t, allowed, "authorize expected to fail after claiming object administered by other user")
+}
+
+func TestRemovePolicies(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	thing, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.ClaimObject(context.Background(), secret, thing)
+	require.Nil(t, err, fmt.Sprintf("claiming object got unexpected error: %s", err))
+
+	policy := auth.Policy{Subject: "otherID", Object: thing, Action: auth.WriteAction}
+	err = svc.AddPolicies(context.Background(), secret, policy)
+	require.Nil(t, err, fmt.Sprintf("adding policies got unexpected error: %s", err))
+
+	err = svc.RemovePolicies(context.Background(), "invalid", policy)
+	assert.True(t, errors.Contains(err, auth.ErrUnauthorizedAccess), fmt.Sprintf("remove policies with invalid token: expected %s got %s\n", auth.ErrUnauthorizedAccess, err))
+
+	err = svc.RemovePolicies(context.Background(), otherSecret, policy)
+	assert.True(t, errors.Contains(err, auth.ErrUnauthorizedAccess), fmt.Sprintf("remove policies without admin rights: expected %s got %s\n", auth.ErrUnauthorizedAccess, err))
+
+	err = svc.RemovePolicies(context.Background(), secret, policy)
+	assert.Nil(t, err, fmt.Sprintf("remove policies got unexpected error: %s", err))
+
+	allowed, err := svc.Authorize(context.Background(), otherSecret, policy.Object, policy.Action)
+	assert.Nil(t, err, fmt.Sprintf("authorize got unexpected error: %s", err))
+	assert.False(t, allowed, "authorize expected to fail after removing policies")
+}
+
+func TestRemoveObjectPolicies(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	thing, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.ClaimObject(context.Background(), secret, thing)
+	require.Nil(t, err, fmt.Sprintf("claiming object got unexpected error: %s", err))
+	err = svc.AddPolicies(context.Background(), secret, auth.Policy{Subject: "otherID", Object: thing, Action: auth.ReadAction})
+	require.Nil(t, err, fmt.Sprintf("adding policies got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		obj   string
+		err   error
+	}{
+		{
+			desc:  "remove object policies without admin rights",
+			token: otherSecret,
+			obj:   thing,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove object policies with invalid token",
+			token: "invalid",
+			obj:   thing,
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove object policies",
+			token: secret,
+			obj:   thing,
+			err:   nil,
+		},
+		{
+			desc:  "remove policies of object without policies",
+			token: otherSecret,
+			obj:   thing,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveObjectPolicies(context.Background(), tc.token, tc.obj)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	allowed, err := svc.Authorize(context.Background(), otherSecret, thing, auth.ReadAction)
+	assert.Nil(t, err, fmt.Sprintf("authorize got unexpected error: %s", err))
+	assert.False(t, allowed, "authorize expected to fail after removing object policies")
+}
+
+func TestListPolicies(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "otherID", Subject: "other@example.com"})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	thing, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = svc.ClaimObject(context.Background(), secret, thing)
+	require.Nil(t, err, fmt.Sprintf("claiming object got unexpected error: %s", err))
+	err = svc.AddPolicies(context.Background(), secret,
+		auth.Policy{Subject: "otherID", Object: thing, Action: auth.ReadAction},
+		auth.Policy{Subject: "otherID", Object: thing, Action: auth.WriteAction})
+	require.Nil(t, err, fmt.Sprintf("adding policies got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		filter auth.Policy
+		size   int
+		err    error
+	}{
+		{
+			desc:   "list policies of administered object",
+			token:  secret,
+			filter: auth.Policy{Object: thing},
+			size:   3,
+			err:    nil,
+		},
+		{
+			desc:   "list own policies",
+			token:  otherSecret,
+			filter: auth.Policy{},
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "list own policies ignoring other subject",
+			token:  otherSecret,
+			filter: auth.Policy{Subject: id},
+			size:   2,
+			err:    nil,
+		},
+		{
+			desc:   "list policies of object without admin rights",
+			token:  otherSecret,
+			filter: auth.Policy{Object: thing},
+			size:   0,
+			err:    auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "list policies with invalid token",
+			token:  "invalid",
+			filter: auth.Policy{},
+			size:   0,
+			err:    auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListPolicies(context.Background(), tc.token, tc.filter, auth.PageMetadata{Limit: 10})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Policies), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Policies)))
+	}
+}
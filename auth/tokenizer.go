@@ -3,6 +3,21 @@
 
 package auth
 
+import "crypto"
+
+// PublicKey represents the public part of a key used to sign tokens.
+type PublicKey struct {
+	// ID is the key identifier, matching the "kid" header of the tokens
+	// signed with the corresponding private key.
+	ID string
+
+	// Algorithm is the algorithm of the signed tokens (e.g. RS256).
+	Algorithm string
+
+	// Key is the actual public key, e.g. *rsa.PublicKey.
+	Key crypto.PublicKey
+}
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +25,8 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(string) (Key, error)
+
+	// PublicKeys returns keys that verify issued tokens. Tokenizers that
+	// use a shared secret return no keys.
+	PublicKeys() []PublicKey
 }
@@ -7,6 +7,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/auth"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -16,6 +17,14 @@ const (
 	saveOp     = "save"
 	retrieveOp = "retrieve_by_id"
 	revokeOp   = "remove"
+
+	retrieveAllOp    = "retrieve_all"
+	updateLastUsedOp = "update_last_used"
+
+	rotateOp          = "rotate"
+	retrieveSessionOp = "retrieve_session"
+	removeSessionOp   = "remove_session"
+	removeExpiredOp   = "remove_expired"
 )
 
 var _ auth.KeyRepository = (*keyRepositoryMiddleware)(nil)
@@ -53,6 +62,22 @@ func (krm keyRepositoryMiddleware) Retrieve(ctx context.Context, owner, id strin
 	return krm.repo.Retrieve(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm auth.PageMetadata) (auth.KeyPage, error) {
+	span := createSpan(ctx, krm.tracer, retrieveAllOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func (krm keyRepositoryMiddleware) UpdateLastUsed(ctx context.Context, owner, id string, t time.Time) error {
+	span := createSpan(ctx, krm.tracer, updateLastUsedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.UpdateLastUsed(ctx, owner, id, t)
+}
+
 func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
 	span := createSpan(ctx, krm.tracer, revokeOp)
 	defer span.Finish()
@@ -61,6 +86,38 @@ func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string)
 	return krm.repo.Remove(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) Rotate(ctx context.Context, id string, key auth.Key) error {
+	span := createSpan(ctx, krm.tracer, rotateOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.Rotate(ctx, id, key)
+}
+
+func (krm keyRepositoryMiddleware) RetrieveSession(ctx context.Context, owner, sessionID string) (auth.Key, error) {
+	span := createSpan(ctx, krm.tracer, retrieveSessionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveSession(ctx, owner, sessionID)
+}
+
+func (krm keyRepositoryMiddleware) RemoveExpired(ctx context.Context, t time.Time) (uint64, error) {
+	span := createSpan(ctx, krm.tracer, removeExpiredOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RemoveExpired(ctx, t)
+}
+
+func (krm keyRepositoryMiddleware) RemoveSession(ctx context.Context, owner, sessionID string) error {
+	span := createSpan(ctx, krm.tracer, removeSessionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RemoveSession(ctx, owner, sessionID)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	savePolicies         = "save_policies"
+	removePolicies       = "remove_policies"
+	removeObjectPolicies = "remove_object_policies"
+	retrieveAllPolicies  = "retrieve_all_policies"
+	evaluatePolicies     = "evaluate_policies"
+	retrieveObjects      = "retrieve_objects"
+)
+
+var _ auth.PolicyRepository = (*policyRepositoryMiddleware)(nil)
+
+type policyRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.PolicyRepository
+}
+
+// PolicyRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func PolicyRepositoryMiddleware(tracer opentracing.Tracer, pr auth.PolicyRepository) auth.PolicyRepository {
+	return policyRepositoryMiddleware{
+		tracer: tracer,
+		repo:   pr,
+	}
+}
+
+func (prm policyRepositoryMiddleware) Save(ctx context.Context, ps ...auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, savePolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Save(ctx, ps...)
+}
+
+func (prm policyRepositoryMiddleware) Remove(ctx context.Context, ps ...auth.Policy) error {
+	span := createSpan(ctx, prm.tracer, removePolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Remove(ctx, ps...)
+}
+
+func (prm policyRepositoryMiddleware) RemoveObject(ctx context.Context, obj string) error {
+	span := createSpan(ctx, prm.tracer, removeObjectPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RemoveObject(ctx, obj)
+}
+
+func (prm policyRepositoryMiddleware) RetrieveAll(ctx context.Context, filter auth.Policy, pm auth.PageMetadata) (auth.PolicyPage, error) {
+	span := createSpan(ctx, prm.tracer, retrieveAllPolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveAll(ctx, filter, pm)
+}
+
+func (prm policyRepositoryMiddleware) Evaluate(ctx context.Context, subjects, objects, actions []string) (bool, error) {
+	span := createSpan(ctx, prm.tracer, evaluatePolicies)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Evaluate(ctx, subjects, objects, actions)
+}
+
+func (prm policyRepositoryMiddleware) RetrieveObjects(ctx context.Context, subjects, actions []string) ([]string, error) {
+	span := createSpan(ctx, prm.tracer, retrieveObjects)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveObjects(ctx, subjects, actions)
+}
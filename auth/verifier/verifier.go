@@ -0,0 +1,187 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package verifier validates user tokens issued by the Auth service offline,
+// using public keys that the service publishes on its JWKS endpoint.
+//
+// Offline verification only checks the token signature and expiration.
+// Since revoked sessions are tracked by the Auth service, a token of the
+// session that ended with logout stays valid until it expires.
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/jwt"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	// maxKeysSize limits the size of the JWKS response.
+	maxKeysSize = 1 << 20
+
+	// defTimeout is the timeout of the JWKS request, unless the client
+	// is provided.
+	defTimeout = 5 * time.Second
+)
+
+var errFetchKeys = errors.New("failed to fetch public keys")
+
+// Verifier validates user access tokens without calling the Auth service.
+type Verifier interface {
+	// Identify validates the user access token, returning the identity of
+	// the user it is issued to. Other types of keys (e.g. API keys) are
+	// rejected, since they can be revoked at any time.
+	Identify(ctx context.Context, token string) (auth.Identity, error)
+}
+
+var _ Verifier = (*verifier)(nil)
+
+type verifier struct {
+	url        string
+	client     *http.Client
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]auth.PublicKey
+	fetched   time.Time
+	attempted time.Time
+
+	// fetchMu serializes fetching, so that the cached keys can be read
+	// while the keys are being fetched.
+	fetchMu sync.Mutex
+}
+
+// New returns Verifier that fetches public keys from the JWKS endpoint with
+// the provided URL (e.g. http://auth:8180/.well-known/jwks.json). Fetched keys
+// are cached for ttl. A token signed with an unknown key triggers a new fetch,
+// at most once per minRefresh, so that rotated keys are picked up. If the
+// client is nil, the client with a 5 seconds timeout is used.
+func New(url string, client *http.Client, ttl, minRefresh time.Duration) Verifier {
+	if client == nil {
+		client = &http.Client{Timeout: defTimeout}
+	}
+
+	return &verifier{
+		url:        url,
+		client:     client,
+		ttl:        ttl,
+		minRefresh: minRefresh,
+		keys:       make(map[string]auth.PublicKey),
+	}
+}
+
+func (v *verifier) Identify(ctx context.Context, token string) (auth.Identity, error) {
+	key, err := jwt.Verify(token, func(id string) (auth.PublicKey, error) {
+		return v.key(ctx, id)
+	})
+	if err != nil {
+		return auth.Identity{}, err
+	}
+
+	if key.Type != auth.UserKey || key.IssuerID == "" {
+		return auth.Identity{}, auth.ErrUnauthorizedAccess
+	}
+
+	return auth.Identity{ID: key.IssuerID, Email: key.Subject}, nil
+}
+
+func (v *verifier) key(ctx context.Context, id string) (auth.PublicKey, error) {
+	pk, ok, fresh, _ := v.cached(id)
+	if ok && fresh {
+		return pk, nil
+	}
+
+	v.fetchMu.Lock()
+	defer v.fetchMu.Unlock()
+
+	// Keys could be fetched while waiting for the lock.
+	pk, ok, fresh, attempted := v.cached(id)
+	if ok && fresh {
+		return pk, nil
+	}
+	if time.Since(attempted) < v.minRefresh {
+		if ok {
+			return pk, nil
+		}
+		return auth.PublicKey{}, auth.ErrUnauthorizedAccess
+	}
+
+	keys, err := v.fetch(ctx)
+	if err != nil {
+		// Keep using the cached key while the Auth service is unavailable.
+		if ok {
+			return pk, nil
+		}
+		return auth.PublicKey{}, err
+	}
+
+	pk, ok = keys[id]
+	if !ok {
+		return auth.PublicKey{}, auth.ErrUnauthorizedAccess
+	}
+
+	return pk, nil
+}
+
+// cached returns the cached key with the provided ID, whether the cached
+// keys are fresh and the time of the last fetch attempt.
+func (v *verifier) cached(id string) (auth.PublicKey, bool, bool, time.Time) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	pk, ok := v.keys[id]
+	return pk, ok, time.Since(v.fetched) < v.ttl, v.attempted
+}
+
+// fetch fetches the keys and caches them. The lock is held only while the
+// cache is updated, so that the request doesn't block the cache readers.
+func (v *verifier) fetch(ctx context.Context) (map[string]auth.PublicKey, error) {
+	v.mu.Lock()
+	v.attempted = time.Now()
+	v.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, errors.Wrap(errFetchKeys, err)
+	}
+	res, err := v.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(errFetchKeys, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Wrap(errFetchKeys, fmt.Errorf("unexpected status code %d", res.StatusCode))
+	}
+
+	var set jwt.JWKS
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxKeysSize)).Decode(&set); err != nil {
+		return nil, errors.Wrap(errFetchKeys, err)
+	}
+
+	keys := make(map[string]auth.PublicKey)
+	for _, k := range set.Keys {
+		pk, err := k.PublicKey()
+		if err != nil {
+			// Skip keys this verifier does not support.
+			continue
+		}
+		keys[pk.ID] = pk
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetched = time.Now()
+	v.mu.Unlock()
+
+	return keys, nil
+}
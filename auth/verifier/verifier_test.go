@@ -0,0 +1,299 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package verifier_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/jwt"
+	"github.com/mainflux/mainflux/auth/verifier"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	id    = "123e4567-e89b-12d3-a456-000000000001"
+	email = "user@example.com"
+)
+
+type jwksServer struct {
+	mu    sync.Mutex
+	keys  []auth.PublicKey
+	count int
+}
+
+func (s *jwksServer) publish(keys ...auth.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) fetches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+
+	set := jwt.JWKS{Keys: []jwt.JWK{}}
+	for _, k := range s.keys {
+		jwk, err := jwt.NewJWK(k)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	json.NewEncoder(w).Encode(set)
+}
+
+func newSigningKey(t *testing.T) jwt.SigningKey {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	key, err := jwt.NewSigningKey(priv)
+	require.Nil(t, err, fmt.Sprintf("creating signing key expected to succeed: %s", err))
+	return key
+}
+
+func issue(t *testing.T, tokenizer auth.Tokenizer, typ uint32, exp time.Duration) string {
+	now := time.Now().UTC()
+	token, err := tokenizer.Issue(auth.Key{
+		Type:      typ,
+		IssuerID:  id,
+		Subject:   email,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(exp),
+		SessionID: "session",
+	})
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	return token
+}
+
+func TestIdentify(t *testing.T) {
+	key := newSigningKey(t)
+	tokenizer, err := jwt.NewAsymmetric("", key)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	srv := &jwksServer{}
+	srv.publish(key.PublicKey())
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	unknown, err := jwt.NewAsymmetric("", newSigningKey(t))
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	v := verifier.New(ts.URL, ts.Client(), time.Hour, time.Hour)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    auth.Identity
+		err   error
+	}{
+		{
+			desc:  "identify user with access token",
+			token: issue(t, tokenizer, auth.UserKey, time.Minute),
+			id:    auth.Identity{ID: id, Email: email},
+			err:   nil,
+		},
+		{
+			desc:  "identify user with expired access token",
+			token: issue(t, tokenizer, auth.UserKey, -time.Minute),
+			id:    auth.Identity{},
+			err:   auth.ErrKeyExpired,
+		},
+		{
+			desc:  "identify user with refresh token",
+			token: issue(t, tokenizer, auth.RefreshKey, time.Minute),
+			id:    auth.Identity{},
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "identify user with API key",
+			token: issue(t, tokenizer, auth.APIKey, time.Minute),
+			id:    auth.Identity{},
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "identify user with token signed with unknown key",
+			token: issue(t, unknown, auth.UserKey, time.Minute),
+			id:    auth.Identity{},
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "identify user with token signed with shared secret",
+			token: issue(t, jwt.New("secret"), auth.UserKey, time.Minute),
+			id:    auth.Identity{},
+			err:   auth.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "identify user with invalid token",
+			token: "invalid",
+			id:    auth.Identity{},
+			err:   auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := v.Identify(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.id, id))
+	}
+
+	assert.Equal(t, 1, srv.fetches(), fmt.Sprintf("expected keys to be fetched once, got %d fetches", srv.fetches()))
+}
+
+func TestIdentifyRotation(t *testing.T) {
+	oldKey := newSigningKey(t)
+	newKey := newSigningKey(t)
+
+	before, err := jwt.NewAsymmetric("", oldKey, newKey)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	after, err := jwt.NewAsymmetric("", newKey, oldKey)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	srv := &jwksServer{}
+	srv.publish(oldKey.PublicKey())
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	v := verifier.New(ts.URL, ts.Client(), time.Hour, 0)
+	rateLimited := verifier.New(ts.URL, ts.Client(), time.Hour, time.Hour)
+
+	oldToken := issue(t, before, auth.UserKey, time.Minute)
+	for _, vr := range []verifier.Verifier{v, rateLimited} {
+		_, err = vr.Identify(context.Background(), oldToken)
+		require.Nil(t, err, fmt.Sprintf("identify user with token signed with old key: got unexpected error: %s", err))
+	}
+
+	// New key is published and starts signing tokens.
+	srv.publish(after.PublicKeys()...)
+	newToken := issue(t, after, auth.UserKey, time.Minute)
+
+	cases := []struct {
+		desc     string
+		verifier verifier.Verifier
+		token    string
+		err      error
+	}{
+		{
+			desc:     "identify user with token signed with new key",
+			verifier: v,
+			token:    newToken,
+			err:      nil,
+		},
+		{
+			desc:     "identify user with token signed with old key after rotation",
+			verifier: v,
+			token:    oldToken,
+			err:      nil,
+		},
+		{
+			desc:     "identify user with token signed with new key before refresh",
+			verifier: rateLimited,
+			token:    newToken,
+			err:      auth.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.verifier.Identify(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestIdentifyUnavailable(t *testing.T) {
+	key := newSigningKey(t)
+	tokenizer, err := jwt.NewAsymmetric("", key)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	token := issue(t, tokenizer, auth.UserKey, time.Minute)
+
+	srv := &jwksServer{}
+	srv.publish(key.PublicKey())
+	ts := httptest.NewServer(srv)
+
+	fresh := verifier.New(ts.URL, ts.Client(), 0, 0)
+	_, err = fresh.Identify(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("identify user: got unexpected error: %s", err))
+
+	ts.Close()
+
+	_, err = fresh.Identify(context.Background(), token)
+	assert.Nil(t, err, fmt.Sprintf("identify user with cached key while auth is unavailable: got unexpected error: %s", err))
+
+	empty := verifier.New(ts.URL, ts.Client(), 0, 0)
+	_, err = empty.Identify(context.Background(), token)
+	assert.NotNil(t, err, "identify user without cached key while auth is unavailable: expected error")
+}
+
+func TestIdentifyDuringFetch(t *testing.T) {
+	key := newSigningKey(t)
+	tokenizer, err := jwt.NewAsymmetric("", key)
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+	unknown, err := jwt.NewAsymmetric("", newSigningKey(t))
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	srv := &jwksServer{}
+	srv.publish(key.PublicKey())
+
+	// Once blocking is set, the fetch is blocked until the gate is closed.
+	var blocking int32
+	started := make(chan struct{})
+	gate := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&blocking) == 1 {
+			close(started)
+			<-gate
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	v := verifier.New(ts.URL, ts.Client(), time.Hour, 0)
+	token := issue(t, tokenizer, auth.UserKey, time.Minute)
+	_, err = v.Identify(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("identify user: got unexpected error: %s", err))
+
+	// Token signed with unknown key triggers the fetch.
+	atomic.StoreInt32(&blocking, 1)
+	unknownToken := issue(t, unknown, auth.UserKey, time.Minute)
+	fetched := make(chan error, 1)
+	go func() {
+		_, err := v.Identify(context.Background(), unknownToken)
+		fetched <- err
+	}()
+	<-started
+
+	identified := make(chan error, 1)
+	go func() {
+		_, err := v.Identify(context.Background(), token)
+		identified <- err
+	}()
+	select {
+	case err := <-identified:
+		assert.Nil(t, err, fmt.Sprintf("identify user with cached key during fetch: got unexpected error: %s", err))
+	case <-time.After(time.Second):
+		assert.Fail(t, "identify user with cached key during fetch: expected not to wait for the fetch")
+	}
+
+	close(gate)
+	err = <-fetched
+	assert.True(t, errors.Contains(err, auth.ErrUnauthorizedAccess), fmt.Sprintf("identify user with token signed with unknown key: expected %s got %s\n", auth.ErrUnauthorizedAccess, err))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/things"
@@ -70,7 +71,7 @@ func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (thing
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (svc *mainfluxThings) Connect(_ context.Context, owner string, chIDs, thIDs []string, connType string) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
 
@@ -167,6 +168,10 @@ func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string) er
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) RotateKey(context.Context, string, string, time.Duration) (string, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ListThings(context.Context, string, things.PageMetadata) (things.Page, error) {
 	panic("not implemented")
 }
@@ -209,11 +214,11 @@ func (svc *mainfluxThings) RemoveChannel(context.Context, string, string) error
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string) (string, error) {
+func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string, string) (string, error) {
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) CanAccessByID(context.Context, string, string) error {
+func (svc *mainfluxThings) CanAccessByID(context.Context, string, string, string) error {
 	panic("not implemented")
 }
 
@@ -221,6 +226,10 @@ func (svc *mainfluxThings) IsChannelOwner(context.Context, string, string) error
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) IsThingOwner(context.Context, string, string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
@@ -238,3 +247,19 @@ func findIndex(list []string, val string) int {
 func (svc *mainfluxThings) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.Page, error) {
 	panic("not implemented")
 }
+
+func (svc *mainfluxThings) ShareThing(context.Context, string, string, []string, []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnshareThing(context.Context, string, string, []string, []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ShareChannel(context.Context, string, string, []string, []string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UnshareChannel(context.Context, string, string, []string, []string) error {
+	panic("not implemented")
+}
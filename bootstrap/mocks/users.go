@@ -30,6 +30,14 @@ func (svc serviceMock) Identify(ctx context.Context, in *mainflux.Token, opts ..
 	return nil, users.ErrUnauthorizedAccess
 }
 
+func (svc serviceMock) CanAccess(ctx context.Context, req *mainflux.AccessReq, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return svc.Identify(ctx, &mainflux.Token{Value: req.GetToken()})
+}
+
+func (svc serviceMock) IdentifyPreAuth(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
 func (svc serviceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
@@ -40,6 +48,18 @@ func (svc serviceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ..
 	return nil, users.ErrUnauthorizedAccess
 }
 
+func (svc serviceMock) Login(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) Logout(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
 func (svc serviceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
 	panic("not implemented")
 }
@@ -51,3 +71,23 @@ func (svc serviceMock) Members(ctx context.Context, req *mainflux.MembersReq, _
 func (svc serviceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc serviceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc serviceMock) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (r *mainflux.ObjectsRes, err error) {
+	panic("not implemented")
+}
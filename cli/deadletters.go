@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var cmdDeadLetters = []cobra.Command{
+	cobra.Command{
+		Use:   "get",
+		Short: "get <user_token>",
+		Long:  `Get the messages sent to the user's channels which the writer failed to consume`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			dp, err := sdk.DeadLetters(args[0], uint64(Offset), uint64(Limit))
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(dp)
+		},
+	},
+	cobra.Command{
+		Use:   "reinject",
+		Short: "reinject <dead_letter_id> <user_token>",
+		Long:  `Publishes dead letter message to its original channel`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			if err := sdk.ReinjectDeadLetter(args[1], id); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+	cobra.Command{
+		Use:   "delete",
+		Short: "delete <dead_letter_id> <user_token>",
+		Long:  `Removes dead letter`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			if err := sdk.RemoveDeadLetter(args[1], id); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
+}
+
+// NewDeadLettersCmd returns dead letters command.
+func NewDeadLettersCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "deadletters",
+		Short: "Dead letters management",
+		Long:  `Inspect, re-inject or remove the messages the writer failed to consume`,
+		Run: func(cmd *cobra.Command, args []string) {
+			logUsage("deadletters [get | reinject | delete]")
+		},
+	}
+
+	for i := range cmdDeadLetters {
+		cmd.AddCommand(&cmdDeadLetters[i])
+	}
+
+	return &cmd
+}
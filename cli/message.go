@@ -3,7 +3,12 @@
 
 package cli
 
-import "github.com/spf13/cobra"
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
 
 const contentTypeSenml = "application/senml+json"
 
@@ -49,18 +54,59 @@ var cmdMessages = []cobra.Command{
 
 // NewMessagesCmd returns messages command.
 func NewMessagesCmd() *cobra.Command {
+	var exportType, file string
+	var from, to float64
+
+	exportCmd := cobra.Command{
+		Use:   "export",
+		Short: "export <channel_id>[.<subtopic>...] <thing_key> [--type=csv] [--from=<time>] [--to=<time>] [--file=<path>]",
+		Long:  `Exports all channel messages as CSV, NDJSON or SenML`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			var w io.Writer = os.Stdout
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					logError(err)
+					return
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if err := sdk.ExportMessages(args[0], exportType, from, to, args[1], w); err != nil {
+				logError(err)
+				return
+			}
+
+			if file != "" {
+				logOK()
+			}
+		},
+	}
+
+	exportCmd.Flags().StringVar(&exportType, "type", "csv", "export type: csv, ndjson or senml")
+	exportCmd.Flags().Float64Var(&from, "from", 0, "export messages sent at or after the time, in seconds")
+	exportCmd.Flags().Float64Var(&to, "to", 0, "export messages sent before the time, in seconds")
+	exportCmd.Flags().StringVar(&file, "file", "", "path of the file to write messages to instead of standard output")
+
 	cmd := cobra.Command{
 		Use:   "messages",
-		Short: "Send or read messages",
-		Long:  `Send or read messages using the http-adapter and the configured database reader`,
+		Short: "Send, read or export messages",
+		Long:  `Send, read or export messages using the http-adapter and the configured database reader`,
 		Run: func(cmd *cobra.Command, args []string) {
-			logUsage("messages [send | read]")
+			logUsage("messages [send | read | export]")
 		},
 	}
 
 	for i := range cmdMessages {
 		cmd.AddCommand(&cmdMessages[i])
 	}
+	cmd.AddCommand(&exportCmd)
 
 	return &cmd
 }
@@ -70,14 +70,52 @@ func NewUsersCmd() *cobra.Command {
 				Email:    args[0],
 				Password: args[1],
 			}
-			token, err := sdk.CreateToken(user)
+			tokens, err := sdk.CreateTokens(user)
 			if err != nil {
 				logError(err)
 				return
 			}
 
-			logCreated(token)
+			logJSON(tokens)
+		},
+	}
+
+	refreshCmd := cobra.Command{
+		Use:   "refresh",
+		Short: "refresh <refresh_token>",
+		Long:  `Exchanges refresh token for new tokens`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Short)
+				return
+			}
 
+			tokens, err := sdk.RefreshTokens(args[0])
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(tokens)
+		},
+	}
+
+	logoutCmd := cobra.Command{
+		Use:   "logout",
+		Short: "logout <user_auth_token>",
+		Long:  `Revokes user session`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Short)
+				return
+			}
+
+			if err := sdk.Logout(args[0]); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
 		},
 	}
 
@@ -130,12 +168,12 @@ func NewUsersCmd() *cobra.Command {
 		Short: "Users management",
 		Long:  `Users management: create accounts and tokens"`,
 		Run: func(cmd *cobra.Command, args []string) {
-			logUsage("users [create | get | update | token | password]")
+			logUsage("users [create | get | update | token | refresh | logout | password]")
 		},
 	}
 
 	cmdUsers := []cobra.Command{
-		createCmd, getCmd, tokenCmd, updateCmd, passwordCmd,
+		createCmd, getCmd, tokenCmd, refreshCmd, logoutCmd, updateCmd, passwordCmd,
 	}
 
 	for i := range cmdUsers {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -9,7 +10,9 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
@@ -44,9 +47,11 @@ const (
 	defHTTPPort      = "8180"
 	defGRPCPort      = "8181"
 	defSecret        = "auth"
+	defSigningKeys   = ""
 	defServerCert    = ""
 	defServerKey     = ""
 	defJaegerURL     = ""
+	defKeysCleanup   = "1h"
 
 	envLogLevel      = "MF_AUTH_LOG_LEVEL"
 	envDBHost        = "MF_AUTH_DB_HOST"
@@ -61,21 +66,25 @@ const (
 	envHTTPPort      = "MF_AUTH_HTTP_PORT"
 	envGRPCPort      = "MF_AUTH_GRPC_PORT"
 	envSecret        = "MF_AUTH_SECRET"
+	envSigningKeys   = "MF_AUTH_SIGNING_KEYS"
 	envServerCert    = "MF_AUTH_SERVER_CERT"
 	envServerKey     = "MF_AUTH_SERVER_KEY"
 	envJaegerURL     = "MF_JAEGER_URL"
+	envKeysCleanup   = "MF_AUTH_KEYS_CLEANUP_INTERVAL"
 )
 
 type config struct {
-	logLevel   string
-	dbConfig   postgres.Config
-	httpPort   string
-	grpcPort   string
-	secret     string
-	serverCert string
-	serverKey  string
-	jaegerURL  string
-	resetURL   string
+	logLevel    string
+	dbConfig    postgres.Config
+	httpPort    string
+	grpcPort    string
+	secret      string
+	signingKeys []string
+	serverCert  string
+	serverKey   string
+	jaegerURL   string
+	resetURL    string
+	keysCleanup time.Duration
 }
 
 type tokenConfig struct {
@@ -100,11 +109,13 @@ func main() {
 	dbTracer, dbCloser := initJaeger("auth_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, cfg.secret, logger)
+	t := newTokenizer(cfg, logger)
+	svc := newService(db, dbTracer, t, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
 	go startGRPCServer(tracer, svc, cfg.grpcPort, cfg.serverCert, cfg.serverKey, logger, errs)
+	go removeExpiredKeys(svc, cfg.keysCleanup, logger)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -129,15 +140,29 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	var signingKeys []string
+	for _, path := range strings.Split(mainflux.Env(envSigningKeys, defSigningKeys), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			signingKeys = append(signingKeys, path)
+		}
+	}
+
+	keysCleanup, err := time.ParseDuration(mainflux.Env(envKeysCleanup, defKeysCleanup))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envKeysCleanup, err.Error())
+	}
+
 	return config{
-		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
-		dbConfig:   dbConfig,
-		httpPort:   mainflux.Env(envHTTPPort, defHTTPPort),
-		grpcPort:   mainflux.Env(envGRPCPort, defGRPCPort),
-		secret:     mainflux.Env(envSecret, defSecret),
-		serverCert: mainflux.Env(envServerCert, defServerCert),
-		serverKey:  mainflux.Env(envServerKey, defServerKey),
-		jaegerURL:  mainflux.Env(envJaegerURL, defJaegerURL),
+		logLevel:    mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:    dbConfig,
+		httpPort:    mainflux.Env(envHTTPPort, defHTTPPort),
+		grpcPort:    mainflux.Env(envGRPCPort, defGRPCPort),
+		secret:      mainflux.Env(envSecret, defSecret),
+		signingKeys: signingKeys,
+		serverCert:  mainflux.Env(envServerCert, defServerCert),
+		serverKey:   mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:   mainflux.Env(envJaegerURL, defJaegerURL),
+		keysCleanup: keysCleanup,
 	}
 
 }
@@ -175,17 +200,41 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, secret string, logger logger.Logger) auth.Service {
+func newTokenizer(cfg config, logger logger.Logger) auth.Tokenizer {
+	if len(cfg.signingKeys) == 0 {
+		return jwt.New(cfg.secret)
+	}
+
+	keys, err := jwt.LoadKeys(cfg.signingKeys...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to load signing keys: %s", err))
+		os.Exit(1)
+	}
+	// Tokens signed using the shared secret are still verified, so that
+	// switching to the signing keys doesn't invalidate issued API keys.
+	t, err := jwt.NewAsymmetric(cfg.secret, keys...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create tokenizer: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Signing tokens using %s key %s", keys[0].Algorithm, keys[0].ID))
+
+	return t
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, t auth.Tokenizer, logger logger.Logger) auth.Service {
 	database := postgres.NewDatabase(db)
 	keysRepo := tracing.New(postgres.New(database), tracer)
 
 	groupsRepo := postgres.NewGroupRepo(database)
 	groupsRepo = tracing.GroupRepositoryMiddleware(tracer, groupsRepo)
 
+	policiesRepo := postgres.NewPolicyRepo(database)
+	policiesRepo = tracing.PolicyRepositoryMiddleware(tracer, policiesRepo)
+
 	idProvider := uuid.New()
-	t := jwt.New(secret)
 
-	svc := auth.New(keysRepo, groupsRepo, idProvider, t)
+	svc := auth.New(keysRepo, groupsRepo, policiesRepo, idProvider, t)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -206,6 +255,20 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, secret string, logger lo
 	return svc
 }
 
+// removeExpiredKeys periodically removes the expired refresh keys. Sessions
+// are persisted as refresh keys, so the ones which are never refreshed nor
+// logged out would otherwise be kept forever.
+func removeExpiredKeys(svc auth.Service, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := svc.RemoveExpiredKeys(context.Background()); err != nil {
+			logger.Error(fmt.Sprintf("Failed to remove expired keys: %s", err))
+		}
+	}
+}
+
 func startHTTPServer(tracer opentracing.Tracer, svc auth.Service, port string, certFile string, keyFile string, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	if certFile != "" || keyFile != "" {
@@ -20,6 +20,8 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/cassandra"
@@ -46,6 +48,8 @@ const (
 	defServerCert        = ""
 	defServerKey         = ""
 	defJaegerURL         = ""
+	defNatsURL           = "nats://localhost:4222"
+	defContentType       = "application/senml+json"
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
 
@@ -61,6 +65,8 @@ const (
 	envServerCert        = "MF_CASSANDRA_READER_SERVER_CERT"
 	envServerKey         = "MF_CASSANDRA_READER_SERVER_KEY"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envNatsURL           = "MF_NATS_URL"
+	envContentType       = "MF_CASSANDRA_READER_CONTENT_TYPE"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
@@ -74,6 +80,8 @@ type config struct {
 	serverCert        string
 	serverKey         string
 	jaegerURL         string
+	natsURL           string
+	contentType       string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
 }
@@ -98,9 +106,18 @@ func main() {
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
 	repo := newService(session, logger)
 
+	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	stream := readers.NewLiveStream(pubSub, senml.New(cfg.contentType))
+
 	errs := make(chan error, 2)
 
-	go startHTTPServer(repo, tc, cfg, errs, logger)
+	go startHTTPServer(repo, tc, stream, cfg, errs, logger)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -145,6 +162,8 @@ func loadConfig() config {
 		serverCert:        mainflux.Env(envServerCert, defServerCert),
 		serverKey:         mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		contentType:       mainflux.Env(envContentType, defContentType),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
 	}
@@ -230,14 +249,14 @@ func newService(session *gocql.Session, logger logger.Logger) readers.MessageRep
 	return repo
 }
 
-func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, cfg config, errs chan error, logger logger.Logger) {
+func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, stream readers.LiveStream, cfg config, errs chan error, logger logger.Logger) {
 	p := fmt.Sprintf(":%s", cfg.port)
 	if cfg.serverCert != "" || cfg.serverKey != "" {
 		logger.Info(fmt.Sprintf("Cassandra reader service started using https on port %s with cert %s key %s",
 			cfg.port, cfg.serverCert, cfg.serverKey))
-		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, "cassandra-reader"))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, stream, "cassandra-reader"))
 		return
 	}
 	logger.Info(fmt.Sprintf("Cassandra reader service started, exposed port %s", cfg.port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, "cassandra-reader"))
+	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, stream, "cassandra-reader"))
 }
@@ -16,6 +16,7 @@ func main() {
 	sdkConf := sdk.Config{
 		BaseURL:           "http://localhost",
 		ReaderURL:         "http://localhost:8905",
+		WriterURL:         "http://localhost:9104",
 		BootstrapURL:      "http://localhost:8202",
 		CertsURL:          "http://localhost:8204",
 		ReaderPrefix:      "",
@@ -50,6 +51,7 @@ func main() {
 	provisionCmd := cli.NewProvisionCmd()
 	bootstrapCmd := cli.NewBootstrapCmd()
 	certsCmd := cli.NewCertsCmd()
+	deadLettersCmd := cli.NewDeadLettersCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(versionCmd)
@@ -61,6 +63,7 @@ func main() {
 	rootCmd.AddCommand(provisionCmd)
 	rootCmd.AddCommand(bootstrapCmd)
 	rootCmd.AddCommand(certsCmd)
+	rootCmd.AddCommand(deadLettersCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -71,6 +74,14 @@ func main() {
 		"Mainflux host URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.WriterURL,
+		"writer-url",
+		"w",
+		sdkConf.WriterURL,
+		"Mainflux writer URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.UsersPrefix,
 		"users-prefix",
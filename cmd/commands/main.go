@@ -0,0 +1,348 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/jmoiron/sqlx"
+	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
+	"github.com/mainflux/mainflux/commands"
+	"github.com/mainflux/mainflux/commands/api"
+	"github.com/mainflux/mainflux/commands/postgres"
+	"github.com/mainflux/mainflux/commands/tracing"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/ulid"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	queue = "commands"
+
+	defLogLevel       = "error"
+	defDBHost         = "localhost"
+	defDBPort         = "5432"
+	defDBUser         = "mainflux"
+	defDBPass         = "mainflux"
+	defDB             = "commands"
+	defDBSSLMode      = "disable"
+	defDBSSLCert      = ""
+	defDBSSLKey       = ""
+	defDBSSLRootCert  = ""
+	defHTTPPort       = "8909"
+	defServerCert     = ""
+	defServerKey      = ""
+	defTimeout        = "30s"
+	defExpireInterval = "5s"
+	defJaegerURL      = ""
+	defNatsURL        = "nats://localhost:4222"
+
+	defAuthTLS     = "false"
+	defAuthCACerts = ""
+	defAuthURL     = "localhost:8181"
+	defAuthTimeout = "1s"
+
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+
+	envLogLevel       = "MF_COMMANDS_LOG_LEVEL"
+	envDBHost         = "MF_COMMANDS_DB_HOST"
+	envDBPort         = "MF_COMMANDS_DB_PORT"
+	envDBUser         = "MF_COMMANDS_DB_USER"
+	envDBPass         = "MF_COMMANDS_DB_PASS"
+	envDB             = "MF_COMMANDS_DB"
+	envDBSSLMode      = "MF_COMMANDS_DB_SSL_MODE"
+	envDBSSLCert      = "MF_COMMANDS_DB_SSL_CERT"
+	envDBSSLKey       = "MF_COMMANDS_DB_SSL_KEY"
+	envDBSSLRootCert  = "MF_COMMANDS_DB_SSL_ROOT_CERT"
+	envHTTPPort       = "MF_COMMANDS_PORT"
+	envServerCert     = "MF_COMMANDS_SERVER_CERT"
+	envServerKey      = "MF_COMMANDS_SERVER_KEY"
+	envTimeout        = "MF_COMMANDS_TIMEOUT"
+	envExpireInterval = "MF_COMMANDS_EXPIRE_INTERVAL"
+	envJaegerURL      = "MF_JAEGER_URL"
+	envNatsURL        = "MF_NATS_URL"
+
+	envAuthTLS     = "MF_AUTH_CLIENT_TLS"
+	envAuthCACerts = "MF_AUTH_CA_CERTS"
+	envAuthURL     = "MF_AUTH_GRPC_URL"
+	envAuthTimeout = "MF_AUTH_GRPC_TIMEOUT"
+
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	natsURL           string
+	logLevel          string
+	dbConfig          postgres.Config
+	httpPort          string
+	serverCert        string
+	serverKey         string
+	timeout           time.Duration
+	expireInterval    time.Duration
+	jaegerURL         string
+	authTLS           bool
+	authCACerts       string
+	authURL           string
+	authTimeout       time.Duration
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	pubSub, err := nats.NewPubSub(cfg.natsURL, queue, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	authTracer, closer := initJaeger("auth", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	auth, close := connectToAuth(cfg, authTracer, logger)
+	if close != nil {
+		defer close()
+	}
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+	things := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
+
+	tracer, closer := initJaeger("commands", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	dbTracer, dbCloser := initJaeger("commands_db", cfg.jaegerURL, logger)
+	defer dbCloser.Close()
+
+	svc := newService(db, dbTracer, auth, things, pubSub, cfg, logger)
+	errs := make(chan error, 2)
+
+	if err := pubSub.Subscribe(commands.AckSubject, svc.HandleAck); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to acknowledgements: %s", err))
+		os.Exit(1)
+	}
+
+	go expireCommands(svc, cfg.expireInterval, logger)
+
+	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+
+	err = <-errs
+	logger.Error(fmt.Sprintf("Commands service terminated: %s", err))
+}
+
+func loadConfig() config {
+	authTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	expireInterval, err := time.ParseDuration(mainflux.Env(envExpireInterval, defExpireInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envExpireInterval, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	return config{
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		dbConfig:          dbConfig,
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		timeout:           timeout,
+		expireInterval:    expireInterval,
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authTLS:           tls,
+		authCACerts:       mainflux.Env(envAuthCACerts, defAuthCACerts),
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       authTimeout,
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func grpcOptions(cfg config, logger logger.Logger) []grpc.DialOption {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+	return opts
+}
+
+func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+	conn, err := grpc.Dial(cfg.authURL, grpcOptions(cfg, logger)...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+
+	return authapi.NewClient(tracer, conn, cfg.authTimeout), conn.Close
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	conn, err := grpc.Dial(cfg.thingsAuthURL, grpcOptions(cfg, logger)...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, pub messaging.Publisher, c config, logger logger.Logger) commands.Service {
+	database := postgres.NewDatabase(db)
+	repo := tracing.CommandRepositoryMiddleware(tracer, postgres.New(database))
+	idp := ulid.New()
+
+	svc := commands.New(auth, things, repo, idp, pub, c.timeout)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "commands",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "commands",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+	return svc
+}
+
+// expireCommands periodically times out the commands that are not
+// acknowledged in time.
+func expireCommands(svc commands.Service, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := svc.ExpireCommands(context.Background()); err != nil {
+			logger.Error(fmt.Sprintf("Failed to expire commands: %s", err))
+		}
+	}
+}
+
+func startHTTPServer(tracer opentracing.Tracer, svc commands.Service, port string, certFile string, keyFile string, logger logger.Logger, errs chan error) {
+	p := fmt.Sprintf(":%s", port)
+	if certFile != "" || keyFile != "" {
+		logger.Info(fmt.Sprintf("Commands service started using https, cert %s key %s, exposed port %s", certFile, keyFile, port))
+		errs <- http.ListenAndServeTLS(p, certFile, keyFile, api.MakeHandler(svc, tracer))
+	} else {
+		logger.Info(fmt.Sprintf("Commands service started using http, exposed port %s", port))
+		errs <- http.ListenAndServe(p, api.MakeHandler(svc, tracer))
+	}
+}
@@ -16,6 +16,8 @@ import (
 	influxdata "github.com/influxdata/influxdb/client/v2"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/influxdb"
@@ -40,6 +42,8 @@ const (
 	defServerCert        = ""
 	defServerKey         = ""
 	defJaegerURL         = ""
+	defNatsURL           = "nats://localhost:4222"
+	defContentType       = "application/senml+json"
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
 
@@ -55,6 +59,8 @@ const (
 	envServerCert        = "MF_INFLUX_READER_SERVER_CERT"
 	envServerKey         = "MF_INFLUX_READER_SERVER_KEY"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envNatsURL           = "MF_NATS_URL"
+	envContentType       = "MF_INFLUX_READER_CONTENT_TYPE"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
@@ -72,6 +78,8 @@ type config struct {
 	serverCert        string
 	serverKey         string
 	jaegerURL         string
+	natsURL           string
+	contentType       string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
 }
@@ -99,6 +107,15 @@ func main() {
 
 	repo := newService(client, cfg.dbName, logger)
 
+	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	stream := readers.NewLiveStream(pubSub, senml.New(cfg.contentType))
+
 	errs := make(chan error, 2)
 	go func() {
 		c := make(chan os.Signal)
@@ -106,7 +123,7 @@ func main() {
 		errs <- fmt.Errorf("%s", <-c)
 	}()
 
-	go startHTTPServer(repo, tc, cfg, logger, errs)
+	go startHTTPServer(repo, tc, stream, cfg, logger, errs)
 
 	err = <-errs
 	logger.Error(fmt.Sprintf("InfluxDB writer service terminated: %s", err))
@@ -136,6 +153,8 @@ func loadConfigs() (config, influxdata.HTTPConfig) {
 		serverCert:        mainflux.Env(envServerCert, defServerCert),
 		serverKey:         mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		contentType:       mainflux.Env(envContentType, defContentType),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
 	}
@@ -219,14 +238,14 @@ func newService(client influxdata.Client, dbName string, logger logger.Logger) r
 	return repo
 }
 
-func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, cfg config, logger logger.Logger, errs chan error) {
+func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, stream readers.LiveStream, cfg config, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.port)
 	if cfg.serverCert != "" || cfg.serverKey != "" {
 		logger.Info(fmt.Sprintf("InfluxDB reader service started using https on port %s with cert %s key %s",
 			cfg.port, cfg.serverCert, cfg.serverKey))
-		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, "influxdb-reader"))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, stream, "influxdb-reader"))
 		return
 	}
 	logger.Info(fmt.Sprintf("InfluxDB reader service started, exposed port %s", cfg.port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, "influxdb-reader"))
+	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, stream, "influxdb-reader"))
 }
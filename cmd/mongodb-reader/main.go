@@ -19,6 +19,8 @@ import (
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/mongodb"
@@ -43,6 +45,8 @@ const (
 	defServerCert        = ""
 	defServerKey         = ""
 	defJaegerURL         = ""
+	defNatsURL           = "nats://localhost:4222"
+	defContentType       = "application/senml+json"
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
 
@@ -56,6 +60,8 @@ const (
 	envServerCert        = "MF_MONGO_READER_SERVER_CERT"
 	envServerKey         = "MF_MONGO_READER_SERVER_KEY"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envNatsURL           = "MF_NATS_URL"
+	envContentType       = "MF_MONGO_READER_CONTENT_TYPE"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
@@ -71,6 +77,8 @@ type config struct {
 	serverCert        string
 	serverKey         string
 	jaegerURL         string
+	natsURL           string
+	contentType       string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
 }
@@ -94,6 +102,15 @@ func main() {
 
 	repo := newService(db, logger)
 
+	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	stream := readers.NewLiveStream(pubSub, senml.New(cfg.contentType))
+
 	errs := make(chan error, 2)
 	go func() {
 		c := make(chan os.Signal)
@@ -101,7 +118,7 @@ func main() {
 		errs <- fmt.Errorf("%s", <-c)
 	}()
 
-	go startHTTPServer(repo, tc, cfg, logger, errs)
+	go startHTTPServer(repo, tc, stream, cfg, logger, errs)
 
 	err = <-errs
 	logger.Error(fmt.Sprintf("MongoDB reader service terminated: %s", err))
@@ -129,6 +146,8 @@ func loadConfigs() config {
 		serverCert:        mainflux.Env(envServerCert, defServerCert),
 		serverKey:         mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		contentType:       mainflux.Env(envContentType, defContentType),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
 	}
@@ -215,14 +234,14 @@ func newService(db *mongo.Database, logger logger.Logger) readers.MessageReposit
 	return repo
 }
 
-func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, cfg config, logger logger.Logger, errs chan error) {
+func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, stream readers.LiveStream, cfg config, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", cfg.port)
 	if cfg.serverCert != "" || cfg.serverKey != "" {
 		logger.Info(fmt.Sprintf("Mongo reader service started using https on port %s with cert %s key %s",
 			cfg.port, cfg.serverCert, cfg.serverKey))
-		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, "mongodb-reader"))
+		errs <- http.ListenAndServeTLS(p, cfg.serverCert, cfg.serverKey, api.MakeHandler(repo, tc, stream, "mongodb-reader"))
 		return
 	}
 	logger.Info(fmt.Sprintf("Mongo reader service started, exposed port %s", cfg.port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, "mongodb-reader"))
+	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, stream, "mongodb-reader"))
 }
@@ -19,6 +19,8 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging/nats"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/mainflux/mainflux/readers/api"
 	"github.com/mainflux/mainflux/readers/postgres"
@@ -48,6 +50,8 @@ const (
 	defDBSSLKey          = ""
 	defDBSSLRootCert     = ""
 	defJaegerURL         = ""
+	defNatsURL           = "nats://localhost:4222"
+	defContentType       = "application/senml+json"
 	defThingsAuthURL     = "localhost:8181"
 	defThingsAuthTimeout = "1s"
 
@@ -65,6 +69,8 @@ const (
 	envDBSSLKey          = "MF_POSTGRES_READER_DB_SSL_KEY"
 	envDBSSLRootCert     = "MF_POSTGRES_READER_DB_SSL_ROOT_CERT"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envNatsURL           = "MF_NATS_URL"
+	envContentType       = "MF_POSTGRES_READER_CONTENT_TYPE"
 	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
@@ -76,6 +82,8 @@ type config struct {
 	caCerts           string
 	dbConfig          postgres.Config
 	jaegerURL         string
+	natsURL           string
+	contentType       string
 	thingsAuthURL     string
 	thingsAuthTimeout time.Duration
 }
@@ -101,9 +109,18 @@ func main() {
 
 	repo := newService(db, logger)
 
+	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	stream := readers.NewLiveStream(pubSub, senml.New(cfg.contentType))
+
 	errs := make(chan error, 2)
 
-	go startHTTPServer(repo, tc, cfg.port, logger, errs)
+	go startHTTPServer(repo, tc, stream, cfg.port, logger, errs)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -145,6 +162,8 @@ func loadConfig() config {
 		caCerts:           mainflux.Env(envCACerts, defCACerts),
 		dbConfig:          dbConfig,
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		contentType:       mainflux.Env(envContentType, defContentType),
 		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
 		thingsAuthTimeout: authTimeout,
 	}
@@ -229,8 +248,8 @@ func newService(db *sqlx.DB, logger logger.Logger) readers.MessageRepository {
 	return svc
 }
 
-func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, port string, logger logger.Logger, errs chan error) {
+func startHTTPServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, stream readers.LiveStream, port string, logger logger.Logger, errs chan error) {
 	p := fmt.Sprintf(":%s", port)
 	logger.Info(fmt.Sprintf("Postgres reader service started, exposed port %s", port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, svcName))
+	errs <- http.ListenAndServe(p, api.MakeHandler(repo, tc, stream, svcName))
 }
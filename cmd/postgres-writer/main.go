@@ -9,68 +9,121 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
 	"github.com/mainflux/mainflux"
+	authapi "github.com/mainflux/mainflux/auth/api/grpc"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	dlapi "github.com/mainflux/mainflux/consumers/deadletter/api"
+	dljs "github.com/mainflux/mainflux/consumers/deadletter/jetstream"
+	"github.com/mainflux/mainflux/consumers/writers"
 	"github.com/mainflux/mainflux/consumers/writers/api"
 	"github.com/mainflux/mainflux/consumers/writers/postgres"
 	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	"github.com/mainflux/mainflux/pkg/transformers"
 	"github.com/mainflux/mainflux/pkg/transformers/json"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName = "postgres-writer"
 	sep     = ","
 
-	defLogLevel      = "error"
-	defNatsURL       = "nats://localhost:4222"
-	defPort          = "8180"
-	defDBHost        = "localhost"
-	defDBPort        = "5432"
-	defDBUser        = "mainflux"
-	defDBPass        = "mainflux"
-	defDB            = "mainflux"
-	defDBSSLMode     = "disable"
-	defDBSSLCert     = ""
-	defDBSSLKey      = ""
-	defDBSSLRootCert = ""
-	defConfigPath    = "/config.toml"
-	defContentType   = "application/senml+json"
-	defTransformer   = "senml"
-
-	envNatsURL       = "MF_NATS_URL"
-	envLogLevel      = "MF_POSTGRES_WRITER_LOG_LEVEL"
-	envPort          = "MF_POSTGRES_WRITER_PORT"
-	envDBHost        = "MF_POSTGRES_WRITER_DB_HOST"
-	envDBPort        = "MF_POSTGRES_WRITER_DB_PORT"
-	envDBUser        = "MF_POSTGRES_WRITER_DB_USER"
-	envDBPass        = "MF_POSTGRES_WRITER_DB_PASS"
-	envDB            = "MF_POSTGRES_WRITER_DB"
-	envDBSSLMode     = "MF_POSTGRES_WRITER_DB_SSL_MODE"
-	envDBSSLCert     = "MF_POSTGRES_WRITER_DB_SSL_CERT"
-	envDBSSLKey      = "MF_POSTGRES_WRITER_DB_SSL_KEY"
-	envDBSSLRootCert = "MF_POSTGRES_WRITER_DB_SSL_ROOT_CERT"
-	envConfigPath    = "MF_POSTGRES_WRITER_CONFIG_PATH"
-	envContentType   = "MF_POSTGRES_WRITER_CONTENT_TYPE"
-	envTransformer   = "MF_POSTGRES_WRITER_TRANSFORMER"
+	defLogLevel          = "error"
+	defNatsURL           = "nats://localhost:4222"
+	defPort              = "8180"
+	defDBHost            = "localhost"
+	defDBPort            = "5432"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "mainflux"
+	defDBSSLMode         = "disable"
+	defDBSSLCert         = ""
+	defDBSSLKey          = ""
+	defDBSSLRootCert     = ""
+	defConfigPath        = "/config.toml"
+	defContentType       = "application/senml+json"
+	defTransformer       = "senml"
+	defBroker            = "nats"
+	defJSStream          = jetstream.DefaultStream
+	defJSMaxDeliver      = "5"
+	defJSReplayFrom      = ""
+	defDLQSubject        = ""
+	defDLQRetries        = "3"
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defAuthURL           = "localhost:8181"
+	defAuthTimeout       = "1s"
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+	defBatchSize         = "1"
+	defBatchMaxLatency   = "1s"
+
+	envNatsURL           = "MF_NATS_URL"
+	envLogLevel          = "MF_POSTGRES_WRITER_LOG_LEVEL"
+	envPort              = "MF_POSTGRES_WRITER_PORT"
+	envDBHost            = "MF_POSTGRES_WRITER_DB_HOST"
+	envDBPort            = "MF_POSTGRES_WRITER_DB_PORT"
+	envDBUser            = "MF_POSTGRES_WRITER_DB_USER"
+	envDBPass            = "MF_POSTGRES_WRITER_DB_PASS"
+	envDB                = "MF_POSTGRES_WRITER_DB"
+	envDBSSLMode         = "MF_POSTGRES_WRITER_DB_SSL_MODE"
+	envDBSSLCert         = "MF_POSTGRES_WRITER_DB_SSL_CERT"
+	envDBSSLKey          = "MF_POSTGRES_WRITER_DB_SSL_KEY"
+	envDBSSLRootCert     = "MF_POSTGRES_WRITER_DB_SSL_ROOT_CERT"
+	envConfigPath        = "MF_POSTGRES_WRITER_CONFIG_PATH"
+	envContentType       = "MF_POSTGRES_WRITER_CONTENT_TYPE"
+	envTransformer       = "MF_POSTGRES_WRITER_TRANSFORMER"
+	envBroker            = "MF_POSTGRES_WRITER_BROKER"
+	envJSStream          = "MF_JETSTREAM_STREAM"
+	envJSDurable         = "MF_POSTGRES_WRITER_DURABLE"
+	envJSMaxDeliver      = "MF_POSTGRES_WRITER_MAX_DELIVER"
+	envJSReplayFrom      = "MF_POSTGRES_WRITER_REPLAY_FROM"
+	envDLQSubject        = "MF_POSTGRES_WRITER_DLQ_SUBJECT"
+	envDLQRetries        = "MF_POSTGRES_WRITER_DLQ_RETRIES"
+	envClientTLS         = "MF_POSTGRES_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_POSTGRES_WRITER_CA_CERTS"
+	envAuthURL           = "MF_AUTH_GRPC_URL"
+	envAuthTimeout       = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envBatchSize         = "MF_POSTGRES_WRITER_BATCH_SIZE"
+	envBatchMaxLatency   = "MF_POSTGRES_WRITER_BATCH_MAX_LATENCY"
 )
 
 type config struct {
-	natsURL     string
-	logLevel    string
-	port        string
-	configPath  string
-	contentType string
-	transformer string
-	dbConfig    postgres.Config
+	natsURL           string
+	logLevel          string
+	port              string
+	configPath        string
+	contentType       string
+	transformer       string
+	broker            string
+	jetStream         jetstream.Config
+	dlqSubject        string
+	dlqRetries        int
+	clientTLS         bool
+	caCerts           string
+	authURL           string
+	authTimeout       time.Duration
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
+	batch             writers.BufferConfig
+	dbConfig          postgres.Config
 }
 
 func main() {
@@ -81,11 +134,7 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
-	pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
-	if err != nil {
-		logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
-		os.Exit(1)
-	}
+	pubSub := connectToBroker(cfg, logger)
 	defer pubSub.Close()
 
 	db := connectToDB(cfg.dbConfig, logger)
@@ -94,13 +143,60 @@ func main() {
 	repo := newService(db, logger)
 	t := makeTransformer(cfg, logger)
 
-	if err = consumers.Start(pubSub, repo, t, cfg.configPath, logger); err != nil {
+	dlCfg := consumers.DeadLetterConfig{Consumer: svcName, Retries: cfg.dlqRetries}
+	var dls deadletter.Service
+	if cfg.dlqSubject != "" {
+		dlq, err := dljs.New(cfg.natsURL, cfg.dlqSubject)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create dead letter queue: %s", err))
+			os.Exit(1)
+		}
+		defer dlq.Close()
+		dlCfg.Publisher = dlq
+
+		authConn := connectToGRPC(cfg, cfg.authURL, "auth", logger)
+		defer authConn.Close()
+		thingsConn := connectToGRPC(cfg, cfg.thingsAuthURL, "things", logger)
+		defer thingsConn.Close()
+		auth := authapi.NewClient(opentracing.NoopTracer{}, authConn, cfg.authTimeout)
+		tc := thingsapi.NewClient(thingsConn, opentracing.NoopTracer{}, cfg.thingsAuthTimeout)
+		dls = newDeadLetterService(auth, tc, dlq, pubSub, logger)
+	}
+
+	if cfg.batch.BatchSize > 1 {
+		// The buffer transforms the messages itself, so that the original
+		// messages of the failed batches can be dead-lettered.
+		buf := writers.NewBuffer(repo, t, cfg.batch, dlCfg, logger)
+		defer func() {
+			if err := buf.Close(); err != nil {
+				logger.Error(fmt.Sprintf("Failed to flush buffered messages: %s", err))
+			}
+		}()
+		repo = api.BufferMetricsMiddleware(
+			buf,
+			kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: "postgres",
+				Subsystem: "message_writer",
+				Name:      "buffer_fill",
+				Help:      "Number of buffered messages.",
+			}, []string{}),
+			kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Namespace: "postgres",
+				Subsystem: "message_writer",
+				Name:      "flush_latency_seconds",
+				Help:      "Duration of buffer flushes in seconds.",
+			}, []string{}),
+		)
+		t = nil
+	}
+
+	if err = consumers.Start(pubSub, repo, t, cfg.configPath, dlCfg, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Postgres writer: %s", err))
 	}
 
 	errs := make(chan error, 2)
 
-	go startHTTPServer(cfg.port, errs, logger)
+	go startHTTPServer(cfg.port, errs, dls, logger)
 
 	go func() {
 		c := make(chan os.Signal)
@@ -125,14 +221,76 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	batchSize, err := strconv.Atoi(mainflux.Env(envBatchSize, defBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchSize, err.Error())
+	}
+	if batchSize > 1 && strings.ToUpper(mainflux.Env(envBroker, defBroker)) == "JETSTREAM" {
+		log.Fatalf("%s can't be greater than 1 with JetStream broker, since buffered messages are acknowledged before they are written", envBatchSize)
+	}
+
+	batchMaxLatency, err := time.ParseDuration(mainflux.Env(envBatchMaxLatency, defBatchMaxLatency))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBatchMaxLatency, err.Error())
+	}
+
+	dlqRetries, err := strconv.Atoi(mainflux.Env(envDLQRetries, defDLQRetries))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envDLQRetries, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authTimeout, err := time.ParseDuration(mainflux.Env(envAuthTimeout, defAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
+	}
+
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
+	maxDeliver, err := strconv.Atoi(mainflux.Env(envJSMaxDeliver, defJSMaxDeliver))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envJSMaxDeliver, err.Error())
+	}
+
+	startSeq, startTime, err := jetstream.ParseReplayStart(mainflux.Env(envJSReplayFrom, defJSReplayFrom))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envJSReplayFrom, err.Error())
+	}
+
+	jsCfg := jetstream.Config{
+		Stream:     mainflux.Env(envJSStream, defJSStream),
+		Durable:    mainflux.Env(envJSDurable, svcName),
+		MaxDeliver: maxDeliver,
+		StartSeq:   startSeq,
+		StartTime:  startTime,
+	}
+
 	return config{
-		natsURL:     mainflux.Env(envNatsURL, defNatsURL),
-		logLevel:    mainflux.Env(envLogLevel, defLogLevel),
-		port:        mainflux.Env(envPort, defPort),
-		configPath:  mainflux.Env(envConfigPath, defConfigPath),
-		contentType: mainflux.Env(envContentType, defContentType),
-		transformer: mainflux.Env(envTransformer, defTransformer),
-		dbConfig:    dbConfig,
+		natsURL:           mainflux.Env(envNatsURL, defNatsURL),
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		contentType:       mainflux.Env(envContentType, defContentType),
+		transformer:       mainflux.Env(envTransformer, defTransformer),
+		broker:            mainflux.Env(envBroker, defBroker),
+		jetStream:         jsCfg,
+		dlqSubject:        mainflux.Env(envDLQSubject, defDLQSubject),
+		dlqRetries:        dlqRetries,
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		authURL:           mainflux.Env(envAuthURL, defAuthURL),
+		authTimeout:       authTimeout,
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
+		batch:             writers.BufferConfig{BatchSize: batchSize, MaxLatency: batchMaxLatency},
+		dbConfig:          dbConfig,
 	}
 }
 
@@ -167,6 +325,78 @@ func newService(db *sqlx.DB, logger logger.Logger) consumers.Consumer {
 	return svc
 }
 
+func connectToGRPC(cfg config, url, name string, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", name, err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func newDeadLetterService(auth mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, dlq *dljs.Queue, pub messaging.Publisher, logger logger.Logger) deadletter.Service {
+	svc := deadletter.New(auth, tc, dlq, pub)
+	svc = dlapi.LoggingMiddleware(svc, logger)
+	svc = dlapi.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "postgres",
+			Subsystem: "dead_letters",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "postgres",
+			Subsystem: "dead_letters",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func connectToBroker(cfg config, logger logger.Logger) nats.PubSub {
+	switch strings.ToUpper(cfg.broker) {
+	case "NATS":
+		logger.Info("Using NATS broker")
+		pubSub, err := nats.NewPubSub(cfg.natsURL, "", logger)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to NATS: %s", err))
+			os.Exit(1)
+		}
+		return pubSub
+	case "JETSTREAM":
+		logger.Info("Using NATS JetStream broker")
+		pubSub, err := jetstream.NewPubSub(cfg.natsURL, cfg.jetStream, logger)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to NATS JetStream: %s", err))
+			os.Exit(1)
+		}
+		return pubSub
+	default:
+		logger.Error(fmt.Sprintf("Can't connect to broker: unknown broker type %s", cfg.broker))
+		os.Exit(1)
+		return nil
+	}
+}
+
 func makeTransformer(cfg config, logger logger.Logger) transformers.Transformer {
 	switch strings.ToUpper(cfg.transformer) {
 	case "SENML":
@@ -182,8 +412,8 @@ func makeTransformer(cfg config, logger logger.Logger) transformers.Transformer
 	}
 }
 
-func startHTTPServer(port string, errs chan error, logger logger.Logger) {
+func startHTTPServer(port string, errs chan error, dls deadletter.Service, logger logger.Logger) {
 	p := fmt.Sprintf(":%s", port)
 	logger.Info(fmt.Sprintf("Postgres writer service started, exposed port %s", port))
-	errs <- http.ListenAndServe(p, api.MakeHandler(svcName))
+	errs <- http.ListenAndServe(p, api.MakeHandler(svcName, dls))
 }
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -25,10 +26,13 @@ import (
 	"github.com/mainflux/mainflux/consumers/notifiers/postgres"
 	"github.com/mainflux/mainflux/consumers/notifiers/smtp"
 	"github.com/mainflux/mainflux/consumers/notifiers/tracing"
+	"github.com/mainflux/mainflux/consumers/notifiers/webhook"
 	"github.com/mainflux/mainflux/internal/email"
 	"github.com/mainflux/mainflux/logger"
 	"github.com/mainflux/mainflux/pkg/messaging/nats"
 	"github.com/mainflux/mainflux/pkg/ulid"
+	thingsapi "github.com/mainflux/mainflux/things/api/auth/grpc"
+	"github.com/mainflux/mainflux/users/aes"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -63,11 +67,22 @@ const (
 	defEmailFromName    = ""
 	defEmailTemplate    = "email.tmpl"
 
+	defWebhookTimeout      = "10s"
+	defWebhookRetries      = "3"
+	defWebhookBackoff      = "1s"
+	defWebhookWorkers      = "10"
+	defWebhookQueueSize    = "1000"
+	defWebhookAllowPrivate = "false"
+	defSecretKey           = ""
+
 	defAuthTLS     = "false"
 	defAuthCACerts = ""
 	defAuthURL     = "localhost:8181"
 	defAuthTimeout = "1s"
 
+	defThingsAuthURL     = "localhost:8181"
+	defThingsAuthTimeout = "1s"
+
 	envLogLevel      = "MF_SMTP_NOTIFIER_LOG_LEVEL"
 	envDBHost        = "MF_SMTP_NOTIFIER_DB_HOST"
 	envDBPort        = "MF_SMTP_NOTIFIER_DB_PORT"
@@ -94,10 +109,21 @@ const (
 	envEmailFromName    = "MF_EMAIL_FROM_NAME"
 	envEmailTemplate    = "MF_EMAIL_TEMPLATE"
 
+	envWebhookTimeout      = "MF_SMTP_NOTIFIER_WEBHOOK_TIMEOUT"
+	envWebhookRetries      = "MF_SMTP_NOTIFIER_WEBHOOK_RETRIES"
+	envWebhookBackoff      = "MF_SMTP_NOTIFIER_WEBHOOK_BACKOFF"
+	envWebhookWorkers      = "MF_SMTP_NOTIFIER_WEBHOOK_WORKERS"
+	envWebhookQueueSize    = "MF_SMTP_NOTIFIER_WEBHOOK_QUEUE_SIZE"
+	envWebhookAllowPrivate = "MF_SMTP_NOTIFIER_WEBHOOK_ALLOW_PRIVATE"
+	envSecretKey           = "MF_SMTP_NOTIFIER_SECRET_KEY"
+
 	envAuthTLS     = "MF_AUTH_CLIENT_TLS"
 	envAuthCACerts = "MF_AUTH_CA_CERTS"
 	envAuthURL     = "MF_AUTH_GRPC_URL"
 	envAuthTimeout = "MF_AUTH_GRPC_TIMEOUT"
+
+	envThingsAuthURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsAuthTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
@@ -106,6 +132,8 @@ type config struct {
 	logLevel    string
 	dbConfig    postgres.Config
 	emailConf   email.Config
+	webhookConf webhook.Config
+	secretKey   string
 	httpPort    string
 	serverCert  string
 	serverKey   string
@@ -114,6 +142,9 @@ type config struct {
 	authCACerts string
 	authURL     string
 	authTimeout time.Duration
+
+	thingsAuthURL     string
+	thingsAuthTimeout time.Duration
 }
 
 func main() {
@@ -142,16 +173,23 @@ func main() {
 		defer close()
 	}
 
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	conn := connectToThings(cfg, logger)
+	defer conn.Close()
+	things := thingsapi.NewClient(conn, thingsTracer, cfg.thingsAuthTimeout)
+
 	tracer, closer := initJaeger("smtp-notifier", cfg.jaegerURL, logger)
 	defer closer.Close()
 
 	dbTracer, dbCloser := initJaeger("smtp-notifier_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, auth, cfg, logger)
+	svc := newService(db, dbTracer, auth, things, cfg, logger)
 	errs := make(chan error, 2)
 
-	if err = consumers.Start(pubSub, svc, nil, cfg.configPath, logger); err != nil {
+	if err = consumers.Start(pubSub, svc, nil, cfg.configPath, consumers.DeadLetterConfig{}, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Postgres writer: %s", err))
 	}
 
@@ -173,6 +211,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthTimeout, err.Error())
 	}
 
+	thingsAuthTimeout, err := time.ParseDuration(mainflux.Env(envThingsAuthTimeout, defThingsAuthTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsAuthTimeout, err.Error())
+	}
+
 	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
 	if err != nil {
 		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
@@ -201,12 +244,53 @@ func loadConfig() config {
 		Template:    mainflux.Env(envEmailTemplate, defEmailTemplate),
 	}
 
+	webhookTimeout, err := time.ParseDuration(mainflux.Env(envWebhookTimeout, defWebhookTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookTimeout, err.Error())
+	}
+
+	webhookRetries, err := strconv.Atoi(mainflux.Env(envWebhookRetries, defWebhookRetries))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookRetries, err.Error())
+	}
+
+	webhookBackoff, err := time.ParseDuration(mainflux.Env(envWebhookBackoff, defWebhookBackoff))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookBackoff, err.Error())
+	}
+
+	webhookWorkers, err := strconv.Atoi(mainflux.Env(envWebhookWorkers, defWebhookWorkers))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookWorkers, err.Error())
+	}
+
+	webhookQueueSize, err := strconv.Atoi(mainflux.Env(envWebhookQueueSize, defWebhookQueueSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookQueueSize, err.Error())
+	}
+
+	webhookAllowPrivate, err := strconv.ParseBool(mainflux.Env(envWebhookAllowPrivate, defWebhookAllowPrivate))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWebhookAllowPrivate, err.Error())
+	}
+
+	webhookConf := webhook.Config{
+		Timeout:      webhookTimeout,
+		Retries:      webhookRetries,
+		Backoff:      webhookBackoff,
+		Workers:      webhookWorkers,
+		QueueSize:    webhookQueueSize,
+		AllowPrivate: webhookAllowPrivate,
+	}
+
 	return config{
 		logLevel:    mainflux.Env(envLogLevel, defLogLevel),
 		natsURL:     mainflux.Env(envNatsURL, defNatsURL),
 		configPath:  mainflux.Env(envConfigPath, defConfigPath),
 		dbConfig:    dbConfig,
 		emailConf:   emailConf,
+		webhookConf: webhookConf,
+		secretKey:   mainflux.Env(envSecretKey, defSecretKey),
 		httpPort:    mainflux.Env(envHTTPPort, defHTTPPort),
 		serverCert:  mainflux.Env(envServerCert, defServerCert),
 		serverKey:   mainflux.Env(envServerKey, defServerKey),
@@ -215,6 +299,9 @@ func loadConfig() config {
 		authCACerts: mainflux.Env(envAuthCACerts, defAuthCACerts),
 		authURL:     mainflux.Env(envAuthURL, defAuthURL),
 		authTimeout: authTimeout,
+
+		thingsAuthURL:     mainflux.Env(envThingsAuthURL, defThingsAuthURL),
+		thingsAuthTimeout: thingsAuthTimeout,
 	}
 
 }
@@ -252,7 +339,7 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+func grpcOptions(cfg config, logger logger.Logger) []grpc.DialOption {
 	var opts []grpc.DialOption
 	if cfg.authTLS {
 		if cfg.authCACerts != "" {
@@ -267,8 +354,11 @@ func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 		opts = append(opts, grpc.WithInsecure())
 		logger.Info("gRPC communication is not encrypted")
 	}
+	return opts
+}
 
-	conn, err := grpc.Dial(cfg.authURL, opts...)
+func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+	conn, err := grpc.Dial(cfg.authURL, grpcOptions(cfg, logger)...)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
 		os.Exit(1)
@@ -277,7 +367,38 @@ func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 	return authapi.NewClient(tracer, conn, cfg.authTimeout), conn.Close
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServiceClient, c config, logger logger.Logger) notifiers.Service {
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	conn, err := grpc.Dial(cfg.thingsAuthURL, grpcOptions(cfg, logger)...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	return conn
+}
+
+// newCipher returns the cipher used to encrypt subscription secrets. If the
+// key is not set, creating subscriptions with secrets is disabled.
+func newCipher(c config, logger logger.Logger) notifiers.Cipher {
+	if c.secretKey == "" {
+		logger.Info(fmt.Sprintf("Subscription secrets are disabled, since %s is not set", envSecretKey))
+		return nil
+	}
+
+	key, err := hex.DecodeString(c.secretKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s value: %s", envSecretKey, err))
+		os.Exit(1)
+	}
+	cipher, err := aes.New(key)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s value: %s", envSecretKey, err))
+		os.Exit(1)
+	}
+
+	return cipher
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, c config, logger logger.Logger) notifiers.Service {
 	database := postgres.NewDatabase(db)
 	repo := tracing.New(postgres.New(database), tracer)
 	idp := ulid.New()
@@ -288,8 +409,14 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServic
 		os.Exit(1)
 	}
 
-	notifier := smtp.New(agent)
-	svc := notifiers.New(auth, repo, idp, notifier)
+	// Subscriptions with URL contacts are notified using webhooks,
+	// while the rest of them are notified by email.
+	wh := webhook.New(repo, c.webhookConf, logger)
+	notifier := notifiers.NewRouter(smtp.New(agent), map[string]notifiers.Notifier{
+		"http":  wh,
+		"https": wh,
+	})
+	svc := notifiers.New(auth, things, repo, idp, notifier, newCipher(c, logger))
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,14 +15,17 @@ import (
 	"os/signal"
 	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/mainflux/mainflux/internal/email"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/aes"
 	"github.com/mainflux/mainflux/users/bcrypt"
 	"github.com/mainflux/mainflux/users/emailer"
+	"github.com/mainflux/mainflux/users/oidc"
 	"github.com/mainflux/mainflux/users/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -74,6 +78,13 @@ const (
 	defAuthURL     = "localhost:8181"
 	defAuthTimeout = "1s"
 
+	defOIDCProviders = ""
+	defOIDCTimeout   = "10s"
+	defOIDCScopes    = "email,profile"
+	defOIDCClaims    = ""
+
+	defTOTPKey = ""
+
 	envLogLevel      = "MF_USERS_LOG_LEVEL"
 	envDBHost        = "MF_USERS_DB_HOST"
 	envDBPort        = "MF_USERS_DB_PORT"
@@ -109,6 +120,19 @@ const (
 	envAuthCACerts = "MF_AUTH_CA_CERTS"
 	envAuthURL     = "MF_AUTH_GRPC_URL"
 	envAuthTimeout = "MF_AUTH_GRPC_TIMEOUT"
+
+	envOIDCProviders = "MF_USERS_OIDC_PROVIDERS"
+	envOIDCTimeout   = "MF_USERS_OIDC_TIMEOUT"
+
+	// Provider specific variables, formatted with the provider name.
+	envOIDCIssuer       = "MF_USERS_OIDC_%s_ISSUER"
+	envOIDCClientID     = "MF_USERS_OIDC_%s_CLIENT_ID"
+	envOIDCClientSecret = "MF_USERS_OIDC_%s_CLIENT_SECRET"
+	envOIDCRedirectURL  = "MF_USERS_OIDC_%s_REDIRECT_URL"
+	envOIDCScopes       = "MF_USERS_OIDC_%s_SCOPES"
+	envOIDCClaims       = "MF_USERS_OIDC_%s_CLAIMS"
+
+	envTOTPKey = "MF_USERS_TOTP_KEY"
 )
 
 type config struct {
@@ -127,6 +151,9 @@ type config struct {
 	adminEmail    string
 	adminPassword string
 	passRegex     *regexp.Regexp
+	oidcTimeout   time.Duration
+	oidc          map[string]oidc.Config
+	totpKey       string
 }
 
 func main() {
@@ -153,7 +180,9 @@ func main() {
 	dbTracer, dbCloser := initJaeger("users_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, auth, cfg, logger)
+	providers := connectToProviders(cfg, logger)
+
+	svc := newService(db, dbTracer, auth, providers, cfg, logger)
 	errs := make(chan error, 2)
 
 	go startHTTPServer(tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger, errs)
@@ -184,6 +213,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid password validation rules %s\n", envPassRegex)
 	}
 
+	oidcTimeout, err := time.ParseDuration(mainflux.Env(envOIDCTimeout, defOIDCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envOIDCTimeout, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -223,8 +257,54 @@ func loadConfig() config {
 		adminEmail:    mainflux.Env(envAdminEmail, defAdminEmail),
 		adminPassword: mainflux.Env(envAdminPassword, defAdminPassword),
 		passRegex:     passRegex,
+		oidcTimeout:   oidcTimeout,
+		oidc:          loadOIDCConfig(),
+		totpKey:       mainflux.Env(envTOTPKey, defTOTPKey),
+	}
+
+}
+
+func loadOIDCConfig() map[string]oidc.Config {
+	configs := make(map[string]oidc.Config)
+	for _, name := range split(mainflux.Env(envOIDCProviders, defOIDCProviders)) {
+		env := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+
+		claims := make(map[string]string)
+		for _, c := range split(mainflux.Env(fmt.Sprintf(envOIDCClaims, env), defOIDCClaims)) {
+			// Claim is mapped to the metadata key of the same name, unless
+			// specified otherwise using the claim:key format.
+			claim, key := c, c
+			if i := strings.Index(c, ":"); i > 0 {
+				claim, key = c[:i], c[i+1:]
+			}
+			claims[claim] = key
+		}
+
+		cfg := oidc.Config{
+			Issuer:       mainflux.Env(fmt.Sprintf(envOIDCIssuer, env), ""),
+			ClientID:     mainflux.Env(fmt.Sprintf(envOIDCClientID, env), ""),
+			ClientSecret: mainflux.Env(fmt.Sprintf(envOIDCClientSecret, env), ""),
+			RedirectURL:  mainflux.Env(fmt.Sprintf(envOIDCRedirectURL, env), ""),
+			Scopes:       split(mainflux.Env(fmt.Sprintf(envOIDCScopes, env), defOIDCScopes)),
+			Claims:       claims,
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			log.Fatalf("Missing issuer, client ID or redirect URL of OpenID Connect provider %s\n", name)
+		}
+		configs[name] = cfg
 	}
 
+	return configs
+}
+
+func split(s string) []string {
+	var ret []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
 }
 
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
@@ -284,10 +364,28 @@ func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 	return authapi.NewClient(tracer, conn, cfg.authTimeout), conn.Close
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServiceClient, c config, logger logger.Logger) users.Service {
+func connectToProviders(cfg config, logger logger.Logger) map[string]users.IdentityProvider {
+	client := &http.Client{Timeout: cfg.oidcTimeout}
+
+	providers := make(map[string]users.IdentityProvider)
+	for name, c := range cfg.oidc {
+		p, err := oidc.New(context.Background(), c, client)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to connect to OpenID Connect provider %s: %s", name, err))
+			os.Exit(1)
+		}
+		providers[name] = p
+		logger.Info(fmt.Sprintf("Users can log in using OpenID Connect provider %s", name))
+	}
+
+	return providers
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServiceClient, providers map[string]users.IdentityProvider, c config, logger logger.Logger) users.Service {
 	database := postgres.NewDatabase(db)
 	hasher := bcrypt.New()
 	userRepo := tracing.UserRepositoryMiddleware(postgres.NewUserRepo(database), tracer)
+	totpRepo := tracing.TOTPRepositoryMiddleware(postgres.NewTOTPRepo(database), tracer)
 
 	emailer, err := emailer.New(c.resetURL, &c.emailConf)
 	if err != nil {
@@ -296,7 +394,7 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServic
 
 	idProvider := uuid.New()
 
-	svc := users.New(userRepo, hasher, auth, emailer, idProvider, c.passRegex)
+	svc := users.New(userRepo, totpRepo, hasher, newCipher(c, logger), auth, emailer, idProvider, c.passRegex, providers, c.adminEmail)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -320,6 +418,28 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, auth mainflux.AuthServic
 	return svc
 }
 
+// newCipher returns the cipher used to encrypt TOTP secrets. If the key
+// is not set, TOTP two-factor authentication is disabled.
+func newCipher(c config, logger logger.Logger) users.Cipher {
+	if c.totpKey == "" {
+		logger.Info(fmt.Sprintf("TOTP two-factor authentication is disabled, since %s is not set", envTOTPKey))
+		return nil
+	}
+
+	key, err := hex.DecodeString(c.totpKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s value: %s", envTOTPKey, err))
+		os.Exit(1)
+	}
+	cipher, err := aes.New(key)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Invalid %s value: %s", envTOTPKey, err))
+		os.Exit(1)
+	}
+
+	return cipher
+}
+
 func createAdmin(svc users.Service, userRepo users.UserRepository, c config) error {
 	user := users.User{
 		Email:    c.adminEmail,
@@ -66,6 +66,7 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 	ar := &mainflux.AccessByKeyReq{
 		Token:  key,
 		ChanID: msg.Channel,
+		Action: messaging.PublishAction,
 	}
 	thid, err := svc.auth.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -90,6 +91,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 	ar := &mainflux.AccessByKeyReq{
 		Token:  key,
 		ChanID: chanID,
+		Action: messaging.SubscribeAction,
 	}
 	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
@@ -117,6 +119,7 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 	ar := &mainflux.AccessByKeyReq{
 		Token:  key,
 		ChanID: chanID,
+		Action: messaging.SubscribeAction,
 	}
 	if _, err := svc.auth.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(ErrUnauthorized, err)
@@ -0,0 +1,104 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/commands"
+)
+
+func sendCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sendCommandReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		timeout, err := req.timeout()
+		if err != nil {
+			return nil, err
+		}
+		cmd := commands.Command{
+			ThingID:   req.thingID,
+			ChannelID: req.ChannelID,
+			Name:      req.Name,
+			Params:    req.Params,
+		}
+		sent, err := svc.SendCommand(ctx, req.token, cmd, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toCommandRes(sent)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewCommandReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		cmd, err := svc.ViewCommand(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toCommandRes(cmd), nil
+	}
+}
+
+func listCommandsEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listCommandsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := commands.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+			Status: req.status,
+		}
+		page, err := svc.ListCommands(ctx, req.token, req.thingID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := commandsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Commands: []commandRes{},
+		}
+		for _, cmd := range page.Commands {
+			res.Commands = append(res.Commands, toCommandRes(cmd))
+		}
+
+		return res, nil
+	}
+}
+
+func toCommandRes(cmd commands.Command) commandRes {
+	return commandRes{
+		ID:        cmd.ID,
+		OwnerID:   cmd.OwnerID,
+		ThingID:   cmd.ThingID,
+		ChannelID: cmd.ChannelID,
+		Name:      cmd.Name,
+		Params:    cmd.Params,
+		Status:    cmd.Status,
+		Message:   cmd.Message,
+		CreatedAt: cmd.CreatedAt,
+		UpdatedAt: cmd.UpdatedAt,
+		ExpiresAt: cmd.ExpiresAt,
+	}
+}
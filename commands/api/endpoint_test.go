@@ -0,0 +1,409 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+	httpapi "github.com/mainflux/mainflux/commands/api"
+	"github.com/mainflux/mainflux/commands/mocks"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType  = "application/json"
+	token        = "token"
+	wrongToken   = "wrong-token"
+	email        = "user@example.com"
+	chanID       = "chan"
+	otherChanID  = "other-chan"
+	thingID      = "thing"
+	otherThingID = "other-thing"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newService() commands.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(
+		map[string]string{chanID: email, otherChanID: "other@example.com"},
+		map[string][]string{chanID: {thingID}, otherChanID: {otherThingID}},
+	)
+	return commands.New(auth, things, mocks.NewCommandRepository(), uuid.NewMock(), mocks.NewPublisher(), time.Minute)
+}
+
+func newServer(svc commands.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+type commandReq struct {
+	ChannelID string                 `json:"channel_id,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Timeout   string                 `json:"timeout,omitempty"`
+}
+
+type commandRes struct {
+	ID        string                 `json:"id"`
+	ThingID   string                 `json:"thing_id"`
+	ChannelID string                 `json:"channel_id"`
+	Name      string                 `json:"name"`
+	Params    map[string]interface{} `json:"params"`
+	Status    string                 `json:"status"`
+	Message   string                 `json:"message"`
+}
+
+type commandsPageRes struct {
+	Total    uint64       `json:"total"`
+	Offset   uint64       `json:"offset"`
+	Limit    uint64       `json:"limit"`
+	Commands []commandRes `json:"commands"`
+}
+
+var cmd = commandReq{
+	ChannelID: chanID,
+	Name:      "reboot",
+	Params:    map[string]interface{}{"delay": float64(5)},
+	Timeout:   "30s",
+}
+
+func TestSendCommand(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		thingID     string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "send command",
+			thingID:     thingID,
+			req:         toJSON(cmd),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/commands/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "send command with invalid token",
+			thingID:     thingID,
+			req:         toJSON(cmd),
+			contentType: contentType,
+			token:       wrongToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "send command with empty token",
+			thingID:     thingID,
+			req:         toJSON(cmd),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "send command over the channel owned by another user",
+			thingID:     otherThingID,
+			req:         toJSON(commandReq{ChannelID: otherChanID, Name: cmd.Name}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "send command to the thing not connected to the channel",
+			thingID:     otherThingID,
+			req:         toJSON(cmd),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "send command without channel",
+			thingID:     thingID,
+			req:         toJSON(commandReq{Name: cmd.Name}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command without name",
+			thingID:     thingID,
+			req:         toJSON(commandReq{ChannelID: chanID}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command with invalid timeout",
+			thingID:     thingID,
+			req:         toJSON(commandReq{ChannelID: chanID, Name: cmd.Name, Timeout: "soon"}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command with invalid request format",
+			thingID:     thingID,
+			req:         "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "send command with invalid content type",
+			thingID:     thingID,
+			req:         toJSON(cmd),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/commands", ts.URL, tc.thingID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	sent, err := svc.SendCommand(context.Background(), token, commands.Command{
+		ThingID:   thingID,
+		ChannelID: chanID,
+		Name:      cmd.Name,
+		Params:    cmd.Params,
+	}, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.HandleAck(messaging.Message{
+		Channel:   chanID,
+		Subtopic:  commands.Topic(thingID) + ".ack",
+		Publisher: thingID,
+		Payload:   []byte(fmt.Sprintf(`{"id":"%s","status":"failed","message":"busy"}`, sent.ID)),
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		res    commandRes
+	}{
+		{
+			desc:   "view command",
+			id:     sent.ID,
+			token:  token,
+			status: http.StatusOK,
+			res: commandRes{
+				ID:        sent.ID,
+				ThingID:   thingID,
+				ChannelID: chanID,
+				Name:      cmd.Name,
+				Params:    cmd.Params,
+				Status:    commands.Failed,
+				Message:   "busy",
+			},
+		},
+		{
+			desc:   "view non-existing command",
+			id:     "non-existing",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view command with invalid token",
+			id:     sent.ID,
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/commands/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body commandRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	for i := 0; i < 5; i++ {
+		_, err := svc.SendCommand(context.Background(), token, commands.Command{
+			ThingID:   thingID,
+			ChannelID: chanID,
+			Name:      cmd.Name,
+		}, 0)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		thingID string
+		query   string
+		token   string
+		status  int
+		total   uint64
+		size    int
+	}{
+		{
+			desc:    "list commands",
+			thingID: thingID,
+			query:   "",
+			token:   token,
+			status:  http.StatusOK,
+			total:   5,
+			size:    5,
+		},
+		{
+			desc:    "list commands with limit",
+			thingID: thingID,
+			query:   "?offset=1&limit=2",
+			token:   token,
+			status:  http.StatusOK,
+			total:   5,
+			size:    2,
+		},
+		{
+			desc:    "list pending commands",
+			thingID: thingID,
+			query:   "?status=pending",
+			token:   token,
+			status:  http.StatusOK,
+			total:   5,
+			size:    5,
+		},
+		{
+			desc:    "list acked commands",
+			thingID: thingID,
+			query:   "?status=acked",
+			token:   token,
+			status:  http.StatusOK,
+			total:   0,
+			size:    0,
+		},
+		{
+			desc:    "list commands of another thing",
+			thingID: otherThingID,
+			query:   "",
+			token:   token,
+			status:  http.StatusOK,
+			total:   0,
+			size:    0,
+		},
+		{
+			desc:    "list commands with invalid status",
+			thingID: thingID,
+			query:   "?status=unknown",
+			token:   token,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "list commands with invalid limit",
+			thingID: thingID,
+			query:   "?limit=1000",
+			token:   token,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "list commands with invalid token",
+			thingID: thingID,
+			query:   "",
+			token:   wrongToken,
+			status:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/commands%s", ts.URL, tc.thingID, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body commandsPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+		assert.Equal(t, tc.size, len(body.Commands), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(body.Commands)))
+	}
+}
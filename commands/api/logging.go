@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ commands.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    commands.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc commands.Service, logger log.Logger) commands.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) SendCommand(ctx context.Context, token string, cmd commands.Command, timeout time.Duration) (sent commands.Command, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method send_command with the id %s to thing %s took %s to complete", sent.ID, cmd.ThingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SendCommand(ctx, token, cmd, timeout)
+}
+
+func (lm *loggingMiddleware) ViewCommand(ctx context.Context, token, id string) (cmd commands.Command, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_command for command %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewCommand(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListCommands(ctx context.Context, token, thingID string, pm commands.PageMetadata) (page commands.CommandsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_commands for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListCommands(ctx, token, thingID, pm)
+}
+
+func (lm *loggingMiddleware) HandleAck(msg messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method handle_ack from thing %s took %s to complete", msg.Publisher, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.HandleAck(msg)
+}
+
+func (lm *loggingMiddleware) ExpireCommands(ctx context.Context) (n uint64, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method expire_commands timed out %d commands and took %s to complete", n, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Debug(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExpireCommands(ctx)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/commands"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ commands.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     commands.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc commands.Service, counter metrics.Counter, latency metrics.Histogram) commands.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) SendCommand(ctx context.Context, token string, cmd commands.Command, timeout time.Duration) (commands.Command, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "send_command").Add(1)
+		ms.latency.With("method", "send_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SendCommand(ctx, token, cmd, timeout)
+}
+
+func (ms *metricsMiddleware) ViewCommand(ctx context.Context, token, id string) (commands.Command, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_command").Add(1)
+		ms.latency.With("method", "view_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewCommand(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListCommands(ctx context.Context, token, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_commands").Add(1)
+		ms.latency.With("method", "list_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListCommands(ctx, token, thingID, pm)
+}
+
+func (ms *metricsMiddleware) HandleAck(msg messaging.Message) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "handle_ack").Add(1)
+		ms.latency.With("method", "handle_ack").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.HandleAck(msg)
+}
+
+func (ms *metricsMiddleware) ExpireCommands(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "expire_commands").Add(1)
+		ms.latency.With("method", "expire_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExpireCommands(ctx)
+}
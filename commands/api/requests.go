@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const maxLimitSize = 100
+
+type sendCommandReq struct {
+	token     string
+	thingID   string
+	ChannelID string                 `json:"channel_id"`
+	Name      string                 `json:"name"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Timeout   string                 `json:"timeout,omitempty"`
+}
+
+func (req sendCommandReq) validate() error {
+	if req.token == "" {
+		return commands.ErrUnauthorizedAccess
+	}
+	if req.thingID == "" || req.ChannelID == "" || req.Name == "" {
+		return commands.ErrMalformedEntity
+	}
+	if _, err := req.timeout(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// timeout parses the command timeout, e.g. "30s" or "5m".
+func (req sendCommandReq) timeout() (time.Duration, error) {
+	if req.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(req.Timeout)
+	if err != nil {
+		return 0, errors.Wrap(commands.ErrMalformedEntity, err)
+	}
+	if d <= 0 {
+		return 0, commands.ErrMalformedEntity
+	}
+	return d, nil
+}
+
+type viewCommandReq struct {
+	token string
+	id    string
+}
+
+func (req viewCommandReq) validate() error {
+	if req.token == "" {
+		return commands.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return commands.ErrMalformedEntity
+	}
+	return nil
+}
+
+type listCommandsReq struct {
+	token   string
+	thingID string
+	offset  uint64
+	limit   uint64
+	status  string
+}
+
+func (req listCommandsReq) validate() error {
+	if req.token == "" {
+		return commands.ErrUnauthorizedAccess
+	}
+	if req.thingID == "" {
+		return commands.ErrMalformedEntity
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	switch req.status {
+	case "", commands.Pending, commands.Delivered, commands.Acked, commands.Failed, commands.TimedOut:
+	default:
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*commandRes)(nil)
+	_ mainflux.Response = (*commandsPageRes)(nil)
+)
+
+type commandRes struct {
+	ID        string                 `json:"id"`
+	OwnerID   string                 `json:"owner_id"`
+	ThingID   string                 `json:"thing_id"`
+	ChannelID string                 `json:"channel_id"`
+	Name      string                 `json:"name"`
+	Params    map[string]interface{} `json:"params,omitempty"`
+	Status    string                 `json:"status"`
+	Message   string                 `json:"message,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	ExpiresAt time.Time              `json:"expires_at"`
+	created   bool
+}
+
+func (res commandRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func (res commandRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/commands/%s", res.ID),
+		}
+	}
+	return map[string]string{}
+}
+
+func (res commandRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type commandsPageRes struct {
+	pageRes
+	Commands []commandRes `json:"commands"`
+}
+
+func (res commandsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res commandsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res commandsPageRes) Empty() bool {
+	return false
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
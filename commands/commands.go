@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Command statuses.
+const (
+	// Pending is the status of the command published to the thing.
+	Pending = "pending"
+	// Delivered is the status of the command the thing reported as received.
+	Delivered = "delivered"
+	// Acked is the status of the command the thing executed successfully.
+	Acked = "acked"
+	// Failed is the status of the command the thing failed to execute, or
+	// the command which couldn't be published.
+	Failed = "failed"
+	// TimedOut is the status of the command that wasn't acknowledged in time.
+	TimedOut = "timed_out"
+)
+
+// Subtopic is the subtopic prefix of the commands. Commands of the thing are
+// published to the "commands.<thing_id>" subtopic of the control channel,
+// while the thing publishes acknowledgements to "commands.<thing_id>.ack".
+const Subtopic = "commands"
+
+// AckSubject is the message broker subject of the acknowledgements.
+const AckSubject = "channels.*." + Subtopic + ".*.ack"
+
+// transitions maps the status to the statuses the command can have before it
+// is changed to that status. Acknowledgements can't change acked, failed and
+// timed out commands.
+var transitions = map[string][]string{
+	Delivered: {Pending},
+	Acked:     {Pending, Delivered},
+	Failed:    {Pending, Delivered},
+	TimedOut:  {Pending, Delivered},
+}
+
+// From returns the statuses the command can be changed to the given status
+// from. Nil is returned for the statuses commands can't be changed to.
+func From(status string) []string {
+	return transitions[status]
+}
+
+// Command represents the command sent to the thing.
+type Command struct {
+	// ID is the correlation ID of the command, sent to the thing and
+	// expected in its acknowledgements.
+	ID        string
+	OwnerID   string
+	ThingID   string
+	ChannelID string
+	Name      string
+	Params    map[string]interface{}
+	Status    string
+	// Message is the detail the thing reported with the last
+	// acknowledgement, e.g. the reason of the failure.
+	Message   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ExpiresAt is the time after which the command that is not acked or
+	// failed is considered timed out.
+	ExpiresAt time.Time
+}
+
+// Validate returns an error if the command is not valid.
+func (cmd Command) Validate() error {
+	if cmd.ThingID == "" || cmd.ChannelID == "" || cmd.Name == "" {
+		return ErrMalformedEntity
+	}
+	// Thing ID is a subtopic token, so it can't contain separators.
+	if strings.ContainsAny(cmd.ThingID, ".*> ") {
+		return ErrMalformedEntity
+	}
+	return nil
+}
+
+// CommandsPage contains page related metadata as well as list of commands
+// that belong to this page.
+type CommandsPage struct {
+	PageMetadata
+	Total    uint64
+	Commands []Command
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset uint64
+	Limit  uint64
+	Status string
+}
+
+// CommandRepository specifies a command persistence API.
+type CommandRepository interface {
+	// Save persists the command. Successful operation is indicated by
+	// non-nil error response.
+	Save(ctx context.Context, cmd Command) (string, error)
+
+	// Retrieve retrieves the command having the provided identifier, that
+	// is owned by the specified user.
+	Retrieve(ctx context.Context, owner, id string) (Command, error)
+
+	// RetrieveAll retrieves the subset of commands of the thing, owned by the
+	// specified user, starting from the most recent one.
+	RetrieveAll(ctx context.Context, owner, thingID string, pm PageMetadata) (CommandsPage, error)
+
+	// UpdateStatus updates status, message and update time of the command
+	// sent to the thing over the channel, provided that its current status
+	// is one of the given statuses.
+	UpdateStatus(ctx context.Context, cmd Command, from []string) error
+
+	// Expire changes the status of the commands that have expired before
+	// the given time and have one of the given statuses to timed out.
+	Expire(ctx context.Context, at time.Time, from []string) (uint64, error)
+}
+
+// Topic returns the subtopic the commands of the thing are published to.
+func Topic(thingID string) string {
+	return fmt.Sprintf("%s.%s", Subtopic, thingID)
+}
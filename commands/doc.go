@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commands contains the domain concept definitions needed to support
+// Mainflux commands functionality. Commands are sent to the things over the
+// control channels and their delivery and execution is tracked using the
+// acknowledgements published by the things.
+package commands
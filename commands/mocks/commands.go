@@ -0,0 +1,123 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+)
+
+var _ commands.CommandRepository = (*commandRepositoryMock)(nil)
+
+type commandRepositoryMock struct {
+	mu       sync.Mutex
+	commands map[string]commands.Command
+}
+
+// NewCommandRepository creates in-memory command repository.
+func NewCommandRepository() commands.CommandRepository {
+	return &commandRepositoryMock{
+		commands: make(map[string]commands.Command),
+	}
+}
+
+func (crm *commandRepositoryMock) Save(_ context.Context, cmd commands.Command) (string, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if _, ok := crm.commands[cmd.ID]; ok {
+		return "", commands.ErrConflict
+	}
+	crm.commands[cmd.ID] = cmd
+	return cmd.ID, nil
+}
+
+func (crm *commandRepositoryMock) Retrieve(_ context.Context, owner, id string) (commands.Command, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	cmd, ok := crm.commands[id]
+	if !ok || cmd.OwnerID != owner {
+		return commands.Command{}, commands.ErrNotFound
+	}
+	return cmd, nil
+}
+
+func (crm *commandRepositoryMock) RetrieveAll(_ context.Context, owner, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var items []commands.Command
+	for _, cmd := range crm.commands {
+		if cmd.OwnerID != owner || cmd.ThingID != thingID || (pm.Status != "" && cmd.Status != pm.Status) {
+			continue
+		}
+		items = append(items, cmd)
+	}
+	// Mocked IDs are increasing, so they are sorted instead of the
+	// creation times, which can be equal.
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID > items[j].ID
+	})
+
+	start := int(pm.Offset)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + int(pm.Limit)
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return commands.CommandsPage{
+		PageMetadata: pm,
+		Total:        uint64(len(items)),
+		Commands:     items[start:end],
+	}, nil
+}
+
+func (crm *commandRepositoryMock) UpdateStatus(_ context.Context, cmd commands.Command, from []string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	c, ok := crm.commands[cmd.ID]
+	if !ok || c.ThingID != cmd.ThingID || c.ChannelID != cmd.ChannelID || !contains(from, c.Status) {
+		return commands.ErrNotFound
+	}
+	c.Status = cmd.Status
+	c.Message = cmd.Message
+	c.UpdatedAt = cmd.UpdatedAt
+	crm.commands[cmd.ID] = c
+	return nil
+}
+
+func (crm *commandRepositoryMock) Expire(_ context.Context, at time.Time, from []string) (uint64, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var n uint64
+	for id, c := range crm.commands {
+		if !c.ExpiresAt.Before(at) || !contains(from, c.Status) {
+			continue
+		}
+		c.Status = commands.TimedOut
+		c.UpdatedAt = at
+		crm.commands[id] = c
+		n++
+	}
+	return n, nil
+}
+
+func contains(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
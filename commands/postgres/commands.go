@@ -0,0 +1,253 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/commands"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	errDuplicate  = "unique_violation"
+	errInvalid    = "invalid_text_representation"
+	errTruncation = "string_data_right_truncation"
+
+	commandColumns = `id, owner_id, thing_id, channel_id, name, params, status, message, created_at, updated_at, expires_at`
+)
+
+var _ commands.CommandRepository = (*commandRepository)(nil)
+
+type commandRepository struct {
+	db Database
+}
+
+// New instantiates a PostgreSQL implementation of command repository.
+func New(db Database) commands.CommandRepository {
+	return &commandRepository{
+		db: db,
+	}
+}
+
+func (cr commandRepository) Save(ctx context.Context, cmd commands.Command) (string, error) {
+	dbc, err := toDBCommand(cmd)
+	if err != nil {
+		return "", errors.Wrap(commands.ErrSave, err)
+	}
+
+	q := fmt.Sprintf(`INSERT INTO commands (%s)
+	VALUES (:id, :owner_id, :thing_id, :channel_id, :name, :params, :status, :message, :created_at, :updated_at, :expires_at)`, commandColumns)
+	if _, err := cr.db.NamedExecContext(ctx, q, dbc); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return "", errors.Wrap(commands.ErrConflict, err)
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(commands.ErrMalformedEntity, err)
+			}
+		}
+		return "", errors.Wrap(commands.ErrSave, err)
+	}
+
+	return cmd.ID, nil
+}
+
+func (cr commandRepository) Retrieve(ctx context.Context, owner, id string) (commands.Command, error) {
+	q := fmt.Sprintf(`SELECT %s FROM commands WHERE owner_id = $1 AND id = $2`, commandColumns)
+
+	var dbc dbCommand
+	if err := cr.db.GetContext(ctx, &dbc, q, owner, id); err != nil {
+		if err == sql.ErrNoRows {
+			return commands.Command{}, errors.Wrap(commands.ErrNotFound, err)
+		}
+		return commands.Command{}, errors.Wrap(commands.ErrSelectEntity, err)
+	}
+
+	cmd, err := fromDBCommand(dbc)
+	if err != nil {
+		return commands.Command{}, errors.Wrap(commands.ErrSelectEntity, err)
+	}
+	return cmd, nil
+}
+
+func (cr commandRepository) RetrieveAll(ctx context.Context, owner, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	params := map[string]interface{}{
+		"owner_id": owner,
+		"thing_id": thingID,
+		"status":   pm.Status,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+	cond := `owner_id = :owner_id AND thing_id = :thing_id`
+	if pm.Status != "" {
+		cond = fmt.Sprintf("%s AND status = :status", cond)
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM commands WHERE %s ORDER BY created_at DESC, id DESC LIMIT :limit OFFSET :offset`, commandColumns, cond)
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return commands.CommandsPage{}, errors.Wrap(commands.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []commands.Command
+	for rows.Next() {
+		var dbc dbCommand
+		if err := rows.StructScan(&dbc); err != nil {
+			return commands.CommandsPage{}, errors.Wrap(commands.ErrSelectEntity, err)
+		}
+		cmd, err := fromDBCommand(dbc)
+		if err != nil {
+			return commands.CommandsPage{}, errors.Wrap(commands.ErrSelectEntity, err)
+		}
+		items = append(items, cmd)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM commands WHERE %s`, cond)
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return commands.CommandsPage{}, errors.Wrap(commands.ErrSelectEntity, err)
+	}
+
+	return commands.CommandsPage{
+		PageMetadata: pm,
+		Total:        total,
+		Commands:     items,
+	}, nil
+}
+
+func (cr commandRepository) UpdateStatus(ctx context.Context, cmd commands.Command, from []string) error {
+	q := `UPDATE commands SET status = :status, message = :message, updated_at = :updated_at
+	WHERE id = :id AND thing_id = :thing_id AND channel_id = :channel_id AND status = ANY(:from)`
+
+	params := map[string]interface{}{
+		"id":         cmd.ID,
+		"thing_id":   cmd.ThingID,
+		"channel_id": cmd.ChannelID,
+		"status":     cmd.Status,
+		"message":    cmd.Message,
+		"updated_at": cmd.UpdatedAt,
+		"from":       pq.Array(from),
+	}
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(commands.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(commands.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(commands.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return commands.ErrNotFound
+	}
+
+	return nil
+}
+
+func (cr commandRepository) Expire(ctx context.Context, at time.Time, from []string) (uint64, error) {
+	q := `UPDATE commands SET status = :status, updated_at = :at WHERE expires_at < :at AND status = ANY(:from)`
+
+	params := map[string]interface{}{
+		"status": commands.TimedOut,
+		"at":     at,
+		"from":   pq.Array(from),
+	}
+	res, err := cr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return 0, errors.Wrap(commands.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(commands.ErrUpdateEntity, err)
+	}
+	return uint64(cnt), nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+type dbCommand struct {
+	ID        string    `db:"id"`
+	OwnerID   string    `db:"owner_id"`
+	ThingID   string    `db:"thing_id"`
+	ChannelID string    `db:"channel_id"`
+	Name      string    `db:"name"`
+	Params    []byte    `db:"params"`
+	Status    string    `db:"status"`
+	Message   string    `db:"message"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+func toDBCommand(cmd commands.Command) (dbCommand, error) {
+	params, err := json.Marshal(cmd.Params)
+	if err != nil {
+		return dbCommand{}, err
+	}
+
+	return dbCommand{
+		ID:        cmd.ID,
+		OwnerID:   cmd.OwnerID,
+		ThingID:   cmd.ThingID,
+		ChannelID: cmd.ChannelID,
+		Name:      cmd.Name,
+		Params:    params,
+		Status:    cmd.Status,
+		Message:   cmd.Message,
+		CreatedAt: cmd.CreatedAt,
+		UpdatedAt: cmd.UpdatedAt,
+		ExpiresAt: cmd.ExpiresAt,
+	}, nil
+}
+
+func fromDBCommand(dbc dbCommand) (commands.Command, error) {
+	var params map[string]interface{}
+	if len(dbc.Params) > 0 {
+		if err := json.Unmarshal(dbc.Params, &params); err != nil {
+			return commands.Command{}, err
+		}
+	}
+
+	return commands.Command{
+		ID:        dbc.ID,
+		OwnerID:   dbc.OwnerID,
+		ThingID:   dbc.ThingID,
+		ChannelID: dbc.ChannelID,
+		Name:      dbc.Name,
+		Params:    params,
+		Status:    dbc.Status,
+		Message:   dbc.Message,
+		CreatedAt: dbc.CreatedAt,
+		UpdatedAt: dbc.UpdatedAt,
+		ExpiresAt: dbc.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,255 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+	"github.com/mainflux/mainflux/commands/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numCommands = 10
+
+func newCommand(t *testing.T, owner, thingID string, expiresAt time.Time) commands.Command {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	// Postgres keeps microseconds precision.
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return commands.Command{
+		ID:        id,
+		OwnerID:   owner,
+		ThingID:   thingID,
+		ChannelID: "chan",
+		Name:      "reboot",
+		Params:    map[string]interface{}{"delay": float64(5)},
+		Status:    commands.Pending,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: expiresAt.UTC().Truncate(time.Microsecond),
+	}
+}
+
+func TestCommandSave(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	cmd := newCommand(t, owner, "thing", time.Now().Add(time.Minute))
+
+	cases := []struct {
+		desc string
+		cmd  commands.Command
+		id   string
+		err  error
+	}{
+		{
+			desc: "save command",
+			cmd:  cmd,
+			id:   cmd.ID,
+			err:  nil,
+		},
+		{
+			desc: "save duplicate command",
+			cmd:  cmd,
+			id:   "",
+			err:  commands.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := repo.Save(context.Background(), tc.cmd)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCommandRetrieve(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	cmd := newCommand(t, owner, "thing", time.Now().Add(time.Minute))
+	_, err = repo.Save(context.Background(), cmd)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve command",
+			owner: owner,
+			id:    cmd.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve command of another owner",
+			owner: "another",
+			id:    cmd.ID,
+			err:   commands.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing command",
+			owner: owner,
+			id:    "non-existing",
+			err:   commands.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := repo.Retrieve(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, cmd.Params, c.Params, fmt.Sprintf("%s: expected params %v got %v\n", tc.desc, cmd.Params, c.Params))
+		assert.True(t, cmd.ExpiresAt.Equal(c.ExpiresAt), fmt.Sprintf("%s: expected expiration %s got %s\n", tc.desc, cmd.ExpiresAt, c.ExpiresAt))
+	}
+}
+
+func TestCommandRetrieveAll(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < numCommands; i++ {
+		cmd := newCommand(t, owner, thingID, time.Now().Add(time.Minute))
+		if i%2 == 0 {
+			cmd.Status = commands.Acked
+		}
+		_, err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+	_, err = repo.Save(context.Background(), newCommand(t, owner, "other", time.Now().Add(time.Minute)))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    commands.PageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all commands of the thing",
+			pm:    commands.PageMetadata{Limit: numCommands},
+			total: numCommands,
+			size:  numCommands,
+		},
+		{
+			desc:  "retrieve page of commands",
+			pm:    commands.PageMetadata{Offset: 8, Limit: 5},
+			total: numCommands,
+			size:  2,
+		},
+		{
+			desc:  "retrieve commands by status",
+			pm:    commands.PageMetadata{Limit: numCommands, Status: commands.Acked},
+			total: numCommands / 2,
+			size:  numCommands / 2,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), owner, thingID, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Commands, tc.size, fmt.Sprintf("%s: expected %d commands got %d\n", tc.desc, tc.size, len(page.Commands)))
+	}
+}
+
+func TestCommandUpdateStatus(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	cmd := newCommand(t, owner, "thing", time.Now().Add(time.Minute))
+	_, err = repo.Save(context.Background(), cmd)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	delivered := cmd
+	delivered.Status = commands.Delivered
+	failed := cmd
+	failed.Status = commands.Failed
+	failed.Message = "not supported"
+	otherThing := failed
+	otherThing.ThingID = "other"
+
+	cases := []struct {
+		desc string
+		cmd  commands.Command
+		err  error
+	}{
+		{
+			desc: "update status of the command sent to another thing",
+			cmd:  otherThing,
+			err:  commands.ErrNotFound,
+		},
+		{
+			desc: "update status to delivered",
+			cmd:  delivered,
+			err:  nil,
+		},
+		{
+			desc: "update status to failed",
+			cmd:  failed,
+			err:  nil,
+		},
+		{
+			desc: "update status of failed command",
+			cmd:  delivered,
+			err:  commands.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateStatus(context.Background(), tc.cmd, commands.From(tc.cmd.Status))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	c, err := repo.Retrieve(context.Background(), owner, cmd.ID)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, commands.Failed, c.Status, fmt.Sprintf("expected status %s got %s\n", commands.Failed, c.Status))
+	assert.Equal(t, failed.Message, c.Message, fmt.Sprintf("expected message %s got %s\n", failed.Message, c.Message))
+}
+
+func TestCommandExpire(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	expired := newCommand(t, owner, "thing", time.Now().Add(-time.Minute))
+	acked := newCommand(t, owner, "thing", time.Now().Add(-time.Minute))
+	acked.Status = commands.Acked
+	active := newCommand(t, owner, "thing", time.Now().Add(time.Minute))
+	for _, cmd := range []commands.Command{expired, acked, active} {
+		_, err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	_, err = repo.Expire(context.Background(), time.Now().UTC(), commands.From(commands.TimedOut))
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := map[string]string{
+		expired.ID: commands.TimedOut,
+		acked.ID:   commands.Acked,
+		active.ID:  commands.Pending,
+	}
+	for id, status := range cases {
+		c, err := repo.Retrieve(context.Background(), owner, id)
+		assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		assert.Equal(t, status, c.Status, fmt.Sprintf("expected status %s got %s\n", status, c.Status))
+	}
+}
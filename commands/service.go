@@ -0,0 +1,242 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	// Protocol is set as the protocol of the published commands.
+	Protocol = "commands"
+
+	ackSuffix = "ack"
+)
+
+var (
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrCreateID indicates error in creating id for entity creation.
+	ErrCreateID = errors.New("failed to create id")
+
+	// ErrNotFound indicates a non-existent entity request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrConflict indicates that entity already exists.
+	ErrConflict = errors.New("entity already exists")
+
+	// ErrSave indicates error saving entity.
+	ErrSave = errors.New("failed to save entity")
+
+	// ErrSelectEntity indicates problem with scanning data from db.
+	ErrSelectEntity = errors.New("failed to select entity")
+
+	// ErrUpdateEntity indicates error updating entity.
+	ErrUpdateEntity = errors.New("failed to update entity")
+
+	// ErrPublish indicates failure to publish the command.
+	ErrPublish = errors.New("failed to publish command")
+)
+
+// request is the payload of the published command.
+type request struct {
+	ID     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// ack is the payload of the acknowledgement published by the thing.
+type ack struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// SendCommand publishes the command to the thing over the channel owned
+	// by the user identified by the provided key. The thing has to be
+	// connected to the channel and allowed to receive messages from it.
+	// Command times out if it's not acked or failed within the timeout, or
+	// within the default timeout if the zero timeout is provided.
+	SendCommand(ctx context.Context, token string, cmd Command, timeout time.Duration) (Command, error)
+
+	// ViewCommand retrieves the command having the provided identifier.
+	ViewCommand(ctx context.Context, token, id string) (Command, error)
+
+	// ListCommands retrieves the commands sent to the thing by the user.
+	ListCommands(ctx context.Context, token, thingID string, pm PageMetadata) (CommandsPage, error)
+
+	// HandleAck updates the status of the command using the acknowledgement
+	// published by the thing.
+	HandleAck(msg messaging.Message) error
+
+	// ExpireCommands changes the status of the commands which are not
+	// acknowledged in time to timed out, and returns their number.
+	ExpireCommands(ctx context.Context) (uint64, error)
+}
+
+var _ Service = (*commandsService)(nil)
+
+type commandsService struct {
+	auth      mainflux.AuthServiceClient
+	things    mainflux.ThingsServiceClient
+	commands  CommandRepository
+	idp       mainflux.IDProvider
+	publisher messaging.Publisher
+	timeout   time.Duration
+}
+
+// New instantiates the commands service implementation. Commands sent without
+// timeout time out after the given default timeout.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, commands CommandRepository, idp mainflux.IDProvider, pub messaging.Publisher, timeout time.Duration) Service {
+	return &commandsService{
+		auth:      auth,
+		things:    things,
+		commands:  commands,
+		idp:       idp,
+		publisher: pub,
+		timeout:   timeout,
+	}
+}
+
+func (cs *commandsService) SendCommand(ctx context.Context, token string, cmd Command, timeout time.Duration) (Command, error) {
+	res, err := cs.identify(ctx, token)
+	if err != nil {
+		return Command{}, err
+	}
+	if err := cmd.Validate(); err != nil {
+		return Command{}, err
+	}
+	if timeout < 0 {
+		return Command{}, ErrMalformedEntity
+	}
+	if timeout == 0 {
+		timeout = cs.timeout
+	}
+
+	if _, err := cs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetEmail(), ChanID: cmd.ChannelID}); err != nil {
+		return Command{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	req := &mainflux.AccessByIDReq{ThingID: cmd.ThingID, ChanID: cmd.ChannelID, Action: messaging.SubscribeAction}
+	if _, err := cs.things.CanAccessByID(ctx, req); err != nil {
+		return Command{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	cmd.ID, err = cs.idp.ID()
+	if err != nil {
+		return Command{}, errors.Wrap(ErrCreateID, err)
+	}
+	payload, err := json.Marshal(request{ID: cmd.ID, Name: cmd.Name, Params: cmd.Params})
+	if err != nil {
+		return Command{}, errors.Wrap(ErrMalformedEntity, err)
+	}
+
+	now := time.Now().UTC()
+	cmd.OwnerID = res.GetId()
+	cmd.Status = Pending
+	cmd.Message = ""
+	cmd.CreatedAt = now
+	cmd.UpdatedAt = now
+	cmd.ExpiresAt = now.Add(timeout)
+
+	// Command is saved before it's published, so the acknowledgement
+	// can't arrive before the command is known.
+	if _, err := cs.commands.Save(ctx, cmd); err != nil {
+		return Command{}, err
+	}
+
+	msg := messaging.Message{
+		Channel:  cmd.ChannelID,
+		Subtopic: Topic(cmd.ThingID),
+		Protocol: Protocol,
+		Payload:  payload,
+		Created:  now.UnixNano(),
+	}
+	if err := cs.publisher.Publish(msg.Channel, msg); err != nil {
+		cmd.Status = Failed
+		cmd.Message = ErrPublish.Error()
+		cmd.UpdatedAt = time.Now().UTC()
+		if err := cs.commands.UpdateStatus(ctx, cmd, From(Failed)); err != nil {
+			return Command{}, err
+		}
+		return Command{}, errors.Wrap(ErrPublish, err)
+	}
+
+	return cmd, nil
+}
+
+func (cs *commandsService) ViewCommand(ctx context.Context, token, id string) (Command, error) {
+	res, err := cs.identify(ctx, token)
+	if err != nil {
+		return Command{}, err
+	}
+
+	return cs.commands.Retrieve(ctx, res.GetId(), id)
+}
+
+func (cs *commandsService) ListCommands(ctx context.Context, token, thingID string, pm PageMetadata) (CommandsPage, error) {
+	res, err := cs.identify(ctx, token)
+	if err != nil {
+		return CommandsPage{}, err
+	}
+
+	return cs.commands.RetrieveAll(ctx, res.GetId(), thingID, pm)
+}
+
+func (cs *commandsService) HandleAck(msg messaging.Message) error {
+	tokens := strings.Split(msg.Subtopic, ".")
+	if len(tokens) != 3 || tokens[0] != Subtopic || tokens[2] != ackSuffix {
+		return ErrMalformedEntity
+	}
+	// Adapters set the publisher to the ID of the authenticated thing, so
+	// the thing can acknowledge only its own commands.
+	thingID := tokens[1]
+	if msg.Publisher != thingID {
+		return ErrUnauthorizedAccess
+	}
+
+	var a ack
+	if err := json.Unmarshal(msg.Payload, &a); err != nil {
+		return errors.Wrap(ErrMalformedEntity, err)
+	}
+	if a.ID == "" || (a.Status != Delivered && a.Status != Acked && a.Status != Failed) {
+		return ErrMalformedEntity
+	}
+
+	cmd := Command{
+		ID:        a.ID,
+		ThingID:   thingID,
+		ChannelID: msg.Channel,
+		Status:    a.Status,
+		Message:   a.Message,
+		UpdatedAt: time.Now().UTC(),
+	}
+	return cs.commands.UpdateStatus(context.Background(), cmd, From(a.Status))
+}
+
+func (cs *commandsService) ExpireCommands(ctx context.Context) (uint64, error) {
+	return cs.commands.Expire(ctx, time.Now().UTC(), From(TimedOut))
+}
+
+func (cs *commandsService) identify(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,351 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+	"github.com/mainflux/mainflux/commands/mocks"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token        = "token"
+	wrongToken   = "wrong-token"
+	email        = "user@example.com"
+	otherToken   = "other-token"
+	otherEmail   = "other@example.com"
+	chanID       = "chan"
+	otherChanID  = "other-chan"
+	thingID      = "thing"
+	otherThingID = "other-thing"
+	timeout      = time.Minute
+)
+
+var cmd = commands.Command{
+	ThingID:   thingID,
+	ChannelID: chanID,
+	Name:      "reboot",
+	Params:    map[string]interface{}{"delay": float64(5)},
+}
+
+func newService(pub messaging.Publisher) commands.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := mocks.NewThingsService(
+		map[string]string{chanID: email, otherChanID: otherEmail},
+		map[string][]string{chanID: {thingID}, otherChanID: {otherThingID}},
+	)
+	return commands.New(auth, things, mocks.NewCommandRepository(), uuid.NewMock(), pub, timeout)
+}
+
+func ackMessage(thingID, id, status string) messaging.Message {
+	return messaging.Message{
+		Channel:   chanID,
+		Subtopic:  fmt.Sprintf("%s.ack", commands.Topic(thingID)),
+		Publisher: thingID,
+		Protocol:  "mqtt",
+		Payload:   []byte(fmt.Sprintf(`{"id":"%s","status":"%s","message":"done"}`, id, status)),
+	}
+}
+
+func TestSendCommand(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc := newService(pub)
+
+	notConnected := cmd
+	notConnected.ThingID = otherThingID
+
+	invalidThing := cmd
+	invalidThing.ThingID = "thing.*"
+
+	unnamed := cmd
+	unnamed.Name = ""
+
+	cases := []struct {
+		desc    string
+		token   string
+		cmd     commands.Command
+		timeout time.Duration
+		err     error
+	}{
+		{
+			desc:    "send command",
+			token:   token,
+			cmd:     cmd,
+			timeout: 0,
+			err:     nil,
+		},
+		{
+			desc:    "send command with timeout",
+			token:   token,
+			cmd:     cmd,
+			timeout: time.Hour,
+			err:     nil,
+		},
+		{
+			desc:    "send command with invalid token",
+			token:   wrongToken,
+			cmd:     cmd,
+			timeout: 0,
+			err:     commands.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "send command over the channel owned by another user",
+			token:   otherToken,
+			cmd:     cmd,
+			timeout: 0,
+			err:     commands.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "send command to the thing not connected to the channel",
+			token:   token,
+			cmd:     notConnected,
+			timeout: 0,
+			err:     commands.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "send command to the thing with invalid ID",
+			token:   token,
+			cmd:     invalidThing,
+			timeout: 0,
+			err:     commands.ErrMalformedEntity,
+		},
+		{
+			desc:    "send command without name",
+			token:   token,
+			cmd:     unnamed,
+			timeout: 0,
+			err:     commands.ErrMalformedEntity,
+		},
+		{
+			desc:    "send command with negative timeout",
+			token:   token,
+			cmd:     cmd,
+			timeout: -time.Second,
+			err:     commands.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		sent, err := svc.SendCommand(context.Background(), tc.token, tc.cmd, tc.timeout)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		topics, msgs := pub.Published()
+		if err != nil {
+			assert.Empty(t, msgs, fmt.Sprintf("%s: expected no published messages\n", tc.desc))
+			continue
+		}
+
+		assert.Equal(t, commands.Pending, sent.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, commands.Pending, sent.Status))
+		assert.Equal(t, email, sent.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, email, sent.OwnerID))
+		expTimeout := tc.timeout
+		if expTimeout == 0 {
+			expTimeout = timeout
+		}
+		assert.Equal(t, expTimeout, sent.ExpiresAt.Sub(sent.CreatedAt), fmt.Sprintf("%s: expected timeout %s got %s\n", tc.desc, expTimeout, sent.ExpiresAt.Sub(sent.CreatedAt)))
+
+		require.Len(t, msgs, 1, fmt.Sprintf("%s: expected one published message\n", tc.desc))
+		assert.Equal(t, []string{chanID}, topics, fmt.Sprintf("%s: expected topic %s got %v\n", tc.desc, chanID, topics))
+		assert.Equal(t, commands.Topic(thingID), msgs[0].Subtopic, fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, commands.Topic(thingID), msgs[0].Subtopic))
+		assert.Equal(t, commands.Protocol, msgs[0].Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, commands.Protocol, msgs[0].Protocol))
+
+		var payload map[string]interface{}
+		err = json.Unmarshal(msgs[0].Payload, &payload)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		expPayload := map[string]interface{}{"id": sent.ID, "name": cmd.Name, "params": cmd.Params}
+		assert.Equal(t, expPayload, payload, fmt.Sprintf("%s: expected payload %v got %v\n", tc.desc, expPayload, payload))
+	}
+}
+
+func TestHandleAck(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+	sent, err := svc.SendCommand(context.Background(), token, cmd, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	spoofed := ackMessage(thingID, sent.ID, commands.Acked)
+	spoofed.Publisher = otherThingID
+
+	otherChannel := ackMessage(thingID, sent.ID, commands.Acked)
+	otherChannel.Channel = otherChanID
+
+	malformed := ackMessage(thingID, sent.ID, commands.Acked)
+	malformed.Payload = []byte("{")
+
+	cases := []struct {
+		desc   string
+		msg    messaging.Message
+		status string
+		err    error
+	}{
+		{
+			desc:   "handle ack with invalid status",
+			msg:    ackMessage(thingID, sent.ID, commands.TimedOut),
+			status: commands.Pending,
+			err:    commands.ErrMalformedEntity,
+		},
+		{
+			desc:   "handle malformed ack",
+			msg:    malformed,
+			status: commands.Pending,
+			err:    commands.ErrMalformedEntity,
+		},
+		{
+			desc:   "handle ack published by another thing",
+			msg:    spoofed,
+			status: commands.Pending,
+			err:    commands.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "handle ack published to another channel",
+			msg:    otherChannel,
+			status: commands.Pending,
+			err:    commands.ErrNotFound,
+		},
+		{
+			desc:   "handle ack of non-existing command",
+			msg:    ackMessage(thingID, "non-existing", commands.Acked),
+			status: commands.Pending,
+			err:    commands.ErrNotFound,
+		},
+		{
+			desc:   "handle delivery ack",
+			msg:    ackMessage(thingID, sent.ID, commands.Delivered),
+			status: commands.Delivered,
+			err:    nil,
+		},
+		{
+			desc:   "handle execution ack",
+			msg:    ackMessage(thingID, sent.ID, commands.Acked),
+			status: commands.Acked,
+			err:    nil,
+		},
+		{
+			desc:   "handle failure ack of acked command",
+			msg:    ackMessage(thingID, sent.ID, commands.Failed),
+			status: commands.Acked,
+			err:    commands.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.HandleAck(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		c, err := svc.ViewCommand(context.Background(), token, sent.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.status, c.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, c.Status))
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		sent, err := svc.SendCommand(context.Background(), token, cmd, 0)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, sent.ID)
+	}
+	err := svc.HandleAck(ackMessage(thingID, ids[0], commands.Failed))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		thingID string
+		pm      commands.PageMetadata
+		ids     []string
+		err     error
+	}{
+		{
+			desc:    "list commands of the thing",
+			token:   token,
+			thingID: thingID,
+			pm:      commands.PageMetadata{Limit: 10},
+			ids:     []string{ids[4], ids[3], ids[2], ids[1], ids[0]},
+			err:     nil,
+		},
+		{
+			desc:    "list page of commands",
+			token:   token,
+			thingID: thingID,
+			pm:      commands.PageMetadata{Offset: 1, Limit: 2},
+			ids:     []string{ids[3], ids[2]},
+			err:     nil,
+		},
+		{
+			desc:    "list failed commands",
+			token:   token,
+			thingID: thingID,
+			pm:      commands.PageMetadata{Limit: 10, Status: commands.Failed},
+			ids:     []string{ids[0]},
+			err:     nil,
+		},
+		{
+			desc:    "list commands of another user",
+			token:   otherToken,
+			thingID: thingID,
+			pm:      commands.PageMetadata{Limit: 10},
+			ids:     nil,
+			err:     nil,
+		},
+		{
+			desc:    "list commands with invalid token",
+			token:   wrongToken,
+			thingID: thingID,
+			pm:      commands.PageMetadata{Limit: 10},
+			err:     commands.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListCommands(context.Background(), tc.token, tc.thingID, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		var ids []string
+		for _, c := range page.Commands {
+			ids = append(ids, c.ID)
+		}
+		assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected commands %v got %v\n", tc.desc, tc.ids, ids))
+	}
+}
+
+func TestExpireCommands(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+
+	expired, err := svc.SendCommand(context.Background(), token, cmd, time.Millisecond)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	acked, err := svc.SendCommand(context.Background(), token, cmd, time.Millisecond)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.HandleAck(ackMessage(thingID, acked.ID, commands.Acked))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	active, err := svc.SendCommand(context.Background(), token, cmd, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	time.Sleep(2 * time.Millisecond)
+	n, err := svc.ExpireCommands(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), n, fmt.Sprintf("expected 1 expired command got %d", n))
+
+	cases := map[string]string{
+		expired.ID: commands.TimedOut,
+		acked.ID:   commands.Acked,
+		active.ID:  commands.Pending,
+	}
+	for id, status := range cases {
+		c, err := svc.ViewCommand(context.Background(), token, id)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, status, c.Status, fmt.Sprintf("command %s: expected status %s got %s\n", id, status, c.Status))
+	}
+
+	err = svc.HandleAck(ackMessage(thingID, expired.ID, commands.Acked))
+	assert.True(t, errors.Contains(err, commands.ErrNotFound), fmt.Sprintf("ack of timed out command: expected %s got %s\n", commands.ErrNotFound, err))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/commands"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveCommandOp         = "save_command"
+	retrieveCommandOp     = "retrieve_command"
+	retrieveAllCommandsOp = "retrieve_all_commands"
+	updateStatusOp        = "update_command_status"
+	expireCommandsOp      = "expire_commands"
+)
+
+var _ commands.CommandRepository = (*commandRepositoryMiddleware)(nil)
+
+type commandRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   commands.CommandRepository
+}
+
+// CommandRepositoryMiddleware tracks request and their latency, and adds
+// spans to context.
+func CommandRepositoryMiddleware(tracer opentracing.Tracer, repo commands.CommandRepository) commands.CommandRepository {
+	return commandRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (crm commandRepositoryMiddleware) Save(ctx context.Context, cmd commands.Command) (string, error) {
+	span := createSpan(ctx, crm.tracer, saveCommandOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Save(ctx, cmd)
+}
+
+func (crm commandRepositoryMiddleware) Retrieve(ctx context.Context, owner, id string) (commands.Command, error) {
+	span := createSpan(ctx, crm.tracer, retrieveCommandOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Retrieve(ctx, owner, id)
+}
+
+func (crm commandRepositoryMiddleware) RetrieveAll(ctx context.Context, owner, thingID string, pm commands.PageMetadata) (commands.CommandsPage, error) {
+	span := createSpan(ctx, crm.tracer, retrieveAllCommandsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveAll(ctx, owner, thingID, pm)
+}
+
+func (crm commandRepositoryMiddleware) UpdateStatus(ctx context.Context, cmd commands.Command, from []string) error {
+	span := createSpan(ctx, crm.tracer, updateStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.UpdateStatus(ctx, cmd, from)
+}
+
+func (crm commandRepositoryMiddleware) Expire(ctx context.Context, at time.Time, from []string) (uint64, error) {
+	span := createSpan(ctx, crm.tracer, expireCommandsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Expire(ctx, at, from)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Alarm severities, from the most to the least severe.
+const (
+	CriticalSeverity = "critical"
+	MajorSeverity    = "major"
+	MinorSeverity    = "minor"
+	WarningSeverity  = "warning"
+)
+
+// Alarm statuses. Raised alarm can be acknowledged by the operator, while
+// both raised and acknowledged alarms can be cleared. Only one alarm per
+// rule can be active, i.e. not cleared, at a time.
+const (
+	RaisedStatus       = "raised"
+	AcknowledgedStatus = "acknowledged"
+	ClearedStatus      = "cleared"
+)
+
+// Alarm represents the alarm raised by the rule.
+type Alarm struct {
+	ID        string
+	RuleID    string
+	OwnerID   string
+	ChannelID string
+	Subtopic  string
+	Publisher string
+	Name      string
+	Severity  string
+	Status    string
+	// Record is the SenML record which raised the alarm.
+	Record    senml.Message
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// AlarmChannelID is the alarm channel of the rule at the time the
+	// alarm was raised, so that the transitions are published to it even
+	// after the rule is removed.
+	AlarmChannelID string
+	// History contains the status transitions of the alarm, starting
+	// with the one that raised it.
+	History []Transition
+}
+
+// Transition represents the change of the alarm status.
+type Transition struct {
+	Status string
+	// Actor is the ID of the user who changed the status. It's empty
+	// if the status was changed by the service.
+	Actor   string
+	Comment string
+	Time    time.Time
+}
+
+// AlarmsPage contains page related metadata as well as list of alarms that
+// belong to this page.
+type AlarmsPage struct {
+	PageMetadata
+	Total  uint64
+	Alarms []Alarm
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset    uint64
+	Limit     uint64
+	ChannelID string
+	Status    string
+	Severity  string
+}
+
+// AlarmRepository specifies an alarm persistence API.
+type AlarmRepository interface {
+	// Save persists the alarm along with its history. Saving an alarm of
+	// the rule which already has an active alarm fails with ErrConflict.
+	Save(ctx context.Context, alarm Alarm) (string, error)
+
+	// Retrieve retrieves the alarm and its history, for the given owner
+	// and alarm ID.
+	Retrieve(ctx context.Context, owner, id string) (Alarm, error)
+
+	// RetrieveActive retrieves the alarm of the rule which is not cleared.
+	RetrieveActive(ctx context.Context, ruleID string) (Alarm, error)
+
+	// RetrieveAll retrieves the subset of alarms owned by the specified
+	// user, newest first. History is not retrieved.
+	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (AlarmsPage, error)
+
+	// UpdateStatus changes the status of the alarm and appends the
+	// transition to its history.
+	UpdateStatus(ctx context.Context, id string, t Transition) error
+}
+
+// ValidSeverity checks if the severity is one of the supported ones.
+func ValidSeverity(severity string) bool {
+	switch severity {
+	case CriticalSeverity, MajorSeverity, MinorSeverity, WarningSeverity:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidStatus checks if the status is one of the supported ones.
+func ValidStatus(status string) bool {
+	switch status {
+	case RaisedStatus, AcknowledgedStatus, ClearedStatus:
+		return true
+	default:
+		return false
+	}
+}
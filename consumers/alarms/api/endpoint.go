@@ -0,0 +1,212 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/alarms"
+)
+
+func createRuleEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule := alarms.Rule{
+			ChannelID:      req.ChannelID,
+			Subtopic:       req.Subtopic,
+			Name:           req.Name,
+			Condition:      req.Condition,
+			ClearCondition: req.ClearCondition,
+			Severity:       req.Severity,
+			AlarmChannelID: req.AlarmChannelID,
+		}
+		saved, err := svc.CreateRule(ctx, req.token, rule)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toRuleRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewRuleEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.ViewRule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toRuleRes(rule), nil
+	}
+}
+
+func listRulesEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListRules(ctx, req.token, req.pageMetadata())
+		if err != nil {
+			return nil, err
+		}
+
+		res := rulesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Rules: []ruleRes{},
+		}
+		for _, rule := range page.Rules {
+			res.Rules = append(res.Rules, toRuleRes(rule))
+		}
+
+		return res, nil
+	}
+}
+
+func removeRuleEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveRule(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func viewAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		alarm, err := svc.ViewAlarm(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toAlarmRes(alarm), nil
+	}
+}
+
+func listAlarmsEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListAlarms(ctx, req.token, req.pageMetadata())
+		if err != nil {
+			return nil, err
+		}
+
+		res := alarmsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Alarms: []alarmRes{},
+		}
+		for _, alarm := range page.Alarms {
+			res.Alarms = append(res.Alarms, toAlarmRes(alarm))
+		}
+
+		return res, nil
+	}
+}
+
+func acknowledgeAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		alarm, err := svc.AcknowledgeAlarm(ctx, req.token, req.id, req.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		return toAlarmRes(alarm), nil
+	}
+}
+
+func clearAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		alarm, err := svc.ClearAlarm(ctx, req.token, req.id, req.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		return toAlarmRes(alarm), nil
+	}
+}
+
+func toRuleRes(rule alarms.Rule) ruleRes {
+	return ruleRes{
+		ID:             rule.ID,
+		OwnerID:        rule.OwnerID,
+		ChannelID:      rule.ChannelID,
+		Subtopic:       rule.Subtopic,
+		Name:           rule.Name,
+		Condition:      rule.Condition,
+		ClearCondition: rule.ClearCondition,
+		Severity:       rule.Severity,
+		AlarmChannelID: rule.AlarmChannelID,
+	}
+}
+
+func toAlarmRes(alarm alarms.Alarm) alarmRes {
+	res := alarmRes{
+		ID:        alarm.ID,
+		RuleID:    alarm.RuleID,
+		ChannelID: alarm.ChannelID,
+		Subtopic:  alarm.Subtopic,
+		Publisher: alarm.Publisher,
+		Name:      alarm.Name,
+		Severity:  alarm.Severity,
+		Status:    alarm.Status,
+		Record:    alarm.Record,
+		CreatedAt: alarm.CreatedAt,
+		UpdatedAt: alarm.UpdatedAt,
+	}
+	for _, t := range alarm.History {
+		res.History = append(res.History, transitionRes{
+			Status:  t.Status,
+			Actor:   t.Actor,
+			Comment: t.Comment,
+			Time:    t.Time,
+		})
+	}
+	return res
+}
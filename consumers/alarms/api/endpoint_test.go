@@ -0,0 +1,407 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+	httpapi "github.com/mainflux/mainflux/consumers/alarms/api"
+	"github.com/mainflux/mainflux/consumers/alarms/mocks"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	token       = "token"
+	wrongToken  = "wrong-token"
+	email       = "user@example.com"
+	chanID      = "chan"
+	otherChanID = "other-chan"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newService() alarms.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(map[string]string{chanID: email, otherChanID: "other@example.com"})
+	return alarms.New(auth, things, mocks.NewRuleRepository(), mocks.NewAlarmRepository(), uuid.NewMock(), mocks.NewPublisher())
+}
+
+func newServer(svc alarms.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+type ruleReq struct {
+	ChannelID      string `json:"channel_id,omitempty"`
+	Subtopic       string `json:"subtopic,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Condition      string `json:"condition,omitempty"`
+	ClearCondition string `json:"clear_condition,omitempty"`
+	Severity       string `json:"severity,omitempty"`
+}
+
+var rule = ruleReq{
+	ChannelID:      chanID,
+	Name:           "High temperature",
+	Condition:      `name == "temp" && v > 40`,
+	ClearCondition: `name == "temp" && v < 30`,
+	Severity:       alarms.CriticalSeverity,
+}
+
+func TestCreateRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create rule",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/rules/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create rule with invalid token",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       wrongToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule with empty token",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule of the channel owned by another user",
+			req:         toJSON(ruleReq{ChannelID: otherChanID, Condition: rule.Condition, Severity: rule.Severity}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule without channel",
+			req:         toJSON(ruleReq{Condition: rule.Condition, Severity: rule.Severity}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid severity",
+			req:         toJSON(ruleReq{ChannelID: chanID, Condition: rule.Condition, Severity: "fatal"}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid condition",
+			req:         toJSON(ruleReq{ChannelID: chanID, Condition: "name > 40", Severity: rule.Severity}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid request format",
+			req:         "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without content type",
+			req:         toJSON(rule),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/rules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.location != "" {
+			location := res.Header.Get("Location")
+			assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+		}
+	}
+}
+
+func TestListRules(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		r := rule
+		r.Subtopic = fmt.Sprintf("room%d", i)
+		_, err := svc.CreateRule(nil, token, alarms.Rule{
+			ChannelID: r.ChannelID,
+			Subtopic:  r.Subtopic,
+			Condition: r.Condition,
+			Severity:  r.Severity,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		count  int
+	}{
+		{
+			desc:   "list rules",
+			url:    "/rules",
+			token:  token,
+			status: http.StatusOK,
+			count:  3,
+		},
+		{
+			desc:   "list rules with limit",
+			url:    "/rules?offset=1&limit=1",
+			token:  token,
+			status: http.StatusOK,
+			count:  1,
+		},
+		{
+			desc:   "list rules of another channel",
+			url:    fmt.Sprintf("/rules?channel=%s", otherChanID),
+			token:  token,
+			status: http.StatusOK,
+			count:  0,
+		},
+		{
+			desc:   "list rules with invalid limit",
+			url:    "/rules?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules with invalid token",
+			url:    "/rules",
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s%s", ts.URL, tc.url),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+		var body struct {
+			Rules []json.RawMessage `json:"rules"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Len(t, body.Rules, tc.count, fmt.Sprintf("%s: expected %d rules got %d", tc.desc, tc.count, len(body.Rules)))
+	}
+}
+
+func TestAlarmLifecycle(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	_, err := svc.CreateRule(nil, token, alarms.Rule{
+		ChannelID: rule.ChannelID,
+		Condition: rule.Condition,
+		Severity:  rule.Severity,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Consume(messaging.Message{Channel: chanID, Payload: []byte(`[{"n":"temp","v":45}]`)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	page, err := svc.ListAlarms(nil, token, alarms.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Alarms, 1, "alarm must be raised")
+	id := page.Alarms[0].ID
+
+	cases := []struct {
+		desc        string
+		method      string
+		url         string
+		contentType string
+		body        string
+		token       string
+		status      int
+		alarmStatus string
+	}{
+		{
+			desc:        "list raised alarms",
+			method:      http.MethodGet,
+			url:         fmt.Sprintf("/alarms?status=%s", alarms.RaisedStatus),
+			token:       token,
+			status:      http.StatusOK,
+			alarmStatus: alarms.RaisedStatus,
+		},
+		{
+			desc:   "list alarms with invalid status",
+			method: http.MethodGet,
+			url:    "/alarms?status=active",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:        "view alarm",
+			method:      http.MethodGet,
+			url:         fmt.Sprintf("/alarms/%s", id),
+			token:       token,
+			status:      http.StatusOK,
+			alarmStatus: alarms.RaisedStatus,
+		},
+		{
+			desc:   "view non-existing alarm",
+			method: http.MethodGet,
+			url:    "/alarms/non-existing",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "acknowledge alarm with invalid token",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/alarms/%s/ack", id),
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:        "acknowledge alarm with invalid request format",
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("/alarms/%s/ack", id),
+			contentType: contentType,
+			body:        "{",
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "acknowledge alarm",
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("/alarms/%s/ack", id),
+			contentType: contentType,
+			body:        `{"comment":"on it"}`,
+			token:       token,
+			status:      http.StatusOK,
+			alarmStatus: alarms.AcknowledgedStatus,
+		},
+		{
+			desc:   "acknowledge acknowledged alarm",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/alarms/%s/ack", id),
+			token:  token,
+			status: http.StatusConflict,
+		},
+		{
+			desc:        "clear alarm without comment",
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("/alarms/%s/clear", id),
+			token:       token,
+			status:      http.StatusOK,
+			alarmStatus: alarms.ClearedStatus,
+		},
+		{
+			desc:   "clear cleared alarm",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/alarms/%s/clear", id),
+			token:  token,
+			status: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         fmt.Sprintf("%s%s", ts.URL, tc.url),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.alarmStatus == "" {
+			continue
+		}
+
+		var body struct {
+			Status string `json:"status"`
+			Alarms []struct {
+				Status string `json:"status"`
+			} `json:"alarms"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		status := body.Status
+		if len(body.Alarms) > 0 {
+			status = body.Alarms[0].Status
+		}
+		assert.Equal(t, tc.alarmStatus, status, fmt.Sprintf("%s: expected alarm status %s got %s", tc.desc, tc.alarmStatus, status))
+	}
+}
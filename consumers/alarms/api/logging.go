@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+	log "github.com/mainflux/mainflux/logger"
+)
+
+var _ alarms.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    alarms.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc alarms.Service, logger log.Logger) alarms.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateRule(ctx context.Context, token string, rule alarms.Rule) (saved alarms.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_rule with the id %s for channel %s took %s to complete", saved.ID, rule.ChannelID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateRule(ctx, token, rule)
+}
+
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, token, id string) (rule alarms.Rule, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_rule for rule %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListRules(ctx context.Context, token string, pm alarms.PageMetadata) (page alarms.RulesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_rules took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RemoveRule(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_rule for rule %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveRule(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ViewAlarm(ctx context.Context, token, id string) (alarm alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_alarm for alarm %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewAlarm(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListAlarms(ctx context.Context, token string, pm alarms.PageMetadata) (page alarms.AlarmsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_alarms took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListAlarms(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) AcknowledgeAlarm(ctx context.Context, token, id, comment string) (alarm alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method acknowledge_alarm for alarm %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AcknowledgeAlarm(ctx, token, id, comment)
+}
+
+func (lm *loggingMiddleware) ClearAlarm(ctx context.Context, token, id, comment string) (alarm alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method clear_alarm for alarm %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ClearAlarm(ctx, token, id, comment)
+}
+
+func (lm *loggingMiddleware) Consume(msg interface{}) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Consume(msg)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/consumers/alarms"
+)
+
+var _ alarms.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     alarms.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc alarms.Service, counter metrics.Counter, latency metrics.Histogram) alarms.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) CreateRule(ctx context.Context, token string, rule alarms.Rule) (alarms.Rule, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_rule").Add(1)
+		ms.latency.With("method", "create_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateRule(ctx, token, rule)
+}
+
+func (ms *metricsMiddleware) ViewRule(ctx context.Context, token, id string) (alarms.Rule, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_rule").Add(1)
+		ms.latency.With("method", "view_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewRule(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListRules(ctx context.Context, token string, pm alarms.PageMetadata) (alarms.RulesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_rules").Add(1)
+		ms.latency.With("method", "list_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListRules(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RemoveRule(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_rule").Add(1)
+		ms.latency.With("method", "remove_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveRule(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ViewAlarm(ctx context.Context, token, id string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_alarm").Add(1)
+		ms.latency.With("method", "view_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewAlarm(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListAlarms(ctx context.Context, token string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_alarms").Add(1)
+		ms.latency.With("method", "list_alarms").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListAlarms(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) AcknowledgeAlarm(ctx context.Context, token, id, comment string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "acknowledge_alarm").Add(1)
+		ms.latency.With("method", "acknowledge_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AcknowledgeAlarm(ctx, token, id, comment)
+}
+
+func (ms *metricsMiddleware) ClearAlarm(ctx context.Context, token, id, comment string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "clear_alarm").Add(1)
+		ms.latency.With("method", "clear_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ClearAlarm(ctx, token, id, comment)
+}
+
+func (ms *metricsMiddleware) Consume(msg interface{}) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "consume").Add(1)
+		ms.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Consume(msg)
+}
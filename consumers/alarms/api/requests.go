@@ -0,0 +1,101 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/consumers/alarms"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const maxLimitSize = 100
+
+type createRuleReq struct {
+	token          string
+	ChannelID      string `json:"channel_id"`
+	Subtopic       string `json:"subtopic,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Condition      string `json:"condition"`
+	ClearCondition string `json:"clear_condition,omitempty"`
+	Severity       string `json:"severity"`
+	AlarmChannelID string `json:"alarm_channel_id,omitempty"`
+}
+
+func (req createRuleReq) validate() error {
+	if req.token == "" {
+		return alarms.ErrUnauthorizedAccess
+	}
+	if req.ChannelID == "" || req.Condition == "" {
+		return alarms.ErrMalformedEntity
+	}
+	if !alarms.ValidSeverity(req.Severity) {
+		return alarms.ErrMalformedEntity
+	}
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return alarms.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return alarms.ErrMalformedEntity
+	}
+	return nil
+}
+
+type listReq struct {
+	token     string
+	offset    uint64
+	limit     uint64
+	channelID string
+	status    string
+	severity  string
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return alarms.ErrUnauthorizedAccess
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	if req.status != "" && !alarms.ValidStatus(req.status) {
+		return errors.ErrInvalidQueryParams
+	}
+	if req.severity != "" && !alarms.ValidSeverity(req.severity) {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
+
+func (req listReq) pageMetadata() alarms.PageMetadata {
+	return alarms.PageMetadata{
+		Offset:    req.offset,
+		Limit:     req.limit,
+		ChannelID: req.channelID,
+		Status:    req.status,
+		Severity:  req.severity,
+	}
+}
+
+type changeStatusReq struct {
+	token   string
+	id      string
+	Comment string `json:"comment,omitempty"`
+}
+
+func (req changeStatusReq) validate() error {
+	if req.token == "" {
+		return alarms.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return alarms.ErrMalformedEntity
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var (
+	_ mainflux.Response = (*ruleRes)(nil)
+	_ mainflux.Response = (*rulesPageRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+	_ mainflux.Response = (*alarmRes)(nil)
+	_ mainflux.Response = (*alarmsPageRes)(nil)
+)
+
+type ruleRes struct {
+	ID             string `json:"id"`
+	OwnerID        string `json:"owner_id"`
+	ChannelID      string `json:"channel_id"`
+	Subtopic       string `json:"subtopic,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Condition      string `json:"condition"`
+	ClearCondition string `json:"clear_condition,omitempty"`
+	Severity       string `json:"severity"`
+	AlarmChannelID string `json:"alarm_channel_id,omitempty"`
+	created        bool
+}
+
+func (res ruleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func (res ruleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/rules/%s", res.ID),
+		}
+	}
+	return map[string]string{}
+}
+
+func (res ruleRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type rulesPageRes struct {
+	pageRes
+	Rules []ruleRes `json:"rules"`
+}
+
+func (res rulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesPageRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type transitionRes struct {
+	Status  string    `json:"status"`
+	Actor   string    `json:"actor,omitempty"`
+	Comment string    `json:"comment,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+type alarmRes struct {
+	ID        string          `json:"id"`
+	RuleID    string          `json:"rule_id"`
+	ChannelID string          `json:"channel_id"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Severity  string          `json:"severity"`
+	Status    string          `json:"status"`
+	Record    senml.Message   `json:"record"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	History   []transitionRes `json:"history,omitempty"`
+}
+
+func (res alarmRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmRes) Empty() bool {
+	return false
+}
+
+type alarmsPageRes struct {
+	pageRes
+	Alarms []alarmRes `json:"alarms"`
+}
+
+func (res alarmsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmsPageRes) Empty() bool {
+	return false
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
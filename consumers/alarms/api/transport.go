@@ -0,0 +1,233 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/alarms"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/errors"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType = "application/json"
+
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	channelKey  = "channel"
+	statusKey   = "status"
+	severityKey = "severity"
+
+	defOffset = 0
+	defLimit  = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc alarms.Service, tracer opentracing.Tracer) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	mux := bone.New()
+
+	mux.Post("/rules", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_rule")(createRuleEndpoint(svc)),
+		decodeCreateRule,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/rules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_rule")(viewRuleEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/rules", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_rules")(listRulesEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/rules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_rule")(removeRuleEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/alarms/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_alarm")(viewAlarmEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/alarms", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_alarms")(listAlarmsEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/alarms/:id/ack", kithttp.NewServer(
+		kitot.TraceServer(tracer, "acknowledge_alarm")(acknowledgeAlarmEndpoint(svc)),
+		decodeChangeStatus,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/alarms/:id/clear", kithttp.NewServer(
+		kitot.TraceServer(tracer, "clear_alarm")(clearAlarmEndpoint(svc)),
+		decodeChangeStatus,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.GetFunc("/version", mainflux.Version("alarms"))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := createRuleReq{token: r.Header.Get("Authorization")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(alarms.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := httputil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := httputil.ReadStringQuery(r, statusKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sev, err := httputil.ReadStringQuery(r, severityKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listReq{
+		token:     r.Header.Get("Authorization"),
+		offset:    o,
+		limit:     l,
+		channelID: c,
+		status:    st,
+		severity:  sev,
+	}
+
+	return req, nil
+}
+
+// decodeChangeStatus decodes the optional comment of the status change.
+func decodeChangeStatus(_ context.Context, r *http.Request) (interface{}, error) {
+	req := changeStatusReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if r.ContentLength == 0 {
+		return req, nil
+	}
+
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return nil, errors.Wrap(alarms.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, alarms.ErrMalformedEntity),
+			errors.Contains(errorVal, conditions.ErrInvalidCondition),
+			errors.Contains(errorVal, errors.ErrInvalidQueryParams):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, alarms.ErrUnauthorizedAccess):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, alarms.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, alarms.ErrConflict),
+			errors.Contains(errorVal, alarms.ErrStatusTransition):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, errors.ErrUnsupportedContentType):
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
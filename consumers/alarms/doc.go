@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package alarms contains the domain concept definitions needed to support
+// Mainflux alarms service functionality. Alarms service evaluates alarm rules
+// against the consumed messages and keeps the state of the raised alarms.
+package alarms
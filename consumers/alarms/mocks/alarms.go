@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+)
+
+var _ alarms.AlarmRepository = (*alarmRepositoryMock)(nil)
+
+type alarmRepositoryMock struct {
+	mu     sync.Mutex
+	alarms map[string]alarms.Alarm
+}
+
+// NewAlarmRepository creates in-memory alarm repository.
+func NewAlarmRepository() alarms.AlarmRepository {
+	return &alarmRepositoryMock{
+		alarms: make(map[string]alarms.Alarm),
+	}
+}
+
+func (arm *alarmRepositoryMock) Save(_ context.Context, alarm alarms.Alarm) (string, error) {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	for _, a := range arm.alarms {
+		if a.RuleID == alarm.RuleID && a.Status != alarms.ClearedStatus {
+			return "", alarms.ErrConflict
+		}
+	}
+	arm.alarms[alarm.ID] = alarm
+	return alarm.ID, nil
+}
+
+func (arm *alarmRepositoryMock) Retrieve(_ context.Context, owner, id string) (alarms.Alarm, error) {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	alarm, ok := arm.alarms[id]
+	if !ok || alarm.OwnerID != owner {
+		return alarms.Alarm{}, alarms.ErrNotFound
+	}
+	return copyAlarm(alarm), nil
+}
+
+func (arm *alarmRepositoryMock) RetrieveActive(_ context.Context, ruleID string) (alarms.Alarm, error) {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	for _, alarm := range arm.alarms {
+		if alarm.RuleID == ruleID && alarm.Status != alarms.ClearedStatus {
+			alarm.History = nil
+			return alarm, nil
+		}
+	}
+	return alarms.Alarm{}, alarms.ErrNotFound
+}
+
+func (arm *alarmRepositoryMock) RetrieveAll(_ context.Context, owner string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	var items []alarms.Alarm
+	for _, alarm := range arm.alarms {
+		if alarm.OwnerID != owner ||
+			(pm.ChannelID != "" && alarm.ChannelID != pm.ChannelID) ||
+			(pm.Status != "" && alarm.Status != pm.Status) ||
+			(pm.Severity != "" && alarm.Severity != pm.Severity) {
+			continue
+		}
+		alarm.History = nil
+		items = append(items, alarm)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	start, end := bounds(len(items), pm)
+	return alarms.AlarmsPage{
+		PageMetadata: pm,
+		Total:        uint64(len(items)),
+		Alarms:       items[start:end],
+	}, nil
+}
+
+func (arm *alarmRepositoryMock) UpdateStatus(_ context.Context, id string, t alarms.Transition) error {
+	arm.mu.Lock()
+	defer arm.mu.Unlock()
+
+	alarm, ok := arm.alarms[id]
+	if !ok {
+		return alarms.ErrNotFound
+	}
+	alarm.Status = t.Status
+	alarm.UpdatedAt = t.Time
+	alarm.History = append(copyAlarm(alarm).History, t)
+	arm.alarms[id] = alarm
+	return nil
+}
+
+func copyAlarm(alarm alarms.Alarm) alarms.Alarm {
+	alarm.History = append([]alarms.Transition{}, alarm.History...)
+	return alarm
+}
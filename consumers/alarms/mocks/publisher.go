@@ -0,0 +1,42 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// Publisher is the publisher mock which keeps the published messages.
+type Publisher struct {
+	mu   sync.Mutex
+	msgs []messaging.Message
+}
+
+// NewPublisher returns the publisher mock.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// Publish stores the message.
+func (pub *Publisher) Publish(_ string, msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.msgs = append(pub.msgs, msg)
+	return nil
+}
+
+// Messages returns the published messages and removes them from the mock.
+func (pub *Publisher) Messages() []messaging.Message {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	msgs := pub.msgs
+	pub.msgs = nil
+	return msgs
+}
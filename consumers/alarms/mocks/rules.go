@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+)
+
+var _ alarms.RuleRepository = (*ruleRepositoryMock)(nil)
+
+type ruleRepositoryMock struct {
+	mu    sync.Mutex
+	rules map[string]alarms.Rule
+}
+
+// NewRuleRepository creates in-memory alarm rule repository.
+func NewRuleRepository() alarms.RuleRepository {
+	return &ruleRepositoryMock{
+		rules: make(map[string]alarms.Rule),
+	}
+}
+
+func (rrm *ruleRepositoryMock) Save(_ context.Context, rule alarms.Rule) (string, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rrm.rules[rule.ID] = rule
+	return rule.ID, nil
+}
+
+func (rrm *ruleRepositoryMock) Retrieve(_ context.Context, owner, id string) (alarms.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rule, ok := rrm.rules[id]
+	if !ok || rule.OwnerID != owner {
+		return alarms.Rule{}, alarms.ErrNotFound
+	}
+	return rule, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveAll(_ context.Context, owner string, pm alarms.PageMetadata) (alarms.RulesPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var items []alarms.Rule
+	for _, rule := range rrm.rules {
+		if rule.OwnerID != owner ||
+			(pm.ChannelID != "" && rule.ChannelID != pm.ChannelID) ||
+			(pm.Severity != "" && rule.Severity != pm.Severity) {
+			continue
+		}
+		items = append(items, rule)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	start, end := bounds(len(items), pm)
+	return alarms.RulesPage{
+		PageMetadata: pm,
+		Total:        uint64(len(items)),
+		Rules:        items[start:end],
+	}, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByChannel(_ context.Context, chanID string) ([]alarms.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var items []alarms.Rule
+	for _, rule := range rrm.rules {
+		if rule.ChannelID == chanID {
+			items = append(items, rule)
+		}
+	}
+	return items, nil
+}
+
+func (rrm *ruleRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if rule, ok := rrm.rules[id]; ok && rule.OwnerID == owner {
+		delete(rrm.rules, id)
+	}
+	return nil
+}
+
+// bounds returns the bounds of the page of n sorted items.
+func bounds(n int, pm alarms.PageMetadata) (int, int) {
+	start := int(pm.Offset)
+	if start > n {
+		start = n
+	}
+	end := start + int(pm.Limit)
+	if end > n {
+		end = n
+	}
+	return start, end
+}
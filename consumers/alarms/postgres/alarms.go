@@ -0,0 +1,313 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/consumers/alarms"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+var _ alarms.AlarmRepository = (*alarmRepository)(nil)
+
+type alarmRepository struct {
+	db Database
+}
+
+// NewAlarmRepository instantiates a PostgreSQL implementation of alarm
+// repository.
+func NewAlarmRepository(db Database) alarms.AlarmRepository {
+	return &alarmRepository{
+		db: db,
+	}
+}
+
+func (ar alarmRepository) Save(ctx context.Context, alarm alarms.Alarm) (string, error) {
+	dba, err := toDBAlarm(alarm)
+	if err != nil {
+		return "", errors.Wrap(alarms.ErrSave, err)
+	}
+
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", errors.Wrap(alarms.ErrSave, err)
+	}
+
+	q := `INSERT INTO alarms (id, rule_id, owner_id, channel_id, subtopic, publisher, name, severity, status, record, created_at, updated_at, alarm_channel_id)
+	VALUES (:id, :rule_id, :owner_id, :channel_id, :subtopic, :publisher, :name, :severity, :status, :record, :created_at, :updated_at, :alarm_channel_id)`
+	if _, err := tx.NamedExecContext(ctx, q, dba); err != nil {
+		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return "", errors.Wrap(alarms.ErrConflict, err)
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(alarms.ErrMalformedEntity, err)
+			}
+		}
+		return "", errors.Wrap(alarms.ErrSave, err)
+	}
+
+	for _, t := range alarm.History {
+		if _, err := tx.NamedExecContext(ctx, insertTransition, toDBTransition(alarm.ID, t)); err != nil {
+			tx.Rollback()
+			return "", errors.Wrap(alarms.ErrSave, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", errors.Wrap(alarms.ErrSave, err)
+	}
+
+	return alarm.ID, nil
+}
+
+func (ar alarmRepository) Retrieve(ctx context.Context, owner, id string) (alarms.Alarm, error) {
+	q := fmt.Sprintf(`SELECT %s FROM alarms WHERE owner_id = $1 AND id = $2`, alarmColumns)
+
+	var dba dbAlarm
+	if err := ar.db.GetContext(ctx, &dba, q, owner, id); err != nil {
+		if err == sql.ErrNoRows {
+			return alarms.Alarm{}, errors.Wrap(alarms.ErrNotFound, err)
+		}
+		return alarms.Alarm{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	alarm, err := fromDBAlarm(dba)
+	if err != nil {
+		return alarms.Alarm{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	q = `SELECT status, actor, comment, time FROM alarm_history WHERE alarm_id = $1 ORDER BY time`
+	rows, err := ar.db.QueryxContext(ctx, q, id)
+	if err != nil {
+		return alarms.Alarm{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbt dbTransition
+		if err := rows.StructScan(&dbt); err != nil {
+			return alarms.Alarm{}, errors.Wrap(alarms.ErrSelectEntity, err)
+		}
+		alarm.History = append(alarm.History, fromDBTransition(dbt))
+	}
+
+	return alarm, nil
+}
+
+func (ar alarmRepository) RetrieveActive(ctx context.Context, ruleID string) (alarms.Alarm, error) {
+	q := fmt.Sprintf(`SELECT %s FROM alarms WHERE rule_id = $1 AND status <> $2`, alarmColumns)
+
+	var dba dbAlarm
+	if err := ar.db.GetContext(ctx, &dba, q, ruleID, alarms.ClearedStatus); err != nil {
+		if err == sql.ErrNoRows {
+			return alarms.Alarm{}, errors.Wrap(alarms.ErrNotFound, err)
+		}
+		return alarms.Alarm{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	alarm, err := fromDBAlarm(dba)
+	if err != nil {
+		return alarms.Alarm{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	return alarm, nil
+}
+
+func (ar alarmRepository) RetrieveAll(ctx context.Context, owner string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	params := map[string]interface{}{
+		"owner_id":   owner,
+		"channel_id": pm.ChannelID,
+		"status":     pm.Status,
+		"severity":   pm.Severity,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+	cond := `owner_id = :owner_id`
+	if pm.ChannelID != "" {
+		cond = fmt.Sprintf("%s AND channel_id = :channel_id", cond)
+	}
+	if pm.Status != "" {
+		cond = fmt.Sprintf("%s AND status = :status", cond)
+	}
+	if pm.Severity != "" {
+		cond = fmt.Sprintf("%s AND severity = :severity", cond)
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM alarms WHERE %s ORDER BY created_at DESC, id LIMIT :limit OFFSET :offset`, alarmColumns, cond)
+	rows, err := ar.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return alarms.AlarmsPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []alarms.Alarm
+	for rows.Next() {
+		var dba dbAlarm
+		if err := rows.StructScan(&dba); err != nil {
+			return alarms.AlarmsPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+		}
+		alarm, err := fromDBAlarm(dba)
+		if err != nil {
+			return alarms.AlarmsPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+		}
+		items = append(items, alarm)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM alarms WHERE %s`, cond)
+	total, err := total(ctx, ar.db, cq, params)
+	if err != nil {
+		return alarms.AlarmsPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	return alarms.AlarmsPage{
+		PageMetadata: pm,
+		Total:        total,
+		Alarms:       items,
+	}, nil
+}
+
+func (ar alarmRepository) UpdateStatus(ctx context.Context, id string, t alarms.Transition) error {
+	tx, err := ar.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(alarms.ErrUpdateEntity, err)
+	}
+
+	dbt := toDBTransition(id, t)
+	q := `UPDATE alarms SET status = :status, updated_at = :time WHERE id = :alarm_id`
+	res, err := tx.NamedExecContext(ctx, q, dbt)
+	if err != nil {
+		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == errDuplicate {
+			return errors.Wrap(alarms.ErrConflict, err)
+		}
+		return errors.Wrap(alarms.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(alarms.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		tx.Rollback()
+		return alarms.ErrNotFound
+	}
+
+	if _, err := tx.NamedExecContext(ctx, insertTransition, dbt); err != nil {
+		tx.Rollback()
+		return errors.Wrap(alarms.ErrUpdateEntity, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(alarms.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+const (
+	alarmColumns = `id, rule_id, owner_id, channel_id, subtopic, publisher, name, severity, status, record, created_at, updated_at, alarm_channel_id`
+
+	insertTransition = `INSERT INTO alarm_history (alarm_id, status, actor, comment, time)
+	VALUES (:alarm_id, :status, :actor, :comment, :time)`
+)
+
+type dbAlarm struct {
+	ID             string    `db:"id"`
+	RuleID         string    `db:"rule_id"`
+	OwnerID        string    `db:"owner_id"`
+	ChannelID      string    `db:"channel_id"`
+	Subtopic       string    `db:"subtopic"`
+	Publisher      string    `db:"publisher"`
+	Name           string    `db:"name"`
+	Severity       string    `db:"severity"`
+	Status         string    `db:"status"`
+	Record         []byte    `db:"record"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+	AlarmChannelID string    `db:"alarm_channel_id"`
+}
+
+func toDBAlarm(alarm alarms.Alarm) (dbAlarm, error) {
+	record, err := json.Marshal(alarm.Record)
+	if err != nil {
+		return dbAlarm{}, err
+	}
+
+	return dbAlarm{
+		ID:             alarm.ID,
+		RuleID:         alarm.RuleID,
+		OwnerID:        alarm.OwnerID,
+		ChannelID:      alarm.ChannelID,
+		Subtopic:       alarm.Subtopic,
+		Publisher:      alarm.Publisher,
+		Name:           alarm.Name,
+		Severity:       alarm.Severity,
+		Status:         alarm.Status,
+		Record:         record,
+		CreatedAt:      alarm.CreatedAt,
+		UpdatedAt:      alarm.UpdatedAt,
+		AlarmChannelID: alarm.AlarmChannelID,
+	}, nil
+}
+
+func fromDBAlarm(dba dbAlarm) (alarms.Alarm, error) {
+	var record senml.Message
+	if len(dba.Record) > 0 {
+		if err := json.Unmarshal(dba.Record, &record); err != nil {
+			return alarms.Alarm{}, err
+		}
+	}
+
+	return alarms.Alarm{
+		ID:             dba.ID,
+		RuleID:         dba.RuleID,
+		OwnerID:        dba.OwnerID,
+		ChannelID:      dba.ChannelID,
+		Subtopic:       dba.Subtopic,
+		Publisher:      dba.Publisher,
+		Name:           dba.Name,
+		Severity:       dba.Severity,
+		Status:         dba.Status,
+		Record:         record,
+		CreatedAt:      dba.CreatedAt,
+		UpdatedAt:      dba.UpdatedAt,
+		AlarmChannelID: dba.AlarmChannelID,
+	}, nil
+}
+
+type dbTransition struct {
+	AlarmID string    `db:"alarm_id"`
+	Status  string    `db:"status"`
+	Actor   string    `db:"actor"`
+	Comment string    `db:"comment"`
+	Time    time.Time `db:"time"`
+}
+
+func toDBTransition(alarmID string, t alarms.Transition) dbTransition {
+	return dbTransition{
+		AlarmID: alarmID,
+		Status:  t.Status,
+		Actor:   t.Actor,
+		Comment: t.Comment,
+		Time:    t.Time,
+	}
+}
+
+func fromDBTransition(dbt dbTransition) alarms.Transition {
+	return alarms.Transition{
+		Status:  dbt.Status,
+		Actor:   dbt.Actor,
+		Comment: dbt.Comment,
+		Time:    dbt.Time,
+	}
+}
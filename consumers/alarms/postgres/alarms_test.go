@@ -0,0 +1,202 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+	"github.com/mainflux/mainflux/consumers/alarms/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAlarm(t *testing.T, owner, ruleID string) alarms.Alarm {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	v := 45.0
+	now := time.Now().UTC().Round(time.Millisecond)
+	return alarms.Alarm{
+		ID:        id,
+		RuleID:    ruleID,
+		OwnerID:   owner,
+		ChannelID: "chan",
+		Publisher: "thing",
+		Name:      "High temperature",
+		Severity:  alarms.MajorSeverity,
+		Status:    alarms.RaisedStatus,
+		Record:    senml.Message{Channel: "chan", Publisher: "thing", Name: "temp", Value: &v},
+		CreatedAt: now,
+		UpdatedAt: now,
+		History:   []alarms.Transition{{Status: alarms.RaisedStatus, Time: now}},
+	}
+}
+
+func TestAlarmSave(t *testing.T) {
+	repo := postgres.NewAlarmRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ruleID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	alarm := newAlarm(t, owner, ruleID)
+	active := newAlarm(t, owner, ruleID)
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		id    string
+		err   error
+	}{
+		{
+			desc:  "save alarm",
+			alarm: alarm,
+			id:    alarm.ID,
+			err:   nil,
+		},
+		{
+			desc:  "save alarm of the rule with active alarm",
+			alarm: active,
+			id:    "",
+			err:   alarms.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := repo.Save(context.Background(), tc.alarm)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), owner, alarm.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, alarm.Record, saved.Record, fmt.Sprintf("expected record %v got %v\n", alarm.Record, saved.Record))
+	assert.Len(t, saved.History, 1, fmt.Sprintf("expected 1 transition got %d\n", len(saved.History)))
+}
+
+func TestAlarmUpdateStatus(t *testing.T) {
+	repo := postgres.NewAlarmRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ruleID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	alarm := newAlarm(t, owner, ruleID)
+	_, err = repo.Save(context.Background(), alarm)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		tr     alarms.Transition
+		active bool
+		err    error
+	}{
+		{
+			desc:   "acknowledge alarm",
+			id:     alarm.ID,
+			tr:     alarms.Transition{Status: alarms.AcknowledgedStatus, Actor: owner, Comment: "on it", Time: time.Now()},
+			active: true,
+			err:    nil,
+		},
+		{
+			desc:   "clear alarm",
+			id:     alarm.ID,
+			tr:     alarms.Transition{Status: alarms.ClearedStatus, Time: time.Now()},
+			active: false,
+			err:    nil,
+		},
+		{
+			desc: "update status of non-existing alarm",
+			id:   "non-existing",
+			tr:   alarms.Transition{Status: alarms.ClearedStatus, Time: time.Now()},
+			err:  alarms.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateStatus(context.Background(), tc.id, tc.tr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		_, err = repo.RetrieveActive(context.Background(), ruleID)
+		assert.Equal(t, tc.active, err == nil, fmt.Sprintf("%s: expected active alarm %t got error %s\n", tc.desc, tc.active, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), owner, alarm.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, alarms.ClearedStatus, saved.Status, fmt.Sprintf("expected status %s got %s\n", alarms.ClearedStatus, saved.Status))
+	assert.Len(t, saved.History, 3, fmt.Sprintf("expected 3 transitions got %d\n", len(saved.History)))
+
+	// The rule can raise a new alarm once the previous one is cleared.
+	_, err = repo.Save(context.Background(), newAlarm(t, owner, ruleID))
+	assert.Nil(t, err, fmt.Sprintf("save alarm after clear: got unexpected error: %s\n", err))
+}
+
+func TestAlarmRetrieveAll(t *testing.T) {
+	repo := postgres.NewAlarmRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := 6
+	for i := 0; i < n; i++ {
+		ruleID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		alarm := newAlarm(t, owner, ruleID)
+		if i%2 == 0 {
+			alarm.Severity = alarms.CriticalSeverity
+		}
+		_, err = repo.Save(context.Background(), alarm)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		pm    alarms.PageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all alarms",
+			pm:    alarms.PageMetadata{Limit: 10},
+			total: uint64(n),
+			size:  n,
+		},
+		{
+			desc:  "retrieve page of alarms",
+			pm:    alarms.PageMetadata{Offset: 4, Limit: 10},
+			total: uint64(n),
+			size:  2,
+		},
+		{
+			desc:  "retrieve alarms by severity",
+			pm:    alarms.PageMetadata{Limit: 10, Severity: alarms.CriticalSeverity},
+			total: uint64(n / 2),
+			size:  n / 2,
+		},
+		{
+			desc:  "retrieve alarms by status",
+			pm:    alarms.PageMetadata{Limit: 10, Status: alarms.ClearedStatus},
+			total: 0,
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), owner, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Alarms, tc.size, fmt.Sprintf("%s: expected %d alarms got %d\n", tc.desc, tc.size, len(page.Alarms)))
+	}
+}
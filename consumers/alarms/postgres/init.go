@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "alarms_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS alarm_rules (
+                        id              VARCHAR(254) PRIMARY KEY,
+                        owner_id        VARCHAR(254) NOT NULL,
+                        channel_id      VARCHAR(254) NOT NULL,
+                        subtopic        VARCHAR(1024) NOT NULL DEFAULT '',
+                        name            VARCHAR(1024) NOT NULL DEFAULT '',
+                        condition       TEXT NOT NULL,
+                        clear_condition TEXT NOT NULL DEFAULT '',
+                        severity        VARCHAR(32) NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS alarm_rules_channel_idx ON alarm_rules (channel_id)`,
+					`CREATE TABLE IF NOT EXISTS alarms (
+                        id         VARCHAR(254) PRIMARY KEY,
+                        rule_id    VARCHAR(254) NOT NULL,
+                        owner_id   VARCHAR(254) NOT NULL,
+                        channel_id VARCHAR(254) NOT NULL,
+                        subtopic   VARCHAR(1024) NOT NULL DEFAULT '',
+                        publisher  VARCHAR(254) NOT NULL DEFAULT '',
+                        name       VARCHAR(1024) NOT NULL DEFAULT '',
+                        severity   VARCHAR(32) NOT NULL,
+                        status     VARCHAR(32) NOT NULL,
+                        record     JSONB,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL
+                    )`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS alarms_active_rule_idx ON alarms (rule_id) WHERE status <> 'cleared'`,
+					`CREATE INDEX IF NOT EXISTS alarms_owner_idx ON alarms (owner_id, created_at)`,
+					`CREATE TABLE IF NOT EXISTS alarm_history (
+                        alarm_id VARCHAR(254) NOT NULL REFERENCES alarms (id) ON DELETE CASCADE,
+                        status   VARCHAR(32) NOT NULL,
+                        actor    VARCHAR(254) NOT NULL DEFAULT '',
+                        comment  TEXT NOT NULL DEFAULT '',
+                        time     TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS alarm_history_alarm_idx ON alarm_history (alarm_id, time)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS alarm_history",
+					"DROP TABLE IF EXISTS alarms",
+					"DROP TABLE IF EXISTS alarm_rules",
+				},
+			},
+			{
+				Id: "alarms_2",
+				Up: []string{
+					`ALTER TABLE alarm_rules ADD COLUMN IF NOT EXISTS alarm_channel_id VARCHAR(254) NOT NULL DEFAULT ''`,
+					`ALTER TABLE alarms ADD COLUMN IF NOT EXISTS alarm_channel_id VARCHAR(254) NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					"ALTER TABLE alarms DROP COLUMN IF EXISTS alarm_channel_id",
+					"ALTER TABLE alarm_rules DROP COLUMN IF EXISTS alarm_channel_id",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
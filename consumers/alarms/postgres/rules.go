@@ -0,0 +1,206 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/consumers/alarms"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	errDuplicate  = "unique_violation"
+	errInvalid    = "invalid_text_representation"
+	errTruncation = "string_data_right_truncation"
+)
+
+var _ alarms.RuleRepository = (*ruleRepository)(nil)
+
+type ruleRepository struct {
+	db Database
+}
+
+// NewRuleRepository instantiates a PostgreSQL implementation of alarm rule
+// repository.
+func NewRuleRepository(db Database) alarms.RuleRepository {
+	return &ruleRepository{
+		db: db,
+	}
+}
+
+func (rr ruleRepository) Save(ctx context.Context, rule alarms.Rule) (string, error) {
+	q := `INSERT INTO alarm_rules (id, owner_id, channel_id, subtopic, name, condition, clear_condition, severity, alarm_channel_id)
+	VALUES (:id, :owner_id, :channel_id, :subtopic, :name, :condition, :clear_condition, :severity, :alarm_channel_id)`
+
+	if _, err := rr.db.NamedExecContext(ctx, q, toDBRule(rule)); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return "", errors.Wrap(alarms.ErrConflict, err)
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(alarms.ErrMalformedEntity, err)
+			}
+		}
+		return "", errors.Wrap(alarms.ErrSave, err)
+	}
+
+	return rule.ID, nil
+}
+
+func (rr ruleRepository) Retrieve(ctx context.Context, owner, id string) (alarms.Rule, error) {
+	q := `SELECT id, owner_id, channel_id, subtopic, name, condition, clear_condition, severity, alarm_channel_id
+	FROM alarm_rules WHERE owner_id = $1 AND id = $2`
+
+	var dbr dbRule
+	if err := rr.db.GetContext(ctx, &dbr, q, owner, id); err != nil {
+		if err == sql.ErrNoRows {
+			return alarms.Rule{}, errors.Wrap(alarms.ErrNotFound, err)
+		}
+		return alarms.Rule{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	return fromDBRule(dbr), nil
+}
+
+func (rr ruleRepository) RetrieveAll(ctx context.Context, owner string, pm alarms.PageMetadata) (alarms.RulesPage, error) {
+	params := map[string]interface{}{
+		"owner_id":   owner,
+		"channel_id": pm.ChannelID,
+		"severity":   pm.Severity,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+	cond := `owner_id = :owner_id`
+	if pm.ChannelID != "" {
+		cond = fmt.Sprintf("%s AND channel_id = :channel_id", cond)
+	}
+	if pm.Severity != "" {
+		cond = fmt.Sprintf("%s AND severity = :severity", cond)
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, channel_id, subtopic, name, condition, clear_condition, severity, alarm_channel_id
+	FROM alarm_rules WHERE %s ORDER BY id LIMIT :limit OFFSET :offset`, cond)
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return alarms.RulesPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []alarms.Rule
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return alarms.RulesPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+		}
+		items = append(items, fromDBRule(dbr))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM alarm_rules WHERE %s`, cond)
+	total, err := total(ctx, rr.db, cq, params)
+	if err != nil {
+		return alarms.RulesPage{}, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+
+	return alarms.RulesPage{
+		PageMetadata: pm,
+		Total:        total,
+		Rules:        items,
+	}, nil
+}
+
+func (rr ruleRepository) RetrieveByChannel(ctx context.Context, chanID string) ([]alarms.Rule, error) {
+	q := `SELECT id, owner_id, channel_id, subtopic, name, condition, clear_condition, severity, alarm_channel_id
+	FROM alarm_rules WHERE channel_id = $1`
+
+	rows, err := rr.db.QueryxContext(ctx, q, chanID)
+	if err != nil {
+		return nil, errors.Wrap(alarms.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []alarms.Rule
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(alarms.ErrSelectEntity, err)
+		}
+		items = append(items, fromDBRule(dbr))
+	}
+
+	return items, nil
+}
+
+func (rr ruleRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM alarm_rules WHERE owner_id = :owner_id AND id = :id`
+
+	dbr := dbRule{
+		ID:      id,
+		OwnerID: owner,
+	}
+	if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+		return errors.Wrap(alarms.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+type dbRule struct {
+	ID             string `db:"id"`
+	OwnerID        string `db:"owner_id"`
+	ChannelID      string `db:"channel_id"`
+	Subtopic       string `db:"subtopic"`
+	Name           string `db:"name"`
+	Condition      string `db:"condition"`
+	ClearCondition string `db:"clear_condition"`
+	Severity       string `db:"severity"`
+	AlarmChannelID string `db:"alarm_channel_id"`
+}
+
+func toDBRule(rule alarms.Rule) dbRule {
+	return dbRule{
+		ID:             rule.ID,
+		OwnerID:        rule.OwnerID,
+		ChannelID:      rule.ChannelID,
+		Subtopic:       rule.Subtopic,
+		Name:           rule.Name,
+		Condition:      rule.Condition,
+		ClearCondition: rule.ClearCondition,
+		Severity:       rule.Severity,
+		AlarmChannelID: rule.AlarmChannelID,
+	}
+}
+
+func fromDBRule(dbr dbRule) alarms.Rule {
+	return alarms.Rule{
+		ID:             dbr.ID,
+		OwnerID:        dbr.OwnerID,
+		ChannelID:      dbr.ChannelID,
+		Subtopic:       dbr.Subtopic,
+		Name:           dbr.Name,
+		Condition:      dbr.Condition,
+		ClearCondition: dbr.ClearCondition,
+		Severity:       dbr.Severity,
+		AlarmChannelID: dbr.AlarmChannelID,
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import "context"
+
+// Rule represents the alarm rule of the channel. The alarm is raised when
+// a record of the message published to the channel satisfies the rule
+// condition and cleared when a record satisfies the clear condition.
+type Rule struct {
+	ID        string
+	OwnerID   string
+	ChannelID string
+	// Subtopic limits the rule to the messages published to the given
+	// subtopic. Messages of all the subtopics are evaluated if empty.
+	Subtopic  string
+	Name      string
+	Condition string
+	// ClearCondition is optional. If it's not set, alarms raised by the
+	// rule have to be cleared manually.
+	ClearCondition string
+	Severity       string
+	// AlarmChannelID is the channel of the rule owner the transitions of
+	// the alarms raised by the rule are published to. Transitions are not
+	// published if it's empty.
+	AlarmChannelID string
+}
+
+// RulesPage contains page related metadata as well as list of rules that
+// belong to this page.
+type RulesPage struct {
+	PageMetadata
+	Total uint64
+	Rules []Rule
+}
+
+// RuleRepository specifies an alarm rule persistence API.
+type RuleRepository interface {
+	// Save persists the rule. Successful operation is indicated by non-nil
+	// error response.
+	Save(ctx context.Context, rule Rule) (string, error)
+
+	// Retrieve retrieves the rule having the provided identifier, that is
+	// owned by the specified user.
+	Retrieve(ctx context.Context, owner, id string) (Rule, error)
+
+	// RetrieveAll retrieves the subset of rules owned by the specified user.
+	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (RulesPage, error)
+
+	// RetrieveByChannel retrieves all the rules of the channel.
+	RetrieveByChannel(ctx context.Context, chanID string) ([]Rule, error)
+
+	// Remove removes the rule having the provided identifier, that is owned
+	// by the specified user.
+	Remove(ctx context.Context, owner, id string) error
+}
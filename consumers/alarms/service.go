@@ -0,0 +1,441 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Protocol is set as the protocol of the published alarm transitions.
+const Protocol = "alarms"
+
+var (
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrCreateID indicates error in creating id for entity creation.
+	ErrCreateID = errors.New("failed to create id")
+
+	// ErrNotFound indicates a non-existent entity request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrConflict indicates that the rule already has an active alarm.
+	ErrConflict = errors.New("active alarm already exists")
+
+	// ErrSave indicates error saving entity.
+	ErrSave = errors.New("failed to save entity")
+
+	// ErrSelectEntity indicates problem with scanning data from db.
+	ErrSelectEntity = errors.New("failed to select entity")
+
+	// ErrUpdateEntity indicates error updating entity.
+	ErrUpdateEntity = errors.New("failed to update entity")
+
+	// ErrRemoveEntity indicates error in removing entity.
+	ErrRemoveEntity = errors.New("failed to remove entity")
+
+	// ErrStatusTransition indicates that the alarm can't be moved to the
+	// requested status, e.g. acknowledging the cleared alarm.
+	ErrStatusTransition = errors.New("invalid alarm status transition")
+
+	// ErrMessage indicates an error converting a message to Mainflux message.
+	ErrMessage = errors.New("failed to convert to Mainflux message")
+
+	// ErrPublish indicates an error publishing the alarm transition.
+	ErrPublish = errors.New("failed to publish alarm transition")
+)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateRule creates the alarm rule of the channel owned by the user
+	// identified by the provided key. The alarm channel of the rule has to
+	// be owned by the user as well.
+	CreateRule(ctx context.Context, token string, rule Rule) (Rule, error)
+
+	// ViewRule retrieves the rule having the provided identifier.
+	ViewRule(ctx context.Context, token, id string) (Rule, error)
+
+	// ListRules retrieves the rules owned by the user.
+	ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error)
+
+	// RemoveRule removes the rule having the provided identifier. Alarms
+	// raised by the rule are kept.
+	RemoveRule(ctx context.Context, token, id string) error
+
+	// ViewAlarm retrieves the alarm and its history.
+	ViewAlarm(ctx context.Context, token, id string) (Alarm, error)
+
+	// ListAlarms retrieves the alarms owned by the user.
+	ListAlarms(ctx context.Context, token string, pm PageMetadata) (AlarmsPage, error)
+
+	// AcknowledgeAlarm marks the raised alarm as acknowledged.
+	AcknowledgeAlarm(ctx context.Context, token, id, comment string) (Alarm, error)
+
+	// ClearAlarm clears the raised or acknowledged alarm.
+	ClearAlarm(ctx context.Context, token, id, comment string) (Alarm, error)
+
+	consumers.Consumer
+}
+
+// Event represents the alarm transition published to the alarm channel of
+// the rule.
+type Event struct {
+	AlarmID   string    `json:"alarm_id"`
+	RuleID    string    `json:"rule_id"`
+	ChannelID string    `json:"channel_id"`
+	Subtopic  string    `json:"subtopic,omitempty"`
+	Publisher string    `json:"publisher,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Severity  string    `json:"severity"`
+	Status    string    `json:"status"`
+	Actor     string    `json:"actor,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+var _ Service = (*alarmsService)(nil)
+
+type alarmsService struct {
+	auth        mainflux.AuthServiceClient
+	things      mainflux.ThingsServiceClient
+	rules       RuleRepository
+	alarms      AlarmRepository
+	idp         mainflux.IDProvider
+	publisher   messaging.Publisher
+	transformer transformers.Transformer
+}
+
+// New instantiates the alarms service implementation. Alarm transitions
+// are published to the alarm channels of the rules.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, rules RuleRepository, alarms AlarmRepository, idp mainflux.IDProvider, pub messaging.Publisher) Service {
+	return &alarmsService{
+		auth:        auth,
+		things:      things,
+		rules:       rules,
+		alarms:      alarms,
+		idp:         idp,
+		publisher:   pub,
+		transformer: senml.New(senml.JSON),
+	}
+}
+
+func (as *alarmsService) CreateRule(ctx context.Context, token string, rule Rule) (Rule, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Rule{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if _, err := as.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetEmail(), ChanID: rule.ChannelID}); err != nil {
+		return Rule{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	// Transitions are published on behalf of the owner, so they can't be
+	// published to the channel of another user.
+	if rule.AlarmChannelID != "" {
+		if _, err := as.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetEmail(), ChanID: rule.AlarmChannelID}); err != nil {
+			return Rule{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+	}
+	if !ValidSeverity(rule.Severity) {
+		return Rule{}, ErrMalformedEntity
+	}
+	if _, err := conditions.ParseCondition(rule.Condition); err != nil {
+		return Rule{}, err
+	}
+	if rule.ClearCondition != "" {
+		if _, err := conditions.ParseCondition(rule.ClearCondition); err != nil {
+			return Rule{}, err
+		}
+	}
+
+	rule.ID, err = as.idp.ID()
+	if err != nil {
+		return Rule{}, errors.Wrap(ErrCreateID, err)
+	}
+	rule.OwnerID = res.GetId()
+
+	if _, err := as.rules.Save(ctx, rule); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+func (as *alarmsService) ViewRule(ctx context.Context, token, id string) (Rule, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Rule{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return as.rules.Retrieve(ctx, res.GetId(), id)
+}
+
+func (as *alarmsService) ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return RulesPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return as.rules.RetrieveAll(ctx, res.GetId(), pm)
+}
+
+func (as *alarmsService) RemoveRule(ctx context.Context, token, id string) error {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return as.rules.Remove(ctx, res.GetId(), id)
+}
+
+func (as *alarmsService) ViewAlarm(ctx context.Context, token, id string) (Alarm, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Alarm{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return as.alarms.Retrieve(ctx, res.GetId(), id)
+}
+
+func (as *alarmsService) ListAlarms(ctx context.Context, token string, pm PageMetadata) (AlarmsPage, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return AlarmsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	return as.alarms.RetrieveAll(ctx, res.GetId(), pm)
+}
+
+func (as *alarmsService) AcknowledgeAlarm(ctx context.Context, token, id, comment string) (Alarm, error) {
+	return as.changeStatus(ctx, token, id, AcknowledgedStatus, comment)
+}
+
+func (as *alarmsService) ClearAlarm(ctx context.Context, token, id, comment string) (Alarm, error) {
+	return as.changeStatus(ctx, token, id, ClearedStatus, comment)
+}
+
+func (as *alarmsService) changeStatus(ctx context.Context, token, id, status, comment string) (Alarm, error) {
+	res, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Alarm{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	alarm, err := as.alarms.Retrieve(ctx, res.GetId(), id)
+	if err != nil {
+		return Alarm{}, err
+	}
+
+	t := Transition{
+		Status:  status,
+		Actor:   res.GetId(),
+		Comment: comment,
+		Time:    time.Now().UTC(),
+	}
+	if err := as.transition(ctx, &alarm, t); err != nil {
+		return Alarm{}, err
+	}
+
+	return alarm, nil
+}
+
+// transition moves the alarm to the new status and publishes the change.
+func (as *alarmsService) transition(ctx context.Context, alarm *Alarm, t Transition) error {
+	switch {
+	case t.Status == AcknowledgedStatus && alarm.Status != RaisedStatus,
+		t.Status == ClearedStatus && alarm.Status == ClearedStatus:
+		return ErrStatusTransition
+	}
+
+	if err := as.alarms.UpdateStatus(ctx, alarm.ID, t); err != nil {
+		return err
+	}
+	alarm.Status = t.Status
+	alarm.UpdatedAt = t.Time
+	alarm.History = append(alarm.History, t)
+
+	return as.publish(*alarm, t)
+}
+
+func (as *alarmsService) Consume(message interface{}) error {
+	msg, ok := message.(messaging.Message)
+	if !ok {
+		return ErrMessage
+	}
+	// Alarm transitions are not evaluated, to avoid feedback loops.
+	if msg.Protocol == Protocol {
+		return nil
+	}
+
+	ctx := context.Background()
+	rules, err := as.rules.RetrieveByChannel(ctx, msg.Channel)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// Only SenML messages are evaluated.
+	res, err := as.transformer.Transform(msg)
+	if err != nil {
+		return nil
+	}
+	records, ok := res.([]senml.Message)
+	if !ok {
+		return nil
+	}
+
+	// Rules are evaluated independently, so that the failure of one of
+	// them doesn't prevent raising or clearing the alarms of the others.
+	var errs error
+	for _, rule := range rules {
+		if rule.Subtopic != "" && rule.Subtopic != msg.Subtopic {
+			continue
+		}
+		if err := as.evaluate(ctx, rule, records); err != nil {
+			if errs == nil {
+				errs = err
+				continue
+			}
+			errs = errors.Wrap(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// evaluate raises the alarm if any of the records satisfies the rule
+// condition, or clears the active one if a record satisfies the clear
+// condition.
+func (as *alarmsService) evaluate(ctx context.Context, rule Rule, records []senml.Message) error {
+	cond, err := conditions.ParseCondition(rule.Condition)
+	if err != nil {
+		return err
+	}
+	var clearCond conditions.Condition
+	if rule.ClearCondition != "" {
+		if clearCond, err = conditions.ParseCondition(rule.ClearCondition); err != nil {
+			return err
+		}
+	}
+
+	cleared := false
+	for _, rec := range records {
+		if cond.Match(rec) {
+			return as.raise(ctx, rule, rec)
+		}
+		if clearCond != nil && clearCond.Match(rec) {
+			cleared = true
+		}
+	}
+	if !cleared {
+		return nil
+	}
+
+	alarm, err := as.alarms.RetrieveActive(ctx, rule.ID)
+	switch {
+	case errors.Contains(err, ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	t := Transition{
+		Status: ClearedStatus,
+		Time:   time.Now().UTC(),
+	}
+	return as.transition(ctx, &alarm, t)
+}
+
+func (as *alarmsService) raise(ctx context.Context, rule Rule, rec senml.Message) error {
+	// The rule keeps at most one active alarm.
+	_, err := as.alarms.RetrieveActive(ctx, rule.ID)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Contains(err, ErrNotFound):
+		return err
+	}
+
+	id, err := as.idp.ID()
+	if err != nil {
+		return errors.Wrap(ErrCreateID, err)
+	}
+	t := Transition{
+		Status: RaisedStatus,
+		Time:   time.Now().UTC(),
+	}
+	alarm := Alarm{
+		ID:             id,
+		RuleID:         rule.ID,
+		OwnerID:        rule.OwnerID,
+		ChannelID:      rec.Channel,
+		Subtopic:       rec.Subtopic,
+		Publisher:      rec.Publisher,
+		Name:           rule.Name,
+		Severity:       rule.Severity,
+		Status:         RaisedStatus,
+		Record:         rec,
+		CreatedAt:      t.Time,
+		UpdatedAt:      t.Time,
+		History:        []Transition{t},
+		AlarmChannelID: rule.AlarmChannelID,
+	}
+	if _, err := as.alarms.Save(ctx, alarm); err != nil {
+		// The alarm was raised by a concurrently consumed message.
+		if errors.Contains(err, ErrConflict) {
+			return nil
+		}
+		return err
+	}
+
+	return as.publish(alarm, t)
+}
+
+func (as *alarmsService) publish(alarm Alarm, t Transition) error {
+	if alarm.AlarmChannelID == "" || as.publisher == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(Event{
+		AlarmID:   alarm.ID,
+		RuleID:    alarm.RuleID,
+		ChannelID: alarm.ChannelID,
+		Subtopic:  alarm.Subtopic,
+		Publisher: alarm.Publisher,
+		Name:      alarm.Name,
+		Severity:  alarm.Severity,
+		Status:    t.Status,
+		Actor:     t.Actor,
+		Comment:   t.Comment,
+		Time:      t.Time,
+	})
+	if err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	msg := messaging.Message{
+		Channel:  alarm.AlarmChannelID,
+		Subtopic: alarm.Severity,
+		Protocol: Protocol,
+		Payload:  payload,
+		Created:  t.Time.UnixNano(),
+	}
+	if err := as.publisher.Publish(alarm.AlarmChannelID, msg); err != nil {
+		return errors.Wrap(ErrPublish, err)
+	}
+
+	return nil
+}
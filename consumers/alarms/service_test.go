@@ -0,0 +1,412 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+	"github.com/mainflux/mainflux/consumers/alarms/mocks"
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token             = "token"
+	wrongToken        = "wrong-token"
+	email             = "user@example.com"
+	otherToken        = "other-token"
+	otherEmail        = "other@example.com"
+	chanID            = "chan"
+	otherChanID       = "other-chan"
+	alarmsChanID      = "alarms"
+	otherAlarmsChanID = "other-alarms"
+)
+
+var rule = alarms.Rule{
+	ChannelID:      chanID,
+	Name:           "High temperature",
+	Condition:      `name == "temp" && v > 40`,
+	ClearCondition: `name == "temp" && v < 30`,
+	Severity:       alarms.MajorSeverity,
+	AlarmChannelID: alarmsChanID,
+}
+
+func newService(pub messaging.Publisher) alarms.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := mocks.NewThingsService(map[string]string{chanID: email, otherChanID: otherEmail, alarmsChanID: email, otherAlarmsChanID: otherEmail})
+	return alarms.New(auth, things, mocks.NewRuleRepository(), mocks.NewAlarmRepository(), uuid.NewMock(), pub)
+}
+
+func message(chanID, payload string) messaging.Message {
+	return messaging.Message{
+		Channel:   chanID,
+		Publisher: "thing",
+		Payload:   []byte(payload),
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  alarms.Rule
+		err   error
+	}{
+		{
+			desc:  "create rule",
+			token: token,
+			rule:  rule,
+			err:   nil,
+		},
+		{
+			desc:  "create rule with invalid token",
+			token: wrongToken,
+			rule:  rule,
+			err:   alarms.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule of the channel owned by another user",
+			token: otherToken,
+			rule:  rule,
+			err:   alarms.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule with alarm channel owned by another user",
+			token: token,
+			rule:  alarms.Rule{ChannelID: chanID, Condition: rule.Condition, Severity: alarms.MinorSeverity, AlarmChannelID: otherAlarmsChanID},
+			err:   alarms.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule with invalid severity",
+			token: token,
+			rule:  alarms.Rule{ChannelID: chanID, Condition: rule.Condition, Severity: "fatal"},
+			err:   alarms.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with invalid condition",
+			token: token,
+			rule:  alarms.Rule{ChannelID: chanID, Condition: "v >", Severity: alarms.MinorSeverity},
+			err:   conditions.ErrInvalidCondition,
+		},
+		{
+			desc:  "create rule with invalid clear condition",
+			token: token,
+			rule:  alarms.Rule{ChannelID: chanID, Condition: rule.Condition, ClearCondition: "v", Severity: alarms.MinorSeverity},
+			err:   conditions.ErrInvalidCondition,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := svc.CreateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected rule ID to be set\n", tc.desc))
+			assert.Equal(t, email, saved.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, email, saved.OwnerID))
+		}
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.RemoveRule(context.Background(), otherToken, saved.ID)
+	assert.Nil(t, err, fmt.Sprintf("removing rule of another user: expected no error got %s", err))
+	_, err = svc.ViewRule(context.Background(), token, saved.ID)
+	assert.Nil(t, err, fmt.Sprintf("rule removed by another user must be kept: %s", err))
+
+	err = svc.RemoveRule(context.Background(), token, saved.ID)
+	assert.Nil(t, err, fmt.Sprintf("removing rule: expected no error got %s", err))
+	_, err = svc.ViewRule(context.Background(), token, saved.ID)
+	assert.True(t, errors.Contains(err, alarms.ErrNotFound), fmt.Sprintf("viewing removed rule: expected %s got %s", alarms.ErrNotFound, err))
+}
+
+func TestConsume(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc := newService(pub)
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		msg    messaging.Message
+		status string
+		events []string
+	}{
+		{
+			desc:   "consume message not raising the alarm",
+			msg:    message(chanID, `[{"n":"temp","v":35}]`),
+			status: "",
+			events: nil,
+		},
+		{
+			desc:   "consume message of another channel",
+			msg:    message(otherChanID, `[{"n":"temp","v":45}]`),
+			status: "",
+			events: nil,
+		},
+		{
+			desc:   "consume non-SenML message",
+			msg:    message(chanID, `{"temp":45}`),
+			status: "",
+			events: nil,
+		},
+		{
+			desc:   "consume message raising the alarm",
+			msg:    message(chanID, `[{"n":"humidity","v":50},{"n":"temp","v":45}]`),
+			status: alarms.RaisedStatus,
+			events: []string{alarms.RaisedStatus},
+		},
+		{
+			desc:   "consume message while the alarm is active",
+			msg:    message(chanID, `[{"n":"temp","v":50}]`),
+			status: alarms.RaisedStatus,
+			events: nil,
+		},
+		{
+			desc:   "consume message clearing the alarm",
+			msg:    message(chanID, `[{"n":"temp","v":25}]`),
+			status: alarms.ClearedStatus,
+			events: []string{alarms.ClearedStatus},
+		},
+		{
+			desc:   "consume message clearing the cleared alarm",
+			msg:    message(chanID, `[{"n":"temp","v":20}]`),
+			status: alarms.ClearedStatus,
+			events: nil,
+		},
+		{
+			desc:   "consume alarm transition",
+			msg:    messaging.Message{Channel: chanID, Protocol: alarms.Protocol, Payload: []byte(`[{"n":"temp","v":45}]`)},
+			status: alarms.ClearedStatus,
+			events: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+		page, err := svc.ListAlarms(context.Background(), token, alarms.PageMetadata{Limit: 10})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		status := ""
+		if len(page.Alarms) > 0 {
+			status = page.Alarms[0].Status
+			assert.Equal(t, saved.ID, page.Alarms[0].RuleID, fmt.Sprintf("%s: expected rule %s got %s\n", tc.desc, saved.ID, page.Alarms[0].RuleID))
+			assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("%s: expected one alarm got %d\n", tc.desc, page.Total))
+		}
+		assert.Equal(t, tc.status, status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, status))
+
+		var events []string
+		for _, msg := range pub.Messages() {
+			var e alarms.Event
+			err := json.Unmarshal(msg.Payload, &e)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, alarmsChanID, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, alarmsChanID, msg.Channel))
+			events = append(events, e.Status)
+		}
+		assert.Equal(t, tc.events, events, fmt.Sprintf("%s: expected events %v got %v\n", tc.desc, tc.events, events))
+	}
+
+	// The alarm is raised again once the previous one is cleared.
+	err = svc.Consume(message(chanID, `[{"n":"temp","v":45}]`))
+	assert.Nil(t, err, fmt.Sprintf("raising alarm again: expected no error got %s", err))
+	page, err := svc.ListAlarms(context.Background(), token, alarms.PageMetadata{Limit: 10, Status: alarms.RaisedStatus})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("raising alarm again: expected one raised alarm got %d", page.Total))
+}
+
+func TestConsumeAlarmChannels(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc := newService(pub)
+
+	_, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other := rule
+	other.ChannelID = otherChanID
+	other.AlarmChannelID = otherAlarmsChanID
+	_, err = svc.CreateRule(context.Background(), otherToken, other)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	silent := rule
+	silent.AlarmChannelID = ""
+	_, err = svc.CreateRule(context.Background(), token, silent)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		msg     messaging.Message
+		channel string
+	}{
+		{
+			desc:    "raise alarm of the user",
+			msg:     message(chanID, `[{"n":"temp","v":45}]`),
+			channel: alarmsChanID,
+		},
+		{
+			desc:    "raise alarm of another user",
+			msg:     message(otherChanID, `[{"n":"temp","v":45}]`),
+			channel: otherAlarmsChanID,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+		// Rule without the alarm channel raises the alarm silently.
+		msgs := pub.Messages()
+		require.Len(t, msgs, 1, fmt.Sprintf("%s: expected single event got %d\n", tc.desc, len(msgs)))
+		assert.Equal(t, tc.channel, msgs[0].Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, tc.channel, msgs[0].Channel))
+	}
+}
+
+func TestConsumeFailure(t *testing.T) {
+	pub := mocks.NewPublisher()
+	rules := mocks.NewRuleRepository()
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(map[string]string{chanID: email, alarmsChanID: email})
+	svc := alarms.New(auth, things, rules, mocks.NewAlarmRepository(), uuid.NewMock(), pub)
+
+	// Rule with malformed condition can't be created using the service,
+	// so it's saved directly to the repository.
+	invalid := rule
+	invalid.ID = "invalid"
+	invalid.OwnerID = email
+	invalid.Condition = `name == 40`
+	_, err := rules.Save(context.Background(), invalid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Consume(message(chanID, `[{"n":"temp","v":45}]`))
+	assert.True(t, errors.Contains(err, conditions.ErrInvalidCondition), fmt.Sprintf("expected %s got %s", conditions.ErrInvalidCondition, err))
+
+	page, err := svc.ListAlarms(context.Background(), token, alarms.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Alarms, 1, "alarm of the valid rule must be raised")
+	assert.Equal(t, saved.ID, page.Alarms[0].RuleID, fmt.Sprintf("expected rule %s got %s", saved.ID, page.Alarms[0].RuleID))
+}
+
+func TestChangeStatus(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc := newService(pub)
+	_, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.Consume(message(chanID, `[{"n":"temp","v":45}]`))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	page, err := svc.ListAlarms(context.Background(), token, alarms.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Alarms, 1, "alarm must be raised")
+	id := page.Alarms[0].ID
+	pub.Messages()
+
+	ack := func(token, id, comment string) (alarms.Alarm, error) {
+		return svc.AcknowledgeAlarm(context.Background(), token, id, comment)
+	}
+	clr := func(token, id, comment string) (alarms.Alarm, error) {
+		return svc.ClearAlarm(context.Background(), token, id, comment)
+	}
+
+	cases := []struct {
+		desc   string
+		change func(token, id, comment string) (alarms.Alarm, error)
+		token  string
+		id     string
+		status string
+		err    error
+	}{
+		{
+			desc:   "acknowledge alarm with invalid token",
+			change: ack,
+			token:  wrongToken,
+			id:     id,
+			err:    alarms.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "acknowledge alarm of another user",
+			change: ack,
+			token:  otherToken,
+			id:     id,
+			err:    alarms.ErrNotFound,
+		},
+		{
+			desc:   "acknowledge non-existing alarm",
+			change: ack,
+			token:  token,
+			id:     "non-existing",
+			err:    alarms.ErrNotFound,
+		},
+		{
+			desc:   "acknowledge alarm",
+			change: ack,
+			token:  token,
+			id:     id,
+			status: alarms.AcknowledgedStatus,
+			err:    nil,
+		},
+		{
+			desc:   "acknowledge acknowledged alarm",
+			change: ack,
+			token:  token,
+			id:     id,
+			err:    alarms.ErrStatusTransition,
+		},
+		{
+			desc:   "clear alarm",
+			change: clr,
+			token:  token,
+			id:     id,
+			status: alarms.ClearedStatus,
+			err:    nil,
+		},
+		{
+			desc:   "clear cleared alarm",
+			change: clr,
+			token:  token,
+			id:     id,
+			err:    alarms.ErrStatusTransition,
+		},
+		{
+			desc:   "acknowledge cleared alarm",
+			change: ack,
+			token:  token,
+			id:     id,
+			err:    alarms.ErrStatusTransition,
+		},
+	}
+
+	for _, tc := range cases {
+		alarm, err := tc.change(tc.token, tc.id, tc.desc)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		msgs := pub.Messages()
+		if err != nil {
+			assert.Empty(t, msgs, fmt.Sprintf("%s: expected no events got %d\n", tc.desc, len(msgs)))
+			continue
+		}
+		assert.Equal(t, tc.status, alarm.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, alarm.Status))
+		assert.Len(t, msgs, 1, fmt.Sprintf("%s: expected one event got %d\n", tc.desc, len(msgs)))
+	}
+
+	alarm, err := svc.ViewAlarm(context.Background(), token, id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var history []string
+	for _, t := range alarm.History {
+		history = append(history, t.Status)
+	}
+	expected := []string{alarms.RaisedStatus, alarms.AcknowledgedStatus, alarms.ClearedStatus}
+	assert.Equal(t, expected, history, fmt.Sprintf("expected history %v got %v", expected, history))
+	assert.Equal(t, email, alarm.History[1].Actor, fmt.Sprintf("expected actor %s got %s", email, alarm.History[1].Actor))
+	assert.Equal(t, "acknowledge alarm", alarm.History[1].Comment, fmt.Sprintf("expected comment got %s", alarm.History[1].Comment))
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/consumers/alarms"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveAlarmOp           = "save_alarm"
+	retrieveAlarmOp       = "retrieve_alarm"
+	retrieveActiveAlarmOp = "retrieve_active_alarm"
+	retrieveAllAlarmsOp   = "retrieve_all_alarms"
+	updateAlarmStatusOp   = "update_alarm_status"
+)
+
+var _ alarms.AlarmRepository = (*alarmRepositoryMiddleware)(nil)
+
+type alarmRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   alarms.AlarmRepository
+}
+
+// AlarmRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func AlarmRepositoryMiddleware(tracer opentracing.Tracer, repo alarms.AlarmRepository) alarms.AlarmRepository {
+	return alarmRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (arm alarmRepositoryMiddleware) Save(ctx context.Context, alarm alarms.Alarm) (string, error) {
+	span := createSpan(ctx, arm.tracer, saveAlarmOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return arm.repo.Save(ctx, alarm)
+}
+
+func (arm alarmRepositoryMiddleware) Retrieve(ctx context.Context, owner, id string) (alarms.Alarm, error) {
+	span := createSpan(ctx, arm.tracer, retrieveAlarmOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return arm.repo.Retrieve(ctx, owner, id)
+}
+
+func (arm alarmRepositoryMiddleware) RetrieveActive(ctx context.Context, ruleID string) (alarms.Alarm, error) {
+	span := createSpan(ctx, arm.tracer, retrieveActiveAlarmOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return arm.repo.RetrieveActive(ctx, ruleID)
+}
+
+func (arm alarmRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	span := createSpan(ctx, arm.tracer, retrieveAllAlarmsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return arm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func (arm alarmRepositoryMiddleware) UpdateStatus(ctx context.Context, id string, t alarms.Transition) error {
+	span := createSpan(ctx, arm.tracer, updateAlarmStatusOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return arm.repo.UpdateStatus(ctx, id, t)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// DeadLetter represents a message which couldn't be consumed.
+type DeadLetter struct {
+	Consumer string            `json:"consumer"`
+	Error    string            `json:"error"`
+	Retries  int               `json:"retries"`
+	Created  time.Time         `json:"created"`
+	Message  messaging.Message `json:"message"`
+}
+
+// DeadLetterPublisher specifies an API for publishing dead letters.
+type DeadLetterPublisher interface {
+	// Publish publishes the dead letter to the dead letter queue.
+	Publish(dl DeadLetter) error
+}
+
+// DeadLetterConfig configures handling of the messages which couldn't
+// be consumed. Dead-lettering is disabled if Publisher is nil.
+type DeadLetterConfig struct {
+	// Publisher publishes the messages which couldn't be consumed.
+	Publisher DeadLetterPublisher
+	// Consumer is the name of the consumer set in the dead letters.
+	Consumer string
+	// Retries is the number of times consuming is immediately retried
+	// before the message is dead-lettered.
+	Retries int
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+)
+
+func listEndpoint(svc deadletter.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDeadLetters(ctx, req.token, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return pageRes{
+			Total:       page.Total,
+			Offset:      page.Offset,
+			Limit:       page.Limit,
+			DeadLetters: page.Letters,
+		}, nil
+	}
+}
+
+func viewEndpoint(svc deadletter.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		l, err := svc.ViewDeadLetter(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return viewRes{l}, nil
+	}
+}
+
+func reinjectEndpoint(svc deadletter.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Reinject(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return reinjectRes{}, nil
+	}
+}
+
+func removeEndpoint(svc deadletter.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveDeadLetter(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
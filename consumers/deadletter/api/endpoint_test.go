@@ -0,0 +1,217 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/consumers/deadletter/api"
+	"github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	numItems   = 5
+	token      = "token"
+	email      = "user@example.com"
+	wrongValue = "wrong-value"
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type pageRes struct {
+	Total       uint64              `json:"total"`
+	Offset      uint64              `json:"offset"`
+	Limit       uint64              `json:"limit"`
+	DeadLetters []deadletter.Letter `json:"dead_letters"`
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+	repo := mocks.NewRepository()
+	for i := 0; i < numItems; i++ {
+		err := repo.Publish(consumers.DeadLetter{Message: messaging.Message{Channel: "1", Payload: []byte("payload")}})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(map[string]string{"1": email})
+	svc := deadletter.New(auth, things, repo, memory.NewBroker().NewPubSub("", logger))
+	return httptest.NewServer(api.MakeHandler(svc, bone.New()))
+}
+
+func TestList(t *testing.T) {
+	ts := newServer(t)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list dead letters",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   numItems,
+		},
+		{
+			desc:   "list dead letters without token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters with invalid token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters with offset and limit",
+			url:    fmt.Sprintf("%s/deadletters?offset=1&limit=2", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list dead letters with zero limit",
+			url:    fmt.Sprintf("%s/deadletters?limit=0", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid offset",
+			url:    fmt.Sprintf("%s/deadletters?offset=invalid", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{client: ts.Client(), method: http.MethodGet, url: tc.url, token: tc.token}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.DeadLetters)))
+	}
+}
+
+func TestManage(t *testing.T) {
+	ts := newServer(t)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		method string
+		url    string
+		token  string
+		status int
+	}{
+		{
+			desc:   "view dead letter",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/1", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "view dead letter without token",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/1", ts.URL),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "reinject dead letter with invalid token",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/1/reinject", ts.URL),
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view non-existing dead letter",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/100", ts.URL),
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view dead letter with invalid id",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/deadletters/invalid", ts.URL),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "reinject dead letter",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/1/reinject", ts.URL),
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "reinject reinjected dead letter",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/1/reinject", ts.URL),
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "remove dead letter",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/deadletters/2", ts.URL),
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed dead letter",
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/deadletters/2", ts.URL),
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{client: ts.Client(), method: tc.method, url: tc.url, token: tc.token}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	log "github.com/mainflux/mainflux/logger"
+)
+
+var _ deadletter.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    deadletter.Service
+}
+
+// LoggingMiddleware adds logging facilities to the dead letter service.
+func LoggingMiddleware(svc deadletter.Service, logger log.Logger) deadletter.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) ListDeadLetters(ctx context.Context, token string, offset, limit uint64) (_ deadletter.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_dead_letters took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeadLetters(ctx, token, offset, limit)
+}
+
+func (lm *loggingMiddleware) ViewDeadLetter(ctx context.Context, token string, id uint64) (_ deadletter.Letter, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_dead_letter for id %d took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewDeadLetter(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Reinject(ctx context.Context, token string, id uint64) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method reinject for id %d took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Reinject(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) RemoveDeadLetter(ctx context.Context, token string, id uint64) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_dead_letter for id %d took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveDeadLetter(ctx, token, id)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// +build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+)
+
+var _ deadletter.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     deadletter.Service
+}
+
+// MetricsMiddleware instruments dead letter service by tracking request count and latency.
+func MetricsMiddleware(svc deadletter.Service, counter metrics.Counter, latency metrics.Histogram) deadletter.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) ListDeadLetters(ctx context.Context, token string, offset, limit uint64) (deadletter.Page, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_dead_letters").Add(1)
+		mm.latency.With("method", "list_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListDeadLetters(ctx, token, offset, limit)
+}
+
+func (mm *metricsMiddleware) ViewDeadLetter(ctx context.Context, token string, id uint64) (deadletter.Letter, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_dead_letter").Add(1)
+		mm.latency.With("method", "view_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewDeadLetter(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) Reinject(ctx context.Context, token string, id uint64) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "reinject").Add(1)
+		mm.latency.With("method", "reinject").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Reinject(ctx, token, id)
+}
+
+func (mm *metricsMiddleware) RemoveDeadLetter(ctx context.Context, token string, id uint64) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_dead_letter").Add(1)
+		mm.latency.With("method", "remove_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveDeadLetter(ctx, token, id)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/mainflux/mainflux/consumers/deadletter"
+
+const maxLimitSize = 100
+
+type listReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return deadletter.ErrUnauthorizedAccess
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return deadletter.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    uint64
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return deadletter.ErrUnauthorizedAccess
+	}
+
+	if req.id == 0 {
+		return deadletter.ErrMalformedEntity
+	}
+
+	return nil
+}
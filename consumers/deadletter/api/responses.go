@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+)
+
+var (
+	_ mainflux.Response = (*pageRes)(nil)
+	_ mainflux.Response = (*viewRes)(nil)
+	_ mainflux.Response = (*reinjectRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type pageRes struct {
+	Total       uint64              `json:"total"`
+	Offset      uint64              `json:"offset"`
+	Limit       uint64              `json:"limit"`
+	DeadLetters []deadletter.Letter `json:"dead_letters"`
+}
+
+func (res pageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res pageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res pageRes) Empty() bool {
+	return false
+}
+
+type viewRes struct {
+	deadletter.Letter
+}
+
+func (res viewRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewRes) Empty() bool {
+	return false
+}
+
+type reinjectRes struct{}
+
+func (res reinjectRes) Code() int {
+	return http.StatusOK
+}
+
+func (res reinjectRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res reinjectRes) Empty() bool {
+	return true
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletter
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var (
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrNotFound indicates a non-existent dead letter request.
+	ErrNotFound = errors.New("non-existent dead letter")
+
+	// ErrReinject indicates failure to publish the message to its channel.
+	ErrReinject = errors.New("failed to re-inject message")
+)
+
+// Letter represents a stored dead letter identified by its position in the queue.
+type Letter struct {
+	ID uint64 `json:"id"`
+	consumers.DeadLetter
+}
+
+// Page contains page related metadata as well as a list of dead letters that
+// belong to this page.
+type Page struct {
+	Total   uint64
+	Offset  uint64
+	Limit   uint64
+	Letters []Letter
+}
+
+// Repository specifies a dead letter persistence API.
+type Repository interface {
+	// RetrieveAll retrieves the subset of dead letters, oldest first.
+	RetrieveAll(ctx context.Context, offset, limit uint64) (Page, error)
+
+	// RetrieveByID retrieves the dead letter having the provided identifier.
+	RetrieveByID(ctx context.Context, id uint64) (Letter, error)
+
+	// Remove removes the dead letter having the provided identifier.
+	Remove(ctx context.Context, id uint64) error
+}
+
+// Service specifies an API for managing dead letters. Users are given
+// access only to the dead letters of the messages sent to their channels.
+type Service interface {
+	// ListDeadLetters retrieves the subset of dead letters of the channels
+	// owned by the user identified by the provided key, oldest first.
+	ListDeadLetters(ctx context.Context, token string, offset, limit uint64) (Page, error)
+
+	// ViewDeadLetter retrieves the dead letter having the provided identifier.
+	ViewDeadLetter(ctx context.Context, token string, id uint64) (Letter, error)
+
+	// Reinject publishes the dead letter message to its original channel
+	// and removes the dead letter.
+	Reinject(ctx context.Context, token string, id uint64) error
+
+	// RemoveDeadLetter discards the dead letter having the provided identifier.
+	RemoveDeadLetter(ctx context.Context, token string, id uint64) error
+}
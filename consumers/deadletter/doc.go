@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deadletter contains the domain concept definitions needed to
+// support inspecting and re-injecting the messages which consumers failed
+// to consume.
+package deadletter
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jetstream contains the dead letter queue implementation
+// which persists dead letters in a NATS JetStream stream.
+package jetstream
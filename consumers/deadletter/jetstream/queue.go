@@ -0,0 +1,164 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/pkg/errors"
+	broker "github.com/nats-io/nats.go"
+)
+
+// JetStream doesn't expose typed errors for the missing stream messages,
+// so the error description sent by the server is matched instead.
+const msgNotFound = "no message found"
+
+var (
+	errInvalidSubject = errors.New("dead letter subject must not contain wildcards")
+	errMarshal        = errors.New("failed to marshal dead letter")
+	errUnmarshal      = errors.New("failed to unmarshal dead letter")
+)
+
+var streamNameReplacer = strings.NewReplacer(".", "_")
+
+var (
+	_ deadletter.Repository         = (*Queue)(nil)
+	_ consumers.DeadLetterPublisher = (*Queue)(nil)
+)
+
+// Queue publishes dead letters to the subject and persists them in the
+// stream dedicated to the subject. Dead letter IDs are stream sequences.
+type Queue struct {
+	conn    *broker.Conn
+	js      broker.JetStreamContext
+	subject string
+	stream  string
+}
+
+// New connects to the NATS server and returns dead letter queue backed
+// by the stream which is created if it does not exist.
+func New(url, subject string) (*Queue, error) {
+	if subject == "" || strings.ContainsAny(subject, "*>") {
+		return nil, errInvalidSubject
+	}
+
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	stream := streamNameReplacer.Replace(subject)
+	if _, err := js.StreamInfo(stream); err != nil {
+		sc := &broker.StreamConfig{
+			Name:     stream,
+			Subjects: []string{subject},
+			Storage:  broker.FileStorage,
+		}
+		if _, err := js.AddStream(sc); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return &Queue{
+		conn:    conn,
+		js:      js,
+		subject: subject,
+		stream:  stream,
+	}, nil
+}
+
+// Publish persists the dead letter in the queue.
+func (q *Queue) Publish(dl consumers.DeadLetter) error {
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return errors.Wrap(errMarshal, err)
+	}
+
+	_, err = q.js.Publish(q.subject, data)
+	return err
+}
+
+// RetrieveAll retrieves the subset of dead letters, oldest first.
+func (q *Queue) RetrieveAll(_ context.Context, offset, limit uint64) (deadletter.Page, error) {
+	info, err := q.js.StreamInfo(q.stream)
+	if err != nil {
+		return deadletter.Page{}, err
+	}
+
+	page := deadletter.Page{
+		Total:   info.State.Msgs,
+		Offset:  offset,
+		Limit:   limit,
+		Letters: []deadletter.Letter{},
+	}
+	if info.State.Msgs == 0 {
+		return page, nil
+	}
+
+	// Removed dead letters leave gaps in the sequence,
+	// so the stream is walked from the first sequence.
+	var idx uint64
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && idx < offset+limit; seq++ {
+		l, err := q.retrieve(seq)
+		if errors.Contains(err, deadletter.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return deadletter.Page{}, err
+		}
+		if idx >= offset {
+			page.Letters = append(page.Letters, l)
+		}
+		idx++
+	}
+
+	return page, nil
+}
+
+// RetrieveByID retrieves the dead letter having the provided identifier.
+func (q *Queue) RetrieveByID(_ context.Context, id uint64) (deadletter.Letter, error) {
+	return q.retrieve(id)
+}
+
+// Remove removes the dead letter having the provided identifier.
+func (q *Queue) Remove(_ context.Context, id uint64) error {
+	if err := q.js.DeleteMsg(q.stream, id); err != nil {
+		if strings.Contains(err.Error(), msgNotFound) {
+			return deadletter.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Close closes the NATS connection.
+func (q *Queue) Close() {
+	q.conn.Close()
+}
+
+func (q *Queue) retrieve(seq uint64) (deadletter.Letter, error) {
+	msg, err := q.js.GetMsg(q.stream, seq)
+	if err != nil {
+		if strings.Contains(err.Error(), msgNotFound) {
+			return deadletter.Letter{}, deadletter.ErrNotFound
+		}
+		return deadletter.Letter{}, err
+	}
+
+	l := deadletter.Letter{ID: msg.Sequence}
+	if err := json.Unmarshal(msg.Data, &l.DeadLetter); err != nil {
+		return deadletter.Letter{}, errors.Wrap(errUnmarshal, err)
+	}
+	return l, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+)
+
+var (
+	_ deadletter.Repository         = (*repositoryMock)(nil)
+	_ consumers.DeadLetterPublisher = (*repositoryMock)(nil)
+)
+
+type repositoryMock struct {
+	mu      sync.Mutex
+	counter uint64
+	letters map[uint64]deadletter.Letter
+}
+
+// NewRepository returns mock dead letter repository which is
+// also used as a dead letter publisher.
+func NewRepository() interface {
+	deadletter.Repository
+	consumers.DeadLetterPublisher
+} {
+	return &repositoryMock{
+		letters: make(map[uint64]deadletter.Letter),
+	}
+}
+
+func (repo *repositoryMock) Publish(dl consumers.DeadLetter) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.counter++
+	if dl.Created.IsZero() {
+		dl.Created = time.Now()
+	}
+	repo.letters[repo.counter] = deadletter.Letter{ID: repo.counter, DeadLetter: dl}
+	return nil
+}
+
+func (repo *repositoryMock) RetrieveAll(_ context.Context, offset, limit uint64) (deadletter.Page, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var ids []uint64
+	for id := range repo.letters {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	page := deadletter.Page{
+		Total:   uint64(len(ids)),
+		Offset:  offset,
+		Limit:   limit,
+		Letters: []deadletter.Letter{},
+	}
+	for i := offset; i < uint64(len(ids)) && i < offset+limit; i++ {
+		page.Letters = append(page.Letters, repo.letters[ids[i]])
+	}
+	return page, nil
+}
+
+func (repo *repositoryMock) RetrieveByID(_ context.Context, id uint64) (deadletter.Letter, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	l, ok := repo.letters[id]
+	if !ok {
+		return deadletter.Letter{}, deadletter.ErrNotFound
+	}
+	return l, nil
+}
+
+func (repo *repositoryMock) Remove(_ context.Context, id uint64) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.letters[id]; !ok {
+		return deadletter.ErrNotFound
+	}
+	delete(repo.letters, id)
+	return nil
+}
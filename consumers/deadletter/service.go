@@ -0,0 +1,128 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletter
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// scanLimit is the number of dead letters retrieved at once while
+// looking for the dead letters of the user's channels.
+const scanLimit = 100
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	auth      mainflux.AuthServiceClient
+	things    mainflux.ThingsServiceClient
+	repo      Repository
+	publisher messaging.Publisher
+}
+
+// New instantiates the dead letter service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, repo Repository, publisher messaging.Publisher) Service {
+	return &service{
+		auth:      auth,
+		things:    things,
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (svc service) ListDeadLetters(ctx context.Context, token string, offset, limit uint64) (Page, error) {
+	owner, err := svc.identify(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{
+		Offset:  offset,
+		Limit:   limit,
+		Letters: []Letter{},
+	}
+	owned := make(map[string]bool)
+	for off := uint64(0); ; off += scanLimit {
+		p, err := svc.repo.RetrieveAll(ctx, off, scanLimit)
+		if err != nil {
+			return Page{}, err
+		}
+		for _, l := range p.Letters {
+			ch := l.Message.Channel
+			if _, ok := owned[ch]; !ok {
+				owned[ch] = svc.isOwner(ctx, owner, ch) == nil
+			}
+			if !owned[ch] {
+				continue
+			}
+			if page.Total >= offset && page.Total < offset+limit {
+				page.Letters = append(page.Letters, l)
+			}
+			page.Total++
+		}
+		if len(p.Letters) == 0 || off+scanLimit >= p.Total {
+			return page, nil
+		}
+	}
+}
+
+func (svc service) ViewDeadLetter(ctx context.Context, token string, id uint64) (Letter, error) {
+	return svc.retrieve(ctx, token, id)
+}
+
+func (svc service) Reinject(ctx context.Context, token string, id uint64) error {
+	l, err := svc.retrieve(ctx, token, id)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.publisher.Publish(l.Message.Channel, l.Message); err != nil {
+		return errors.Wrap(ErrReinject, err)
+	}
+
+	return svc.repo.Remove(ctx, id)
+}
+
+func (svc service) RemoveDeadLetter(ctx context.Context, token string, id uint64) error {
+	if _, err := svc.retrieve(ctx, token, id); err != nil {
+		return err
+	}
+
+	return svc.repo.Remove(ctx, id)
+}
+
+// retrieve retrieves the dead letter of the channel owned by the user.
+// Dead letters of other users' channels are reported as non-existent.
+func (svc service) retrieve(ctx context.Context, token string, id uint64) (Letter, error) {
+	owner, err := svc.identify(ctx, token)
+	if err != nil {
+		return Letter{}, err
+	}
+
+	l, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Letter{}, err
+	}
+
+	if err := svc.isOwner(ctx, owner, l.Message.Channel); err != nil {
+		return Letter{}, errors.Wrap(ErrNotFound, err)
+	}
+	return l, nil
+}
+
+func (svc service) identify(ctx context.Context, token string) (string, error) {
+	res, err := svc.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return res.GetEmail(), nil
+}
+
+func (svc service) isOwner(ctx context.Context, owner, chanID string) error {
+	_, err := svc.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: chanID})
+	return err
+}
@@ -0,0 +1,249 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package deadletter_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	"github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID     = "1"
+	otherChan  = "2"
+	token      = "token"
+	email      = "user@example.com"
+	otherEmail = "other@example.com"
+	wrongValue = "wrong-value"
+	numItems   = 10
+	wrongID    = 1000
+)
+
+// otherID is the ID of the dead letter of the channel owned by other user.
+const otherID = numItems + 1
+
+func newService(t *testing.T) (deadletter.Service, memory.PubSub) {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+	ps := memory.NewBroker().NewPubSub("", logger)
+	repo := mocks.NewRepository()
+	for i := 0; i < numItems; i++ {
+		dl := consumers.DeadLetter{
+			Consumer: "writer",
+			Error:    "failed to consume",
+			Message:  messaging.Message{Channel: chanID, Payload: []byte(fmt.Sprintf("%d", i))},
+		}
+		err := repo.Publish(dl)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	err := repo.Publish(consumers.DeadLetter{Message: messaging.Message{Channel: otherChan, Payload: []byte("other")}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(map[string]string{chanID: email, otherChan: otherEmail})
+	return deadletter.New(auth, things, repo, ps), ps
+}
+
+func TestListDeadLetters(t *testing.T) {
+	svc, _ := newService(t)
+
+	cases := []struct {
+		desc   string
+		offset uint64
+		limit  uint64
+		size   int
+	}{
+		{
+			desc:   "list all dead letters",
+			offset: 0,
+			limit:  numItems,
+			size:   numItems,
+		},
+		{
+			desc:   "list half of dead letters",
+			offset: numItems / 2,
+			limit:  numItems,
+			size:   numItems / 2,
+		},
+		{
+			desc:   "list dead letters with offset out of range",
+			offset: numItems,
+			limit:  numItems,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListDeadLetters(context.Background(), token, tc.offset, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Letters), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.Letters)))
+		assert.Equal(t, uint64(numItems), page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, numItems, page.Total))
+	}
+}
+
+func TestListDeadLettersUnauthorized(t *testing.T) {
+	svc, _ := newService(t)
+
+	_, err := svc.ListDeadLetters(context.Background(), wrongValue, 0, numItems)
+	assert.True(t, errors.Contains(err, deadletter.ErrUnauthorizedAccess), fmt.Sprintf("expected %s got %s", deadletter.ErrUnauthorizedAccess, err))
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	svc, _ := newService(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    uint64
+		err   error
+	}{
+		{
+			desc:  "view existing dead letter",
+			token: token,
+			id:    1,
+			err:   nil,
+		},
+		{
+			desc:  "view dead letter of other user's channel",
+			token: token,
+			id:    otherID,
+			err:   deadletter.ErrNotFound,
+		},
+		{
+			desc:  "view dead letter with invalid token",
+			token: wrongValue,
+			id:    1,
+			err:   deadletter.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "view non-existing dead letter",
+			token: token,
+			id:    wrongID,
+			err:   deadletter.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		l, err := svc.ViewDeadLetter(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.id, l.ID, fmt.Sprintf("%s: expected id %d got %d", tc.desc, tc.id, l.ID))
+		}
+	}
+}
+
+func TestReinject(t *testing.T) {
+	svc, ps := newService(t)
+
+	msgs := make(chan messaging.Message, 1)
+	err := ps.Subscribe(memory.SubjectAllChannels, func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    uint64
+		err   error
+	}{
+		{
+			desc:  "reinject dead letter of other user's channel",
+			token: token,
+			id:    otherID,
+			err:   deadletter.ErrNotFound,
+		},
+		{
+			desc:  "reinject dead letter with invalid token",
+			token: wrongValue,
+			id:    1,
+			err:   deadletter.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "reinject existing dead letter",
+			token: token,
+			id:    1,
+			err:   nil,
+		},
+		{
+			desc:  "reinject already reinjected dead letter",
+			token: token,
+			id:    1,
+			err:   deadletter.ErrNotFound,
+		},
+		{
+			desc:  "reinject non-existing dead letter",
+			token: token,
+			id:    wrongID,
+			err:   deadletter.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Reinject(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		select {
+		case msg := <-msgs:
+			assert.Equal(t, "0", string(msg.Payload), fmt.Sprintf("%s: expected payload 0 got %s", tc.desc, msg.Payload))
+		case <-time.After(time.Second):
+			assert.Fail(t, fmt.Sprintf("%s: message not reinjected", tc.desc))
+		}
+	}
+}
+
+func TestRemoveDeadLetter(t *testing.T) {
+	svc, _ := newService(t)
+
+	cases := []struct {
+		desc  string
+		token string
+		id    uint64
+		err   error
+	}{
+		{
+			desc:  "remove dead letter of other user's channel",
+			token: token,
+			id:    otherID,
+			err:   deadletter.ErrNotFound,
+		},
+		{
+			desc:  "remove dead letter with invalid token",
+			token: wrongValue,
+			id:    1,
+			err:   deadletter.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove existing dead letter",
+			token: token,
+			id:    1,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed dead letter",
+			token: token,
+			id:    1,
+			err:   deadletter.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveDeadLetter(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
@@ -6,6 +6,7 @@ package consumers
 import (
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/pelletier/go-toml"
 
@@ -19,37 +20,72 @@ import (
 var (
 	errOpenConfFile  = errors.New("unable to open configuration file")
 	errParseConfFile = errors.New("unable to parse configuration file")
+	errDeadLetter    = errors.New("failed to publish dead letter")
 )
 
 // Start method starts consuming messages received from NATS.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(sub messaging.Subscriber, consumer Consumer, transformer transformers.Transformer, subjectsCfgPath string, logger logger.Logger) error {
+// using MessageRepository to store them. Messages which can't
+// be transformed or consumed are published as dead letters
+// if dead-lettering is configured.
+func Start(sub messaging.Subscriber, consumer Consumer, transformer transformers.Transformer, subjectsCfgPath string, dl DeadLetterConfig, logger logger.Logger) error {
 	subjects, err := loadSubjectsConfig(subjectsCfgPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load subjects: %s", err))
 	}
 
 	for _, subject := range subjects {
-		if err := sub.Subscribe(subject, handler(transformer, consumer)); err != nil {
+		if err := sub.Subscribe(subject, handler(transformer, consumer, dl)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func handler(t transformers.Transformer, c Consumer) messaging.MessageHandler {
+func handler(t transformers.Transformer, c Consumer, dl DeadLetterConfig) messaging.MessageHandler {
 	return func(msg messaging.Message) error {
 		m := interface{}(msg)
 		var err error
 		if t != nil {
 			m, err = t.Transform(msg)
 			if err != nil {
-				return err
+				// Transformation is deterministic, so there is no point in retrying it.
+				return PublishDeadLetter(dl, msg, err, 0)
 			}
 		}
-		return c.Consume(m)
+
+		if err = c.Consume(m); err == nil || dl.Publisher == nil {
+			return err
+		}
+		// Retries are immediate, since waiting would block the delivery of
+		// the following messages. Delayed redelivery is left to the broker.
+		for i := 1; i <= dl.Retries; i++ {
+			if err = c.Consume(m); err == nil {
+				return nil
+			}
+		}
+		return PublishDeadLetter(dl, msg, err, dl.Retries)
+	}
+}
+
+// PublishDeadLetter publishes the message which couldn't be consumed. The
+// original error is returned if dead-lettering is disabled or fails.
+func PublishDeadLetter(dl DeadLetterConfig, msg messaging.Message, err error, retries int) error {
+	if dl.Publisher == nil {
+		return err
+	}
+
+	letter := DeadLetter{
+		Consumer: dl.Consumer,
+		Error:    err.Error(),
+		Retries:  retries,
+		Created:  time.Now(),
+		Message:  msg,
+	}
+	if pubErr := dl.Publisher.Publish(letter); pubErr != nil {
+		return errors.Wrap(err, errors.Wrap(errDeadLetter, pubErr))
 	}
+	return nil
 }
 
 type filterConfig struct {
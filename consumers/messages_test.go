@@ -0,0 +1,138 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	svcName  = "test-writer"
+	chanID   = "1"
+	retries  = 2
+	payload  = `[{"bn":"base-name","n":"temperature","v":23}]`
+	deadline = 3 * time.Second
+)
+
+var errConsume = errors.New("failed to consume")
+
+type consumer struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	done     chan struct{}
+}
+
+func (c *consumer) Consume(msgs interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	if c.calls <= c.failures {
+		return errConsume
+	}
+	c.done <- struct{}{}
+	return nil
+}
+
+func TestDeadLetter(t *testing.T) {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+
+	cases := []struct {
+		desc     string
+		payload  string
+		failures int
+		letter   bool
+		calls    int
+		retries  int
+	}{
+		{
+			desc:     "consume message",
+			payload:  payload,
+			failures: 0,
+			calls:    1,
+		},
+		{
+			desc:     "consume message after retry",
+			payload:  payload,
+			failures: retries,
+			calls:    retries + 1,
+		},
+		{
+			desc:     "dead-letter message failed to consume",
+			payload:  payload,
+			failures: retries + 1,
+			letter:   true,
+			calls:    retries + 1,
+			retries:  retries,
+		},
+		{
+			desc:    "dead-letter message failed to transform",
+			payload: "invalid",
+			letter:  true,
+			calls:   0,
+			retries: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		ps := memory.NewBroker().NewPubSub("", logger)
+		dlq := mocks.NewRepository()
+		c := &consumer{failures: tc.failures, done: make(chan struct{}, 1)}
+		dl := consumers.DeadLetterConfig{
+			Publisher: dlq,
+			Consumer:  svcName,
+			Retries:   retries,
+		}
+		err := consumers.Start(ps, c, senml.New(senml.JSON), "", dl, logger)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		msg := messaging.Message{Channel: chanID, Payload: []byte(tc.payload), Created: time.Now().UnixNano()}
+		err = ps.Publish(chanID, msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		if !tc.letter {
+			select {
+			case <-c.done:
+			case <-time.After(deadline):
+				assert.Fail(t, fmt.Sprintf("%s: message not consumed", tc.desc))
+			}
+		}
+
+		require.Eventually(t, func() bool {
+			page, err := dlq.RetrieveAll(context.Background(), 0, 10)
+			return err == nil && (page.Total > 0) == tc.letter
+		}, deadline, 10*time.Millisecond, fmt.Sprintf("%s: unexpected dead letters", tc.desc))
+		ps.Close()
+
+		c.mu.Lock()
+		assert.Equal(t, tc.calls, c.calls, fmt.Sprintf("%s: expected %d consume calls got %d", tc.desc, tc.calls, c.calls))
+		c.mu.Unlock()
+
+		if !tc.letter {
+			continue
+		}
+		l, err := dlq.RetrieveByID(context.Background(), 1)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, svcName, l.Consumer, fmt.Sprintf("%s: expected consumer %s got %s", tc.desc, svcName, l.Consumer))
+		assert.Equal(t, tc.retries, l.Retries, fmt.Sprintf("%s: expected %d retries got %d", tc.desc, tc.retries, l.Retries))
+		assert.Equal(t, msg, l.Message, fmt.Sprintf("%s: expected message %v got %v", tc.desc, msg, l.Message))
+		assert.NotEmpty(t, l.Error, fmt.Sprintf("%s: expected error to be set", tc.desc))
+	}
+}
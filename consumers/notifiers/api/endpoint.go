@@ -16,9 +16,17 @@ func createSubscriptionEndpoint(svc notifiers.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return createSubRes{}, err
 		}
+		cooldown, err := req.cooldown()
+		if err != nil {
+			return createSubRes{}, err
+		}
 		sub := notifiers.Subscription{
-			Contact: req.Contact,
-			Topic:   req.Topic,
+			Contact:    req.Contact,
+			Topic:      req.Topic,
+			Condition:  req.Condition,
+			Cooldown:   cooldown,
+			RisingEdge: req.RisingEdge,
+			Secret:     req.Secret,
 		}
 		id, err := svc.CreateSubscription(ctx, req.token, sub)
 		if err != nil {
@@ -42,13 +50,7 @@ func viewSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 		if err != nil {
 			return viewSubRes{}, err
 		}
-		res := viewSubRes{
-			ID:      sub.ID,
-			OwnerID: sub.OwnerID,
-			Contact: sub.Contact,
-			Topic:   sub.Topic,
-		}
-		return res, nil
+		return toViewSubRes(sub), nil
 	}
 }
 
@@ -74,13 +76,7 @@ func listSubscriptionsEndpoint(svc notifiers.Service) endpoint.Endpoint {
 			Total:  page.Total,
 		}
 		for _, sub := range page.Subscriptions {
-			r := viewSubRes{
-				ID:      sub.ID,
-				OwnerID: sub.OwnerID,
-				Contact: sub.Contact,
-				Topic:   sub.Topic,
-			}
-			res.Subscriptions = append(res.Subscriptions, r)
+			res.Subscriptions = append(res.Subscriptions, toViewSubRes(sub))
 		}
 		return res, nil
 	}
@@ -98,3 +94,26 @@ func deleteSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 		return removeSubRes{}, nil
 	}
 }
+
+func toViewSubRes(sub notifiers.Subscription) viewSubRes {
+	res := viewSubRes{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Condition:  sub.Condition,
+		RisingEdge: sub.RisingEdge,
+	}
+	if sub.Cooldown > 0 {
+		res.Cooldown = sub.Cooldown.String()
+	}
+	if sub.Delivery.Status != "" {
+		res.Delivery = &deliveryRes{
+			Status:   sub.Delivery.Status,
+			Attempts: sub.Delivery.Attempts,
+			Error:    sub.Delivery.Error,
+			Time:     sub.Delivery.Time,
+		}
+	}
+	return res
+}
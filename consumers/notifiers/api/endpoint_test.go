@@ -13,6 +13,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	httpapi "github.com/mainflux/mainflux/consumers/notifiers/api"
@@ -65,10 +66,19 @@ func (tr testRequest) make() (*http.Response, error) {
 
 func newService(tokens map[string]string) notifiers.Service {
 	auth := mocks.NewAuth(tokens)
+	things := mocks.NewThingsService(map[string]string{
+		topic:         email,
+		"topic123":    email,
+		"conditional": email,
+		"invalid":     email,
+		"webhook":     email,
+		"channel":     email,
+		"other":       contact1,
+	})
 	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
 	idp := uuid.NewMock()
 	notif := mocks.NewNotifier()
-	return notifiers.New(auth, repo, idp, notif)
+	return notifiers.New(auth, things, repo, idp, notif, mocks.NewCipher())
 }
 
 func newServer(svc notifiers.Service) *httptest.Server {
@@ -86,15 +96,32 @@ func TestCreate(t *testing.T) {
 	ss := newServer(svc)
 	defer ss.Close()
 
-	sub := notifiers.Subscription{
+	sub := subReq{
 		Topic:   topic,
 		Contact: contact1,
 	}
 
 	data := toJSON(sub)
 
-	emptyTopic := toJSON(notifiers.Subscription{Contact: contact1})
-	emptyContact := toJSON(notifiers.Subscription{Topic: "topic123"})
+	emptyTopic := toJSON(subReq{Contact: contact1})
+	emptyContact := toJSON(subReq{Topic: "topic123"})
+	conditional := toJSON(subReq{
+		Topic:      "conditional",
+		Contact:    contact1,
+		Condition:  `name == "temp" && v > 40`,
+		Cooldown:   "5m",
+		RisingEdge: true,
+	})
+	invalidCondition := toJSON(subReq{Topic: "invalid", Contact: contact1, Condition: `name > 40`})
+	invalidCooldown := toJSON(subReq{Topic: "invalid", Contact: contact1, Cooldown: "5 minutes"})
+	negativeCooldown := toJSON(subReq{Topic: "invalid", Contact: contact1, Cooldown: "-5m"})
+	webhook := toJSON(subReq{Topic: "webhook", Contact: "https://example.com/hook", Secret: "secret"})
+	invalidScheme := toJSON(subReq{Topic: "invalid", Contact: "ftp://example.com/hook"})
+	missingHost := toJSON(subReq{Topic: "invalid", Contact: "https:///hook"})
+	wildcard := toJSON(subReq{Topic: "channel.*.>", Contact: contact1})
+	invalidWildcard := toJSON(subReq{Topic: "channel.>.temp", Contact: contact1})
+	wildcardChannel := toJSON(subReq{Topic: "*.temp", Contact: contact1})
+	otherChannel := toJSON(subReq{Topic: "other.temp", Contact: contact1})
 
 	cases := []struct {
 		desc        string
@@ -136,6 +163,94 @@ func TestCreate(t *testing.T) {
 			status:      http.StatusBadRequest,
 			location:    "",
 		},
+		{
+			desc:        "add with condition",
+			req:         conditional,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 3),
+		},
+		{
+			desc:        "add with invalid condition",
+			req:         invalidCondition,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with invalid cooldown",
+			req:         invalidCooldown,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with negative cooldown",
+			req:         negativeCooldown,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add webhook",
+			req:         webhook,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 4),
+		},
+		{
+			desc:        "add webhook with unsupported scheme",
+			req:         invalidScheme,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add webhook without host",
+			req:         missingHost,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with wildcard topic",
+			req:         wildcard,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 5),
+		},
+		{
+			desc:        "add with invalid wildcard topic",
+			req:         invalidWildcard,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with wildcard channel",
+			req:         wildcardChannel,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+		},
+		{
+			desc:        "add with channel owned by another user",
+			req:         otherChannel,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusUnauthorized,
+			location:    "",
+		},
 		{
 			desc:        "add with invalid auth token",
 			req:         data,
@@ -194,16 +309,22 @@ func TestView(t *testing.T) {
 	defer ss.Close()
 
 	sub := notifiers.Subscription{
-		Topic:   topic,
-		Contact: contact1,
+		Topic:      topic,
+		Contact:    contact1,
+		Condition:  `name == "temp" && v > 40`,
+		Cooldown:   5 * time.Minute,
+		RisingEdge: true,
 	}
 	id, err := svc.CreateSubscription(context.Background(), token, sub)
 	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
 	sr := subRes{
-		ID:      id,
-		OwnerID: email,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+		ID:         id,
+		OwnerID:    email,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Condition:  sub.Condition,
+		Cooldown:   "5m0s",
+		RisingEdge: true,
 	}
 	data := toJSON(sr)
 
@@ -462,11 +583,23 @@ type errorRes struct {
 	Err string `json:"error"`
 }
 
+type subReq struct {
+	Topic      string `json:"topic,omitempty"`
+	Contact    string `json:"contact,omitempty"`
+	Condition  string `json:"condition,omitempty"`
+	Cooldown   string `json:"cooldown,omitempty"`
+	RisingEdge bool   `json:"rising_edge,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+}
+
 type subRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID         string `json:"id"`
+	OwnerID    string `json:"owner_id"`
+	Contact    string `json:"contact"`
+	Topic      string `json:"topic"`
+	Condition  string `json:"condition,omitempty"`
+	Cooldown   string `json:"cooldown,omitempty"`
+	RisingEdge bool   `json:"rising_edge,omitempty"`
 }
 type page struct {
 	Offset        uint     `json:"offset"`
@@ -4,35 +4,75 @@
 package api
 
 import (
+	"net/url"
+	"time"
+
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/conditions"
 	"github.com/mainflux/mainflux/pkg/errors"
 )
 
 var (
-	errInvalidTopic   = errors.New("invalid Subscription topic")
-	errInvalidContact = errors.New("invalid Subscription contact")
-	errNotFound       = errors.New("invalid or empty Subscription id")
+	errInvalidTopic    = errors.New("invalid Subscription topic")
+	errInvalidContact  = errors.New("invalid Subscription contact")
+	errNotFound        = errors.New("invalid or empty Subscription id")
+	errInvalidCooldown = errors.New("invalid Subscription cooldown")
 )
 
 type createSubReq struct {
-	token   string
-	Topic   string `json:"topic,omitempty"`
-	Contact string `json:"contact,omitempty"`
+	token      string
+	Topic      string `json:"topic,omitempty"`
+	Contact    string `json:"contact,omitempty"`
+	Condition  string `json:"condition,omitempty"`
+	Cooldown   string `json:"cooldown,omitempty"`
+	RisingEdge bool   `json:"rising_edge,omitempty"`
+	Secret     string `json:"secret,omitempty"`
 }
 
 func (req createSubReq) validate() error {
 	if req.token == "" {
 		return notifiers.ErrUnauthorizedAccess
 	}
-	if req.Topic == "" {
+	// Wildcards are allowed only in subtopic, since the subscription
+	// is limited to the channel owned by the user.
+	if !conditions.ValidTopic(req.Topic) || conditions.Wildcard(notifiers.TopicChannel(req.Topic)) {
 		return errInvalidTopic
 	}
 	if req.Contact == "" {
 		return errInvalidContact
 	}
+	// Contacts given as URLs are notified using webhooks.
+	switch notifiers.ContactScheme(req.Contact) {
+	case "":
+	case "http", "https":
+		u, err := url.Parse(req.Contact)
+		if err != nil || u.Host == "" {
+			return errInvalidContact
+		}
+	default:
+		return errInvalidContact
+	}
+	if _, err := req.cooldown(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// cooldown parses the cooldown duration, e.g. "30s" or "15m".
+func (req createSubReq) cooldown() (time.Duration, error) {
+	if req.Cooldown == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(req.Cooldown)
+	if err != nil {
+		return 0, errors.Wrap(errInvalidCooldown, err)
+	}
+	if d < 0 {
+		return 0, errInvalidCooldown
+	}
+	return d, nil
+}
+
 type subReq struct {
 	token string
 	id    string
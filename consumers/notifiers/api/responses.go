@@ -6,6 +6,7 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/mainflux/mainflux"
 )
@@ -36,10 +37,21 @@ func (res createSubRes) Empty() bool {
 }
 
 type viewSubRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID         string       `json:"id"`
+	OwnerID    string       `json:"owner_id"`
+	Contact    string       `json:"contact"`
+	Topic      string       `json:"topic"`
+	Condition  string       `json:"condition,omitempty"`
+	Cooldown   string       `json:"cooldown,omitempty"`
+	RisingEdge bool         `json:"rising_edge,omitempty"`
+	Delivery   *deliveryRes `json:"last_delivery,omitempty"`
+}
+
+type deliveryRes struct {
+	Status   string    `json:"status"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error,omitempty"`
+	Time     time.Time `json:"time"`
 }
 
 func (res viewSubRes) Code() int {
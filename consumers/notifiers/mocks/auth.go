@@ -30,6 +30,14 @@ func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opt
 	return nil, users.ErrUnauthorizedAccess
 }
 
+func (svc authServiceMock) CanAccess(ctx context.Context, req *mainflux.AccessReq, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return svc.Identify(ctx, &mainflux.Token{Value: req.GetToken()})
+}
+
+func (svc authServiceMock) IdentifyPreAuth(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
 func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
@@ -40,6 +48,18 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 	return nil, users.ErrUnauthorizedAccess
 }
 
+func (svc authServiceMock) Login(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Logout(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
 func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
 	panic("not implemented")
 }
@@ -51,3 +71,23 @@ func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq
 func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (r *mainflux.ObjectsRes, err error) {
+	panic("not implemented")
+}
@@ -0,0 +1,33 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"bytes"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+var _ notifiers.Cipher = (*cipherMock)(nil)
+
+type cipherMock struct{}
+
+// NewCipher creates "no-op" cipher for test purposes. This implementation
+// prepends the additional data to the plaintext instead of encrypting it.
+func NewCipher() notifiers.Cipher {
+	return &cipherMock{}
+}
+
+func (cm *cipherMock) Encrypt(plaintext, data []byte) ([]byte, error) {
+	return append(append([]byte{}, data...), plaintext...), nil
+}
+
+func (cm *cipherMock) Decrypt(ciphertext, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, data) {
+		return nil, errors.ErrMalformedEntity
+	}
+
+	return ciphertext[len(data):], nil
+}
@@ -19,9 +19,9 @@ func NewNotifier() notifiers.Notifier {
 	return notifier{}
 }
 
-func (n notifier) Notify(from string, to []string, msg messaging.Message) error {
+func (n notifier) Notify(from string, to []notifiers.Subscription, msg messaging.Message) error {
 	for _, t := range to {
-		if t == invalidSender {
+		if t.Contact == invalidSender {
 			return notifiers.ErrNotify
 		}
 	}
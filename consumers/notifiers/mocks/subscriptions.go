@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/conditions"
 )
 
 var _ notifiers.SubscriptionsRepository = (*subRepoMock)(nil)
@@ -67,36 +68,21 @@ func (srm *subRepoMock) RetrieveAll(_ context.Context, pm notifiers.PageMetadata
 	offset := int(pm.Offset)
 	for _, k := range keys {
 		v := srm.subs[k]
-		if pm.Topic == "" {
-			if pm.Contact == "" {
-				if total < offset {
-					total++
-					continue
-				}
-				total++
-				subs = appendSubs(subs, v, pm.Limit)
-				continue
-			}
-			if pm.Contact == v.Contact {
-				if total < offset {
-					total++
-					continue
-				}
-				total++
-				subs = appendSubs(subs, v, pm.Limit)
-				continue
-			}
+		if pm.Topic != "" && pm.Topic != v.Topic {
+			continue
 		}
-		if pm.Topic == v.Topic {
-			if pm.Contact == "" || pm.Contact == v.Contact {
-				if total < offset {
-					total++
-					continue
-				}
-				total++
-				subs = appendSubs(subs, v, pm.Limit)
-			}
+		if pm.Contact != "" && pm.Contact != v.Contact {
+			continue
 		}
+		if pm.MsgTopic != "" && !conditions.MatchTopic(v.Topic, pm.MsgTopic) {
+			continue
+		}
+		if total < offset {
+			total++
+			continue
+		}
+		total++
+		subs = appendSubs(subs, v, pm.Limit)
 	}
 
 	if len(subs) == 0 {
@@ -119,6 +105,18 @@ func appendSubs(subs []notifiers.Subscription, sub notifiers.Subscription, max i
 	return subs
 }
 
+func (srm *subRepoMock) UpdateDelivery(_ context.Context, id string, d notifiers.Delivery) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+	sub, ok := srm.subs[id]
+	if !ok {
+		return notifiers.ErrNotFound
+	}
+	sub.Delivery = d
+	srm.subs[id] = sub
+	return nil
+}
+
 func (srm *subRepoMock) Remove(_ context.Context, id string) error {
 	srm.mu.Lock()
 	defer srm.mu.Unlock()
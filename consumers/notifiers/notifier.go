@@ -15,6 +15,6 @@ var ErrNotify = errors.New("Error sending notification")
 // Notifier represents an API for sending notification.
 type Notifier interface {
 	// Notify method is used to send notification for the
-	// received message to the provided list of receivers.
-	Notify(from string, to []string, msg messaging.Message) error
+	// received message to the contacts of the provided subscriptions.
+	Notify(from string, to []Subscription, msg messaging.Message) error
 }
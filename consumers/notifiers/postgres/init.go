@@ -60,6 +60,56 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE IF EXISTS subscriptions",
 				},
 			},
+			{
+				Id: "subscriptions_2",
+				Up: []string{
+					`ALTER TABLE subscriptions
+                        ADD COLUMN condition   TEXT NOT NULL DEFAULT '',
+                        ADD COLUMN cooldown    BIGINT NOT NULL DEFAULT 0,
+                        ADD COLUMN rising_edge BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions
+                        DROP COLUMN condition,
+                        DROP COLUMN cooldown,
+                        DROP COLUMN rising_edge`,
+				},
+			},
+			{
+				Id: "subscriptions_3",
+				Up: []string{
+					`ALTER TABLE subscriptions
+                        ADD COLUMN secret            TEXT NOT NULL DEFAULT '',
+                        ADD COLUMN delivery_status   VARCHAR(32) NOT NULL DEFAULT '',
+                        ADD COLUMN delivery_attempts INTEGER NOT NULL DEFAULT 0,
+                        ADD COLUMN delivery_error    TEXT NOT NULL DEFAULT '',
+                        ADD COLUMN delivered_at      TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions
+                        DROP COLUMN secret,
+                        DROP COLUMN delivery_status,
+                        DROP COLUMN delivery_attempts,
+                        DROP COLUMN delivery_error,
+                        DROP COLUMN delivered_at`,
+				},
+			},
+			{
+				Id: "subscriptions_4",
+				Up: []string{
+					`ALTER TABLE subscriptions
+                        ADD COLUMN topic_prefix  TEXT NOT NULL DEFAULT '',
+                        ADD COLUMN topic_pattern TEXT NOT NULL DEFAULT ''`,
+					`UPDATE subscriptions SET topic_prefix = topic`,
+					`CREATE INDEX IF NOT EXISTS subscriptions_topic_prefix_idx ON subscriptions (topic_prefix)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS subscriptions_topic_prefix_idx`,
+					`ALTER TABLE subscriptions
+                        DROP COLUMN topic_prefix,
+                        DROP COLUMN topic_pattern`,
+				},
+			},
 		},
 	}
 
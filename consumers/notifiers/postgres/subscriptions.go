@@ -7,10 +7,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/lib/pq"
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/pkg/conditions"
 	"github.com/mainflux/mainflux/pkg/errors"
 )
 
@@ -30,14 +33,12 @@ func New(db Database) notifiers.SubscriptionsRepository {
 }
 
 func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscription) (string, error) {
-	q := `INSERT INTO subscriptions (id, owner_id, contact, topic) VALUES (:id, :owner_id, :contact, :topic) RETURNING id`
+	q := `INSERT INTO subscriptions (id, owner_id, contact, topic, topic_prefix, topic_pattern,
+	condition, cooldown, rising_edge, secret)
+	VALUES (:id, :owner_id, :contact, :topic, :topic_prefix, :topic_pattern,
+	:condition, :cooldown, :rising_edge, :secret) RETURNING id`
 
-	dbSub := dbSubscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
-	}
+	dbSub := toDBSub(sub)
 
 	row, err := repo.db.NamedQueryContext(ctx, q, dbSub)
 	if err != nil {
@@ -52,7 +53,8 @@ func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscripti
 }
 
 func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions WHERE id = $1`
+	q := `SELECT id, owner_id, contact, topic, condition, cooldown, rising_edge, secret,
+	delivery_status, delivery_attempts, delivery_error, delivered_at FROM subscriptions WHERE id = $1`
 	sub := dbSubscription{}
 	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&sub); err != nil {
 		if err == sql.ErrNoRows {
@@ -65,20 +67,28 @@ func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifier
 }
 
 func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.PageMetadata) (notifiers.Page, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions`
+	q := `SELECT id, owner_id, contact, topic, condition, cooldown, rising_edge, secret,
+	delivery_status, delivery_attempts, delivery_error, delivered_at FROM subscriptions`
 	args := make(map[string]interface{})
+	var cond []string
 	if pm.Topic != "" {
 		args["topic"] = pm.Topic
+		cond = append(cond, "topic = :topic")
 	}
 	if pm.Contact != "" {
 		args["contact"] = pm.Contact
+		cond = append(cond, "contact = :contact")
+	}
+	if pm.MsgTopic != "" {
+		// Wildcard subscriptions are looked up by the literal prefix of their
+		// topic using the index, and only then matched against the pattern.
+		args["msg_topic"] = pm.MsgTopic
+		args["msg_prefixes"] = pq.Array(topicPrefixes(pm.MsgTopic))
+		cond = append(cond, `(topic = :msg_topic OR (topic_pattern <> '' AND
+		topic_prefix = ANY(:msg_prefixes) AND :msg_topic ~ topic_pattern))`)
 	}
 	var condition string
-	if len(args) > 0 {
-		var cond []string
-		for k := range args {
-			cond = append(cond, fmt.Sprintf("%s = :%s", k, k))
-		}
+	if len(cond) > 0 {
 		condition = fmt.Sprintf(" WHERE %s", strings.Join(cond, " AND "))
 		q = fmt.Sprintf("%s%s", q, condition)
 	}
@@ -123,6 +133,34 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 	return ret, nil
 }
 
+func (repo subscriptionsRepo) UpdateDelivery(ctx context.Context, id string, d notifiers.Delivery) error {
+	q := `UPDATE subscriptions SET delivery_status = :delivery_status, delivery_attempts = :delivery_attempts,
+	delivery_error = :delivery_error, delivered_at = :delivered_at WHERE id = :id`
+
+	dbSub := dbSubscription{
+		ID:               id,
+		DeliveryStatus:   d.Status,
+		DeliveryAttempts: d.Attempts,
+		DeliveryError:    d.Error,
+		DeliveredAt:      &d.Time,
+	}
+
+	res, err := repo.db.NamedExecContext(ctx, q, dbSub)
+	if err != nil {
+		return errors.Wrap(notifiers.ErrSave, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(notifiers.ErrSave, err)
+	}
+	if cnt == 0 {
+		return notifiers.ErrNotFound
+	}
+
+	return nil
+}
+
 func (repo subscriptionsRepo) Remove(ctx context.Context, id string) error {
 	q := `DELETE from subscriptions WHERE id = $1`
 
@@ -148,17 +186,102 @@ func total(ctx context.Context, db Database, query string, params interface{}) (
 }
 
 type dbSubscription struct {
-	ID      string `db:"id"`
-	OwnerID string `db:"owner_id"`
-	Contact string `db:"contact"`
-	Topic   string `db:"topic"`
+	ID        string `db:"id"`
+	OwnerID   string `db:"owner_id"`
+	Contact   string `db:"contact"`
+	Topic     string `db:"topic"`
+	Condition string `db:"condition"`
+	// Literal tokens of the topic preceding the first wildcard and the
+	// regular expression the wildcard topic is matched with.
+	TopicPrefix  string `db:"topic_prefix"`
+	TopicPattern string `db:"topic_pattern"`
+	Cooldown     int64  `db:"cooldown"`
+	RisingEdge   bool   `db:"rising_edge"`
+	Secret       string `db:"secret"`
+
+	DeliveryStatus   string     `db:"delivery_status"`
+	DeliveryAttempts int        `db:"delivery_attempts"`
+	DeliveryError    string     `db:"delivery_error"`
+	DeliveredAt      *time.Time `db:"delivered_at"`
+}
+
+func toDBSub(sub notifiers.Subscription) dbSubscription {
+	return dbSubscription{
+		ID:        sub.ID,
+		OwnerID:   sub.OwnerID,
+		Contact:   sub.Contact,
+		Topic:     sub.Topic,
+		Condition: sub.Condition,
+		Cooldown:  int64(sub.Cooldown),
+
+		TopicPrefix:  topicPrefix(sub.Topic),
+		TopicPattern: topicPattern(sub.Topic),
+		RisingEdge:   sub.RisingEdge,
+		Secret:       sub.Secret,
+	}
 }
 
 func fromDBSub(sub dbSubscription) notifiers.Subscription {
-	return notifiers.Subscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+	ret := notifiers.Subscription{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Condition:  sub.Condition,
+		Cooldown:   time.Duration(sub.Cooldown),
+		RisingEdge: sub.RisingEdge,
+		Secret:     sub.Secret,
+		Delivery: notifiers.Delivery{
+			Status:   sub.DeliveryStatus,
+			Attempts: sub.DeliveryAttempts,
+			Error:    sub.DeliveryError,
+		},
+	}
+	if sub.DeliveredAt != nil {
+		ret.Delivery.Time = *sub.DeliveredAt
+	}
+	return ret
+}
+
+// topicPrefix returns the tokens of the topic preceding the first wildcard.
+func topicPrefix(topic string) string {
+	var prefix []string
+	for _, t := range strings.Split(topic, ".") {
+		if t == conditions.SingleWildcard || t == conditions.FullWildcard {
+			break
+		}
+		prefix = append(prefix, t)
+	}
+	return strings.Join(prefix, ".")
+}
+
+// topicPattern returns the regular expression matching the message topics
+// covered by the wildcard topic, or an empty string for the other topics.
+func topicPattern(topic string) string {
+	if !conditions.Wildcard(topic) {
+		return ""
+	}
+	var tokens []string
+	for _, t := range strings.Split(topic, ".") {
+		switch t {
+		case conditions.SingleWildcard:
+			tokens = append(tokens, `[^.]+`)
+		case conditions.FullWildcard:
+			tokens = append(tokens, `.+`)
+		default:
+			tokens = append(tokens, regexp.QuoteMeta(t))
+		}
+	}
+	return fmt.Sprintf(`^%s$`, strings.Join(tokens, `\.`))
+}
+
+// topicPrefixes returns all the prefixes of the message topic wildcard
+// subscriptions matching it can have.
+func topicPrefixes(msgTopic string) []string {
+	tokens := strings.Split(msgTopic, ".")
+	prefixes := []string{""}
+	for i := 1; i < len(tokens); i++ {
+		prefixes = append(prefixes, strings.Join(tokens[:i], "."))
 	}
+	return prefixes
 }
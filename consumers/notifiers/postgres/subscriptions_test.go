@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/consumers/notifiers/postgres"
@@ -76,10 +77,13 @@ func TestView(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
 
 	sub := notifiers.Subscription{
-		OwnerID: id,
-		ID:      id,
-		Contact: owner,
-		Topic:   "view.subtopic",
+		OwnerID:    id,
+		ID:         id,
+		Contact:    owner,
+		Topic:      "view.subtopic",
+		Condition:  `name == "temp" && v > 40`,
+		Cooldown:   5 * time.Minute,
+		RisingEdge: true,
 	}
 
 	ret, err := repo.Save(context.Background(), sub)
@@ -221,6 +225,87 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveAllMatching(t *testing.T) {
+	_, err := db.Exec("DELETE FROM subscriptions")
+	require.Nil(t, err, fmt.Sprintf("cleanup must not fail: %s", err))
+
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	topics := []string{
+		"match.subtopic",
+		"match.*",
+		"match.>",
+		"match.*.temp",
+		"match.sub+topic",
+		"other.>",
+	}
+	for _, topic := range topics {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		sub := notifiers.Subscription{
+			OwnerID: "owner",
+			ID:      id,
+			Contact: owner,
+			Topic:   topic,
+		}
+		_, err = repo.Save(context.Background(), sub)
+		require.Nil(t, err, fmt.Sprintf("creating subscription must not fail: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		msgTopic string
+		topics   []string
+		err      error
+	}{
+		{
+			desc:     "retrieve matching channel",
+			msgTopic: "match",
+			topics:   nil,
+			err:      notifiers.ErrNotFound,
+		},
+		{
+			desc:     "retrieve matching subtopic",
+			msgTopic: "match.subtopic",
+			topics:   []string{"match.subtopic", "match.*", "match.>"},
+			err:      nil,
+		},
+		{
+			desc:     "retrieve matching nested subtopic",
+			msgTopic: "match.subtopic.temp",
+			topics:   []string{"match.>", "match.*.temp"},
+			err:      nil,
+		},
+		{
+			desc:     "retrieve matching subtopic with special characters",
+			msgTopic: "match.sub+topic",
+			topics:   []string{"match.sub+topic", "match.*", "match.>"},
+			err:      nil,
+		},
+		{
+			desc:     "retrieve matching another channel",
+			msgTopic: "another.subtopic.temp",
+			topics:   nil,
+			err:      notifiers.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		pm := notifiers.PageMetadata{
+			MsgTopic: tc.msgTopic,
+			Limit:    -1,
+		}
+		page, err := repo.RetrieveAll(context.Background(), pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		var topics []string
+		for _, sub := range page.Subscriptions {
+			topics = append(topics, sub.Topic)
+		}
+		assert.ElementsMatch(t, tc.topics, topics, fmt.Sprintf("%s: expected topics %v got %v\n", tc.desc, tc.topics, topics))
+	}
+}
+
 func TestRemove(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	repo := postgres.New(dbMiddleware)
@@ -259,3 +344,56 @@ func TestRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestUpdateDelivery(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+	sub := notifiers.Subscription{
+		OwnerID: id,
+		ID:      id,
+		Contact: "https://example.com/hook",
+		Topic:   "delivery.subtopic",
+		Secret:  "secret",
+	}
+
+	_, err = repo.Save(context.Background(), sub)
+	require.Nil(t, err, fmt.Sprintf("creating subscription must not fail: %s", err))
+
+	delivery := notifiers.Delivery{
+		Status:   notifiers.FailedStatus,
+		Attempts: 3,
+		Error:    "unexpected webhook response status",
+		Time:     time.Now().UTC().Round(time.Millisecond),
+	}
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "update delivery successfully",
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc: "update delivery of not existing subscription",
+			id:   "empty",
+			err:  notifiers.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateDelivery(context.Background(), tc.id, delivery)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	ret, err := repo.Retrieve(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("retrieving subscription must not fail: %s", err))
+	assert.Equal(t, sub.Secret, ret.Secret, fmt.Sprintf("expected secret %s got %s\n", sub.Secret, ret.Secret))
+	assert.True(t, delivery.Time.Equal(ret.Delivery.Time), fmt.Sprintf("expected delivery time %s got %s\n", delivery.Time, ret.Delivery.Time))
+	ret.Delivery.Time = delivery.Time
+	assert.Equal(t, delivery, ret.Delivery, fmt.Sprintf("expected delivery %v got %v\n", delivery, ret.Delivery))
+}
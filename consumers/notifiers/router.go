@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ Notifier = (*router)(nil)
+
+type router struct {
+	def    Notifier
+	routes map[string]Notifier
+}
+
+// NewRouter returns Notifier which passes each subscription to the notifier
+// registered for its contact URL scheme, e.g. "https" for webhooks. The rest
+// of the subscriptions, such as the ones with email contacts, are passed to
+// the default notifier.
+func NewRouter(def Notifier, routes map[string]Notifier) Notifier {
+	return router{
+		def:    def,
+		routes: routes,
+	}
+}
+
+func (r router) Notify(from string, to []Subscription, msg messaging.Message) error {
+	subs := make(map[Notifier][]Subscription)
+	for _, sub := range to {
+		n, ok := r.routes[ContactScheme(sub.Contact)]
+		if !ok {
+			n = r.def
+		}
+		subs[n] = append(subs[n], sub)
+	}
+
+	var err error
+	for n, to := range subs {
+		if e := n.Notify(from, to, msg); e != nil {
+			if err == nil {
+				err = e
+				continue
+			}
+			err = errors.Wrap(err, e)
+		}
+	}
+
+	return err
+}
+
+// ContactScheme returns lowercase URL scheme of the subscription contact,
+// or an empty string if the contact is not an URL.
+func ContactScheme(contact string) string {
+	if !strings.Contains(contact, "://") {
+		return ""
+	}
+	u, err := url.Parse(contact)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Scheme)
+}
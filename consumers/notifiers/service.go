@@ -5,12 +5,18 @@ package notifiers
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/pkg/conditions"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
 )
 
 var (
@@ -38,6 +44,12 @@ var (
 
 	// ErrMessage indicates an error converting a message to Mainflux message.
 	ErrMessage = errors.New("failed to convert to Mainflux message")
+
+	// ErrSecretUnavailable indicates that the subscription secrets can't be
+	// stored, since the secrets encryption is not configured.
+	ErrSecretUnavailable = errors.New("subscription secrets are not configured")
+
+	errSecret = errors.New("failed to process subscription secret")
 )
 
 // Service reprents a notification service.
@@ -61,19 +73,41 @@ type Service interface {
 var _ Service = (*notifierService)(nil)
 
 type notifierService struct {
-	auth     mainflux.AuthServiceClient
-	subs     SubscriptionsRepository
-	idp      mainflux.IDProvider
-	notifier Notifier
+	auth        mainflux.AuthServiceClient
+	things      mainflux.ThingsServiceClient
+	subs        SubscriptionsRepository
+	idp         mainflux.IDProvider
+	notifier    Notifier
+	cipher      Cipher
+	transformer transformers.Transformer
+	mu          sync.Mutex
+	states      map[string]*subState
+}
+
+// subState keeps the state of the conditional subscription between
+// two consumed messages.
+type subState struct {
+	condition string
+	cond      conditions.Condition
+	met       bool
+	notified  time.Time
+	// version is incremented on each update of the state.
+	version uint64
 }
 
-// New instantiates the subscriptions service implementation.
-func New(auth mainflux.AuthServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier) Service {
+// New instantiates the subscriptions service implementation. Subscription
+// secrets are encrypted using the cipher; if it's nil, creating subscriptions
+// with secrets is disabled.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier, cipher Cipher) Service {
 	return &notifierService{
-		auth:     auth,
-		subs:     subs,
-		idp:      idp,
-		notifier: notifier,
+		auth:        auth,
+		things:      things,
+		subs:        subs,
+		idp:         idp,
+		notifier:    notifier,
+		cipher:      cipher,
+		transformer: senml.New(senml.JSON),
+		states:      make(map[string]*subState),
 	}
 }
 
@@ -82,10 +116,30 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 	if err != nil {
 		return "", errors.Wrap(ErrUnauthorizedAccess, err)
 	}
+	// Wildcards are allowed only in subtopic, so the subscription
+	// can't receive messages from the channels of the other users.
+	if _, err := ns.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetEmail(), ChanID: TopicChannel(sub.Topic)}); err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if sub.Condition != "" {
+		if _, err := conditions.ParseCondition(sub.Condition); err != nil {
+			return "", err
+		}
+	}
 	sub.ID, err = ns.idp.ID()
 	if err != nil {
 		return "", errors.Wrap(ErrCreateID, err)
 	}
+	if sub.Secret != "" {
+		if ns.cipher == nil {
+			return "", ErrSecretUnavailable
+		}
+		secret, err := ns.cipher.Encrypt([]byte(sub.Secret), []byte(sub.ID))
+		if err != nil {
+			return "", errors.Wrap(errSecret, err)
+		}
+		sub.Secret = hex.EncodeToString(secret)
+	}
 
 	sub.OwnerID = res.GetId()
 	return ns.subs.Save(ctx, sub)
@@ -112,7 +166,15 @@ func (ns *notifierService) RemoveSubscription(ctx context.Context, token, id str
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ns.subs.Remove(ctx, id)
+	if err := ns.subs.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	ns.mu.Lock()
+	delete(ns.states, id)
+	ns.mu.Unlock()
+
+	return nil
 }
 
 func (ns *notifierService) Consume(message interface{}) error {
@@ -125,25 +187,161 @@ func (ns *notifierService) Consume(message interface{}) error {
 		topic = fmt.Sprintf("%s.%s", msg.Channel, msg.Subtopic)
 	}
 	pm := PageMetadata{
-		Topic:  topic,
-		Offset: 0,
-		Limit:  -1,
+		MsgTopic: topic,
+		Offset:   0,
+		Limit:    -1,
 	}
 	page, err := ns.subs.RetrieveAll(context.Background(), pm)
 	if err != nil {
 		return err
 	}
 
-	var to []string
+	// Message is transformed to SenML only if there is a subscription
+	// with the condition.
+	var records []senml.Message
+	transformed := false
+	senmlRecords := func() []senml.Message {
+		if !transformed {
+			transformed = true
+			if res, err := ns.transformer.Transform(msg); err == nil {
+				records, _ = res.([]senml.Message)
+			}
+		}
+		return records
+	}
+
+	// States are updated under the lock, while the notifications are sent
+	// without holding it, so that slow contacts don't block other messages.
+	ns.mu.Lock()
+	now := time.Now()
+	var to []notification
 	for _, sub := range page.Subscriptions {
-		to = append(to, sub.Contact)
+		n := notification{sub: sub}
+		if st, ok := ns.states[sub.ID]; ok {
+			prev := *st
+			n.prev = &prev
+		}
+		if !ns.fire(sub, senmlRecords, now) {
+			continue
+		}
+		if st, ok := ns.states[sub.ID]; ok {
+			n.version = st.version
+		}
+		to = append(to, n)
 	}
-	if len(to) > 0 {
-		err := ns.notifier.Notify("", to, msg)
-		if err != nil {
-			return errors.Wrap(ErrNotify, err)
+	ns.mu.Unlock()
+
+	var failed []notification
+	for _, n := range to {
+		e := ns.decryptSecret(&n.sub)
+		if e == nil {
+			e = ns.notifier.Notify("", []Subscription{n.sub}, msg)
 		}
+		if e != nil {
+			failed = append(failed, n)
+			if err == nil {
+				err = e
+				continue
+			}
+			err = errors.Wrap(err, e)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
 	}
 
+	// Restore the states of the failed subscriptions, so the message can be
+	// consumed again. States updated by another message in the meantime are
+	// kept.
+	ns.mu.Lock()
+	for _, n := range failed {
+		st, ok := ns.states[n.sub.ID]
+		if !ok || st.version != n.version {
+			continue
+		}
+		if n.prev == nil {
+			delete(ns.states, n.sub.ID)
+			continue
+		}
+		*st = *n.prev
+	}
+	ns.mu.Unlock()
+
+	return errors.Wrap(ErrNotify, err)
+}
+
+// decryptSecret replaces the stored secret of the subscription with the
+// plaintext one.
+func (ns *notifierService) decryptSecret(sub *Subscription) error {
+	if sub.Secret == "" {
+		return nil
+	}
+	if ns.cipher == nil {
+		return ErrSecretUnavailable
+	}
+	ciphertext, err := hex.DecodeString(sub.Secret)
+	if err != nil {
+		return errors.Wrap(errSecret, err)
+	}
+	secret, err := ns.cipher.Decrypt(ciphertext, []byte(sub.ID))
+	if err != nil {
+		return errors.Wrap(errSecret, err)
+	}
+	sub.Secret = string(secret)
+
 	return nil
 }
+
+// notification represents the subscription to be notified together with
+// its state before the message was consumed.
+type notification struct {
+	sub     Subscription
+	prev    *subState
+	version uint64
+}
+
+// fire checks if the subscriber should be notified about the message and
+// updates the subscription state accordingly.
+func (ns *notifierService) fire(sub Subscription, records func() []senml.Message, now time.Time) bool {
+	if sub.Condition == "" && sub.Cooldown == 0 && !sub.RisingEdge {
+		return true
+	}
+
+	st, ok := ns.states[sub.ID]
+	if !ok || st.condition != sub.Condition {
+		st = &subState{condition: sub.Condition}
+		if sub.Condition != "" {
+			cond, err := conditions.ParseCondition(sub.Condition)
+			if err != nil {
+				// Conditions are validated on creation, so this
+				// can happen only if the repository is corrupted.
+				return false
+			}
+			st.cond = cond
+		}
+		ns.states[sub.ID] = st
+	}
+	st.version++
+
+	met := st.cond == nil
+	if !met {
+		for _, r := range records() {
+			if st.cond.Match(r) {
+				met = true
+				break
+			}
+		}
+	}
+
+	wasMet := st.met
+	st.met = met
+	if !met || (sub.RisingEdge && wasMet) {
+		return false
+	}
+	if sub.Cooldown > 0 && now.Sub(st.notified) < sub.Cooldown {
+		return false
+	}
+	st.notified = now
+
+	return true
+}
@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
 	"github.com/mainflux/mainflux/consumers/notifiers/mocks"
+	"github.com/mainflux/mainflux/pkg/conditions"
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -24,12 +26,22 @@ const (
 	invalidUser  = "invalid@example.com"
 )
 
+// channels maps the channels used in tests to their owners.
+var channels = map[string]string{
+	"valid":       exampleUser1,
+	"conditional": exampleUser1,
+	"invalid":     exampleUser1,
+	"topic":       exampleUser1,
+	"other":       exampleUser2,
+}
+
 func newService() notifiers.Service {
 	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
 	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1, exampleUser2: exampleUser2, invalidUser: invalidUser})
+	things := mocks.NewThingsService(channels)
 	notifier := mocks.NewNotifier()
 	idp := uuid.NewMock()
-	return notifiers.New(auth, repo, idp, notifier)
+	return notifiers.New(auth, things, repo, idp, notifier, mocks.NewCipher())
 }
 
 func TestCreateSubscription(t *testing.T) {
@@ -63,6 +75,27 @@ func TestCreateSubscription(t *testing.T) {
 			id:    "",
 			err:   notifiers.ErrUnauthorizedAccess,
 		},
+		{
+			desc:  "test with condition",
+			token: exampleUser1,
+			sub:   notifiers.Subscription{Contact: exampleUser1, Topic: "conditional.topic", Condition: `name == "temp" && v > 40`},
+			id:    uuid.Prefix + fmt.Sprintf("%012d", 3),
+			err:   nil,
+		},
+		{
+			desc:  "test with invalid condition",
+			token: exampleUser1,
+			sub:   notifiers.Subscription{Contact: exampleUser1, Topic: "invalid.topic", Condition: `name == 40`},
+			id:    "",
+			err:   conditions.ErrInvalidCondition,
+		},
+		{
+			desc:  "test with channel owned by another user",
+			token: exampleUser1,
+			sub:   notifiers.Subscription{Contact: exampleUser1, Topic: "other.topic"},
+			id:    "",
+			err:   notifiers.ErrUnauthorizedAccess,
+		},
 	}
 
 	for _, tc := range cases {
@@ -72,6 +105,30 @@ func TestCreateSubscription(t *testing.T) {
 	}
 }
 
+func TestSubscriptionSecret(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := &recorder{}
+	svc := notifiers.New(auth, mocks.NewThingsService(channels), repo, uuid.NewMock(), notifier, mocks.NewCipher())
+	noCipherSvc := notifiers.New(auth, mocks.NewThingsService(channels), repo, uuid.NewMock(), notifier, nil)
+
+	const secret = "secret"
+	sub := notifiers.Subscription{Contact: "https://example.com/hook", Topic: "topic", Secret: secret}
+
+	_, err := noCipherSvc.CreateSubscription(context.Background(), exampleUser1, sub)
+	assert.True(t, errors.Contains(err, notifiers.ErrSecretUnavailable), fmt.Sprintf("create subscription with secret without cipher: expected %s got %s\n", notifiers.ErrSecretUnavailable, err))
+
+	id, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+	saved, err := repo.Retrieve(context.Background(), id)
+	require.Nil(t, err, "Retrieving a Subscription must succeed")
+	assert.NotEqual(t, secret, saved.Secret, "expected secret to be stored encrypted")
+
+	err = svc.Consume(messaging.Message{Channel: "topic"})
+	assert.Nil(t, err, fmt.Sprintf("consume message: expected no error got %s\n", err))
+	assert.Equal(t, []string{secret}, notifier.secrets, fmt.Sprintf("expected notified secrets %v got %v\n", []string{secret}, notifier.secrets))
+}
+
 func TestViewSubscription(t *testing.T) {
 	svc := newService()
 	sub := notifiers.Subscription{Contact: exampleUser1, Topic: "valid.topic"}
@@ -121,16 +178,18 @@ func TestListSubscriptions(t *testing.T) {
 	svc := newService()
 	sub := notifiers.Subscription{Contact: exampleUser1, OwnerID: exampleUser1}
 	topic := "topic.subtopic"
+	otherTopic := "other.subtopic"
 	var subs []notifiers.Subscription
 	for i := 0; i < total; i++ {
 		tmp := sub
 		token := exampleUser1
+		tmp.Topic = fmt.Sprintf("%s.%d", topic, i)
 		if i%2 == 0 {
 			tmp.Contact = exampleUser2
 			tmp.OwnerID = exampleUser2
+			tmp.Topic = fmt.Sprintf("%s.%d", otherTopic, i)
 			token = exampleUser2
 		}
-		tmp.Topic = fmt.Sprintf("%s.%d", topic, i)
 		id, err := svc.CreateSubscription(context.Background(), token, tmp)
 		require.Nil(t, err, "Saving a Subscription must succeed")
 		tmp.ID = id
@@ -192,14 +251,14 @@ func TestListSubscriptions(t *testing.T) {
 			token: exampleUser1,
 			pageMeta: notifiers.PageMetadata{
 				Limit: 10,
-				Topic: fmt.Sprintf("%s.%d", topic, 4),
+				Topic: fmt.Sprintf("%s.%d", topic, 5),
 			},
 			page: notifiers.Page{
 				PageMetadata: notifiers.PageMetadata{
 					Limit: 10,
-					Topic: fmt.Sprintf("%s.%d", topic, 4),
+					Topic: fmt.Sprintf("%s.%d", topic, 5),
 				},
-				Subscriptions: subs[4:5],
+				Subscriptions: subs[5:6],
 				Total:         1,
 			},
 			err: nil,
@@ -324,3 +383,197 @@ func TestConsume(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestConsumeWithConditions(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := &recorder{}
+	svc := notifiers.New(auth, mocks.NewThingsService(channels), repo, uuid.NewMock(), notifier, mocks.NewCipher())
+
+	const (
+		conditional = "conditional@example.com"
+		risingEdge  = "rising@example.com"
+		cooldown    = "cooldown@example.com"
+	)
+	condition := `name == "temp" && v > 40`
+	subs := []notifiers.Subscription{
+		{Contact: conditional, Topic: "topic.subtopic", Condition: condition},
+		{Contact: risingEdge, Topic: "topic.subtopic", Condition: condition, RisingEdge: true},
+		{Contact: cooldown, Topic: "topic.subtopic", Cooldown: 100 * time.Millisecond},
+	}
+	for _, sub := range subs {
+		_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+		require.Nil(t, err, "Saving a Subscription must succeed")
+	}
+
+	msg := func(payload string) messaging.Message {
+		return messaging.Message{
+			Channel:  "topic",
+			Subtopic: "subtopic",
+			Payload:  []byte(payload),
+		}
+	}
+
+	cases := []struct {
+		desc  string
+		msg   messaging.Message
+		sleep time.Duration
+		to    []string
+	}{
+		{
+			desc: "consume message not matching the condition",
+			msg:  msg(`[{"n":"temp","v":30}]`),
+			to:   []string{cooldown},
+		},
+		{
+			desc: "consume message matching the condition",
+			msg:  msg(`[{"n":"temp","v":45}]`),
+			to:   []string{conditional, risingEdge},
+		},
+		{
+			desc:  "consume message still matching the condition",
+			msg:   msg(`[{"n":"humidity","v":30},{"n":"temp","v":50}]`),
+			sleep: 100 * time.Millisecond,
+			to:    []string{conditional, cooldown},
+		},
+		{
+			desc: "consume message of another record",
+			msg:  msg(`[{"n":"humidity","v":50}]`),
+			to:   nil,
+		},
+		{
+			desc: "consume message matching the condition again",
+			msg:  msg(`[{"n":"temp","v":41}]`),
+			to:   []string{conditional, risingEdge},
+		},
+		{
+			desc:  "consume non-SenML message",
+			msg:   msg(`{"temp":50}`),
+			sleep: 100 * time.Millisecond,
+			to:    []string{cooldown},
+		},
+	}
+
+	for _, tc := range cases {
+		time.Sleep(tc.sleep)
+		notifier.to = nil
+		err := svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.ElementsMatch(t, tc.to, notifier.to, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.to, notifier.to))
+	}
+}
+
+func TestConsumeFailure(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := &recorder{fail: invalidUser}
+	svc := notifiers.New(auth, mocks.NewThingsService(channels), repo, uuid.NewMock(), notifier, mocks.NewCipher())
+
+	subs := []notifiers.Subscription{
+		{Contact: exampleUser2, Topic: "topic", Cooldown: time.Hour},
+		{Contact: invalidUser, Topic: "topic", Cooldown: time.Hour},
+	}
+	for _, sub := range subs {
+		_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+		require.Nil(t, err, "Saving a Subscription must succeed")
+	}
+
+	cases := []struct {
+		desc string
+		to   []string
+	}{
+		{
+			desc: "consume message failing to notify one of subscriptions",
+			to:   []string{exampleUser2, invalidUser},
+		},
+		{
+			desc: "consume message again notifying only failed subscription",
+			to:   []string{invalidUser},
+		},
+	}
+
+	for _, tc := range cases {
+		notifier.to = nil
+		err := svc.Consume(messaging.Message{Channel: "topic"})
+		assert.True(t, errors.Contains(err, notifiers.ErrNotify), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, notifiers.ErrNotify, err))
+		assert.ElementsMatch(t, tc.to, notifier.to, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.to, notifier.to))
+	}
+}
+
+func TestConsumeWithWildcards(t *testing.T) {
+	repo := mocks.NewRepo(make(map[string]notifiers.Subscription))
+	auth := mocks.NewAuth(map[string]string{exampleUser1: exampleUser1})
+	notifier := &recorder{}
+	svc := notifiers.New(auth, mocks.NewThingsService(channels), repo, uuid.NewMock(), notifier, mocks.NewCipher())
+
+	const (
+		exact  = "exact@example.com"
+		single = "single@example.com"
+		full   = "full@example.com"
+	)
+	subs := []notifiers.Subscription{
+		{Contact: exact, Topic: "topic.subtopic"},
+		{Contact: single, Topic: "topic.*"},
+		{Contact: full, Topic: "topic.>"},
+	}
+	for _, sub := range subs {
+		_, err := svc.CreateSubscription(context.Background(), exampleUser1, sub)
+		require.Nil(t, err, "Saving a Subscription must succeed")
+	}
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		to   []string
+		err  error
+	}{
+		{
+			desc: "consume message without subtopic",
+			msg:  messaging.Message{Channel: "topic"},
+			to:   nil,
+			err:  notifiers.ErrNotFound,
+		},
+		{
+			desc: "consume message with subtopic",
+			msg:  messaging.Message{Channel: "topic", Subtopic: "subtopic"},
+			to:   []string{exact, single, full},
+		},
+		{
+			desc: "consume message with nested subtopic",
+			msg:  messaging.Message{Channel: "topic", Subtopic: "subtopic.temp"},
+			to:   []string{full},
+		},
+		{
+			desc: "consume message from another channel",
+			msg:  messaging.Message{Channel: "other", Subtopic: "subtopic.temp"},
+			to:   nil,
+			err:  notifiers.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		notifier.to = nil
+		err := svc.Consume(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.ElementsMatch(t, tc.to, notifier.to, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.to, notifier.to))
+	}
+}
+
+// recorder records the notified contacts and secrets, failing
+// to notify the fail contact.
+type recorder struct {
+	to      []string
+	secrets []string
+	fail    string
+}
+
+func (r *recorder) Notify(from string, to []notifiers.Subscription, msg messaging.Message) error {
+	for _, sub := range to {
+		r.to = append(r.to, sub.Contact)
+		r.secrets = append(r.secrets, sub.Secret)
+		if sub.Contact == r.fail {
+			return notifiers.ErrNotify
+		}
+	}
+	return nil
+}
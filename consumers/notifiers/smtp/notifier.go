@@ -27,7 +27,7 @@ func New(agent *email.Agent) notifiers.Notifier {
 	return &notifier{agent: agent}
 }
 
-func (n *notifier) Notify(from string, to []string, msg messaging.Message) error {
+func (n *notifier) Notify(from string, to []notifiers.Subscription, msg messaging.Message) error {
 	subject := fmt.Sprintf(`Notification for Channel %s`, msg.Channel)
 	if msg.Subtopic != "" {
 		subject = fmt.Sprintf("%s and subtopic %s", subject, msg.Subtopic)
@@ -36,5 +36,10 @@ func (n *notifier) Notify(from string, to []string, msg messaging.Message) error
 	values := string(msg.Payload)
 	content := fmt.Sprintf(contentTemplate, msg.Publisher, msg.Protocol, values)
 
-	return n.agent.Send(to, from, subject, "", content, footer)
+	var contacts []string
+	for _, sub := range to {
+		contacts = append(contacts, sub.Contact)
+	}
+
+	return n.agent.Send(contacts, from, subject, "", content, footer)
 }
@@ -3,7 +3,11 @@
 
 package notifiers
 
-import "context"
+import (
+	"context"
+	"strings"
+	"time"
+)
 
 // Subscription represents a user Subscription.
 type Subscription struct {
@@ -11,6 +15,41 @@ type Subscription struct {
 	OwnerID string
 	Contact string
 	Topic   string
+	// Condition over SenML record fields, e.g. `name == "temp" && v > 40`.
+	// Subscriber is notified only about the messages which contain a record
+	// that satisfies the condition. Empty condition matches every message.
+	Condition string
+	// Cooldown is the minimal time between two notifications.
+	Cooldown time.Duration
+	// RisingEdge indicates that subscriber is notified only when the
+	// condition becomes satisfied, rather than about every matching message.
+	RisingEdge bool
+	// Secret is used to sign the notifications sent to webhook contacts.
+	// It's stored encrypted and decrypted only to sign the notifications.
+	Secret string
+	// Delivery is the outcome of the latest webhook notification.
+	Delivery Delivery
+}
+
+// TopicChannel returns the channel ID of the subscription topic.
+func TopicChannel(topic string) string {
+	return strings.SplitN(topic, ".", 2)[0]
+}
+
+const (
+	// DeliveredStatus indicates that notification is delivered.
+	DeliveredStatus = "delivered"
+	// FailedStatus indicates that notification delivery failed after
+	// all the attempts.
+	FailedStatus = "failed"
+)
+
+// Delivery represents the outcome of the notification delivery.
+type Delivery struct {
+	Status   string
+	Attempts int
+	Error    string
+	Time     time.Time
 }
 
 // Page represents page metadata with content.
@@ -27,6 +66,10 @@ type PageMetadata struct {
 	Limit   int
 	Topic   string
 	Contact string
+	// MsgTopic is the topic of the consumed message. If set, only the
+	// subscriptions whose topic matches it, taking wildcards into account,
+	// are retrieved.
+	MsgTopic string
 }
 
 // SubscriptionsRepository specifies a Subscription persistence API.
@@ -41,6 +84,21 @@ type SubscriptionsRepository interface {
 	// RetrieveAll retrieves all the subscriptions for the given page metadata.
 	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
 
+	// UpdateDelivery records the outcome of the latest notification
+	// delivery for the subscription with the given ID.
+	UpdateDelivery(ctx context.Context, id string, d Delivery) error
+
 	// Remove removes the subscription for the given ID.
 	Remove(ctx context.Context, id string) error
 }
+
+// Cipher specifies an API for encrypting the subscription secrets.
+type Cipher interface {
+	// Encrypt encrypts the plaintext, authenticating the additional data
+	// alongside, so that the ciphertext can't be used in other context.
+	Encrypt(plaintext, data []byte) ([]byte, error)
+
+	// Decrypt decrypts the ciphertext encrypted with the same additional
+	// data. An error indicates that the ciphertext or the data is modified.
+	Decrypt(ciphertext, data []byte) ([]byte, error)
+}
@@ -13,10 +13,11 @@ import (
 )
 
 const (
-	saveOp        = "save_op"
-	retrieveOp    = "retrieve_op"
-	retrieveAllOp = "retrieve_all_op"
-	removeOp      = "remove_op"
+	saveOp           = "save_op"
+	retrieveOp       = "retrieve_op"
+	retrieveAllOp    = "retrieve_all_op"
+	removeOp         = "remove_op"
+	updateDeliveryOp = "update_delivery_op"
 )
 
 var _ notifiers.SubscriptionsRepository = (*subRepositoryMiddleware)(nil)
@@ -59,6 +60,14 @@ func (urm subRepositoryMiddleware) RetrieveAll(ctx context.Context, pm notifiers
 	return urm.repo.RetrieveAll(ctx, pm)
 }
 
+func (urm subRepositoryMiddleware) UpdateDelivery(ctx context.Context, id string, d notifiers.Delivery) error {
+	span := createSpan(ctx, urm.tracer, updateDeliveryOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UpdateDelivery(ctx, id, d)
+}
+
 func (urm subRepositoryMiddleware) Remove(ctx context.Context, id string) error {
 	span := createSpan(ctx, urm.tracer, removeOp)
 	defer span.Finish()
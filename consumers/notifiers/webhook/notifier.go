@@ -0,0 +1,210 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	contentType = "application/json"
+
+	// SignatureHeader contains hex encoded HMAC-SHA256 of the request body,
+	// calculated using the subscription secret and prefixed with "sha256=".
+	SignatureHeader = "X-Mainflux-Signature"
+
+	// SubscriptionHeader contains ID of the notified subscription.
+	SubscriptionHeader = "X-Mainflux-Subscription"
+)
+
+var (
+	errUnexpectedStatus = errors.New("unexpected webhook response status")
+	errQueueFull        = errors.New("webhook delivery queue is full")
+)
+
+var _ notifiers.Notifier = (*notifier)(nil)
+
+// Config contains webhook delivery parameters.
+type Config struct {
+	// Timeout of a single delivery attempt.
+	Timeout time.Duration
+	// Retries is the number of delivery attempts after the first one fails.
+	Retries int
+	// Backoff is the delay before the first retry, doubled for every
+	// subsequent one.
+	Backoff time.Duration
+	// Workers is the number of concurrent deliveries.
+	Workers int
+	// QueueSize is the maximum number of pending deliveries.
+	QueueSize int
+	// AllowPrivate allows delivering notifications to the loopback,
+	// private and link-local addresses, which are refused by default.
+	AllowPrivate bool
+}
+
+type delivery struct {
+	sub  notifiers.Subscription
+	body []byte
+}
+
+type notifier struct {
+	client *http.Client
+	subs   notifiers.SubscriptionsRepository
+	cfg    Config
+	queue  chan delivery
+	logger logger.Logger
+}
+
+// New instantiates webhook message notifier. Notifications are queued and
+// delivered asynchronously by the workers, and the outcome of each delivery
+// is recorded using the subscriptions repository.
+func New(subs notifiers.SubscriptionsRepository, cfg Config, logger logger.Logger) notifiers.Notifier {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	client := httputil.NewPublicClient(cfg.Timeout)
+	if cfg.AllowPrivate {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	n := &notifier{
+		client: client,
+		subs:   subs,
+		cfg:    cfg,
+		queue:  make(chan delivery, cfg.QueueSize),
+		logger: logger,
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		go n.work()
+	}
+
+	return n
+}
+
+type message struct {
+	Channel   string          `json:"channel"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher"`
+	Protocol  string          `json:"protocol"`
+	Created   int64           `json:"created"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Notify queues the message to be posted to the contacts of the subscriptions.
+// An error is returned for the subscriptions which can't be queued.
+func (n *notifier) Notify(from string, to []notifiers.Subscription, msg messaging.Message) error {
+	body, err := encode(msg)
+	if err != nil {
+		return errors.Wrap(notifiers.ErrNotify, err)
+	}
+
+	for _, sub := range to {
+		select {
+		case n.queue <- delivery{sub: sub, body: body}:
+		default:
+			return errors.Wrap(notifiers.ErrNotify, errQueueFull)
+		}
+	}
+
+	return nil
+}
+
+func (n *notifier) work() {
+	for d := range n.queue {
+		n.deliver(d.sub, d.body)
+	}
+}
+
+// deliver sends the notification, retrying with exponential backoff.
+func (n *notifier) deliver(sub notifiers.Subscription, body []byte) {
+	d := notifiers.Delivery{Status: notifiers.DeliveredStatus}
+	backoff := n.cfg.Backoff
+
+	for d.Attempts = 1; ; d.Attempts++ {
+		err := n.post(sub, body)
+		if err == nil {
+			break
+		}
+		if d.Attempts > n.cfg.Retries {
+			d.Status = notifiers.FailedStatus
+			d.Error = err.Error()
+			n.logger.Warn(fmt.Sprintf("Failed to notify subscription %s after %d attempts: %s", sub.ID, d.Attempts, err))
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	d.Time = time.Now()
+
+	if err := n.subs.UpdateDelivery(context.Background(), sub.ID, d); err != nil {
+		n.logger.Error(fmt.Sprintf("Failed to record delivery of subscription %s: %s", sub.ID, err))
+	}
+}
+
+func (n *notifier) post(sub notifiers.Subscription, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, sub.Contact, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(SubscriptionHeader, sub.ID)
+	if sub.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return errors.Wrap(errUnexpectedStatus, fmt.Errorf("%s", res.Status))
+	}
+
+	return nil
+}
+
+// Sign returns the value of the signature header for the given body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
+
+// encode marshals the message. JSON payload is embedded as is, while any
+// other payload is sent as a string.
+func encode(msg messaging.Message) ([]byte, error) {
+	payload := json.RawMessage(msg.Payload)
+	if !json.Valid(msg.Payload) {
+		str, err := json.Marshal(string(msg.Payload))
+		if err != nil {
+			return nil, err
+		}
+		payload = str
+	}
+
+	return json.Marshal(message{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+		Payload:   payload,
+	})
+}
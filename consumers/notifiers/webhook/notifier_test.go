@@ -0,0 +1,188 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	notifiers "github.com/mainflux/mainflux/consumers/notifiers"
+	"github.com/mainflux/mainflux/consumers/notifiers/mocks"
+	"github.com/mainflux/mainflux/consumers/notifiers/webhook"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret  = "secret"
+	subID   = "sub"
+	timeout = 3 * time.Second
+)
+
+// hook records the requests and fails the first `failures` of them.
+type hook struct {
+	mu       sync.Mutex
+	failures int
+	requests int
+	body     []byte
+	header   http.Header
+}
+
+func (h *hook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.requests++
+	h.body, _ = ioutil.ReadAll(r.Body)
+	h.header = r.Header.Clone()
+	if h.requests <= h.failures {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestNotify(t *testing.T) {
+	msg := messaging.Message{
+		Channel:   "chan",
+		Subtopic:  "topic",
+		Publisher: "pub",
+		Protocol:  "http",
+		Created:   1,
+		Payload:   []byte(`[{"n":"temp","v":42}]`),
+	}
+	cfg := webhook.Config{
+		Timeout:      time.Second,
+		Retries:      2,
+		Backoff:      time.Millisecond,
+		Workers:      1,
+		QueueSize:    1,
+		AllowPrivate: true,
+	}
+
+	cases := []struct {
+		desc     string
+		failures int
+		secret   string
+		status   string
+		attempts int
+	}{
+		{
+			desc:     "notify successfully",
+			secret:   secret,
+			status:   notifiers.DeliveredStatus,
+			attempts: 1,
+		},
+		{
+			desc:     "notify successfully without secret",
+			status:   notifiers.DeliveredStatus,
+			attempts: 1,
+		},
+		{
+			desc:     "notify successfully after retries",
+			failures: 2,
+			secret:   secret,
+			status:   notifiers.DeliveredStatus,
+			attempts: 3,
+		},
+		{
+			desc:     "notify with all attempts failed",
+			failures: 3,
+			secret:   secret,
+			status:   notifiers.FailedStatus,
+			attempts: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		h := &hook{failures: tc.failures}
+		ts := httptest.NewServer(h)
+
+		sub := notifiers.Subscription{
+			ID:      subID,
+			Contact: ts.URL,
+			Topic:   "topic",
+			Secret:  tc.secret,
+		}
+		repo := mocks.NewRepo(map[string]notifiers.Subscription{subID: sub})
+		n := webhook.New(repo, cfg, newLogger())
+
+		err := n.Notify("", []notifiers.Subscription{sub}, msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		require.Eventually(t, func() bool {
+			saved, err := repo.Retrieve(context.Background(), subID)
+			return err == nil && saved.Delivery.Status != ""
+		}, timeout, 10*time.Millisecond, fmt.Sprintf("%s: expected delivery to be recorded", tc.desc))
+		ts.Close()
+
+		assert.Equal(t, tc.attempts, h.requests, fmt.Sprintf("%s: expected %d requests got %d\n", tc.desc, tc.attempts, h.requests))
+
+		var body map[string]interface{}
+		err = json.Unmarshal(h.body, &body)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, msg.Channel, body["channel"], fmt.Sprintf("%s: expected channel %s got %v\n", tc.desc, msg.Channel, body["channel"]))
+		assert.Equal(t, []interface{}{map[string]interface{}{"n": "temp", "v": 42.0}}, body["payload"], fmt.Sprintf("%s: got unexpected payload %v\n", tc.desc, body["payload"]))
+		assert.Equal(t, subID, h.header.Get(webhook.SubscriptionHeader), fmt.Sprintf("%s: expected subscription header %s got %s\n", tc.desc, subID, h.header.Get(webhook.SubscriptionHeader)))
+
+		sig := ""
+		if tc.secret != "" {
+			sig = webhook.Sign(tc.secret, h.body)
+		}
+		assert.Equal(t, sig, h.header.Get(webhook.SignatureHeader), fmt.Sprintf("%s: expected signature %s got %s\n", tc.desc, sig, h.header.Get(webhook.SignatureHeader)))
+
+		saved, err := repo.Retrieve(context.Background(), subID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, saved.Delivery.Status, fmt.Sprintf("%s: expected delivery status %s got %s\n", tc.desc, tc.status, saved.Delivery.Status))
+		assert.Equal(t, tc.attempts, saved.Delivery.Attempts, fmt.Sprintf("%s: expected %d delivery attempts got %d\n", tc.desc, tc.attempts, saved.Delivery.Attempts))
+	}
+}
+
+func TestNotifyPrivateAddress(t *testing.T) {
+	h := &hook{}
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	sub := notifiers.Subscription{ID: subID, Contact: ts.URL, Topic: "topic"}
+	repo := mocks.NewRepo(map[string]notifiers.Subscription{subID: sub})
+	n := webhook.New(repo, webhook.Config{Timeout: time.Second, QueueSize: 1}, newLogger())
+
+	err := n.Notify("", []notifiers.Subscription{sub}, messaging.Message{Channel: "chan"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	var saved notifiers.Subscription
+	require.Eventually(t, func() bool {
+		saved, err = repo.Retrieve(context.Background(), subID)
+		return err == nil && saved.Delivery.Status != ""
+	}, timeout, 10*time.Millisecond, "expected delivery to be recorded")
+	assert.Equal(t, notifiers.FailedStatus, saved.Delivery.Status, fmt.Sprintf("expected delivery status %s got %s", notifiers.FailedStatus, saved.Delivery.Status))
+	assert.Contains(t, saved.Delivery.Error, httputil.ErrForbiddenAddress.Error(), fmt.Sprintf("expected error %s got %s", httputil.ErrForbiddenAddress, saved.Delivery.Error))
+	assert.Equal(t, 0, h.requests, fmt.Sprintf("expected no requests got %d", h.requests))
+}
+
+func TestNotifyQueueFull(t *testing.T) {
+	// With a single worker and no queue, at most one delivery is accepted.
+	repo := mocks.NewRepo(map[string]notifiers.Subscription{})
+	n := webhook.New(repo, webhook.Config{Timeout: time.Second}, newLogger())
+
+	sub := notifiers.Subscription{ID: subID, Contact: "http://127.0.0.1:1", Topic: "topic"}
+	err := n.Notify("", []notifiers.Subscription{sub, sub}, messaging.Message{Channel: "chan"})
+	assert.True(t, errors.Contains(err, notifiers.ErrNotify), fmt.Sprintf("expected %s got %s", notifiers.ErrNotify, err))
+}
+
+func newLogger() logger.Logger {
+	l, _ := logger.New(os.Stdout, logger.Info.String())
+	return l
+}
@@ -0,0 +1,215 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	mfjson "github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const contentType = "application/json"
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// TemplateData is passed to the template of the transform action.
+type TemplateData struct {
+	Channel   string
+	Subtopic  string
+	Publisher string
+	Protocol  string
+	Created   int64
+	// Record is the first record which satisfies the rule condition. It's
+	// senml.Message for SenML and flattened JSON object for JSON rules.
+	Record interface{}
+	// Records contains all the records of the message.
+	Records []interface{}
+}
+
+// webhookReq is the body of the webhook action request.
+type webhookReq struct {
+	Channel   string          `json:"channel"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher,omitempty"`
+	Protocol  string          `json:"protocol,omitempty"`
+	Created   int64           `json:"created"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// compile returns the function checking if the decoded record satisfies the
+// rule condition.
+func compile(rule Rule) (func(rec interface{}) bool, error) {
+	if rule.Condition == "" {
+		return func(interface{}) bool { return true }, nil
+	}
+
+	if rule.Format == JSONFormat {
+		cond, err := conditions.ParseJSONCondition(rule.Condition)
+		if err != nil {
+			return nil, err
+		}
+		return func(rec interface{}) bool {
+			obj, ok := rec.(map[string]interface{})
+			return ok && cond.Match(obj)
+		}, nil
+	}
+
+	cond, err := conditions.ParseCondition(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+	return func(rec interface{}) bool {
+		m, ok := rec.(senml.Message)
+		return ok && cond.Match(m)
+	}, nil
+}
+
+// decode returns the records of the message payload. SenML payload is
+// decoded to SenML records, while JSON object or array of objects is decoded
+// to flattened JSON objects. If the payload can't be decoded, no records are
+// returned.
+func (rs *rulesService) decode(format string, msg messaging.Message) []interface{} {
+	var records []interface{}
+
+	if format == JSONFormat {
+		var payload interface{}
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return nil
+		}
+		objs := []interface{}{payload}
+		if arr, ok := payload.([]interface{}); ok {
+			objs = arr
+		}
+		for _, o := range objs {
+			obj, ok := o.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			flat, err := mfjson.Flatten(obj)
+			if err != nil {
+				return nil
+			}
+			records = append(records, flat)
+		}
+		return records
+	}
+
+	res, err := rs.transformer.Transform(msg)
+	if err != nil {
+		return nil
+	}
+	msgs, ok := res.([]senml.Message)
+	if !ok {
+		return nil
+	}
+	for _, m := range msgs {
+		records = append(records, m)
+	}
+	return records
+}
+
+func (rs *rulesService) execute(a Action, msg messaging.Message, rec interface{}, records []interface{}) error {
+	switch a.Type {
+	case RepublishAction:
+		out := msg
+		out.Channel = a.ChannelID
+		out.Subtopic = a.Subtopic
+		out.Protocol = Protocol
+		return rs.publisher.Publish(out.Channel, out)
+	case TransformAction:
+		payload, err := transform(a.Template, msg, rec, records)
+		if err != nil {
+			return err
+		}
+		out := msg
+		out.Channel = a.ChannelID
+		out.Subtopic = a.Subtopic
+		out.Protocol = Protocol
+		out.Payload = payload
+		return rs.publisher.Publish(out.Channel, out)
+	case WebhookAction:
+		return rs.call(a.URL, msg)
+	case WriterAction:
+		// Publisher prefixes the topic, so the prefix is removed from the
+		// subject. Subtopic of the message is appended to the subject.
+		out := msg
+		out.Protocol = Protocol
+		return rs.publisher.Publish(strings.TrimPrefix(a.Subject, SubjectPrefix), out)
+	default:
+		return ErrMalformedEntity
+	}
+}
+
+func transform(text string, msg messaging.Message, rec interface{}, records []interface{}) ([]byte, error) {
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	data := TemplateData{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+		Record:    rec,
+		Records:   records,
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (rs *rulesService) call(url string, msg messaging.Message) error {
+	payload := json.RawMessage(msg.Payload)
+	if !json.Valid(payload) {
+		// Payload which is not JSON is sent as a JSON string.
+		b, err := json.Marshal(string(msg.Payload))
+		if err != nil {
+			return err
+		}
+		payload = b
+	}
+
+	body, err := json.Marshal(webhookReq{
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+		Payload:   payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := rs.client.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
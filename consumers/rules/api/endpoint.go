@@ -0,0 +1,125 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/consumers/rules"
+)
+
+func createRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		saved, err := svc.CreateRule(ctx, req.token, req.rule())
+		if err != nil {
+			return nil, err
+		}
+
+		res := toRuleRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.ViewRule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toRuleRes(rule), nil
+	}
+}
+
+func listRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := rules.PageMetadata{
+			Offset:    req.offset,
+			Limit:     req.limit,
+			ChannelID: req.channelID,
+		}
+		page, err := svc.ListRules(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := rulesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Rules: []ruleRes{},
+		}
+		for _, rule := range page.Rules {
+			res.Rules = append(res.Rules, toRuleRes(rule))
+		}
+
+		return res, nil
+	}
+}
+
+func updateRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateRule(ctx, req.token, req.rule()); err != nil {
+			return nil, err
+		}
+
+		return updateRes{}, nil
+	}
+}
+
+func removeRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveRule(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func toRuleRes(rule rules.Rule) ruleRes {
+	res := ruleRes{
+		ID:        rule.ID,
+		OwnerID:   rule.OwnerID,
+		Name:      rule.Name,
+		ChannelID: rule.ChannelID,
+		Subtopic:  rule.Subtopic,
+		Format:    rule.Format,
+		Condition: rule.Condition,
+		Actions:   []actionRes{},
+	}
+	for _, a := range rule.Actions {
+		res.Actions = append(res.Actions, actionRes(a))
+	}
+	return res
+}
@@ -0,0 +1,503 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	httpapi "github.com/mainflux/mainflux/consumers/rules/api"
+	"github.com/mainflux/mainflux/consumers/rules/mocks"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	token       = "token"
+	wrongToken  = "wrong-token"
+	email       = "user@example.com"
+	chanID      = "chan"
+	outChanID   = "out-chan"
+	otherChanID = "other-chan"
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newService() rules.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(map[string]string{chanID: email, outChanID: email, otherChanID: "other@example.com"})
+	return rules.New(auth, things, mocks.NewRuleRepository(), uuid.NewMock(), mocks.NewPublisher(), rules.WebhookConfig{Timeout: time.Second})
+}
+
+func newServer(svc rules.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+type actionReq struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Template  string `json:"template,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+type ruleReq struct {
+	Name      string      `json:"name,omitempty"`
+	ChannelID string      `json:"channel_id,omitempty"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Format    string      `json:"format,omitempty"`
+	Condition string      `json:"condition,omitempty"`
+	Actions   []actionReq `json:"actions,omitempty"`
+}
+
+type ruleRes struct {
+	ID        string      `json:"id"`
+	OwnerID   string      `json:"owner_id"`
+	Name      string      `json:"name,omitempty"`
+	ChannelID string      `json:"channel_id"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Format    string      `json:"format"`
+	Condition string      `json:"condition,omitempty"`
+	Actions   []actionReq `json:"actions"`
+}
+
+type rulesPageRes struct {
+	Total  uint64    `json:"total"`
+	Offset uint64    `json:"offset"`
+	Limit  uint64    `json:"limit"`
+	Rules  []ruleRes `json:"rules"`
+}
+
+var rule = ruleReq{
+	Name:      "Forward high temperature",
+	ChannelID: chanID,
+	Condition: `name == "temp" && v > 40`,
+	Actions: []actionReq{
+		{Type: rules.RepublishAction, ChannelID: outChanID, Subtopic: "alerts"},
+	},
+}
+
+func TestCreateRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create rule",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/rules/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create rule with invalid token",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       wrongToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule with empty token",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule of the channel owned by another user",
+			req:         toJSON(ruleReq{ChannelID: otherChanID, Actions: rule.Actions}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rule without channel",
+			req:         toJSON(ruleReq{Actions: rule.Actions}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without actions",
+			req:         toJSON(ruleReq{ChannelID: chanID}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid action",
+			req:         toJSON(ruleReq{ChannelID: chanID, Actions: []actionReq{{Type: "unknown"}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid condition",
+			req:         toJSON(ruleReq{ChannelID: chanID, Condition: "v >", Actions: rule.Actions}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid request format",
+			req:         "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid content type",
+			req:         toJSON(rule),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/rules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved, err := svc.CreateRule(nil, token, rules.Rule{
+		Name:      rule.Name,
+		ChannelID: rule.ChannelID,
+		Condition: rule.Condition,
+		Actions:   []rules.Action{rules.Action(rule.Actions[0])},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		res    ruleRes
+	}{
+		{
+			desc:   "view rule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusOK,
+			res: ruleRes{
+				ID:        saved.ID,
+				OwnerID:   email,
+				Name:      rule.Name,
+				ChannelID: chanID,
+				Format:    rules.SenMLFormat,
+				Condition: rule.Condition,
+				Actions:   rule.Actions,
+			},
+		},
+		{
+			desc:   "view non-existing rule",
+			id:     "non-existing",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view rule with invalid token",
+			id:     saved.ID,
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body ruleRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListRules(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	r := rules.Rule{
+		ChannelID: chanID,
+		Actions:   []rules.Action{rules.Action(rule.Actions[0])},
+	}
+	for i := 0; i < 5; i++ {
+		_, err := svc.CreateRule(nil, token, r)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	r.ChannelID = outChanID
+	_, err := svc.CreateRule(nil, token, r)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		total  uint64
+		size   int
+	}{
+		{
+			desc:   "list rules",
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			total:  6,
+			size:   6,
+		},
+		{
+			desc:   "list rules with limit",
+			query:  "?offset=1&limit=2",
+			token:  token,
+			status: http.StatusOK,
+			total:  6,
+			size:   2,
+		},
+		{
+			desc:   "list rules of the channel",
+			query:  fmt.Sprintf("?channel=%s", outChanID),
+			token:  token,
+			status: http.StatusOK,
+			total:  1,
+			size:   1,
+		},
+		{
+			desc:   "list rules with invalid limit",
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules with invalid offset",
+			query:  "?offset=-1",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules with invalid token",
+			query:  "",
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body rulesPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+		assert.Equal(t, tc.size, len(body.Rules), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(body.Rules)))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved, err := svc.CreateRule(nil, token, rules.Rule{
+		ChannelID: chanID,
+		Actions:   []rules.Action{rules.Action(rule.Actions[0])},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		id          string
+		req         string
+		contentType string
+		token       string
+		status      int
+	}{
+		{
+			desc:        "update rule",
+			id:          saved.ID,
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update non-existing rule",
+			id:          "non-existing",
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update rule with invalid writer subject",
+			id:          saved.ID,
+			req:         toJSON(ruleReq{ChannelID: chanID, Actions: []actionReq{{Type: rules.WriterAction, Subject: "channels.>"}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update rule with invalid token",
+			id:          saved.ID,
+			req:         toJSON(rule),
+			contentType: contentType,
+			token:       wrongToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update rule with invalid content type",
+			id:          saved.ID,
+			req:         toJSON(rule),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved, err := svc.CreateRule(nil, token, rules.Rule{
+		ChannelID: chanID,
+		Actions:   []rules.Action{rules.Action(rule.Actions[0])},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove rule with invalid token",
+			id:     saved.ID,
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove rule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed rule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
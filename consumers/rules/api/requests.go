@@ -0,0 +1,102 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const maxLimitSize = 100
+
+type actionReq struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Template  string `json:"template,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+type ruleReq struct {
+	token     string
+	id        string
+	Name      string      `json:"name,omitempty"`
+	ChannelID string      `json:"channel_id"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Format    string      `json:"format,omitempty"`
+	Condition string      `json:"condition,omitempty"`
+	Actions   []actionReq `json:"actions"`
+}
+
+func (req ruleReq) validate() error {
+	if req.token == "" {
+		return rules.ErrUnauthorizedAccess
+	}
+	if req.ChannelID == "" || len(req.Actions) == 0 {
+		return rules.ErrMalformedEntity
+	}
+	return nil
+}
+
+func (req ruleReq) rule() rules.Rule {
+	rule := rules.Rule{
+		ID:        req.id,
+		Name:      req.Name,
+		ChannelID: req.ChannelID,
+		Subtopic:  req.Subtopic,
+		Format:    req.Format,
+		Condition: req.Condition,
+	}
+	for _, a := range req.Actions {
+		rule.Actions = append(rule.Actions, rules.Action(a))
+	}
+	return rule
+}
+
+type updateRuleReq struct {
+	ruleReq
+}
+
+func (req updateRuleReq) validate() error {
+	if err := req.ruleReq.validate(); err != nil {
+		return err
+	}
+	if req.id == "" {
+		return rules.ErrMalformedEntity
+	}
+	return nil
+}
+
+type viewReq struct {
+	token string
+	id    string
+}
+
+func (req viewReq) validate() error {
+	if req.token == "" {
+		return rules.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return rules.ErrMalformedEntity
+	}
+	return nil
+}
+
+type listReq struct {
+	token     string
+	offset    uint64
+	limit     uint64
+	channelID string
+}
+
+func (req listReq) validate() error {
+	if req.token == "" {
+		return rules.ErrUnauthorizedAccess
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*ruleRes)(nil)
+	_ mainflux.Response = (*rulesPageRes)(nil)
+	_ mainflux.Response = (*updateRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type actionRes struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Template  string `json:"template,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+type ruleRes struct {
+	ID        string      `json:"id"`
+	OwnerID   string      `json:"owner_id"`
+	Name      string      `json:"name,omitempty"`
+	ChannelID string      `json:"channel_id"`
+	Subtopic  string      `json:"subtopic,omitempty"`
+	Format    string      `json:"format"`
+	Condition string      `json:"condition,omitempty"`
+	Actions   []actionRes `json:"actions"`
+	created   bool
+}
+
+func (res ruleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func (res ruleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/rules/%s", res.ID),
+		}
+	}
+	return map[string]string{}
+}
+
+func (res ruleRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type rulesPageRes struct {
+	pageRes
+	Rules []ruleRes `json:"rules"`
+}
+
+func (res rulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesPageRes) Empty() bool {
+	return false
+}
+
+type updateRes struct{}
+
+func (res updateRes) Code() int {
+	return http.StatusOK
+}
+
+func (res updateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateRes) Empty() bool {
+	return true
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules contains the domain concept definitions needed to support
+// Mainflux rules engine functionality. Rules engine evaluates the rules of
+// the channel against the consumed messages and executes the actions of the
+// satisfied rules, such as routing the message to another channel.
+package rules
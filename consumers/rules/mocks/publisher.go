@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// Publisher is the publisher mock which keeps the published messages.
+type Publisher struct {
+	mu     sync.Mutex
+	topics []string
+	msgs   []messaging.Message
+}
+
+// NewPublisher returns the publisher mock.
+func NewPublisher() *Publisher {
+	return &Publisher{}
+}
+
+// Publish stores the message and the topic it's published to.
+func (pub *Publisher) Publish(topic string, msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.topics = append(pub.topics, topic)
+	pub.msgs = append(pub.msgs, msg)
+	return nil
+}
+
+// Published returns the topics and the messages published to them, and
+// removes them from the mock.
+func (pub *Publisher) Published() ([]string, []messaging.Message) {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	topics, msgs := pub.topics, pub.msgs
+	pub.topics, pub.msgs = nil, nil
+	return topics, msgs
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+)
+
+var _ rules.RuleRepository = (*ruleRepositoryMock)(nil)
+
+type ruleRepositoryMock struct {
+	mu    sync.Mutex
+	rules map[string]rules.Rule
+}
+
+// NewRuleRepository creates in-memory rule repository.
+func NewRuleRepository() rules.RuleRepository {
+	return &ruleRepositoryMock{
+		rules: make(map[string]rules.Rule),
+	}
+}
+
+func (rrm *ruleRepositoryMock) Save(_ context.Context, rule rules.Rule) (string, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.rules[rule.ID]; ok {
+		return "", rules.ErrConflict
+	}
+	rrm.rules[rule.ID] = rule
+	return rule.ID, nil
+}
+
+func (rrm *ruleRepositoryMock) Retrieve(_ context.Context, owner, id string) (rules.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rule, ok := rrm.rules[id]
+	if !ok || rule.OwnerID != owner {
+		return rules.Rule{}, rules.ErrNotFound
+	}
+	return rule, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveAll(_ context.Context, owner string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var items []rules.Rule
+	for _, rule := range rrm.rules {
+		if rule.OwnerID != owner || (pm.ChannelID != "" && rule.ChannelID != pm.ChannelID) {
+			continue
+		}
+		items = append(items, rule)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	start := int(pm.Offset)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + int(pm.Limit)
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return rules.RulesPage{
+		PageMetadata: pm,
+		Total:        uint64(len(items)),
+		Rules:        items[start:end],
+	}, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByChannel(_ context.Context, chanID string) ([]rules.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var items []rules.Rule
+	for _, rule := range rrm.rules {
+		if rule.ChannelID == chanID {
+			items = append(items, rule)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+func (rrm *ruleRepositoryMock) Update(_ context.Context, rule rules.Rule) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if r, ok := rrm.rules[rule.ID]; !ok || r.OwnerID != rule.OwnerID {
+		return rules.ErrNotFound
+	}
+	rrm.rules[rule.ID] = rule
+	return nil
+}
+
+func (rrm *ruleRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if rule, ok := rrm.rules[id]; ok && rule.OwnerID == owner {
+		delete(rrm.rules, id)
+	}
+	return nil
+}
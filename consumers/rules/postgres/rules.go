@@ -0,0 +1,266 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	errDuplicate  = "unique_violation"
+	errInvalid    = "invalid_text_representation"
+	errTruncation = "string_data_right_truncation"
+
+	ruleColumns = `id, owner_id, name, channel_id, subtopic, format, condition, actions`
+)
+
+var _ rules.RuleRepository = (*ruleRepository)(nil)
+
+type ruleRepository struct {
+	db Database
+}
+
+// New instantiates a PostgreSQL implementation of rule repository.
+func New(db Database) rules.RuleRepository {
+	return &ruleRepository{
+		db: db,
+	}
+}
+
+func (rr ruleRepository) Save(ctx context.Context, rule rules.Rule) (string, error) {
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return "", errors.Wrap(rules.ErrSave, err)
+	}
+
+	q := fmt.Sprintf(`INSERT INTO rules (%s)
+	VALUES (:id, :owner_id, :name, :channel_id, :subtopic, :format, :condition, :actions)`, ruleColumns)
+	if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return "", errors.Wrap(rules.ErrConflict, err)
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(rules.ErrMalformedEntity, err)
+			}
+		}
+		return "", errors.Wrap(rules.ErrSave, err)
+	}
+
+	return rule.ID, nil
+}
+
+func (rr ruleRepository) Retrieve(ctx context.Context, owner, id string) (rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE owner_id = $1 AND id = $2`, ruleColumns)
+
+	var dbr dbRule
+	if err := rr.db.GetContext(ctx, &dbr, q, owner, id); err != nil {
+		if err == sql.ErrNoRows {
+			return rules.Rule{}, errors.Wrap(rules.ErrNotFound, err)
+		}
+		return rules.Rule{}, errors.Wrap(rules.ErrSelectEntity, err)
+	}
+
+	rule, err := fromDBRule(dbr)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(rules.ErrSelectEntity, err)
+	}
+	return rule, nil
+}
+
+func (rr ruleRepository) RetrieveAll(ctx context.Context, owner string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	params := map[string]interface{}{
+		"owner_id":   owner,
+		"channel_id": pm.ChannelID,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+	cond := `owner_id = :owner_id`
+	if pm.ChannelID != "" {
+		cond = fmt.Sprintf("%s AND channel_id = :channel_id", cond)
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE %s ORDER BY id LIMIT :limit OFFSET :offset`, ruleColumns, cond)
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return rules.RulesPage{}, errors.Wrap(rules.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanRules(rows)
+	if err != nil {
+		return rules.RulesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM rules WHERE %s`, cond)
+	total, err := total(ctx, rr.db, cq, params)
+	if err != nil {
+		return rules.RulesPage{}, errors.Wrap(rules.ErrSelectEntity, err)
+	}
+
+	return rules.RulesPage{
+		PageMetadata: pm,
+		Total:        total,
+		Rules:        items,
+	}, nil
+}
+
+func (rr ruleRepository) RetrieveByChannel(ctx context.Context, chanID string) ([]rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE channel_id = $1 ORDER BY id`, ruleColumns)
+
+	rows, err := rr.db.QueryxContext(ctx, q, chanID)
+	if err != nil {
+		return nil, errors.Wrap(rules.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	return scanRules(rows)
+}
+
+func (rr ruleRepository) Update(ctx context.Context, rule rules.Rule) error {
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return errors.Wrap(rules.ErrUpdateEntity, err)
+	}
+
+	q := `UPDATE rules SET name = :name, channel_id = :channel_id, subtopic = :subtopic, format = :format,
+	condition = :condition, actions = :actions WHERE owner_id = :owner_id AND id = :id`
+	res, err := rr.db.NamedExecContext(ctx, q, dbr)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(rules.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(rules.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(rules.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return rules.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr ruleRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM rules WHERE owner_id = :owner_id AND id = :id`
+
+	dbr := dbRule{
+		ID:      id,
+		OwnerID: owner,
+	}
+	if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+		return errors.Wrap(rules.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func scanRules(rows *sqlx.Rows) ([]rules.Rule, error) {
+	var items []rules.Rule
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(rules.ErrSelectEntity, err)
+		}
+		rule, err := fromDBRule(dbr)
+		if err != nil {
+			return nil, errors.Wrap(rules.ErrSelectEntity, err)
+		}
+		items = append(items, rule)
+	}
+	return items, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+type dbRule struct {
+	ID        string `db:"id"`
+	OwnerID   string `db:"owner_id"`
+	Name      string `db:"name"`
+	ChannelID string `db:"channel_id"`
+	Subtopic  string `db:"subtopic"`
+	Format    string `db:"format"`
+	Condition string `db:"condition"`
+	Actions   []byte `db:"actions"`
+}
+
+type dbAction struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Template  string `json:"template,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Subject   string `json:"subject,omitempty"`
+}
+
+func toDBRule(rule rules.Rule) (dbRule, error) {
+	actions := []dbAction{}
+	for _, a := range rule.Actions {
+		actions = append(actions, dbAction(a))
+	}
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return dbRule{}, err
+	}
+
+	return dbRule{
+		ID:        rule.ID,
+		OwnerID:   rule.OwnerID,
+		Name:      rule.Name,
+		ChannelID: rule.ChannelID,
+		Subtopic:  rule.Subtopic,
+		Format:    rule.Format,
+		Condition: rule.Condition,
+		Actions:   data,
+	}, nil
+}
+
+func fromDBRule(dbr dbRule) (rules.Rule, error) {
+	var actions []dbAction
+	if err := json.Unmarshal(dbr.Actions, &actions); err != nil {
+		return rules.Rule{}, err
+	}
+
+	rule := rules.Rule{
+		ID:        dbr.ID,
+		OwnerID:   dbr.OwnerID,
+		Name:      dbr.Name,
+		ChannelID: dbr.ChannelID,
+		Subtopic:  dbr.Subtopic,
+		Format:    dbr.Format,
+		Condition: dbr.Condition,
+	}
+	for _, a := range actions {
+		rule.Actions = append(rule.Actions, rules.Action(a))
+	}
+	return rule, nil
+}
@@ -0,0 +1,251 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/consumers/rules/postgres"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numRules = 10
+
+func newRule(t *testing.T, owner, chanID string) rules.Rule {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return rules.Rule{
+		ID:        id,
+		OwnerID:   owner,
+		Name:      "Forward high temperature",
+		ChannelID: chanID,
+		Subtopic:  "devices.*",
+		Format:    rules.SenMLFormat,
+		Condition: `name == "temp" && v > 40`,
+		Actions: []rules.Action{
+			{Type: rules.RepublishAction, ChannelID: "out", Subtopic: "alerts"},
+			{Type: rules.WebhookAction, URL: "https://example.com/hook"},
+		},
+	}
+}
+
+func TestRuleSave(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	rule := newRule(t, owner, "chan")
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		id   string
+		err  error
+	}{
+		{
+			desc: "save rule",
+			rule: rule,
+			id:   rule.ID,
+			err:  nil,
+		},
+		{
+			desc: "save duplicate rule",
+			rule: rule,
+			id:   "",
+			err:  rules.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := repo.Save(context.Background(), tc.rule)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRuleRetrieve(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	rule := newRule(t, owner, "chan")
+	_, err = repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		rule  rules.Rule
+		err   error
+	}{
+		{
+			desc:  "retrieve rule",
+			owner: owner,
+			id:    rule.ID,
+			rule:  rule,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve rule of another owner",
+			owner: "another",
+			id:    rule.ID,
+			err:   rules.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing rule",
+			owner: owner,
+			id:    "non-existing",
+			err:   rules.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.Retrieve(context.Background(), tc.owner, tc.id)
+		assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, r))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRuleRetrieveAll(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < numRules; i++ {
+		ch := chanID
+		if i%2 == 0 {
+			ch = "other"
+		}
+		_, err := repo.Save(context.Background(), newRule(t, owner, ch))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		pm    rules.PageMetadata
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "retrieve all rules",
+			pm:    rules.PageMetadata{Limit: numRules},
+			total: numRules,
+			size:  numRules,
+		},
+		{
+			desc:  "retrieve page of rules",
+			pm:    rules.PageMetadata{Offset: 8, Limit: 5},
+			total: numRules,
+			size:  2,
+		},
+		{
+			desc:  "retrieve rules of the channel",
+			pm:    rules.PageMetadata{Limit: numRules, ChannelID: chanID},
+			total: numRules / 2,
+			size:  numRules / 2,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), owner, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.Rules, tc.size, fmt.Sprintf("%s: expected %d rules got %d\n", tc.desc, tc.size, len(page.Rules)))
+	}
+
+	rs, err := repo.RetrieveByChannel(context.Background(), chanID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve by channel: got unexpected error: %s\n", err))
+	assert.Len(t, rs, numRules/2, fmt.Sprintf("retrieve by channel: expected %d rules got %d\n", numRules/2, len(rs)))
+}
+
+func TestRuleUpdate(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	rule := newRule(t, owner, "chan")
+	_, err = repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	updated := rule
+	updated.Format = rules.JSONFormat
+	updated.Condition = `temp > 50`
+	updated.Actions = []rules.Action{{Type: rules.WriterAction, Subject: "channels.archive"}}
+
+	foreign := updated
+	foreign.OwnerID = "another"
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "update rule",
+			rule: updated,
+			err:  nil,
+		},
+		{
+			desc: "update rule of another owner",
+			rule: foreign,
+			err:  rules.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	r, err := repo.Retrieve(context.Background(), owner, rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve updated rule: got unexpected error: %s\n", err))
+	assert.Equal(t, updated, r, fmt.Sprintf("retrieve updated rule: expected %v got %v\n", updated, r))
+}
+
+func TestRuleRemove(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	rule := newRule(t, owner, "chan")
+	_, err = repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove rule of another owner",
+			owner: "another",
+			id:    rule.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove rule",
+			owner: owner,
+			id:    rule.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = repo.Retrieve(context.Background(), owner, rule.ID)
+	assert.True(t, errors.Contains(err, rules.ErrNotFound), fmt.Sprintf("retrieve removed rule: expected %s got %s\n", rules.ErrNotFound, err))
+}
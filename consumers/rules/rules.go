@@ -0,0 +1,190 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/mainflux/mainflux/pkg/conditions"
+)
+
+// Supported formats of the message payload.
+const (
+	SenMLFormat = "senml"
+	JSONFormat  = "json"
+)
+
+// Supported types of rule actions.
+const (
+	// RepublishAction publishes the message to another channel.
+	RepublishAction = "republish"
+
+	// TransformAction publishes the message with the payload built from
+	// the template to another channel.
+	TransformAction = "transform"
+
+	// WebhookAction posts the message to the URL.
+	WebhookAction = "webhook"
+
+	// WriterAction publishes the message to the message broker subject,
+	// which is consumed by the writer configured to listen to it.
+	WriterAction = "writer"
+)
+
+// SubjectPrefix is the prefix of all the message broker subjects the
+// messages are published to.
+const SubjectPrefix = "channels."
+
+// Rule represents the rule evaluated against the messages published to the
+// channel. Actions are executed for every message which satisfies the rule
+// condition.
+type Rule struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	ChannelID string
+	// Subtopic limits the rule to the messages published to the matching
+	// subtopic. It may contain `*` and `>` wildcards. Messages of all the
+	// subtopics are evaluated if empty.
+	Subtopic string
+	// Format specifies whether the payload is SenML or JSON.
+	Format string
+	// Condition is optional. If it's not set, the rule is satisfied by
+	// every message of the channel.
+	Condition string
+	Actions   []Action
+}
+
+// Action represents the action executed when the rule is satisfied.
+type Action struct {
+	Type string
+	// ChannelID and Subtopic are the destination of republish and
+	// transform actions.
+	ChannelID string
+	Subtopic  string
+	// Template is the text/template used to build the payload of the
+	// transform action.
+	Template string
+	// URL is the endpoint the webhook action posts the message to.
+	URL string
+	// Subject is the message broker subject of the writer action.
+	Subject string
+}
+
+// RulesPage contains page related metadata as well as list of rules that
+// belong to this page.
+type RulesPage struct {
+	PageMetadata
+	Total uint64
+	Rules []Rule
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset    uint64
+	Limit     uint64
+	ChannelID string
+}
+
+// RuleRepository specifies a rule persistence API.
+type RuleRepository interface {
+	// Save persists the rule. Successful operation is indicated by non-nil
+	// error response.
+	Save(ctx context.Context, rule Rule) (string, error)
+
+	// Retrieve retrieves the rule having the provided identifier, that is
+	// owned by the specified user.
+	Retrieve(ctx context.Context, owner, id string) (Rule, error)
+
+	// RetrieveAll retrieves the subset of rules owned by the specified user.
+	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (RulesPage, error)
+
+	// RetrieveByChannel retrieves all the rules of the channel.
+	RetrieveByChannel(ctx context.Context, chanID string) ([]Rule, error)
+
+	// Update updates the rule owned by the specified user.
+	Update(ctx context.Context, rule Rule) error
+
+	// Remove removes the rule having the provided identifier, that is owned
+	// by the specified user.
+	Remove(ctx context.Context, owner, id string) error
+}
+
+// Validate returns an error if the rule is not valid.
+func (rule Rule) Validate() error {
+	if rule.ChannelID == "" || len(rule.Actions) == 0 {
+		return ErrMalformedEntity
+	}
+	if rule.Subtopic != "" && !conditions.ValidTopic(rule.Subtopic) {
+		return ErrMalformedEntity
+	}
+	if rule.Format != SenMLFormat && rule.Format != JSONFormat {
+		return ErrMalformedEntity
+	}
+	if _, err := compile(rule); err != nil {
+		return err
+	}
+	for _, a := range rule.Actions {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the action is not valid.
+func (a Action) Validate() error {
+	switch a.Type {
+	case RepublishAction:
+		if a.ChannelID == "" {
+			return ErrMalformedEntity
+		}
+	case TransformAction:
+		if a.ChannelID == "" || a.Template == "" {
+			return ErrMalformedEntity
+		}
+		if _, err := parseTemplate(a.Template); err != nil {
+			return ErrMalformedEntity
+		}
+	case WebhookAction:
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrMalformedEntity
+		}
+	case WriterAction:
+		if !validSubject(a.Subject) {
+			return ErrMalformedEntity
+		}
+	default:
+		return ErrMalformedEntity
+	}
+	return nil
+}
+
+// SubjectChannel returns the channel ID of the writer action subject, i.e.
+// the first token following the SubjectPrefix.
+func SubjectChannel(subject string) string {
+	return strings.SplitN(strings.TrimPrefix(subject, SubjectPrefix), ".", 2)[0]
+}
+
+// validSubject checks if the subject is a valid message broker subject
+// without wildcards, starting with the SubjectPrefix.
+func validSubject(subject string) bool {
+	if !strings.HasPrefix(subject, SubjectPrefix) {
+		return false
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(subject, SubjectPrefix), ".") {
+		if token == "" || strings.ContainsAny(token, "*> ") {
+			return false
+		}
+	}
+	return true
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("payload").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
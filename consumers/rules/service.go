@@ -0,0 +1,299 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// Protocol is set as the protocol of the messages published by the rules
+// engine. Messages having this protocol are not evaluated, so the rules
+// can't form feedback loops.
+const Protocol = "rules"
+
+var (
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrCreateID indicates error in creating id for entity creation.
+	ErrCreateID = errors.New("failed to create id")
+
+	// ErrNotFound indicates a non-existent entity request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrConflict indicates that entity already exists.
+	ErrConflict = errors.New("entity already exists")
+
+	// ErrSave indicates error saving entity.
+	ErrSave = errors.New("failed to save entity")
+
+	// ErrSelectEntity indicates problem with scanning data from db.
+	ErrSelectEntity = errors.New("failed to select entity")
+
+	// ErrUpdateEntity indicates error updating entity.
+	ErrUpdateEntity = errors.New("failed to update entity")
+
+	// ErrRemoveEntity indicates error in removing entity.
+	ErrRemoveEntity = errors.New("failed to remove entity")
+
+	// ErrMessage indicates an error converting a message to Mainflux message.
+	ErrMessage = errors.New("failed to convert to Mainflux message")
+
+	// ErrAction indicates failure to execute the rule action.
+	ErrAction = errors.New("failed to execute rule action")
+)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateRule creates the rule of the channel owned by the user
+	// identified by the provided key. Destination channels of the rule
+	// actions have to be owned by the user as well.
+	CreateRule(ctx context.Context, token string, rule Rule) (Rule, error)
+
+	// ViewRule retrieves the rule having the provided identifier.
+	ViewRule(ctx context.Context, token, id string) (Rule, error)
+
+	// ListRules retrieves the rules owned by the user.
+	ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error)
+
+	// UpdateRule updates the rule owned by the user.
+	UpdateRule(ctx context.Context, token string, rule Rule) error
+
+	// RemoveRule removes the rule having the provided identifier.
+	RemoveRule(ctx context.Context, token, id string) error
+
+	consumers.Consumer
+}
+
+var _ Service = (*rulesService)(nil)
+
+type rulesService struct {
+	auth        mainflux.AuthServiceClient
+	things      mainflux.ThingsServiceClient
+	rules       RuleRepository
+	idp         mainflux.IDProvider
+	publisher   messaging.Publisher
+	client      *http.Client
+	transformer transformers.Transformer
+}
+
+// WebhookConfig represents webhook actions configuration.
+type WebhookConfig struct {
+	// Timeout is the timeout of the webhook request.
+	Timeout time.Duration
+	// AllowPrivate allows requests to loopback, private and link-local
+	// addresses, which are refused by default.
+	AllowPrivate bool
+}
+
+// New instantiates the rules service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, rules RuleRepository, idp mainflux.IDProvider, pub messaging.Publisher, webhook WebhookConfig) Service {
+	// Webhook URLs are provided by the users, so they must not be able
+	// to reach the internal services.
+	client := httputil.NewPublicClient(webhook.Timeout)
+	if webhook.AllowPrivate {
+		client = &http.Client{Timeout: webhook.Timeout}
+	}
+
+	return &rulesService{
+		auth:        auth,
+		things:      things,
+		rules:       rules,
+		idp:         idp,
+		publisher:   pub,
+		client:      client,
+		transformer: senml.New(senml.JSON),
+	}
+}
+
+func (rs *rulesService) CreateRule(ctx context.Context, token string, rule Rule) (Rule, error) {
+	res, err := rs.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+	if rule.Format == "" {
+		rule.Format = SenMLFormat
+	}
+	if err := rule.Validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := rs.authorize(ctx, res.GetEmail(), rule); err != nil {
+		return Rule{}, err
+	}
+
+	rule.ID, err = rs.idp.ID()
+	if err != nil {
+		return Rule{}, errors.Wrap(ErrCreateID, err)
+	}
+	rule.OwnerID = res.GetId()
+
+	if _, err := rs.rules.Save(ctx, rule); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+func (rs *rulesService) ViewRule(ctx context.Context, token, id string) (Rule, error) {
+	res, err := rs.identify(ctx, token)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return rs.rules.Retrieve(ctx, res.GetId(), id)
+}
+
+func (rs *rulesService) ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error) {
+	res, err := rs.identify(ctx, token)
+	if err != nil {
+		return RulesPage{}, err
+	}
+
+	return rs.rules.RetrieveAll(ctx, res.GetId(), pm)
+}
+
+func (rs *rulesService) UpdateRule(ctx context.Context, token string, rule Rule) error {
+	res, err := rs.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if rule.Format == "" {
+		rule.Format = SenMLFormat
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if err := rs.authorize(ctx, res.GetEmail(), rule); err != nil {
+		return err
+	}
+
+	rule.OwnerID = res.GetId()
+	return rs.rules.Update(ctx, rule)
+}
+
+func (rs *rulesService) RemoveRule(ctx context.Context, token, id string) error {
+	res, err := rs.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return rs.rules.Remove(ctx, res.GetId(), id)
+}
+
+func (rs *rulesService) identify(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return res, nil
+}
+
+// authorize checks if the user owns both the rule channel and the
+// destination channels of the rule actions. Writer action subject is
+// the channel subject as well, so its channel has to be owned too.
+func (rs *rulesService) authorize(ctx context.Context, owner string, rule Rule) error {
+	channels := map[string]bool{rule.ChannelID: true}
+	for _, a := range rule.Actions {
+		switch a.Type {
+		case RepublishAction, TransformAction:
+			channels[a.ChannelID] = true
+		case WriterAction:
+			channels[SubjectChannel(a.Subject)] = true
+		}
+	}
+
+	for ch := range channels {
+		if _, err := rs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: ch}); err != nil {
+			return errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+	}
+	return nil
+}
+
+func (rs *rulesService) Consume(message interface{}) error {
+	msg, ok := message.(messaging.Message)
+	if !ok {
+		return ErrMessage
+	}
+	if msg.Protocol == Protocol {
+		return nil
+	}
+
+	rules, err := rs.rules.RetrieveByChannel(context.Background(), msg.Channel)
+	if err != nil {
+		return err
+	}
+
+	// Payload is decoded at most once per format.
+	decoded := map[string][]interface{}{}
+	var errs error
+	for _, rule := range rules {
+		if rule.Subtopic != "" && !conditions.MatchTopic(rule.Subtopic, msg.Subtopic) {
+			continue
+		}
+
+		records, ok := decoded[rule.Format]
+		if !ok {
+			records = rs.decode(rule.Format, msg)
+			decoded[rule.Format] = records
+		}
+
+		if err := rs.evaluate(rule, msg, records); err != nil {
+			errs = err
+		}
+	}
+
+	return errs
+}
+
+// evaluate executes the actions of the rule if any of the records satisfies
+// its condition. Rules without condition are satisfied by every message,
+// even the one with the payload that can't be decoded.
+func (rs *rulesService) evaluate(rule Rule, msg messaging.Message, records []interface{}) error {
+	if rule.Condition == "" {
+		var rec interface{}
+		if len(records) > 0 {
+			rec = records[0]
+		}
+		return rs.executeAll(rule, msg, rec, records)
+	}
+
+	match, err := compile(rule)
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if match(rec) {
+			return rs.executeAll(rule, msg, rec, records)
+		}
+	}
+
+	return nil
+}
+
+// executeAll executes all the actions of the rule, even if some of them fail.
+func (rs *rulesService) executeAll(rule Rule, msg messaging.Message, rec interface{}, records []interface{}) error {
+	var errs error
+	for _, a := range rule.Actions {
+		if err := rs.execute(a, msg, rec, records); err != nil {
+			errs = errors.Wrap(ErrAction, err)
+		}
+	}
+	return errs
+}
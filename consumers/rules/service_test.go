@@ -0,0 +1,418 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	"github.com/mainflux/mainflux/consumers/rules/mocks"
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token       = "token"
+	wrongToken  = "wrong-token"
+	email       = "user@example.com"
+	otherToken  = "other-token"
+	otherEmail  = "other@example.com"
+	chanID      = "chan"
+	outChanID   = "out-chan"
+	otherChanID = "other-chan"
+	archiveID   = "archive"
+)
+
+var rule = rules.Rule{
+	Name:      "Forward high temperature",
+	ChannelID: chanID,
+	Condition: `name == "temp" && v > 40`,
+	Actions: []rules.Action{
+		{Type: rules.RepublishAction, ChannelID: outChanID, Subtopic: "alerts"},
+	},
+}
+
+func newService(pub messaging.Publisher) rules.Service {
+	return newServiceWithWebhook(pub, rules.WebhookConfig{Timeout: time.Second, AllowPrivate: true})
+}
+
+func newServiceWithWebhook(pub messaging.Publisher, webhook rules.WebhookConfig) rules.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := mocks.NewThingsService(map[string]string{chanID: email, outChanID: email, archiveID: email, otherChanID: otherEmail})
+	return rules.New(auth, things, mocks.NewRuleRepository(), uuid.NewMock(), pub, webhook)
+}
+
+func message(subtopic, payload string) messaging.Message {
+	return messaging.Message{
+		Channel:   chanID,
+		Subtopic:  subtopic,
+		Publisher: "thing",
+		Protocol:  "http",
+		Payload:   []byte(payload),
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+
+	invalidSubject := rule
+	invalidSubject.Actions = []rules.Action{{Type: rules.WriterAction, Subject: "writers.influx"}}
+
+	invalidTemplate := rule
+	invalidTemplate.Actions = []rules.Action{{Type: rules.TransformAction, ChannelID: outChanID, Template: "{{.Record"}}
+
+	invalidURL := rule
+	invalidURL.Actions = []rules.Action{{Type: rules.WebhookAction, URL: "ftp://example.com"}}
+
+	jsonRule := rule
+	jsonRule.Format = rules.JSONFormat
+	jsonRule.Condition = `sensor/temp > 40`
+
+	foreignTarget := rule
+	foreignTarget.Actions = []rules.Action{{Type: rules.RepublishAction, ChannelID: otherChanID}}
+
+	foreignSubject := rule
+	foreignSubject.Actions = []rules.Action{{Type: rules.WriterAction, Subject: rules.SubjectPrefix + otherChanID + ".archive"}}
+
+	cases := []struct {
+		desc   string
+		token  string
+		rule   rules.Rule
+		format string
+		err    error
+	}{
+		{
+			desc:   "create rule",
+			token:  token,
+			rule:   rule,
+			format: rules.SenMLFormat,
+			err:    nil,
+		},
+		{
+			desc:   "create rule over JSON fields",
+			token:  token,
+			rule:   jsonRule,
+			format: rules.JSONFormat,
+			err:    nil,
+		},
+		{
+			desc:  "create rule with invalid token",
+			token: wrongToken,
+			rule:  rule,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule of the channel owned by another user",
+			token: otherToken,
+			rule:  rule,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule republishing to the channel owned by another user",
+			token: token,
+			rule:  foreignTarget,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule writing to the subject of the channel owned by another user",
+			token: token,
+			rule:  foreignSubject,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "create rule with invalid writer subject",
+			token: token,
+			rule:  invalidSubject,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with invalid template",
+			token: token,
+			rule:  invalidTemplate,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with invalid webhook URL",
+			token: token,
+			rule:  invalidURL,
+			err:   rules.ErrMalformedEntity,
+		},
+		{
+			desc:  "create rule with SenML condition over JSON fields",
+			token: token,
+			rule:  rules.Rule{ChannelID: chanID, Condition: `sensor/temp > 40`, Actions: rule.Actions},
+			err:   conditions.ErrInvalidCondition,
+		},
+		{
+			desc:  "create rule without actions",
+			token: token,
+			rule:  rules.Rule{ChannelID: chanID},
+			err:   rules.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := svc.CreateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected non-empty ID\n", tc.desc))
+		assert.Equal(t, email, saved.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, email, saved.OwnerID))
+		assert.Equal(t, tc.format, saved.Format, fmt.Sprintf("%s: expected format %s got %s\n", tc.desc, tc.format, saved.Format))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := saved
+	updated.Condition = `name == "temp" && v > 50`
+
+	foreign := saved
+	foreign.Actions = []rules.Action{{Type: rules.TransformAction, ChannelID: otherChanID, Template: "{}"}}
+
+	nonExisting := saved
+	nonExisting.ID = "non-existing"
+
+	cases := []struct {
+		desc  string
+		token string
+		rule  rules.Rule
+		err   error
+	}{
+		{
+			desc:  "update rule",
+			token: token,
+			rule:  updated,
+			err:   nil,
+		},
+		{
+			desc:  "update rule transforming to the channel owned by another user",
+			token: token,
+			rule:  foreign,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "update non-existing rule",
+			token: token,
+			rule:  nonExisting,
+			err:   rules.ErrNotFound,
+		},
+		{
+			desc:  "update rule with invalid token",
+			token: wrongToken,
+			rule:  updated,
+			err:   rules.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	r, err := svc.ViewRule(context.Background(), token, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, updated.Condition, r.Condition, fmt.Sprintf("expected condition %s got %s\n", updated.Condition, r.Condition))
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc := newService(mocks.NewPublisher())
+	saved, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.RemoveRule(context.Background(), wrongToken, saved.ID)
+	assert.True(t, errors.Contains(err, rules.ErrUnauthorizedAccess), fmt.Sprintf("remove rule with invalid token: expected %s got %s\n", rules.ErrUnauthorizedAccess, err))
+
+	err = svc.RemoveRule(context.Background(), token, saved.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove rule: unexpected error %s\n", err))
+
+	_, err = svc.ViewRule(context.Background(), token, saved.ID)
+	assert.True(t, errors.Contains(err, rules.ErrNotFound), fmt.Sprintf("view removed rule: expected %s got %s\n", rules.ErrNotFound, err))
+}
+
+func TestConsume(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc := newService(pub)
+
+	_, err := svc.CreateRule(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = svc.CreateRule(context.Background(), token, rules.Rule{
+		ChannelID: chanID,
+		Subtopic:  "devices.*",
+		Format:    rules.JSONFormat,
+		Condition: `sensor/hum >= 80`,
+		Actions: []rules.Action{
+			{Type: rules.TransformAction, ChannelID: outChanID, Template: `{"humidity":{{index .Record "sensor/hum"}},"publisher":{{json .Publisher}}}`},
+			{Type: rules.WriterAction, Subject: rules.SubjectPrefix + archiveID},
+		},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		msg      messaging.Message
+		topics   []string
+		subtopic string
+		payload  string
+		protocol string
+	}{
+		{
+			desc:     "consume SenML message satisfying the condition",
+			msg:      message("", `[{"n":"hum","v":20},{"n":"temp","v":45}]`),
+			topics:   []string{outChanID},
+			subtopic: "alerts",
+			payload:  `[{"n":"hum","v":20},{"n":"temp","v":45}]`,
+			protocol: rules.Protocol,
+		},
+		{
+			desc:    "consume SenML message not satisfying the condition",
+			msg:     message("", `[{"n":"temp","v":25}]`),
+			payload: "",
+		},
+		{
+			desc:     "consume JSON message satisfying the condition",
+			msg:      message("devices.d1", `{"sensor":{"hum":85}}`),
+			topics:   []string{outChanID, archiveID},
+			subtopic: "",
+			payload:  `{"humidity":85,"publisher":"thing"}`,
+			protocol: rules.Protocol,
+		},
+		{
+			desc:    "consume JSON message of the unmatched subtopic",
+			msg:     message("devices.d1.raw", `{"sensor":{"hum":85}}`),
+			payload: "",
+		},
+		{
+			desc: "consume message published by the rules engine",
+			msg: messaging.Message{
+				Channel:  chanID,
+				Protocol: rules.Protocol,
+				Payload:  []byte(`[{"n":"temp","v":45}]`),
+			},
+			payload: "",
+		},
+		{
+			desc:    "consume message of the channel without rules",
+			msg:     messaging.Message{Channel: otherChanID, Payload: []byte(`[{"n":"temp","v":45}]`)},
+			payload: "",
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+
+		topics, msgs := pub.Published()
+		assert.Equal(t, tc.topics, topics, fmt.Sprintf("%s: expected topics %v got %v\n", tc.desc, tc.topics, topics))
+		if len(msgs) == 0 {
+			continue
+		}
+		assert.Equal(t, tc.subtopic, msgs[0].Subtopic, fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, tc.subtopic, msgs[0].Subtopic))
+		assert.Equal(t, tc.payload, string(msgs[0].Payload), fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.payload, msgs[0].Payload))
+		assert.Equal(t, tc.protocol, msgs[0].Protocol, fmt.Sprintf("%s: expected protocol %s got %s\n", tc.desc, tc.protocol, msgs[0].Protocol))
+	}
+}
+
+func TestConsumeWebhook(t *testing.T) {
+	type hookReq struct {
+		Channel   string          `json:"channel"`
+		Subtopic  string          `json:"subtopic"`
+		Publisher string          `json:"publisher"`
+		Payload   json.RawMessage `json:"payload"`
+	}
+	reqs := make(chan hookReq, 1)
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req hookReq
+		json.Unmarshal(body, &req)
+		reqs <- req
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	svc := newService(mocks.NewPublisher())
+	_, err := svc.CreateRule(context.Background(), token, rules.Rule{
+		ChannelID: chanID,
+		Actions:   []rules.Action{{Type: rules.WebhookAction, URL: ts.URL}},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		msg     messaging.Message
+		status  int
+		payload string
+		err     error
+	}{
+		{
+			desc:    "call webhook with JSON payload",
+			msg:     message("room1", `{"temp":45}`),
+			status:  http.StatusOK,
+			payload: `{"temp":45}`,
+			err:     nil,
+		},
+		{
+			desc:    "call webhook with binary payload",
+			msg:     message("room1", "on"),
+			status:  http.StatusNoContent,
+			payload: `"on"`,
+			err:     nil,
+		},
+		{
+			desc:    "call failing webhook",
+			msg:     message("room1", `{"temp":45}`),
+			status:  http.StatusInternalServerError,
+			payload: `{"temp":45}`,
+			err:     rules.ErrAction,
+		},
+	}
+
+	for _, tc := range cases {
+		status = tc.status
+		err := svc.Consume(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		req := <-reqs
+		assert.Equal(t, chanID, req.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, chanID, req.Channel))
+		assert.Equal(t, tc.msg.Subtopic, req.Subtopic, fmt.Sprintf("%s: expected subtopic %s got %s\n", tc.desc, tc.msg.Subtopic, req.Subtopic))
+		assert.Equal(t, tc.payload, string(req.Payload), fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, tc.payload, req.Payload))
+	}
+}
+
+func TestConsumeWebhookPrivateAddress(t *testing.T) {
+	called := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	svc := newServiceWithWebhook(mocks.NewPublisher(), rules.WebhookConfig{Timeout: time.Second})
+	_, err := svc.CreateRule(context.Background(), token, rules.Rule{
+		ChannelID: chanID,
+		Actions:   []rules.Action{{Type: rules.WebhookAction, URL: ts.URL}},
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Consume(message("room1", `{"temp":45}`))
+	assert.True(t, errors.Contains(err, rules.ErrAction), fmt.Sprintf("expected %s got %s", rules.ErrAction, err))
+	assert.False(t, called, "expected webhook on loopback address not to be called")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/consumers/rules"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveRuleOp               = "save_rule"
+	retrieveRuleOp           = "retrieve_rule"
+	retrieveAllRulesOp       = "retrieve_all_rules"
+	retrieveRulesByChannelOp = "retrieve_rules_by_channel"
+	updateRuleOp             = "update_rule"
+	removeRuleOp             = "remove_rule"
+)
+
+var _ rules.RuleRepository = (*ruleRepositoryMiddleware)(nil)
+
+type ruleRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   rules.RuleRepository
+}
+
+// RuleRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func RuleRepositoryMiddleware(tracer opentracing.Tracer, repo rules.RuleRepository) rules.RuleRepository {
+	return ruleRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (rrm ruleRepositoryMiddleware) Save(ctx context.Context, rule rules.Rule) (string, error) {
+	span := createSpan(ctx, rrm.tracer, saveRuleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Save(ctx, rule)
+}
+
+func (rrm ruleRepositoryMiddleware) Retrieve(ctx context.Context, owner, id string) (rules.Rule, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveRuleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Retrieve(ctx, owner, id)
+}
+
+func (rrm ruleRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveAllRulesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func (rrm ruleRepositoryMiddleware) RetrieveByChannel(ctx context.Context, chanID string) ([]rules.Rule, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveRulesByChannelOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.RetrieveByChannel(ctx, chanID)
+}
+
+func (rrm ruleRepositoryMiddleware) Update(ctx context.Context, rule rules.Rule) error {
+	span := createSpan(ctx, rrm.tracer, updateRuleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Update(ctx, rule)
+}
+
+func (rrm ruleRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
+	span := createSpan(ctx, rrm.tracer, removeRuleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Remove(ctx, owner, id)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
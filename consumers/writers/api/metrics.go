@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/writers"
 )
 
 var _ consumers.Consumer = (*metricsMiddleware)(nil)
@@ -35,3 +36,37 @@ func (mm *metricsMiddleware) Consume(msgs interface{}) error {
 	}(time.Now())
 	return mm.consumer.Consume(msgs)
 }
+
+var _ consumers.Consumer = (*bufferMetricsMiddleware)(nil)
+
+type bufferMetricsMiddleware struct {
+	fill    metrics.Gauge
+	latency metrics.Histogram
+	buffer  writers.Buffer
+}
+
+// BufferMetricsMiddleware returns new message repository with Save method
+// wrapped to expose the number of buffered messages. The number is updated
+// on every consumed message and after every flush, whose duration is
+// observed by the flush latency histogram.
+func BufferMetricsMiddleware(buffer writers.Buffer, fill metrics.Gauge, latency metrics.Histogram) consumers.Consumer {
+	bm := &bufferMetricsMiddleware{
+		fill:    fill,
+		latency: latency,
+		buffer:  buffer,
+	}
+	buffer.OnFlush(bm.flushed)
+	return bm
+}
+
+func (bm *bufferMetricsMiddleware) Consume(msgs interface{}) error {
+	defer func() {
+		bm.fill.Set(float64(bm.buffer.Len()))
+	}()
+	return bm.buffer.Consume(msgs)
+}
+
+func (bm *bufferMetricsMiddleware) flushed(_ int, elapsed time.Duration) {
+	bm.latency.Observe(elapsed.Seconds())
+	bm.fill.Set(float64(bm.buffer.Len()))
+}
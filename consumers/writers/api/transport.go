@@ -10,14 +10,21 @@ import (
 
 	"github.com/go-zoo/bone"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	dlapi "github.com/mainflux/mainflux/consumers/deadletter/api"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // MakeHandler returns a HTTP API handler with version and metrics.
-func MakeHandler(svcName string) http.Handler {
+// If dead letter service is provided, dead letter API is exposed as well.
+func MakeHandler(svcName string, dls deadletter.Service) http.Handler {
 	r := bone.New()
 	r.GetFunc("/version", mainflux.Version(svcName))
 	r.Handle("/metrics", promhttp.Handler())
 
+	if dls != nil {
+		dlapi.MakeHandler(dls, r)
+	}
+
 	return r
 }
@@ -0,0 +1,320 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const defCapacityFactor = 10
+
+var errClosed = errors.New("buffer closed")
+
+// Buffer represents consumer which accumulates messages and
+// passes them to the underlying consumer in batches.
+type Buffer interface {
+	consumers.Consumer
+
+	// Len returns the number of buffered messages.
+	Len() int
+
+	// Close flushes the buffered messages and stops the buffer.
+	Close() error
+
+	// OnFlush registers the function which is called after every flush
+	// with the number of flushed messages and the flush duration.
+	OnFlush(func(n int, elapsed time.Duration))
+}
+
+// BufferConfig represents batching configuration.
+type BufferConfig struct {
+	// BatchSize is the number of buffered messages which triggers flush.
+	BatchSize int
+	// MaxLatency is the maximum time messages are kept in the buffer.
+	MaxLatency time.Duration
+	// Capacity is the maximum number of buffered messages. Consume
+	// blocks once it's reached, until the buffered messages are taken
+	// to be flushed. Defaults to ten batches.
+	Capacity int
+}
+
+var _ Buffer = (*buffer)(nil)
+
+// entry is a buffered message together with its transformed form.
+type entry struct {
+	msg  messaging.Message
+	data interface{}
+}
+
+type buffer struct {
+	consumer    consumers.Consumer
+	transformer transformers.Transformer
+	dl          consumers.DeadLetterConfig
+	cfg         BufferConfig
+	logger      log.Logger
+
+	mu      sync.Mutex
+	space   *sync.Cond
+	entries []entry
+	size    int
+	oldest  time.Time
+	closed  bool
+	onFlush func(int, time.Duration)
+
+	// err is the error of the flush on close.
+	err error
+
+	flush chan struct{}
+	done  chan struct{}
+}
+
+// NewBuffer returns buffer which receives the messages, transforms them and
+// flushes them to the consumer once batch size is reached or the oldest
+// message exceeds max latency. If the batch fails, its messages are consumed
+// one by one, so that a single message can't block the others. Messages which
+// still fail are dead-lettered if dead-lettering is configured, or dropped.
+func NewBuffer(consumer consumers.Consumer, transformer transformers.Transformer, cfg BufferConfig, dl consumers.DeadLetterConfig, logger log.Logger) Buffer {
+	if cfg.Capacity < cfg.BatchSize {
+		cfg.Capacity = defCapacityFactor * cfg.BatchSize
+	}
+
+	b := &buffer{
+		consumer:    consumer,
+		transformer: transformer,
+		dl:          dl,
+		cfg:         cfg,
+		logger:      logger,
+		flush:       make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	b.space = sync.NewCond(&b.mu)
+	go b.run()
+
+	return b
+}
+
+func (b *buffer) Consume(msg interface{}) error {
+	m, ok := msg.(messaging.Message)
+	if !ok || b.transformer == nil {
+		return b.consumer.Consume(msg)
+	}
+
+	data, err := b.transformer.Transform(m)
+	if err != nil {
+		// Transformation is deterministic, so there is no point in retrying it.
+		return consumers.PublishDeadLetter(b.dl, m, err, 0)
+	}
+	n := count(data)
+	if n < 0 {
+		// Unknown messages can't be merged into a batch.
+		return b.consumer.Consume(data)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Messages are not dropped when the buffer is full. Instead, the
+	// caller is blocked, so that the backpressure reaches the broker.
+	for !b.closed && b.size > 0 && b.size+n > b.cfg.Capacity {
+		b.signal()
+		b.space.Wait()
+	}
+	if b.closed {
+		return errClosed
+	}
+	if len(b.entries) == 0 {
+		b.oldest = time.Now()
+	}
+	b.entries = append(b.entries, entry{msg: m, data: data})
+	b.size += n
+
+	if b.size >= b.cfg.BatchSize {
+		b.signal()
+	}
+
+	return nil
+}
+
+func (b *buffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.size
+}
+
+func (b *buffer) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return errClosed
+	}
+	b.closed = true
+	b.signal()
+	b.space.Broadcast()
+	b.mu.Unlock()
+
+	<-b.done
+	return b.err
+}
+
+func (b *buffer) OnFlush(f func(n int, elapsed time.Duration)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.onFlush = f
+}
+
+func (b *buffer) signal() {
+	select {
+	case b.flush <- struct{}{}:
+	default:
+	}
+}
+
+func (b *buffer) run() {
+	defer close(b.done)
+
+	timer := time.NewTimer(b.cfg.MaxLatency)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-b.flush:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-timer.C:
+		}
+
+		b.mu.Lock()
+		closed := b.closed
+		due := len(b.entries) > 0 && (closed || b.size >= b.cfg.BatchSize || time.Since(b.oldest) >= b.cfg.MaxLatency)
+		b.mu.Unlock()
+
+		if due {
+			err := b.flushAll()
+			if err != nil {
+				b.logger.Error(fmt.Sprintf("Failed to flush buffered messages: %s", err))
+			}
+			if closed {
+				b.err = err
+			}
+		}
+		if closed {
+			return
+		}
+
+		// Wake up once the oldest buffered message exceeds max latency.
+		wait := b.cfg.MaxLatency
+		b.mu.Lock()
+		if len(b.entries) > 0 {
+			wait = time.Until(b.oldest.Add(b.cfg.MaxLatency))
+		}
+		b.mu.Unlock()
+		timer.Reset(wait)
+	}
+}
+
+// flushAll passes the buffered messages to the consumer merged into
+// batches. Messages of the failed batches are consumed one by one.
+func (b *buffer) flushAll() error {
+	start := time.Now()
+	b.mu.Lock()
+	entries, n := b.entries, b.size
+	b.entries, b.size = nil, 0
+	onFlush := b.onFlush
+	b.space.Broadcast()
+	b.mu.Unlock()
+
+	if onFlush != nil {
+		defer func() {
+			onFlush(n, time.Since(start))
+		}()
+	}
+
+	var sm []senml.Message
+	var smEntries []entry
+	jm := make(map[string][]json.Message)
+	jmEntries := make(map[string][]entry)
+	for _, e := range entries {
+		switch data := e.data.(type) {
+		case []senml.Message:
+			sm = append(sm, data...)
+			smEntries = append(smEntries, e)
+		case json.Messages:
+			jm[data.Format] = append(jm[data.Format], data.Data...)
+			jmEntries[data.Format] = append(jmEntries[data.Format], e)
+		}
+	}
+
+	dropped := 0
+	if len(sm) > 0 {
+		if err := b.consumer.Consume(sm); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to consume batch of %d messages: %s", len(sm), err))
+			dropped += b.consumeEach(smEntries)
+		}
+	}
+	for format, msgs := range jm {
+		if err := b.consumer.Consume(json.Messages{Data: msgs, Format: format}); err != nil {
+			b.logger.Warn(fmt.Sprintf("Failed to consume batch of %d messages: %s", len(msgs), err))
+			dropped += b.consumeEach(jmEntries[format])
+		}
+	}
+
+	if dropped > 0 {
+		return fmt.Errorf("dropped %d messages", dropped)
+	}
+	return nil
+}
+
+// consumeEach consumes the entries separately and dead-letters the ones
+// which fail. It returns the number of entries which are dropped.
+func (b *buffer) consumeEach(entries []entry) int {
+	retries := 0
+	if b.dl.Publisher != nil {
+		retries = b.dl.Retries
+	}
+
+	dropped := 0
+	for _, e := range entries {
+		err := b.consumer.Consume(e.data)
+		for i := 0; err != nil && i < retries; i++ {
+			err = b.consumer.Consume(e.data)
+		}
+		if err == nil {
+			continue
+		}
+		if err := consumers.PublishDeadLetter(b.dl, e.msg, err, retries); err != nil {
+			b.logger.Error(fmt.Sprintf("Failed to consume message from channel %s: %s", e.msg.Channel, err))
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// count returns the number of transformed messages, or -1 if they
+// can't be batched.
+func count(data interface{}) int {
+	switch m := data.(type) {
+	case []senml.Message:
+		return len(m)
+	case json.Messages:
+		return len(m.Data)
+	default:
+		return -1
+	}
+}
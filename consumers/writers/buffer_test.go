@@ -0,0 +1,294 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	"github.com/mainflux/mainflux/consumers/writers"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers/json"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	batchSize = 10
+	retries   = 2
+	timeout   = time.Second
+	invalid   = "invalid"
+	poison    = "poison"
+)
+
+var (
+	errConsume   = errors.New("failed to consume")
+	errTransform = errors.New("failed to transform")
+)
+
+// transformer transforms the payload to the name of a single SenML
+// message, or to a single JSON message if subtopic is set.
+type transformer struct{}
+
+func (transformer) Transform(msg messaging.Message) (interface{}, error) {
+	if string(msg.Payload) == invalid {
+		return nil, errTransform
+	}
+	if msg.Subtopic != "" {
+		return json.Messages{Format: msg.Subtopic, Data: []json.Message{{Channel: msg.Channel}}}, nil
+	}
+	return []senml.Message{{Channel: msg.Channel, Name: string(msg.Payload)}}, nil
+}
+
+// consumer fails the batches which contain poison message. If gate
+// is set, consuming blocks until it's closed.
+type consumer struct {
+	mu      sync.Mutex
+	gate    chan struct{}
+	batches []interface{}
+	count   int
+}
+
+func (c *consumer) Consume(msgs interface{}) error {
+	if c.gate != nil {
+		<-c.gate
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch m := msgs.(type) {
+	case []senml.Message:
+		for _, msg := range m {
+			if msg.Name == poison {
+				return errConsume
+			}
+		}
+		c.count += len(m)
+	case json.Messages:
+		c.count += len(m.Data)
+	}
+	c.batches = append(c.batches, msgs)
+	return nil
+}
+
+func (c *consumer) stats() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.batches), c.count
+}
+
+func newLogger() log.Logger {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+	return logger
+}
+
+func newMessage(payload string) messaging.Message {
+	return messaging.Message{Channel: "1", Payload: []byte(payload), Created: time.Now().UnixNano()}
+}
+
+func TestBufferBatchSize(t *testing.T) {
+	c := &consumer{}
+	buf := writers.NewBuffer(c, transformer{}, writers.BufferConfig{BatchSize: batchSize, MaxLatency: time.Hour}, consumers.DeadLetterConfig{}, newLogger())
+
+	for i := 0; i < batchSize-1; i++ {
+		err := buf.Consume(newMessage(fmt.Sprintf("%d", i)))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	assert.Equal(t, batchSize-1, buf.Len(), fmt.Sprintf("expected %d buffered messages got %d", batchSize-1, buf.Len()))
+	batches, _ := c.stats()
+	assert.Equal(t, 0, batches, "expected no flush before batch size is reached")
+
+	err := buf.Consume(newMessage("last"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Eventually(t, func() bool {
+		batches, count := c.stats()
+		return batches == 1 && count == batchSize
+	}, timeout, 10*time.Millisecond, "expected single batch once batch size is reached")
+	assert.Equal(t, 0, buf.Len(), fmt.Sprintf("expected empty buffer got %d messages", buf.Len()))
+}
+
+func TestBufferMaxLatency(t *testing.T) {
+	c := &consumer{}
+	latency := 200 * time.Millisecond
+	buf := writers.NewBuffer(c, transformer{}, writers.BufferConfig{BatchSize: batchSize, MaxLatency: latency}, consumers.DeadLetterConfig{}, newLogger())
+
+	// Let the flush interval elapse partially, so that the flush
+	// depends on the age of the oldest message.
+	time.Sleep(latency / 2)
+	start := time.Now()
+	err := buf.Consume(newMessage("senml"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	msg := newMessage("{}")
+	msg.Subtopic = "format"
+	err = buf.Consume(msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	assert.Eventually(t, func() bool {
+		batches, count := c.stats()
+		return batches == 2 && count == 2
+	}, timeout, 10*time.Millisecond, "expected messages to be flushed after max latency")
+	assert.True(t, time.Since(start) >= latency, "expected messages to be kept in buffer until max latency")
+}
+
+func TestBufferFull(t *testing.T) {
+	c := &consumer{gate: make(chan struct{})}
+	cfg := writers.BufferConfig{BatchSize: 2, MaxLatency: time.Hour, Capacity: 2}
+	buf := writers.NewBuffer(c, transformer{}, cfg, consumers.DeadLetterConfig{}, newLogger())
+
+	var flushed int32
+	buf.OnFlush(func(n int, _ time.Duration) {
+		atomic.AddInt32(&flushed, int32(n))
+	})
+
+	// Fill the buffer while the first batch is being flushed.
+	for i := 0; i < 2*cfg.Capacity; i++ {
+		err := buf.Consume(newMessage(fmt.Sprintf("%d", i)))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if i == cfg.Capacity-1 {
+			require.Eventually(t, func() bool { return buf.Len() == 0 }, timeout, 10*time.Millisecond, "expected flush to start")
+		}
+	}
+
+	consumed := make(chan error)
+	go func() {
+		consumed <- buf.Consume(newMessage("full"))
+	}()
+
+	select {
+	case err := <-consumed:
+		assert.Fail(t, fmt.Sprintf("expected full buffer to block, got %v", err))
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once the first flush completes, the buffered messages are taken to be
+	// flushed and the blocked message is accepted.
+	close(c.gate)
+	select {
+	case err := <-consumed:
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	case <-time.After(timeout):
+		assert.Fail(t, "expected buffer to accept message after flush")
+	}
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&flushed) >= int32(2*cfg.Capacity)
+	}, timeout, 10*time.Millisecond, "expected flushes to be reported")
+}
+
+func TestBufferFullClose(t *testing.T) {
+	c := &consumer{gate: make(chan struct{})}
+	cfg := writers.BufferConfig{BatchSize: 2, MaxLatency: time.Hour, Capacity: 2}
+	buf := writers.NewBuffer(c, transformer{}, cfg, consumers.DeadLetterConfig{}, newLogger())
+
+	for i := 0; i < 2*cfg.Capacity; i++ {
+		err := buf.Consume(newMessage(fmt.Sprintf("%d", i)))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if i == cfg.Capacity-1 {
+			require.Eventually(t, func() bool { return buf.Len() == 0 }, timeout, 10*time.Millisecond, "expected flush to start")
+		}
+	}
+
+	consumed := make(chan error)
+	go func() {
+		consumed <- buf.Consume(newMessage("full"))
+	}()
+
+	closed := make(chan error)
+	go func() {
+		closed <- buf.Close()
+	}()
+
+	select {
+	case err := <-consumed:
+		assert.NotNil(t, err, "expected error consuming with closed buffer")
+	case <-time.After(timeout):
+		assert.Fail(t, "expected close to unblock consuming with full buffer")
+	}
+
+	close(c.gate)
+	select {
+	case <-closed:
+	case <-time.After(timeout):
+		assert.Fail(t, "expected buffer to close")
+	}
+}
+
+func TestBufferDeadLetter(t *testing.T) {
+	c := &consumer{}
+	dlq := mocks.NewRepository()
+	dl := consumers.DeadLetterConfig{Publisher: dlq, Consumer: "writer", Retries: retries}
+	buf := writers.NewBuffer(c, transformer{}, writers.BufferConfig{BatchSize: batchSize, MaxLatency: time.Hour}, dl, newLogger())
+
+	msgs := []messaging.Message{newMessage(poison), newMessage(invalid)}
+	for i := 0; i < batchSize-1; i++ {
+		msgs = append(msgs, newMessage(fmt.Sprintf("%d", i)))
+	}
+	for _, msg := range msgs {
+		err := buf.Consume(msg)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	// Poison message fails the batch, so the batch messages are
+	// consumed one by one and only the poison one is dead-lettered.
+	assert.Eventually(t, func() bool {
+		_, count := c.stats()
+		return count == batchSize-1
+	}, timeout, 10*time.Millisecond, "expected valid messages to be consumed")
+
+	page, err := dlq.RetrieveAll(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, uint64(2), page.Total, fmt.Sprintf("expected 2 dead letters got %d", page.Total))
+
+	cases := []struct {
+		desc    string
+		msg     messaging.Message
+		retries int
+	}{
+		{
+			desc:    "dead-letter message failed to transform",
+			msg:     msgs[1],
+			retries: 0,
+		},
+		{
+			desc:    "dead-letter message failed to consume",
+			msg:     msgs[0],
+			retries: retries,
+		},
+	}
+
+	for i, tc := range cases {
+		l := page.Letters[i]
+		assert.Equal(t, tc.msg, l.Message, fmt.Sprintf("%s: expected message %v got %v", tc.desc, tc.msg, l.Message))
+		assert.Equal(t, tc.retries, l.Retries, fmt.Sprintf("%s: expected %d retries got %d", tc.desc, tc.retries, l.Retries))
+	}
+}
+
+func TestBufferClose(t *testing.T) {
+	c := &consumer{}
+	buf := writers.NewBuffer(c, transformer{}, writers.BufferConfig{BatchSize: batchSize, MaxLatency: time.Hour}, consumers.DeadLetterConfig{}, newLogger())
+
+	err := buf.Consume(newMessage("pending"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = buf.Consume(newMessage(poison))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = buf.Close()
+	assert.NotNil(t, err, "expected error closing buffer with message which can't be consumed")
+	_, count := c.stats()
+	assert.Equal(t, 1, count, "expected pending messages to be flushed on close")
+
+	err = buf.Consume(newMessage("closed"))
+	assert.NotNil(t, err, "expected error consuming with closed buffer")
+}
@@ -37,6 +37,7 @@ func (as *adapterService) Publish(ctx context.Context, token string, msg messagi
 	ar := &mainflux.AccessByKeyReq{
 		Token:  token,
 		ChanID: msg.Channel,
+		Action: messaging.PublishAction,
 	}
 	thid, err := as.things.CanAccessByKey(ctx, ar)
 	if err != nil {
@@ -0,0 +1,90 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package httputil
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// ErrForbiddenAddress indicates that the request targets a loopback,
+// private, link-local or otherwise non-public address.
+var ErrForbiddenAddress = errors.New("forbidden destination address")
+
+var forbiddenNets = parseCIDRs(
+	"0.0.0.0/8",      // "This" network
+	"10.0.0.0/8",     // Private
+	"100.64.0.0/10",  // Carrier-grade NAT
+	"127.0.0.0/8",    // Loopback
+	"169.254.0.0/16", // Link-local, including cloud metadata services
+	"172.16.0.0/12",  // Private
+	"192.168.0.0/16", // Private
+	"224.0.0.0/4",    // Multicast
+	"240.0.0.0/4",    // Reserved and broadcast
+	"::/128",         // Unspecified
+	"::1/128",        // Loopback
+	"fc00::/7",       // Unique local
+	"fe80::/10",      // Link-local
+	"ff00::/8",       // Multicast
+)
+
+// NewPublicClient returns HTTP client which refuses to connect to the
+// loopback, private, link-local and other non-public addresses, so that
+// the user provided URLs can't be used to reach the internal services.
+// Addresses are checked after the host name is resolved, which covers
+// redirects and DNS records pointing to the internal addresses as well.
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			return checkAddress(address)
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// Proxy is not used, since the proxy address would be checked
+			// instead of the destination one.
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
+
+func checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(ErrForbiddenAddress, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errors.Wrap(ErrForbiddenAddress, fmt.Errorf("invalid IP address %s", host))
+	}
+	for _, n := range forbiddenNets {
+		if n.Contains(ip) {
+			return errors.Wrap(ErrForbiddenAddress, fmt.Errorf("%s", ip))
+		}
+	}
+
+	return nil
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package httputil_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	client := httputil.NewPublicClient(time.Second)
+
+	cases := []struct {
+		desc string
+		url  string
+	}{
+		{
+			desc: "request loopback address",
+			url:  ts.URL,
+		},
+		{
+			desc: "request localhost",
+			url:  strings.Replace(ts.URL, "127.0.0.1", "localhost", 1),
+		},
+		{
+			desc: "request link-local address",
+			url:  "http://169.254.169.254/latest/meta-data",
+		},
+		{
+			desc: "request private address",
+			url:  "http://10.0.0.1",
+		},
+		{
+			desc: "request IPv4-mapped IPv6 loopback address",
+			url:  strings.Replace(ts.URL, "127.0.0.1", "[::ffff:127.0.0.1]", 1),
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := client.Get(tc.url)
+		if res != nil {
+			res.Body.Close()
+		}
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+		if err != nil {
+			assert.Contains(t, err.Error(), httputil.ErrForbiddenAddress.Error(), fmt.Sprintf("%s: expected %s got %s", tc.desc, httputil.ErrForbiddenAddress, err))
+		}
+	}
+}
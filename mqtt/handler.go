@@ -86,7 +86,7 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 		return errNilTopicPub
 	}
 
-	return h.authAccess(c.Username, *topic)
+	return h.authAccess(c.Username, *topic, messaging.PublishAction)
 }
 
 // AuthSubscribe is called on device publish,
@@ -100,7 +100,7 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 	}
 
 	for _, v := range *topics {
-		if err := h.authAccess(c.Username, v); err != nil {
+		if err := h.authAccess(c.Username, v, messaging.SubscribeAction); err != nil {
 			return err
 		}
 
@@ -189,7 +189,7 @@ func (h *handler) Disconnect(c *session.Client) {
 	}
 }
 
-func (h *handler) authAccess(username string, topic string) error {
+func (h *handler) authAccess(username, topic, action string) error {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.Match([]byte(topic)) {
@@ -203,7 +203,7 @@ func (h *handler) authAccess(username string, topic string) error {
 	}
 
 	chanID := channelParts[1]
-	return h.auth.Authorize(context.Background(), chanID, username)
+	return h.auth.Authorize(context.Background(), chanID, username, action)
 }
 
 func parseSubtopic(subtopic string) (string, error) {
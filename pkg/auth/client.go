@@ -12,7 +12,12 @@ import (
 
 // Client represents Auth cache.
 type Client interface {
-	Authorize(ctx context.Context, chanID, thingID string) error
+	// Authorize checks if the thing is connected to the channel by the
+	// connection that allows the action (publish or subscribe).
+	Authorize(ctx context.Context, chanID, thingID, action string) error
+
+	// Identify returns ID of the thing identified by the provided key. The
+	// previous key of the thing is resolved until its grace period expires.
 	Identify(ctx context.Context, thingKey string) (string, error)
 }
 
@@ -51,14 +56,15 @@ func (c client) Identify(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) Authorize(ctx context.Context, chanID, thingID string) error {
-	if c.redisClient.SIsMember(ctx, chanPrefix+":"+chanID, thingID).Val() {
+func (c client) Authorize(ctx context.Context, chanID, thingID, action string) error {
+	if c.redisClient.SIsMember(ctx, chanPrefix+":"+chanID+":"+action, thingID).Val() {
 		return nil
 	}
 
 	ar := &mainflux.AccessByIDReq{
 		ThingID: thingID,
 		ChanID:  chanID,
+		Action:  action,
 	}
 	_, err := c.thingsClient.CanAccessByID(ctx, ar)
 	return err
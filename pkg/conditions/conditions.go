@@ -0,0 +1,510 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// ErrInvalidCondition indicates malformed condition.
+var ErrInvalidCondition = errors.New("invalid condition")
+
+// Condition represents a parsed condition over SenML record fields.
+type Condition interface {
+	// Match checks if the SenML record satisfies the condition.
+	Match(msg senml.Message) bool
+}
+
+// JSONCondition represents a parsed condition over the fields of a flattened
+// JSON object, such as the one produced by the JSON transformer.
+type JSONCondition interface {
+	// Match checks if the flattened JSON object satisfies the condition.
+	Match(obj map[string]interface{}) bool
+}
+
+// lookup returns the value of the field, or false if the field is not set.
+type lookup func(field string) (interface{}, bool)
+
+// expr is a node of the parsed condition.
+type expr interface {
+	eval(get lookup) bool
+}
+
+// Record fields available in conditions. Both SenML and descriptive
+// names of the fields can be used, e.g. `n` and `name`.
+var (
+	numFields = map[string]func(m senml.Message) *float64{
+		"v":           func(m senml.Message) *float64 { return m.Value },
+		"value":       func(m senml.Message) *float64 { return m.Value },
+		"s":           func(m senml.Message) *float64 { return m.Sum },
+		"sum":         func(m senml.Message) *float64 { return m.Sum },
+		"t":           func(m senml.Message) *float64 { return &m.Time },
+		"time":        func(m senml.Message) *float64 { return &m.Time },
+		"ut":          func(m senml.Message) *float64 { return &m.UpdateTime },
+		"update_time": func(m senml.Message) *float64 { return &m.UpdateTime },
+	}
+	strFields = map[string]func(m senml.Message) *string{
+		"n":            func(m senml.Message) *string { return &m.Name },
+		"name":         func(m senml.Message) *string { return &m.Name },
+		"u":            func(m senml.Message) *string { return &m.Unit },
+		"unit":         func(m senml.Message) *string { return &m.Unit },
+		"vs":           func(m senml.Message) *string { return m.StringValue },
+		"string_value": func(m senml.Message) *string { return m.StringValue },
+		"vd":           func(m senml.Message) *string { return m.DataValue },
+		"data_value":   func(m senml.Message) *string { return m.DataValue },
+		"publisher":    func(m senml.Message) *string { return &m.Publisher },
+		"subtopic":     func(m senml.Message) *string { return &m.Subtopic },
+		"protocol":     func(m senml.Message) *string { return &m.Protocol },
+	}
+	boolFields = map[string]func(m senml.Message) *bool{
+		"vb":         func(m senml.Message) *bool { return m.BoolValue },
+		"bool_value": func(m senml.Message) *bool { return m.BoolValue },
+	}
+)
+
+// ParseCondition parses the condition expression such as
+// `name == "temp" && v > 40`. Expression consists of comparisons of record
+// fields with literals, combined using `&&`, `||`, `!` and parentheses.
+// Numeric fields support all the comparison operators, while string and
+// boolean fields can only be compared using `==` and `!=`. Comparison of the
+// field that is not set in the record is never satisfied.
+func ParseCondition(cond string) (Condition, error) {
+	e, err := parse(cond, senmlComparison)
+	if err != nil {
+		return nil, err
+	}
+	return senmlCondition{e}, nil
+}
+
+// ParseJSONCondition parses the condition expression over the fields of a
+// flattened JSON object, such as `sensor/temp > 40 && status == "on"`. Syntax
+// is the same as in ParseCondition, but since the fields are not known in
+// advance, the type of the field is determined by the literal it's compared
+// to. Nested fields are referred to by their flattened keys, joined with `/`.
+// Comparison of the field that is missing or has a different type is never
+// satisfied.
+func ParseJSONCondition(cond string) (JSONCondition, error) {
+	e, err := parse(cond, jsonComparison)
+	if err != nil {
+		return nil, err
+	}
+	return jsonCondition{e}, nil
+}
+
+func parse(cond string, check checkFunc) (expr, error) {
+	tokens, err := tokenize(cond)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCondition, err)
+	}
+
+	p := parser{tokens: tokens, check: check}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCondition, err)
+	}
+	if !p.done() {
+		return nil, errors.Wrap(ErrInvalidCondition, fmt.Errorf("unexpected %q", p.peek().text))
+	}
+
+	return e, nil
+}
+
+type senmlCondition struct {
+	e expr
+}
+
+func (c senmlCondition) Match(msg senml.Message) bool {
+	return c.e.eval(func(field string) (interface{}, bool) {
+		if get, ok := numFields[field]; ok {
+			if v := get(msg); v != nil {
+				return *v, true
+			}
+			return nil, false
+		}
+		if get, ok := strFields[field]; ok {
+			if v := get(msg); v != nil {
+				return *v, true
+			}
+			return nil, false
+		}
+		if get, ok := boolFields[field]; ok {
+			if v := get(msg); v != nil {
+				return *v, true
+			}
+		}
+		return nil, false
+	})
+}
+
+type jsonCondition struct {
+	e expr
+}
+
+func (c jsonCondition) Match(obj map[string]interface{}) bool {
+	return c.e.eval(func(field string) (interface{}, bool) {
+		v, ok := obj[field]
+		return v, ok
+	})
+}
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	numberToken
+	stringToken
+	opToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")"}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expr) && expr[end] != '"'; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			s, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: stringToken, text: s})
+			i = end + 1
+		case unicode.IsDigit(c) || c == '-' || c == '.':
+			end := i + 1
+			for ; end < len(expr); end++ {
+				d := rune(expr[end])
+				if !unicode.IsDigit(d) && d != '.' && d != 'e' && d != 'E' &&
+					!((d == '-' || d == '+') && (expr[end-1] == 'e' || expr[end-1] == 'E')) {
+					break
+				}
+			}
+			tokens = append(tokens, token{kind: numberToken, text: expr[i:end]})
+			i = end
+		case unicode.IsLetter(c) || c == '_':
+			end := i + 1
+			for ; end < len(expr); end++ {
+				d := rune(expr[end])
+				if !unicode.IsLetter(d) && !unicode.IsDigit(d) && d != '_' && d != '/' {
+					break
+				}
+			}
+			tokens = append(tokens, token{kind: identToken, text: expr[i:end]})
+			i = end
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(expr[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+			tokens = append(tokens, token{kind: opToken, text: op})
+			i += len(op)
+		}
+	}
+
+	return tokens, nil
+}
+
+// checkFunc validates the comparison of the field with the literal.
+type checkFunc func(field, op string, lit token) error
+
+type parser struct {
+	tokens []token
+	pos    int
+	check  checkFunc
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, fmt.Errorf("unexpected end of condition")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+func (p *parser) acceptOp(op string) bool {
+	if t := p.peek(); t.kind == opToken && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.acceptOp("!") {
+		cond, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{cond}, nil
+	}
+	if p.acceptOp("(") {
+		cond, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptOp(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return cond, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if field.kind != identToken {
+		return nil, fmt.Errorf("expected field, got %q", field.text)
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if op.kind != opToken || !comparison(op.text) {
+		return nil, fmt.Errorf("expected comparison operator, got %q", op.text)
+	}
+	lit, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.check(field.text, op.text, lit); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case lit.kind == numberToken:
+		val, err := strconv.ParseFloat(lit.text, 64)
+		if err != nil {
+			return nil, err
+		}
+		return numCondition{field: field.text, op: op.text, val: val}, nil
+	case lit.kind == stringToken:
+		return strCondition{field: field.text, eq: op.text == "==", val: lit.text}, nil
+	default:
+		return boolCondition{field: field.text, eq: op.text == "==", val: lit.text == "true"}, nil
+	}
+}
+
+// senmlComparison allows comparisons of known SenML record fields with the
+// literals of the matching type.
+func senmlComparison(field, op string, lit token) error {
+	if _, ok := numFields[field]; ok {
+		if lit.kind != numberToken {
+			return fmt.Errorf("field %s must be compared to a number", field)
+		}
+		return nil
+	}
+
+	if op != "==" && op != "!=" {
+		return fmt.Errorf("field %s supports only == and != operators", field)
+	}
+	if _, ok := strFields[field]; ok {
+		if lit.kind != stringToken {
+			return fmt.Errorf("field %s must be compared to a string", field)
+		}
+		return nil
+	}
+	if _, ok := boolFields[field]; ok {
+		if !boolLiteral(lit) {
+			return fmt.Errorf("field %s must be compared to a boolean", field)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown field %s", field)
+}
+
+// jsonComparison allows comparisons of any field with number, string and
+// boolean literals.
+func jsonComparison(field, op string, lit token) error {
+	switch {
+	case lit.kind == numberToken:
+		return nil
+	case lit.kind != stringToken && !boolLiteral(lit):
+		return fmt.Errorf("field %s must be compared to a number, string or boolean", field)
+	case op != "==" && op != "!=":
+		return fmt.Errorf("field %s supports only == and != operators", field)
+	default:
+		return nil
+	}
+}
+
+func boolLiteral(lit token) bool {
+	return lit.kind == identToken && (lit.text == "true" || lit.text == "false")
+}
+
+func comparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	default:
+		return false
+	}
+}
+
+type andCondition struct {
+	left, right expr
+}
+
+func (c andCondition) eval(get lookup) bool {
+	return c.left.eval(get) && c.right.eval(get)
+}
+
+type orCondition struct {
+	left, right expr
+}
+
+func (c orCondition) eval(get lookup) bool {
+	return c.left.eval(get) || c.right.eval(get)
+}
+
+type notCondition struct {
+	cond expr
+}
+
+func (c notCondition) eval(get lookup) bool {
+	return !c.cond.eval(get)
+}
+
+type numCondition struct {
+	field string
+	op    string
+	val   float64
+}
+
+func (c numCondition) eval(get lookup) bool {
+	f, ok := get(c.field)
+	if !ok {
+		return false
+	}
+	v, ok := f.(float64)
+	if !ok {
+		return false
+	}
+	switch c.op {
+	case "==":
+		return v == c.val
+	case "!=":
+		return v != c.val
+	case "<":
+		return v < c.val
+	case "<=":
+		return v <= c.val
+	case ">":
+		return v > c.val
+	case ">=":
+		return v >= c.val
+	default:
+		return false
+	}
+}
+
+type strCondition struct {
+	field string
+	eq    bool
+	val   string
+}
+
+func (c strCondition) eval(get lookup) bool {
+	f, ok := get(c.field)
+	if !ok {
+		return false
+	}
+	v, ok := f.(string)
+	if !ok {
+		return false
+	}
+	return (v == c.val) == c.eq
+}
+
+type boolCondition struct {
+	field string
+	eq    bool
+	val   bool
+}
+
+func (c boolCondition) eval(get lookup) bool {
+	f, ok := get(c.field)
+	if !ok {
+		return false
+	}
+	v, ok := f.(bool)
+	if !ok {
+		return false
+	}
+	return (v == c.val) == c.eq
+}
@@ -0,0 +1,205 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+	v := 42.0
+	vb := true
+	vs := "on"
+	msg := senml.Message{
+		Name:        "temp",
+		Unit:        "Cel",
+		Publisher:   "publisher",
+		Time:        1600000000,
+		Value:       &v,
+		BoolValue:   &vb,
+		StringValue: &vs,
+	}
+
+	cases := []struct {
+		desc  string
+		expr  string
+		match bool
+		err   error
+	}{
+		{
+			desc:  "parse comparison of name",
+			expr:  `name == "temp"`,
+			match: true,
+		},
+		{
+			desc:  "parse comparison of value",
+			expr:  `v > 40`,
+			match: true,
+		},
+		{
+			desc:  "parse conjunction",
+			expr:  `name == "temp" && v > 40`,
+			match: true,
+		},
+		{
+			desc:  "parse conjunction not satisfied",
+			expr:  `n == "temp" && value >= 42.5`,
+			match: false,
+		},
+		{
+			desc:  "parse disjunction",
+			expr:  `v < -10 || v >= 4.2e1`,
+			match: true,
+		},
+		{
+			desc:  "parse negation with parentheses",
+			expr:  `!(unit == "Cel" || t < 1600000000)`,
+			match: false,
+		},
+		{
+			desc:  "parse precedence of conjunction",
+			expr:  `name != "temp" && v > 40 || vb == true`,
+			match: true,
+		},
+		{
+			desc:  "parse comparison of string value",
+			expr:  `vs == "on" && publisher == "publisher"`,
+			match: true,
+		},
+		{
+			desc:  "parse comparison of missing value",
+			expr:  `s != 0`,
+			match: false,
+		},
+		{
+			desc: "parse empty condition",
+			expr: ``,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse unknown field",
+			expr: `pressure > 40`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse comparison of string to number",
+			expr: `name == 40`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse ordering of strings",
+			expr: `name > "temp"`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse comparison of boolean to string",
+			expr: `vb == "true"`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse missing operand",
+			expr: `v > 40 &&`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse unbalanced parentheses",
+			expr: `(v > 40`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse unterminated string",
+			expr: `name == "temp`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse invalid character",
+			expr: `v > 40 ; v < 50`,
+			err:  conditions.ErrInvalidCondition,
+		},
+	}
+
+	for _, tc := range cases {
+		cond, err := conditions.ParseCondition(tc.expr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		match := cond.Match(msg)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected match %t got %t\n", tc.desc, tc.match, match))
+	}
+}
+
+func TestParseJSONCondition(t *testing.T) {
+	obj := map[string]interface{}{
+		"temp":          42.0,
+		"status":        "on",
+		"active":        true,
+		"sensor/hum":    55.5,
+		"sensor/serial": "s-1",
+	}
+
+	cases := []struct {
+		desc  string
+		expr  string
+		match bool
+		err   error
+	}{
+		{
+			desc:  "parse comparison of number",
+			expr:  `temp > 40`,
+			match: true,
+		},
+		{
+			desc:  "parse comparison of nested field",
+			expr:  `sensor/hum <= 55.5 && sensor/serial == "s-1"`,
+			match: true,
+		},
+		{
+			desc:  "parse comparison of boolean",
+			expr:  `active == false || status != "on"`,
+			match: false,
+		},
+		{
+			desc:  "parse comparison of missing field",
+			expr:  `pressure != 0`,
+			match: false,
+		},
+		{
+			desc:  "parse comparison of field with different type",
+			expr:  `status > 1`,
+			match: false,
+		},
+		{
+			desc: "parse ordering of strings",
+			expr: `status > "off"`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse comparison to field",
+			expr: `temp == status`,
+			err:  conditions.ErrInvalidCondition,
+		},
+		{
+			desc: "parse empty condition",
+			expr: ``,
+			err:  conditions.ErrInvalidCondition,
+		},
+	}
+
+	for _, tc := range cases {
+		cond, err := conditions.ParseJSONCondition(tc.expr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		match := cond.Match(obj)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected match %t got %t\n", tc.desc, tc.match, match))
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conditions contains the parsers of the conditions over message
+// fields and the matching of message topics against NATS-style topic
+// patterns, shared by the services which evaluate consumed messages.
+package conditions
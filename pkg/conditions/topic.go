@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions
+
+import "strings"
+
+const (
+	// SingleWildcard matches exactly one topic token.
+	SingleWildcard = "*"
+
+	// FullWildcard matches one or more trailing topic tokens.
+	FullWildcard = ">"
+
+	topicSeparator = "."
+)
+
+// ValidTopic checks if the topic pattern is valid. Topic consists of non-empty
+// tokens separated by dots, such as `channel.subtopic`. A token can be replaced
+// with a `*` wildcard which matches any single token, and the last token can be
+// replaced with a `>` wildcard which matches one or more tokens, the same as in
+// NATS subjects.
+func ValidTopic(topic string) bool {
+	if topic == "" {
+		return false
+	}
+	tokens := strings.Split(topic, topicSeparator)
+	for i, t := range tokens {
+		switch {
+		case t == "":
+			return false
+		case t == FullWildcard && i != len(tokens)-1:
+			return false
+		case t != SingleWildcard && t != FullWildcard &&
+			strings.ContainsAny(t, SingleWildcard+FullWildcard):
+			return false
+		}
+	}
+	return true
+}
+
+// Wildcard checks if the topic contains wildcards.
+func Wildcard(topic string) bool {
+	for _, t := range strings.Split(topic, topicSeparator) {
+		if t == SingleWildcard || t == FullWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTopic checks if the message topic, such as `channel.subtopic`,
+// matches the topic pattern.
+func MatchTopic(topic, msgTopic string) bool {
+	tokens := strings.Split(topic, topicSeparator)
+	msgTokens := strings.Split(msgTopic, topicSeparator)
+	for i, t := range tokens {
+		if t == FullWildcard {
+			return len(msgTokens) > i
+		}
+		if i >= len(msgTokens) || (t != SingleWildcard && t != msgTokens[i]) {
+			return false
+		}
+	}
+	return len(tokens) == len(msgTokens)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package conditions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/conditions"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidTopic(t *testing.T) {
+	cases := []struct {
+		topic string
+		valid bool
+	}{
+		{topic: "channel", valid: true},
+		{topic: "channel.subtopic", valid: true},
+		{topic: "channel.*", valid: true},
+		{topic: "channel.*.temp", valid: true},
+		{topic: "channel.>", valid: true},
+		{topic: "*.subtopic.>", valid: true},
+		{topic: ">", valid: true},
+		{topic: "", valid: false},
+		{topic: "channel.", valid: false},
+		{topic: "channel..subtopic", valid: false},
+		{topic: "channel.>.temp", valid: false},
+		{topic: "channel.sub*", valid: false},
+		{topic: "channel.sub>", valid: false},
+	}
+
+	for _, tc := range cases {
+		valid := conditions.ValidTopic(tc.topic)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t\n", tc.topic, tc.valid, valid))
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	cases := []struct {
+		topic    string
+		msgTopic string
+		match    bool
+	}{
+		{topic: "channel.subtopic", msgTopic: "channel.subtopic", match: true},
+		{topic: "channel.subtopic", msgTopic: "channel.other", match: false},
+		{topic: "channel.subtopic", msgTopic: "channel.subtopic.temp", match: false},
+		{topic: "channel.*", msgTopic: "channel.subtopic", match: true},
+		{topic: "channel.*", msgTopic: "channel", match: false},
+		{topic: "channel.*", msgTopic: "channel.subtopic.temp", match: false},
+		{topic: "channel.*.temp", msgTopic: "channel.subtopic.temp", match: true},
+		{topic: "channel.>", msgTopic: "channel.subtopic", match: true},
+		{topic: "channel.>", msgTopic: "channel.subtopic.temp", match: true},
+		{topic: "channel.>", msgTopic: "channel", match: false},
+		{topic: "channel.>", msgTopic: "other.subtopic", match: false},
+		{topic: "*.subtopic.>", msgTopic: "channel.subtopic.temp", match: true},
+	}
+
+	for _, tc := range cases {
+		match := conditions.MatchTopic(tc.topic, tc.msgTopic)
+		assert.Equal(t, tc.match, match, fmt.Sprintf("%s matching %s: expected %t got %t\n", tc.topic, tc.msgTopic, tc.match, match))
+	}
+}
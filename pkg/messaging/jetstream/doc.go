@@ -0,0 +1,11 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jetstream holds the implementation of the PubSub interface
+// backed by NATS JetStream. Unlike the core NATS implementation, messages
+// are persisted in a stream and delivered to durable consumers which
+// acknowledge them explicitly, so messages published while a subscriber
+// is down, or whose handling failed, are redelivered instead of lost.
+// Subscribers using the same durable name share the consumer, which allows
+// running multiple replicas of the same service.
+package jetstream
@@ -0,0 +1,326 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+const (
+	chansPrefix = "channels"
+
+	// DefaultStream is the name of the stream used if none is configured.
+	DefaultStream = "mainflux"
+	// DefaultMaxDeliver is the number of delivery attempts used if
+	// none is configured.
+	DefaultMaxDeliver = 5
+	// DefaultMaxAge is the message retention used when the stream is created.
+	DefaultMaxAge = 7 * 24 * time.Hour
+)
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+var (
+	errAlreadySubscribed = errors.New("already subscribed to topic")
+	errNotSubscribed     = errors.New("not subscribed")
+	errEmptyTopic        = errors.New("empty topic")
+	errEmptyDurable      = errors.New("empty durable name")
+	errInvalidReplay     = errors.New("replay start must be a sequence number or RFC3339 timestamp")
+)
+
+const errConsumerNotFound = "nats: consumer not found"
+
+var durableReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// PubSub wraps messaging PubSub exposing
+// Close() method for JetStream connection.
+type PubSub interface {
+	messaging.PubSub
+	Close()
+}
+
+// Config represents JetStream PubSub configuration.
+type Config struct {
+	// Stream is the name of the stream holding channel messages. The
+	// stream is created on connect if it does not exist.
+	Stream string
+	// MaxAge is the retention of the messages in the newly created stream.
+	MaxAge time.Duration
+	// Durable is the name of the durable consumers. Every subscribed topic
+	// gets its own consumer whose name is prefixed with this value. Service
+	// replicas using the same name share the consumers as a queue group, so
+	// that every message is handled by one of them.
+	Durable string
+	// MaxDeliver is the maximum number of delivery attempts of a message
+	// whose handling failed.
+	MaxDeliver int
+	// AckWait is the time after which unacknowledged message is redelivered.
+	// Zero value uses the server default.
+	AckWait time.Duration
+	// StartSeq and StartTime replay the stream from the given sequence
+	// or time. Since durable consumers keep their position, the consumer
+	// is recreated when it was started from a different position.
+	StartSeq  uint64
+	StartTime time.Time
+}
+
+type pubsub struct {
+	conn          *broker.Conn
+	js            broker.JetStreamContext
+	cfg           Config
+	logger        log.Logger
+	mu            sync.Mutex
+	subscriptions map[string]*broker.Subscription
+}
+
+// NewPubSub returns JetStream message publisher/subscriber. The stream
+// capturing all the channel subjects is created if it does not exist.
+func NewPubSub(url string, cfg Config, logger log.Logger) (PubSub, error) {
+	if cfg.Durable == "" {
+		return nil, errEmptyDurable
+	}
+	if cfg.Stream == "" {
+		cfg.Stream = DefaultStream
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = DefaultMaxAge
+	}
+	if cfg.MaxDeliver == 0 {
+		cfg.MaxDeliver = DefaultMaxDeliver
+	}
+
+	conn, err := broker.Connect(url)
+	if err != nil {
+		return nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err := js.StreamInfo(cfg.Stream); err != nil {
+		sc := &broker.StreamConfig{
+			Name:     cfg.Stream,
+			Subjects: []string{SubjectAllChannels},
+			Storage:  broker.FileStorage,
+			MaxAge:   cfg.MaxAge,
+		}
+		if _, err := js.AddStream(sc); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	ret := &pubsub{
+		conn:          conn,
+		js:            js,
+		cfg:           cfg,
+		logger:        logger,
+		subscriptions: make(map[string]*broker.Subscription),
+	}
+	return ret, nil
+}
+
+// ParseReplayStart parses replay start which is either a stream sequence
+// number or an RFC3339 timestamp. Empty value means no replay.
+func ParseReplayStart(val string) (uint64, time.Time, error) {
+	if val == "" {
+		return 0, time.Time{}, nil
+	}
+	if seq, err := strconv.ParseUint(val, 10, 64); err == nil {
+		return seq, time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return 0, time.Time{}, errInvalidReplay
+	}
+	return 0, t, nil
+}
+
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+	if _, err := ps.js.Publish(subject, data); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+
+	durable := fmt.Sprintf("%s-%s", ps.cfg.Durable, durableReplacer.Replace(topic))
+	opts := []broker.SubOpt{
+		broker.BindStream(ps.cfg.Stream),
+		broker.Durable(durable),
+		broker.ManualAck(),
+		broker.AckExplicit(),
+		broker.MaxDeliver(ps.cfg.MaxDeliver),
+	}
+	if ps.cfg.AckWait > 0 {
+		opts = append(opts, broker.AckWait(ps.cfg.AckWait))
+	}
+
+	resumeSeq, err := ps.syncConsumer(durable)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ps.cfg.StartSeq > 0:
+		opts = append(opts, broker.StartSequence(ps.cfg.StartSeq))
+	case !ps.cfg.StartTime.IsZero():
+		opts = append(opts, broker.StartTime(ps.cfg.StartTime))
+	case resumeSeq > 0:
+		opts = append(opts, broker.StartSequence(resumeSeq))
+	default:
+		opts = append(opts, broker.DeliverAll())
+	}
+
+	// Durable name is used as the queue group as well, so that the
+	// replicas attached to the same consumer don't handle messages twice.
+	sub, err := ps.js.QueueSubscribe(topic, durable, ps.jsHandler(handler), opts...)
+	if err != nil {
+		return err
+	}
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	// Unlike Unsubscribe, Drain keeps the durable consumer
+	// so that the subscription can be resumed later.
+	if err := sub.Drain(); err != nil {
+		return err
+	}
+
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+func (ps *pubsub) Close() {
+	ps.conn.Close()
+}
+
+// syncConsumer deletes the existing durable consumer whose configuration
+// differs from the configured one. Existing consumers are attached to as
+// they are, so they have to be recreated for the new configuration to
+// take effect. Returned stream sequence is the one following the last
+// message delivered by the deleted consumer, so that the recreated one
+// resumes where it stopped instead of replaying the whole stream. Zero is
+// returned if there is no position to resume from.
+func (ps *pubsub) syncConsumer(durable string) (uint64, error) {
+	info, err := ps.js.ConsumerInfo(ps.cfg.Stream, durable)
+	if err != nil {
+		// Client doesn't expose the error type for the missing consumer.
+		if err.Error() == errConsumerNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if !ps.changed(info.Config) {
+		return 0, nil
+	}
+
+	ps.logger.Info(fmt.Sprintf("Recreating consumer %s of stream %s due to configuration change", durable, ps.cfg.Stream))
+	if err := ps.js.DeleteConsumer(ps.cfg.Stream, durable); err != nil {
+		return 0, err
+	}
+	if info.Delivered.Stream == 0 {
+		return 0, nil
+	}
+	return info.Delivered.Stream + 1, nil
+}
+
+func (ps *pubsub) changed(cc broker.ConsumerConfig) bool {
+	if cc.MaxDeliver != ps.cfg.MaxDeliver {
+		return true
+	}
+	if ps.cfg.AckWait > 0 && cc.AckWait != ps.cfg.AckWait {
+		return true
+	}
+	// Consumer without configured replay start keeps its position,
+	// no matter where it was started from.
+	switch {
+	case ps.cfg.StartSeq > 0:
+		return cc.DeliverPolicy != broker.DeliverByStartSequencePolicy || cc.OptStartSeq != ps.cfg.StartSeq
+	case !ps.cfg.StartTime.IsZero():
+		return cc.DeliverPolicy != broker.DeliverByStartTimePolicy || cc.OptStartTime == nil || !cc.OptStartTime.Equal(ps.cfg.StartTime)
+	default:
+		return false
+	}
+}
+
+func (ps *pubsub) jsHandler(h messaging.MessageHandler) broker.MsgHandler {
+	return func(m *broker.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			// Malformed message can't be handled no matter how many times it's redelivered.
+			if err := m.Term(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to terminate message: %s", err))
+			}
+			return
+		}
+
+		if err := h(msg); err != nil {
+			ps.nak(m, err)
+			return
+		}
+		if err := m.Ack(); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to acknowledge message: %s", err))
+		}
+	}
+}
+
+func (ps *pubsub) nak(m *broker.Msg, err error) {
+	if md, mdErr := m.Metadata(); mdErr == nil && md.NumDelivered >= uint64(ps.cfg.MaxDeliver) {
+		ps.logger.Error(fmt.Sprintf("Failed to handle Mainflux message after %d attempts, dropping it: %s", md.NumDelivered, err))
+	} else {
+		ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message, it will be redelivered: %s", err))
+	}
+	if err := m.Nak(); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to negatively acknowledge message: %s", err))
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chansPrefix = "channels"
+	channel     = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic    = "engine"
+	timeout     = 5 * time.Second
+)
+
+var data = []byte("payload")
+
+func TestPubsub(t *testing.T) {
+	msgChan := make(chan messaging.Message)
+	topic := fmt.Sprintf("%s.%s.>", chansPrefix, channel)
+	err := pubsub.Subscribe(topic, func(msg messaging.Message) error {
+		msgChan <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pubsub.Unsubscribe(topic)
+
+	err = pubsub.Subscribe(topic, func(msg messaging.Message) error { return nil })
+	assert.NotNil(t, err, "expected error subscribing to the same topic twice")
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:     "publish message with nil payload",
+			subtopic: subtopic,
+			payload:  nil,
+		},
+		{
+			desc:     "publish message with string payload",
+			subtopic: subtopic,
+			payload:  data,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+		}
+		err = pubsub.Publish(channel, expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		select {
+		case receivedMsg := <-msgChan:
+			assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+		case <-time.After(timeout):
+			assert.Fail(t, fmt.Sprintf("%s: message not received", tc.desc))
+		}
+	}
+}
+
+func TestRedelivery(t *testing.T) {
+	const ch = "redelivery"
+	topic := fmt.Sprintf("%s.%s", chansPrefix, ch)
+	attempts := make(chan int, jetstream.DefaultMaxDeliver+1)
+	count := 0
+	err := pubsub.Subscribe(topic, func(msg messaging.Message) error {
+		count++
+		attempts <- count
+		if count < 3 {
+			return errors.New("handling failed")
+		}
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pubsub.Unsubscribe(topic)
+
+	err = pubsub.Publish(ch, messaging.Message{Channel: ch, Payload: data})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case n := <-attempts:
+			assert.Equal(t, i, n, fmt.Sprintf("expected delivery attempt %d got %d", i, n))
+		case <-time.After(timeout):
+			require.Fail(t, fmt.Sprintf("delivery attempt %d not received", i))
+		}
+	}
+
+	// Message acknowledged on the third attempt must not be redelivered.
+	select {
+	case n := <-attempts:
+		assert.Fail(t, fmt.Sprintf("unexpected delivery attempt %d", n))
+	case <-time.After(time.Second):
+	}
+}
+
+func TestReplay(t *testing.T) {
+	const ch = "replay"
+	topic := fmt.Sprintf("%s.%s", chansPrefix, ch)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		err := pubsub.Publish(ch, messaging.Message{Channel: ch, Payload: []byte(fmt.Sprintf("%d", i))})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	ps, err := jetstream.NewPubSub(address, jetstream.Config{Durable: "replay", StartTime: start}, logg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+
+	msgChan := make(chan messaging.Message, 3)
+	err = ps.Subscribe(topic, func(msg messaging.Message) error {
+		msgChan <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < 3; i++ {
+		select {
+		case msg := <-msgChan:
+			assert.Equal(t, fmt.Sprintf("%d", i), string(msg.Payload), fmt.Sprintf("expected replayed message %d got %s", i, msg.Payload))
+		case <-time.After(timeout):
+			require.Fail(t, fmt.Sprintf("replayed message %d not received", i))
+		}
+	}
+}
+
+func TestConsumerReconfiguration(t *testing.T) {
+	const ch = "reconfigured"
+	const count = 3
+	topic := fmt.Sprintf("%s.%s", chansPrefix, ch)
+
+	ps, err := jetstream.NewPubSub(address, jetstream.Config{Durable: "reconfigured"}, logg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msgChan := make(chan messaging.Message, count)
+	err = ps.Subscribe(topic, func(msg messaging.Message) error {
+		msgChan <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < count; i++ {
+		err := pubsub.Publish(ch, messaging.Message{Channel: ch, Payload: []byte(fmt.Sprintf("%d", i))})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		select {
+		case <-msgChan:
+		case <-time.After(timeout):
+			require.Fail(t, fmt.Sprintf("message %d not received", i))
+		}
+	}
+	err = ps.Unsubscribe(topic)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ps.Close()
+
+	// Changed delivery attempts limit recreates the consumer, which must
+	// resume after the acknowledged messages instead of replaying them.
+	cfg := jetstream.Config{Durable: "reconfigured", MaxDeliver: jetstream.DefaultMaxDeliver + 1}
+	ps, err = jetstream.NewPubSub(address, cfg, logg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+
+	msgChan = make(chan messaging.Message, count+1)
+	err = ps.Subscribe(topic, func(msg messaging.Message) error {
+		msgChan <- msg
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = pubsub.Publish(ch, messaging.Message{Channel: ch, Payload: data})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	select {
+	case msg := <-msgChan:
+		assert.Equal(t, data, msg.Payload, fmt.Sprintf("expected new message %s got %s", data, msg.Payload))
+	case <-time.After(timeout):
+		require.Fail(t, "new message not received")
+	}
+
+	select {
+	case msg := <-msgChan:
+		assert.Fail(t, fmt.Sprintf("unexpected redelivered message %s", msg.Payload))
+	case <-time.After(time.Second):
+	}
+}
+
+func TestSharedConsumer(t *testing.T) {
+	const ch = "shared"
+	const count = 10
+	topic := fmt.Sprintf("%s.%s", chansPrefix, ch)
+
+	msgChan := make(chan messaging.Message, 2*count)
+	for i := 0; i < 2; i++ {
+		ps, err := jetstream.NewPubSub(address, jetstream.Config{Durable: "shared"}, logg)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		defer ps.Close()
+
+		err = ps.Subscribe(topic, func(msg messaging.Message) error {
+			msgChan <- msg
+			return nil
+		})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	for i := 0; i < count; i++ {
+		err := pubsub.Publish(ch, messaging.Message{Channel: ch, Payload: []byte(fmt.Sprintf("%d", i))})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	received := make(map[string]int)
+	for i := 0; i < count; i++ {
+		select {
+		case msg := <-msgChan:
+			received[string(msg.Payload)]++
+		case <-time.After(timeout):
+			require.Fail(t, fmt.Sprintf("message %d not received", i))
+		}
+	}
+	assert.Equal(t, count, len(received), fmt.Sprintf("expected %d distinct messages got %d", count, len(received)))
+
+	// Replicas share the consumer, so no message is handled twice.
+	select {
+	case msg := <-msgChan:
+		assert.Fail(t, fmt.Sprintf("unexpected duplicate message %s", msg.Payload))
+	case <-time.After(time.Second):
+	}
+}
+
+func TestParseReplayStart(t *testing.T) {
+	ts := "2021-06-01T10:00:00Z"
+	tm, err := time.Parse(time.RFC3339, ts)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		val  string
+		seq  uint64
+		time time.Time
+		err  bool
+	}{
+		{desc: "parse empty value", val: ""},
+		{desc: "parse sequence", val: "42", seq: 42},
+		{desc: "parse timestamp", val: ts, time: tm},
+		{desc: "parse invalid value", val: "yesterday", err: true},
+	}
+
+	for _, tc := range cases {
+		seq, st, err := jetstream.ParseReplayStart(tc.val)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		assert.Equal(t, tc.seq, seq, fmt.Sprintf("%s: expected sequence %d got %d", tc.desc, tc.seq, seq))
+		assert.True(t, tc.time.Equal(st), fmt.Sprintf("%s: expected time %s got %s", tc.desc, tc.time, st))
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memory holds the in-process implementation of the PubSub
+// interface. Messages are routed between the PubSubs created from the
+// same Broker, following the NATS subject and queue group semantics,
+// so that services and tests can run without an external message broker.
+package memory
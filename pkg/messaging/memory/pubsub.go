@@ -0,0 +1,275 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	chansPrefix = "channels"
+	bufSize     = 1024
+)
+
+// SubjectAllChannels represents subject to subscribe for all the channels.
+const SubjectAllChannels = "channels.>"
+
+var (
+	errAlreadySubscribed = errors.New("already subscribed to topic")
+	errNotSubscribed     = errors.New("not subscribed")
+	errEmptyTopic        = errors.New("empty topic")
+	errInvalidTopic      = errors.New("invalid topic")
+	errClosed            = errors.New("pubsub closed")
+)
+
+var defaultBroker = NewBroker()
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// PubSub wraps messaging PubSub exposing
+// Close() method which drops all the subscriptions.
+type PubSub interface {
+	messaging.PubSub
+	Close()
+}
+
+// Broker routes the messages published by any of its PubSubs
+// to the matching subscriptions of all of its PubSubs.
+type Broker struct {
+	mu     sync.Mutex
+	subs   []*subscription
+	groups map[string]uint64
+}
+
+type subscription struct {
+	tokens  []string
+	queue   string
+	handler messaging.MessageHandler
+	logger  log.Logger
+	msgs    chan messaging.Message
+	done    chan struct{}
+}
+
+type pubsub struct {
+	broker        *Broker
+	logger        log.Logger
+	mu            sync.Mutex
+	queue         string
+	closed        bool
+	subscriptions map[string]*subscription
+}
+
+// NewBroker returns new in-memory message broker.
+func NewBroker() *Broker {
+	return &Broker{
+		groups: make(map[string]uint64),
+	}
+}
+
+// NewPubSub returns in-memory message publisher/subscriber attached to
+// the process-wide broker. Parameter queue has the same meaning as in
+// the NATS implementation: if it's not empty, each message is delivered
+// to only one of the subscriptions to the same topic sharing the queue.
+func NewPubSub(queue string, logger log.Logger) PubSub {
+	return defaultBroker.NewPubSub(queue, logger)
+}
+
+// NewPubSub returns in-memory message publisher/subscriber attached to the broker.
+func (b *Broker) NewPubSub(queue string, logger log.Logger) PubSub {
+	return &pubsub{
+		broker:        b,
+		logger:        logger,
+		queue:         queue,
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+// Publish never blocks. Same as slow NATS consumers, subscriptions whose
+// buffer is full drop the message.
+func (ps *pubsub) Publish(topic string, msg messaging.Message) error {
+	ps.mu.Lock()
+	closed := ps.closed
+	ps.mu.Unlock()
+	if closed {
+		return errClosed
+	}
+
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	for _, sub := range ps.broker.route(strings.Split(subject, ".")) {
+		select {
+		case sub.msgs <- msg:
+		default:
+			ps.logger.Warn(fmt.Sprintf("Dropping message published to %s, subscription buffer is full", subject))
+		}
+	}
+
+	return nil
+}
+
+func (ps *pubsub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	tokens, err := parse(topic)
+	if err != nil {
+		return err
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.closed {
+		return errClosed
+	}
+	if _, ok := ps.subscriptions[topic]; ok {
+		return errAlreadySubscribed
+	}
+
+	sub := &subscription{
+		tokens:  tokens,
+		queue:   ps.queue,
+		handler: handler,
+		logger:  ps.logger,
+		msgs:    make(chan messaging.Message, bufSize),
+		done:    make(chan struct{}),
+	}
+	go sub.listen()
+	ps.broker.add(sub)
+	ps.subscriptions[topic] = sub
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(topic string) error {
+	if topic == "" {
+		return errEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	sub, ok := ps.subscriptions[topic]
+	if !ok {
+		return errNotSubscribed
+	}
+
+	ps.broker.remove(sub)
+	delete(ps.subscriptions, topic)
+	return nil
+}
+
+func (ps *pubsub) Close() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for topic, sub := range ps.subscriptions {
+		ps.broker.remove(sub)
+		delete(ps.subscriptions, topic)
+	}
+	ps.closed = true
+}
+
+func (b *Broker) add(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs = append(b.subs, sub)
+}
+
+func (b *Broker) remove(sub *subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, s := range b.subs {
+		if s == sub {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			close(sub.done)
+			return
+		}
+	}
+}
+
+// route returns subscriptions the message published to the subject is
+// delivered to. Among the subscriptions sharing both the queue and the
+// topic, the message is delivered to a single one in round-robin fashion.
+func (b *Broker) route(subject []string) []*subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var ret []*subscription
+	var keys []string
+	groups := make(map[string][]*subscription)
+	for _, sub := range b.subs {
+		if !match(sub.tokens, subject) {
+			continue
+		}
+		if sub.queue == "" {
+			ret = append(ret, sub)
+			continue
+		}
+		key := fmt.Sprintf("%s %s", sub.queue, strings.Join(sub.tokens, "."))
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], sub)
+	}
+
+	for _, key := range keys {
+		members := groups[key]
+		ret = append(ret, members[b.groups[key]%uint64(len(members))])
+		b.groups[key]++
+	}
+
+	return ret
+}
+
+func (sub *subscription) listen() {
+	for {
+		select {
+		case msg := <-sub.msgs:
+			if err := sub.handler(msg); err != nil {
+				sub.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message: %s", err))
+			}
+		case <-sub.done:
+			return
+		}
+	}
+}
+
+func parse(topic string) ([]string, error) {
+	tokens := strings.Split(topic, ".")
+	for i, t := range tokens {
+		if t == "" {
+			return nil, errInvalidTopic
+		}
+		if t == ">" && i != len(tokens)-1 {
+			return nil, errInvalidTopic
+		}
+	}
+	return tokens, nil
+}
+
+// match reports whether the subject matches the topic tokens. Token "*"
+// matches exactly one subject token and ">" matches one or more trailing ones.
+func match(tokens, subject []string) bool {
+	for i, t := range tokens {
+		if t == ">" {
+			return len(subject) > i
+		}
+		if i >= len(subject) {
+			return false
+		}
+		if t != "*" && t != subject[i] {
+			return false
+		}
+	}
+	return len(tokens) == len(subject)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	channel  = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic = "engine"
+	timeout  = 100 * time.Millisecond
+)
+
+var data = []byte("payload")
+
+func newLogger() log.Logger {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+	return logger
+}
+
+func handler(msgs chan<- messaging.Message) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		msgs <- msg
+		return nil
+	}
+}
+
+func received(msgs <-chan messaging.Message) int {
+	count := 0
+	for {
+		select {
+		case <-msgs:
+			count++
+		case <-time.After(timeout):
+			return count
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	ps := memory.NewBroker().NewPubSub("", newLogger())
+	defer ps.Close()
+
+	cases := []struct {
+		desc  string
+		topic string
+		err   bool
+	}{
+		{
+			desc:  "subscribe to all channels",
+			topic: memory.SubjectAllChannels,
+		},
+		{
+			desc:  "subscribe to channel with wildcard subtopic",
+			topic: fmt.Sprintf("channels.%s.*", channel),
+		},
+		{
+			desc:  "subscribe to the same topic again",
+			topic: memory.SubjectAllChannels,
+			err:   true,
+		},
+		{
+			desc:  "subscribe to empty topic",
+			topic: "",
+			err:   true,
+		},
+		{
+			desc:  "subscribe to topic with empty token",
+			topic: "channels..engine",
+			err:   true,
+		},
+		{
+			desc:  "subscribe to topic with non-trailing full wildcard",
+			topic: "channels.>.engine",
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := ps.Subscribe(tc.topic, func(messaging.Message) error { return nil })
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+	}
+}
+
+func TestPubsub(t *testing.T) {
+	b := memory.NewBroker()
+	pub := b.NewPubSub("", newLogger())
+	sub := b.NewPubSub("", newLogger())
+	defer sub.Close()
+
+	cases := []struct {
+		desc     string
+		topic    string
+		subtopic string
+		count    int
+	}{
+		{
+			desc:  "publish to exact subject",
+			topic: fmt.Sprintf("channels.%s", channel),
+			count: 1,
+		},
+		{
+			desc:     "publish subtopic message to exact subject",
+			topic:    fmt.Sprintf("channels.%s", channel),
+			subtopic: subtopic,
+			count:    0,
+		},
+		{
+			desc:     "publish to single token wildcard subject",
+			topic:    "channels.*.engine",
+			subtopic: subtopic,
+			count:    1,
+		},
+		{
+			desc:  "publish channel message to single token wildcard subject",
+			topic: "channels.*.engine",
+			count: 0,
+		},
+		{
+			desc:     "publish to full wildcard subject",
+			topic:    memory.SubjectAllChannels,
+			subtopic: subtopic,
+			count:    1,
+		},
+		{
+			desc:  "publish channel message to channel full wildcard subject",
+			topic: fmt.Sprintf("channels.%s.>", channel),
+			count: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		msgs := make(chan messaging.Message, 10)
+		err := sub.Subscribe(tc.topic, handler(msgs))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		msg := messaging.Message{Channel: channel, Subtopic: tc.subtopic, Payload: data}
+		err = pub.Publish(channel, msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		count := received(msgs)
+		assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.count, count))
+
+		err = sub.Unsubscribe(tc.topic)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+	}
+}
+
+func TestQueue(t *testing.T) {
+	b := memory.NewBroker()
+	pub := b.NewPubSub("", newLogger())
+
+	msgs := make(chan messaging.Message, 10)
+	queued := make(chan messaging.Message, 10)
+	for _, queue := range []string{"", "", "writers", "writers"} {
+		ps := b.NewPubSub(queue, newLogger())
+		defer ps.Close()
+		h := handler(msgs)
+		if queue != "" {
+			h = handler(queued)
+		}
+		err := ps.Subscribe(memory.SubjectAllChannels, h)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	for i := 0; i < 2; i++ {
+		err := pub.Publish(channel, messaging.Message{Channel: channel, Payload: data})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	count := received(msgs)
+	assert.Equal(t, 4, count, fmt.Sprintf("expected every plain subscriber to receive all messages, got %d messages", count))
+	count = received(queued)
+	assert.Equal(t, 2, count, fmt.Sprintf("expected queue group to receive each message once, got %d messages", count))
+}
+
+func TestUnsubscribe(t *testing.T) {
+	b := memory.NewBroker()
+	ps := b.NewPubSub("", newLogger())
+
+	msgs := make(chan messaging.Message, 10)
+	err := ps.Subscribe(memory.SubjectAllChannels, handler(msgs))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = ps.Unsubscribe(memory.SubjectAllChannels)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = ps.Unsubscribe(memory.SubjectAllChannels)
+	assert.NotNil(t, err, "expected error unsubscribing twice")
+
+	err = ps.Publish(channel, messaging.Message{Channel: channel, Payload: data})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 0, received(msgs), "expected no messages after unsubscribe")
+
+	ps.Close()
+	err = ps.Subscribe(memory.SubjectAllChannels, handler(msgs))
+	assert.NotNil(t, err, "expected error subscribing to closed pubsub")
+	err = ps.Publish(channel, messaging.Message{Channel: channel, Payload: data})
+	assert.NotNil(t, err, "expected error publishing to closed pubsub")
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	b := memory.NewBroker()
+	pub := b.NewPubSub("", newLogger())
+	sub := b.NewPubSub("", newLogger())
+	defer sub.Close()
+
+	release := make(chan struct{})
+	defer close(release)
+	err := sub.Subscribe(memory.SubjectAllChannels, func(messaging.Message) error {
+		<-release
+		return nil
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2000; i++ {
+			pub.Publish(channel, messaging.Message{Channel: channel, Payload: data})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		assert.Fail(t, "expected publishing not to block on slow subscriber")
+	}
+}
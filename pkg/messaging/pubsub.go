@@ -3,6 +3,16 @@
 
 package messaging
 
+// Actions that things perform over the channels they are connected to,
+// checked by the Things service when authorizing the access.
+const (
+	// PublishAction stands for publishing messages to the channel.
+	PublishAction = "publish"
+
+	// SubscribeAction stands for receiving messages from the channel.
+	SubscribeAction = "subscribe"
+)
+
 // Publisher specifies message publishing API.
 type Publisher interface {
 	// Publishes message to the stream.
@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const deadLettersEndpoint = "deadletters"
+
+// DeadLetter represents a message which a writer failed to consume.
+type DeadLetter struct {
+	ID       uint64            `json:"id"`
+	Consumer string            `json:"consumer"`
+	Error    string            `json:"error"`
+	Retries  int               `json:"retries"`
+	Created  time.Time         `json:"created"`
+	Message  messaging.Message `json:"message"`
+}
+
+func (sdk mfSDK) DeadLetters(token string, offset, limit uint64) (DeadLettersPage, error) {
+	endpoint := fmt.Sprintf("%s?offset=%d&limit=%d", deadLettersEndpoint, offset, limit)
+	url := createURL(sdk.writerURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return DeadLettersPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, "")
+	if err != nil {
+		return DeadLettersPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return DeadLettersPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return DeadLettersPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var dp DeadLettersPage
+	if err := json.Unmarshal(body, &dp); err != nil {
+		return DeadLettersPage{}, err
+	}
+
+	return dp, nil
+}
+
+func (sdk mfSDK) ReinjectDeadLetter(token string, id uint64) error {
+	endpoint := fmt.Sprintf("%s/%d/reinject", deadLettersEndpoint, id)
+	url := createURL(sdk.writerURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedPublish, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
+func (sdk mfSDK) RemoveDeadLetter(token string, id uint64) error {
+	endpoint := fmt.Sprintf("%s/%d", deadLettersEndpoint, id)
+	url := createURL(sdk.writerURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedRemoval, errors.New(resp.Status))
+	}
+
+	return nil
+}
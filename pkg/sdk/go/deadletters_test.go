@@ -0,0 +1,146 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/consumers"
+	"github.com/mainflux/mainflux/consumers/deadletter"
+	dlapi "github.com/mainflux/mainflux/consumers/deadletter/api"
+	dlmocks "github.com/mainflux/mainflux/consumers/deadletter/mocks"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
+	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeadLetterServer(t *testing.T, n int) *httptest.Server {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+	repo := dlmocks.NewRepository()
+	for i := 0; i < n; i++ {
+		err := repo.Publish(consumers.DeadLetter{Message: messaging.Message{Channel: "1", Payload: []byte("payload")}})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	auth := dlmocks.NewAuthService(map[string]string{token: email})
+	things := dlmocks.NewThingsService(map[string]string{"1": email})
+	svc := deadletter.New(auth, things, repo, memory.NewBroker().NewPubSub("", logger))
+	return httptest.NewServer(dlapi.MakeHandler(svc, bone.New()))
+}
+
+func TestDeadLetters(t *testing.T) {
+	n := 5
+	ts := newDeadLetterServer(t, n)
+	defer ts.Close()
+	mainfluxSDK := sdk.NewSDK(sdk.Config{WriterURL: ts.URL})
+
+	cases := []struct {
+		desc   string
+		token  string
+		offset uint64
+		limit  uint64
+		size   int
+		err    error
+	}{
+		{
+			desc:   "get dead letters",
+			token:  token,
+			offset: 0,
+			limit:  uint64(n),
+			size:   n,
+			err:    nil,
+		},
+		{
+			desc:   "get dead letters with offset",
+			token:  token,
+			offset: 2,
+			limit:  uint64(n),
+			size:   n - 2,
+			err:    nil,
+		},
+		{
+			desc:   "get dead letters with zero limit",
+			token:  token,
+			offset: 0,
+			limit:  0,
+			err:    createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+		},
+		{
+			desc:   "get dead letters with invalid token",
+			token:  wrongValue,
+			offset: 0,
+			limit:  uint64(n),
+			err:    createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := mainfluxSDK.DeadLetters(tc.token, tc.offset, tc.limit)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.DeadLetters), fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.DeadLetters)))
+	}
+}
+
+func TestReinjectDeadLetter(t *testing.T) {
+	ts := newDeadLetterServer(t, 1)
+	defer ts.Close()
+	mainfluxSDK := sdk.NewSDK(sdk.Config{WriterURL: ts.URL})
+
+	cases := []struct {
+		desc string
+		id   uint64
+		err  error
+	}{
+		{
+			desc: "reinject dead letter",
+			id:   1,
+			err:  nil,
+		},
+		{
+			desc: "reinject non-existing dead letter",
+			id:   1,
+			err:  createError(sdk.ErrFailedPublish, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.ReinjectDeadLetter(token, tc.id)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemoveDeadLetter(t *testing.T) {
+	ts := newDeadLetterServer(t, 1)
+	defer ts.Close()
+	mainfluxSDK := sdk.NewSDK(sdk.Config{WriterURL: ts.URL})
+
+	cases := []struct {
+		desc string
+		id   uint64
+		err  error
+	}{
+		{
+			desc: "remove dead letter",
+			id:   1,
+			err:  nil,
+		},
+		{
+			desc: "remove non-existing dead letter",
+			id:   1,
+			err:  createError(sdk.ErrFailedRemoval, http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.RemoveDeadLetter(token, tc.id)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+	}
+}
@@ -6,8 +6,11 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -79,6 +82,49 @@ func (sdk mfSDK) ReadMessages(chanName, token string) (MessagesPage, error) {
 	return mp, nil
 }
 
+func (sdk mfSDK) ExportMessages(chanName, exportType string, from, to float64, token string, w io.Writer) error {
+	chanNameParts := strings.SplitN(chanName, ".", 2)
+	chanID := chanNameParts[0]
+
+	query := url.Values{}
+	if exportType != "" {
+		query.Set("type", exportType)
+	}
+	if len(chanNameParts) == 2 {
+		query.Set("subtopic", strings.Replace(chanNameParts[1], ".", "/", -1))
+	}
+	if from != 0 {
+		query.Set("from", strconv.FormatFloat(from, 'f', -1, 64))
+	}
+	if to != 0 {
+		query.Set("to", strconv.FormatFloat(to, 'f', -1, 64))
+	}
+
+	endpoint := fmt.Sprintf("channels/%s/messages/export?%s", chanID, query.Encode())
+	url := createURL(sdk.readerURL, "", endpoint)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedRead, errors.New(resp.Status))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(ErrFailedRead, err)
+	}
+
+	return nil
+}
+
 func (sdk mfSDK) SetContentType(ct ContentType) error {
 	if ct != CTJSON && ct != CTJSONSenML && ct != CTBinary {
 		return ErrInvalidContentType
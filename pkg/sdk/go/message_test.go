@@ -4,9 +4,11 @@
 package sdk_test
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/mainflux/mainflux"
@@ -14,6 +16,10 @@ import (
 	"github.com/mainflux/mainflux/http/api"
 	"github.com/mainflux/mainflux/http/mocks"
 	sdk "github.com/mainflux/mainflux/pkg/sdk/go"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	rapi "github.com/mainflux/mainflux/readers/api"
+	rmocks "github.com/mainflux/mainflux/readers/mocks"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 )
@@ -139,3 +145,74 @@ func TestSetContentType(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestExportMessages(t *testing.T) {
+	chanID := "1"
+	atoken := "auth_token"
+	invalidToken := "invalid"
+
+	var msgs []readers.Message
+	for i := 0; i < 10; i++ {
+		v := float64(i)
+		msgs = append(msgs, senml.Message{Channel: chanID, Name: "current", Time: float64(i), Value: &v})
+	}
+	repo := rmocks.NewMessageRepository(chanID, msgs)
+	ts := httptest.NewServer(rapi.MakeHandler(repo, rmocks.NewThingsService(), nil, "reader"))
+	defer ts.Close()
+
+	sdkConf := sdk.Config{
+		ReaderURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc       string
+		exportType string
+		from       float64
+		to         float64
+		auth       string
+		lines      int
+		err        error
+	}{
+		{
+			desc:       "export messages as CSV",
+			exportType: "csv",
+			auth:       atoken,
+			lines:      len(msgs) + 1,
+			err:        nil,
+		},
+		{
+			desc:       "export messages as NDJSON within time range",
+			exportType: "ndjson",
+			from:       2,
+			to:         7,
+			auth:       atoken,
+			lines:      5,
+			err:        nil,
+		},
+		{
+			desc:       "export messages with invalid type",
+			exportType: "xml",
+			auth:       atoken,
+			err:        createError(sdk.ErrFailedRead, http.StatusBadRequest),
+		},
+		{
+			desc:       "export messages with invalid token",
+			exportType: "csv",
+			auth:       invalidToken,
+			err:        createError(sdk.ErrFailedRead, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		err := mainfluxSDK.ExportMessages(chanID, tc.exportType, tc.from, tc.to, tc.auth, &buf)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+		lines := strings.Count(buf.String(), "\n")
+		assert.Equal(t, tc.lines, lines, fmt.Sprintf("%s: expected %d lines, got %d", tc.desc, tc.lines, lines))
+	}
+}
@@ -13,4 +13,5 @@ type UserPasswordReq struct {
 type ConnectionIDs struct {
 	ChannelIDs []string `json:"channel_ids"`
 	ThingIDs   []string `json:"thing_ids"`
+	Type       string   `json:"type,omitempty"`
 }
@@ -5,10 +5,6 @@ package sdk
 
 import "github.com/mainflux/mainflux/pkg/transformers/senml"
 
-type tokenRes struct {
-	Token string `json:"token,omitempty"`
-}
-
 type createThingsRes struct {
 	Things []Thing `json:"things"`
 }
@@ -35,6 +31,12 @@ type ChannelsPage struct {
 	pageRes
 }
 
+// DeadLettersPage contains list of dead letters in a page with proper metadata.
+type DeadLettersPage struct {
+	DeadLetters []DeadLetter `json:"dead_letters"`
+	pageRes
+}
+
 // MessagesPage contains list of messages in a page with proper metadata.
 type MessagesPage struct {
 	Messages []senml.Message `json:"messages,omitempty"`
@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/mainflux/mainflux/auth"
@@ -90,6 +91,12 @@ type User struct {
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// Tokens represents the access and refresh token of a user session.
+type Tokens struct {
+	AccessToken  string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
 // Group represents mainflux users group.
 type Group struct {
 	ID          string                 `json:"id,omitempty"`
@@ -122,9 +129,19 @@ type SDK interface {
 	// User returns user object.
 	User(token string) (User, error)
 
-	// CreateToken receives credentials and returns user token.
+	// CreateToken receives credentials and returns user access token.
 	CreateToken(user User) (string, error)
 
+	// CreateTokens receives credentials and returns user access token
+	// alongside the refresh token of the new session.
+	CreateTokens(user User) (Tokens, error)
+
+	// RefreshTokens exchanges refresh token for the new pair of tokens.
+	RefreshTokens(refreshToken string) (Tokens, error)
+
+	// Logout revokes the session the token belongs to.
+	Logout(token string) error
+
 	// UpdateUser updates existing user.
 	UpdateUser(user User, token string) error
 
@@ -220,6 +237,21 @@ type SDK interface {
 	// ReadMessages read messages of specified channel.
 	ReadMessages(chanID, token string) (MessagesPage, error)
 
+	// ExportMessages writes all the messages of specified channel sent within
+	// the given time range to the writer, using the given export type (csv,
+	// ndjson or senml). Zero from and to leave the range unbounded.
+	ExportMessages(chanID, exportType string, from, to float64, token string, w io.Writer) error
+
+	// DeadLetters returns page of the messages sent to the user's channels
+	// which the writer failed to consume.
+	DeadLetters(token string, offset, limit uint64) (DeadLettersPage, error)
+
+	// ReinjectDeadLetter publishes dead letter message to its original channel.
+	ReinjectDeadLetter(token string, id uint64) error
+
+	// RemoveDeadLetter discards dead letter.
+	RemoveDeadLetter(token string, id uint64) error
+
 	// SetContentType sets message content type.
 	SetContentType(ct ContentType) error
 
@@ -260,6 +292,7 @@ type SDK interface {
 type mfSDK struct {
 	baseURL           string
 	readerURL         string
+	writerURL         string
 	bootstrapURL      string
 	certsURL          string
 	readerPrefix      string
@@ -278,6 +311,7 @@ type mfSDK struct {
 type Config struct {
 	BaseURL           string
 	ReaderURL         string
+	WriterURL         string
 	BootstrapURL      string
 	CertsURL          string
 	ReaderPrefix      string
@@ -295,6 +329,7 @@ func NewSDK(conf Config) SDK {
 	return &mfSDK{
 		baseURL:           conf.BaseURL,
 		readerURL:         conf.ReaderURL,
+		writerURL:         conf.WriterURL,
 		bootstrapURL:      conf.BootstrapURL,
 		certsURL:          conf.CertsURL,
 		readerPrefix:      conf.ReaderPrefix,
@@ -825,8 +825,8 @@ func TestConnect(t *testing.T) {
 
 	for _, tc := range cases {
 		connIDs := sdk.ConnectionIDs{
-			[]string{tc.thingID},
-			[]string{tc.chanID},
+			ChannelIDs: []string{tc.thingID},
+			ThingIDs:   []string{tc.chanID},
 		}
 
 		err := mainfluxSDK.Connect(connIDs, tc.token)
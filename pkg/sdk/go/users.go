@@ -17,6 +17,8 @@ import (
 const (
 	usersEndpoint    = "users"
 	tokensEndpoint   = "tokens"
+	refreshEndpoint  = "tokens/refresh"
+	logoutEndpoint   = "logout"
 	passwordEndpoint = "password"
 	membersEndpoint  = "members"
 )
@@ -74,34 +76,79 @@ func (sdk mfSDK) User(token string) (User, error) {
 }
 
 func (sdk mfSDK) CreateToken(user User) (string, error) {
-	data, err := json.Marshal(user)
+	tokens, err := sdk.CreateTokens(user)
 	if err != nil {
 		return "", err
 	}
 
+	return tokens.AccessToken, nil
+}
+
+func (sdk mfSDK) CreateTokens(user User) (Tokens, error) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return Tokens{}, err
+	}
+
 	url := createURL(sdk.baseURL, sdk.usersPrefix, tokensEndpoint)
 
+	return sdk.tokens(url, data)
+}
+
+func (sdk mfSDK) RefreshTokens(refreshToken string) (Tokens, error) {
+	data, err := json.Marshal(Tokens{RefreshToken: refreshToken})
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	url := createURL(sdk.baseURL, sdk.usersPrefix, refreshEndpoint)
+
+	return sdk.tokens(url, data)
+}
+
+func (sdk mfSDK) Logout(token string) error {
+	url := createURL(sdk.baseURL, sdk.usersPrefix, logoutEndpoint)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedRemoval, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
+func (sdk mfSDK) tokens(url string, data []byte) (Tokens, error) {
 	resp, err := sdk.client.Post(url, string(CTJSON), bytes.NewReader(data))
 	if err != nil {
-		return "", err
+		return Tokens{}, err
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return Tokens{}, err
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
+		return Tokens{}, errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
 	}
 
-	var tr tokenRes
-	if err := json.Unmarshal(body, &tr); err != nil {
-		return "", err
+	var tokens Tokens
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return Tokens{}, err
 	}
 
-	return tr.Token, nil
+	return tokens, nil
 }
 
 func (sdk mfSDK) UpdateUser(u User, token string) error {
@@ -19,6 +19,7 @@ import (
 	"github.com/mainflux/mainflux/users/mocks"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -36,7 +37,7 @@ func newUserService() users.Service {
 	emailer := mocks.NewEmailer()
 	idProvider := uuid.New()
 
-	return users.New(usersRepo, hasher, auth, emailer, idProvider, passRegex)
+	return users.New(usersRepo, mocks.NewTOTPRepository(), hasher, mocks.NewCipher(), auth, emailer, idProvider, passRegex, nil, "")
 }
 
 func newUserServer(svc users.Service) *httptest.Server {
@@ -159,3 +160,89 @@ func TestCreateToken(t *testing.T) {
 		assert.Equal(t, tc.token, token, fmt.Sprintf("%s: expected response: %s, got:  %s", tc.desc, token, tc.token))
 	}
 }
+
+func TestRefreshTokens(t *testing.T) {
+	svc := newUserService()
+	ts := newUserServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:         ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	user := sdk.User{Email: "user@example.com", Password: "password"}
+	mainfluxSDK.CreateUser(user)
+	tokens, err := mainfluxSDK.CreateTokens(user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		tokens sdk.Tokens
+		err    error
+	}{
+		{
+			desc:   "refresh tokens with refresh token",
+			token:  tokens.RefreshToken,
+			tokens: tokens,
+			err:    nil,
+		},
+		{
+			desc:   "refresh tokens with access token",
+			token:  tokens.AccessToken,
+			tokens: sdk.Tokens{},
+			err:    createError(sdk.ErrFailedCreation, http.StatusForbidden),
+		},
+		{
+			desc:   "refresh tokens with empty token",
+			token:  "",
+			tokens: sdk.Tokens{},
+			err:    createError(sdk.ErrFailedCreation, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		tokens, err := mainfluxSDK.RefreshTokens(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.tokens, tokens, fmt.Sprintf("%s: expected response: %v, got:  %v", tc.desc, tc.tokens, tokens))
+	}
+}
+
+func TestLogout(t *testing.T) {
+	svc := newUserService()
+	ts := newUserServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		BaseURL:         ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	user := sdk.User{Email: "user@example.com", Password: "password"}
+	mainfluxSDK.CreateUser(user)
+	tokens, err := mainfluxSDK.CreateTokens(user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "logout with access token",
+			token: tokens.AccessToken,
+			err:   nil,
+		},
+		{
+			desc:  "logout with invalid token",
+			token: "invalid",
+			err:   createError(sdk.ErrFailedRemoval, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		err := mainfluxSDK.Logout(tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package totp implements time-based one-time passwords (RFC 6238), as
+// generated by authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// Digits is the number of digits of the code.
+	Digits = 6
+
+	// Period is the duration of the time step.
+	Period = 30 * time.Second
+
+	// SecretSize is the size of the generated secret, matching the size
+	// of the HMAC-SHA1 output as recommended by RFC 4226.
+	SecretSize = 20
+
+	modulo = 1000000
+
+	// skew is the number of time steps before and after the current one
+	// whose codes are accepted, allowing for the clock drift.
+	skew = 1
+)
+
+// Encoding is the encoding of the secret shared with authenticator apps.
+var Encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret generates a random secret.
+func NewSecret() ([]byte, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Step returns the time step the provided time belongs to.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// Generate returns the code of the time step the provided time belongs to.
+func Generate(secret []byte, t time.Time) string {
+	return hotp(secret, Step(t))
+}
+
+// Validate checks the code against the time step the provided time belongs
+// to, as well as the adjacent time steps. Only the time steps after the last
+// one are accepted, so that each code can be used only once. The time step
+// of the accepted code is returned.
+func Validate(secret []byte, code string, t time.Time, last int64) (int64, bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	cur := Step(t)
+	for step := cur - skew; step <= cur+skew; step++ {
+		if step <= last {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hotp(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URI returns the key URI used to provision authenticator apps, usually
+// by scanning it as a QR code.
+func URI(secret []byte, issuer, account string) string {
+	q := url.Values{}
+	q.Set("secret", Encoding.EncodeToString(secret))
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(int64(Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// hotp returns the HOTP value (RFC 4226) of the counter.
+func hotp(secret []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%modulo)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package totp_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// secret is the SHA1 secret of the RFC 6238 test vectors.
+var secret = []byte("12345678901234567890")
+
+func TestGenerate(t *testing.T) {
+	// RFC 6238, Appendix B, truncated to 6 digits.
+	cases := []struct {
+		time int64
+		code string
+	}{
+		{time: 59, code: "287082"},
+		{time: 1111111109, code: "081804"},
+		{time: 1111111111, code: "050471"},
+		{time: 1234567890, code: "005924"},
+		{time: 2000000000, code: "279037"},
+		{time: 20000000000, code: "353130"},
+	}
+
+	for _, tc := range cases {
+		code := totp.Generate(secret, time.Unix(tc.time, 0))
+		assert.Equal(t, tc.code, code, fmt.Sprintf("time %d: expected %s got %s", tc.time, tc.code, code))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	step := totp.Step(now)
+
+	cases := []struct {
+		desc string
+		code string
+		last int64
+		step int64
+		ok   bool
+	}{
+		{
+			desc: "validate current code",
+			code: totp.Generate(secret, now),
+			last: 0,
+			step: step,
+			ok:   true,
+		},
+		{
+			desc: "validate previous code",
+			code: totp.Generate(secret, now.Add(-totp.Period)),
+			last: 0,
+			step: step - 1,
+			ok:   true,
+		},
+		{
+			desc: "validate next code",
+			code: totp.Generate(secret, now.Add(totp.Period)),
+			last: 0,
+			step: step + 1,
+			ok:   true,
+		},
+		{
+			desc: "validate expired code",
+			code: totp.Generate(secret, now.Add(-2*totp.Period)),
+			last: 0,
+			ok:   false,
+		},
+		{
+			desc: "validate used code",
+			code: totp.Generate(secret, now),
+			last: step,
+			ok:   false,
+		},
+		{
+			desc: "validate code with invalid length",
+			code: "12345",
+			last: 0,
+			ok:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		step, ok := totp.Validate(secret, tc.code, now, tc.last)
+		assert.Equal(t, tc.ok, ok, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.ok, ok))
+		assert.Equal(t, tc.step, step, fmt.Sprintf("%s: expected step %d got %d", tc.desc, tc.step, step))
+	}
+}
+
+func TestURI(t *testing.T) {
+	u, err := url.Parse(totp.URI(secret, "Mainflux", "user@example.com"))
+	require.Nil(t, err, fmt.Sprintf("parsing URI expected to succeed: %s", err))
+
+	expected := url.Values{
+		"secret":    {"GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"},
+		"issuer":    {"Mainflux"},
+		"algorithm": {"SHA1"},
+		"digits":    {"6"},
+		"period":    {"30"},
+	}
+	assert.Equal(t, "otpauth", u.Scheme, "unexpected URI scheme")
+	assert.Equal(t, "totp", u.Host, "unexpected URI type")
+	assert.Equal(t, "/Mainflux:user@example.com", u.Path, "unexpected URI label")
+	assert.Equal(t, expected, u.Query(), fmt.Sprintf("expected query %v got %v", expected, u.Query()))
+}
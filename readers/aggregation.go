@@ -0,0 +1,200 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+const (
+	// AvgAggregation represents the average value aggregation key.
+	AvgAggregation = "avg"
+	// MinAggregation represents the minimum value aggregation key.
+	MinAggregation = "min"
+	// MaxAggregation represents the maximum value aggregation key.
+	MaxAggregation = "max"
+	// SumAggregation represents the sum of values aggregation key.
+	SumAggregation = "sum"
+	// CountAggregation represents the number of values aggregation key.
+	CountAggregation = "count"
+	// FirstAggregation represents the earliest value aggregation key.
+	FirstAggregation = "first"
+	// LastAggregation represents the latest value aggregation key.
+	LastAggregation = "last"
+)
+
+var (
+	// ErrInvalidAggregation indicates unsupported aggregation function.
+	ErrInvalidAggregation = errors.New("invalid aggregation")
+
+	// ErrInvalidInterval indicates malformed or too short aggregation interval.
+	ErrInvalidInterval = errors.New("invalid aggregation interval")
+
+	// ErrUnboundedRange indicates that the query which reads all the matching
+	// messages isn't limited to the time range set by both from and to.
+	ErrUnboundedRange = errors.New("time range must be set by from and to")
+)
+
+// Aggregate represents the value calculated by applying the aggregation
+// function to the values of the messages with the same name and publisher
+// received within a single time interval.
+type Aggregate struct {
+	Time      float64 `json:"time"`
+	Name      string  `json:"name,omitempty"`
+	Publisher string  `json:"publisher,omitempty"`
+	Value     float64 `json:"value"`
+}
+
+// ValidAggregation checks if the given aggregation key is supported.
+func ValidAggregation(aggregation string) bool {
+	switch aggregation {
+	case AvgAggregation,
+		MinAggregation,
+		MaxAggregation,
+		SumAggregation,
+		CountAggregation,
+		FirstAggregation,
+		LastAggregation:
+		return true
+	default:
+		return false
+	}
+}
+
+// Bounded checks if the page metadata limits messages to the time range
+// set by both from and to. Readers which aggregate messages or pick the
+// latest ones on the reader side use it to avoid reading whole channels.
+func Bounded(pm PageMetadata) bool {
+	return pm.From > 0 && pm.To > pm.From
+}
+
+// ParseInterval parses the aggregation interval (e.g. "30s", "15m" or "1h").
+// Intervals shorter than a second are rejected.
+func ParseInterval(interval string) (time.Duration, error) {
+	d, err := time.ParseDuration(interval)
+	if err != nil || d < time.Second {
+		return 0, ErrInvalidInterval
+	}
+	return d, nil
+}
+
+// AggregateMessages groups numeric SenML messages into fixed time intervals
+// by name and publisher and applies the aggregation function to each group.
+// It is used by the readers whose database lacks the native support for such
+// queries. Aggregates are sorted the same way as SortAggregates does.
+func AggregateMessages(msgs []senml.Message, aggregation string, interval time.Duration) []Aggregate {
+	type key struct {
+		time      float64
+		name      string
+		publisher string
+	}
+	type bucket struct {
+		Aggregate
+		count     float64
+		firstTime float64
+		lastTime  float64
+	}
+
+	step := interval.Seconds()
+	buckets := make(map[key]*bucket)
+	for _, msg := range msgs {
+		if msg.Value == nil {
+			continue
+		}
+		v := *msg.Value
+		k := key{
+			time:      math.Floor(msg.Time/step) * step,
+			name:      msg.Name,
+			publisher: msg.Publisher,
+		}
+
+		b, ok := buckets[k]
+		if !ok {
+			buckets[k] = &bucket{
+				Aggregate: Aggregate{
+					Time:      k.time,
+					Name:      k.name,
+					Publisher: k.publisher,
+					Value:     v,
+				},
+				count:     1,
+				firstTime: msg.Time,
+				lastTime:  msg.Time,
+			}
+			continue
+		}
+
+		b.count++
+		switch aggregation {
+		case AvgAggregation, SumAggregation:
+			b.Value += v
+		case MinAggregation:
+			b.Value = math.Min(b.Value, v)
+		case MaxAggregation:
+			b.Value = math.Max(b.Value, v)
+		case FirstAggregation:
+			if msg.Time < b.firstTime {
+				b.firstTime = msg.Time
+				b.Value = v
+			}
+		case LastAggregation:
+			if msg.Time >= b.lastTime {
+				b.lastTime = msg.Time
+				b.Value = v
+			}
+		}
+	}
+
+	aggs := make([]Aggregate, 0, len(buckets))
+	for _, b := range buckets {
+		switch aggregation {
+		case AvgAggregation:
+			b.Value /= b.count
+		case CountAggregation:
+			b.Value = b.count
+		}
+		aggs = append(aggs, b.Aggregate)
+	}
+	SortAggregates(aggs)
+
+	return aggs
+}
+
+// SortAggregates sorts aggregates starting from the latest time interval.
+// Aggregates of the same interval are sorted by name and publisher.
+func SortAggregates(aggs []Aggregate) {
+	sort.Slice(aggs, func(i, j int) bool {
+		if aggs[i].Time != aggs[j].Time {
+			return aggs[i].Time > aggs[j].Time
+		}
+		if aggs[i].Name != aggs[j].Name {
+			return aggs[i].Name < aggs[j].Name
+		}
+		return aggs[i].Publisher < aggs[j].Publisher
+	})
+}
+
+// PageAggregates returns a page of sorted aggregates as messages.
+func PageAggregates(aggs []Aggregate, offset, limit uint64) []Message {
+	msgs := []Message{}
+	total := uint64(len(aggs))
+	if offset >= total {
+		return msgs
+	}
+
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	for _, a := range aggs[offset:end] {
+		msgs = append(msgs, a)
+	}
+
+	return msgs
+}
@@ -30,3 +30,40 @@ func listMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func exportMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportMessagesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res := exportRes{
+			output: req.output,
+			format: req.pageMeta.Format,
+			export: func(handler readers.MessageHandler) error {
+				return svc.Export(req.chanID, req.pageMeta, handler)
+			},
+		}
+
+		return res, nil
+	}
+}
+
+func latestValuesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(_ context.Context, request interface{}) (interface{}, error) {
+		req := request.(latestValuesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		msgs, err := svc.LatestValues(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return latestRes{Messages: msgs}, nil
+	}
+}
@@ -4,15 +4,22 @@
 package api_test
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/mainflux/mainflux"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/messaging/memory"
 	"github.com/mainflux/mainflux/pkg/transformers/senml"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/readers"
@@ -45,7 +52,11 @@ var (
 )
 
 func newServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient) *httptest.Server {
-	mux := api.MakeHandler(repo, tc, svcName)
+	return newLiveServer(repo, tc, nil)
+}
+
+func newLiveServer(repo readers.MessageRepository, tc mainflux.ThingsServiceClient, stream readers.LiveStream) *httptest.Server {
+	mux := api.MakeHandler(repo, tc, stream, svcName)
 	return httptest.NewServer(mux)
 }
 
@@ -187,6 +198,12 @@ func TestReadAll(t *testing.T) {
 			token:  invalid,
 			status: http.StatusForbidden,
 		},
+		{
+			desc:   "read page with publish-only thing key",
+			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&limit=10", ts.URL, chanID),
+			token:  mocks.PublisherToken,
+			status: http.StatusForbidden,
+		},
 		{
 			desc:   "read page with multiple offset",
 			url:    fmt.Sprintf("%s/channels/%s/messages?offset=0&offset=1&limit=10", ts.URL, chanID),
@@ -437,6 +454,598 @@ func TestReadAll(t *testing.T) {
 	}
 }
 
+func TestReadAggregates(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Two one-minute intervals with six messages each.
+	var base float64 = 1600000800
+	var messages []senml.Message
+	for i := 0; i < 12; i++ {
+		val := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      base + float64(i*10),
+			Value:     &val,
+		})
+	}
+	messages = append(messages, senml.Message{
+		Channel:     chanID,
+		Publisher:   pubID,
+		Protocol:    mqttProt,
+		Name:        msgName,
+		Time:        base,
+		StringValue: &vs,
+	})
+
+	svc := mocks.NewThingsService()
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, svc)
+	defer ts.Close()
+
+	aggs := func(latest, earliest float64) []readers.Aggregate {
+		return []readers.Aggregate{
+			{Time: base + 60, Name: msgName, Publisher: pubID, Value: latest},
+			{Time: base, Name: msgName, Publisher: pubID, Value: earliest},
+		}
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		status int
+		total  uint64
+		res    []readers.Aggregate
+	}{
+		{
+			desc:   "read average values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(8.5, 2.5),
+		},
+		{
+			desc:   "read minimal values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=min&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(6, 0),
+		},
+		{
+			desc:   "read maximal values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=max&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(11, 5),
+		},
+		{
+			desc:   "read sum of values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=sum&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(51, 15),
+		},
+		{
+			desc:   "read count of values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=count&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(6, 6),
+		},
+		{
+			desc:   "read first values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=first&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(6, 0),
+		},
+		{
+			desc:   "read last values",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=last&interval=1m", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(11, 5),
+		},
+		{
+			desc:   "read last values with limit",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=last&interval=1m&limit=1", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  2,
+			res:    aggs(11, 5)[:1],
+		},
+		{
+			desc:   "read sum of values over a single interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=sum&interval=1h", ts.URL, chanID),
+			status: http.StatusOK,
+			total:  1,
+			res:    []readers.Aggregate{{Time: 1599998400, Name: msgName, Publisher: pubID, Value: 66}},
+		},
+		{
+			desc:   "read values with invalid aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=median&interval=1m", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read values with aggregation and without interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read values with interval and without aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?interval=1m", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read values with invalid interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=minute", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read values with interval shorter than a second",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=10ms", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read JSON messages with aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=1m&format=json", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var page aggregatesPageRes
+		json.NewDecoder(res.Body).Decode(&page)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.res, page.Messages, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, page.Messages))
+	}
+}
+
+func TestLatestValues(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := float64(time.Now().Unix())
+	var messages []senml.Message
+	for i, name := range []string{"temperature", "humidity"} {
+		for j, pub := range []string{pubID, pubID2} {
+			for k := 0; k < 3; k++ {
+				val := float64(i*100 + j*10 + k)
+				messages = append(messages, senml.Message{
+					Channel:   chanID,
+					Publisher: pub,
+					Protocol:  mqttProt,
+					Name:      name,
+					Time:      now - float64(k*10+j),
+					Value:     &val,
+				})
+			}
+		}
+	}
+
+	svc := mocks.NewThingsService()
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, svc)
+	defer ts.Close()
+
+	// Messages are generated newest first for each name and publisher.
+	humidity, humidity2 := messages[6], messages[9]
+	temperature, temperature2 := messages[0], messages[3]
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		res    []senml.Message
+	}{
+		{
+			desc:   "read latest values",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{humidity, temperature},
+		},
+		{
+			desc:   "read latest values per publisher",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?per_publisher=true", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res:    sortLatest([]senml.Message{humidity, humidity2, temperature, temperature2}),
+		},
+		{
+			desc:   "read latest values with publisher",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?publisher=%s", ts.URL, chanID, pubID2),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{humidity2, temperature2},
+		},
+		{
+			desc:   "read latest values with name",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=temperature&per_publisher=true", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res:    sortLatest([]senml.Message{temperature, temperature2}),
+		},
+		{
+			desc:   "read latest values before given time",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=humidity&to=%f", ts.URL, chanID, now-5),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{messages[7]},
+		},
+		{
+			desc:   "read latest values with non-existent name",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?name=pressure", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			res:    []senml.Message{},
+		},
+		{
+			desc:   "read latest values with invalid per publisher flag",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?per_publisher=invalid", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest values of JSON messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?format=json", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest values with aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest?aggregation=avg&interval=1m", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read latest values with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "read latest values without token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/latest", ts.URL, chanID),
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		var body latestRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, body.Messages, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body.Messages))
+	}
+}
+
+func sortLatest(msgs []senml.Message) []senml.Message {
+	readers.SortLatest(msgs)
+	return msgs
+}
+
+func TestExport(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().Unix()
+	// More messages than fit in a single flush to force chunked response.
+	var messages []senml.Message
+	for i := 0; i < 2*numOfMessages; i++ {
+		val := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Unit:      "C",
+			Time:      float64(now - int64(i)),
+			Value:     &val,
+		})
+	}
+
+	svc := mocks.NewThingsService()
+	repo := mocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		token       string
+		status      int
+		contentType string
+		count       int
+	}{
+		{
+			desc:        "export messages as CSV",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?type=csv", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			count:       len(messages),
+		},
+		{
+			desc:        "export messages using default type",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			count:       len(messages),
+		},
+		{
+			desc:        "export messages as NDJSON",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?type=ndjson", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			count:       len(messages),
+		},
+		{
+			desc:        "export messages as SenML",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?type=senml", ts.URL, chanID),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/senml+json",
+			count:       len(messages),
+		},
+		{
+			desc:        "export messages within time range",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?type=ndjson&from=%f&to=%f", ts.URL, chanID, messages[159].Time, messages[9].Time),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			count:       150,
+		},
+		{
+			desc:        "export messages without matches",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?type=senml&name=%s", ts.URL, chanID, invalid),
+			token:       token,
+			status:      http.StatusOK,
+			contentType: "application/senml+json",
+			count:       0,
+		},
+		{
+			desc:   "export messages with invalid type",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?type=xml", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export JSON messages as SenML",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?type=senml&format=json", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export aggregated messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?aggregation=avg&interval=1m", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid comparator",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?v=1&comparator=invalid", ts.URL, chanID),
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		ct := res.Header.Get("Content-Type")
+		assert.Equal(t, tc.contentType, ct, fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, ct))
+		if tc.count > numOfMessages {
+			assert.Contains(t, res.TransferEncoding, "chunked", fmt.Sprintf("%s: expected chunked transfer encoding", tc.desc))
+		}
+
+		var count int
+		switch tc.contentType {
+		case "text/csv":
+			rows, err := csv.NewReader(res.Body).ReadAll()
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			require.True(t, len(rows) > 0, fmt.Sprintf("%s: expected CSV header", tc.desc))
+			assert.Equal(t, "channel", rows[0][0], fmt.Sprintf("%s: expected CSV header got %v", tc.desc, rows[0]))
+			count = len(rows) - 1
+		case "application/x-ndjson":
+			dec := json.NewDecoder(res.Body)
+			for dec.More() {
+				var msg senml.Message
+				err := dec.Decode(&msg)
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, chanID, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, chanID, msg.Channel))
+				count++
+			}
+		case "application/senml+json":
+			var pack []map[string]interface{}
+			err := json.NewDecoder(res.Body).Decode(&pack)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			for _, rec := range pack {
+				assert.Equal(t, msgName, rec["n"], fmt.Sprintf("%s: expected record name %s got %v", tc.desc, msgName, rec["n"]))
+			}
+			count = len(pack)
+		}
+		res.Body.Close()
+		assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.count, count))
+	}
+}
+
+func TestLive(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	logger, err := log.New(os.Stdout, log.Info.String())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ps := memory.NewBroker().NewPubSub("", logger)
+	defer ps.Close()
+
+	stream := readers.NewLiveStream(ps, senml.New(senml.JSON))
+	repo := mocks.NewMessageRepository(chanID, nil)
+	ts := newLiveServer(repo, mocks.NewThingsService(), stream)
+	defer ts.Close()
+
+	payload := `[{"bn":"dev:","n":"temperature","v":21.5},{"n":"humidity","v":40}]`
+	msg := messaging.Message{
+		Channel:   chanID,
+		Publisher: pubID,
+		Protocol:  mqttProt,
+		Payload:   []byte(payload),
+		Created:   time.Now().UnixNano(),
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		names  []string
+	}{
+		{
+			desc:   "tail channel messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages/live", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			names:  []string{"dev:temperature", "dev:humidity"},
+		},
+		{
+			desc:   "tail channel messages with name",
+			url:    fmt.Sprintf("%s/channels/%s/messages/live?name=dev:humidity", ts.URL, chanID),
+			token:  token,
+			status: http.StatusOK,
+			names:  []string{"dev:humidity"},
+		},
+		{
+			desc:   "tail channel messages using key query parameter",
+			url:    fmt.Sprintf("%s/channels/%s/messages/live?name=dev:humidity&authorization=%s", ts.URL, chanID, token),
+			status: http.StatusOK,
+			names:  []string{"dev:humidity"},
+		},
+		{
+			desc:   "tail channel messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/live", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "tail channel messages without token",
+			url:    fmt.Sprintf("%s/channels/%s/messages/live", ts.URL, chanID),
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			res.Body.Close()
+			continue
+		}
+		ct := res.Header.Get("Content-Type")
+		assert.Equal(t, "text/event-stream", ct, fmt.Sprintf("%s: expected content type text/event-stream got %s", tc.desc, ct))
+
+		// Messages published to other channels must not be delivered.
+		err = ps.Publish("other", messaging.Message{Channel: "other", Payload: []byte(payload)})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		err = ps.Publish(chanID, msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		events := make(chan senml.Message)
+		go func() {
+			defer close(events)
+			scanner := bufio.NewScanner(res.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				if !strings.HasPrefix(line, "data: ") {
+					continue
+				}
+				var m senml.Message
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &m); err != nil {
+					return
+				}
+				events <- m
+			}
+		}()
+
+		for _, name := range tc.names {
+			select {
+			case m := <-events:
+				assert.Equal(t, name, m.Name, fmt.Sprintf("%s: expected message name %s got %s", tc.desc, name, m.Name))
+				assert.Equal(t, chanID, m.Channel, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, chanID, m.Channel))
+				assert.Equal(t, pubID, m.Publisher, fmt.Sprintf("%s: expected publisher %s got %s", tc.desc, pubID, m.Publisher))
+			case <-time.After(time.Second):
+				assert.Fail(t, fmt.Sprintf("%s: expected message %s", tc.desc, name))
+			}
+		}
+		res.Body.Close()
+	}
+}
+
+type aggregatesPageRes struct {
+	Total    uint64              `json:"total"`
+	Messages []readers.Aggregate `json:"messages,omitempty"`
+}
+
+type latestRes struct {
+	Messages []senml.Message `json:"messages"`
+}
+
 type pageRes struct {
 	readers.PageMetadata
 	Total    uint64          `json:"total"`
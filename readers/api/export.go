@@ -0,0 +1,284 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+	"github.com/mainflux/mainflux/readers"
+	msenml "github.com/mainflux/senml"
+)
+
+const (
+	csvType    = "csv"
+	ndjsonType = "ndjson"
+	senmlType  = "senml"
+
+	csvContentType    = "text/csv"
+	ndjsonContentType = "application/x-ndjson"
+	senmlContentType  = "application/senml+json"
+
+	// Number of messages written to the client between two flushes.
+	flushSize = 100
+)
+
+var (
+	errUnexpectedMessage = errors.New("unexpected message type")
+
+	senmlHeader = []string{"channel", "subtopic", "publisher", "protocol", "name", "unit",
+		"time", "update_time", "value", "string_value", "bool_value", "data_value", "sum"}
+	jsonHeader = []string{"channel", "subtopic", "publisher", "protocol", "created", "payload"}
+)
+
+// exportEncoder writes exported messages in a single output format.
+type exportEncoder interface {
+	contentType() string
+	begin() error
+	encode(msg readers.Message) error
+	flush() error
+	end() error
+}
+
+func newExportEncoder(output, format string, w io.Writer) exportEncoder {
+	switch output {
+	case ndjsonType:
+		return ndjsonEncoder{enc: json.NewEncoder(w)}
+	case senmlType:
+		return &senmlEncoder{w: w}
+	default:
+		return csvEncoder{
+			w:     csv.NewWriter(w),
+			senml: format == defFormat,
+		}
+	}
+}
+
+// encodeExport writes messages as they are passed by the repository. Since the
+// status is sent along with the first message, an error that occurs after it
+// aborts the response, so the client can't mistake it for a complete export.
+func encodeExport(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportRes)
+	enc := newExportEncoder(res.output, res.format, w)
+	flusher, _ := w.(http.Flusher)
+
+	started := false
+	start := func() error {
+		w.Header().Set("Content-Type", enc.contentType())
+		w.WriteHeader(http.StatusOK)
+		started = true
+		return enc.begin()
+	}
+
+	count := 0
+	err := res.export(func(msg readers.Message) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := enc.encode(msg); err != nil {
+			return err
+		}
+		count++
+		if count%flushSize == 0 && flusher != nil {
+			if err := enc.flush(); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+		return nil
+	})
+
+	switch {
+	case err != nil && !started:
+		return err
+	case err != nil:
+		panic(http.ErrAbortHandler)
+	case !started:
+		if err := start(); err != nil {
+			return err
+		}
+	}
+
+	return enc.end()
+}
+
+type csvEncoder struct {
+	w     *csv.Writer
+	senml bool
+}
+
+func (enc csvEncoder) contentType() string {
+	return csvContentType
+}
+
+func (enc csvEncoder) begin() error {
+	if enc.senml {
+		return enc.w.Write(senmlHeader)
+	}
+	return enc.w.Write(jsonHeader)
+}
+
+func (enc csvEncoder) encode(msg readers.Message) error {
+	switch m := msg.(type) {
+	case senml.Message:
+		return enc.w.Write([]string{
+			m.Channel,
+			m.Subtopic,
+			m.Publisher,
+			m.Protocol,
+			m.Name,
+			m.Unit,
+			fmtFloat(&m.Time),
+			fmtFloat(&m.UpdateTime),
+			fmtFloat(m.Value),
+			fmtString(m.StringValue),
+			fmtBool(m.BoolValue),
+			fmtString(m.DataValue),
+			fmtFloat(m.Sum),
+		})
+	case map[string]interface{}:
+		pld, err := json.Marshal(m["payload"])
+		if err != nil {
+			return err
+		}
+		return enc.w.Write([]string{
+			fmtValue(m["channel"]),
+			fmtValue(m["subtopic"]),
+			fmtValue(m["publisher"]),
+			fmtValue(m["protocol"]),
+			fmtValue(m["created"]),
+			string(pld),
+		})
+	default:
+		return errUnexpectedMessage
+	}
+}
+
+// flush writes buffered rows since csv.Writer doesn't write them on its own.
+func (enc csvEncoder) flush() error {
+	enc.w.Flush()
+	return enc.w.Error()
+}
+
+func (enc csvEncoder) end() error {
+	return enc.flush()
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (enc ndjsonEncoder) contentType() string {
+	return ndjsonContentType
+}
+
+func (enc ndjsonEncoder) begin() error {
+	return nil
+}
+
+func (enc ndjsonEncoder) encode(msg readers.Message) error {
+	return enc.enc.Encode(msg)
+}
+
+func (enc ndjsonEncoder) flush() error {
+	return nil
+}
+
+func (enc ndjsonEncoder) end() error {
+	return nil
+}
+
+// senmlEncoder writes messages as a single SenML pack, i.e. a JSON array of
+// resolved SenML records.
+type senmlEncoder struct {
+	w     io.Writer
+	empty bool
+}
+
+func (enc *senmlEncoder) contentType() string {
+	return senmlContentType
+}
+
+func (enc *senmlEncoder) begin() error {
+	enc.empty = true
+	_, err := io.WriteString(enc.w, "[")
+	return err
+}
+
+func (enc *senmlEncoder) encode(msg readers.Message) error {
+	m, ok := msg.(senml.Message)
+	if !ok {
+		return errUnexpectedMessage
+	}
+
+	rec, err := json.Marshal(msenml.Record{
+		Name:        m.Name,
+		Unit:        m.Unit,
+		Time:        m.Time,
+		UpdateTime:  m.UpdateTime,
+		Value:       m.Value,
+		StringValue: m.StringValue,
+		DataValue:   m.DataValue,
+		BoolValue:   m.BoolValue,
+		Sum:         m.Sum,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !enc.empty {
+		if _, err := io.WriteString(enc.w, ","); err != nil {
+			return err
+		}
+	}
+	enc.empty = false
+	_, err = enc.w.Write(rec)
+	return err
+}
+
+func (enc *senmlEncoder) flush() error {
+	return nil
+}
+
+func (enc *senmlEncoder) end() error {
+	_, err := io.WriteString(enc.w, "]")
+	return err
+}
+
+func fmtFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func fmtString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func fmtBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func fmtValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
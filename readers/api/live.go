@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/readers"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	authorizationKey       = "authorization"
+
+	// Interval of the comments sent to keep idle connections open.
+	keepAlive = 30 * time.Second
+)
+
+var errStreamingUnsupported = errors.New("streaming unsupported")
+
+// handleLive streams the messages published to the channel as Server-Sent
+// Events until the client disconnects.
+func handleLive(stream readers.LiveStream) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		chanID := bone.GetValue(r, "chanID")
+		if chanID == "" {
+			encodeError(ctx, errors.ErrInvalidQueryParams, w)
+			return
+		}
+
+		// Browsers are not able to set EventSource request headers,
+		// so thing key can be passed as a query parameter as well.
+		if r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", r.URL.Query().Get(authorizationKey))
+		}
+		if err := authorize(r, chanID); err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		pm, err := readPageMetadata(r)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			encodeError(ctx, errStreamingUnsupported, w)
+			return
+		}
+
+		tail, err := stream.Subscribe(chanID, pm)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+		defer stream.Unsubscribe(tail)
+
+		w.Header().Set("Content-Type", eventStreamContentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		ticker := time.NewTicker(keepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case msg := <-tail.Messages():
+				data, err := json.Marshal(msg)
+				if err != nil {
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
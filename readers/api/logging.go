@@ -40,3 +40,29 @@ func (lm *loggingMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (p
 
 	return lm.svc.ReadAll(chanID, rpm)
 }
+
+func (lm *loggingMiddleware) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method export for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Export(chanID, rpm, handler)
+}
+
+func (lm *loggingMiddleware) LatestValues(chanID string, rpm readers.PageMetadata) (msgs []readers.Message, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method latest_values for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.LatestValues(chanID, rpm)
+}
@@ -37,3 +37,21 @@ func (mm *metricsMiddleware) ReadAll(chanID string, rpm readers.PageMetadata) (r
 
 	return mm.svc.ReadAll(chanID, rpm)
 }
+
+func (mm *metricsMiddleware) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "export").Add(1)
+		mm.latency.With("method", "export").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Export(chanID, rpm, handler)
+}
+
+func (mm *metricsMiddleware) LatestValues(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "latest_values").Add(1)
+		mm.latency.With("method", "latest_values").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.LatestValues(chanID, rpm)
+}
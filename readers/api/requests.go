@@ -21,14 +21,83 @@ func (req listMessagesReq) validate() error {
 	if req.pageMeta.Limit < 1 || req.pageMeta.Offset < 0 {
 		return errors.ErrInvalidQueryParams
 	}
-	if req.pageMeta.Comparator != "" &&
-		req.pageMeta.Comparator != readers.EqualKey &&
-		req.pageMeta.Comparator != readers.LowerThanKey &&
-		req.pageMeta.Comparator != readers.LowerThanEqualKey &&
-		req.pageMeta.Comparator != readers.GreaterThanKey &&
-		req.pageMeta.Comparator != readers.GreaterThanEqualKey {
+	if !validComparator(req.pageMeta.Comparator) {
 		return errors.ErrInvalidQueryParams
 	}
+	if req.pageMeta.Aggregation != "" || req.pageMeta.Interval != "" {
+		if !readers.ValidAggregation(req.pageMeta.Aggregation) {
+			return errors.ErrInvalidQueryParams
+		}
+		if _, err := readers.ParseInterval(req.pageMeta.Interval); err != nil {
+			return errors.Wrap(errors.ErrInvalidQueryParams, err)
+		}
+		// Aggregation is supported only for the numeric SenML values.
+		if req.pageMeta.Format != defFormat {
+			return errors.ErrInvalidQueryParams
+		}
+	}
 
 	return nil
 }
+
+type exportMessagesReq struct {
+	chanID   string
+	output   string
+	pageMeta readers.PageMetadata
+}
+
+func (req exportMessagesReq) validate() error {
+	if !validComparator(req.pageMeta.Comparator) {
+		return errors.ErrInvalidQueryParams
+	}
+	if req.pageMeta.Aggregation != "" || req.pageMeta.Interval != "" {
+		return errors.ErrInvalidQueryParams
+	}
+
+	switch req.output {
+	case csvType, ndjsonType:
+		return nil
+	case senmlType:
+		// Only SenML messages can be exported as SenML records.
+		if req.pageMeta.Format != defFormat {
+			return errors.ErrInvalidQueryParams
+		}
+		return nil
+	default:
+		return errors.ErrInvalidQueryParams
+	}
+}
+
+type latestValuesReq struct {
+	chanID   string
+	pageMeta readers.PageMetadata
+}
+
+func (req latestValuesReq) validate() error {
+	if !validComparator(req.pageMeta.Comparator) {
+		return errors.ErrInvalidQueryParams
+	}
+	if req.pageMeta.Aggregation != "" || req.pageMeta.Interval != "" {
+		return errors.ErrInvalidQueryParams
+	}
+	// Only SenML messages have names.
+	if req.pageMeta.Format != defFormat {
+		return errors.ErrInvalidQueryParams
+	}
+
+	return nil
+}
+
+func validComparator(comparator string) bool {
+	switch comparator {
+	case "",
+		readers.EqualKey,
+		readers.LowerThanKey,
+		readers.LowerThanEqualKey,
+		readers.GreaterThanKey,
+		readers.GreaterThanEqualKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -30,6 +30,32 @@ func (res pageRes) Empty() bool {
 	return false
 }
 
+var _ mainflux.Response = (*latestRes)(nil)
+
+type latestRes struct {
+	Messages []readers.Message `json:"messages"`
+}
+
+func (res latestRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res latestRes) Code() int {
+	return http.StatusOK
+}
+
+func (res latestRes) Empty() bool {
+	return false
+}
+
+// exportRes defers reading the messages until the response is encoded, so
+// they can be written to the client as they are read from the database.
+type exportRes struct {
+	output string
+	format string
+	export func(handler readers.MessageHandler) error
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
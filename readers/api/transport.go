@@ -15,6 +15,7 @@ import (
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/internal/httputil"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"github.com/mainflux/mainflux/readers"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc/codes"
@@ -22,23 +23,28 @@ import (
 )
 
 const (
-	contentType    = "application/json"
-	offsetKey      = "offset"
-	limitKey       = "limit"
-	formatKey      = "format"
-	subtopicKey    = "subtopic"
-	publisherKey   = "publisher"
-	protocolKey    = "protocol"
-	nameKey        = "name"
-	valueKey       = "v"
-	stringValueKey = "vs"
-	dataValueKey   = "vd"
-	comparatorKey  = "comparator"
-	fromKey        = "from"
-	toKey          = "to"
-	defLimit       = 10
-	defOffset      = 0
-	defFormat      = "messages"
+	contentType     = "application/json"
+	offsetKey       = "offset"
+	limitKey        = "limit"
+	formatKey       = "format"
+	subtopicKey     = "subtopic"
+	publisherKey    = "publisher"
+	protocolKey     = "protocol"
+	nameKey         = "name"
+	valueKey        = "v"
+	stringValueKey  = "vs"
+	dataValueKey    = "vd"
+	comparatorKey   = "comparator"
+	fromKey         = "from"
+	toKey           = "to"
+	aggregationKey  = "aggregation"
+	intervalKey     = "interval"
+	typeKey         = "type"
+	perPublisherKey = "per_publisher"
+	defLimit        = 10
+	defOffset       = 0
+	defFormat       = "messages"
+	defType         = csvType
 )
 
 var (
@@ -46,8 +52,9 @@ var (
 	auth                  mainflux.ThingsServiceClient
 )
 
-// MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, svcName string) http.Handler {
+// MakeHandler returns a HTTP handler for API endpoints. Live messages endpoint
+// is exposed only if the live stream is provided.
+func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient, stream readers.LiveStream, svcName string) http.Handler {
 	auth = tc
 
 	opts := []kithttp.ServerOption{
@@ -62,6 +69,24 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		opts...,
 	))
 
+	mux.Get("/channels/:chanID/messages/export", kithttp.NewServer(
+		exportMessagesEndpoint(svc),
+		decodeExport,
+		encodeExport,
+		opts...,
+	))
+
+	mux.Get("/channels/:chanID/messages/latest", kithttp.NewServer(
+		latestValuesEndpoint(svc),
+		decodeLatest,
+		encodeResponse,
+		opts...,
+	))
+
+	if stream != nil {
+		mux.GetFunc("/channels/:chanID/messages/live", handleLive(stream))
+	}
+
 	mux.GetFunc("/version", mainflux.Version(svcName))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -78,99 +103,179 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	offset, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	pm, err := readPageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	limit, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	req := listMessagesReq{
+		chanID:   chanID,
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+func decodeExport(_ context.Context, r *http.Request) (interface{}, error) {
+	chanID := bone.GetValue(r, "chanID")
+	if chanID == "" {
+		return nil, errors.ErrInvalidQueryParams
+	}
+
+	if err := authorize(r, chanID); err != nil {
+		return nil, err
+	}
+
+	pm, err := readPageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	format, err := httputil.ReadStringQuery(r, formatKey, defFormat)
+	output, err := httputil.ReadStringQuery(r, typeKey, defType)
 	if err != nil {
 		return nil, err
 	}
 
-	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
+	req := exportMessagesReq{
+		chanID:   chanID,
+		output:   output,
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+func decodeLatest(_ context.Context, r *http.Request) (interface{}, error) {
+	chanID := bone.GetValue(r, "chanID")
+	if chanID == "" {
+		return nil, errors.ErrInvalidQueryParams
+	}
+
+	if err := authorize(r, chanID); err != nil {
+		return nil, err
+	}
+
+	pm, err := readPageMetadata(r)
 	if err != nil {
 		return nil, err
 	}
 
-	publisher, err := httputil.ReadStringQuery(r, publisherKey, "")
+	pm.PerPublisher, err = httputil.ReadBoolQuery(r, perPublisherKey, false)
 	if err != nil {
 		return nil, err
 	}
 
+	req := latestValuesReq{
+		chanID:   chanID,
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+func readPageMetadata(r *http.Request) (readers.PageMetadata, error) {
+	offset, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	limit, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	format, err := httputil.ReadStringQuery(r, formatKey, defFormat)
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	subtopic, err := httputil.ReadStringQuery(r, subtopicKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	publisher, err := httputil.ReadStringQuery(r, publisherKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
 	protocol, err := httputil.ReadStringQuery(r, protocolKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	name, err := httputil.ReadStringQuery(r, nameKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	v, err := httputil.ReadFloatQuery(r, valueKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	comparator, err := httputil.ReadStringQuery(r, comparatorKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vs, err := httputil.ReadStringQuery(r, stringValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	vd, err := httputil.ReadStringQuery(r, dataValueKey, "")
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	from, err := httputil.ReadFloatQuery(r, fromKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
 	to, err := httputil.ReadFloatQuery(r, toKey, 0)
 	if err != nil {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 
-	req := listMessagesReq{
-		chanID: chanID,
-		pageMeta: readers.PageMetadata{
-			Offset:      offset,
-			Limit:       limit,
-			Format:      format,
-			Subtopic:    subtopic,
-			Publisher:   publisher,
-			Protocol:    protocol,
-			Name:        name,
-			Value:       v,
-			Comparator:  comparator,
-			StringValue: vs,
-			DataValue:   vd,
-			From:        from,
-			To:          to,
-		},
+	aggregation, err := httputil.ReadStringQuery(r, aggregationKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	interval, err := httputil.ReadStringQuery(r, intervalKey, "")
+	if err != nil {
+		return readers.PageMetadata{}, err
+	}
+
+	pm := readers.PageMetadata{
+		Offset:      offset,
+		Limit:       limit,
+		Format:      format,
+		Subtopic:    subtopic,
+		Publisher:   publisher,
+		Protocol:    protocol,
+		Name:        name,
+		Value:       v,
+		Comparator:  comparator,
+		StringValue: vs,
+		DataValue:   vd,
+		From:        from,
+		To:          to,
+		Aggregation: aggregation,
+		Interval:    interval,
 	}
 
 	vb, err := readBoolValueQuery(r, "vb")
 	if err != nil && err != errors.ErrNotFoundParam {
-		return nil, err
+		return readers.PageMetadata{}, err
 	}
 	if err == nil {
-		req.pageMeta.BoolValue = vb
+		pm.BoolValue = vb
 	}
 
-	return req, nil
+	return pm, nil
 }
 
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
@@ -194,7 +299,8 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, nil):
-	case errors.Contains(err, errors.ErrInvalidQueryParams):
+	case errors.Contains(err, errors.ErrInvalidQueryParams),
+		errors.Contains(err, readers.ErrUnboundedRange):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errUnauthorizedAccess):
 		w.WriteHeader(http.StatusForbidden)
@@ -219,7 +325,7 @@ func authorize(r *http.Request, chanID string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	_, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: token, ChanID: chanID})
+	_, err := auth.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: token, ChanID: chanID, Action: messaging.SubscribeAction})
 	if err != nil {
 		e, ok := status.FromError(err)
 		if ok && e.Code() == codes.PermissionDenied {
@@ -39,6 +39,10 @@ func New(session *gocql.Session) readers.MessageRepository {
 }
 
 func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return cr.readAggregates(chanID, rpm)
+	}
+
 	format := defTable
 	if rpm.Format != "" {
 		format = rpm.Format
@@ -74,45 +78,142 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		Messages:     []readers.Message{},
 	}
 
-	switch format {
-	case defTable:
-		for scanner.Next() {
-			var msg senml.Message
-			err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
-				&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
-				&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
-			if err != nil {
-				if e, ok := err.(gocql.RequestError); ok {
-					if e.Code() == undefinedTableCode {
-						return readers.MessagesPage{}, nil
-					}
+	for scanner.Next() {
+		msg, err := scanMessage(format, scanner)
+		if err != nil {
+			if e, ok := err.(gocql.RequestError); ok {
+				if e.Code() == undefinedTableCode {
+					return readers.MessagesPage{}, nil
 				}
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 			}
-			page.Messages = append(page.Messages, msg)
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 		}
-	default:
-		for scanner.Next() {
-			var msg jsonMessage
-			err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload)
-			if err != nil {
-				if e, ok := err.(gocql.RequestError); ok {
-					if e.Code() == undefinedTableCode {
-						return readers.MessagesPage{}, nil
-					}
-				}
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		page.Messages = append(page.Messages, msg)
+	}
+
+	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+		if e, ok := err.(gocql.RequestError); ok {
+			if e.Code() == undefinedTableCode {
+				return readers.MessagesPage{}, nil
 			}
-			m, err := msg.toMap()
-			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	return page, nil
+}
+
+func (cr cassandraRepository) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) error {
+	format := defTable
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	q, vals := buildQuery(chanID, rpm)
+	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
+		value, string_value, bool_value, data_value, sum, time,
+		update_time FROM messages WHERE channel = ? %s ALLOW FILTERING`, q)
+	if format != defTable {
+		selectCQL = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, created, payload FROM %s WHERE channel = ? %s
+			ALLOW FILTERING`, format, q)
+	}
+
+	iter := cr.session.Query(selectCQL, vals[:len(vals)-1]...).Iter()
+	scanner := iter.Scanner()
+	for scanner.Next() {
+		msg, err := scanMessage(format, scanner)
+		if err != nil {
+			iter.Close()
+			return errors.Wrap(errReadMessages, err)
+		}
+		if err := handler(msg); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	if err := iter.Close(); err != nil {
+		if e, ok := err.(gocql.RequestError); ok {
+			if e.Code() == undefinedTableCode {
+				return nil
 			}
-			m["payload"] = jsont.ParseFlat(m["payload"])
-			page.Messages = append(page.Messages, m)
 		}
+		return errors.Wrap(errReadMessages, err)
 	}
 
-	if err := cr.session.Query(countCQL, vals[:len(vals)-1]...).Scan(&page.Total); err != nil {
+	return nil
+}
+
+// Cassandra doesn't support grouping by arbitrary columns, so the latest
+// messages are picked on the reader side. To bound the number of the read
+// messages, the time range has to be set.
+func (cr cassandraRepository) LatestValues(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
+	if !readers.Bounded(rpm) {
+		return nil, errors.Wrap(errReadMessages, readers.ErrUnboundedRange)
+	}
+
+	q, vals := buildQuery(chanID, rpm)
+	selectCQL := fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit,
+		value, string_value, bool_value, data_value, sum, time,
+		update_time FROM %s WHERE channel = ? %s ALLOW FILTERING`, defTable, q)
+
+	iter := cr.session.Query(selectCQL, vals[:len(vals)-1]...).Iter()
+	scanner := iter.Scanner()
+
+	var msgs []senml.Message
+	for scanner.Next() {
+		msg, err := scanMessage(defTable, scanner)
+		if err != nil {
+			iter.Close()
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, msg.(senml.Message))
+	}
+	if err := iter.Close(); err != nil {
+		if e, ok := err.(gocql.RequestError); ok {
+			if e.Code() == undefinedTableCode {
+				return []readers.Message{}, nil
+			}
+		}
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return readers.LatestMessages(msgs, rpm.PerPublisher), nil
+}
+
+// Cassandra doesn't support grouping by arbitrary columns, so messages are
+// aggregated on the reader side. To bound the number of the read messages,
+// the time range has to be set.
+func (cr cassandraRepository) readAggregates(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if !readers.Bounded(rpm) {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, readers.ErrUnboundedRange)
+	}
+
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if !readers.ValidAggregation(rpm.Aggregation) {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, readers.ErrInvalidAggregation)
+	}
+
+	q, vals := buildQuery(chanID, rpm)
+	selectCQL := fmt.Sprintf(`SELECT name, publisher, value, time FROM %s
+		WHERE channel = ? %s ALLOW FILTERING`, defTable, q)
+
+	iter := cr.session.Query(selectCQL, vals[:len(vals)-1]...).Iter()
+	scanner := iter.Scanner()
+
+	var msgs []senml.Message
+	for scanner.Next() {
+		var msg senml.Message
+		if err := scanner.Scan(&msg.Name, &msg.Publisher, &msg.Value, &msg.Time); err != nil {
+			iter.Close()
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := iter.Close(); err != nil {
 		if e, ok := err.(gocql.RequestError); ok {
 			if e.Code() == undefinedTableCode {
 				return readers.MessagesPage{}, nil
@@ -121,7 +222,37 @@ func (cr cassandraRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 
-	return page, nil
+	aggs := readers.AggregateMessages(msgs, rpm.Aggregation, interval)
+
+	return readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        uint64(len(aggs)),
+		Messages:     readers.PageAggregates(aggs, rpm.Offset, rpm.Limit),
+	}, nil
+}
+
+func scanMessage(format string, scanner gocql.Scanner) (readers.Message, error) {
+	if format == defTable {
+		var msg senml.Message
+		err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol,
+			&msg.Name, &msg.Unit, &msg.Value, &msg.StringValue, &msg.BoolValue,
+			&msg.DataValue, &msg.Sum, &msg.Time, &msg.UpdateTime)
+		if err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+
+	var msg jsonMessage
+	if err := scanner.Scan(&msg.Channel, &msg.Subtopic, &msg.Publisher, &msg.Protocol, &msg.Created, &msg.Payload); err != nil {
+		return nil, err
+	}
+	m, err := msg.toMap()
+	if err != nil {
+		return nil, err
+	}
+	m["payload"] = jsont.ParseFlat(m["payload"])
+	return m, nil
 }
 
 func buildQuery(chanID string, rpm readers.PageMetadata) (string, []interface{}) {
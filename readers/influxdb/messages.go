@@ -3,6 +3,7 @@ package influxdb
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
@@ -17,7 +18,8 @@ import (
 )
 
 const (
-	countCol = "count_protocol"
+	countCol  = "count_protocol"
+	chunkSize = 1000
 	// Measurement for SenML messages
 	defMeasurement = "messages"
 )
@@ -40,6 +42,10 @@ func New(client influxdata.Client, database string) readers.MessageRepository {
 }
 
 func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return repo.readAggregates(chanID, rpm)
+	}
+
 	format := defMeasurement
 	if rpm.Format != "" {
 		format = rpm.Format
@@ -91,6 +97,161 @@ func (repo *influxRepository) ReadAll(chanID string, rpm readers.PageMetadata) (
 	return page, nil
 }
 
+func (repo *influxRepository) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) error {
+	format := defMeasurement
+	if rpm.Format != "" {
+		format = rpm.Format
+	}
+
+	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY time DESC`, format, fmtCondition(chanID, rpm))
+	q := influxdata.Query{
+		Command:   cmd,
+		Database:  repo.database,
+		Chunked:   true,
+		ChunkSize: chunkSize,
+	}
+
+	resp, err := repo.client.QueryAsChunk(q)
+	if err != nil {
+		return errors.Wrap(errReadMessages, err)
+	}
+	defer resp.Close()
+
+	for {
+		r, err := resp.NextResponse()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(errReadMessages, err)
+		}
+		if r.Error() != nil {
+			return errors.Wrap(errReadMessages, r.Error())
+		}
+
+		for _, res := range r.Results {
+			for _, series := range res.Series {
+				for _, v := range series.Values {
+					msg, err := parseMessage(format, series.Columns, v)
+					if err != nil {
+						return errors.Wrap(errReadMessages, err)
+					}
+					if err := handler(msg); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+}
+
+// The time range has to be set, since series of the whole channel would be
+// scanned otherwise.
+func (repo *influxRepository) LatestValues(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
+	if !readers.Bounded(rpm) {
+		return nil, errors.Wrap(errReadMessages, readers.ErrUnboundedRange)
+	}
+
+	group := `"name"`
+	if rpm.PerPublisher {
+		group = `"name", "publisher"`
+	}
+
+	// Since LIMIT is applied per series, grouping by tags returns
+	// the newest point of each series.
+	cmd := fmt.Sprintf(`SELECT * FROM %s WHERE %s GROUP BY %s ORDER BY time DESC LIMIT 1`,
+		defMeasurement, fmtCondition(chanID, rpm), group)
+	q := influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	}
+
+	resp, err := repo.client.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return nil, errors.Wrap(errReadMessages, resp.Error())
+	}
+
+	msgs := []senml.Message{}
+	for _, res := range resp.Results {
+		for _, series := range res.Series {
+			for _, v := range series.Values {
+				// Tags used for grouping are not returned as columns.
+				msg := parseSenml(series.Columns, v).(senml.Message)
+				msg.Name = series.Tags["name"]
+				if rpm.PerPublisher {
+					msg.Publisher = series.Tags["publisher"]
+				}
+				msgs = append(msgs, msg)
+			}
+		}
+	}
+	readers.SortLatest(msgs)
+
+	ret := make([]readers.Message, len(msgs))
+	for i, msg := range msgs {
+		ret[i] = msg
+	}
+
+	return ret, nil
+}
+
+// The time range has to be set, since the number of the time intervals
+// isn't bounded otherwise.
+func (repo *influxRepository) readAggregates(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if !readers.Bounded(rpm) {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, readers.ErrUnboundedRange)
+	}
+
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	fn, err := fmtAggregation(rpm.Aggregation)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	// InfluxDB applies LIMIT and OFFSET per series, so all the aggregates are
+	// fetched and the page is taken after series are merged and sorted.
+	cmd := fmt.Sprintf(`SELECT %s(value) AS value FROM %s WHERE %s GROUP BY time(%dms), "name", "publisher" fill(none)`,
+		fn, defMeasurement, fmtCondition(chanID, rpm), interval.Milliseconds())
+	q := influxdata.Query{
+		Command:  cmd,
+		Database: repo.database,
+	}
+
+	resp, err := repo.client.Query(q)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	if resp.Error() != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, resp.Error())
+	}
+
+	aggs := []readers.Aggregate{}
+	for _, res := range resp.Results {
+		for _, series := range res.Series {
+			for _, v := range series.Values {
+				agg, err := parseAggregate(series.Tags, series.Columns, v)
+				if err != nil {
+					return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+				}
+				aggs = append(aggs, agg)
+			}
+		}
+	}
+	readers.SortAggregates(aggs)
+
+	return readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        uint64(len(aggs)),
+		Messages:     readers.PageAggregates(aggs, rpm.Offset, rpm.Limit),
+	}, nil
+}
+
 func (repo *influxRepository) count(measurement, condition string) (uint64, error) {
 	cmd := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, measurement, condition)
 	q := influxdata.Query{
@@ -171,6 +332,55 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+func fmtAggregation(aggregation string) (string, error) {
+	switch aggregation {
+	case readers.AvgAggregation:
+		return "MEAN", nil
+	case readers.MinAggregation:
+		return "MIN", nil
+	case readers.MaxAggregation:
+		return "MAX", nil
+	case readers.SumAggregation:
+		return "SUM", nil
+	case readers.CountAggregation:
+		return "COUNT", nil
+	case readers.FirstAggregation:
+		return "FIRST", nil
+	case readers.LastAggregation:
+		return "LAST", nil
+	default:
+		return "", readers.ErrInvalidAggregation
+	}
+}
+
+func parseAggregate(tags map[string]string, names []string, fields []interface{}) (readers.Aggregate, error) {
+	agg := readers.Aggregate{
+		Name:      tags["name"],
+		Publisher: tags["publisher"],
+	}
+	for i, name := range names {
+		switch name {
+		case "time":
+			t, err := time.Parse(time.RFC3339Nano, fmt.Sprint(fields[i]))
+			if err != nil {
+				return readers.Aggregate{}, err
+			}
+			agg.Time = float64(t.UnixNano()) / float64(1e9)
+		case "value":
+			num, ok := fields[i].(json.Number)
+			if !ok {
+				continue
+			}
+			v, err := num.Float64()
+			if err != nil {
+				return readers.Aggregate{}, err
+			}
+			agg.Value = v
+		}
+	}
+	return agg, nil
+}
+
 // ParseMessage and parseValues are util methods. Since InfluxDB client returns
 // results in form of rows and columns, this obscure message conversion is needed
 // to return actual []broker.Message from the query result.
@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"sort"
+
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// LatestMessages returns the newest message for each distinct name or, if
+// perPublisher is set, for each distinct name and publisher. It is used by the
+// readers whose database lacks the native support for such queries. Messages
+// are sorted the same way as SortLatest does.
+func LatestMessages(msgs []senml.Message, perPublisher bool) []Message {
+	type key struct {
+		name      string
+		publisher string
+	}
+
+	latest := make(map[key]senml.Message)
+	for _, msg := range msgs {
+		k := key{name: msg.Name}
+		if perPublisher {
+			k.publisher = msg.Publisher
+		}
+		if m, ok := latest[k]; ok && m.Time >= msg.Time {
+			continue
+		}
+		latest[k] = msg
+	}
+
+	ret := make([]senml.Message, 0, len(latest))
+	for _, msg := range latest {
+		ret = append(ret, msg)
+	}
+	SortLatest(ret)
+
+	res := make([]Message, len(ret))
+	for i, msg := range ret {
+		res[i] = msg
+	}
+	return res
+}
+
+// SortLatest orders the latest messages by name and publisher.
+func SortLatest(msgs []senml.Message) {
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Name != msgs[j].Name {
+			return msgs[i].Name < msgs[j].Name
+		}
+		return msgs[i].Publisher < msgs[j].Publisher
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readers
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/transformers"
+	"github.com/mainflux/mainflux/pkg/transformers/senml"
+)
+
+// SubjectAllChannels represents the subject the live stream consumes.
+const SubjectAllChannels = "channels.>"
+
+// Number of messages buffered for a single tail before new ones are dropped.
+const tailBuffer = 100
+
+// ErrNotTailed indicates that the tail is not subscribed to the stream.
+var ErrNotTailed = errors.New("tail is not subscribed")
+
+// LiveStream fans the messages received from the message broker out to the
+// clients tailing the channels.
+type LiveStream interface {
+	// Subscribe starts tailing the channel. Only the messages that match
+	// subtopic, publisher and name of the page metadata are delivered.
+	Subscribe(chanID string, pm PageMetadata) (*Tail, error)
+
+	// Unsubscribe stops delivering messages to the tail and closes its
+	// messages channel.
+	Unsubscribe(t *Tail) error
+}
+
+// Tail represents a single client tailing the channel.
+type Tail struct {
+	chanID string
+	pm     PageMetadata
+	msgs   chan Message
+}
+
+// Messages returns the channel the tailed messages are delivered to.
+func (t *Tail) Messages() <-chan Message {
+	return t.msgs
+}
+
+func (t *Tail) match(msg senml.Message) bool {
+	return (t.pm.Subtopic == "" || t.pm.Subtopic == msg.Subtopic) &&
+		(t.pm.Publisher == "" || t.pm.Publisher == msg.Publisher) &&
+		(t.pm.Name == "" || t.pm.Name == msg.Name)
+}
+
+var _ LiveStream = (*liveStream)(nil)
+
+type liveStream struct {
+	sub         messaging.Subscriber
+	transformer transformers.Transformer
+	mu          sync.Mutex
+	tails       map[string]map[*Tail]bool
+}
+
+// NewLiveStream returns live stream that consumes all the channels while
+// there is at least one tail and transforms received messages to SenML.
+func NewLiveStream(sub messaging.Subscriber, t transformers.Transformer) LiveStream {
+	return &liveStream{
+		sub:         sub,
+		transformer: t,
+		tails:       make(map[string]map[*Tail]bool),
+	}
+}
+
+func (ls *liveStream) Subscribe(chanID string, pm PageMetadata) (*Tail, error) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	// Subscribe to the broker only once and fan the
+	// received messages out to all the tails.
+	if len(ls.tails) == 0 {
+		if err := ls.sub.Subscribe(SubjectAllChannels, ls.handle); err != nil {
+			return nil, err
+		}
+	}
+
+	t := &Tail{
+		chanID: chanID,
+		pm:     pm,
+		msgs:   make(chan Message, tailBuffer),
+	}
+	tails, ok := ls.tails[chanID]
+	if !ok {
+		tails = make(map[*Tail]bool)
+		ls.tails[chanID] = tails
+	}
+	tails[t] = true
+
+	return t, nil
+}
+
+func (ls *liveStream) Unsubscribe(t *Tail) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	tails, ok := ls.tails[t.chanID]
+	if !ok || !tails[t] {
+		return ErrNotTailed
+	}
+	delete(tails, t)
+	close(t.msgs)
+	if len(tails) == 0 {
+		delete(ls.tails, t.chanID)
+	}
+
+	// If there are no tails left, stop consuming the messages.
+	if len(ls.tails) == 0 {
+		return ls.sub.Unsubscribe(SubjectAllChannels)
+	}
+
+	return nil
+}
+
+func (ls *liveStream) handle(msg messaging.Message) error {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	tails := ls.tails[msg.Channel]
+	if len(tails) == 0 {
+		return nil
+	}
+
+	res, err := ls.transformer.Transform(msg)
+	if err != nil {
+		return err
+	}
+	msgs, ok := res.([]senml.Message)
+	if !ok {
+		return nil
+	}
+
+	for t := range tails {
+		for _, m := range msgs {
+			if !t.match(m) {
+				continue
+			}
+			// Don't block the broker because of a slow client.
+			select {
+			case t.msgs <- m:
+			default:
+			}
+		}
+	}
+
+	return nil
+}
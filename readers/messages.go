@@ -26,8 +26,22 @@ type MessageRepository interface {
 	// ReadAll skips given number of messages for given channel and returns next
 	// limited number of messages.
 	ReadAll(chanID string, pm PageMetadata) (MessagesPage, error)
+
+	// Export passes all the messages of the given channel that match the page
+	// metadata to the handler, as they are read from the database. Offset and
+	// limit are ignored and exporting stops at the first handler error.
+	Export(chanID string, pm PageMetadata, handler MessageHandler) error
+
+	// LatestValues returns the newest SenML message for each distinct name
+	// of the given channel, or for each distinct name and publisher if the
+	// page metadata PerPublisher flag is set. Messages that don't match the
+	// page metadata filters are skipped, while offset and limit are ignored.
+	LatestValues(chanID string, pm PageMetadata) ([]Message, error)
 }
 
+// MessageHandler handles a single exported message.
+type MessageHandler func(msg Message) error
+
 // Message represents any message format.
 type Message interface{}
 
@@ -41,20 +55,23 @@ type MessagesPage struct {
 
 // PageMetadata represents the parameters used to create database queries
 type PageMetadata struct {
-	Offset      uint64  `json:"offset"`
-	Limit       uint64  `json:"limit"`
-	Subtopic    string  `json:"subtopic,omitempty"`
-	Publisher   string  `json:"publisher,omitempty"`
-	Protocol    string  `json:"protocol,omitempty"`
-	Name        string  `json:"name,omitempty"`
-	Value       float64 `json:"v,omitempty"`
-	Comparator  string  `json:"comparator,omitempty"`
-	BoolValue   bool    `json:"vb,omitempty"`
-	StringValue string  `json:"vs,omitempty"`
-	DataValue   string  `json:"vd,omitempty"`
-	From        float64 `json:"from,omitempty"`
-	To          float64 `json:"to,omitempty"`
-	Format      string  `json:"format,omitempty"`
+	Offset       uint64  `json:"offset"`
+	Limit        uint64  `json:"limit"`
+	Subtopic     string  `json:"subtopic,omitempty"`
+	Publisher    string  `json:"publisher,omitempty"`
+	Protocol     string  `json:"protocol,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Value        float64 `json:"v,omitempty"`
+	Comparator   string  `json:"comparator,omitempty"`
+	BoolValue    bool    `json:"vb,omitempty"`
+	StringValue  string  `json:"vs,omitempty"`
+	DataValue    string  `json:"vd,omitempty"`
+	From         float64 `json:"from,omitempty"`
+	To           float64 `json:"to,omitempty"`
+	Format       string  `json:"format,omitempty"`
+	Aggregation  string  `json:"aggregation,omitempty"`
+	Interval     string  `json:"interval,omitempty"`
+	PerPublisher bool    `json:"per_publisher,omitempty"`
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation
@@ -38,6 +38,63 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		return readers.MessagesPage{}, nil
 	}
 
+	msgs := repo.filter(chanID, rpm)
+	if rpm.Aggregation != "" {
+		return aggregate(msgs, rpm)
+	}
+
+	numOfMessages := uint64(len(msgs))
+
+	if rpm.Offset >= numOfMessages {
+		return readers.MessagesPage{}, nil
+	}
+
+	if rpm.Limit < 1 {
+		return readers.MessagesPage{}, nil
+	}
+
+	end := rpm.Offset + rpm.Limit
+	if rpm.Offset+rpm.Limit > numOfMessages {
+		end = numOfMessages
+	}
+
+	return readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        uint64(len(msgs)),
+		Messages:     msgs[rpm.Offset:end],
+	}, nil
+}
+
+func (repo *messageRepositoryMock) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) error {
+	repo.mutex.Lock()
+	msgs := []readers.Message{}
+	if rpm.Format == "" || rpm.Format == "messages" {
+		msgs = repo.filter(chanID, rpm)
+	}
+	repo.mutex.Unlock()
+
+	for _, msg := range msgs {
+		if err := handler(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *messageRepositoryMock) LatestValues(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	var msgs []senml.Message
+	for _, m := range repo.filter(chanID, rpm) {
+		msgs = append(msgs, m.(senml.Message))
+	}
+
+	return readers.LatestMessages(msgs, rpm.PerPublisher), nil
+}
+
+func (repo *messageRepositoryMock) filter(chanID string, rpm readers.PageMetadata) []readers.Message {
 	var query map[string]interface{}
 	meta, _ := json.Marshal(rpm)
 	json.Unmarshal(meta, &query)
@@ -140,24 +197,24 @@ func (repo *messageRepositoryMock) ReadAll(chanID string, rpm readers.PageMetada
 		}
 	}
 
-	numOfMessages := uint64(len(msgs))
+	return msgs
+}
 
-	if rpm.Offset >= numOfMessages {
-		return readers.MessagesPage{}, nil
+func aggregate(msgs []readers.Message, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, err
 	}
 
-	if rpm.Limit < 1 {
-		return readers.MessagesPage{}, nil
-	}
-
-	end := rpm.Offset + rpm.Limit
-	if rpm.Offset+rpm.Limit > numOfMessages {
-		end = numOfMessages
+	var senmlMsgs []senml.Message
+	for _, m := range msgs {
+		senmlMsgs = append(senmlMsgs, m.(senml.Message))
 	}
+	aggs := readers.AggregateMessages(senmlMsgs, rpm.Aggregation, interval)
 
 	return readers.MessagesPage{
 		PageMetadata: rpm,
-		Total:        uint64(len(msgs)),
-		Messages:     msgs[rpm.Offset:end],
+		Total:        uint64(len(aggs)),
+		Messages:     readers.PageAggregates(aggs, rpm.Offset, rpm.Limit),
 	}, nil
 }
@@ -9,11 +9,15 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/messaging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// PublisherToken is a key of the thing that is only allowed to publish.
+const PublisherToken = "publisher"
+
 var errUnauthorized = status.Error(codes.PermissionDenied, "missing or invalid credentials provided")
 
 var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
@@ -35,6 +39,10 @@ func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.Ac
 		return nil, errUnauthorized
 	}
 
+	if token == PublisherToken && in.GetAction() != messaging.PublishAction {
+		return nil, errUnauthorized
+	}
+
 	return &mainflux.ThingID{Value: token}, nil
 }
 
@@ -46,6 +54,10 @@ func (svc thingsServiceMock) IsChannelOwner(context.Context, *mainflux.ChannelOw
 	panic("not implemented")
 }
 
+func (svc thingsServiceMock) IsThingOwner(context.Context, *mainflux.ThingOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
 func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
 	panic("not implemented")
 }
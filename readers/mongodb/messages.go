@@ -38,6 +38,10 @@ func New(db *mongo.Database) readers.MessageRepository {
 }
 
 func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return repo.readAggregates(chanID, rpm)
+	}
+
 	format := defCollection
 	order := "time"
 	if rpm.Format != "" && rpm.Format != defCollection {
@@ -59,26 +63,12 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 	defer cursor.Close(context.Background())
 
 	var messages []readers.Message
-	switch format {
-	case defCollection:
-		for cursor.Next(context.Background()) {
-			var m senml.Message
-			if err := cursor.Decode(&m); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
-			}
-
-			messages = append(messages, m)
-		}
-	default:
-		for cursor.Next(context.Background()) {
-			var m map[string]interface{}
-			if err := cursor.Decode(&m); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
-			}
-			m["payload"] = jsont.ParseFlat(m["payload"])
-
-			messages = append(messages, m)
+	for cursor.Next(context.Background()) {
+		m, err := decodeMessage(format, cursor)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 		}
+		messages = append(messages, m)
 	}
 
 	total, err := col.CountDocuments(context.Background(), filter)
@@ -95,6 +85,207 @@ func (repo mongoRepository) ReadAll(chanID string, rpm readers.PageMetadata) (re
 	return mp, nil
 }
 
+func (repo mongoRepository) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) error {
+	format := defCollection
+	order := "time"
+	if rpm.Format != "" && rpm.Format != defCollection {
+		order = "created"
+		format = rpm.Format
+	}
+
+	col := repo.db.Collection(format)
+	filter := fmtCondition(chanID, rpm)
+	cursor, err := col.Find(context.Background(), filter, options.Find().SetSort(map[string]interface{}{order: -1}))
+	if err != nil {
+		return errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	for cursor.Next(context.Background()) {
+		m, err := decodeMessage(format, cursor)
+		if err != nil {
+			return errors.Wrap(errReadMessages, err)
+		}
+		if err := handler(m); err != nil {
+			return err
+		}
+	}
+
+	if err := cursor.Err(); err != nil {
+		return errors.Wrap(errReadMessages, err)
+	}
+
+	return nil
+}
+
+func (repo mongoRepository) LatestValues(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
+	group := bson.D{{Key: "name", Value: "$name"}}
+	if rpm.PerPublisher {
+		group = append(group, bson.E{Key: "publisher", Value: "$publisher"})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: fmtCondition(chanID, rpm)}},
+		{{Key: "$sort", Value: bson.D{{Key: "time", Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: group},
+			{Key: "message", Value: bson.M{"$first": "$$ROOT"}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$message"}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "name", Value: 1},
+			{Key: "publisher", Value: 1},
+		}}},
+	}
+
+	cursor, err := repo.db.Collection(defCollection).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	msgs := []readers.Message{}
+	for cursor.Next(context.Background()) {
+		m, err := decodeMessage(defCollection, cursor)
+		if err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, m)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+
+	return msgs, nil
+}
+
+func decodeMessage(format string, cursor *mongo.Cursor) (readers.Message, error) {
+	if format == defCollection {
+		var m senml.Message
+		if err := cursor.Decode(&m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	var m map[string]interface{}
+	if err := cursor.Decode(&m); err != nil {
+		return nil, err
+	}
+	m["payload"] = jsont.ParseFlat(m["payload"])
+	return m, nil
+}
+
+func (repo mongoRepository) readAggregates(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	acc, err := fmtAccumulator(rpm.Aggregation)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	step := interval.Seconds()
+	filter := append(fmtCondition(chanID, rpm), bson.E{Key: "value", Value: bson.M{"$exists": true}})
+	bucket := bson.M{"$multiply": bson.A{bson.M{"$floor": bson.M{"$divide": bson.A{"$time", step}}}, step}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "time", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "time", Value: bucket},
+				{Key: "name", Value: "$name"},
+				{Key: "publisher", Value: "$publisher"},
+			}},
+			{Key: "value", Value: acc},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "_id.time", Value: -1},
+			{Key: "_id.name", Value: 1},
+			{Key: "_id.publisher", Value: 1},
+		}}},
+	}
+
+	col := repo.db.Collection(defCollection)
+	page := append(pipeline,
+		bson.D{{Key: "$skip", Value: int64(rpm.Offset)}},
+		bson.D{{Key: "$limit", Value: int64(rpm.Limit)}},
+	)
+	cursor, err := col.Aggregate(context.Background(), page)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	messages := []readers.Message{}
+	for cursor.Next(context.Background()) {
+		var res aggregateResult
+		if err := cursor.Decode(&res); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		messages = append(messages, readers.Aggregate{
+			Time:      res.ID.Time,
+			Name:      res.ID.Name,
+			Publisher: res.ID.Publisher,
+			Value:     res.Value,
+		})
+	}
+
+	count := append(pipeline, bson.D{{Key: "$count", Value: "total"}})
+	cursor, err = col.Aggregate(context.Background(), count)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	var total struct {
+		Total uint64 `bson:"total"`
+	}
+	if cursor.Next(context.Background()) {
+		if err := cursor.Decode(&total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+	}
+
+	return readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        total.Total,
+		Messages:     messages,
+	}, nil
+}
+
+type aggregateResult struct {
+	ID struct {
+		Time      float64 `bson:"time"`
+		Name      string  `bson:"name"`
+		Publisher string  `bson:"publisher"`
+	} `bson:"_id"`
+	Value float64 `bson:"value"`
+}
+
+func fmtAccumulator(aggregation string) (bson.M, error) {
+	switch aggregation {
+	case readers.AvgAggregation:
+		return bson.M{"$avg": "$value"}, nil
+	case readers.MinAggregation:
+		return bson.M{"$min": "$value"}, nil
+	case readers.MaxAggregation:
+		return bson.M{"$max": "$value"}, nil
+	case readers.SumAggregation:
+		return bson.M{"$sum": "$value"}, nil
+	case readers.CountAggregation:
+		return bson.M{"$sum": 1}, nil
+	case readers.FirstAggregation:
+		return bson.M{"$first": "$value"}, nil
+	case readers.LastAggregation:
+		return bson.M{"$last": "$value"}, nil
+	default:
+		return nil, readers.ErrInvalidAggregation
+	}
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 	filter := bson.D{
 		bson.E{
@@ -529,6 +529,230 @@ func TestReadJSON(t *testing.T) {
 	}
 }
 
+func TestReadAggregates(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	writer := mwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Two one-minute intervals with six numeric values each.
+	var base float64 = 1600000800
+	messages := []senml.Message{}
+	for i := 0; i < 12; i++ {
+		val := float64(i)
+		messages = append(messages, senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      base + float64(i*10),
+			Value:     &val,
+		})
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreader.New(db)
+
+	aggs := func(latest, earliest float64) []readers.Message {
+		return []readers.Message{
+			readers.Aggregate{Time: base + 60, Name: msgName, Publisher: pubID, Value: latest},
+			readers.Aggregate{Time: base, Name: msgName, Publisher: pubID, Value: earliest},
+		}
+	}
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		page     readers.MessagesPage
+	}{
+		"read average values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.AvgAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(8.5, 2.5)},
+		},
+		"read minimal values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.MinAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(6, 0)},
+		},
+		"read maximal values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.MaxAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(11, 5)},
+		},
+		"read sum of values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.SumAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(51, 15)},
+		},
+		"read count of values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.CountAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(6, 6)},
+		},
+		"read first values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.FirstAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(6, 0)},
+		},
+		"read last values": {
+			pageMeta: readers.PageMetadata{Limit: limit, Aggregation: readers.LastAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(11, 5)},
+		},
+		"read last values with offset": {
+			pageMeta: readers.PageMetadata{Offset: 1, Limit: limit, Aggregation: readers.LastAggregation, Interval: "1m"},
+			page:     readers.MessagesPage{Total: 2, Messages: aggs(11, 5)[1:]},
+		},
+	}
+
+	for desc, tc := range cases {
+		result, err := reader.ReadAll(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.Equal(t, tc.page.Messages, result.Messages, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Messages, result.Messages))
+		assert.Equal(t, tc.page.Total, result.Total, fmt.Sprintf("%s: expected %v got %v", desc, tc.page.Total, result.Total))
+	}
+}
+
+func TestLatestValues(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	writer := mwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID2, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Three values of each name per publisher, the newest one first.
+	now := float64(time.Now().Unix())
+	messages := []senml.Message{}
+	for i, name := range []string{"humidity", msgName} {
+		for j, pub := range []string{pubID, pubID2} {
+			for k := 0; k < 3; k++ {
+				val := float64(i*100 + j*10 + k)
+				messages = append(messages, senml.Message{
+					Channel:   chanID,
+					Publisher: pub,
+					Protocol:  mqttProt,
+					Name:      name,
+					Time:      now - float64(k*10+j),
+					Value:     &val,
+				})
+			}
+		}
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreader.New(db)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		msgs     []readers.Message
+	}{
+		"read latest values": {
+			pageMeta: readers.PageMetadata{},
+			msgs:     []readers.Message{messages[0], messages[6]},
+		},
+		"read latest values per publisher": {
+			pageMeta: readers.PageMetadata{PerPublisher: true},
+			msgs:     []readers.Message{messages[0], messages[3], messages[6], messages[9]},
+		},
+		"read latest values with publisher": {
+			pageMeta: readers.PageMetadata{Publisher: pubID2},
+			msgs:     []readers.Message{messages[3], messages[9]},
+		},
+		"read latest values with name": {
+			pageMeta: readers.PageMetadata{Name: msgName, PerPublisher: true},
+			msgs:     []readers.Message{messages[6], messages[9]},
+		},
+		"read latest values before given time": {
+			pageMeta: readers.PageMetadata{Name: msgName, To: now - 5},
+			msgs:     []readers.Message{messages[7]},
+		},
+		"read latest values with non-existent name": {
+			pageMeta: readers.PageMetadata{Name: "pressure"},
+			msgs:     []readers.Message{},
+		},
+	}
+
+	for desc, tc := range cases {
+		msgs, err := reader.LatestValues(chanID, tc.pageMeta)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.msgs, msgs, fmt.Sprintf("%s: expected %v got %v", desc, tc.msgs, msgs))
+	}
+}
+
+func TestExport(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	writer := mwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := float64(time.Now().Unix())
+	messages := []senml.Message{}
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  mqttProt,
+			Name:      msgName,
+			Time:      now - float64(i),
+			Value:     &v,
+		}
+		messages = append(messages, msg)
+	}
+
+	err = writer.Consume(messages)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := mreader.New(db)
+
+	cases := map[string]struct {
+		pageMeta readers.PageMetadata
+		messages []readers.Message
+	}{
+		"export all messages": {
+			pageMeta: readers.PageMetadata{},
+			messages: fromSenml(messages),
+		},
+		"export messages ignoring offset and limit": {
+			pageMeta: readers.PageMetadata{Offset: 10, Limit: limit},
+			messages: fromSenml(messages),
+		},
+		"export messages within time range": {
+			pageMeta: readers.PageMetadata{From: messages[49].Time, To: messages[9].Time},
+			messages: fromSenml(messages[10:50]),
+		},
+		"export messages with non-existing name": {
+			pageMeta: readers.PageMetadata{Name: "non-existing"},
+			messages: []readers.Message{},
+		},
+	}
+
+	for desc, tc := range cases {
+		msgs := []readers.Message{}
+		err := reader.Export(chanID, tc.pageMeta, func(msg readers.Message) error {
+			msgs = append(msgs, msg)
+			return nil
+		})
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", desc, err))
+		assert.ElementsMatch(t, tc.messages, msgs, fmt.Sprintf("%s: expected %v got %v", desc, tc.messages, msgs))
+	}
+}
+
 func fromSenml(in []senml.Message) []readers.Message {
 	var ret []readers.Message
 	for _, m := range in {
@@ -42,6 +42,10 @@ func New(db *sqlx.DB) readers.MessageRepository {
 }
 
 func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Aggregation != "" {
+		return tr.readAggregates(chanID, rpm)
+	}
+
 	order := "time"
 	format := defTable
 
@@ -54,21 +58,7 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
     WHERE %s ORDER BY %s DESC
 	LIMIT :limit OFFSET :offset;`, format, fmtCondition(chanID, rpm), order)
 
-	params := map[string]interface{}{
-		"channel":      chanID,
-		"limit":        rpm.Limit,
-		"offset":       rpm.Offset,
-		"subtopic":     rpm.Subtopic,
-		"publisher":    rpm.Publisher,
-		"name":         rpm.Name,
-		"protocol":     rpm.Protocol,
-		"value":        rpm.Value,
-		"bool_value":   rpm.BoolValue,
-		"string_value": rpm.StringValue,
-		"data_value":   rpm.DataValue,
-		"from":         rpm.From,
-		"to":           rpm.To,
-	}
+	params := fmtParams(chanID, rpm)
 
 	rows, err := tr.db.NamedQuery(q, params)
 	if err != nil {
@@ -85,50 +75,221 @@ func (tr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (r
 		PageMetadata: rpm,
 		Messages:     []readers.Message{},
 	}
-	switch format {
-	case defTable:
-		for rows.Next() {
-			msg := senmlMessage{Message: senml.Message{}}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	for rows.Next() {
+		msg, err := scanMessage(format, rows)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Messages = append(page.Messages, msg)
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(chanID, rpm))
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return page, err
+		}
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+func (tr postgresRepository) Export(chanID string, rpm readers.PageMetadata, handler readers.MessageHandler) error {
+	order := "time"
+	format := defTable
+
+	if rpm.Format != "" && rpm.Format != defTable {
+		order = "created"
+		format = rpm.Format
+	}
+
+	q := fmt.Sprintf(`SELECT * FROM %s WHERE %s ORDER BY %s DESC;`, format, fmtCondition(chanID, rpm), order)
+	rows, err := tr.db.NamedQuery(q, fmtParams(chanID, rpm))
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return nil
 			}
+		}
+		return errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
 
-			page.Messages = append(page.Messages, msg.Message)
+	for rows.Next() {
+		msg, err := scanMessage(format, rows)
+		if err != nil {
+			return errors.Wrap(errReadMessages, err)
 		}
-	default:
-		for rows.Next() {
-			msg := jsonMessage{}
-			if err := rows.StructScan(&msg); err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		if err := handler(msg); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(errReadMessages, err)
+	}
+
+	return nil
+}
+
+func (tr postgresRepository) LatestValues(chanID string, rpm readers.PageMetadata) ([]readers.Message, error) {
+	group := "name"
+	if rpm.PerPublisher {
+		group = "name, publisher"
+	}
+
+	q := fmt.Sprintf(`SELECT DISTINCT ON (%s) * FROM %s WHERE %s
+	ORDER BY %s, time DESC;`, group, defTable, fmtCondition(chanID, rpm), group)
+
+	rows, err := tr.db.NamedQuery(q, fmtParams(chanID, rpm))
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return []readers.Message{}, nil
 			}
-			m, err := msg.toMap()
-			if err != nil {
-				return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		return nil, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
+
+	msgs := []readers.Message{}
+	for rows.Next() {
+		msg, err := scanMessage(defTable, rows)
+		if err != nil {
+			return nil, errors.Wrap(errReadMessages, err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func (tr postgresRepository) readAggregates(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	interval, err := readers.ParseInterval(rpm.Interval)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	fn, err := fmtAggregation(rpm.Aggregation)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+
+	condition := fmt.Sprintf(`%s AND value IS NOT NULL`, fmtCondition(chanID, rpm))
+	q := fmt.Sprintf(`SELECT FLOOR(time / :interval) * :interval AS bucket, name, publisher,
+	CAST(%s AS DOUBLE PRECISION) AS value FROM %s WHERE %s
+	GROUP BY bucket, name, publisher ORDER BY bucket DESC, name, publisher
+	LIMIT :limit OFFSET :offset;`, fn, defTable, condition)
+
+	params := fmtParams(chanID, rpm)
+	params["interval"] = interval.Seconds()
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		if e, ok := err.(*pq.Error); ok {
+			if e.Code == undefinedTableCode {
+				return readers.MessagesPage{}, nil
 			}
-			m["payload"] = jsont.ParseFlat(m["payload"])
-			page.Messages = append(page.Messages, m)
 		}
+		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+	}
+	defer rows.Close()
 
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+	for rows.Next() {
+		var agg readers.Aggregate
+		if err := rows.Scan(&agg.Time, &agg.Name, &agg.Publisher, &agg.Value); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
+		}
+		page.Messages = append(page.Messages, agg)
 	}
 
-	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, fmtCondition(chanID, rpm))
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT 1 FROM %s WHERE %s
+	GROUP BY FLOOR(time / :interval), name, publisher) AS buckets;`, defTable, condition)
 	rows, err = tr.db.NamedQuery(q, params)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 	}
 	defer rows.Close()
 
-	total := uint64(0)
 	if rows.Next() {
-		if err := rows.Scan(&total); err != nil {
-			return page, err
+		if err := rows.Scan(&page.Total); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(errReadMessages, err)
 		}
 	}
-	page.Total = total
 
 	return page, nil
 }
 
+func fmtAggregation(aggregation string) (string, error) {
+	switch aggregation {
+	case readers.AvgAggregation:
+		return "AVG(value)", nil
+	case readers.MinAggregation:
+		return "MIN(value)", nil
+	case readers.MaxAggregation:
+		return "MAX(value)", nil
+	case readers.SumAggregation:
+		return "SUM(value)", nil
+	case readers.CountAggregation:
+		return "COUNT(value)", nil
+	case readers.FirstAggregation:
+		return "(ARRAY_AGG(value ORDER BY time))[1]", nil
+	case readers.LastAggregation:
+		return "(ARRAY_AGG(value ORDER BY time DESC))[1]", nil
+	default:
+		return "", readers.ErrInvalidAggregation
+	}
+}
+
+func scanMessage(format string, rows *sqlx.Rows) (readers.Message, error) {
+	if format == defTable {
+		msg := senmlMessage{Message: senml.Message{}}
+		if err := rows.StructScan(&msg); err != nil {
+			return nil, err
+		}
+		return msg.Message, nil
+	}
+
+	msg := jsonMessage{}
+	if err := rows.StructScan(&msg); err != nil {
+		return nil, err
+	}
+	m, err := msg.toMap()
+	if err != nil {
+		return nil, err
+	}
+	m["payload"] = jsont.ParseFlat(m["payload"])
+	return m, nil
+}
+
+func fmtParams(chanID string, rpm readers.PageMetadata) map[string]interface{} {
+	return map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+}
+
 func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	condition := `channel = :channel`
 
@@ -0,0 +1,182 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+func createScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		s := scheduler.Schedule{
+			ThingID:   req.ThingID,
+			ChannelID: req.ChannelID,
+			Subtopic:  req.Subtopic,
+			Payload:   []byte(req.Payload),
+			Cron:      req.Cron,
+		}
+		if req.RunAt != nil {
+			s.RunAt = *req.RunAt
+		}
+		saved, err := svc.CreateSchedule(ctx, req.token, s)
+		if err != nil {
+			return nil, err
+		}
+
+		res := toScheduleRes(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func viewScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		s, err := svc.ViewSchedule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toScheduleRes(s), nil
+	}
+}
+
+func listSchedulesEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSchedulesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := scheduler.PageMetadata{
+			Offset:    req.offset,
+			Limit:     req.limit,
+			ChannelID: req.channelID,
+		}
+		page, err := svc.ListSchedules(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := schedulesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Schedules: []scheduleRes{},
+		}
+		for _, s := range page.Schedules {
+			res.Schedules = append(res.Schedules, toScheduleRes(s))
+		}
+
+		return res, nil
+	}
+}
+
+func listUpcomingEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listUpcomingReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		runs, err := svc.ListUpcoming(ctx, req.token, req.id, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return upcomingRes{Runs: runs}, nil
+	}
+}
+
+func removeScheduleEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewScheduleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveSchedule(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func listExecutionsEndpoint(svc scheduler.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listExecutionsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := scheduler.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListExecutions(ctx, req.token, req.id, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := executionsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Executions: []executionRes{},
+		}
+		for _, e := range page.Executions {
+			res.Executions = append(res.Executions, executionRes{
+				ID:          e.ID,
+				ScheduledAt: e.ScheduledAt,
+				ExecutedAt:  e.ExecutedAt,
+				Status:      e.Status,
+				Error:       e.Error,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func toScheduleRes(s scheduler.Schedule) scheduleRes {
+	return scheduleRes{
+		ID:        s.ID,
+		OwnerID:   s.OwnerID,
+		ThingID:   s.ThingID,
+		ChannelID: s.ChannelID,
+		Subtopic:  s.Subtopic,
+		Payload:   string(s.Payload),
+		Cron:      s.Cron,
+		RunAt:     optionalTime(s.RunAt),
+		NextRun:   optionalTime(s.NextRun),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// optionalTime omits zero time from the response.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
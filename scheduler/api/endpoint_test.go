@@ -0,0 +1,575 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/scheduler"
+	httpapi "github.com/mainflux/mainflux/scheduler/api"
+	"github.com/mainflux/mainflux/scheduler/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType    = "application/json"
+	token          = "token"
+	wrongToken     = "wrong-token"
+	email          = "user@example.com"
+	chanID         = "chan"
+	otherChanID    = "other-chan"
+	thingID        = "thing"
+	otherThingID   = "other-thing"
+	foreignThingID = "foreign-thing"
+	payload        = `[{"n":"temperature","v":21}]`
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newService() scheduler.Service {
+	auth := mocks.NewAuthService(map[string]string{token: email})
+	things := mocks.NewThingsService(
+		map[string]string{thingID: email, otherThingID: email, foreignThingID: "other@example.com"},
+		map[string]string{chanID: email, otherChanID: "other@example.com"},
+		map[string][]string{chanID: {thingID, foreignThingID}, otherChanID: {otherThingID}},
+	)
+	return scheduler.New(auth, things, mocks.NewScheduleRepository(), mocks.NewExecutionRepository(), uuid.NewMock(), mocks.NewPublisher(), 10)
+}
+
+func newServer(svc scheduler.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+type scheduleReq struct {
+	ThingID   string     `json:"thing_id,omitempty"`
+	ChannelID string     `json:"channel_id,omitempty"`
+	Subtopic  string     `json:"subtopic,omitempty"`
+	Payload   string     `json:"payload,omitempty"`
+	Cron      string     `json:"cron,omitempty"`
+	RunAt     *time.Time `json:"run_at,omitempty"`
+}
+
+type scheduleRes struct {
+	ID        string `json:"id"`
+	ThingID   string `json:"thing_id"`
+	ChannelID string `json:"channel_id"`
+	Subtopic  string `json:"subtopic"`
+	Payload   string `json:"payload"`
+	Cron      string `json:"cron"`
+}
+
+type schedulesPageRes struct {
+	Total     uint64        `json:"total"`
+	Offset    uint64        `json:"offset"`
+	Limit     uint64        `json:"limit"`
+	Schedules []scheduleRes `json:"schedules"`
+}
+
+type upcomingRes struct {
+	Runs []time.Time `json:"runs"`
+}
+
+var sch = scheduleReq{
+	ThingID:   thingID,
+	ChannelID: chanID,
+	Subtopic:  "setpoints",
+	Payload:   payload,
+	Cron:      "0 8 * * 1-5",
+}
+
+func createSchedule(t *testing.T, svc scheduler.Service) scheduler.Schedule {
+	saved, err := svc.CreateSchedule(context.Background(), token, scheduler.Schedule{
+		ThingID:   sch.ThingID,
+		ChannelID: sch.ChannelID,
+		Subtopic:  sch.Subtopic,
+		Payload:   []byte(sch.Payload),
+		Cron:      sch.Cron,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return saved
+}
+
+func TestCreateSchedule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	runAt := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create recurring schedule",
+			req:         toJSON(sch),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/schedules/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create one-off schedule",
+			req:         toJSON(scheduleReq{ThingID: thingID, ChannelID: chanID, Payload: payload, RunAt: &runAt}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/schedules/%s%012d", uuid.Prefix, 2),
+		},
+		{
+			desc:        "create schedule with invalid token",
+			req:         toJSON(sch),
+			contentType: contentType,
+			token:       wrongToken,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create schedule with empty token",
+			req:         toJSON(sch),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create schedule for the channel owned by another user",
+			req:         toJSON(scheduleReq{ThingID: otherThingID, ChannelID: otherChanID, Payload: payload, Cron: sch.Cron}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create schedule for the thing owned by another user",
+			req:         toJSON(scheduleReq{ThingID: foreignThingID, ChannelID: chanID, Payload: payload, Cron: sch.Cron}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create schedule for the thing not connected to the channel",
+			req:         toJSON(scheduleReq{ThingID: otherThingID, ChannelID: chanID, Payload: payload, Cron: sch.Cron}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create schedule without payload",
+			req:         toJSON(scheduleReq{ThingID: thingID, ChannelID: chanID, Cron: sch.Cron}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule without cron and run time",
+			req:         toJSON(scheduleReq{ThingID: thingID, ChannelID: chanID, Payload: payload}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule with invalid cron",
+			req:         toJSON(scheduleReq{ThingID: thingID, ChannelID: chanID, Payload: payload, Cron: "every day"}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create one-off schedule in the past",
+			req:         toJSON(scheduleReq{ThingID: thingID, ChannelID: chanID, Payload: payload, RunAt: &past}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule with invalid request format",
+			req:         "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create schedule with invalid content type",
+			req:         toJSON(sch),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/schedules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		location := res.Header.Get("Location")
+		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
+	}
+}
+
+func TestViewSchedule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved := createSchedule(t, svc)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		res    scheduleRes
+	}{
+		{
+			desc:   "view schedule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusOK,
+			res: scheduleRes{
+				ID:        saved.ID,
+				ThingID:   thingID,
+				ChannelID: chanID,
+				Subtopic:  sch.Subtopic,
+				Payload:   payload,
+				Cron:      sch.Cron,
+			},
+		},
+		{
+			desc:   "view non-existing schedule",
+			id:     "non-existing",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view schedule with invalid token",
+			id:     saved.ID,
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body scheduleRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListSchedules(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	for i := 0; i < 5; i++ {
+		createSchedule(t, svc)
+	}
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		total  uint64
+		size   int
+	}{
+		{
+			desc:   "list schedules",
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			total:  5,
+			size:   5,
+		},
+		{
+			desc:   "list schedules with limit",
+			query:  "?offset=1&limit=2",
+			token:  token,
+			status: http.StatusOK,
+			total:  5,
+			size:   2,
+		},
+		{
+			desc:   "list schedules of the channel",
+			query:  fmt.Sprintf("?channel=%s", otherChanID),
+			token:  token,
+			status: http.StatusOK,
+			total:  0,
+			size:   0,
+		},
+		{
+			desc:   "list schedules with invalid limit",
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list schedules with invalid offset",
+			query:  "?offset=first",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list schedules with invalid token",
+			query:  "",
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body schedulesPageRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+		assert.Equal(t, tc.size, len(body.Schedules), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(body.Schedules)))
+	}
+}
+
+func TestListUpcoming(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved := createSchedule(t, svc)
+
+	cases := []struct {
+		desc   string
+		id     string
+		query  string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list upcoming runs",
+			id:     saved.ID,
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+			size:   5,
+		},
+		{
+			desc:   "list upcoming runs with limit",
+			id:     saved.ID,
+			query:  "?limit=2",
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list upcoming runs with invalid limit",
+			id:     saved.ID,
+			query:  "?limit=0",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list upcoming runs of non-existing schedule",
+			id:     "non-existing",
+			query:  "",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "list upcoming runs with invalid token",
+			id:     saved.ID,
+			query:  "",
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules/%s/upcoming%s", ts.URL, tc.id, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body upcomingRes
+		json.NewDecoder(res.Body).Decode(&body)
+		assert.Equal(t, tc.size, len(body.Runs), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(body.Runs)))
+	}
+}
+
+func TestListExecutions(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved := createSchedule(t, svc)
+
+	cases := []struct {
+		desc   string
+		id     string
+		query  string
+		token  string
+		status int
+	}{
+		{
+			desc:   "list executions",
+			id:     saved.ID,
+			query:  "",
+			token:  token,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list executions with invalid limit",
+			id:     saved.ID,
+			query:  "?limit=1000",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list executions of non-existing schedule",
+			id:     "non-existing",
+			query:  "",
+			token:  token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "list executions with invalid token",
+			id:     saved.ID,
+			query:  "",
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/schedules/%s/executions%s", ts.URL, tc.id, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveSchedule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	saved := createSchedule(t, svc)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove schedule with invalid token",
+			id:     saved.ID,
+			token:  wrongToken,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove schedule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed schedule",
+			id:     saved.ID,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/schedules/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+var _ scheduler.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     scheduler.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc scheduler.Service, counter metrics.Counter, latency metrics.Histogram) scheduler.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) CreateSchedule(ctx context.Context, token string, s scheduler.Schedule) (scheduler.Schedule, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_schedule").Add(1)
+		ms.latency.With("method", "create_schedule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateSchedule(ctx, token, s)
+}
+
+func (ms *metricsMiddleware) ViewSchedule(ctx context.Context, token, id string) (scheduler.Schedule, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_schedule").Add(1)
+		ms.latency.With("method", "view_schedule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewSchedule(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListSchedules(ctx context.Context, token string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_schedules").Add(1)
+		ms.latency.With("method", "list_schedules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListSchedules(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) ListUpcoming(ctx context.Context, token, id string, n uint64) ([]time.Time, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_upcoming").Add(1)
+		ms.latency.With("method", "list_upcoming").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListUpcoming(ctx, token, id, n)
+}
+
+func (ms *metricsMiddleware) RemoveSchedule(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_schedule").Add(1)
+		ms.latency.With("method", "remove_schedule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveSchedule(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListExecutions(ctx context.Context, token, id string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_executions").Add(1)
+		ms.latency.With("method", "list_executions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListExecutions(ctx, token, id, pm)
+}
+
+func (ms *metricsMiddleware) RunDue(ctx context.Context) (uint64, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "run_due").Add(1)
+		ms.latency.With("method", "run_due").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RunDue(ctx)
+}
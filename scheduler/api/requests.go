@@ -0,0 +1,107 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+const maxLimitSize = 100
+
+type createScheduleReq struct {
+	token     string
+	ThingID   string     `json:"thing_id"`
+	ChannelID string     `json:"channel_id"`
+	Subtopic  string     `json:"subtopic,omitempty"`
+	Payload   string     `json:"payload"`
+	Cron      string     `json:"cron,omitempty"`
+	RunAt     *time.Time `json:"run_at,omitempty"`
+}
+
+func (req createScheduleReq) validate() error {
+	if req.token == "" {
+		return scheduler.ErrUnauthorizedAccess
+	}
+	if req.ThingID == "" || req.ChannelID == "" || req.Payload == "" {
+		return scheduler.ErrMalformedEntity
+	}
+	if (req.Cron == "") == (req.RunAt == nil) {
+		return scheduler.ErrMalformedEntity
+	}
+	return nil
+}
+
+type viewScheduleReq struct {
+	token string
+	id    string
+}
+
+func (req viewScheduleReq) validate() error {
+	if req.token == "" {
+		return scheduler.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return scheduler.ErrMalformedEntity
+	}
+	return nil
+}
+
+type listSchedulesReq struct {
+	token     string
+	offset    uint64
+	limit     uint64
+	channelID string
+}
+
+func (req listSchedulesReq) validate() error {
+	if req.token == "" {
+		return scheduler.ErrUnauthorizedAccess
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
+
+type listUpcomingReq struct {
+	token string
+	id    string
+	limit uint64
+}
+
+func (req listUpcomingReq) validate() error {
+	if req.token == "" {
+		return scheduler.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return scheduler.ErrMalformedEntity
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
+
+type listExecutionsReq struct {
+	token  string
+	id     string
+	offset uint64
+	limit  uint64
+}
+
+func (req listExecutionsReq) validate() error {
+	if req.token == "" {
+		return scheduler.ErrUnauthorizedAccess
+	}
+	if req.id == "" {
+		return scheduler.ErrMalformedEntity
+	}
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return errors.ErrInvalidQueryParams
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mainflux/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*scheduleRes)(nil)
+	_ mainflux.Response = (*schedulesPageRes)(nil)
+	_ mainflux.Response = (*upcomingRes)(nil)
+	_ mainflux.Response = (*executionsPageRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type scheduleRes struct {
+	ID        string     `json:"id"`
+	OwnerID   string     `json:"owner_id"`
+	ThingID   string     `json:"thing_id"`
+	ChannelID string     `json:"channel_id"`
+	Subtopic  string     `json:"subtopic,omitempty"`
+	Payload   string     `json:"payload"`
+	Cron      string     `json:"cron,omitempty"`
+	RunAt     *time.Time `json:"run_at,omitempty"`
+	NextRun   *time.Time `json:"next_run,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	created   bool
+}
+
+func (res scheduleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func (res scheduleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/schedules/%s", res.ID),
+		}
+	}
+	return map[string]string{}
+}
+
+func (res scheduleRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type schedulesPageRes struct {
+	pageRes
+	Schedules []scheduleRes `json:"schedules"`
+}
+
+func (res schedulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res schedulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res schedulesPageRes) Empty() bool {
+	return false
+}
+
+type upcomingRes struct {
+	Runs []time.Time `json:"runs"`
+}
+
+func (res upcomingRes) Code() int {
+	return http.StatusOK
+}
+
+func (res upcomingRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res upcomingRes) Empty() bool {
+	return false
+}
+
+type executionRes struct {
+	ID          string    `json:"id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	ExecutedAt  time.Time `json:"executed_at"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+}
+
+type executionsPageRes struct {
+	pageRes
+	Executions []executionRes `json:"executions"`
+}
+
+func (res executionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res executionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res executionsPageRes) Empty() bool {
+	return false
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type errorRes struct {
+	Err string `json:"error"`
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/internal/httputil"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType = "application/json"
+
+	offsetKey  = "offset"
+	limitKey   = "limit"
+	channelKey = "channel"
+
+	defOffset        = 0
+	defLimit         = 10
+	defUpcomingLimit = 5
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc scheduler.Service, tracer opentracing.Tracer) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(encodeError),
+	}
+
+	mux := bone.New()
+
+	mux.Post("/schedules", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_schedule")(createScheduleEndpoint(svc)),
+		decodeCreate,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_schedules")(listSchedulesEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_schedule")(viewScheduleEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/schedules/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_schedule")(removeScheduleEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules/:id/upcoming", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_upcoming")(listUpcomingEndpoint(svc)),
+		decodeUpcoming,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/schedules/:id/executions", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_executions")(listExecutionsEndpoint(svc)),
+		decodeExecutions,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.GetFunc("/version", mainflux.Version("scheduler"))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := createScheduleReq{token: r.Header.Get("Authorization")}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(scheduler.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewScheduleReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := httputil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listSchedulesReq{
+		token:     r.Header.Get("Authorization"),
+		offset:    o,
+		limit:     l,
+		channelID: c,
+	}
+
+	return req, nil
+}
+
+func decodeUpcoming(_ context.Context, r *http.Request) (interface{}, error) {
+	l, err := httputil.ReadUintQuery(r, limitKey, defUpcomingLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listUpcomingReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+		limit: l,
+	}
+
+	return req, nil
+}
+
+func decodeExecutions(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := httputil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listExecutionsReq{
+		token:  r.Header.Get("Authorization"),
+		id:     bone.GetValue(r, "id"),
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch errorVal := err.(type) {
+	case errors.Error:
+		w.Header().Set("Content-Type", contentType)
+		switch {
+		case errors.Contains(errorVal, scheduler.ErrMalformedEntity),
+			errors.Contains(errorVal, errors.ErrInvalidQueryParams):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, scheduler.ErrUnauthorizedAccess):
+			w.WriteHeader(http.StatusUnauthorized)
+		case errors.Contains(errorVal, scheduler.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, scheduler.ErrConflict):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, errors.ErrUnsupportedContentType):
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		if errorVal.Msg() != "" {
+			if err := json.NewEncoder(w).Encode(errorRes{Err: errorVal.Msg()}); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Cron searches for the next run at most this far in the future, so that
+// expressions which never match (e.g. "0 0 30 2 *") don't loop forever.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+// ErrInvalidCron indicates malformed cron expression.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type bounds struct {
+	min, max uint
+}
+
+var (
+	minutes = bounds{0, 59}
+	hours   = bounds{0, 23}
+	doms    = bounds{1, 31}
+	months  = bounds{1, 12}
+	// Both 0 and 7 stand for Sunday.
+	dows = bounds{0, 7}
+)
+
+// Cron represents a parsed standard five-field cron expression: minute, hour,
+// day of month, month and day of week. Each field is a list of values, ranges
+// ("1-5") and steps ("*/15", "0-30/10"). Expressions are evaluated in UTC.
+type Cron struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// If both day of month and day of week are restricted, the day
+	// matches if either of them matches, as in the standard cron.
+	domAny bool
+	dowAny bool
+}
+
+// ParseCron parses the five-field cron expression or one of the predefined
+// descriptors, e.g. "@daily".
+func ParseCron(expr string) (Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := descriptors[expr]; ok {
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Cron{}, ErrInvalidCron
+	}
+
+	var c Cron
+	var err error
+	if c.minute, err = parseField(fields[0], minutes); err != nil {
+		return Cron{}, err
+	}
+	if c.hour, err = parseField(fields[1], hours); err != nil {
+		return Cron{}, err
+	}
+	if c.dom, err = parseField(fields[2], doms); err != nil {
+		return Cron{}, err
+	}
+	if c.month, err = parseField(fields[3], months); err != nil {
+		return Cron{}, err
+	}
+	if c.dow, err = parseField(fields[4], dows); err != nil {
+		return Cron{}, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domAny = strings.HasPrefix(fields[2], "*")
+	c.dowAny = strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// Next returns the first time matching the expression which is strictly after
+// the given time. Zero time is returned if there is no such time.
+func (c Cron) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(maxCronSearch)
+
+	for t.Before(end) {
+		switch {
+		case !has(c.month, uint(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(c.hour, uint(t.Hour())):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(c.minute, uint(t.Minute())):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c Cron) matchDay(t time.Time) bool {
+	dom := has(c.dom, uint(t.Day()))
+	dow := has(c.dow, uint(t.Weekday()))
+	switch {
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func has(set uint64, v uint) bool {
+	return set&(1<<v) != 0
+}
+
+// parseField parses the comma separated list of values, ranges and steps
+// into the set of the values which match the field.
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || s == 0 {
+				return 0, ErrInvalidCron
+			}
+			rng, step = part[:i], uint(s)
+		}
+
+		lo, hi := b.min, b.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			ends := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = parseValue(ends[0], b); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(ends[1], b); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, ErrInvalidCron
+			}
+		default:
+			v, err := parseValue(rng, b)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			// A single value with the step, e.g. "5/15", stands
+			// for the range from the value to the maximum.
+			hi = v
+			if step > 1 {
+				hi = b.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(s string, b bounds) (uint, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || uint(v) < b.min || uint(v) > b.max {
+		return 0, ErrInvalidCron
+	}
+	return uint(v), nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	cases := []struct {
+		desc string
+		expr string
+		err  error
+	}{
+		{desc: "parse every minute", expr: "* * * * *", err: nil},
+		{desc: "parse lists, ranges and steps", expr: "0,30 8-18/2 1-15 */3 1-5", err: nil},
+		{desc: "parse value with step", expr: "5/15 * * * *", err: nil},
+		{desc: "parse Sunday as 7", expr: "0 0 * * 7", err: nil},
+		{desc: "parse descriptor", expr: "@daily", err: nil},
+		{desc: "parse empty expression", expr: "", err: scheduler.ErrInvalidCron},
+		{desc: "parse expression with too few fields", expr: "* * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse expression with too many fields", expr: "0 * * * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse minute out of range", expr: "60 * * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse day of month out of range", expr: "0 0 0 * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse reversed range", expr: "0 18-8 * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse zero step", expr: "*/0 * * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse invalid value", expr: "a * * * *", err: scheduler.ErrInvalidCron},
+		{desc: "parse unknown descriptor", expr: "@never", err: scheduler.ErrInvalidCron},
+	}
+
+	for _, tc := range cases {
+		_, err := scheduler.ParseCron(tc.expr)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Monday.
+	now := time.Date(2021, time.March, 1, 10, 15, 30, 0, time.UTC)
+
+	cases := []struct {
+		desc string
+		expr string
+		next time.Time
+	}{
+		{
+			desc: "next run every minute",
+			expr: "* * * * *",
+			next: time.Date(2021, time.March, 1, 10, 16, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run every quarter of an hour",
+			expr: "*/15 * * * *",
+			next: time.Date(2021, time.March, 1, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run daily",
+			expr: "@daily",
+			next: time.Date(2021, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run on workdays",
+			expr: "0 8 * * 1-5",
+			next: time.Date(2021, time.March, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run on Sunday",
+			expr: "0 8 * * 7",
+			next: time.Date(2021, time.March, 7, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run on day of month or day of week",
+			expr: "0 0 15 * 5",
+			next: time.Date(2021, time.March, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run in the next year",
+			expr: "0 0 1 1 *",
+			next: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run on leap day",
+			expr: "0 0 29 2 *",
+			next: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "next run on non-existent day",
+			expr: "0 0 30 2 *",
+			next: time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := scheduler.ParseCron(tc.expr)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		next := c.Next(now)
+		assert.True(t, tc.next.Equal(next), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.next, next))
+	}
+}
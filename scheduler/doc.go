@@ -0,0 +1,8 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scheduler contains the domain concept definitions needed to support
+// Mainflux scheduler functionality. Scheduler publishes the stored messages to
+// the channels at the given time, or periodically using cron expressions, on
+// behalf of the things owned by the user.
+package scheduler
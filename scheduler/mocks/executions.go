@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+var _ scheduler.ExecutionRepository = (*executionRepositoryMock)(nil)
+
+type executionRepositoryMock struct {
+	mu         sync.Mutex
+	executions map[string]scheduler.Execution
+}
+
+// NewExecutionRepository creates in-memory execution repository.
+func NewExecutionRepository() scheduler.ExecutionRepository {
+	return &executionRepositoryMock{
+		executions: make(map[string]scheduler.Execution),
+	}
+}
+
+func (erm *executionRepositoryMock) Save(_ context.Context, e scheduler.Execution) (string, error) {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	if _, ok := erm.executions[e.ID]; ok {
+		return "", scheduler.ErrConflict
+	}
+	erm.executions[e.ID] = e
+	return e.ID, nil
+}
+
+func (erm *executionRepositoryMock) RetrieveAll(_ context.Context, scheduleID string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	var items []scheduler.Execution
+	for _, e := range erm.executions {
+		if e.ScheduleID == scheduleID {
+			items = append(items, e)
+		}
+	}
+	// Mocked IDs are increasing, so they are sorted instead of the
+	// execution times, which can be equal.
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ID > items[j].ID
+	})
+
+	start, end := bounds(len(items), pm)
+	return scheduler.ExecutionsPage{
+		PageMetadata: pm,
+		Total:        uint64(len(items)),
+		Executions:   items[start:end],
+	}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+var _ scheduler.ScheduleRepository = (*scheduleRepositoryMock)(nil)
+
+type scheduleRepositoryMock struct {
+	mu        sync.Mutex
+	schedules map[string]scheduler.Schedule
+}
+
+// NewScheduleRepository creates in-memory schedule repository.
+func NewScheduleRepository() scheduler.ScheduleRepository {
+	return &scheduleRepositoryMock{
+		schedules: make(map[string]scheduler.Schedule),
+	}
+}
+
+func (srm *scheduleRepositoryMock) Save(_ context.Context, s scheduler.Schedule) (string, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if _, ok := srm.schedules[s.ID]; ok {
+		return "", scheduler.ErrConflict
+	}
+	srm.schedules[s.ID] = s
+	return s.ID, nil
+}
+
+func (srm *scheduleRepositoryMock) Retrieve(_ context.Context, owner, id string) (scheduler.Schedule, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	s, ok := srm.schedules[id]
+	if !ok || s.OwnerID != owner {
+		return scheduler.Schedule{}, scheduler.ErrNotFound
+	}
+	return s, nil
+}
+
+func (srm *scheduleRepositoryMock) RetrieveAll(_ context.Context, owner string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	var items []scheduler.Schedule
+	for _, s := range srm.schedules {
+		if s.OwnerID != owner || (pm.ChannelID != "" && s.ChannelID != pm.ChannelID) {
+			continue
+		}
+		items = append(items, s)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return before(items[i], items[j])
+	})
+
+	start, end := bounds(len(items), pm)
+	return scheduler.SchedulesPage{
+		PageMetadata: pm,
+		Total:        uint64(len(items)),
+		Schedules:    items[start:end],
+	}, nil
+}
+
+func (srm *scheduleRepositoryMock) RetrieveDue(_ context.Context, at time.Time, limit uint64) ([]scheduler.Schedule, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	var items []scheduler.Schedule
+	for _, s := range srm.schedules {
+		if s.NextRun.IsZero() || s.NextRun.After(at) {
+			continue
+		}
+		items = append(items, s)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return before(items[i], items[j])
+	})
+	if uint64(len(items)) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (srm *scheduleRepositoryMock) UpdateNextRun(_ context.Context, s scheduler.Schedule, prev time.Time) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	sch, ok := srm.schedules[s.ID]
+	if !ok || !sch.NextRun.Equal(prev) {
+		return scheduler.ErrNotFound
+	}
+	sch.NextRun = s.NextRun
+	sch.UpdatedAt = s.UpdatedAt
+	srm.schedules[s.ID] = sch
+	return nil
+}
+
+func (srm *scheduleRepositoryMock) Remove(_ context.Context, owner, id string) error {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	if s, ok := srm.schedules[id]; ok && s.OwnerID == owner {
+		delete(srm.schedules, id)
+	}
+	return nil
+}
+
+// before orders the schedules by their upcoming runs, while the schedules
+// without runs left come last. Mocked IDs are increasing, so they are used
+// to order the schedules with equal runs.
+func before(a, b scheduler.Schedule) bool {
+	switch {
+	case a.NextRun.Equal(b.NextRun):
+		return a.ID < b.ID
+	case a.NextRun.IsZero():
+		return false
+	case b.NextRun.IsZero():
+		return true
+	default:
+		return a.NextRun.Before(b.NextRun)
+	}
+}
+
+func bounds(n int, pm scheduler.PageMetadata) (int, int) {
+	start := int(pm.Offset)
+	if start > n {
+		start = n
+	}
+	end := start + int(pm.Limit)
+	if end > n {
+		end = n
+	}
+	return start, end
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
+
+type thingsServiceMock struct {
+	things      map[string]string
+	channels    map[string]string
+	connections map[string][]string
+}
+
+// NewThingsService returns mock implementation of things service. Things
+// and channels are given as maps of their IDs to their owners, while
+// connections are given as a map of channel IDs to the IDs of the
+// connected things.
+func NewThingsService(things, channels map[string]string, connections map[string][]string) mainflux.ThingsServiceClient {
+	return &thingsServiceMock{things, channels, connections}
+}
+
+func (svc thingsServiceMock) CanAccessByKey(context.Context, *mainflux.AccessByKeyReq, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) CanAccessByID(_ context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	for _, id := range svc.connections[req.GetChanID()] {
+		if id == req.GetThingID() {
+			return &empty.Empty{}, nil
+		}
+	}
+	return nil, status.Error(codes.PermissionDenied, "thing not connected")
+}
+
+func (svc thingsServiceMock) IsChannelOwner(_ context.Context, req *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if owner, ok := svc.channels[req.GetChanID()]; ok && owner == req.GetOwner() {
+		return &empty.Empty{}, nil
+	}
+	return nil, status.Error(codes.PermissionDenied, "not the channel owner")
+}
+
+func (svc thingsServiceMock) IsThingOwner(_ context.Context, req *mainflux.ThingOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if owner, ok := svc.things[req.GetThingID()]; ok && owner == req.GetOwner() {
+		return &empty.Empty{}, nil
+	}
+	return nil, status.Error(codes.PermissionDenied, "not the thing owner")
+}
+
+func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
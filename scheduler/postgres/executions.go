@@ -0,0 +1,113 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+var _ scheduler.ExecutionRepository = (*executionRepository)(nil)
+
+type executionRepository struct {
+	db Database
+}
+
+// NewExecutionRepository instantiates a PostgreSQL implementation of execution
+// repository.
+func NewExecutionRepository(db Database) scheduler.ExecutionRepository {
+	return &executionRepository{
+		db: db,
+	}
+}
+
+func (er executionRepository) Save(ctx context.Context, e scheduler.Execution) (string, error) {
+	q := `INSERT INTO executions (id, schedule_id, scheduled_at, executed_at, status, error)
+	VALUES (:id, :schedule_id, :scheduled_at, :executed_at, :status, :error)`
+
+	dbe := dbExecution{
+		ID:          e.ID,
+		ScheduleID:  e.ScheduleID,
+		ScheduledAt: e.ScheduledAt,
+		ExecutedAt:  e.ExecutedAt,
+		Status:      e.Status,
+		Error:       e.Error,
+	}
+	if _, err := er.db.NamedExecContext(ctx, q, dbe); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return "", errors.Wrap(scheduler.ErrConflict, err)
+			case errFK:
+				return "", errors.Wrap(scheduler.ErrNotFound, err)
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(scheduler.ErrMalformedEntity, err)
+			}
+		}
+		return "", errors.Wrap(scheduler.ErrSave, err)
+	}
+
+	return e.ID, nil
+}
+
+func (er executionRepository) RetrieveAll(ctx context.Context, scheduleID string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	q := `SELECT id, schedule_id, scheduled_at, executed_at, status, error FROM executions
+	WHERE schedule_id = :schedule_id ORDER BY executed_at DESC, id DESC LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"schedule_id": scheduleID,
+		"limit":       pm.Limit,
+		"offset":      pm.Offset,
+	}
+	rows, err := er.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return scheduler.ExecutionsPage{}, errors.Wrap(scheduler.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	var items []scheduler.Execution
+	for rows.Next() {
+		var dbe dbExecution
+		if err := rows.StructScan(&dbe); err != nil {
+			return scheduler.ExecutionsPage{}, errors.Wrap(scheduler.ErrSelectEntity, err)
+		}
+		items = append(items, fromDBExecution(dbe))
+	}
+
+	cq := `SELECT COUNT(*) FROM executions WHERE schedule_id = :schedule_id`
+	total, err := total(ctx, er.db, cq, params)
+	if err != nil {
+		return scheduler.ExecutionsPage{}, errors.Wrap(scheduler.ErrSelectEntity, err)
+	}
+
+	return scheduler.ExecutionsPage{
+		PageMetadata: pm,
+		Total:        total,
+		Executions:   items,
+	}, nil
+}
+
+type dbExecution struct {
+	ID          string    `db:"id"`
+	ScheduleID  string    `db:"schedule_id"`
+	ScheduledAt time.Time `db:"scheduled_at"`
+	ExecutedAt  time.Time `db:"executed_at"`
+	Status      string    `db:"status"`
+	Error       string    `db:"error"`
+}
+
+func fromDBExecution(dbe dbExecution) scheduler.Execution {
+	return scheduler.Execution{
+		ID:          dbe.ID,
+		ScheduleID:  dbe.ScheduleID,
+		ScheduledAt: dbe.ScheduledAt,
+		ExecutedAt:  dbe.ExecutedAt,
+		Status:      dbe.Status,
+		Error:       dbe.Error,
+	}
+}
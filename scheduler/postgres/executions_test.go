@@ -0,0 +1,148 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+	"github.com/mainflux/mainflux/scheduler/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numExecutions = 10
+
+func newExecution(t *testing.T, scheduleID string, executedAt time.Time) scheduler.Execution {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	executedAt = executedAt.UTC().Truncate(time.Microsecond)
+	return scheduler.Execution{
+		ID:          id,
+		ScheduleID:  scheduleID,
+		ScheduledAt: executedAt,
+		ExecutedAt:  executedAt,
+		Status:      scheduler.Published,
+	}
+}
+
+func TestExecutionSave(t *testing.T) {
+	database := postgres.NewDatabase(db)
+	schedules := postgres.NewScheduleRepository(database)
+	repo := postgres.NewExecutionRepository(database)
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	s := newSchedule(t, owner, time.Now().Add(time.Hour))
+	_, err = schedules.Save(context.Background(), s)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	e := newExecution(t, s.ID, time.Now())
+
+	cases := []struct {
+		desc      string
+		execution scheduler.Execution
+		id        string
+		err       error
+	}{
+		{
+			desc:      "save execution",
+			execution: e,
+			id:        e.ID,
+			err:       nil,
+		},
+		{
+			desc:      "save duplicate execution",
+			execution: e,
+			id:        "",
+			err:       scheduler.ErrConflict,
+		},
+		{
+			desc:      "save execution of non-existing schedule",
+			execution: newExecution(t, "non-existing", time.Now()),
+			id:        "",
+			err:       scheduler.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := repo.Save(context.Background(), tc.execution)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestExecutionRetrieveAll(t *testing.T) {
+	database := postgres.NewDatabase(db)
+	schedules := postgres.NewScheduleRepository(database)
+	repo := postgres.NewExecutionRepository(database)
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	s := newSchedule(t, owner, time.Now().Add(time.Hour))
+	_, err = schedules.Save(context.Background(), s)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	var ids []string
+	for i := 0; i < numExecutions; i++ {
+		e := newExecution(t, s.ID, now.Add(time.Duration(i)*time.Minute))
+		_, err := repo.Save(context.Background(), e)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ids = append(ids, e.ID)
+	}
+
+	cases := []struct {
+		desc       string
+		scheduleID string
+		pm         scheduler.PageMetadata
+		first      string
+		size       int
+		total      uint64
+	}{
+		{
+			desc:       "retrieve all executions",
+			scheduleID: s.ID,
+			pm:         scheduler.PageMetadata{Offset: 0, Limit: numExecutions},
+			first:      ids[numExecutions-1],
+			size:       numExecutions,
+			total:      numExecutions,
+		},
+		{
+			desc:       "retrieve last page of executions",
+			scheduleID: s.ID,
+			pm:         scheduler.PageMetadata{Offset: numExecutions - 1, Limit: numExecutions},
+			first:      ids[0],
+			size:       1,
+			total:      numExecutions,
+		},
+		{
+			desc:       "retrieve executions of non-existing schedule",
+			scheduleID: "non-existing",
+			pm:         scheduler.PageMetadata{Offset: 0, Limit: numExecutions},
+			size:       0,
+			total:      0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.scheduleID, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Executions), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Executions)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		if tc.size > 0 {
+			assert.Equal(t, tc.first, page.Executions[0].ID, fmt.Sprintf("%s: expected first %s got %s\n", tc.desc, tc.first, page.Executions[0].ID))
+		}
+	}
+
+	err = schedules.Remove(context.Background(), owner, s.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	page, err := repo.RetrieveAll(context.Background(), s.ID, scheduler.PageMetadata{Limit: numExecutions})
+	assert.Nil(t, err, fmt.Sprintf("retrieve executions of removed schedule: got unexpected error: %s\n", err))
+	assert.Equal(t, uint64(0), page.Total, fmt.Sprintf("retrieve executions of removed schedule: expected no executions got %d\n", page.Total))
+}
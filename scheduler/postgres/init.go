@@ -0,0 +1,86 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "scheduler_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS schedules (
+                        id         VARCHAR(254) PRIMARY KEY,
+                        owner_id   VARCHAR(254) NOT NULL,
+                        thing_id   VARCHAR(254) NOT NULL,
+                        channel_id VARCHAR(254) NOT NULL,
+                        subtopic   VARCHAR(1024) NOT NULL DEFAULT '',
+                        payload    BYTEA NOT NULL,
+                        cron       VARCHAR(254) NOT NULL DEFAULT '',
+                        run_at     TIMESTAMP,
+                        next_run   TIMESTAMP,
+                        created_at TIMESTAMP NOT NULL,
+                        updated_at TIMESTAMP NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS schedules_owner_idx ON schedules (owner_id, next_run)`,
+					`CREATE INDEX IF NOT EXISTS schedules_next_run_idx ON schedules (next_run) WHERE next_run IS NOT NULL`,
+					`CREATE TABLE IF NOT EXISTS executions (
+                        id           VARCHAR(254) PRIMARY KEY,
+                        schedule_id  VARCHAR(254) NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+                        scheduled_at TIMESTAMP NOT NULL,
+                        executed_at  TIMESTAMP NOT NULL,
+                        status       VARCHAR(32) NOT NULL,
+                        error        TEXT NOT NULL DEFAULT ''
+                    )`,
+					`CREATE INDEX IF NOT EXISTS executions_schedule_idx ON executions (schedule_id, executed_at)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS executions",
+					"DROP TABLE IF EXISTS schedules",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,250 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+)
+
+const (
+	errDuplicate  = "unique_violation"
+	errFK         = "foreign_key_violation"
+	errInvalid    = "invalid_text_representation"
+	errTruncation = "string_data_right_truncation"
+
+	scheduleColumns = `id, owner_id, thing_id, channel_id, subtopic, payload, cron, run_at, next_run, created_at, updated_at`
+)
+
+var _ scheduler.ScheduleRepository = (*scheduleRepository)(nil)
+
+type scheduleRepository struct {
+	db Database
+}
+
+// NewScheduleRepository instantiates a PostgreSQL implementation of schedule
+// repository.
+func NewScheduleRepository(db Database) scheduler.ScheduleRepository {
+	return &scheduleRepository{
+		db: db,
+	}
+}
+
+func (sr scheduleRepository) Save(ctx context.Context, s scheduler.Schedule) (string, error) {
+	q := fmt.Sprintf(`INSERT INTO schedules (%s)
+	VALUES (:id, :owner_id, :thing_id, :channel_id, :subtopic, :payload, :cron, :run_at, :next_run, :created_at, :updated_at)`, scheduleColumns)
+	if _, err := sr.db.NamedExecContext(ctx, q, toDBSchedule(s)); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case errDuplicate:
+				return "", errors.Wrap(scheduler.ErrConflict, err)
+			case errInvalid, errTruncation:
+				return "", errors.Wrap(scheduler.ErrMalformedEntity, err)
+			}
+		}
+		return "", errors.Wrap(scheduler.ErrSave, err)
+	}
+
+	return s.ID, nil
+}
+
+func (sr scheduleRepository) Retrieve(ctx context.Context, owner, id string) (scheduler.Schedule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM schedules WHERE owner_id = $1 AND id = $2`, scheduleColumns)
+
+	var dbs dbSchedule
+	if err := sr.db.GetContext(ctx, &dbs, q, owner, id); err != nil {
+		if err == sql.ErrNoRows {
+			return scheduler.Schedule{}, errors.Wrap(scheduler.ErrNotFound, err)
+		}
+		return scheduler.Schedule{}, errors.Wrap(scheduler.ErrSelectEntity, err)
+	}
+
+	return fromDBSchedule(dbs), nil
+}
+
+func (sr scheduleRepository) RetrieveAll(ctx context.Context, owner string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	params := map[string]interface{}{
+		"owner_id":   owner,
+		"channel_id": pm.ChannelID,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+	}
+	cond := `owner_id = :owner_id`
+	if pm.ChannelID != "" {
+		cond = fmt.Sprintf("%s AND channel_id = :channel_id", cond)
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM schedules WHERE %s ORDER BY next_run ASC NULLS LAST, id LIMIT :limit OFFSET :offset`, scheduleColumns, cond)
+	rows, err := sr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return scheduler.SchedulesPage{}, errors.Wrap(scheduler.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	items, err := scanSchedules(rows)
+	if err != nil {
+		return scheduler.SchedulesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM schedules WHERE %s`, cond)
+	total, err := total(ctx, sr.db, cq, params)
+	if err != nil {
+		return scheduler.SchedulesPage{}, errors.Wrap(scheduler.ErrSelectEntity, err)
+	}
+
+	return scheduler.SchedulesPage{
+		PageMetadata: pm,
+		Total:        total,
+		Schedules:    items,
+	}, nil
+}
+
+func (sr scheduleRepository) RetrieveDue(ctx context.Context, at time.Time, limit uint64) ([]scheduler.Schedule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM schedules WHERE next_run <= :at ORDER BY next_run, id LIMIT :limit`, scheduleColumns)
+
+	params := map[string]interface{}{
+		"at":    at,
+		"limit": limit,
+	}
+	rows, err := sr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(scheduler.ErrSelectEntity, err)
+	}
+	defer rows.Close()
+
+	return scanSchedules(rows)
+}
+
+func (sr scheduleRepository) UpdateNextRun(ctx context.Context, s scheduler.Schedule, prev time.Time) error {
+	q := `UPDATE schedules SET next_run = :next_run, updated_at = :updated_at WHERE id = :id AND next_run = :prev`
+
+	params := map[string]interface{}{
+		"id":         s.ID,
+		"next_run":   nullTime(s.NextRun),
+		"updated_at": s.UpdatedAt,
+		"prev":       prev,
+	}
+	res, err := sr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(scheduler.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(scheduler.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return scheduler.ErrNotFound
+	}
+
+	return nil
+}
+
+func (sr scheduleRepository) Remove(ctx context.Context, owner, id string) error {
+	q := `DELETE FROM schedules WHERE owner_id = :owner_id AND id = :id`
+
+	dbs := dbSchedule{
+		ID:      id,
+		OwnerID: owner,
+	}
+	if _, err := sr.db.NamedExecContext(ctx, q, dbs); err != nil {
+		return errors.Wrap(scheduler.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func scanSchedules(rows *sqlx.Rows) ([]scheduler.Schedule, error) {
+	var items []scheduler.Schedule
+	for rows.Next() {
+		var dbs dbSchedule
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, errors.Wrap(scheduler.ErrSelectEntity, err)
+		}
+		items = append(items, fromDBSchedule(dbs))
+	}
+	return items, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+type dbSchedule struct {
+	ID        string     `db:"id"`
+	OwnerID   string     `db:"owner_id"`
+	ThingID   string     `db:"thing_id"`
+	ChannelID string     `db:"channel_id"`
+	Subtopic  string     `db:"subtopic"`
+	Payload   []byte     `db:"payload"`
+	Cron      string     `db:"cron"`
+	RunAt     *time.Time `db:"run_at"`
+	NextRun   *time.Time `db:"next_run"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+func toDBSchedule(s scheduler.Schedule) dbSchedule {
+	return dbSchedule{
+		ID:        s.ID,
+		OwnerID:   s.OwnerID,
+		ThingID:   s.ThingID,
+		ChannelID: s.ChannelID,
+		Subtopic:  s.Subtopic,
+		Payload:   s.Payload,
+		Cron:      s.Cron,
+		RunAt:     nullTime(s.RunAt),
+		NextRun:   nullTime(s.NextRun),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func fromDBSchedule(dbs dbSchedule) scheduler.Schedule {
+	s := scheduler.Schedule{
+		ID:        dbs.ID,
+		OwnerID:   dbs.OwnerID,
+		ThingID:   dbs.ThingID,
+		ChannelID: dbs.ChannelID,
+		Subtopic:  dbs.Subtopic,
+		Payload:   dbs.Payload,
+		Cron:      dbs.Cron,
+		CreatedAt: dbs.CreatedAt,
+		UpdatedAt: dbs.UpdatedAt,
+	}
+	if dbs.RunAt != nil {
+		s.RunAt = *dbs.RunAt
+	}
+	if dbs.NextRun != nil {
+		s.NextRun = *dbs.NextRun
+	}
+	return s
+}
+
+// nullTime stores zero time as NULL.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,278 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/scheduler"
+	"github.com/mainflux/mainflux/scheduler/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numSchedules = 10
+
+func newSchedule(t *testing.T, owner string, nextRun time.Time) scheduler.Schedule {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	// Postgres keeps microseconds precision.
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return scheduler.Schedule{
+		ID:        id,
+		OwnerID:   owner,
+		ThingID:   "thing",
+		ChannelID: "chan",
+		Subtopic:  "setpoints",
+		Payload:   []byte(`[{"n":"temperature","v":21}]`),
+		Cron:      "*/5 * * * *",
+		NextRun:   nextRun.UTC().Truncate(time.Microsecond),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestScheduleSave(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	s := newSchedule(t, owner, time.Now().Add(time.Hour))
+
+	oneOff := newSchedule(t, owner, time.Now().Add(time.Hour))
+	oneOff.Cron = ""
+	oneOff.RunAt = oneOff.NextRun
+
+	cases := []struct {
+		desc     string
+		schedule scheduler.Schedule
+		id       string
+		err      error
+	}{
+		{
+			desc:     "save recurring schedule",
+			schedule: s,
+			id:       s.ID,
+			err:      nil,
+		},
+		{
+			desc:     "save one-off schedule",
+			schedule: oneOff,
+			id:       oneOff.ID,
+			err:      nil,
+		},
+		{
+			desc:     "save duplicate schedule",
+			schedule: s,
+			id:       "",
+			err:      scheduler.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := repo.Save(context.Background(), tc.schedule)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestScheduleRetrieve(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	s := newSchedule(t, owner, time.Now().Add(time.Hour))
+	_, err = repo.Save(context.Background(), s)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		owner string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "retrieve schedule",
+			owner: owner,
+			id:    s.ID,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve schedule of another owner",
+			owner: "other",
+			id:    s.ID,
+			err:   scheduler.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing schedule",
+			owner: owner,
+			id:    "non-existing",
+			err:   scheduler.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := repo.Retrieve(context.Background(), tc.owner, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, s.Payload, saved.Payload, fmt.Sprintf("%s: expected payload %s got %s\n", tc.desc, s.Payload, saved.Payload))
+			assert.True(t, s.NextRun.Equal(saved.NextRun), fmt.Sprintf("%s: expected next run %s got %s\n", tc.desc, s.NextRun, saved.NextRun))
+			assert.True(t, saved.RunAt.IsZero(), fmt.Sprintf("%s: expected no run time got %s\n", tc.desc, saved.RunAt))
+		}
+	}
+}
+
+func TestScheduleRetrieveAll(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	var ids []string
+	for i := 0; i < numSchedules; i++ {
+		// The first schedule has no runs left.
+		next := time.Time{}
+		if i > 0 {
+			next = now.Add(time.Duration(numSchedules-i) * time.Hour)
+		}
+		s := newSchedule(t, owner, next)
+		_, err := repo.Save(context.Background(), s)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ids = append(ids, s.ID)
+	}
+
+	cases := []struct {
+		desc  string
+		owner string
+		pm    scheduler.PageMetadata
+		first string
+		size  int
+		total uint64
+	}{
+		{
+			desc:  "retrieve all schedules",
+			owner: owner,
+			pm:    scheduler.PageMetadata{Offset: 0, Limit: numSchedules},
+			first: ids[numSchedules-1],
+			size:  numSchedules,
+			total: numSchedules,
+		},
+		{
+			desc:  "retrieve last page of schedules",
+			owner: owner,
+			pm:    scheduler.PageMetadata{Offset: numSchedules - 1, Limit: numSchedules},
+			first: ids[0],
+			size:  1,
+			total: numSchedules,
+		},
+		{
+			desc:  "retrieve schedules of the channel",
+			owner: owner,
+			pm:    scheduler.PageMetadata{Offset: 0, Limit: numSchedules, ChannelID: "other-chan"},
+			size:  0,
+			total: 0,
+		},
+		{
+			desc:  "retrieve schedules of another owner",
+			owner: "other",
+			pm:    scheduler.PageMetadata{Offset: 0, Limit: numSchedules},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.owner, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Schedules), fmt.Sprintf("%s: expected size %d got %d\n", tc.desc, tc.size, len(page.Schedules)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+		if tc.size > 0 {
+			assert.Equal(t, tc.first, page.Schedules[0].ID, fmt.Sprintf("%s: expected first %s got %s\n", tc.desc, tc.first, page.Schedules[0].ID))
+		}
+	}
+}
+
+func TestScheduleRetrieveDueAndUpdateNextRun(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UTC()
+	due := newSchedule(t, owner, now.Add(-time.Minute))
+	upcoming := newSchedule(t, owner, now.Add(time.Hour))
+	for _, s := range []scheduler.Schedule{due, upcoming} {
+		_, err := repo.Save(context.Background(), s)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	items, err := repo.RetrieveDue(context.Background(), now, 100)
+	assert.Nil(t, err, fmt.Sprintf("retrieve due schedules: got unexpected error: %s\n", err))
+	var ids []string
+	for _, s := range items {
+		ids = append(ids, s.ID)
+	}
+	assert.Contains(t, ids, due.ID, fmt.Sprintf("retrieve due schedules: expected %s in %v\n", due.ID, ids))
+	assert.NotContains(t, ids, upcoming.ID, fmt.Sprintf("retrieve due schedules: expected no %s in %v\n", upcoming.ID, ids))
+
+	claimed := due
+	claimed.NextRun = now.Add(time.Hour).Truncate(time.Microsecond)
+	claimed.UpdatedAt = now
+
+	cases := []struct {
+		desc string
+		prev time.Time
+		err  error
+	}{
+		{
+			desc: "update next run",
+			prev: due.NextRun,
+			err:  nil,
+		},
+		{
+			desc: "update next run claimed by another instance",
+			prev: due.NextRun,
+			err:  scheduler.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateNextRun(context.Background(), claimed, tc.prev)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	finished := claimed
+	finished.NextRun = time.Time{}
+	err = repo.UpdateNextRun(context.Background(), finished, claimed.NextRun)
+	assert.Nil(t, err, fmt.Sprintf("finish schedule: got unexpected error: %s\n", err))
+
+	saved, err := repo.Retrieve(context.Background(), owner, due.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.True(t, saved.NextRun.IsZero(), fmt.Sprintf("finish schedule: expected no next run got %s\n", saved.NextRun))
+}
+
+func TestScheduleRemove(t *testing.T) {
+	repo := postgres.NewScheduleRepository(postgres.NewDatabase(db))
+
+	owner, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	s := newSchedule(t, owner, time.Now().Add(time.Hour))
+	_, err = repo.Save(context.Background(), s)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), "other", s.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove schedule of another owner: got unexpected error: %s", err))
+	_, err = repo.Retrieve(context.Background(), owner, s.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve schedule removed by another owner: got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), owner, s.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove schedule: got unexpected error: %s", err))
+	_, err = repo.Retrieve(context.Background(), owner, s.ID)
+	assert.True(t, errors.Contains(err, scheduler.ErrNotFound), fmt.Sprintf("retrieve removed schedule: expected %s got %s", scheduler.ErrNotFound, err))
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+// Execution statuses.
+const (
+	// Published is the status of the run which published the message.
+	Published = "published"
+	// Failed is the status of the run which failed to publish the message.
+	Failed = "failed"
+)
+
+// Schedule represents the message periodically, or only once, published to
+// the channel on behalf of the thing.
+type Schedule struct {
+	ID      string
+	OwnerID string
+	// ThingID is the ID of the thing set as the publisher of the messages.
+	ThingID   string
+	ChannelID string
+	Subtopic  string
+	Payload   []byte
+	// Cron is the cron expression of the recurring schedule. It's empty if
+	// the schedule runs only once.
+	Cron string
+	// RunAt is the time of the one-off schedule run.
+	RunAt time.Time
+	// NextRun is the time of the upcoming run. It's zero time once the
+	// schedule has no runs left.
+	NextRun   time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate returns an error if the schedule is not valid.
+func (s Schedule) Validate() error {
+	if s.ThingID == "" || s.ChannelID == "" || len(s.Payload) == 0 {
+		return ErrMalformedEntity
+	}
+	// Messages are published to the exact subtopic, so it can't contain
+	// wildcards.
+	if strings.ContainsAny(s.Subtopic, "*> ") {
+		return ErrMalformedEntity
+	}
+	// Schedule is either recurring or one-off.
+	if (s.Cron == "") == s.RunAt.IsZero() {
+		return ErrMalformedEntity
+	}
+	if s.Cron != "" {
+		if _, err := ParseCron(s.Cron); err != nil {
+			return errors.Wrap(ErrMalformedEntity, err)
+		}
+	}
+	return nil
+}
+
+// Next returns the time of the first run of the schedule after the given
+// time, or zero time if there is no such run.
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.Cron == "" {
+		if s.RunAt.After(t) {
+			return s.RunAt.UTC()
+		}
+		return time.Time{}
+	}
+
+	c, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return c.Next(t)
+}
+
+// Upcoming returns at most n upcoming runs of the schedule.
+func (s Schedule) Upcoming(n uint64) []time.Time {
+	runs := []time.Time{}
+	for t := s.NextRun; !t.IsZero() && uint64(len(runs)) < n; t = s.Next(t) {
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+// SchedulesPage contains page related metadata as well as list of schedules
+// that belong to this page.
+type SchedulesPage struct {
+	PageMetadata
+	Total     uint64
+	Schedules []Schedule
+}
+
+// Execution represents a single run of the schedule.
+type Execution struct {
+	ID         string
+	ScheduleID string
+	// ScheduledAt is the time the run was scheduled for.
+	ScheduledAt time.Time
+	// ExecutedAt is the time the run actually happened, which is later
+	// than the scheduled time if the service was unavailable.
+	ExecutedAt time.Time
+	Status     string
+	// Error describes the reason of the failed run.
+	Error string
+}
+
+// ExecutionsPage contains page related metadata as well as list of executions
+// that belong to this page.
+type ExecutionsPage struct {
+	PageMetadata
+	Total      uint64
+	Executions []Execution
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset    uint64
+	Limit     uint64
+	ChannelID string
+}
+
+// ScheduleRepository specifies a schedule persistence API.
+type ScheduleRepository interface {
+	// Save persists the schedule. Successful operation is indicated by
+	// non-nil error response.
+	Save(ctx context.Context, s Schedule) (string, error)
+
+	// Retrieve retrieves the schedule having the provided identifier, that
+	// is owned by the specified user.
+	Retrieve(ctx context.Context, owner, id string) (Schedule, error)
+
+	// RetrieveAll retrieves the subset of schedules owned by the specified
+	// user, ordered by their upcoming runs. Schedules without runs left
+	// come last.
+	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (SchedulesPage, error)
+
+	// RetrieveDue retrieves at most limit schedules whose upcoming run is
+	// not after the given time, starting from the most overdue one.
+	RetrieveDue(ctx context.Context, at time.Time, limit uint64) ([]Schedule, error)
+
+	// UpdateNextRun sets the upcoming run of the schedule, provided that
+	// its current upcoming run is the given one. It's used to claim the
+	// run, so that it's executed only once.
+	UpdateNextRun(ctx context.Context, s Schedule, prev time.Time) error
+
+	// Remove removes the schedule having the provided identifier, that is
+	// owned by the specified user, along with its executions.
+	Remove(ctx context.Context, owner, id string) error
+}
+
+// ExecutionRepository specifies an execution persistence API.
+type ExecutionRepository interface {
+	// Save persists the execution. Successful operation is indicated by
+	// non-nil error response.
+	Save(ctx context.Context, e Execution) (string, error)
+
+	// RetrieveAll retrieves the subset of executions of the schedule,
+	// starting from the most recent one.
+	RetrieveAll(ctx context.Context, scheduleID string, pm PageMetadata) (ExecutionsPage, error)
+}
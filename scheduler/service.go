@@ -0,0 +1,286 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const (
+	// Protocol is set as the protocol of the published messages.
+	Protocol = "scheduler"
+)
+
+var (
+	// ErrUnauthorizedAccess indicates missing or invalid credentials provided
+	// when accessing a protected resource.
+	ErrUnauthorizedAccess = errors.New("missing or invalid credentials provided")
+
+	// ErrMalformedEntity indicates malformed entity specification.
+	ErrMalformedEntity = errors.New("malformed entity specification")
+
+	// ErrCreateID indicates error in creating id for entity creation.
+	ErrCreateID = errors.New("failed to create id")
+
+	// ErrNotFound indicates a non-existent entity request.
+	ErrNotFound = errors.New("non-existent entity")
+
+	// ErrConflict indicates that entity already exists.
+	ErrConflict = errors.New("entity already exists")
+
+	// ErrSave indicates error saving entity.
+	ErrSave = errors.New("failed to save entity")
+
+	// ErrSelectEntity indicates problem with scanning data from db.
+	ErrSelectEntity = errors.New("failed to select entity")
+
+	// ErrUpdateEntity indicates error updating entity.
+	ErrUpdateEntity = errors.New("failed to update entity")
+
+	// ErrRemoveEntity indicates error removing entity.
+	ErrRemoveEntity = errors.New("failed to remove entity")
+
+	// ErrNoRuns indicates the schedule which would never run.
+	ErrNoRuns = errors.New("schedule has no upcoming runs")
+)
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateSchedule adds the schedule publishing to the channel owned by
+	// the user identified by the provided key. The thing set as the
+	// publisher has to be connected to the channel and allowed to publish.
+	CreateSchedule(ctx context.Context, token string, s Schedule) (Schedule, error)
+
+	// ViewSchedule retrieves the schedule having the provided identifier.
+	ViewSchedule(ctx context.Context, token, id string) (Schedule, error)
+
+	// ListSchedules retrieves the schedules of the user, ordered by their
+	// upcoming runs.
+	ListSchedules(ctx context.Context, token string, pm PageMetadata) (SchedulesPage, error)
+
+	// ListUpcoming returns at most n upcoming runs of the schedule.
+	ListUpcoming(ctx context.Context, token, id string, n uint64) ([]time.Time, error)
+
+	// RemoveSchedule removes the schedule having the provided identifier.
+	RemoveSchedule(ctx context.Context, token, id string) error
+
+	// ListExecutions retrieves the runs of the schedule, starting from the
+	// most recent one.
+	ListExecutions(ctx context.Context, token, id string, pm PageMetadata) (ExecutionsPage, error)
+
+	// RunDue publishes the messages of the schedules which are due, records
+	// their executions and returns the number of executed runs.
+	RunDue(ctx context.Context) (uint64, error)
+}
+
+var _ Service = (*schedulerService)(nil)
+
+type schedulerService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	schedules  ScheduleRepository
+	executions ExecutionRepository
+	idp        mainflux.IDProvider
+	publisher  messaging.Publisher
+	batchSize  uint64
+}
+
+// New instantiates the scheduler service implementation. At most batchSize
+// due schedules are run at once.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, schedules ScheduleRepository, executions ExecutionRepository, idp mainflux.IDProvider, pub messaging.Publisher, batchSize uint64) Service {
+	return &schedulerService{
+		auth:       auth,
+		things:     things,
+		schedules:  schedules,
+		executions: executions,
+		idp:        idp,
+		publisher:  pub,
+		batchSize:  batchSize,
+	}
+}
+
+func (ss *schedulerService) CreateSchedule(ctx context.Context, token string, s Schedule) (Schedule, error) {
+	res, err := ss.identify(ctx, token)
+	if err != nil {
+		return Schedule{}, err
+	}
+	if err := s.Validate(); err != nil {
+		return Schedule{}, err
+	}
+
+	if _, err := ss.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetEmail(), ChanID: s.ChannelID}); err != nil {
+		return Schedule{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if _, err := ss.things.IsThingOwner(ctx, &mainflux.ThingOwnerReq{Owner: res.GetEmail(), ThingID: s.ThingID}); err != nil {
+		return Schedule{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if err := ss.canPublish(ctx, s); err != nil {
+		return Schedule{}, err
+	}
+
+	now := time.Now().UTC()
+	s.NextRun = s.Next(now)
+	if s.NextRun.IsZero() {
+		return Schedule{}, errors.Wrap(ErrMalformedEntity, ErrNoRuns)
+	}
+
+	s.ID, err = ss.idp.ID()
+	if err != nil {
+		return Schedule{}, errors.Wrap(ErrCreateID, err)
+	}
+	s.OwnerID = res.GetId()
+	if !s.RunAt.IsZero() {
+		s.RunAt = s.RunAt.UTC()
+	}
+	s.CreatedAt = now
+	s.UpdatedAt = now
+
+	if _, err := ss.schedules.Save(ctx, s); err != nil {
+		return Schedule{}, err
+	}
+
+	return s, nil
+}
+
+func (ss *schedulerService) ViewSchedule(ctx context.Context, token, id string) (Schedule, error) {
+	res, err := ss.identify(ctx, token)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	return ss.schedules.Retrieve(ctx, res.GetId(), id)
+}
+
+func (ss *schedulerService) ListSchedules(ctx context.Context, token string, pm PageMetadata) (SchedulesPage, error) {
+	res, err := ss.identify(ctx, token)
+	if err != nil {
+		return SchedulesPage{}, err
+	}
+
+	return ss.schedules.RetrieveAll(ctx, res.GetId(), pm)
+}
+
+func (ss *schedulerService) ListUpcoming(ctx context.Context, token, id string, n uint64) ([]time.Time, error) {
+	s, err := ss.ViewSchedule(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Upcoming(n), nil
+}
+
+func (ss *schedulerService) RemoveSchedule(ctx context.Context, token, id string) error {
+	res, err := ss.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return ss.schedules.Remove(ctx, res.GetId(), id)
+}
+
+func (ss *schedulerService) ListExecutions(ctx context.Context, token, id string, pm PageMetadata) (ExecutionsPage, error) {
+	// Retrieving the schedule checks that it's owned by the user.
+	if _, err := ss.ViewSchedule(ctx, token, id); err != nil {
+		return ExecutionsPage{}, err
+	}
+
+	return ss.executions.RetrieveAll(ctx, id, pm)
+}
+
+func (ss *schedulerService) RunDue(ctx context.Context) (uint64, error) {
+	now := time.Now().UTC()
+	due, err := ss.schedules.RetrieveDue(ctx, now, ss.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var cnt uint64
+	for _, s := range due {
+		// Runs missed while the service was unavailable are executed
+		// only once, and the schedule continues from the current time.
+		scheduled := s.NextRun
+		s.NextRun = s.Next(now)
+		s.UpdatedAt = now
+		if err := ss.schedules.UpdateNextRun(ctx, s, scheduled); err != nil {
+			if errors.Contains(err, ErrNotFound) {
+				// The run is claimed by another instance.
+				continue
+			}
+			return cnt, err
+		}
+
+		exec, err := ss.run(ctx, s, scheduled)
+		if err != nil {
+			return cnt, err
+		}
+		// Execution of the schedule removed in the meantime is not found.
+		if _, err := ss.executions.Save(ctx, exec); err != nil && !errors.Contains(err, ErrNotFound) {
+			return cnt, err
+		}
+		cnt++
+	}
+
+	return cnt, nil
+}
+
+// run publishes the message of the schedule. Failures to publish are recorded
+// in the execution, instead of being returned.
+func (ss *schedulerService) run(ctx context.Context, s Schedule, scheduled time.Time) (Execution, error) {
+	id, err := ss.idp.ID()
+	if err != nil {
+		return Execution{}, errors.Wrap(ErrCreateID, err)
+	}
+
+	exec := Execution{
+		ID:          id,
+		ScheduleID:  s.ID,
+		ScheduledAt: scheduled,
+		ExecutedAt:  time.Now().UTC(),
+		Status:      Published,
+	}
+
+	// The thing may have been disconnected since the schedule was created.
+	if err := ss.canPublish(ctx, s); err != nil {
+		exec.Status = Failed
+		exec.Error = err.Error()
+		return exec, nil
+	}
+
+	msg := messaging.Message{
+		Channel:   s.ChannelID,
+		Subtopic:  s.Subtopic,
+		Publisher: s.ThingID,
+		Protocol:  Protocol,
+		Payload:   s.Payload,
+		Created:   exec.ExecutedAt.UnixNano(),
+	}
+	if err := ss.publisher.Publish(msg.Channel, msg); err != nil {
+		exec.Status = Failed
+		exec.Error = err.Error()
+	}
+
+	return exec, nil
+}
+
+func (ss *schedulerService) canPublish(ctx context.Context, s Schedule) error {
+	req := &mainflux.AccessByIDReq{ThingID: s.ThingID, ChanID: s.ChannelID, Action: messaging.PublishAction}
+	if _, err := ss.things.CanAccessByID(ctx, req); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return nil
+}
+
+func (ss *schedulerService) identify(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	res, err := ss.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,467 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/pkg/uuid"
+	"github.com/mainflux/mainflux/scheduler"
+	"github.com/mainflux/mainflux/scheduler/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token          = "token"
+	wrongToken     = "wrong-token"
+	email          = "user@example.com"
+	otherToken     = "other-token"
+	otherEmail     = "other@example.com"
+	chanID         = "chan"
+	otherChanID    = "other-chan"
+	thingID        = "thing"
+	otherThingID   = "other-thing"
+	foreignThingID = "foreign-thing"
+	batchSize      = 10
+)
+
+var schedule = scheduler.Schedule{
+	ThingID:   thingID,
+	ChannelID: chanID,
+	Subtopic:  "setpoints",
+	Payload:   []byte(`[{"n":"temperature","v":21}]`),
+	Cron:      "0 8 * * 1-5",
+}
+
+func newService(pub messaging.Publisher) (scheduler.Service, scheduler.ScheduleRepository) {
+	auth := mocks.NewAuthService(map[string]string{token: email, otherToken: otherEmail})
+	things := mocks.NewThingsService(
+		map[string]string{thingID: email, otherThingID: email, foreignThingID: otherEmail},
+		map[string]string{chanID: email, otherChanID: otherEmail},
+		map[string][]string{chanID: {thingID, foreignThingID}, otherChanID: {otherThingID}},
+	)
+	repo := mocks.NewScheduleRepository()
+	return scheduler.New(auth, things, repo, mocks.NewExecutionRepository(), uuid.NewMock(), pub, batchSize), repo
+}
+
+func TestCreateSchedule(t *testing.T) {
+	svc, _ := newService(mocks.NewPublisher())
+
+	runAt := time.Now().Add(time.Hour).UTC()
+	oneOff := schedule
+	oneOff.Cron = ""
+	oneOff.RunAt = runAt
+
+	past := oneOff
+	past.RunAt = time.Now().Add(-time.Hour)
+
+	both := schedule
+	both.RunAt = runAt
+
+	never := schedule
+	never.Cron = "0 0 30 2 *"
+
+	invalidCron := schedule
+	invalidCron.Cron = "* * *"
+
+	wildcard := schedule
+	wildcard.Subtopic = "setpoints.*"
+
+	empty := schedule
+	empty.Payload = nil
+
+	notConnected := schedule
+	notConnected.ThingID = otherThingID
+
+	foreign := schedule
+	foreign.ThingID = foreignThingID
+
+	cases := []struct {
+		desc     string
+		token    string
+		schedule scheduler.Schedule
+		next     time.Time
+		err      error
+	}{
+		{
+			desc:     "create recurring schedule",
+			token:    token,
+			schedule: schedule,
+			err:      nil,
+		},
+		{
+			desc:     "create one-off schedule",
+			token:    token,
+			schedule: oneOff,
+			next:     runAt,
+			err:      nil,
+		},
+		{
+			desc:     "create schedule with invalid token",
+			token:    wrongToken,
+			schedule: schedule,
+			err:      scheduler.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "create schedule for the channel owned by another user",
+			token:    otherToken,
+			schedule: schedule,
+			err:      scheduler.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "create schedule for the thing owned by another user",
+			token:    token,
+			schedule: foreign,
+			err:      scheduler.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "create schedule for the thing not connected to the channel",
+			token:    token,
+			schedule: notConnected,
+			err:      scheduler.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "create one-off schedule in the past",
+			token:    token,
+			schedule: past,
+			err:      scheduler.ErrMalformedEntity,
+		},
+		{
+			desc:     "create schedule with both cron and run time",
+			token:    token,
+			schedule: both,
+			err:      scheduler.ErrMalformedEntity,
+		},
+		{
+			desc:     "create schedule which never runs",
+			token:    token,
+			schedule: never,
+			err:      scheduler.ErrNoRuns,
+		},
+		{
+			desc:     "create schedule with invalid cron",
+			token:    token,
+			schedule: invalidCron,
+			err:      scheduler.ErrInvalidCron,
+		},
+		{
+			desc:     "create schedule with wildcard subtopic",
+			token:    token,
+			schedule: wildcard,
+			err:      scheduler.ErrMalformedEntity,
+		},
+		{
+			desc:     "create schedule without payload",
+			token:    token,
+			schedule: empty,
+			err:      scheduler.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := svc.CreateSchedule(context.Background(), tc.token, tc.schedule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected non-empty ID\n", tc.desc))
+		assert.Equal(t, email, saved.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, email, saved.OwnerID))
+		assert.True(t, saved.NextRun.After(saved.CreatedAt), fmt.Sprintf("%s: expected next run after %s got %s\n", tc.desc, saved.CreatedAt, saved.NextRun))
+		if !tc.next.IsZero() {
+			assert.True(t, tc.next.Equal(saved.NextRun), fmt.Sprintf("%s: expected next run %s got %s\n", tc.desc, tc.next, saved.NextRun))
+		}
+	}
+}
+
+func TestListUpcoming(t *testing.T) {
+	svc, _ := newService(mocks.NewPublisher())
+
+	hourly := schedule
+	hourly.Cron = "@hourly"
+	recurring, err := svc.CreateSchedule(context.Background(), token, hourly)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	oneOff := schedule
+	oneOff.Cron = ""
+	oneOff.RunAt = time.Now().Add(time.Hour)
+	once, err := svc.CreateSchedule(context.Background(), token, oneOff)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		n     uint64
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list upcoming runs of recurring schedule",
+			token: token,
+			id:    recurring.ID,
+			n:     5,
+			size:  5,
+			err:   nil,
+		},
+		{
+			desc:  "list upcoming runs of one-off schedule",
+			token: token,
+			id:    once.ID,
+			n:     5,
+			size:  1,
+			err:   nil,
+		},
+		{
+			desc:  "list upcoming runs of schedule owned by another user",
+			token: otherToken,
+			id:    recurring.ID,
+			n:     5,
+			size:  0,
+			err:   scheduler.ErrNotFound,
+		},
+		{
+			desc:  "list upcoming runs with invalid token",
+			token: wrongToken,
+			id:    recurring.ID,
+			n:     5,
+			size:  0,
+			err:   scheduler.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		runs, err := svc.ListUpcoming(context.Background(), tc.token, tc.id, tc.n)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Len(t, runs, tc.size, fmt.Sprintf("%s: expected %d runs got %d\n", tc.desc, tc.size, len(runs)))
+		for i := 1; i < len(runs); i++ {
+			assert.Equal(t, time.Hour, runs[i].Sub(runs[i-1]), fmt.Sprintf("%s: expected runs an hour apart got %s and %s\n", tc.desc, runs[i-1], runs[i]))
+		}
+	}
+}
+
+func TestListSchedules(t *testing.T) {
+	svc, _ := newService(mocks.NewPublisher())
+
+	var ids []string
+	for i := 0; i < 5; i++ {
+		s := schedule
+		s.Cron = ""
+		// Create schedules in the reversed order of their runs.
+		s.RunAt = time.Now().Add(time.Duration(5-i) * time.Hour)
+		saved, err := svc.CreateSchedule(context.Background(), token, s)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, saved.ID)
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    scheduler.PageMetadata
+		ids   []string
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all schedules",
+			token: token,
+			pm:    scheduler.PageMetadata{Limit: 10},
+			ids:   []string{ids[4], ids[3], ids[2], ids[1], ids[0]},
+			total: 5,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules with offset and limit",
+			token: token,
+			pm:    scheduler.PageMetadata{Offset: 1, Limit: 2},
+			ids:   []string{ids[3], ids[2]},
+			total: 5,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules of the channel",
+			token: token,
+			pm:    scheduler.PageMetadata{Limit: 10, ChannelID: otherChanID},
+			ids:   nil,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules of another user",
+			token: otherToken,
+			pm:    scheduler.PageMetadata{Limit: 10},
+			ids:   nil,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list schedules with invalid token",
+			token: wrongToken,
+			pm:    scheduler.PageMetadata{Limit: 10},
+			ids:   nil,
+			total: 0,
+			err:   scheduler.ErrUnauthorizedAccess,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListSchedules(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		var got []string
+		for _, s := range page.Schedules {
+			got = append(got, s.ID)
+		}
+		assert.Equal(t, tc.ids, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, got))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRemoveSchedule(t *testing.T) {
+	svc, _ := newService(mocks.NewPublisher())
+	saved, err := svc.CreateSchedule(context.Background(), token, schedule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove schedule with invalid token",
+			token: wrongToken,
+			id:    saved.ID,
+			err:   scheduler.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "remove schedule of another user",
+			token: otherToken,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove schedule",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed schedule",
+			token: token,
+			id:    saved.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveSchedule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.ViewSchedule(context.Background(), token, saved.ID)
+	assert.True(t, errors.Contains(err, scheduler.ErrNotFound), fmt.Sprintf("view removed schedule: expected %s got %s\n", scheduler.ErrNotFound, err))
+}
+
+func TestRunDue(t *testing.T) {
+	pub := mocks.NewPublisher()
+	svc, repo := newService(pub)
+
+	now := time.Now().UTC()
+	// Schedules are saved directly, since the service accepts only
+	// schedules with upcoming runs.
+	recurring := schedule
+	recurring.ID = "recurring"
+	recurring.OwnerID = email
+	recurring.NextRun = now.Add(-time.Hour)
+
+	once := schedule
+	once.ID = "once"
+	once.OwnerID = email
+	once.Cron = ""
+	once.RunAt = now.Add(-time.Minute)
+	once.NextRun = once.RunAt
+
+	disconnected := schedule
+	disconnected.ID = "disconnected"
+	disconnected.OwnerID = email
+	disconnected.ThingID = otherThingID
+	disconnected.NextRun = now.Add(-time.Minute)
+
+	upcoming := schedule
+	upcoming.ID = "upcoming"
+	upcoming.OwnerID = email
+	upcoming.NextRun = now.Add(time.Hour)
+
+	for _, s := range []scheduler.Schedule{recurring, once, disconnected, upcoming} {
+		_, err := repo.Save(context.Background(), s)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	n, err := svc.RunDue(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("run due schedules: unexpected error %s\n", err))
+	assert.Equal(t, uint64(3), n, fmt.Sprintf("run due schedules: expected 3 runs got %d\n", n))
+
+	topics, msgs := pub.Published()
+	require.Len(t, msgs, 2, "run due schedules: expected two published messages")
+	assert.Equal(t, []string{chanID, chanID}, topics, fmt.Sprintf("run due schedules: expected topics %s got %v\n", chanID, topics))
+	for _, msg := range msgs {
+		assert.Equal(t, thingID, msg.Publisher, fmt.Sprintf("run due schedules: expected publisher %s got %s\n", thingID, msg.Publisher))
+		assert.Equal(t, schedule.Subtopic, msg.Subtopic, fmt.Sprintf("run due schedules: expected subtopic %s got %s\n", schedule.Subtopic, msg.Subtopic))
+		assert.Equal(t, scheduler.Protocol, msg.Protocol, fmt.Sprintf("run due schedules: expected protocol %s got %s\n", scheduler.Protocol, msg.Protocol))
+		assert.Equal(t, schedule.Payload, msg.Payload, fmt.Sprintf("run due schedules: expected payload %s got %s\n", schedule.Payload, msg.Payload))
+	}
+
+	cases := []struct {
+		desc   string
+		id     string
+		status string
+		done   bool
+	}{
+		{
+			desc:   "run recurring schedule",
+			id:     recurring.ID,
+			status: scheduler.Published,
+			done:   false,
+		},
+		{
+			desc:   "run one-off schedule",
+			id:     once.ID,
+			status: scheduler.Published,
+			done:   true,
+		},
+		{
+			desc:   "run schedule of disconnected thing",
+			id:     disconnected.ID,
+			status: scheduler.Failed,
+			done:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		s, err := svc.ViewSchedule(context.Background(), token, tc.id)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.done, s.NextRun.IsZero(), fmt.Sprintf("%s: expected no runs left %t got next run %s\n", tc.desc, tc.done, s.NextRun))
+		if !tc.done {
+			assert.True(t, s.NextRun.After(now), fmt.Sprintf("%s: expected next run after %s got %s\n", tc.desc, now, s.NextRun))
+		}
+
+		page, err := svc.ListExecutions(context.Background(), token, tc.id, scheduler.PageMetadata{Limit: 10})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		require.Len(t, page.Executions, 1, fmt.Sprintf("%s: expected one execution\n", tc.desc))
+		assert.Equal(t, tc.status, page.Executions[0].Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, page.Executions[0].Status))
+	}
+
+	n, err = svc.RunDue(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("run due schedules again: unexpected error %s\n", err))
+	assert.Equal(t, uint64(0), n, fmt.Sprintf("run due schedules again: expected no runs got %d\n", n))
+
+	_, err = svc.ListExecutions(context.Background(), otherToken, recurring.ID, scheduler.PageMetadata{Limit: 10})
+	assert.True(t, errors.Contains(err, scheduler.ErrNotFound), fmt.Sprintf("list executions of another user: expected %s got %s\n", scheduler.ErrNotFound, err))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/mainflux/mainflux/scheduler"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveExecutionOp         = "save_execution"
+	retrieveAllExecutionsOp = "retrieve_all_executions"
+)
+
+var _ scheduler.ExecutionRepository = (*executionRepositoryMiddleware)(nil)
+
+type executionRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   scheduler.ExecutionRepository
+}
+
+// ExecutionRepositoryMiddleware tracks request and their latency, and adds
+// spans to context.
+func ExecutionRepositoryMiddleware(tracer opentracing.Tracer, repo scheduler.ExecutionRepository) scheduler.ExecutionRepository {
+	return executionRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (erm executionRepositoryMiddleware) Save(ctx context.Context, e scheduler.Execution) (string, error) {
+	span := createSpan(ctx, erm.tracer, saveExecutionOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return erm.repo.Save(ctx, e)
+}
+
+func (erm executionRepositoryMiddleware) RetrieveAll(ctx context.Context, scheduleID string, pm scheduler.PageMetadata) (scheduler.ExecutionsPage, error) {
+	span := createSpan(ctx, erm.tracer, retrieveAllExecutionsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return erm.repo.RetrieveAll(ctx, scheduleID, pm)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/scheduler"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveScheduleOp         = "save_schedule"
+	retrieveScheduleOp     = "retrieve_schedule"
+	retrieveAllSchedulesOp = "retrieve_all_schedules"
+	retrieveDueOp          = "retrieve_due_schedules"
+	updateNextRunOp        = "update_schedule_next_run"
+	removeScheduleOp       = "remove_schedule"
+)
+
+var _ scheduler.ScheduleRepository = (*scheduleRepositoryMiddleware)(nil)
+
+type scheduleRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   scheduler.ScheduleRepository
+}
+
+// ScheduleRepositoryMiddleware tracks request and their latency, and adds
+// spans to context.
+func ScheduleRepositoryMiddleware(tracer opentracing.Tracer, repo scheduler.ScheduleRepository) scheduler.ScheduleRepository {
+	return scheduleRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (srm scheduleRepositoryMiddleware) Save(ctx context.Context, s scheduler.Schedule) (string, error) {
+	span := createSpan(ctx, srm.tracer, saveScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Save(ctx, s)
+}
+
+func (srm scheduleRepositoryMiddleware) Retrieve(ctx context.Context, owner, id string) (scheduler.Schedule, error) {
+	span := createSpan(ctx, srm.tracer, retrieveScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Retrieve(ctx, owner, id)
+}
+
+func (srm scheduleRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm scheduler.PageMetadata) (scheduler.SchedulesPage, error) {
+	span := createSpan(ctx, srm.tracer, retrieveAllSchedulesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.RetrieveAll(ctx, owner, pm)
+}
+
+func (srm scheduleRepositoryMiddleware) RetrieveDue(ctx context.Context, at time.Time, limit uint64) ([]scheduler.Schedule, error) {
+	span := createSpan(ctx, srm.tracer, retrieveDueOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.RetrieveDue(ctx, at, limit)
+}
+
+func (srm scheduleRepositoryMiddleware) UpdateNextRun(ctx context.Context, s scheduler.Schedule, prev time.Time) error {
+	span := createSpan(ctx, srm.tracer, updateNextRunOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.UpdateNextRun(ctx, s, prev)
+}
+
+func (srm scheduleRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
+	span := createSpan(ctx, srm.tracer, removeScheduleOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return srm.repo.Remove(ctx, owner, id)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
@@ -23,6 +23,7 @@ type grpcClient struct {
 	canAccessByKey endpoint.Endpoint
 	canAccessByID  endpoint.Endpoint
 	isChannelOwner endpoint.Endpoint
+	isThingOwner   endpoint.Endpoint
 	identify       endpoint.Endpoint
 }
 
@@ -56,6 +57,14 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		isThingOwner: kitot.TraceClient(tracer, "is_thing_owner")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"IsThingOwner",
+			encodeIsThingOwner,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
 		identify: kitot.TraceClient(tracer, "identify")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -74,6 +83,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 	ar := AccessByKeyReq{
 		thingKey: req.GetToken(),
 		chanID:   req.GetChanID(),
+		action:   req.GetAction(),
 	}
 	res, err := client.canAccessByKey(ctx, ar)
 	if err != nil {
@@ -85,7 +95,7 @@ func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.Acces
 }
 
 func (client grpcClient) CanAccessByID(ctx context.Context, req *mainflux.AccessByIDReq, _ ...grpc.CallOption) (*empty.Empty, error) {
-	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}
+	ar := accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), action: req.GetAction()}
 	res, err := client.canAccessByID(ctx, ar)
 	if err != nil {
 		return nil, err
@@ -106,6 +116,17 @@ func (client grpcClient) IsChannelOwner(ctx context.Context, req *mainflux.Chann
 	return &empty.Empty{}, er.err
 }
 
+func (client grpcClient) IsThingOwner(ctx context.Context, req *mainflux.ThingOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ar := thingOwnerReq{owner: req.GetOwner(), thingID: req.GetThingID()}
+	res, err := client.isThingOwner(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
 func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ...grpc.CallOption) (*mainflux.ThingID, error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
@@ -121,12 +142,12 @@ func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ..
 
 func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(AccessByKeyReq)
-	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID}, nil
+	return &mainflux.AccessByKeyReq{Token: req.thingKey, ChanID: req.chanID, Action: req.action}, nil
 }
 
 func encodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(accessByIDReq)
-	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID}, nil
+	return &mainflux.AccessByIDReq{ThingID: req.thingID, ChanID: req.chanID, Action: req.action}, nil
 }
 
 func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -134,6 +155,11 @@ func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{},
 	return &mainflux.ChannelOwnerReq{Owner: req.owner, ChanID: req.chanID}, nil
 }
 
+func encodeIsThingOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(thingOwnerReq)
+	return &mainflux.ThingOwnerReq{Owner: req.owner, ThingID: req.thingID}, nil
+}
+
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(identifyReq)
 	return &mainflux.Token{Value: req.key}, nil
@@ -17,7 +17,7 @@ func canAccessEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.thingKey, req.action)
 		if err != nil {
 			return identityRes{}, err
 		}
@@ -32,7 +32,7 @@ func canAccessByIDEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		err := svc.CanAccessByID(ctx, req.chanID, req.thingID)
+		err := svc.CanAccessByID(ctx, req.chanID, req.thingID, req.action)
 		return emptyRes{err: err}, err
 	}
 }
@@ -49,6 +49,18 @@ func isChannelOwnerEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func isThingOwnerEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(thingOwnerReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		err := svc.IsThingOwner(ctx, req.owner, req.thingID)
+		return emptyRes{err: err}, err
+	}
+}
+
 func identifyEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(identifyReq)
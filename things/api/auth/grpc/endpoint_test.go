@@ -37,7 +37,7 @@ func TestCanAccessByKey(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th1.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th1.ID}, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
@@ -99,7 +99,7 @@ func TestCanAccessByID(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	svc.Connect(context.Background(), token, []string{ch.ID}, []string{th2.ID})
+	svc.Connect(context.Background(), token, []string{ch.ID}, []string{th2.ID}, "")
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
 	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
@@ -148,6 +148,58 @@ func TestCanAccessByID(t *testing.T) {
 	}
 }
 
+func TestIsThingOwner(t *testing.T) {
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		owner   string
+		thingID string
+		code    codes.Code
+	}{
+		"check if user owns existing thing": {
+			owner:   email,
+			thingID: th.ID,
+			code:    codes.OK,
+		},
+		"check if other user owns existing thing": {
+			owner:   wrong,
+			thingID: th.ID,
+			code:    codes.NotFound,
+		},
+		"check if user owns non-existent thing": {
+			owner:   email,
+			thingID: wrong,
+			code:    codes.NotFound,
+		},
+		"check if user owns thing with empty ID": {
+			owner:   email,
+			thingID: "",
+			code:    codes.InvalidArgument,
+		},
+		"check if user with empty email owns existing thing": {
+			owner:   "",
+			thingID: th.ID,
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		_, err := cli.IsThingOwner(ctx, &mainflux.ThingOwnerReq{Owner: tc.owner, ThingID: tc.thingID})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
@@ -8,6 +8,7 @@ import "github.com/mainflux/mainflux/things"
 type AccessByKeyReq struct {
 	thingKey string
 	chanID   string
+	action   string
 }
 
 func (req AccessByKeyReq) validate() error {
@@ -15,12 +16,17 @@ func (req AccessByKeyReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if things.ConnTypes(req.action) == nil {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
 
 type accessByIDReq struct {
 	thingID string
 	chanID  string
+	action  string
 }
 
 func (req accessByIDReq) validate() error {
@@ -28,6 +34,10 @@ func (req accessByIDReq) validate() error {
 		return things.ErrMalformedEntity
 	}
 
+	if things.ConnTypes(req.action) == nil {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
 
@@ -44,6 +54,19 @@ func (req channelOwnerReq) validate() error {
 	return nil
 }
 
+type thingOwnerReq struct {
+	owner   string
+	thingID string
+}
+
+func (req thingOwnerReq) validate() error {
+	if req.owner == "" || req.thingID == "" {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type identifyReq struct {
 	key string
 }
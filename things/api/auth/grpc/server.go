@@ -22,6 +22,7 @@ type grpcServer struct {
 	canAccessByKey kitgrpc.Handler
 	canAccessByID  kitgrpc.Handler
 	isChannelOwner kitgrpc.Handler
+	isThingOwner   kitgrpc.Handler
 	identify       kitgrpc.Handler
 }
 
@@ -43,6 +44,11 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeIsChannelOwnerRequest,
 			encodeEmptyResponse,
 		),
+		isThingOwner: kitgrpc.NewServer(
+			isThingOwnerEndpoint(svc),
+			decodeIsThingOwnerRequest,
+			encodeEmptyResponse,
+		),
 		identify: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "identify")(identifyEndpoint(svc)),
 			decodeIdentifyRequest,
@@ -78,6 +84,15 @@ func (gs *grpcServer) IsChannelOwner(ctx context.Context, req *mainflux.ChannelO
 	return res.(*empty.Empty), nil
 }
 
+func (gs *grpcServer) IsThingOwner(ctx context.Context, req *mainflux.ThingOwnerReq) (*empty.Empty, error) {
+	_, res, err := gs.isThingOwner.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*empty.Empty), nil
+}
+
 func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainflux.ThingID, error) {
 	_, res, err := gs.identify.ServeGRPC(ctx, req)
 	if err != nil {
@@ -89,12 +104,12 @@ func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainf
 
 func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByKeyReq)
-	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID()}, nil
+	return AccessByKeyReq{thingKey: req.GetToken(), chanID: req.GetChanID(), action: req.GetAction()}, nil
 }
 
 func decodeCanAccessByIDRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AccessByIDReq)
-	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID()}, nil
+	return accessByIDReq{thingID: req.GetThingID(), chanID: req.GetChanID(), action: req.GetAction()}, nil
 }
 
 func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -102,6 +117,11 @@ func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interf
 	return channelOwnerReq{owner: req.GetOwner(), chanID: req.GetChanID()}, nil
 }
 
+func decodeIsThingOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingOwnerReq)
+	return thingOwnerReq{owner: req.GetOwner(), thingID: req.GetThingID()}, nil
+}
+
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.Token)
 	return identifyReq{key: req.GetValue()}, nil
@@ -155,7 +155,7 @@ func TestCanAccessByKey(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	ch := chs[0]
 
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, "")
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
 	data := toJSON(canAccessByKeyReq{
@@ -233,7 +233,7 @@ func TestCanAccessByID(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("failed to create channel: %s", err))
 	ch := chs[0]
 
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, "")
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
 	data := toJSON(canAccessByIDReq{
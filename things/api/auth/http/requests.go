@@ -20,6 +20,7 @@ func (req identifyReq) validate() error {
 type canAccessByKeyReq struct {
 	chanID string
 	Token  string `json:"token"`
+	Action string `json:"action,omitempty"`
 }
 
 func (req canAccessByKeyReq) validate() error {
@@ -27,12 +28,17 @@ func (req canAccessByKeyReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
+	if things.ConnTypes(req.Action) == nil {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
 
 type canAccessByIDReq struct {
 	chanID  string
 	ThingID string `json:"thing_id"`
+	Action  string `json:"action,omitempty"`
 }
 
 func (req canAccessByIDReq) validate() error {
@@ -40,5 +46,9 @@ func (req canAccessByIDReq) validate() error {
 		return things.ErrUnauthorizedAccess
 	}
 
+	if things.ConnTypes(req.Action) == nil {
+		return things.ErrMalformedEntity
+	}
+
 	return nil
 }
@@ -122,6 +122,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusNotFound)
 	case things.ErrEntityConnected:
 		w.WriteHeader(http.StatusForbidden)
+	case things.ErrMalformedEntity:
+		w.WriteHeader(http.StatusBadRequest)
 
 	case errors.ErrUnsupportedContentType:
 		w.WriteHeader(http.StatusUnsupportedMediaType)
@@ -65,6 +65,19 @@ func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return lm.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (lm *loggingMiddleware) RotateKey(ctx context.Context, token, id string, grace time.Duration) (key string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method rotate_key for thing %s with grace period %s took %s to complete", id, grace, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RotateKey(ctx, token, id, grace)
+}
+
 func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (thing things.Thing, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_thing for token %s and thing %s took %s to complete", token, id, time.Since(begin))
@@ -201,9 +214,9 @@ func (lm *loggingMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return lm.svc.RemoveChannel(ctx, token, id)
 }
 
-func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) (err error) {
+func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string, connType string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method connect for token %s, channels %s and things %s took %s to complete", token, chIDs, thIDs, time.Since(begin))
+		message := fmt.Sprintf("Method connect for token %s, channels %s and things %s of type %s took %s to complete", token, chIDs, thIDs, connType, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -211,7 +224,7 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, token string, chIDs, t
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Connect(ctx, token, chIDs, thIDs)
+	return lm.svc.Connect(ctx, token, chIDs, thIDs, connType)
 }
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chanID, thingID string) (err error) {
@@ -227,9 +240,9 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chanID, thin
 	return lm.svc.Disconnect(ctx, token, chanID, thingID)
 }
 
-func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string) (thing string, err error) {
+func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key, action string) (thing string, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access for channel %s and thing %s took %s to complete", id, thing, time.Since(begin))
+		message := fmt.Sprintf("Method can_access for channel %s, thing %s and action %s took %s to complete", id, thing, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -237,12 +250,12 @@ func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, id, key string)
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByKey(ctx, id, key)
+	return lm.svc.CanAccessByKey(ctx, id, key, action)
 }
 
-func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) (err error) {
+func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access_by_id for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		message := fmt.Sprintf("Method can_access_by_id for channel %s, thing %s and action %s took %s to complete", chanID, thingID, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -250,7 +263,7 @@ func (lm *loggingMiddleware) CanAccessByID(ctx context.Context, chanID, thingID
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.CanAccessByID(ctx, chanID, thingID)
+	return lm.svc.CanAccessByID(ctx, chanID, thingID, action)
 }
 
 func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
@@ -266,6 +279,19 @@ func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID s
 	return lm.svc.IsChannelOwner(ctx, owner, chanID)
 }
 
+func (lm *loggingMiddleware) IsThingOwner(ctx context.Context, owner, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method is_thing_owner for thing %s and user %s took %s to complete", thingID, owner, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.IsThingOwner(ctx, owner, thingID)
+}
+
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id string, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method identify for token %s and thing %s took %s to complete", key, id, time.Since(begin))
@@ -291,3 +317,55 @@ func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return lm.svc.ListMembers(ctx, token, groupID,  pm)
 }
+
+func (lm *loggingMiddleware) ShareThing(ctx context.Context, token, thingID string, actions, subjects []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share_thing for thing %s with actions %v and subjects %v took %s to complete", thingID, actions, subjects, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareThing(ctx, token, thingID, actions, subjects)
+}
+
+func (lm *loggingMiddleware) UnshareThing(ctx context.Context, token, thingID string, actions, subjects []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unshare_thing for thing %s with actions %v and subjects %v took %s to complete", thingID, actions, subjects, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnshareThing(ctx, token, thingID, actions, subjects)
+}
+
+func (lm *loggingMiddleware) ShareChannel(ctx context.Context, token, chanID string, actions, subjects []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method share_channel for channel %s with actions %v and subjects %v took %s to complete", chanID, actions, subjects, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ShareChannel(ctx, token, chanID, actions, subjects)
+}
+
+func (lm *loggingMiddleware) UnshareChannel(ctx context.Context, token, chanID string, actions, subjects []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unshare_channel for channel %s with actions %v and subjects %v took %s to complete", chanID, actions, subjects, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnshareChannel(ctx, token, chanID, actions, subjects)
+}
@@ -57,6 +57,15 @@ func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return ms.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (ms *metricsMiddleware) RotateKey(ctx context.Context, token, id string, grace time.Duration) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "rotate_key").Add(1)
+		ms.latency.With("method", "rotate_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RotateKey(ctx, token, id, grace)
+}
+
 func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_thing").Add(1)
@@ -147,13 +156,13 @@ func (ms *metricsMiddleware) RemoveChannel(ctx context.Context, token, id string
 	return ms.svc.RemoveChannel(ctx, token, id)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
+func (ms *metricsMiddleware) Connect(ctx context.Context, token string, chIDs, thIDs []string, connType string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Connect(ctx, token, chIDs, thIDs)
+	return ms.svc.Connect(ctx, token, chIDs, thIDs, connType)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chanID, thingID string) error {
@@ -165,22 +174,22 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chanID, thin
 	return ms.svc.Disconnect(ctx, token, chanID, thingID)
 }
 
-func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key string) (string, error) {
+func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, id, key, action string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_key").Add(1)
 		ms.latency.With("method", "can_access_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByKey(ctx, id, key)
+	return ms.svc.CanAccessByKey(ctx, id, key, action)
 }
 
-func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID string) error {
+func (ms *metricsMiddleware) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "can_access_by_id").Add(1)
 		ms.latency.With("method", "can_access_by_id").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.CanAccessByID(ctx, chanID, thingID)
+	return ms.svc.CanAccessByID(ctx, chanID, thingID, action)
 }
 
 func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -192,6 +201,15 @@ func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID s
 	return ms.svc.IsChannelOwner(ctx, owner, chanID)
 }
 
+func (ms *metricsMiddleware) IsThingOwner(ctx context.Context, owner, thingID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "is_thing_owner").Add(1)
+		ms.latency.With("method", "is_thing_owner").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.IsThingOwner(ctx, owner, thingID)
+}
+
 func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "identify").Add(1)
@@ -209,3 +227,39 @@ func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return ms.svc.ListMembers(ctx, token, groupID,  pm)
 }
+
+func (ms *metricsMiddleware) ShareThing(ctx context.Context, token, thingID string, actions, subjects []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_thing").Add(1)
+		ms.latency.With("method", "share_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareThing(ctx, token, thingID, actions, subjects)
+}
+
+func (ms *metricsMiddleware) UnshareThing(ctx context.Context, token, thingID string, actions, subjects []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unshare_thing").Add(1)
+		ms.latency.With("method", "unshare_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnshareThing(ctx, token, thingID, actions, subjects)
+}
+
+func (ms *metricsMiddleware) ShareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "share_channel").Add(1)
+		ms.latency.With("method", "share_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ShareChannel(ctx, token, chanID, actions, subjects)
+}
+
+func (ms *metricsMiddleware) UnshareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unshare_channel").Add(1)
+		ms.latency.With("method", "unshare_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnshareChannel(ctx, token, chanID, actions, subjects)
+}
@@ -5,6 +5,7 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/mainflux/mainflux/auth"
@@ -121,6 +122,25 @@ func updateKeyEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func rotateKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		grace := time.Duration(req.GracePeriod) * time.Second
+		key, err := svc.RotateKey(ctx, req.token, req.id, grace)
+		if err != nil {
+			return nil, err
+		}
+
+		res := rotateKeyRes{ID: req.id, Key: key}
+		return res, nil
+	}
+}
+
 func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewResourceReq)
@@ -452,7 +472,7 @@ func connectEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, []string{cr.chanID}, []string{cr.thingID}); err != nil {
+		if err := svc.Connect(ctx, cr.token, []string{cr.chanID}, []string{cr.thingID}, ""); err != nil {
 			return nil, err
 		}
 
@@ -468,7 +488,7 @@ func createConnectionsEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, cr.ChannelIDs, cr.ThingIDs); err != nil {
+		if err := svc.Connect(ctx, cr.token, cr.ChannelIDs, cr.ThingIDs, cr.Type); err != nil {
 			return nil, err
 		}
 
@@ -492,6 +512,70 @@ func disconnectEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func shareThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareThing(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return shareRes{}, nil
+	}
+}
+
+func unshareThingEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnshareThing(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
+
+func shareChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ShareChannel(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return shareRes{}, nil
+	}
+}
+
+func unshareChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(shareReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UnshareChannel(ctx, req.token, req.id, req.Actions, req.Subjects); err != nil {
+			return nil, err
+		}
+
+		return unshareRes{}, nil
+	}
+}
+
 func listMembersEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listThingsGroupReq)
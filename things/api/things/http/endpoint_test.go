@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -16,6 +17,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/auth"
+	authgrpc "github.com/mainflux/mainflux/auth/api/grpc"
+	"github.com/mainflux/mainflux/auth/jwt"
+	authmocks "github.com/mainflux/mainflux/auth/mocks"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/things"
 	httpapi "github.com/mainflux/mainflux/things/api/things/http"
@@ -23,6 +29,7 @@ import (
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -560,6 +567,106 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestRotateKey(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	data := `{"grace_period": 3600}`
+
+	cases := []struct {
+		desc        string
+		req         string
+		id          string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "rotate key of an existing thing",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "rotate key without grace period",
+			req:         "{}",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "rotate key with too long grace period",
+			req:         `{"grace_period": 604801}`,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "rotate key with negative grace period",
+			req:         `{"grace_period": -1}`,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "rotate key of non-existent thing",
+			req:         data,
+			id:          strconv.FormatUint(wrongID, 10),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "rotate key with invalid user token",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "rotate key with invalid data format",
+			req:         "{",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "rotate key without content type",
+			req:         data,
+			id:          th.ID,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/key", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -637,6 +744,85 @@ func TestViewThing(t *testing.T) {
 	}
 }
 
+func TestScopedAPIKey(t *testing.T) {
+	authSvc := auth.New(authmocks.NewKeyRepository(), authmocks.NewGroupRepository(), authmocks.NewPolicyRepository(), uuid.NewMock(), jwt.New("secret"))
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	server := grpc.NewServer()
+	mainflux.RegisterAuthServiceServer(server, authgrpc.NewServer(mocktracer.New(), authSvc))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer conn.Close()
+
+	conns := make(chan mocks.Connection)
+	thingsRepo := mocks.NewThingRepository(conns)
+	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	svc := things.New(authgrpc.NewClient(mocktracer.New(), conn, time.Second), thingsRepo, channelsRepo, mocks.NewChannelCache(), mocks.NewThingCache(), uuid.NewMock())
+	ts := newServer(svc)
+	defer ts.Close()
+
+	_, loginToken, err := authSvc.Issue(context.Background(), "", auth.Key{Type: auth.UserKey, IssuedAt: time.Now(), IssuerID: "userID", Subject: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ths, err := svc.CreateThings(context.Background(), loginToken, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th, other := ths[0], ths[1]
+
+	scope := auth.Scope{Actions: []string{auth.ReadAction}, Objects: []string{th.ID}}
+	_, scopedToken, err := authSvc.Issue(context.Background(), loginToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scope: scope})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		method string
+		url    string
+		body   string
+		status int
+	}{
+		{
+			desc:   "view thing in the key scope",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s", ts.URL, th.ID),
+			status: http.StatusOK,
+		},
+		{
+			desc:   "update thing in the key scope",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("%s/things/%s", ts.URL, th.ID),
+			body:   toJSON(thing),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view thing out of the key scope",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s", ts.URL, other.ID),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list things",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things", ts.URL),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         tc.url,
+			contentType: contentType,
+			token:       scopedToken,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestListThings(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
@@ -1051,7 +1237,7 @@ func TestListThingsByChannel(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, "")
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		data = append(data, thingRes{
@@ -1605,7 +1791,7 @@ func TestViewChannel(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
-	svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID})
+	svc.Connect(context.Background(), token, []string{sch.ID}, []string{th.ID}, "")
 
 	data := toJSON(channelRes{
 		ID:       sch.ID,
@@ -1692,7 +1878,7 @@ func TestListChannels(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, "")
 
 		channels = append(channels, channelRes{
 			ID:       ch.ID,
@@ -1902,7 +2088,7 @@ func TestListChannelsByThing(t *testing.T) {
 		chs, err := svc.CreateChannels(context.Background(), token, channel)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		ch := chs[0]
-		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+		err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, "")
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 		channels = append(channels, channelRes{
@@ -2421,7 +2607,7 @@ func TestDisconnnect(t *testing.T) {
 	th1 := ths[0]
 	chs, _ := svc.CreateChannels(context.Background(), token, channel)
 	ch1 := chs[0]
-	svc.Connect(context.Background(), token, []string{ch1.ID}, []string{th1.ID})
+	svc.Connect(context.Background(), token, []string{ch1.ID}, []string{th1.ID}, "")
 	chs, _ = svc.CreateChannels(context.Background(), otherToken, channel)
 	ch2 := chs[0]
 
@@ -2510,6 +2696,135 @@ func TestDisconnnect(t *testing.T) {
 	}
 }
 
+func TestShareThing(t *testing.T) {
+	otherToken := "other_token"
+	otherEmail := "other_user@example.com"
+	svc := newService(map[string]string{
+		token:      email,
+		otherToken: otherEmail,
+	})
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	share := toJSON(struct {
+		Actions  []string `json:"actions"`
+		Subjects []string `json:"subjects"`
+	}{
+		[]string{"read"},
+		[]string{otherEmail},
+	})
+
+	cases := []struct {
+		desc        string
+		method      string
+		id          string
+		auth        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			desc:        "share thing",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        token,
+			contentType: contentType,
+			body:        share,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "share thing with invalid token",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        wrongValue,
+			contentType: contentType,
+			body:        share,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "share thing by non-owner",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        otherToken,
+			contentType: contentType,
+			body:        share,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "share thing without subjects",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        token,
+			contentType: contentType,
+			body:        `{"actions":["read"]}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share thing with invalid action",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        token,
+			contentType: contentType,
+			body:        fmt.Sprintf(`{"actions":["invalid"],"subjects":["%s"]}`, otherEmail),
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share thing with invalid request format",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        token,
+			contentType: contentType,
+			body:        "}",
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "share thing without content type",
+			method:      http.MethodPost,
+			id:          th.ID,
+			auth:        token,
+			contentType: "",
+			body:        share,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "unshare thing",
+			method:      http.MethodDelete,
+			id:          th.ID,
+			auth:        token,
+			contentType: contentType,
+			body:        share,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "unshare non-existent thing",
+			method:      http.MethodDelete,
+			id:          strconv.FormatUint(wrongID, 10),
+			auth:        token,
+			contentType: contentType,
+			body:        share,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         fmt.Sprintf("%s/things/%s/share", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 type thingRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
@@ -15,6 +15,10 @@ const (
 	idOrder      = "id"
 	ascDir       = "asc"
 	descDir      = "desc"
+
+	// maxGracePeriod is the longest period, in seconds, during which the
+	// previous thing key remains valid after the rotation (one week).
+	maxGracePeriod = 7 * 24 * 60 * 60
 )
 
 type createThingReq struct {
@@ -100,6 +104,24 @@ func (req updateKeyReq) validate() error {
 	return nil
 }
 
+type rotateKeyReq struct {
+	token       string
+	id          string
+	GracePeriod uint64 `json:"grace_period"`
+}
+
+func (req rotateKeyReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || req.GracePeriod > maxGracePeriod {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type createChannelReq struct {
 	token    string
 	Name     string                 `json:"name,omitempty"`
@@ -270,6 +292,7 @@ type createConnectionsReq struct {
 	token      string
 	ChannelIDs []string `json:"channel_ids,omitempty"`
 	ThingIDs   []string `json:"thing_ids,omitempty"`
+	Type       string   `json:"type,omitempty"`
 }
 
 func (req createConnectionsReq) validate() error {
@@ -295,6 +318,25 @@ func (req createConnectionsReq) validate() error {
 	return nil
 }
 
+type shareReq struct {
+	token    string
+	id       string
+	Actions  []string `json:"actions,omitempty"`
+	Subjects []string `json:"subjects,omitempty"`
+}
+
+func (req shareReq) validate() error {
+	if req.token == "" {
+		return things.ErrUnauthorizedAccess
+	}
+
+	if req.id == "" || len(req.Actions) == 0 || len(req.Subjects) == 0 {
+		return things.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type listThingsGroupReq struct {
 	token        string
 	groupID      string
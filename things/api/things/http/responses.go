@@ -20,6 +20,8 @@ var (
 	_ mainflux.Response = (*channelsPageRes)(nil)
 	_ mainflux.Response = (*connectionRes)(nil)
 	_ mainflux.Response = (*disconnectionRes)(nil)
+	_ mainflux.Response = (*shareRes)(nil)
+	_ mainflux.Response = (*unshareRes)(nil)
 )
 
 type removeRes struct{}
@@ -67,6 +69,23 @@ func (res thingRes) Empty() bool {
 	return true
 }
 
+type rotateKeyRes struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+func (res rotateKeyRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rotateKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rotateKeyRes) Empty() bool {
+	return false
+}
+
 type thingsRes struct {
 	Things  []thingRes `json:"things"`
 	created bool
@@ -257,6 +276,34 @@ func (res disconnectionRes) Empty() bool {
 	return true
 }
 
+type shareRes struct{}
+
+func (res shareRes) Code() int {
+	return http.StatusOK
+}
+
+func (res shareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res shareRes) Empty() bool {
+	return true
+}
+
+type unshareRes struct{}
+
+func (res unshareRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unshareRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unshareRes) Empty() bool {
+	return true
+}
+
 type pageRes struct {
 	Total  uint64 `json:"total"`
 	Offset uint64 `json:"offset"`
@@ -64,6 +64,13 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/things/:id/key", kithttp.NewServer(
+		kitot.TraceServer(tracer, "rotate_key")(rotateKeyEndpoint(svc)),
+		decodeKeyRotation,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Put("/things/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_thing")(updateThingEndpoint(svc)),
 		decodeThingUpdate,
@@ -176,6 +183,34 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service) http.Handler {
 		opts...,
 	))
 
+	r.Post("/things/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_thing")(shareThingEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/things/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_thing")(unshareThingEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/channels/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "share_channel")(shareChannelEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/channels/:id/share", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unshare_channel")(unshareChannelEndpoint(svc)),
+		decodeShare,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/groups/:groupId", kithttp.NewServer(
 		kitot.TraceServer(tracer, "list_members")(listMembersEndpoint(svc)),
 		decodeListMembersRequest,
@@ -247,6 +282,22 @@ func decodeKeyUpdate(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeKeyRotation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := rotateKeyReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeChannelCreation(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errors.ErrUnsupportedContentType
@@ -418,6 +469,22 @@ func decodeCreateConnections(_ context.Context, r *http.Request) (interface{}, e
 	return req, nil
 }
 
+func decodeShare(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	req := shareReq{
+		token: r.Header.Get("Authorization"),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(things.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeListMembersRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := httputil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -495,6 +562,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			errors.Contains(errorVal, things.ErrRemoveEntity),
 			errors.Contains(errorVal, things.ErrConnect),
 			errors.Contains(errorVal, things.ErrDisconnect),
+			errors.Contains(errorVal, things.ErrShareEntity),
+			errors.Contains(errorVal, things.ErrUnshareEntity),
 			errors.Contains(errorVal, auth.ErrCreateGroup):
 			w.WriteHeader(http.StatusBadRequest)
 
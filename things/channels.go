@@ -5,8 +5,39 @@ package things
 
 import (
 	"context"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
 )
 
+// Connection types determine the direction in which messages may flow
+// between the connected thing and the channel.
+const (
+	// ConnPublish allows the thing only to publish messages to the channel.
+	ConnPublish = messaging.PublishAction
+
+	// ConnSubscribe allows the thing only to receive messages from the channel.
+	ConnSubscribe = messaging.SubscribeAction
+
+	// ConnPubSub allows the thing both to publish and receive messages.
+	ConnPubSub = "pubsub"
+)
+
+// ConnTypes returns types of the connections that allow the action, which is
+// either ConnPublish or ConnSubscribe. Empty action is allowed by any
+// connection. Nil is returned for unknown actions.
+func ConnTypes(action string) []string {
+	switch action {
+	case "":
+		return []string{ConnPublish, ConnSubscribe, ConnPubSub}
+	case ConnPublish:
+		return []string{ConnPublish, ConnPubSub}
+	case ConnSubscribe:
+		return []string{ConnSubscribe, ConnPubSub}
+	default:
+		return nil
+	}
+}
+
 // Channel represents a Mainflux "communication group". This group contains the
 // things that can exchange messages between eachother.
 type Channel struct {
@@ -38,8 +69,9 @@ type ChannelRepository interface {
 	// by the specified user.
 	RetrieveByID(ctx context.Context, owner, id string) (Channel, error)
 
-	// RetrieveAll retrieves the subset of channels owned by the specified user.
-	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (ChannelsPage, error)
+	// RetrieveAll retrieves the subset of channels owned by the specified user
+	// or identified by the provided shared channel IDs.
+	RetrieveAll(ctx context.Context, owner string, shared []string, pm PageMetadata) (ChannelsPage, error)
 
 	// RetrieveByThing retrieves the subset of channels owned by the specified
 	// user and have specified thing connected or not connected to them.
@@ -49,31 +81,34 @@ type ChannelRepository interface {
 	// by the specified user.
 	Remove(ctx context.Context, owner, id string) error
 
-	// Connect adds things to the channel's list of connected things.
-	Connect(ctx context.Context, owner string, chIDs, thIDs []string) error
+	// Connect adds things to the channel's list of connected things using
+	// the connections of the specified type. Channels and things must be
+	// owned by the specified user or be listed among the shared IDs.
+	Connect(ctx context.Context, owner string, shared, chIDs, thIDs []string, connType string) error
 
 	// Disconnect removes thing from the channel's list of connected
 	// things.
 	Disconnect(ctx context.Context, owner, chanID, thingID string) error
 
 	// HasThing determines whether the thing with the provided access key, is
-	// "connected" to the specified channel. If that's the case, it returns
-	// thing's ID.
-	HasThing(ctx context.Context, chanID, key string) (string, error)
+	// "connected" to the specified channel by the connection that allows the
+	// action. If that's the case, it returns thing's ID.
+	HasThing(ctx context.Context, chanID, key, action string) (string, error)
 
 	// HasThingByID determines whether the thing with the provided ID, is
-	// "connected" to the specified channel. If that's the case, then
-	// returned error will be nil.
-	HasThingByID(ctx context.Context, chanID, thingID string) error
+	// "connected" to the specified channel by the connection that allows the
+	// action. If that's the case, then returned error will be nil.
+	HasThingByID(ctx context.Context, chanID, thingID, action string) error
 }
 
 // ChannelCache contains channel-thing connection caching interface.
 type ChannelCache interface {
-	// Connect channel thing connection.
-	Connect(context.Context, string, string) error
+	// Connect caches channel thing connection of the given type.
+	Connect(ctx context.Context, chanID, thingID, connType string) error
 
-	// HasThing checks if thing is connected to channel.
-	HasThing(context.Context, string, string) bool
+	// HasThing checks if thing is connected to channel by the connection
+	// that allows the action.
+	HasThing(ctx context.Context, chanID, thingID, action string) bool
 
 	// Disconnects thing from channel.
 	Disconnect(context.Context, string, string) error
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
@@ -15,22 +16,35 @@ import (
 var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
 type authServiceMock struct {
-	users map[string]string
+	mu       sync.Mutex
+	users    map[string]string
+	policies map[string]map[string]map[string]bool
 }
 
 // NewAuthService creates mock of users service.
 func NewAuthService(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceMock{users}
+	return &authServiceMock{
+		users:    users,
+		policies: make(map[string]map[string]map[string]bool),
+	}
 }
 
-func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+func (svc *authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: id, Email: id}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+func (svc *authServiceMock) CanAccess(ctx context.Context, req *mainflux.AccessReq, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return svc.Identify(ctx, &mainflux.Token{Value: req.GetToken()})
+}
+
+func (svc *authServiceMock) IdentifyPreAuth(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
 		default:
@@ -40,14 +54,162 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+func (svc *authServiceMock) Login(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceMock) Logout(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
 	panic("not implemented")
 }
 
-func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
+func (svc *authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	return &mainflux.AuthorizeRes{Authorized: svc.allowed(sub, req.GetObj(), req.GetAct())}, nil
+}
+
+func (svc *authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
 	panic("not implemented")
 }
 
-func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+func (svc *authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc *authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if err := svc.canManage(req); err != nil {
+		return nil, err
+	}
+
+	svc.add(req.GetSub(), req.GetObj(), req.GetAct())
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if err := svc.canManage(req); err != nil {
+		return nil, err
+	}
+
+	delete(svc.policies[req.GetSub()][req.GetObj()], req.GetAct())
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if svc.claimable(req.GetObj()) {
+		return &empty.Empty{}, nil
+	}
+	if !svc.allowed(sub, req.GetObj(), "admin") {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	for _, objs := range svc.policies {
+		delete(objs, req.GetObj())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if svc.allowed(sub, req.GetObj(), "admin") {
+		return &empty.Empty{}, nil
+	}
+	if !svc.claimable(req.GetObj()) {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.add(sub, req.GetObj(), "admin")
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (r *mainflux.ObjectsRes, err error) {
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	var objects []string
+	for obj := range svc.policies[sub] {
+		if svc.allowed(sub, obj, req.GetAct()) {
+			objects = append(objects, obj)
+		}
+	}
+	return &mainflux.ObjectsRes{Objects: objects}, nil
+}
+
+func (svc *authServiceMock) allowed(sub, obj, act string) bool {
+	acts := svc.policies[sub][obj]
+	switch act {
+	case "read":
+		return acts["read"] || acts["write"] || acts["admin"]
+	case "write":
+		return acts["write"] || acts["admin"]
+	default:
+		return acts[act] || acts["admin"]
+	}
+}
+
+func (svc *authServiceMock) add(sub, obj, act string) {
+	if _, ok := svc.policies[sub]; !ok {
+		svc.policies[sub] = make(map[string]map[string]bool)
+	}
+	if _, ok := svc.policies[sub][obj]; !ok {
+		svc.policies[sub][obj] = make(map[string]bool)
+	}
+	svc.policies[sub][obj][act] = true
+}
+
+// canManage checks if the token owner administers the policy object.
+func (svc *authServiceMock) canManage(req *mainflux.PolicyReq) error {
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return users.ErrUnauthorizedAccess
+	}
+	if !svc.allowed(sub, req.GetObj(), "admin") {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+// claimable checks if the object is not covered by any policy.
+func (svc *authServiceMock) claimable(obj string) bool {
+	for _, objs := range svc.policies {
+		for _, allowed := range objs[obj] {
+			if allowed {
+				return false
+			}
+		}
+	}
+	return true
+}
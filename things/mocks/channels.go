@@ -29,6 +29,7 @@ type channelRepositoryMock struct {
 	channels map[string]things.Channel
 	tconns   chan Connection                      // used for syncronization with thing repo
 	cconns   map[string]map[string]things.Channel // used to track connections
+	ctypes   map[string]string                    // used to track connection types
 	things   things.ThingRepository
 }
 
@@ -38,6 +39,7 @@ func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) t
 		channels: make(map[string]things.Channel),
 		tconns:   tconns,
 		cconns:   make(map[string]map[string]things.Channel),
+		ctypes:   make(map[string]string),
 		things:   repo,
 	}
 }
@@ -77,7 +79,7 @@ func (crm *channelRepositoryMock) RetrieveByID(_ context.Context, owner, id stri
 	return things.Channel{}, things.ErrNotFound
 }
 
-func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, shared []string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	if pm.Limit < 0 {
 		return things.ChannelsPage{}, nil
 	}
@@ -94,7 +96,7 @@ func (crm *channelRepositoryMock) RetrieveAll(_ context.Context, owner string, p
 	// itself (see mocks/commons.go).
 	prefix := fmt.Sprintf("%s-", owner)
 	for k, v := range crm.channels {
-		if strings.HasPrefix(k, prefix) {
+		if strings.HasPrefix(k, prefix) || contains(shared, v.ID) {
 			chs = append(chs, v)
 		}
 	}
@@ -188,15 +190,28 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner, id string) er
 	return nil
 }
 
-func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs, thIDs []string) error {
+func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, shared, chIDs, thIDs []string, connType string) error {
 	for _, chID := range chIDs {
 		ch, err := crm.RetrieveByID(context.Background(), owner, chID)
+		if err != nil && contains(shared, chID) {
+			ch, err = crm.retrieveShared(chID)
+		}
 		if err != nil {
 			return err
 		}
 
 		for _, thID := range thIDs {
 			th, err := crm.things.RetrieveByID(context.Background(), owner, thID)
+			if err != nil && contains(shared, thID) {
+				var page things.Page
+				page, err = crm.things.RetrieveByIDs(context.Background(), []string{thID}, things.PageMetadata{Limit: 1})
+				if err == nil && len(page.Things) == 0 {
+					err = things.ErrNotFound
+				}
+				if err == nil {
+					th = page.Things[0]
+				}
+			}
 			if err != nil {
 				return err
 			}
@@ -210,12 +225,26 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner string, chIDs
 				crm.cconns[thID] = make(map[string]things.Channel)
 			}
 			crm.cconns[thID][chID] = ch
+			crm.ctypes[key(chID, thID)] = connType
 		}
 	}
 
 	return nil
 }
 
+// retrieveShared retrieves the channel regardless of its owner.
+func (crm *channelRepositoryMock) retrieveShared(id string) (things.Channel, error) {
+	// This obscure way to examine map keys is enforced by the key structure
+	// itself (see mocks/commons.go).
+	suffix := fmt.Sprintf("-%s", id)
+	for k, v := range crm.channels {
+		if strings.HasSuffix(k, suffix) {
+			return v, nil
+		}
+	}
+	return things.Channel{}, things.ErrNotFound
+}
+
 func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chanID, thingID string) error {
 	if _, ok := crm.cconns[thingID]; !ok {
 		return things.ErrNotFound
@@ -234,11 +263,12 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chanID, t
 	return nil
 }
 
-func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token string) (string, error) {
-	tid, err := crm.things.RetrieveByKey(context.Background(), token)
+func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token, action string) (string, error) {
+	th, err := crm.things.RetrieveByKey(context.Background(), token)
 	if err != nil {
 		return "", err
 	}
+	tid := th.ID
 
 	chans, ok := crm.cconns[tid]
 	if !ok {
@@ -249,10 +279,14 @@ func (crm *channelRepositoryMock) HasThing(_ context.Context, chanID, token stri
 		return "", things.ErrEntityConnected
 	}
 
+	if !contains(things.ConnTypes(action), crm.ctypes[key(chanID, tid)]) {
+		return "", things.ErrEntityConnected
+	}
+
 	return tid, nil
 }
 
-func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID string) error {
+func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID, action string) error {
 	chans, ok := crm.cconns[thingID]
 	if !ok {
 		return things.ErrEntityConnected
@@ -262,34 +296,41 @@ func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingI
 		return things.ErrEntityConnected
 	}
 
+	if !contains(things.ConnTypes(action), crm.ctypes[key(chanID, thingID)]) {
+		return things.ErrEntityConnected
+	}
+
 	return nil
 }
 
 type channelCacheMock struct {
 	mu       sync.Mutex
 	channels map[string]string
+	types    map[string]string
 }
 
 // NewChannelCache returns mock cache instance.
 func NewChannelCache() things.ChannelCache {
 	return &channelCacheMock{
 		channels: make(map[string]string),
+		types:    make(map[string]string),
 	}
 }
 
-func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string) error {
+func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID, connType string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
 	ccm.channels[chanID] = thingID
+	ccm.types[chanID] = connType
 	return nil
 }
 
-func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string) bool {
+func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID, action string) bool {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	return ccm.channels[chanID] == thingID
+	return ccm.channels[chanID] == thingID && contains(things.ConnTypes(action), ccm.types[chanID])
 }
 
 func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID string) error {
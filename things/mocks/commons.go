@@ -18,6 +18,15 @@ func key(owner string, id string) string {
 	return fmt.Sprintf("%s-%s", owner, id)
 }
 
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
 func sortThings(pm things.PageMetadata, ths []things.Thing) []things.Thing {
 	switch pm.Order {
 	case "name":
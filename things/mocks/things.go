@@ -9,26 +9,34 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mainflux/mainflux/things"
 )
 
 var _ things.ThingRepository = (*thingRepositoryMock)(nil)
 
+type prevKey struct {
+	key       string
+	expiresAt time.Time
+}
+
 type thingRepositoryMock struct {
-	mu      sync.Mutex
-	counter uint64
-	conns   chan Connection
-	tconns  map[string]map[string]things.Thing
-	things  map[string]things.Thing
+	mu       sync.Mutex
+	counter  uint64
+	conns    chan Connection
+	tconns   map[string]map[string]things.Thing
+	things   map[string]things.Thing
+	prevKeys map[string]prevKey
 }
 
 // NewThingRepository creates in-memory thing repository.
 func NewThingRepository(conns chan Connection) things.ThingRepository {
 	repo := &thingRepositoryMock{
-		conns:  conns,
-		things: make(map[string]things.Thing),
-		tconns: make(map[string]map[string]things.Thing),
+		conns:    conns,
+		things:   make(map[string]things.Thing),
+		tconns:   make(map[string]map[string]things.Thing),
+		prevKeys: make(map[string]prevKey),
 	}
 	go func(conns chan Connection, repo *thingRepositoryMock) {
 		for conn := range conns {
@@ -94,6 +102,31 @@ func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val stri
 		return things.ErrNotFound
 	}
 
+	th.Key = val
+	trm.things[dbKey] = th
+	delete(trm.prevKeys, id)
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) RotateKey(_ context.Context, owner, id, val string, expiresAt time.Time) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, th := range trm.things {
+		if th.Key == val {
+			return things.ErrConflict
+		}
+	}
+
+	dbKey := key(owner, id)
+
+	th, ok := trm.things[dbKey]
+	if !ok {
+		return things.ErrNotFound
+	}
+
+	trm.prevKeys[id] = prevKey{key: th.Key, expiresAt: expiresAt}
 	th.Key = val
 	trm.things[dbKey] = th
 
@@ -111,7 +144,7 @@ func (trm *thingRepositoryMock) RetrieveByID(_ context.Context, owner, id string
 	return things.Thing{}, things.ErrNotFound
 }
 
-func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, shared []string, pm things.PageMetadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
@@ -129,7 +162,7 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context, owner string, pm
 	prefix := fmt.Sprintf("%s-", owner)
 	for k, v := range trm.things {
 		id, _ := strconv.ParseUint(v.ID, 10, 64)
-		if strings.HasPrefix(k, prefix) && id >= first && id < last {
+		if (strings.HasPrefix(k, prefix) || contains(shared, v.ID)) && id >= first && id < last {
 			ths = append(ths, v)
 		}
 	}
@@ -159,16 +192,12 @@ func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []stri
 		return things.Page{}, nil
 	}
 
-	first := uint64(pm.Offset) + 1
-	last := first + uint64(pm.Limit)
-
 	// This obscure way to examine map keys is enforced by the key structure
 	// itself (see mocks/commons.go).
 	for _, id := range thingIDs {
 		suffix := fmt.Sprintf("-%s", id)
 		for k, v := range trm.things {
-			id, _ := strconv.ParseUint(v.ID, 10, 64)
-			if strings.HasSuffix(k, suffix) && id >= first && id < last {
+			if strings.HasSuffix(k, suffix) {
 				items = append(items, v)
 			}
 		}
@@ -176,6 +205,16 @@ func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []stri
 
 	items = sortThings(pm, items)
 
+	first := pm.Offset
+	last := first + pm.Limit
+	if last > uint64(len(items)) {
+		last = uint64(len(items))
+	}
+	if first > last {
+		first = last
+	}
+	items = items[first:last]
+
 	page := things.Page{
 		Things: items,
 		PageMetadata: things.PageMetadata{
@@ -248,20 +287,32 @@ func (trm *thingRepositoryMock) Remove(_ context.Context, owner, id string) erro
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 	delete(trm.things, key(owner, id))
+	delete(trm.prevKeys, id)
 	return nil
 }
 
-func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (string, error) {
+func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (things.Thing, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
 
 	for _, thing := range trm.things {
 		if thing.Key == key {
-			return thing.ID, nil
+			return thing, nil
 		}
 	}
 
-	return "", things.ErrNotFound
+	for id, pk := range trm.prevKeys {
+		if pk.key != key || !time.Now().Before(pk.expiresAt) {
+			continue
+		}
+		for _, thing := range trm.things {
+			if thing.ID == id {
+				return thing, nil
+			}
+		}
+	}
+
+	return things.Thing{}, things.ErrNotFound
 }
 
 func (trm *thingRepositoryMock) connect(conn Connection) {
@@ -286,14 +337,16 @@ func (trm *thingRepositoryMock) disconnect(conn Connection) {
 }
 
 type thingCacheMock struct {
-	mu     sync.Mutex
-	things map[string]string
+	mu      sync.Mutex
+	things  map[string]string
+	expires map[string]time.Time
 }
 
 // NewThingCache returns mock cache instance.
 func NewThingCache() things.ThingCache {
 	return &thingCacheMock{
-		things: make(map[string]string),
+		things:  make(map[string]string),
+		expires: make(map[string]time.Time),
 	}
 }
 
@@ -302,6 +355,7 @@ func (tcm *thingCacheMock) Save(_ context.Context, key, id string) error {
 	defer tcm.mu.Unlock()
 
 	tcm.things[key] = id
+	delete(tcm.expires, key)
 	return nil
 }
 
@@ -314,9 +368,29 @@ func (tcm *thingCacheMock) ID(_ context.Context, key string) (string, error) {
 		return "", things.ErrNotFound
 	}
 
+	if exp, ok := tcm.expires[key]; ok && time.Now().After(exp) {
+		delete(tcm.things, key)
+		delete(tcm.expires, key)
+		return "", things.ErrNotFound
+	}
+
 	return id, nil
 }
 
+func (tcm *thingCacheMock) Rotate(_ context.Context, id, prevKey, key string, grace time.Duration) error {
+	tcm.mu.Lock()
+	defer tcm.mu.Unlock()
+
+	delete(tcm.things, prevKey)
+	delete(tcm.expires, prevKey)
+	if grace > 0 {
+		tcm.things[prevKey] = id
+		tcm.expires[prevKey] = time.Now().Add(grace)
+	}
+	tcm.things[key] = id
+	return nil
+}
+
 func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
 	tcm.mu.Lock()
 	defer tcm.mu.Unlock()
@@ -324,7 +398,7 @@ func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
 	for key, val := range tcm.things {
 		if val == id {
 			delete(tcm.things, key)
-			return nil
+			delete(tcm.expires, key)
 		}
 	}
 
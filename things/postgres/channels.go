@@ -120,7 +120,8 @@ func (cr channelRepository) RetrieveByID(ctx context.Context, owner, id string)
 	return toChannel(dbch), nil
 }
 
-func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	owq, sh := getOwnerQuery(shared)
 	nq, name := getNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
@@ -129,11 +130,12 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm th
 		return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels
-	      WHERE owner = :owner %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, oq, dq)
+	q := fmt.Sprintf(`SELECT id, owner, name, metadata FROM channels
+	      WHERE %s %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, owq, mq, nq, oq, dq)
 
 	params := map[string]interface{}{
 		"owner":    owner,
+		"shared":   sh,
 		"limit":    pm.Limit,
 		"offset":   pm.Offset,
 		"name":     name,
@@ -147,7 +149,7 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm th
 
 	items := []things.Channel{}
 	for rows.Next() {
-		dbch := dbChannel{Owner: owner}
+		dbch := dbChannel{}
 		if err := rows.StructScan(&dbch); err != nil {
 			return things.ChannelsPage{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -156,7 +158,7 @@ func (cr channelRepository) RetrieveAll(ctx context.Context, owner string, pm th
 		items = append(items, ch)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE owner = :owner %s%s;`, nq, mq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM channels WHERE %s %s%s;`, owq, nq, mq)
 
 	total, err := total(ctx, cr.db, cq, params)
 	if err != nil {
@@ -272,24 +274,42 @@ func (cr channelRepository) Remove(ctx context.Context, owner, id string) error
 	return nil
 }
 
-func (cr channelRepository) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (cr channelRepository) Connect(ctx context.Context, owner string, shared, chIDs, thIDs []string, connType string) error {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(things.ErrConnect, err)
 	}
 
-	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner)
-	      VALUES (:channel, :owner, :thing, :owner);`
+	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, type)
+	      VALUES (:channel, :owner, :thing, :owner, :type);`
+
+	owq, sh := getOwnerQuery(shared)
+	if len(shared) > 0 {
+		// Shared channels and things keep their owners in the connection.
+		q = fmt.Sprintf(`INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, type)
+		      SELECT ch.id, ch.owner, th.id, th.owner, :type
+		      FROM (SELECT id, owner FROM channels WHERE id = :channel AND %s) ch,
+		           (SELECT id, owner FROM things WHERE id = :thing AND %s) th;`, owq, owq)
+	}
 
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
-			dbco := dbConnection{
-				Channel: chID,
-				Thing:   thID,
-				Owner:   owner,
+			params := map[string]interface{}{
+				"channel": chID,
+				"thing":   thID,
+				"owner":   owner,
+				"shared":  sh,
+				"type":    connType,
 			}
 
-			_, err := tx.NamedExecContext(ctx, q, dbco)
+			res, err := tx.NamedExecContext(ctx, q, params)
+			if err == nil {
+				// Nothing is inserted if channel or thing is not accessible.
+				if cnt, _ := res.RowsAffected(); cnt == 0 {
+					tx.Rollback()
+					return things.ErrNotFound
+				}
+			}
 			if err != nil {
 				tx.Rollback()
 				pqErr, ok := err.(*pq.Error)
@@ -342,28 +362,28 @@ func (cr channelRepository) Disconnect(ctx context.Context, owner, chanID, thing
 	return nil
 }
 
-func (cr channelRepository) HasThing(ctx context.Context, chanID, thingKey string) (string, error) {
+func (cr channelRepository) HasThing(ctx context.Context, chanID, thingKey, action string) (string, error) {
 	var thingID string
-	q := `SELECT id FROM things WHERE key = $1`
+	q := fmt.Sprintf(`SELECT id FROM things WHERE %s ORDER BY key = $1 DESC LIMIT 1`, keyQuery)
 	if err := cr.db.QueryRowxContext(ctx, q, thingKey).Scan(&thingID); err != nil {
 		return "", errors.Wrap(things.ErrEntityConnected, err)
 	}
 
-	if err := cr.hasThing(ctx, chanID, thingID); err != nil {
+	if err := cr.hasThing(ctx, chanID, thingID, action); err != nil {
 		return "", err
 	}
 
 	return thingID, nil
 }
 
-func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID string) error {
-	return cr.hasThing(ctx, chanID, thingID)
+func (cr channelRepository) HasThingByID(ctx context.Context, chanID, thingID, action string) error {
+	return cr.hasThing(ctx, chanID, thingID, action)
 }
 
-func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID string) error {
-	q := `SELECT EXISTS (SELECT 1 FROM connections WHERE channel_id = $1 AND thing_id = $2);`
+func (cr channelRepository) hasThing(ctx context.Context, chanID, thingID, action string) error {
+	q := `SELECT EXISTS (SELECT 1 FROM connections WHERE channel_id = $1 AND thing_id = $2 AND type = ANY($3));`
 	exists := false
-	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).Scan(&exists); err != nil {
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID, pq.Array(things.ConnTypes(action))).Scan(&exists); err != nil {
 		return errors.Wrap(things.ErrEntityConnected, err)
 	}
 
@@ -446,6 +466,22 @@ func getNameQuery(name string) (string, string) {
 	return nq, name
 }
 
+// getOwnerQuery returns condition that matches entities owned by the user
+// or identified by one of the shared IDs. Shared IDs that are not valid
+// UUIDs are ignored, since they can't identify a thing or a channel.
+func getOwnerQuery(shared []string) (string, interface{}) {
+	var ids []string
+	for _, id := range shared {
+		if _, err := uuid.FromString(id); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return "owner = :owner", pq.Array(ids)
+	}
+	return "(owner = :owner OR id = ANY(CAST(:shared AS UUID[])))", pq.Array(ids)
+}
+
 func getOrderQuery(order string) string {
 	switch order {
 	case "name":
@@ -166,7 +166,7 @@ func TestSingleChannelRetrieval(t *testing.T) {
 	}
 	chs, _ := chanRepo.Save(context.Background(), ch)
 	ch.ID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{ch.ID}, []string{th.ID})
+	chanRepo.Connect(context.Background(), email, nil, []string{ch.ID}, []string{th.ID}, things.ConnPubSub)
 
 	nonexistentChanID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -356,7 +356,7 @@ func TestMultiChannelRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := chanRepo.RetrieveAll(context.Background(), tc.owner, tc.pageMetadata)
+		page, err := chanRepo.RetrieveAll(context.Background(), tc.owner, nil, tc.pageMetadata)
 		size := uint64(len(page.Channels))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.pageMetadata.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.pageMetadata.Total, page.Total))
@@ -401,7 +401,7 @@ func TestRetrieveByThing(t *testing.T) {
 			break
 		}
 
-		err = chanRepo.Connect(context.Background(), email, []string{cid}, []string{thID})
+		err = chanRepo.Connect(context.Background(), email, nil, []string{cid}, []string{thID}, things.ConnPubSub)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -637,7 +637,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Connect(context.Background(), tc.owner, []string{tc.chID}, []string{tc.thID})
+		err := chanRepo.Connect(context.Background(), tc.owner, nil, []string{tc.chID}, []string{tc.thID}, things.ConnPubSub)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -670,7 +670,7 @@ func TestDisconnect(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+	chanRepo.Connect(context.Background(), email, nil, []string{chID}, []string{thID}, things.ConnPubSub)
 
 	nonexistentThingID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -756,7 +756,7 @@ func TestHasThing(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+	chanRepo.Connect(context.Background(), email, nil, []string{chID}, []string{thID}, things.ConnPubSub)
 
 	nonexistentChanID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -784,7 +784,7 @@ func TestHasThing(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.HasThing(context.Background(), tc.chID, tc.key)
+		_, err := chanRepo.HasThing(context.Background(), tc.chID, tc.key, "")
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
@@ -831,7 +831,16 @@ func TestHasThingByID(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	chanRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+	chanRepo.Connect(context.Background(), email, nil, []string{chID}, []string{thID}, things.ConnPubSub)
+
+	pubThID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubThKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: pubThID, Owner: email, Key: pubThKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = chanRepo.Connect(context.Background(), email, nil, []string{chID}, []string{pubThID}, things.ConnPublish)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	nonexistentChanID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -839,6 +848,7 @@ func TestHasThingByID(t *testing.T) {
 	cases := map[string]struct {
 		chID      string
 		thID      string
+		action    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
@@ -846,6 +856,24 @@ func TestHasThingByID(t *testing.T) {
 			thID:      thID,
 			hasAccess: true,
 		},
+		"subscribe check for thing that has access": {
+			chID:      chID,
+			thID:      thID,
+			action:    things.ConnSubscribe,
+			hasAccess: true,
+		},
+		"publish check for publish-only thing": {
+			chID:      chID,
+			thID:      pubThID,
+			action:    things.ConnPublish,
+			hasAccess: true,
+		},
+		"subscribe check for publish-only thing": {
+			chID:      chID,
+			thID:      pubThID,
+			action:    things.ConnSubscribe,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			chID:      chID,
 			thID:      disconnectedThingID,
@@ -864,7 +892,7 @@ func TestHasThingByID(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		err := chanRepo.HasThingByID(context.Background(), tc.chID, tc.thID)
+		err := chanRepo.HasThingByID(context.Background(), tc.chID, tc.thID, tc.action)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
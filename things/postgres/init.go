@@ -99,6 +99,29 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS things ADD CONSTRAINT things_id_key UNIQUE (id)`,
 				},
 			},
+			{
+				Id: "things_5",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS
+					 type VARCHAR(16) NOT NULL DEFAULT 'pubsub'`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS connections DROP COLUMN IF EXISTS type`,
+				},
+			},
+			{
+				Id: "things_6",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS prev_key VARCHAR(4096)`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS prev_key_expires_at TIMESTAMP`,
+					`CREATE INDEX IF NOT EXISTS things_prev_key_idx ON things (prev_key)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS things_prev_key_idx`,
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS prev_key_expires_at`,
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS prev_key`,
+				},
+			},
 		},
 	}
 
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/lib/pq" // required for DB access
@@ -23,6 +24,10 @@ const (
 	errTruncation = "string_data_right_truncation"
 )
 
+// keyQuery matches things by either the current key or the previous key
+// which has not expired yet. The key is expected as the first parameter.
+const keyQuery = `(key = $1 OR (prev_key = $1 AND prev_key_expires_at > NOW() AT TIME ZONE 'UTC'))`
+
 var _ things.ThingRepository = (*thingRepository)(nil)
 
 type thingRepository struct {
@@ -109,7 +114,8 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 }
 
 func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string) error {
-	q := `UPDATE things SET key = :key WHERE owner = :owner AND id = :id;`
+	q := `UPDATE things SET key = :key, prev_key = NULL, prev_key_expires_at = NULL
+		  WHERE owner = :owner AND id = :id;`
 
 	dbth := dbThing{
 		ID:    id,
@@ -117,7 +123,25 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 		Key:   key,
 	}
 
-	res, err := tr.db.NamedExecContext(ctx, q, dbth)
+	return tr.updateKey(ctx, q, dbth)
+}
+
+func (tr thingRepository) RotateKey(ctx context.Context, owner, id, key string, expiresAt time.Time) error {
+	q := `UPDATE things SET prev_key = key, prev_key_expires_at = :expires_at, key = :key
+		  WHERE owner = :owner AND id = :id;`
+
+	params := map[string]interface{}{
+		"id":         id,
+		"owner":      owner,
+		"key":        key,
+		"expires_at": expiresAt.UTC(),
+	}
+
+	return tr.updateKey(ctx, q, params)
+}
+
+func (tr thingRepository) updateKey(ctx context.Context, q string, arg interface{}) error {
+	res, err := tr.db.NamedExecContext(ctx, q, arg)
 	if err != nil {
 		pqErr, ok := err.(*pq.Error)
 		if ok {
@@ -163,18 +187,18 @@ func (tr thingRepository) RetrieveByID(ctx context.Context, owner, id string) (t
 	return toThing(dbth)
 }
 
-func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (string, error) {
-	q := `SELECT id FROM things WHERE key = $1;`
+func (tr thingRepository) RetrieveByKey(ctx context.Context, key string) (things.Thing, error) {
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things WHERE %s ORDER BY key = $1 DESC LIMIT 1;`, keyQuery)
 
-	var id string
-	if err := tr.db.QueryRowxContext(ctx, q, key).Scan(&id); err != nil {
+	var dbth dbThing
+	if err := tr.db.QueryRowxContext(ctx, q, key).StructScan(&dbth); err != nil {
 		if err == sql.ErrNoRows {
-			return "", errors.Wrap(things.ErrNotFound, err)
+			return things.Thing{}, errors.Wrap(things.ErrNotFound, err)
 		}
-		return "", errors.Wrap(things.ErrSelectEntity, err)
+		return things.Thing{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	return id, nil
+	return toThing(dbth)
 }
 
 func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
@@ -244,7 +268,8 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 	return page, nil
 }
 
-func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.Page, error) {
+	owq, sh := getOwnerQuery(shared)
 	nq, name := getNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
@@ -253,10 +278,11 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 		return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things
-	      WHERE owner = :owner %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, mq, nq, oq, dq)
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things
+	      WHERE %s %s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, owq, mq, nq, oq, dq)
 	params := map[string]interface{}{
 		"owner":    owner,
+		"shared":   sh,
 		"limit":    pm.Limit,
 		"offset":   pm.Offset,
 		"name":     name,
@@ -271,7 +297,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 
 	var items []things.Thing
 	for rows.Next() {
-		dbth := dbThing{Owner: owner}
+		dbth := dbThing{}
 		if err := rows.StructScan(&dbth); err != nil {
 			return things.Page{}, errors.Wrap(things.ErrSelectEntity, err)
 		}
@@ -284,7 +310,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context, owner string, pm thin
 		items = append(items, th)
 	}
 
-	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE owner = :owner %s%s;`, nq, mq)
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM things WHERE %s %s%s;`, owq, nq, mq)
 
 	total, err := total(ctx, tr.db, cq, params)
 	if err != nil {
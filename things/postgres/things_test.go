@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 	"github.com/mainflux/mainflux/pkg/uuid"
@@ -366,12 +367,76 @@ func TestThingRetrieveByKey(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		id, err := thingRepo.RetrieveByKey(context.Background(), tc.key)
-		assert.Equal(t, tc.ID, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.ID, id))
+		th, err := thingRepo.RetrieveByKey(context.Background(), tc.key)
+		assert.Equal(t, tc.ID, th.ID, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.ID, th.ID))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
 
+func TestThingRotateKey(t *testing.T) {
+	email := "thing-rotate-key@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	var ths []things.Thing
+	for i := 0; i < 2; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		saved, err := thingRepo.Save(context.Background(), things.Thing{ID: id, Owner: email, Key: key})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		ths = append(ths, saved[0])
+	}
+	th, expTh := ths[0], ths[1]
+
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = thingRepo.RotateKey(context.Background(), email, th.ID, key, time.Now().Add(time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("rotate thing key: unexpected error: %s\n", err))
+
+	expKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = thingRepo.RotateKey(context.Background(), email, expTh.ID, expKey, time.Now().Add(-time.Second))
+	assert.Nil(t, err, fmt.Sprintf("rotate thing key: unexpected error: %s\n", err))
+
+	err = thingRepo.RotateKey(context.Background(), wrongValue, th.ID, wrongValue, time.Now())
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("rotate key of non-existent thing: expected %s got %s\n", things.ErrNotFound, err))
+
+	cases := map[string]struct {
+		key string
+		ID  string
+		err error
+	}{
+		"retrieve thing by rotated key": {
+			key: key,
+			ID:  th.ID,
+			err: nil,
+		},
+		"retrieve thing by previous key": {
+			key: th.Key,
+			ID:  th.ID,
+			err: nil,
+		},
+		"retrieve thing by expired previous key": {
+			key: expTh.Key,
+			ID:  "",
+			err: things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		th, err := thingRepo.RetrieveByKey(context.Background(), tc.key)
+		assert.Equal(t, tc.ID, th.ID, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.ID, th.ID))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	err = thingRepo.UpdateKey(context.Background(), email, th.ID, wrongValue+th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = thingRepo.RetrieveByKey(context.Background(), th.Key)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("retrieve thing by replaced key: expected %s got %s\n", things.ErrNotFound, err))
+}
+
 func TestMultiThingRetrieval(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	thingRepo := postgres.NewThingRepository(dbMiddleware)
@@ -397,6 +462,7 @@ func TestMultiThingRetrieval(t *testing.T) {
 	nameMetaNum := uint64(2)
 
 	n := uint64(10)
+	var ids []string
 	for i := uint64(0); i < n; i++ {
 		id, err := idProvider.ID()
 		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -424,10 +490,12 @@ func TestMultiThingRetrieval(t *testing.T) {
 
 		_, err = thingRepo.Save(context.Background(), th)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		ids = append(ids, id)
 	}
 
 	cases := map[string]struct {
 		owner        string
+		shared       []string
 		pageMetadata things.PageMetadata
 		size         uint64
 	}{
@@ -458,6 +526,36 @@ func TestMultiThingRetrieval(t *testing.T) {
 			},
 			size: 0,
 		},
+		"retrieve shared things with non-existing owner": {
+			owner:  wrongValue,
+			shared: ids[:2],
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  2,
+			},
+			size: 2,
+		},
+		"retrieve shared things with existing owner": {
+			owner:  email,
+			shared: ids[:2],
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+			},
+			size: n,
+		},
+		"retrieve shared things with invalid id": {
+			owner:  wrongValue,
+			shared: []string{wrongValue},
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  0,
+			},
+			size: 0,
+		},
 		"retrieve things with existing name": {
 			owner: email,
 			pageMetadata: things.PageMetadata{
@@ -544,7 +642,7 @@ func TestMultiThingRetrieval(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		page, err := thingRepo.RetrieveAll(context.Background(), tc.owner, tc.pageMetadata)
+		page, err := thingRepo.RetrieveAll(context.Background(), tc.owner, tc.shared, tc.pageMetadata)
 		size := uint64(len(page.Things))
 		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
 		assert.Equal(t, tc.pageMetadata.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.pageMetadata.Total, page.Total))
@@ -594,7 +692,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 			break
 		}
 
-		err = channelRepo.Connect(context.Background(), email, []string{chID}, []string{thID})
+		err = channelRepo.Connect(context.Background(), email, nil, []string{chID}, []string{thID}, things.ConnPubSub)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
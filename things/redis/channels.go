@@ -25,37 +25,53 @@ func NewChannelCache(client *redis.Client) things.ChannelCache {
 	return channelCache{client: client}
 }
 
-func (cc channelCache) Connect(ctx context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SAdd(ctx, cid, tid).Err(); err != nil {
-		return errors.Wrap(things.ErrConnect, err)
+func (cc channelCache) Connect(ctx context.Context, chanID, thingID, connType string) error {
+	for _, action := range actions(connType) {
+		if err := cc.client.SAdd(ctx, key(chanID, action), thingID).Err(); err != nil {
+			return errors.Wrap(things.ErrConnect, err)
+		}
 	}
 	return nil
 }
 
-func (cc channelCache) HasThing(ctx context.Context, chanID, thingID string) bool {
-	cid, tid := kv(chanID, thingID)
-	return cc.client.SIsMember(ctx, cid, tid).Val()
+func (cc channelCache) HasThing(ctx context.Context, chanID, thingID, action string) bool {
+	if action != "" {
+		return cc.client.SIsMember(ctx, key(chanID, action), thingID).Val()
+	}
+	for _, act := range actions(things.ConnPubSub) {
+		if cc.client.SIsMember(ctx, key(chanID, act), thingID).Val() {
+			return true
+		}
+	}
+	return false
 }
 
 func (cc channelCache) Disconnect(ctx context.Context, chanID, thingID string) error {
-	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SRem(ctx, cid, tid).Err(); err != nil {
-		return errors.Wrap(things.ErrDisconnect, err)
+	for _, action := range actions(things.ConnPubSub) {
+		if err := cc.client.SRem(ctx, key(chanID, action), thingID).Err(); err != nil {
+			return errors.Wrap(things.ErrDisconnect, err)
+		}
 	}
 	return nil
 }
 
 func (cc channelCache) Remove(ctx context.Context, chanID string) error {
-	cid, _ := kv(chanID, "0")
-	if err := cc.client.Del(ctx, cid).Err(); err != nil {
+	keys := []string{key(chanID, things.ConnPublish), key(chanID, things.ConnSubscribe)}
+	if err := cc.client.Del(ctx, keys...).Err(); err != nil {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 	return nil
 }
 
-// Generates key-value pair
-func kv(chanID, thingID string) (string, string) {
-	cid := fmt.Sprintf("%s:%s", chanPrefix, chanID)
-	return cid, thingID
+// Connected things are kept in a set per channel and action, so the
+// connection of pubsub type is stored in both publish and subscribe sets.
+func key(chanID, action string) string {
+	return fmt.Sprintf("%s:%s:%s", chanPrefix, chanID, action)
+}
+
+func actions(connType string) []string {
+	if connType == things.ConnPubSub {
+		return []string{things.ConnPublish, things.ConnSubscribe}
+	}
+	return []string{connType}
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/mainflux/mainflux/things"
 	"github.com/mainflux/mainflux/things/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,7 +37,7 @@ func TestConnect(t *testing.T) {
 		},
 	}
 	for _, tc := range cases {
-		err := channelCache.Connect(context.Background(), cid, tid)
+		err := channelCache.Connect(context.Background(), cid, tid, things.ConnPubSub)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail to connect due to: %s\n", tc.desc, err))
 	}
 }
@@ -46,13 +47,17 @@ func TestHasThing(t *testing.T) {
 
 	cid := "123"
 	tid := "321"
+	pubID := "322"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.ConnPubSub)
+	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
+	err = channelCache.Connect(context.Background(), cid, pubID, things.ConnPublish)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := map[string]struct {
 		cid       string
 		tid       string
+		action    string
 		hasAccess bool
 	}{
 		"access check for thing that has access": {
@@ -60,6 +65,24 @@ func TestHasThing(t *testing.T) {
 			tid:       tid,
 			hasAccess: true,
 		},
+		"subscribe check for thing that has access": {
+			cid:       cid,
+			tid:       tid,
+			action:    things.ConnSubscribe,
+			hasAccess: true,
+		},
+		"publish check for publish-only thing": {
+			cid:       cid,
+			tid:       pubID,
+			action:    things.ConnPublish,
+			hasAccess: true,
+		},
+		"subscribe check for publish-only thing": {
+			cid:       cid,
+			tid:       pubID,
+			action:    things.ConnSubscribe,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
 			cid:       cid,
 			tid:       cid,
@@ -73,7 +96,7 @@ func TestHasThing(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid, tc.action)
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
 }
@@ -84,7 +107,7 @@ func TestDisconnect(t *testing.T) {
 	tid := "321"
 	tid2 := "322"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.ConnPubSub)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
@@ -110,7 +133,7 @@ func TestDisconnect(t *testing.T) {
 		err := channelCache.Disconnect(context.Background(), tc.cid, tc.tid)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail due to: %s\n", tc.desc, err))
 
-		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid, "")
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("access check after %s: expected %t got %t\n", tc.desc, tc.hasAccess, hasAccess))
 	}
 }
@@ -122,7 +145,7 @@ func TestRemove(t *testing.T) {
 	cid2 := "124"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.ConnPubSub)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
@@ -151,7 +174,7 @@ func TestRemove(t *testing.T) {
 	for _, tc := range cases {
 		err := channelCache.Remove(context.Background(), tc.cid)
 		assert.Nil(t, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		hasAcces := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAcces := channelCache.HasThing(context.Background(), tc.cid, tc.tid, "")
 		assert.Equal(t, tc.hasAccess, hasAcces, "%s - check access after removing channel: expected %t got %t\n", tc.desc, tc.hasAccess, hasAcces)
 	}
 }
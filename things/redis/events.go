@@ -170,14 +170,16 @@ func (rce removeChannelEvent) Encode() map[string]interface{} {
 }
 
 type connectThingEvent struct {
-	chanID  string
-	thingID string
+	chanID   string
+	thingID  string
+	connType string
 }
 
 func (cte connectThingEvent) Encode() map[string]interface{} {
 	return map[string]interface{}{
 		"chan_id":   cte.chanID,
 		"thing_id":  cte.thingID,
+		"type":      cte.connType,
 		"operation": thingConnect,
 	}
 }
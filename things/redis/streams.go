@@ -5,6 +5,7 @@ package redis
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/mainflux/mainflux/things"
@@ -82,6 +83,11 @@ func (es eventStore) UpdateKey(ctx context.Context, token, id, key string) error
 	return es.svc.UpdateKey(ctx, token, id, key)
 }
 
+// RotateKey doesn't send event for the same reason as UpdateKey.
+func (es eventStore) RotateKey(ctx context.Context, token, id string, grace time.Duration) (string, error) {
+	return es.svc.RotateKey(ctx, token, id, grace)
+}
+
 func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	return es.svc.ViewThing(ctx, token, id)
 }
@@ -186,16 +192,21 @@ func (es eventStore) RemoveChannel(ctx context.Context, token, id string) error
 	return nil
 }
 
-func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
-	if err := es.svc.Connect(ctx, token, chIDs, thIDs); err != nil {
+func (es eventStore) Connect(ctx context.Context, token string, chIDs, thIDs []string, connType string) error {
+	if err := es.svc.Connect(ctx, token, chIDs, thIDs, connType); err != nil {
 		return err
 	}
 
+	if connType == "" {
+		connType = things.ConnPubSub
+	}
+
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
 			event := connectThingEvent{
-				chanID:  chID,
-				thingID: thID,
+				chanID:   chID,
+				thingID:  thID,
+				connType: connType,
 			}
 			record := &redis.XAddArgs{
 				Stream:       streamID,
@@ -228,18 +239,22 @@ func (es eventStore) Disconnect(ctx context.Context, token, chanID, thingID stri
 	return nil
 }
 
-func (es eventStore) CanAccessByKey(ctx context.Context, chanID string, key string) (string, error) {
-	return es.svc.CanAccessByKey(ctx, chanID, key)
+func (es eventStore) CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error) {
+	return es.svc.CanAccessByKey(ctx, chanID, key, action)
 }
 
-func (es eventStore) CanAccessByID(ctx context.Context, chanID string, thingID string) error {
-	return es.svc.CanAccessByID(ctx, chanID, thingID)
+func (es eventStore) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
+	return es.svc.CanAccessByID(ctx, chanID, thingID, action)
 }
 
 func (es eventStore) IsChannelOwner(ctx context.Context, owner, chanID string) error {
 	return es.svc.IsChannelOwner(ctx, owner, chanID)
 }
 
+func (es eventStore) IsThingOwner(ctx context.Context, owner, thingID string) error {
+	return es.svc.IsThingOwner(ctx, owner, thingID)
+}
+
 func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 	return es.svc.Identify(ctx, key)
 }
@@ -247,3 +262,19 @@ func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 func (es eventStore) ListMembers(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.Page, error) {
 	return es.svc.ListMembers(ctx, token, groupID, pm)
 }
+
+func (es eventStore) ShareThing(ctx context.Context, token, thingID string, actions, subjects []string) error {
+	return es.svc.ShareThing(ctx, token, thingID, actions, subjects)
+}
+
+func (es eventStore) UnshareThing(ctx context.Context, token, thingID string, actions, subjects []string) error {
+	return es.svc.UnshareThing(ctx, token, thingID, actions, subjects)
+}
+
+func (es eventStore) ShareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error {
+	return es.svc.ShareChannel(ctx, token, chanID, actions, subjects)
+}
+
+func (es eventStore) UnshareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error {
+	return es.svc.UnshareChannel(ctx, token, chanID, actions, subjects)
+}
@@ -6,6 +6,7 @@ package redis
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -13,8 +14,9 @@ import (
 )
 
 const (
-	keyPrefix = "thing_key"
-	idPrefix  = "thing"
+	keyPrefix     = "thing_key"
+	idPrefix      = "thing"
+	prevKeyPrefix = "thing_prev_key"
 )
 
 var _ things.ThingCache = (*thingCache)(nil)
@@ -53,19 +55,53 @@ func (tc *thingCache) ID(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
+func (tc *thingCache) Rotate(ctx context.Context, thingID, prevKey, key string, grace time.Duration) error {
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	key, err := tc.client.Get(ctx, tid).Result()
-	// Redis returns Nil Reply when key does not exist.
-	if err == redis.Nil {
-		return nil
+	pid := fmt.Sprintf("%s:%s", prevKeyPrefix, thingID)
+	pkey := fmt.Sprintf("%s:%s", keyPrefix, prevKey)
+	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
+
+	// Key of the earlier rotation is superseded by the current one.
+	oldKey, err := tc.client.Get(ctx, pid).Result()
+	if err != nil && err != redis.Nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
 	}
-	if err != nil {
-		return errors.Wrap(things.ErrRemoveEntity, err)
+
+	pipe := tc.client.TxPipeline()
+	if oldKey != "" && oldKey != prevKey {
+		pipe.Del(ctx, fmt.Sprintf("%s:%s", keyPrefix, oldKey))
+	}
+	if grace > 0 {
+		pipe.Set(ctx, pkey, thingID, grace)
+		pipe.Set(ctx, pid, prevKey, grace)
+	} else {
+		pipe.Del(ctx, pkey, pid)
+	}
+	pipe.Set(ctx, tkey, thingID, 0)
+	pipe.Set(ctx, tid, key, 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(things.ErrUpdateEntity, err)
 	}
+	return nil
+}
 
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
+	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
+	pid := fmt.Sprintf("%s:%s", prevKeyPrefix, thingID)
+	keys := []string{tid, pid}
+	for _, id := range []string{tid, pid} {
+		key, err := tc.client.Get(ctx, id).Result()
+		// Redis returns Nil Reply when key does not exist.
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(things.ErrRemoveEntity, err)
+		}
+		keys = append(keys, fmt.Sprintf("%s:%s", keyPrefix, key))
+	}
+
+	if err := tc.client.Del(ctx, keys...).Err(); err != nil {
 		return errors.Wrap(things.ErrRemoveEntity, err)
 	}
 	return nil
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	r "github.com/go-redis/redis/v8"
 	"github.com/mainflux/mainflux/pkg/errors"
@@ -120,3 +121,80 @@ func TestThingRemove(t *testing.T) {
 	}
 
 }
+
+func TestThingRotate(t *testing.T) {
+	thingCache := redis.NewThingCache(redisClient)
+
+	prevKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	id := "123"
+	err = thingCache.Save(context.Background(), prevKey, id)
+	require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
+
+	err = thingCache.Rotate(context.Background(), id, prevKey, key, time.Second)
+	require.Nil(t, err, fmt.Sprintf("Rotate thing key: expected nil got %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		ID   string
+	}{
+		{
+			desc: "Get ID by rotated thing key",
+			key:  key,
+			ID:   id,
+		},
+		{
+			desc: "Get ID by previous thing key",
+			key:  prevKey,
+			ID:   id,
+		},
+	}
+
+	for _, tc := range cases {
+		cacheID, err := thingCache.ID(context.Background(), tc.key)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s\n", tc.desc, err))
+		assert.Equal(t, tc.ID, cacheID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.ID, cacheID))
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	_, err = thingCache.ID(context.Background(), prevKey)
+	assert.True(t, errors.Contains(err, r.Nil), fmt.Sprintf("Get ID by expired thing key: expected %s got %s\n", r.Nil, err))
+
+	err = thingCache.Remove(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("Remove thing from cache: expected nil got %s", err))
+	_, err = thingCache.ID(context.Background(), key)
+	assert.True(t, errors.Contains(err, r.Nil), fmt.Sprintf("Get ID by removed thing key: expected %s got %s\n", r.Nil, err))
+}
+
+func TestThingRotateTwice(t *testing.T) {
+	thingCache := redis.NewThingCache(redisClient)
+
+	var keys []string
+	for i := 0; i < 3; i++ {
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		keys = append(keys, key)
+	}
+	id := "456"
+	err := thingCache.Save(context.Background(), keys[0], id)
+	require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
+
+	for i := 1; i < len(keys); i++ {
+		err = thingCache.Rotate(context.Background(), id, keys[i-1], keys[i], time.Hour)
+		require.Nil(t, err, fmt.Sprintf("Rotate thing key: expected nil got %s", err))
+	}
+
+	_, err = thingCache.ID(context.Background(), keys[0])
+	assert.True(t, errors.Contains(err, r.Nil), fmt.Sprintf("Get ID by superseded thing key: expected %s got %s\n", r.Nil, err))
+	for _, key := range keys[1:] {
+		cacheID, err := thingCache.ID(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("Get ID by thing key: expected nil got %s\n", err))
+		assert.Equal(t, id, cacheID, fmt.Sprintf("Get ID by thing key: expected %s got %s\n", id, cacheID))
+	}
+
+	err = thingCache.Remove(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("Remove thing from cache: expected nil got %s", err))
+}
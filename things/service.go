@@ -5,6 +5,7 @@ package things
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 
@@ -40,6 +41,21 @@ var (
 
 	// ErrFailedToRetrieveThings failed to retrieve things.
 	ErrFailedToRetrieveThings = errors.New("failed to retrieve group members")
+
+	// ErrShareEntity indicates error in sharing entity
+	ErrShareEntity = errors.New("share entity failed")
+
+	// ErrUnshareEntity indicates error in revoking entity sharing
+	ErrUnshareEntity = errors.New("unshare entity failed")
+)
+
+const (
+	readAction  = "read"
+	writeAction = "write"
+	adminAction = "admin"
+
+	thingKind   = "thing"
+	channelKind = "channel"
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -56,6 +72,11 @@ type Service interface {
 	// returned to indicate operation failure.
 	UpdateKey(ctx context.Context, token, id, key string) error
 
+	// RotateKey issues a new key to the thing identified by the provided ID,
+	// that belongs to the user identified by the provided key. The current
+	// key remains valid for the duration of the grace period.
+	RotateKey(ctx context.Context, token, id string, grace time.Duration) (string, error)
+
 	// ViewThing retrieves data about the thing identified with the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
@@ -97,30 +118,55 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveChannel(ctx context.Context, token, id string) error
 
-	// Connect adds things to the channel's list of connected things.
-	Connect(ctx context.Context, token string, chIDs, thIDs []string) error
+	// Connect adds things to the channel's list of connected things using
+	// the connections of the given type. Empty type connects things both
+	// for publishing and subscribing.
+	Connect(ctx context.Context, token string, chIDs, thIDs []string, connType string) error
 
 	// Disconnect removes thing from the channel's list of connected
 	// things.
 	Disconnect(ctx context.Context, token, chanID, thingID string) error
 
 	// CanAccessByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	CanAccessByKey(ctx context.Context, chanID, key string) (string, error)
+	// provided key for the given action (publish or subscribe) and returns
+	// thing's id if access is allowed. Empty action is allowed by any connection.
+	CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error)
 
-	// CanAccessByID determines whether the channel can be accessed by
-	// the given thing and returns error if it cannot.
-	CanAccessByID(ctx context.Context, chanID, thingID string) error
+	// CanAccessByID determines whether the channel can be accessed by the
+	// given thing for the given action and returns error if it cannot.
+	CanAccessByID(ctx context.Context, chanID, thingID, action string) error
 
 	// IsChannelOwner determines whether the channel can be accessed by
 	// the given user and returns error if it cannot.
 	IsChannelOwner(ctx context.Context, owner, chanID string) error
 
+	// IsThingOwner determines whether the thing is owned by the given
+	// user and returns error if it is not.
+	IsThingOwner(ctx context.Context, owner, thingID string) error
+
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
 
 	// ListMembers retrieves everything that is assigned to a group identified by groupID.
 	ListMembers(ctx context.Context, token, groupID string, pm PageMetadata) (Page, error)
+
+	// ShareThing allows users or groups identified by the provided subjects
+	// to perform the actions (read, write or admin) over the thing. Only the
+	// thing owner and the users with admin access can share the thing.
+	ShareThing(ctx context.Context, token, thingID string, actions, subjects []string) error
+
+	// UnshareThing revokes the actions over the thing from the users or
+	// groups identified by the provided subjects.
+	UnshareThing(ctx context.Context, token, thingID string, actions, subjects []string) error
+
+	// ShareChannel allows users or groups identified by the provided subjects
+	// to perform the actions (read, write or admin) over the channel. Only the
+	// channel owner and the users with admin access can share the channel.
+	ShareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error
+
+	// UnshareChannel revokes the actions over the channel from the users or
+	// groups identified by the provided subjects.
+	UnshareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -182,11 +228,20 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 		}
 	}
 
-	return ts.things.Save(ctx, things...)
+	ths, err := ts.things.Save(ctx, things...)
+	if err != nil {
+		return []Thing{}, err
+	}
+	for _, th := range ths {
+		if err := ts.claim(ctx, token, th.ID); err != nil {
+			return []Thing{}, errors.Wrap(ErrCreateEntity, err)
+		}
+	}
+	return ths, nil
 }
 
 func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Thing) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, thing.ID, writeAction)
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -197,23 +252,73 @@ func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Th
 }
 
 func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, id, writeAction)
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
 	owner := res.GetEmail()
 
-	return ts.things.UpdateKey(ctx, owner, id, key)
+	if err := ts.things.UpdateKey(ctx, owner, id, key); err != nil {
+		return err
+	}
+	return ts.thingCache.Remove(ctx, id)
+}
+
+func (ts *thingsService) RotateKey(ctx context.Context, token, id string, grace time.Duration) (string, error) {
+	if grace < 0 {
+		return "", ErrMalformedEntity
+	}
+
+	res, err := ts.canAccess(ctx, token, id, writeAction)
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	owner := res.GetEmail()
+
+	th, err := ts.things.RetrieveByID(ctx, owner, id)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := ts.idProvider.ID()
+	if err != nil {
+		return "", errors.Wrap(ErrCreateUUID, err)
+	}
+
+	if err := ts.things.RotateKey(ctx, owner, id, key, time.Now().Add(grace)); err != nil {
+		return "", err
+	}
+	if err := ts.thingCache.Rotate(ctx, id, th.Key, key, grace); err != nil {
+		return "", err
+	}
+	return key, nil
 }
 
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, id, readAction)
 	if err != nil {
 		return Thing{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveByID(ctx, res.GetEmail(), id)
+	th, err := ts.things.RetrieveByID(ctx, res.GetEmail(), id)
+	if err == nil || !errors.Contains(err, ErrNotFound) {
+		return th, err
+	}
+
+	// The thing is not owned by the user, but it may be shared with them.
+	if err := ts.authorize(ctx, token, id, readAction); err != nil {
+		return Thing{}, err
+	}
+	page, err := ts.things.RetrieveByIDs(ctx, []string{id}, PageMetadata{Limit: 1})
+	if err != nil {
+		return Thing{}, err
+	}
+	if len(page.Things) == 0 {
+		return Thing{}, ErrNotFound
+	}
+	return hideKeys(res.GetEmail(), page.Things)[0], nil
 }
 
 func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMetadata) (Page, error) {
@@ -222,11 +327,21 @@ func (ts *thingsService) ListThings(ctx context.Context, token string, pm PageMe
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.things.RetrieveAll(ctx, res.GetEmail(), pm)
+	shared, err := ts.sharedObjects(ctx, token, readAction)
+	if err != nil {
+		return Page{}, errors.Wrap(ErrViewEntity, err)
+	}
+
+	page, err := ts.things.RetrieveAll(ctx, res.GetEmail(), shared, pm)
+	if err != nil {
+		return Page{}, err
+	}
+	page.Things = hideKeys(res.GetEmail(), page.Things)
+	return page, nil
 }
 
 func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID string, pm PageMetadata) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, chID, readAction)
 	if err != nil {
 		return Page{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -243,6 +358,9 @@ func (ts *thingsService) RemoveThing(ctx context.Context, token, id string) erro
 	if err := ts.thingCache.Remove(ctx, id); err != nil {
 		return err
 	}
+	if err := ts.removePolicies(ctx, token, id); err != nil {
+		return err
+	}
 	return ts.things.Remove(ctx, res.GetEmail(), id)
 }
 
@@ -261,11 +379,20 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 		channels[i].Owner = res.GetEmail()
 	}
 
-	return ts.channels.Save(ctx, channels...)
+	chs, err := ts.channels.Save(ctx, channels...)
+	if err != nil {
+		return []Channel{}, err
+	}
+	for _, ch := range chs {
+		if err := ts.claim(ctx, token, ch.ID); err != nil {
+			return []Channel{}, errors.Wrap(ErrCreateEntity, err)
+		}
+	}
+	return chs, nil
 }
 
 func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channel Channel) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, channel.ID, writeAction)
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -275,7 +402,7 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 }
 
 func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, id, readAction)
 	if err != nil {
 		return Channel{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -289,11 +416,16 @@ func (ts *thingsService) ListChannels(ctx context.Context, token string, pm Page
 		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.RetrieveAll(ctx, res.GetEmail(), pm)
+	shared, err := ts.sharedObjects(ctx, token, readAction)
+	if err != nil {
+		return ChannelsPage{}, errors.Wrap(ErrViewEntity, err)
+	}
+
+	return ts.channels.RetrieveAll(ctx, res.GetEmail(), shared, pm)
 }
 
 func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID string, pm PageMetadata) (ChannelsPage, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, thID, readAction)
 	if err != nil {
 		return ChannelsPage{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
@@ -310,24 +442,42 @@ func (ts *thingsService) RemoveChannel(ctx context.Context, token, id string) er
 	if err := ts.channelCache.Remove(ctx, id); err != nil {
 		return err
 	}
-
+	if err := ts.removePolicies(ctx, token, id); err != nil {
+		return err
+	}
 	return ts.channels.Remove(ctx, res.GetEmail(), id)
 }
 
-func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string) error {
+func (ts *thingsService) Connect(ctx context.Context, token string, chIDs, thIDs []string, connType string) error {
 	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
-	return ts.channels.Connect(ctx, res.GetEmail(), chIDs, thIDs)
+	switch connType {
+	case "":
+		connType = ConnPubSub
+	case ConnPublish, ConnSubscribe, ConnPubSub:
+	default:
+		return ErrMalformedEntity
+	}
+
+	shared, err := ts.sharedObjects(ctx, token, writeAction)
+	if err != nil {
+		return errors.Wrap(ErrConnect, err)
+	}
+
+	return ts.channels.Connect(ctx, res.GetEmail(), shared, chIDs, thIDs, connType)
 }
 
 func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.canAccess(ctx, token, chanID, writeAction)
 	if err != nil {
 		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
+	if _, err := ts.canAccess(ctx, token, thingID, writeAction); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
 
 	if err := ts.channelCache.Disconnect(ctx, chanID, thingID); err != nil {
 		return err
@@ -336,39 +486,54 @@ func (ts *thingsService) Disconnect(ctx context.Context, token, chanID, thingID
 	return ts.channels.Disconnect(ctx, res.GetEmail(), chanID, thingID)
 }
 
-func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey string) (string, error) {
-	thingID, err := ts.hasThing(ctx, chanID, thingKey)
-	if err == nil {
-		return thingID, nil
+func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, thingKey, action string) (string, error) {
+	if ConnTypes(action) == nil {
+		return "", ErrMalformedEntity
 	}
 
-	thingID, err = ts.channels.HasThing(ctx, chanID, thingKey)
+	thingID, err := ts.Identify(ctx, thingKey)
 	if err != nil {
 		return "", err
 	}
 
-	if err := ts.thingCache.Save(ctx, thingKey, thingID); err != nil {
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, action); connected {
+		return thingID, nil
+	}
+
+	if err := ts.channels.HasThingByID(ctx, chanID, thingID, action); err != nil {
 		return "", err
 	}
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
+
+	if err := ts.cacheConnection(ctx, chanID, thingID, action); err != nil {
 		return "", err
 	}
 	return thingID, nil
 }
 
-func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID string) error {
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); connected {
+func (ts *thingsService) CanAccessByID(ctx context.Context, chanID, thingID, action string) error {
+	if ConnTypes(action) == nil {
+		return ErrMalformedEntity
+	}
+
+	if connected := ts.channelCache.HasThing(ctx, chanID, thingID, action); connected {
 		return nil
 	}
 
-	if err := ts.channels.HasThingByID(ctx, chanID, thingID); err != nil {
+	if err := ts.channels.HasThingByID(ctx, chanID, thingID, action); err != nil {
 		return err
 	}
 
-	if err := ts.channelCache.Connect(ctx, chanID, thingID); err != nil {
-		return err
+	return ts.cacheConnection(ctx, chanID, thingID, action)
+}
+
+// cacheConnection caches the connection that is known to allow the action.
+// Since the actual connection type is not known when any action is allowed,
+// such connections are not cached.
+func (ts *thingsService) cacheConnection(ctx context.Context, chanID, thingID, action string) error {
+	if action == "" {
+		return nil
 	}
-	return nil
+	return ts.channelCache.Connect(ctx, chanID, thingID, action)
 }
 
 func (ts *thingsService) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -378,33 +543,33 @@ func (ts *thingsService) IsChannelOwner(ctx context.Context, owner, chanID strin
 	return nil
 }
 
+func (ts *thingsService) IsThingOwner(ctx context.Context, owner, thingID string) error {
+	if _, err := ts.things.RetrieveByID(ctx, owner, thingID); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (ts *thingsService) Identify(ctx context.Context, key string) (string, error) {
 	id, err := ts.thingCache.ID(ctx, key)
 	if err == nil {
 		return id, nil
 	}
 
-	id, err = ts.things.RetrieveByKey(ctx, key)
+	th, err := ts.things.RetrieveByKey(ctx, key)
 	if err != nil {
 		return "", err
 	}
 
-	if err := ts.thingCache.Save(ctx, key, id); err != nil {
-		return "", err
+	// The previous key of a rotated thing is cached only by the rotation
+	// itself, so that it expires together with its grace period.
+	if th.Key != key {
+		return th.ID, nil
 	}
-	return id, nil
-}
-
-func (ts *thingsService) hasThing(ctx context.Context, chanID, thingKey string) (string, error) {
-	thingID, err := ts.thingCache.ID(ctx, thingKey)
-	if err != nil {
+	if err := ts.thingCache.Save(ctx, key, th.ID); err != nil {
 		return "", err
 	}
-
-	if connected := ts.channelCache.HasThing(ctx, chanID, thingID); !connected {
-		return "", ErrEntityConnected
-	}
-	return thingID, nil
+	return th.ID, nil
 }
 
 func (ts *thingsService) ListMembers(ctx context.Context, token, groupID string, pm PageMetadata) (Page, error) {
@@ -420,6 +585,166 @@ func (ts *thingsService) ListMembers(ctx context.Context, token, groupID string,
 	return ts.things.RetrieveByIDs(ctx, res, pm)
 }
 
+func (ts *thingsService) ShareThing(ctx context.Context, token, thingID string, actions, subjects []string) error {
+	return ts.updateSharing(ctx, token, thingID, thingKind, true, actions, subjects)
+}
+
+func (ts *thingsService) UnshareThing(ctx context.Context, token, thingID string, actions, subjects []string) error {
+	return ts.updateSharing(ctx, token, thingID, thingKind, false, actions, subjects)
+}
+
+func (ts *thingsService) ShareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error {
+	return ts.updateSharing(ctx, token, chanID, channelKind, true, actions, subjects)
+}
+
+func (ts *thingsService) UnshareChannel(ctx context.Context, token, chanID string, actions, subjects []string) error {
+	return ts.updateSharing(ctx, token, chanID, channelKind, false, actions, subjects)
+}
+
+// updateSharing adds or removes the policies of the thing or the channel,
+// depending on the kind. The owner of the entity is registered as its
+// administrator first, while other users have to be allowed to administer it.
+func (ts *thingsService) updateSharing(ctx context.Context, token, objID, kind string, add bool, actions, subjects []string) error {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	switch kind {
+	case thingKind:
+		_, err = ts.things.RetrieveByID(ctx, res.GetEmail(), objID)
+	default:
+		_, err = ts.channels.RetrieveByID(ctx, res.GetEmail(), objID)
+	}
+
+	if err != nil {
+		if !errors.Contains(err, ErrNotFound) {
+			return err
+		}
+		if err := ts.authorize(ctx, token, objID, adminAction); err != nil {
+			return err
+		}
+	} else if err := ts.claim(ctx, token, objID); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	if add {
+		return ts.share(ctx, token, objID, actions, subjects)
+	}
+	return ts.unshare(ctx, token, objID, actions, subjects)
+}
+
+func (ts *thingsService) share(ctx context.Context, token, objID string, actions, subjects []string) error {
+	if err := validateSharing(actions, subjects); err != nil {
+		return err
+	}
+
+	for _, sub := range subjects {
+		for _, act := range actions {
+			req := mainflux.PolicyReq{Token: token, Sub: sub, Obj: objID, Act: act}
+			if _, err := ts.auth.AddPolicy(ctx, &req); err != nil {
+				return errors.Wrap(ErrShareEntity, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (ts *thingsService) unshare(ctx context.Context, token, objID string, actions, subjects []string) error {
+	if err := validateSharing(actions, subjects); err != nil {
+		return err
+	}
+
+	for _, sub := range subjects {
+		for _, act := range actions {
+			req := mainflux.PolicyReq{Token: token, Sub: sub, Obj: objID, Act: act}
+			if _, err := ts.auth.DeletePolicy(ctx, &req); err != nil {
+				return errors.Wrap(ErrUnshareEntity, err)
+			}
+		}
+	}
+	return nil
+}
+
+// claim registers the owner as the administrator of the entity, so that
+// the owner is allowed to manage its policies. Claiming the entity that is
+// already administered by the owner has no effect.
+func (ts *thingsService) claim(ctx context.Context, token, objID string) error {
+	req := mainflux.ObjectPoliciesReq{Token: token, Obj: objID}
+	_, err := ts.auth.ClaimObject(ctx, &req)
+	return err
+}
+
+// removePolicies removes policies of the removed entity, which would
+// otherwise outlive it.
+func (ts *thingsService) removePolicies(ctx context.Context, token, objID string) error {
+	req := mainflux.ObjectPoliciesReq{Token: token, Obj: objID}
+	if _, err := ts.auth.DeleteObjectPolicies(ctx, &req); err != nil {
+		return errors.Wrap(ErrRemoveEntity, err)
+	}
+	return nil
+}
+
+// canAccess identifies the user holding the token, which is allowed to
+// perform the action over the entity. Unlike Identify, it accepts the API
+// keys whose scope is limited to the entity and the action.
+func (ts *thingsService) canAccess(ctx context.Context, token, objID, act string) (*mainflux.UserIdentity, error) {
+	return ts.auth.CanAccess(ctx, &mainflux.AccessReq{Token: token, Obj: objID, Act: act})
+}
+
+// authorize checks if the user is allowed to perform the action over the
+// entity that is shared with them. Entities that are not shared are
+// reported as non-existent.
+func (ts *thingsService) authorize(ctx context.Context, token, objID, act string) error {
+	req := mainflux.AuthorizeReq{Token: token, Obj: objID, Act: act}
+	res, err := ts.auth.Authorize(ctx, &req)
+	if err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !res.GetAuthorized() {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// sharedObjects returns IDs of the entities that the user is allowed to
+// perform the action over, without being their owner.
+func (ts *thingsService) sharedObjects(ctx context.Context, token, act string) ([]string, error) {
+	res, err := ts.auth.ListObjects(ctx, &mainflux.ObjectsReq{Token: token, Act: act})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetObjects(), nil
+}
+
+// hideKeys clears keys of the things that are shared with the user, since
+// the key allows its holder to act on behalf of the thing.
+func hideKeys(owner string, ths []Thing) []Thing {
+	for i := range ths {
+		if ths[i].Owner != owner {
+			ths[i].Key = ""
+		}
+	}
+	return ths
+}
+
+func validateSharing(actions, subjects []string) error {
+	if len(actions) == 0 || len(subjects) == 0 {
+		return ErrMalformedEntity
+	}
+	for _, act := range actions {
+		if act != readAction && act != writeAction && act != adminAction {
+			return ErrMalformedEntity
+		}
+	}
+	for _, sub := range subjects {
+		if sub == "" {
+			return ErrMalformedEntity
+		}
+	}
+	return nil
+}
+
 func (ts *thingsService) members(ctx context.Context, token, groupID, groupType string, limit, offset uint64) ([]string, error) {
 	req := mainflux.MembersReq{
 		Token:   token,
@@ -21,6 +21,7 @@ const (
 	wrongID    = ""
 	wrongValue = "wrong-value"
 	email      = "user@example.com"
+	email2     = "user2@example.com"
 	token      = "token"
 	token2     = "token2"
 	n          = uint64(10)
@@ -154,6 +155,128 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestRotateKey(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		grace time.Duration
+		err   error
+	}{
+		{
+			desc:  "rotate key of an existing thing",
+			token: token,
+			id:    th.ID,
+			grace: time.Hour,
+			err:   nil,
+		},
+		{
+			desc:  "rotate key without grace period",
+			token: token,
+			id:    th.ID,
+			grace: 0,
+			err:   nil,
+		},
+		{
+			desc:  "rotate key with negative grace period",
+			token: token,
+			id:    th.ID,
+			grace: -time.Hour,
+			err:   things.ErrMalformedEntity,
+		},
+		{
+			desc:  "rotate key with invalid credentials",
+			token: wrongValue,
+			id:    th.ID,
+			grace: time.Hour,
+			err:   things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "rotate key of non-existing thing",
+			token: token,
+			id:    wrongID,
+			grace: time.Hour,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := svc.RotateKey(context.Background(), tc.token, tc.id, tc.grace)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, key, fmt.Sprintf("%s: expected non-empty key\n", tc.desc))
+		}
+	}
+}
+
+func TestIdentifyRotatedKey(t *testing.T) {
+	svc := newService(map[string]string{token: email})
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, expTh := ths[0], ths[1]
+
+	// Resolve the key once so that it gets cached.
+	_, err = svc.Identify(context.Background(), th.Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	key, err := svc.RotateKey(context.Background(), token, th.ID, time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	expKey, err := svc.RotateKey(context.Background(), token, expTh.ID, time.Millisecond)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	time.Sleep(10 * time.Millisecond)
+
+	cases := []struct {
+		desc string
+		key  string
+		id   string
+		err  error
+	}{
+		{
+			desc: "identify thing by rotated key",
+			key:  key,
+			id:   th.ID,
+			err:  nil,
+		},
+		{
+			desc: "identify thing by previous key within grace period",
+			key:  th.Key,
+			id:   th.ID,
+			err:  nil,
+		},
+		{
+			desc: "identify thing by rotated key after grace period",
+			key:  expKey,
+			id:   expTh.ID,
+			err:  nil,
+		},
+		{
+			desc: "identify thing by previous key after grace period",
+			key:  expTh.Key,
+			id:   "",
+			err:  things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := svc.Identify(context.Background(), tc.key)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	// Updating the key invalidates the previous key immediately.
+	err = svc.UpdateKey(context.Background(), token, th.ID, "updated-key")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	for _, k := range []string{th.Key, key} {
+		_, err = svc.Identify(context.Background(), k)
+		assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("identify thing by replaced key: expected %s got %s\n", things.ErrNotFound, err))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ths, err := svc.CreateThings(context.Background(), token, thing)
@@ -335,7 +458,7 @@ func TestListThingsByChannel(t *testing.T) {
 	}
 	chIDs := []string{chs[0].ID}
 
-	err = svc.Connect(context.Background(), token, chIDs, thIDs[0:n-thsDisconNum])
+	err = svc.Connect(context.Background(), token, chIDs, thIDs[0:n-thsDisconNum], "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	// Wait for things and channels to connect
@@ -810,7 +933,7 @@ func TestListChannelsByThing(t *testing.T) {
 	}
 	thIDs := []string{ths[0].ID}
 
-	err = svc.Connect(context.Background(), token, chIDs[0:n-chsDisconNum], thIDs)
+	err = svc.Connect(context.Background(), token, chIDs[0:n-chsDisconNum], thIDs, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	// Wait for things and channels to connect.
@@ -1059,7 +1182,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID}, "")
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -1073,7 +1196,7 @@ func TestDisconnect(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, "")
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
@@ -1127,19 +1250,76 @@ func TestDisconnect(t *testing.T) {
 
 }
 
+func TestConnectShared(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token2, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	// Mocked repositories generate the same IDs for things and channels,
+	// so the thing with the channel ID has to be owned by the same user.
+	_, err = svc.CreateThings(context.Background(), token2, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ShareThing(context.Background(), token, ths[0].ID, []string{"write"}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ShareThing(context.Background(), token, ths[1].ID, []string{"read"}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		chanID  string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "connect thing shared with write access",
+			token:   token2,
+			chanID:  ch.ID,
+			thingID: ths[0].ID,
+			err:     nil,
+		},
+		{
+			desc:    "connect thing shared with read access",
+			token:   token2,
+			chanID:  ch.ID,
+			thingID: ths[1].ID,
+			err:     things.ErrNotFound,
+		},
+		{
+			desc:    "connect thing to channel that is not shared",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: ths[0].ID,
+			err:     things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Connect(context.Background(), tc.token, []string{tc.chanID}, []string{tc.thingID}, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestCanAccessByKey(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
-	ths, err := svc.CreateThings(context.Background(), token, thing)
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID})
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[0].ID}, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Connect(context.Background(), token, []string{chs[0].ID}, []string{ths[1].ID}, things.ConnPublish)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		token   string
 		channel string
+		action  string
 		err     error
 	}{
 		"allowed access": {
@@ -1147,6 +1327,30 @@ func TestCanAccessByKey(t *testing.T) {
 			channel: chs[0].ID,
 			err:     nil,
 		},
+		"allowed publish access": {
+			token:   ths[0].Key,
+			channel: chs[0].ID,
+			action:  things.ConnPublish,
+			err:     nil,
+		},
+		"allowed publish access for publish-only connection": {
+			token:   ths[1].Key,
+			channel: chs[0].ID,
+			action:  things.ConnPublish,
+			err:     nil,
+		},
+		"subscribe access for publish-only connection": {
+			token:   ths[1].Key,
+			channel: chs[0].ID,
+			action:  things.ConnSubscribe,
+			err:     things.ErrEntityConnected,
+		},
+		"access with invalid action": {
+			token:   ths[0].Key,
+			channel: chs[0].ID,
+			action:  wrongValue,
+			err:     things.ErrMalformedEntity,
+		},
 		"non-existing thing": {
 			token:   wrongValue,
 			channel: chs[0].ID,
@@ -1165,7 +1369,7 @@ func TestCanAccessByKey(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token)
+		_, err := svc.CanAccessByKey(context.Background(), tc.channel, tc.token, tc.action)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", desc, tc.err, err))
 	}
 }
@@ -1179,12 +1383,13 @@ func TestCanAccessByID(t *testing.T) {
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID})
+	err = svc.Connect(context.Background(), token, []string{ch.ID}, []string{th.ID}, things.ConnSubscribe)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := map[string]struct {
 		thingID string
 		channel string
+		action  string
 		err     error
 	}{
 		"allowed access": {
@@ -1192,6 +1397,18 @@ func TestCanAccessByID(t *testing.T) {
 			channel: ch.ID,
 			err:     nil,
 		},
+		"allowed subscribe access for subscribe-only connection": {
+			thingID: th.ID,
+			channel: ch.ID,
+			action:  things.ConnSubscribe,
+			err:     nil,
+		},
+		"publish access for subscribe-only connection": {
+			thingID: th.ID,
+			channel: ch.ID,
+			action:  things.ConnPublish,
+			err:     things.ErrEntityConnected,
+		},
 		"access to non-existing thing": {
 			thingID: wrongValue,
 			channel: ch.ID,
@@ -1210,7 +1427,7 @@ func TestCanAccessByID(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID)
+		err := svc.CanAccessByID(context.Background(), tc.channel, tc.thingID, tc.action)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -1249,6 +1466,40 @@ func TestIsChannelOwner(t *testing.T) {
 	}
 }
 
+func TestIsThingOwner(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: "john.doe@email.net"})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ownedTh := ths[0]
+	ths, err = svc.CreateThings(context.Background(), token2, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	nonOwnedTh := ths[0]
+
+	cases := map[string]struct {
+		thing string
+		err   error
+	}{
+		"user owns thing": {
+			thing: ownedTh.ID,
+			err:   nil,
+		},
+		"user does not own thing": {
+			thing: nonOwnedTh.ID,
+			err:   things.ErrNotFound,
+		},
+		"access to non-existing thing": {
+			thing: wrongID,
+			err:   things.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.IsThingOwner(context.Background(), email, tc.thing)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 
@@ -1315,3 +1566,164 @@ func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel
 		break
 	}
 }
+
+func TestShareThing(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		actions  []string
+		subjects []string
+		err      error
+	}{
+		{
+			desc:     "share thing by non-owner",
+			token:    token2,
+			id:       th.ID,
+			actions:  []string{"read"},
+			subjects: []string{email2},
+			err:      things.ErrNotFound,
+		},
+		{
+			desc:     "share thing with wrong credentials",
+			token:    wrongValue,
+			id:       th.ID,
+			actions:  []string{"read"},
+			subjects: []string{email2},
+			err:      things.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "share thing with invalid action",
+			token:    token,
+			id:       th.ID,
+			actions:  []string{"invalid"},
+			subjects: []string{email2},
+			err:      things.ErrMalformedEntity,
+		},
+		{
+			desc:     "share thing without subjects",
+			token:    token,
+			id:       th.ID,
+			actions:  []string{"read"},
+			subjects: []string{},
+			err:      things.ErrMalformedEntity,
+		},
+		{
+			desc:     "share thing by owner",
+			token:    token,
+			id:       th.ID,
+			actions:  []string{"admin"},
+			subjects: []string{email2},
+			err:      nil,
+		},
+		{
+			desc:     "share thing by user with admin access",
+			token:    token2,
+			id:       th.ID,
+			actions:  []string{"read"},
+			subjects: []string{"group"},
+			err:      nil,
+		},
+		{
+			desc:     "share non-existing thing",
+			token:    token,
+			id:       wrongID,
+			actions:  []string{"read"},
+			subjects: []string{email2},
+			err:      things.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ShareThing(context.Background(), tc.token, tc.id, tc.actions, tc.subjects)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestViewSharedThing(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[1]
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view thing that is not shared: expected %s got %s\n", things.ErrNotFound, err))
+
+	err = svc.ShareThing(context.Background(), token, th.ID, []string{"read"}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	res, err := svc.ViewThing(context.Background(), token2, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view shared thing: unexpected error: %s\n", err))
+	assert.Equal(t, th.ID, res.ID, fmt.Sprintf("view shared thing: expected %s got %s\n", th.ID, res.ID))
+	assert.Empty(t, res.Key, fmt.Sprintf("view shared thing: expected empty key got %s\n", res.Key))
+
+	page, err := svc.ListThings(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list shared things: unexpected error: %s\n", err))
+	require.Equal(t, 1, len(page.Things), fmt.Sprintf("list shared things: expected 1 got %d\n", len(page.Things)))
+	assert.Empty(t, page.Things[0].Key, fmt.Sprintf("list shared things: expected empty key got %s\n", page.Things[0].Key))
+
+	res, err = svc.ViewThing(context.Background(), token, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view owned thing: unexpected error: %s\n", err))
+	assert.Equal(t, th.Key, res.Key, fmt.Sprintf("view owned thing: expected key %s got %s\n", th.Key, res.Key))
+
+	err = svc.UnshareThing(context.Background(), token, th.ID, []string{"read"}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view unshared thing: expected %s got %s\n", things.ErrNotFound, err))
+}
+
+func TestRemoveSharedThing(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	err = svc.ShareThing(context.Background(), token, th.ID, []string{"read"}, []string{email2})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.RemoveThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrUnauthorizedAccess), fmt.Sprintf("remove thing shared for reading: expected %s got %s\n", things.ErrUnauthorizedAccess, err))
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view thing after failed removal: unexpected error: %s\n", err))
+
+	err = svc.RemoveThing(context.Background(), token, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove thing by owner: unexpected error: %s\n", err))
+
+	_, err = svc.ViewThing(context.Background(), token2, th.ID)
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("view removed thing: expected %s got %s\n", things.ErrNotFound, err))
+}
+
+func TestShareChannel(t *testing.T) {
+	svc := newService(map[string]string{token: email, token2: email2})
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	err = svc.ShareChannel(context.Background(), token2, ch.ID, []string{"read"}, []string{email2})
+	assert.True(t, errors.Contains(err, things.ErrNotFound), fmt.Sprintf("share channel by non-owner: expected %s got %s\n", things.ErrNotFound, err))
+
+	err = svc.ShareChannel(context.Background(), token, ch.ID, []string{"read"}, []string{email2})
+	assert.Nil(t, err, fmt.Sprintf("share channel by owner: unexpected error: %s\n", err))
+
+	page, err := svc.ListChannels(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list shared channels: unexpected error: %s\n", err))
+	assert.Equal(t, 1, len(page.Channels), fmt.Sprintf("list shared channels: expected 1 got %d\n", len(page.Channels)))
+
+	err = svc.UnshareChannel(context.Background(), token, ch.ID, []string{"read"}, []string{email2})
+	assert.Nil(t, err, fmt.Sprintf("unshare channel by owner: unexpected error: %s\n", err))
+
+	page, err = svc.ListChannels(context.Background(), token2, things.PageMetadata{Offset: 0, Limit: n})
+	assert.Nil(t, err, fmt.Sprintf("list unshared channels: unexpected error: %s\n", err))
+	assert.Equal(t, 0, len(page.Channels), fmt.Sprintf("list unshared channels: expected 0 got %d\n", len(page.Channels)))
+}
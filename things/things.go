@@ -5,6 +5,7 @@ package things
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/pkg/errors"
 )
@@ -62,19 +63,28 @@ type ThingRepository interface {
 	// returned to indicate operation failure.
 	Update(ctx context.Context, t Thing) error
 
-	// UpdateKey updates key value of the existing thing. A non-nil error is
-	// returned to indicate operation failure.
+	// UpdateKey updates key value of the existing thing. The previous key,
+	// if any, is invalidated immediately. A non-nil error is returned to
+	// indicate operation failure.
 	UpdateKey(ctx context.Context, owner, id, key string) error
 
+	// RotateKey replaces key value of the existing thing, keeping the
+	// current key valid until the provided expiration time.
+	RotateKey(ctx context.Context, owner, id, key string, expiresAt time.Time) error
+
 	// RetrieveByID retrieves the thing having the provided identifier, that is owned
 	// by the specified user.
 	RetrieveByID(ctx context.Context, owner, id string) (Thing, error)
 
-	// RetrieveByKey returns thing ID for given thing key.
-	RetrieveByKey(ctx context.Context, key string) (string, error)
+	// RetrieveByKey retrieves the thing having the provided key. Both the
+	// current and the previous key of the thing are resolved until the
+	// previous key expires, while the retrieved thing always contains the
+	// current key.
+	RetrieveByKey(ctx context.Context, key string) (Thing, error)
 
 	// RetrieveAll retrieves the subset of things owned by the specified user
-	RetrieveAll(ctx context.Context, owner string, pm PageMetadata) (Page, error)
+	// or identified by the provided shared thing IDs.
+	RetrieveAll(ctx context.Context, owner string, shared []string, pm PageMetadata) (Page, error)
 
 	// RetrieveByIDs retrieves the subset of things specified by given thing ids.
 	RetrieveByIDs(ctx context.Context, thingIDs []string, pm PageMetadata) (Page, error)
@@ -96,6 +106,10 @@ type ThingCache interface {
 	// ID returns thing ID for given key.
 	ID(context.Context, string) (string, error)
 
+	// Rotate replaces the cached key of the thing with the new one. The
+	// previous key remains cached for the duration of the grace period.
+	Rotate(ctx context.Context, thingID, prevKey, key string, grace time.Duration) error
+
 	// Removes thing from cache.
 	Remove(context.Context, string) error
 }
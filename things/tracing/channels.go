@@ -66,12 +66,12 @@ func (crm channelRepositoryMiddleware) RetrieveByID(ctx context.Context, owner,
 	return crm.repo.RetrieveByID(ctx, owner, id)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveAllChannelsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveAll(ctx, owner, pm)
+	return crm.repo.RetrieveAll(ctx, owner, shared, pm)
 }
 
 func (crm channelRepositoryMiddleware) RetrieveByThing(ctx context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
@@ -90,12 +90,12 @@ func (crm channelRepositoryMiddleware) Remove(ctx context.Context, owner, id str
 	return crm.repo.Remove(ctx, owner, id)
 }
 
-func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, chIDs, thIDs []string) error {
+func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner string, shared, chIDs, thIDs []string, connType string) error {
 	span := createSpan(ctx, crm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Connect(ctx, owner, chIDs, thIDs)
+	return crm.repo.Connect(ctx, owner, shared, chIDs, thIDs, connType)
 }
 
 func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, chanID, thingID string) error {
@@ -106,20 +106,20 @@ func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, ch
 	return crm.repo.Disconnect(ctx, owner, chanID, thingID)
 }
 
-func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key string) (string, error) {
+func (crm channelRepositoryMiddleware) HasThing(ctx context.Context, chanID, key, action string) (string, error) {
 	span := createSpan(ctx, crm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThing(ctx, chanID, key)
+	return crm.repo.HasThing(ctx, chanID, key, action)
 }
 
-func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID string) error {
+func (crm channelRepositoryMiddleware) HasThingByID(ctx context.Context, chanID, thingID, action string) error {
 	span := createSpan(ctx, crm.tracer, hasThingByIDOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.HasThingByID(ctx, chanID, thingID)
+	return crm.repo.HasThingByID(ctx, chanID, thingID, action)
 }
 
 type channelCacheMiddleware struct {
@@ -136,20 +136,20 @@ func ChannelCacheMiddleware(tracer opentracing.Tracer, cache things.ChannelCache
 	}
 }
 
-func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string) error {
+func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID, connType string) error {
 	span := createSpan(ctx, ccm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.Connect(ctx, chanID, thingID)
+	return ccm.cache.Connect(ctx, chanID, thingID, connType)
 }
 
-func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID string) bool {
+func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID, action string) bool {
 	span := createSpan(ctx, ccm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.HasThing(ctx, chanID, thingID)
+	return ccm.cache.HasThing(ctx, chanID, thingID, action)
 }
 
 func (ccm channelCacheMiddleware) Disconnect(ctx context.Context, chanID, thingID string) error {
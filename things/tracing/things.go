@@ -5,6 +5,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/mainflux/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -15,6 +16,7 @@ const (
 	saveThingsOp              = "save_things"
 	updateThingOp             = "update_thing"
 	updateThingKeyOp          = "update_thing_by_key"
+	rotateThingKeyOp          = "rotate_thing_key"
 	retrieveThingByIDOp       = "retrieve_thing_by_id"
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
 	retrieveAllThingsOp       = "retrieve_all_things"
@@ -66,6 +68,14 @@ func (trm thingRepositoryMiddleware) UpdateKey(ctx context.Context, owner, id, k
 	return trm.repo.UpdateKey(ctx, owner, id, key)
 }
 
+func (trm thingRepositoryMiddleware) RotateKey(ctx context.Context, owner, id, key string, expiresAt time.Time) error {
+	span := createSpan(ctx, trm.tracer, rotateThingKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RotateKey(ctx, owner, id, key, expiresAt)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id string) (things.Thing, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingByIDOp)
 	defer span.Finish()
@@ -74,7 +84,7 @@ func (trm thingRepositoryMiddleware) RetrieveByID(ctx context.Context, owner, id
 	return trm.repo.RetrieveByID(ctx, owner, id)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (string, error) {
+func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (things.Thing, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingByKeyOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
@@ -82,12 +92,12 @@ func (trm thingRepositoryMiddleware) RetrieveByKey(ctx context.Context, key stri
 	return trm.repo.RetrieveByKey(ctx, key)
 }
 
-func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, pm things.PageMetadata) (things.Page, error) {
+func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context, owner string, shared []string, pm things.PageMetadata) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllThingsOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return trm.repo.RetrieveAll(ctx, owner, pm)
+	return trm.repo.RetrieveAll(ctx, owner, shared, pm)
 }
 
 func (trm thingRepositoryMiddleware) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
@@ -144,6 +154,14 @@ func (tcm thingCacheMiddleware) ID(ctx context.Context, thingKey string) (string
 	return tcm.cache.ID(ctx, thingKey)
 }
 
+func (tcm thingCacheMiddleware) Rotate(ctx context.Context, thingID, prevKey, key string, grace time.Duration) error {
+	span := createSpan(ctx, tcm.tracer, rotateThingKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return tcm.cache.Rotate(ctx, thingID, prevKey, key, grace)
+}
+
 func (tcm thingCacheMiddleware) Remove(ctx context.Context, thingID string) error {
 	span := createSpan(ctx, tcm.tracer, removeThingOp)
 	defer span.Finish()
@@ -47,6 +47,18 @@ func (repo singleUserRepo) Issue(ctx context.Context, req *mainflux.IssueReq, op
 	return &mainflux.Token{Value: repo.token}, nil
 }
 
+func (repo singleUserRepo) Login(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	return &mainflux.Tokens{}, errUnsupported
+}
+
+func (repo singleUserRepo) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	return &mainflux.Tokens{}, errUnsupported
+}
+
+func (repo singleUserRepo) Logout(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, errUnsupported
+}
+
 func (repo singleUserRepo) Identify(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
@@ -58,6 +70,14 @@ func (repo singleUserRepo) Identify(ctx context.Context, token *mainflux.Token,
 	return &mainflux.UserIdentity{Id: repo.email, Email: repo.email}, nil
 }
 
+func (repo singleUserRepo) CanAccess(ctx context.Context, req *mainflux.AccessReq, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return repo.Identify(ctx, &mainflux.Token{Value: req.GetToken()})
+}
+
+func (repo singleUserRepo) IdentifyPreAuth(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return &mainflux.UserIdentity{}, errUnsupported
+}
+
 func (repo singleUserRepo) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
 	return &mainflux.AuthorizeRes{}, errUnsupported
 }
@@ -70,3 +90,23 @@ func (repo singleUserRepo) Members(ctx context.Context, req *mainflux.MembersReq
 func (repo singleUserRepo) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	return &empty.Empty{}, errUnsupported
 }
+
+func (repo singleUserRepo) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+func (repo singleUserRepo) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (r *mainflux.ObjectsRes, err error) {
+	return &mainflux.ObjectsRes{}, errUnsupported
+}
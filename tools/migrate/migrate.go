@@ -0,0 +1,125 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // required for SQL access
+)
+
+const adminAction = "admin"
+
+// Config - migration configuration
+type Config struct {
+	ThingsDB string
+	UsersDB  string
+	AuthDB   string
+	DryRun   bool
+}
+
+type entity struct {
+	ID    string `db:"id"`
+	Owner string `db:"owner"`
+}
+
+// Migrate registers the owners of the things and channels as their
+// administrators, the same way things service does for the newly created
+// entities. Entities created before the policies were introduced are not
+// covered by any policy, so they would otherwise be left without the owner
+// policy. Already existing policies are kept.
+func Migrate(cfg Config) {
+	thingsDB, err := sqlx.Connect("postgres", cfg.ThingsDB)
+	if err != nil {
+		log.Fatalf("Failed to connect to things database: %s", err)
+	}
+	defer thingsDB.Close()
+
+	usersDB, err := sqlx.Connect("postgres", cfg.UsersDB)
+	if err != nil {
+		log.Fatalf("Failed to connect to users database: %s", err)
+	}
+	defer usersDB.Close()
+
+	authDB, err := sqlx.Connect("postgres", cfg.AuthDB)
+	if err != nil {
+		log.Fatalf("Failed to connect to auth database: %s", err)
+	}
+	defer authDB.Close()
+
+	ids, err := userIDs(usersDB)
+	if err != nil {
+		log.Fatalf("Failed to retrieve users: %s", err)
+	}
+
+	var entities []entity
+	q := `SELECT id, owner FROM things UNION ALL SELECT id, owner FROM channels`
+	if err := thingsDB.Select(&entities, q); err != nil {
+		log.Fatalf("Failed to retrieve things and channels: %s", err)
+	}
+
+	tx, err := authDB.Beginx()
+	if err != nil {
+		log.Fatalf("Failed to start transaction: %s", err)
+	}
+
+	created := time.Now().UTC().Round(time.Millisecond)
+	added, skipped := 0, 0
+	for _, e := range entities {
+		userID, ok := ids[e.Owner]
+		if !ok {
+			log.Printf("Skipping entity %s: owner %s doesn't exist", e.ID, e.Owner)
+			skipped++
+			continue
+		}
+		if cfg.DryRun {
+			fmt.Printf("%s %s %s\n", userID, e.ID, adminAction)
+			added++
+			continue
+		}
+
+		q := `INSERT INTO policies (subject, object, action, created_at) VALUES ($1, $2, $3, $4)
+		      ON CONFLICT (subject, object, action) DO NOTHING`
+		res, err := tx.Exec(q, userID, e.ID, adminAction, created)
+		if err != nil {
+			tx.Rollback()
+			log.Fatalf("Failed to save policy of entity %s: %s", e.ID, err)
+		}
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			log.Fatalf("Failed to save policy of entity %s: %s", e.ID, err)
+		}
+		added += int(cnt)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Fatalf("Failed to commit transaction: %s", err)
+	}
+	log.Printf("Added %d owner policies, skipped %d entities of %d", added, skipped, len(entities))
+}
+
+// userIDs maps the emails of the users, which are used as the owners of
+// the things and channels, to the user IDs, which are used as the policy
+// subjects.
+func userIDs(db *sqlx.DB) (map[string]string, error) {
+	rows, err := db.Queryx(`SELECT id, email FROM users`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]string)
+	for rows.Next() {
+		var id, email string
+		if err := rows.Scan(&id, &email); err != nil {
+			return nil, err
+		}
+		ids[email] = id
+	}
+	return ids, rows.Err()
+}
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
@@ -15,27 +16,60 @@ import (
 var _ mainflux.AuthServiceClient = (*authServiceClient)(nil)
 
 type authServiceClient struct {
-	users map[string]string
+	mu       sync.Mutex
+	users    map[string]string
+	policies map[string]map[string]string
 }
 
-// NewAuthServiceClient creates mock of auth service.
+// NewAuthServiceClient creates mock of auth service. Policies grant a single
+// action of the subject over the object, and admin action implies others.
 func NewAuthServiceClient(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceClient{users}
+	return &authServiceClient{
+		users:    users,
+		policies: make(map[string]map[string]string),
+	}
 }
 
-func (svc authServiceClient) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+func (svc *authServiceClient) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: id, Email: id}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
 
+func (svc *authServiceClient) CanAccess(ctx context.Context, req *mainflux.AccessReq, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return svc.Identify(ctx, &mainflux.Token{Value: req.GetToken()})
+}
+
+func (svc *authServiceClient) IdentifyPreAuth(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
 func (svc *authServiceClient) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	return new(mainflux.Token), nil
 }
 
+func (svc *authServiceClient) Login(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	return new(mainflux.Tokens), nil
+}
+
+func (svc *authServiceClient) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	return new(mainflux.Tokens), nil
+}
+
+func (svc *authServiceClient) Logout(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return new(empty.Empty), nil
+}
+
 func (svc *authServiceClient) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
-	panic("not implemented")
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	return &mainflux.AuthorizeRes{Authorized: svc.allowed(sub, req.GetObj(), req.GetAct())}, nil
 }
 
 func (svc *authServiceClient) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
@@ -45,3 +79,83 @@ func (svc *authServiceClient) Members(ctx context.Context, req *mainflux.Members
 func (svc *authServiceClient) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
+
+func (svc *authServiceClient) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub, ok := svc.users[req.GetToken()]
+	if !ok || !svc.allowed(sub, req.GetObj(), "admin") {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	svc.add(req.GetSub(), req.GetObj(), req.GetAct())
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceClient) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceClient) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if svc.claimable(req.GetObj()) {
+		return &empty.Empty{}, nil
+	}
+	if !svc.allowed(sub, req.GetObj(), "admin") {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	for _, objs := range svc.policies {
+		delete(objs, req.GetObj())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceClient) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if svc.allowed(sub, req.GetObj(), "admin") {
+		return &empty.Empty{}, nil
+	}
+	if !svc.claimable(req.GetObj()) {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	svc.add(sub, req.GetObj(), "admin")
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceClient) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (r *mainflux.ObjectsRes, err error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceClient) add(sub, obj, act string) {
+	if _, ok := svc.policies[sub]; !ok {
+		svc.policies[sub] = make(map[string]string)
+	}
+	svc.policies[sub][obj] = act
+}
+
+func (svc *authServiceClient) allowed(sub, obj, act string) bool {
+	granted, ok := svc.policies[sub][obj]
+	return ok && (granted == act || granted == "admin" || (granted == "write" && act == "read"))
+}
+
+// claimable checks if the object is not covered by any policy.
+func (svc *authServiceClient) claimable(obj string) bool {
+	for _, objs := range svc.policies {
+		if _, ok := objs[obj]; ok {
+			return false
+		}
+	}
+	return true
+}
@@ -18,7 +18,13 @@ import (
 	"github.com/mainflux/senml"
 )
 
-const publisher = "twins"
+const (
+	publisher = "twins"
+
+	readAction  = "read"
+	writeAction = "write"
+	adminAction = "admin"
+)
 
 var (
 	// ErrMalformedEntity indicates malformed entity specification (e.g.
@@ -43,15 +49,18 @@ type Service interface {
 	AddTwin(ctx context.Context, token string, twin Twin, def Definition) (tw Twin, err error)
 
 	// UpdateTwin updates twin identified by the provided Twin that
-	// belongs to the user identified by the provided key.
+	// belongs to the user identified by the provided key, or that the user
+	// is allowed to write by the policy.
 	UpdateTwin(ctx context.Context, token string, twin Twin, def Definition) (err error)
 
-	// ViewTwin retrieves data about twin with the provided
-	// ID belonging to the user identified by the provided key.
+	// ViewTwin retrieves data about twin with the provided ID belonging
+	// to the user identified by the provided key, or that the user is
+	// allowed to read by the policy.
 	ViewTwin(ctx context.Context, token, twinID string) (tw Twin, err error)
 
 	// RemoveTwin removes the twin identified with the provided ID, that
-	// belongs to the user identified by the provided key.
+	// belongs to the user identified by the provided key, or that the user
+	// is allowed to administer by the policy.
 	RemoveTwin(ctx context.Context, token, twinID string) (err error)
 
 	// ListTwins retrieves data about subset of twins that belongs to the
@@ -59,7 +68,7 @@ type Service interface {
 	ListTwins(ctx context.Context, token string, offset uint64, limit uint64, name string, metadata Metadata) (Page, error)
 
 	// ListStates retrieves data about subset of states that belongs to the
-	// twin identified by the id.
+	// twin identified by the id, which the user is allowed to read.
 	ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (StatesPage, error)
 
 	// SaveStates persists states into database
@@ -152,6 +161,13 @@ func (ts *twinsService) AddTwin(ctx context.Context, token string, twin Twin, de
 		return Twin{}, err
 	}
 
+	// Owner is registered as the administrator of the twin, so that the
+	// owner is allowed to share it with other users.
+	req := mainflux.ObjectPoliciesReq{Token: token, Obj: twin.ID}
+	if _, err = ts.auth.ClaimObject(ctx, &req); err != nil {
+		return Twin{}, err
+	}
+
 	id = twin.ID
 	b, err = json.Marshal(twin)
 
@@ -163,12 +179,7 @@ func (ts *twinsService) UpdateTwin(ctx context.Context, token string, twin Twin,
 	var id string
 	defer ts.publish(&id, &err, crudOp["updateSucc"], crudOp["updateFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return ErrUnauthorizedAccess
-	}
-
-	tw, err := ts.twins.RetrieveByID(ctx, twin.ID)
+	tw, err := ts.authorize(ctx, token, twin.ID, writeAction)
 	if err != nil {
 		return err
 	}
@@ -213,12 +224,7 @@ func (ts *twinsService) ViewTwin(ctx context.Context, token, twinID string) (tw
 	var b []byte
 	defer ts.publish(&twinID, &err, crudOp["getSucc"], crudOp["getFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return Twin{}, ErrUnauthorizedAccess
-	}
-
-	twin, err := ts.twins.RetrieveByID(ctx, twinID)
+	twin, err := ts.authorize(ctx, token, twinID, readAction)
 	if err != nil {
 		return Twin{}, err
 	}
@@ -232,9 +238,17 @@ func (ts *twinsService) RemoveTwin(ctx context.Context, token, twinID string) (e
 	var b []byte
 	defer ts.publish(&twinID, &err, crudOp["removeSucc"], crudOp["removeFail"], &b)
 
-	_, err = ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return ErrUnauthorizedAccess
+	switch _, err := ts.authorize(ctx, token, twinID, adminAction); {
+	case errors.Contains(err, ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	// Policies are removed first, so that they can't outlive the twin.
+	req := mainflux.ObjectPoliciesReq{Token: token, Obj: twinID}
+	if _, err := ts.auth.DeleteObjectPolicies(ctx, &req); err != nil {
+		return err
 	}
 
 	if err := ts.twins.Remove(ctx, twinID); err != nil {
@@ -254,14 +268,44 @@ func (ts *twinsService) ListTwins(ctx context.Context, token string, offset uint
 }
 
 func (ts *twinsService) ListStates(ctx context.Context, token string, offset uint64, limit uint64, twinID string) (StatesPage, error) {
-	_, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return StatesPage{}, ErrUnauthorizedAccess
+	switch _, err := ts.authorize(ctx, token, twinID, readAction); {
+	case errors.Contains(err, ErrNotFound):
+		return StatesPage{PageMetadata: PageMetadata{Offset: offset, Limit: limit}}, nil
+	case err != nil:
+		return StatesPage{}, err
 	}
 
 	return ts.states.RetrieveAll(ctx, offset, limit, twinID)
 }
 
+// authorize retrieves the twin which is either owned by the user identified
+// by the token, or which the user is allowed to perform the action over by
+// the policy.
+func (ts *twinsService) authorize(ctx context.Context, token, twinID, act string) (Twin, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Twin{}, ErrUnauthorizedAccess
+	}
+
+	tw, err := ts.twins.RetrieveByID(ctx, twinID)
+	if err != nil {
+		return Twin{}, err
+	}
+	if tw.Owner == res.GetEmail() {
+		return tw, nil
+	}
+
+	req := mainflux.AuthorizeReq{Token: token, Obj: twinID, Act: act}
+	ar, err := ts.auth.Authorize(ctx, &req)
+	if err != nil {
+		return Twin{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !ar.GetAuthorized() {
+		return Twin{}, ErrUnauthorizedAccess
+	}
+	return tw, nil
+}
+
 func (ts *twinsService) SaveStates(msg *messaging.Message) error {
 	var ids []string
 
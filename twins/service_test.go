@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/twins"
 	"github.com/mainflux/mainflux/twins/mocks"
 	"github.com/mainflux/senml"
@@ -418,3 +420,30 @@ func TestListStates(t *testing.T) {
 		assert.Equal(t, tc.size, len(page.States), fmt.Sprintf("%s: expected %d total got %d total\n", tc.desc, tc.size, len(page.States)))
 	}
 }
+
+func TestSharedTwin(t *testing.T) {
+	otherToken, otherEmail := "other-token", "other@example.com"
+	auth := mocks.NewAuthServiceClient(map[string]string{token: email, otherToken: otherEmail})
+	svc := twins.New(mocks.NewBroker(map[string]string{"chanID": "chanID"}), auth, mocks.NewTwinRepository(), mocks.NewTwinCache(), mocks.NewStateRepository(), uuid.NewMock(), "chanID", nil)
+
+	saved, err := svc.AddTwin(context.Background(), token, twins.Twin{}, twins.Definition{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.ViewTwin(context.Background(), otherToken, saved.ID)
+	assert.Equal(t, twins.ErrUnauthorizedAccess, err, fmt.Sprintf("view not shared twin: expected %s got %s\n", twins.ErrUnauthorizedAccess, err))
+
+	_, err = auth.ClaimObject(context.Background(), &mainflux.ObjectPoliciesReq{Token: otherToken, Obj: saved.ID})
+	assert.NotNil(t, err, "claim twin owned by other user: expected error got nil\n")
+
+	_, err = auth.AddPolicy(context.Background(), &mainflux.PolicyReq{Token: token, Sub: otherEmail, Obj: saved.ID, Act: "read"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.ViewTwin(context.Background(), otherToken, saved.ID)
+	assert.Nil(t, err, fmt.Sprintf("view shared twin: expected no error got %s\n", err))
+
+	err = svc.UpdateTwin(context.Background(), otherToken, saved, twins.Definition{})
+	assert.Equal(t, twins.ErrUnauthorizedAccess, err, fmt.Sprintf("update twin shared for reading: expected %s got %s\n", twins.ErrUnauthorizedAccess, err))
+
+	err = svc.RemoveTwin(context.Background(), otherToken, saved.ID)
+	assert.Equal(t, twins.ErrUnauthorizedAccess, err, fmt.Sprintf("remove twin shared for reading: expected %s got %s\n", twins.ErrUnauthorizedAccess, err))
+}
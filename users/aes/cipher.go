@@ -0,0 +1,64 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package aes provides a cipher implementation utilizing AES-GCM.
+package aes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+var (
+	errInvalidKey = errors.New("invalid encryption key")
+	errEncrypt    = errors.New("failed to encrypt secret")
+	errDecrypt    = errors.New("failed to decrypt secret")
+)
+
+var _ users.Cipher = (*aesCipher)(nil)
+
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// New instantiates an AES-GCM cipher using the 16, 24 or 32 bytes long key,
+// selecting AES-128, AES-192 or AES-256.
+func New(key []byte) (users.Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidKey, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidKey, err)
+	}
+
+	return &aesCipher{aead: aead}, nil
+}
+
+// Encrypt returns the ciphertext prefixed with the random nonce.
+func (ac *aesCipher) Encrypt(plaintext, data []byte) ([]byte, error) {
+	nonce := make([]byte, ac.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(errEncrypt, err)
+	}
+
+	return ac.aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+func (ac *aesCipher) Decrypt(ciphertext, data []byte) ([]byte, error) {
+	size := ac.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errDecrypt
+	}
+
+	plaintext, err := ac.aead.Open(nil, ciphertext[:size], ciphertext[size:], data)
+	if err != nil {
+		return nil, errors.Wrap(errDecrypt, err)
+	}
+	return plaintext, nil
+}
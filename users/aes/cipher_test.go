@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package aes_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/aes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var key = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		desc string
+		key  []byte
+		err  bool
+	}{
+		{"create AES-128 cipher", key[:16], false},
+		{"create AES-192 cipher", key[:24], false},
+		{"create AES-256 cipher", key, false},
+		{"create cipher with invalid key size", key[:10], true},
+		{"create cipher with empty key", nil, true},
+	}
+
+	for _, tc := range cases {
+		_, err := aes.New(tc.key)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	c, err := aes.New(key)
+	require.Nil(t, err, fmt.Sprintf("creating cipher expected to succeed: %s", err))
+
+	plaintext := []byte("secret")
+	data := []byte("user")
+	ciphertext, err := c.Encrypt(plaintext, data)
+	require.Nil(t, err, fmt.Sprintf("encrypting expected to succeed: %s", err))
+
+	modified := append([]byte{}, ciphertext...)
+	modified[len(modified)-1] ^= 1
+
+	other, err := aes.New(key[:16])
+	require.Nil(t, err, fmt.Sprintf("creating cipher expected to succeed: %s", err))
+
+	cases := []struct {
+		desc       string
+		cipher     users.Cipher
+		ciphertext []byte
+		data       []byte
+		err        bool
+	}{
+		{"decrypt ciphertext", c, ciphertext, data, false},
+		{"decrypt ciphertext with other data", c, ciphertext, []byte("other"), true},
+		{"decrypt modified ciphertext", c, modified, data, true},
+		{"decrypt truncated ciphertext", c, ciphertext[:4], data, true},
+		{"decrypt ciphertext with other key", other, ciphertext, data, true},
+	}
+
+	for _, tc := range cases {
+		res, err := tc.cipher.Decrypt(tc.ciphertext, tc.data)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if !tc.err {
+			assert.Equal(t, plaintext, res, fmt.Sprintf("%s: expected %s got %s", tc.desc, plaintext, res))
+		}
+	}
+}
@@ -164,12 +164,130 @@ func loginEndpoint(svc users.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
-		token, err := svc.Login(ctx, req.user)
+		tokens, err := svc.Login(ctx, req.user)
 		if err != nil {
 			return nil, err
 		}
 
-		return tokenRes{token}, nil
+		return tokenRes{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken, PreAuthToken: tokens.PreAuthToken}, nil
+	}
+}
+
+func refreshEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(refreshReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		tokens, err := svc.Refresh(ctx, req.RefreshToken)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+	}
+}
+
+func logoutEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(logoutReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.Logout(ctx, req.token); err != nil {
+			return nil, err
+		}
+
+		return logoutRes{}, nil
+	}
+}
+
+func oidcAuthorizeEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oidcAuthorizeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		authReq, err := svc.OIDCAuthorize(ctx, req.provider)
+		if err != nil {
+			return nil, err
+		}
+
+		return oidcAuthorizeRes{provider: req.provider, authReq: authReq}, nil
+	}
+}
+
+func oidcLoginEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oidcLoginReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		tokens, err := svc.OIDCLogin(ctx, req.provider, req.state, req.code, *req.authReq)
+		if err != nil {
+			return nil, err
+		}
+
+		return oidcTokenRes{tokenRes{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken, PreAuthToken: tokens.PreAuthToken}}, nil
+	}
+}
+
+func enrolTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrolTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		e, err := svc.EnrolTOTP(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return enrolTOTPRes{Secret: e.Secret, URI: e.URI}, nil
+	}
+}
+
+func confirmTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(confirmTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		codes, err := svc.ConfirmTOTP(ctx, req.token, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return confirmTOTPRes{RecoveryCodes: codes}, nil
+	}
+}
+
+func verifyTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		tokens, err := svc.VerifyTOTP(ctx, req.PreAuthToken, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken}, nil
+	}
+}
+
+func resetTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resetTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.ResetTOTP(ctx, req.token, req.userID); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
 	}
 }
 
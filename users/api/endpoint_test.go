@@ -11,12 +11,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/totp"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/users"
 	"github.com/mainflux/mainflux/users/api"
@@ -33,6 +36,10 @@ const (
 	invalidEmail = "userexample.com"
 	validPass    = "password"
 	invalidPass  = "wrong"
+	provider     = "mock"
+	providerURL  = "http://localhost/authorize"
+	oidcEmail    = "oidc-user@example.com"
+	wrongValue   = "wrong-value"
 )
 
 var (
@@ -44,6 +51,10 @@ var (
 	unsupportedRes = toJSON(errorRes{errors.ErrUnsupportedContentType.Error()})
 	failDecodeRes  = toJSON(errorRes{errors.ErrMalformedEntity.Error()})
 	passRegex      = regexp.MustCompile("^.{8,}$")
+	identities     = map[string]users.Identity{
+		"code":       {Subject: "1", Email: oidcEmail, EmailVerified: true},
+		"unverified": {Subject: "2", Email: "unverified@example.com"},
+	}
 )
 
 type testRequest struct {
@@ -52,6 +63,7 @@ type testRequest struct {
 	url         string
 	contentType string
 	token       string
+	cookie      *http.Cookie
 	body        io.Reader
 }
 
@@ -66,6 +78,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	if tr.cookie != nil {
+		req.AddCookie(tr.cookie)
+	}
 
 	req.Header.Set("Referer", "http://localhost")
 	return tr.client.Do(req)
@@ -74,11 +89,14 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() users.Service {
 	usersRepo := mocks.NewUserRepository()
 	hasher := bcrypt.New()
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email, oidcEmail: oidcEmail})
 	email := mocks.NewEmailer()
 	idProvider := uuid.New()
+	providers := map[string]users.IdentityProvider{
+		provider: mocks.NewIdentityProvider(providerURL, identities),
+	}
 
-	return users.New(usersRepo, hasher, auth, email, idProvider, passRegex)
+	return users.New(usersRepo, mocks.NewTOTPRepository(), hasher, mocks.NewCipher(), auth, email, idProvider, passRegex, providers, "")
 }
 
 func newServer(svc users.Service) *httptest.Server {
@@ -141,7 +159,7 @@ func TestLogin(t *testing.T) {
 	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
 	tkn, _ := auth.Issue(context.Background(), &mainflux.IssueReq{Id: user.ID, Email: user.Email, Type: 0})
 	token := tkn.GetValue()
-	tokenData := toJSON(map[string]string{"token": token})
+	tokenData := toJSON(tokenRes{Token: token, RefreshToken: mocks.RefreshPrefix + token})
 	data := toJSON(user)
 	invalidEmailData := toJSON(users.User{
 		Email:    invalidEmail,
@@ -194,6 +212,187 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+// noRedirectClient returns the client that does not follow the redirect to
+// the OpenID Connect provider.
+func noRedirectClient(ts *httptest.Server) *http.Client {
+	client := *ts.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &client
+}
+
+func TestOIDCAuthorize(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := noRedirectClient(ts)
+
+	cases := []struct {
+		desc     string
+		provider string
+		status   int
+	}{
+		{"authorize with known provider", provider, http.StatusFound},
+		{"authorize with unknown provider", "unknown", http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/oidc/%s", ts.URL, tc.provider),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusFound {
+			continue
+		}
+
+		loc := res.Header.Get("Location")
+		assert.True(t, strings.HasPrefix(loc, providerURL), fmt.Sprintf("%s: expected redirect to %s got %s", tc.desc, providerURL, loc))
+		cookies := res.Cookies()
+		require.Len(t, cookies, 1, fmt.Sprintf("%s: expected authorization request cookie", tc.desc))
+		assert.True(t, cookies[0].HttpOnly && cookies[0].Secure, fmt.Sprintf("%s: expected secure HTTP only cookie", tc.desc))
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := noRedirectClient(ts)
+
+	res, err := testRequest{client: client, method: http.MethodGet, url: fmt.Sprintf("%s/oidc/%s", ts.URL, provider)}.make()
+	require.Nil(t, err, fmt.Sprintf("authorize: unexpected error %s", err))
+	require.Len(t, res.Cookies(), 1, "authorize: expected authorization request cookie")
+	cookie := res.Cookies()[0]
+	loc, err := url.Parse(res.Header.Get("Location"))
+	require.Nil(t, err, fmt.Sprintf("authorize: unexpected error %s", err))
+	state := loc.Query().Get("state")
+
+	tokenData := toJSON(tokenRes{Token: oidcEmail, RefreshToken: mocks.RefreshPrefix + oidcEmail})
+	unverifiedRes := toJSON(errorRes{users.ErrUnverifiedEmail.Error()})
+
+	cases := []struct {
+		desc     string
+		provider string
+		query    string
+		cookie   *http.Cookie
+		status   int
+		res      string
+	}{
+		{"login with valid code", provider, fmt.Sprintf("state=%s&code=code", state), cookie, http.StatusCreated, tokenData},
+		{"login without authorization request cookie", provider, fmt.Sprintf("state=%s&code=code", state), nil, http.StatusForbidden, unauthRes},
+		{"login with mismatched state", provider, "state=wrong&code=code", cookie, http.StatusForbidden, unauthRes},
+		{"login with invalid code", provider, fmt.Sprintf("state=%s&code=wrong", state), cookie, http.StatusForbidden, unauthRes},
+		{"login without code", provider, fmt.Sprintf("state=%s", state), cookie, http.StatusBadRequest, malformedRes},
+		{"login with access denied", provider, fmt.Sprintf("state=%s&error=access_denied", state), cookie, http.StatusForbidden, unauthRes},
+		{"login with unverified email", provider, fmt.Sprintf("state=%s&code=unverified", state), cookie, http.StatusForbidden, unverifiedRes},
+		{"login with other provider", "unknown", fmt.Sprintf("state=%s&code=code", state), cookie, http.StatusForbidden, unauthRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/oidc/%s/callback?%s", ts.URL, tc.provider, tc.query),
+			cookie: tc.cookie,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		data := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, data))
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("login user got unexpected error: %s", err))
+	data := toJSON(map[string]string{"refresh_token": tokens.RefreshToken})
+	accessData := toJSON(map[string]string{"refresh_token": tokens.AccessToken})
+	tokenData := toJSON(tokenRes{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+		res         string
+	}{
+		{"refresh with valid refresh token", data, contentType, http.StatusCreated, tokenData},
+		{"refresh with access token", accessData, contentType, http.StatusForbidden, unauthRes},
+		{"refresh with empty JSON request", "{}", contentType, http.StatusForbidden, unauthRes},
+		{"refresh with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"refresh with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens/refresh", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		token := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
+	}
+}
+
+func TestLogout(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("login user got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"logout with access token", tokens.AccessToken, http.StatusNoContent},
+		{"logout with refresh token", tokens.RefreshToken, http.StatusNoContent},
+		{"logout with invalid token", "invalid", http.StatusForbidden},
+		{"logout with empty token", "", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/logout", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestUser(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -435,6 +634,243 @@ func TestPasswordChange(t *testing.T) {
 	}
 }
 
+// enableTOTP enrols and confirms TOTP of the user identified by the token,
+// returning the secret and the recovery codes.
+func enableTOTP(t *testing.T, svc users.Service, token string) ([]byte, []string) {
+	e, err := svc.EnrolTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("enrol TOTP got unexpected error: %s", err))
+	secret, err := totp.Encoding.DecodeString(e.Secret)
+	require.Nil(t, err, fmt.Sprintf("decode TOTP secret got unexpected error: %s", err))
+	codes, err := svc.ConfirmTOTP(context.Background(), token, totp.Generate(secret, time.Now()))
+	require.Nil(t, err, fmt.Sprintf("confirm TOTP got unexpected error: %s", err))
+	return secret, codes
+}
+
+func TestEnrolTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	token := user.Email
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"enrol TOTP with valid token", token, http.StatusCreated},
+		{"enrol TOTP with invalid token", wrongValue, http.StatusForbidden},
+		{"enrol TOTP with empty token", "", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/users/totp", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+		var body enrolTOTPRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, body.Secret, fmt.Sprintf("%s: expected secret", tc.desc))
+		assert.True(t, strings.HasPrefix(body.URI, "otpauth://totp/"), fmt.Sprintf("%s: unexpected URI %s", tc.desc, body.URI))
+	}
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	token := user.Email
+
+	e, err := svc.EnrolTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("enrol TOTP got unexpected error: %s", err))
+	secret, err := totp.Encoding.DecodeString(e.Secret)
+	require.Nil(t, err, fmt.Sprintf("decode TOTP secret got unexpected error: %s", err))
+	data := toJSON(confirmTOTPReq{Code: totp.Generate(secret, time.Now())})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+	}{
+		{"confirm TOTP with invalid code", toJSON(confirmTOTPReq{Code: wrongValue}), contentType, token, http.StatusForbidden},
+		{"confirm TOTP with empty code", "{}", contentType, token, http.StatusBadRequest},
+		{"confirm TOTP with invalid token", data, contentType, wrongValue, http.StatusForbidden},
+		{"confirm TOTP with invalid request format", "{", contentType, token, http.StatusBadRequest},
+		{"confirm TOTP with missing content type", data, "", token, http.StatusUnsupportedMediaType},
+		{"confirm TOTP with valid code", data, contentType, token, http.StatusOK},
+		{"confirm enabled TOTP", data, contentType, token, http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/users/totp", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var body confirmTOTPRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Len(t, body.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d", tc.desc, len(body.RecoveryCodes)))
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	secret, codes := enableTOTP(t, svc, user.Email)
+
+	req := testRequest{
+		client:      client,
+		method:      http.MethodPost,
+		url:         fmt.Sprintf("%s/tokens", ts.URL),
+		contentType: contentType,
+		body:        strings.NewReader(toJSON(user)),
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("login got unexpected error: %s", err))
+	require.Equal(t, http.StatusAccepted, res.StatusCode, fmt.Sprintf("login with TOTP enabled: expected status code %d got %d", http.StatusAccepted, res.StatusCode))
+	var login tokenRes
+	err = json.NewDecoder(res.Body).Decode(&login)
+	require.Nil(t, err, fmt.Sprintf("login got unexpected error: %s", err))
+	require.Empty(t, login.Token, "login with TOTP enabled: expected no access token")
+	preAuth := login.PreAuthToken
+
+	tokenData := toJSON(tokenRes{Token: user.Email, RefreshToken: mocks.RefreshPrefix + user.Email})
+	data := toJSON(verifyTOTPReq{PreAuthToken: preAuth, Code: totp.Generate(secret, time.Now().Add(totp.Period))})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+		res         string
+	}{
+		{"verify TOTP with valid code", data, contentType, http.StatusCreated, tokenData},
+		{"verify TOTP with reused code", data, contentType, http.StatusForbidden, unauthRes},
+		{"verify TOTP with recovery code", toJSON(verifyTOTPReq{PreAuthToken: preAuth, Code: codes[0]}), contentType, http.StatusCreated, tokenData},
+		{"verify TOTP with invalid pre-auth token", toJSON(verifyTOTPReq{PreAuthToken: wrongValue, Code: codes[1]}), contentType, http.StatusForbidden, unauthRes},
+		{"verify TOTP with empty code", toJSON(verifyTOTPReq{PreAuthToken: preAuth}), contentType, http.StatusBadRequest, malformedRes},
+		{"verify TOTP with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"verify TOTP with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens/totp", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		token := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
+	}
+}
+
+func TestResetTOTP(t *testing.T) {
+	admin := users.User{Email: "admin@example.com", Password: validPass}
+	other := users.User{Email: "other@example.com", Password: validPass}
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email, admin.Email: admin.Email, other.Email: other.Email})
+	svc := users.New(mocks.NewUserRepository(), mocks.NewTOTPRepository(), bcrypt.New(), mocks.NewCipher(), auth, mocks.NewEmailer(), uuid.New(), passRegex, nil, admin.Email)
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	userID, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), other)
+	require.Nil(t, err, fmt.Sprintf("register user got unexpected error: %s", err))
+	enableTOTP(t, svc, user.Email)
+	// Policy over the user doesn't make the other user platform administrator.
+	_, err = auth.AddPolicy(context.Background(), &mainflux.PolicyReq{Sub: other.Email, Obj: userID, Act: "admin"})
+	require.Nil(t, err, fmt.Sprintf("add policy got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"reset TOTP with invalid token", wrongValue, http.StatusForbidden},
+		{"reset TOTP without admin rights", user.Email, http.StatusForbidden},
+		{"reset TOTP with admin policy over the user", other.Email, http.StatusForbidden},
+		{"reset TOTP", admin.Email, http.StatusNoContent},
+		{"reset disabled TOTP", admin.Email, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/users/%s/totp", ts.URL, userID),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 type errorRes struct {
 	Err string `json:"error"`
 }
+
+type tokenRes struct {
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	PreAuthToken string `json:"pre_auth_token,omitempty"`
+}
+
+type enrolTOTPRes struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type confirmTOTPReq struct {
+	Code string `json:"code"`
+}
+
+type confirmTOTPRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type verifyTOTPReq struct {
+	PreAuthToken string `json:"pre_auth_token"`
+	Code         string `json:"code"`
+}
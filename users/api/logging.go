@@ -38,7 +38,7 @@ func (lm *loggingMiddleware) Register(ctx context.Context, user users.User) (uid
 	return lm.svc.Register(ctx, user)
 }
 
-func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token string, err error) {
+func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (tokens users.Tokens, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method login for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
@@ -51,6 +51,32 @@ func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token
 	return lm.svc.Login(ctx, user)
 }
 
+func (lm *loggingMiddleware) Refresh(ctx context.Context, refreshToken string) (tokens users.Tokens, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method refresh took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Refresh(ctx, refreshToken)
+}
+
+func (lm *loggingMiddleware) Logout(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method logout took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Logout(ctx, token)
+}
+
 func (lm *loggingMiddleware) ViewUser(ctx context.Context, token, id string) (u users.User, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_user for user %s took %s to complete", u.Email, time.Since(begin))
@@ -167,3 +193,81 @@ func (lm *loggingMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return lm.svc.ListMembers(ctx, token, groupID, offset, limit, m)
 }
+
+func (lm *loggingMiddleware) OIDCAuthorize(ctx context.Context, provider string) (req users.AuthRequest, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_authorize for provider %s took %s to complete", provider, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OIDCAuthorize(ctx, provider)
+}
+
+func (lm *loggingMiddleware) OIDCLogin(ctx context.Context, provider, state, code string, req users.AuthRequest) (tokens users.Tokens, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oidc_login for provider %s took %s to complete", provider, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OIDCLogin(ctx, provider, state, code, req)
+}
+
+func (lm *loggingMiddleware) EnrolTOTP(ctx context.Context, token string) (e users.TOTPEnrolment, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enrol_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnrolTOTP(ctx, token)
+}
+
+func (lm *loggingMiddleware) ConfirmTOTP(ctx context.Context, token, code string) (codes []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method confirm_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConfirmTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) VerifyTOTP(ctx context.Context, preAuthToken, code string) (tokens users.Tokens, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method verify_totp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.VerifyTOTP(ctx, preAuthToken, code)
+}
+
+func (lm *loggingMiddleware) ResetTOTP(ctx context.Context, token, userID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method reset_totp for user %s took %s to complete", userID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ResetTOTP(ctx, token, userID)
+}
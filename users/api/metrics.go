@@ -37,7 +37,7 @@ func (ms *metricsMiddleware) Register(ctx context.Context, user users.User) (str
 	return ms.svc.Register(ctx, user)
 }
 
-func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (string, error) {
+func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (users.Tokens, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "login").Add(1)
 		ms.latency.With("method", "login").Observe(time.Since(begin).Seconds())
@@ -46,6 +46,24 @@ func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (string
 	return ms.svc.Login(ctx, user)
 }
 
+func (ms *metricsMiddleware) Refresh(ctx context.Context, refreshToken string) (users.Tokens, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "refresh").Add(1)
+		ms.latency.With("method", "refresh").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Refresh(ctx, refreshToken)
+}
+
+func (ms *metricsMiddleware) Logout(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "logout").Add(1)
+		ms.latency.With("method", "logout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Logout(ctx, token)
+}
+
 func (ms *metricsMiddleware) ViewUser(ctx context.Context, token, id string) (users.User, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_user").Add(1)
@@ -126,3 +144,57 @@ func (ms *metricsMiddleware) ListMembers(ctx context.Context, token, groupID str
 
 	return ms.svc.ListMembers(ctx, token, groupID, offset, limit, gm)
 }
+
+func (ms *metricsMiddleware) OIDCAuthorize(ctx context.Context, provider string) (users.AuthRequest, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_authorize").Add(1)
+		ms.latency.With("method", "oidc_authorize").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OIDCAuthorize(ctx, provider)
+}
+
+func (ms *metricsMiddleware) OIDCLogin(ctx context.Context, provider, state, code string, req users.AuthRequest) (users.Tokens, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oidc_login").Add(1)
+		ms.latency.With("method", "oidc_login").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OIDCLogin(ctx, provider, state, code, req)
+}
+
+func (ms *metricsMiddleware) EnrolTOTP(ctx context.Context, token string) (users.TOTPEnrolment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enrol_totp").Add(1)
+		ms.latency.With("method", "enrol_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnrolTOTP(ctx, token)
+}
+
+func (ms *metricsMiddleware) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "confirm_totp").Add(1)
+		ms.latency.With("method", "confirm_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ConfirmTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) VerifyTOTP(ctx context.Context, preAuthToken, code string) (users.Tokens, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_totp").Add(1)
+		ms.latency.With("method", "verify_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.VerifyTOTP(ctx, preAuthToken, code)
+}
+
+func (ms *metricsMiddleware) ResetTOTP(ctx context.Context, token, userID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "reset_totp").Add(1)
+		ms.latency.With("method", "reset_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ResetTOTP(ctx, token, userID)
+}
@@ -16,6 +16,28 @@ func (req userReq) validate() error {
 	return req.user.Validate()
 }
 
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (req refreshReq) validate() error {
+	if req.RefreshToken == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type logoutReq struct {
+	token string
+}
+
+func (req logoutReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
 type viewUserReq struct {
 	token  string
 	userID string
@@ -121,3 +143,89 @@ func (req listMemberGroupReq) validate() error {
 
 	return nil
 }
+
+type oidcAuthorizeReq struct {
+	provider string
+}
+
+func (req oidcAuthorizeReq) validate() error {
+	if req.provider == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type oidcLoginReq struct {
+	provider string
+	state    string
+	code     string
+	err      string
+	authReq  *users.AuthRequest
+}
+
+func (req oidcLoginReq) validate() error {
+	// Provider redirects with an error if the user denied the access.
+	if req.err != "" || req.authReq == nil {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.provider == "" || req.state == "" || req.code == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type enrolTOTPReq struct {
+	token string
+}
+
+func (req enrolTOTPReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	return nil
+}
+
+type confirmTOTPReq struct {
+	token string
+	Code  string `json:"code"`
+}
+
+func (req confirmTOTPReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.Code == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type verifyTOTPReq struct {
+	PreAuthToken string `json:"pre_auth_token"`
+	Code         string `json:"code"`
+}
+
+func (req verifyTOTPReq) validate() error {
+	if req.PreAuthToken == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.Code == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
+
+type resetTOTPReq struct {
+	token  string
+	userID string
+}
+
+func (req resetTOTPReq) validate() error {
+	if req.token == "" {
+		return users.ErrUnauthorizedAccess
+	}
+	if req.userID == "" {
+		return users.ErrMalformedEntity
+	}
+	return nil
+}
@@ -8,10 +8,16 @@ import (
 	"net/http"
 
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/users"
 )
 
 var (
 	_ mainflux.Response = (*tokenRes)(nil)
+	_ mainflux.Response = (*logoutRes)(nil)
+	_ mainflux.Response = (*oidcAuthorizeRes)(nil)
+	_ mainflux.Response = (*oidcTokenRes)(nil)
+	_ mainflux.Response = (*enrolTOTPRes)(nil)
+	_ mainflux.Response = (*confirmTOTPRes)(nil)
 	_ mainflux.Response = (*viewUserRes)(nil)
 	_ mainflux.Response = (*passwChangeRes)(nil)
 	_ mainflux.Response = (*updateGroupRes)(nil)
@@ -60,10 +66,17 @@ func (res createUserRes) Empty() bool {
 }
 
 type tokenRes struct {
-	Token string `json:"token,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	PreAuthToken string `json:"pre_auth_token,omitempty"`
 }
 
+// Code returns 202 Accepted if the user has to complete the second login
+// step using the pre-auth token.
 func (res tokenRes) Code() int {
+	if res.Token == "" && res.PreAuthToken != "" {
+		return http.StatusAccepted
+	}
 	return http.StatusCreated
 }
 
@@ -72,7 +85,88 @@ func (res tokenRes) Headers() map[string]string {
 }
 
 func (res tokenRes) Empty() bool {
-	return res.Token == ""
+	return res.Token == "" && res.PreAuthToken == ""
+}
+
+// oidcAuthorizeRes redirects the user to the OpenID Connect provider,
+// keeping the authorization request in the cookie.
+type oidcAuthorizeRes struct {
+	provider string
+	authReq  users.AuthRequest
+}
+
+func (res oidcAuthorizeRes) Code() int {
+	return http.StatusFound
+}
+
+func (res oidcAuthorizeRes) Headers() map[string]string {
+	return map[string]string{
+		"Location":   res.authReq.URL,
+		"Set-Cookie": authCookie(res.provider, res.authReq).String(),
+	}
+}
+
+func (res oidcAuthorizeRes) Empty() bool {
+	return true
+}
+
+// oidcTokenRes returns the session tokens, removing the authorization
+// request cookie.
+type oidcTokenRes struct {
+	tokenRes
+}
+
+func (res oidcTokenRes) Headers() map[string]string {
+	return map[string]string{
+		"Set-Cookie": expiredAuthCookie().String(),
+	}
+}
+
+type enrolTOTPRes struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func (res enrolTOTPRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrolTOTPRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrolTOTPRes) Empty() bool {
+	return false
+}
+
+type confirmTOTPRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res confirmTOTPRes) Code() int {
+	return http.StatusOK
+}
+
+func (res confirmTOTPRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res confirmTOTPRes) Empty() bool {
+	return false
+}
+
+type logoutRes struct{}
+
+func (res logoutRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res logoutRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res logoutRes) Empty() bool {
+	return true
 }
 
 type updateUserRes struct{}
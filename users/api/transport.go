@@ -5,10 +5,12 @@ package api
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
@@ -29,6 +31,15 @@ const (
 	metadataKey = "metadata"
 	defOffset   = 0
 	defLimit    = 10
+
+	providerKey = "provider"
+	stateKey    = "state"
+	codeKey     = "code"
+	errorKey    = "error"
+
+	authCookieName = "mf_oidc"
+	authCookiePath = "/oidc"
+	authCookieTTL  = 10 * time.Minute
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
@@ -53,6 +64,27 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Post("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enrol_totp")(enrolTOTPEndpoint(svc)),
+		decodeEnrolTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "confirm_totp")(confirmTOTPEndpoint(svc)),
+		decodeConfirmTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/users/:userID/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "reset_totp")(resetTOTPEndpoint(svc)),
+		decodeResetTOTP,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/users/:userID", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_user")(viewUserEndpoint(svc)),
 		decodeViewUser,
@@ -109,6 +141,41 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer) http.Handler {
 		opts...,
 	))
 
+	mux.Post("/tokens/refresh", kithttp.NewServer(
+		kitot.TraceServer(tracer, "refresh")(refreshEndpoint(svc)),
+		decodeRefresh,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/tokens/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "verify_totp")(verifyTOTPEndpoint(svc)),
+		decodeVerifyTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/logout", kithttp.NewServer(
+		kitot.TraceServer(tracer, "logout")(logoutEndpoint(svc)),
+		decodeLogout,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/oidc/:provider", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oidc_authorize")(oidcAuthorizeEndpoint(svc)),
+		decodeOIDCAuthorize,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/oidc/:provider/callback", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oidc_login")(oidcLoginEndpoint(svc)),
+		decodeOIDCLogin,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/version", mainflux.Version("users"))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -187,6 +254,153 @@ func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error)
 	return userReq{user}, nil
 }
 
+func decodeRefresh(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	var req refreshReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeLogout(_ context.Context, r *http.Request) (interface{}, error) {
+	req := logoutReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func decodeEnrolTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	req := enrolTOTPReq{
+		token: r.Header.Get("Authorization"),
+	}
+	return req, nil
+}
+
+func decodeConfirmTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	var req confirmTOTPReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	req.token = r.Header.Get("Authorization")
+	return req, nil
+}
+
+func decodeVerifyTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, errors.ErrUnsupportedContentType
+	}
+
+	var req verifyTOTPReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeResetTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	req := resetTOTPReq{
+		token:  r.Header.Get("Authorization"),
+		userID: bone.GetValue(r, "userID"),
+	}
+	return req, nil
+}
+
+func decodeOIDCAuthorize(_ context.Context, r *http.Request) (interface{}, error) {
+	req := oidcAuthorizeReq{
+		provider: bone.GetValue(r, providerKey),
+	}
+	return req, nil
+}
+
+func decodeOIDCLogin(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := oidcLoginReq{
+		provider: bone.GetValue(r, providerKey),
+		state:    q.Get(stateKey),
+		code:     q.Get(codeKey),
+		err:      q.Get(errorKey),
+	}
+
+	if c, err := r.Cookie(authCookieName); err == nil {
+		if authReq, ok := parseAuthCookie(c, req.provider); ok {
+			req.authReq = &authReq
+		}
+	}
+
+	return req, nil
+}
+
+// authCookieValue represents the OpenID Connect authorization request kept
+// in the cookie until the provider redirects the user back.
+type authCookieValue struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// authCookie binds the authorization request to the user agent. Cookie is
+// sent with the provider redirect, which is a top-level navigation, so it
+// is restricted using lax same-site policy.
+func authCookie(provider string, req users.AuthRequest) *http.Cookie {
+	v, _ := json.Marshal(authCookieValue{
+		Provider: provider,
+		State:    req.State,
+		Nonce:    req.Nonce,
+		Verifier: req.Verifier,
+	})
+
+	return &http.Cookie{
+		Name:     authCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(v),
+		Path:     authCookiePath,
+		MaxAge:   int(authCookieTTL.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func expiredAuthCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     authCookieName,
+		Path:     authCookiePath,
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func parseAuthCookie(c *http.Cookie, provider string) (users.AuthRequest, bool) {
+	b, err := base64.RawURLEncoding.DecodeString(c.Value)
+	if err != nil {
+		return users.AuthRequest{}, false
+	}
+
+	var v authCookieValue
+	if err := json.Unmarshal(b, &v); err != nil || v.Provider != provider {
+		return users.AuthRequest{}, false
+	}
+
+	return users.AuthRequest{
+		State:    v.State,
+		Nonce:    v.Nonce,
+		Verifier: v.Verifier,
+	}, true
+}
+
 func decodePasswordResetRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, errors.ErrUnsupportedContentType
@@ -304,6 +518,18 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 			w.WriteHeader(http.StatusNotFound)
 		case errors.Contains(errorVal, users.ErrPasswordFormat):
 			w.WriteHeader(http.StatusBadRequest)
+		case errors.Contains(errorVal, users.ErrUnknownProvider):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Contains(errorVal, users.ErrUnverifiedEmail):
+			w.WriteHeader(http.StatusForbidden)
+		case errors.Contains(errorVal, users.ErrTOTPEnabled):
+			w.WriteHeader(http.StatusConflict)
+		case errors.Contains(errorVal, users.ErrTOTPLocked):
+			w.WriteHeader(http.StatusTooManyRequests)
+		case errors.Contains(errorVal, users.ErrTOTPUnavailable):
+			w.WriteHeader(http.StatusNotImplemented)
+		case errors.Contains(errorVal, users.ErrNotFound):
+			w.WriteHeader(http.StatusNotFound)
 		default:
 			w.WriteHeader(http.StatusInternalServerError)
 		}
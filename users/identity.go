@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// randomSize is the number of random bytes used for state, nonce and PKCE
+// code verifier of the authorization request.
+const randomSize = 32
+
+// Identity represents the user account at the OpenID Connect provider,
+// as asserted by the ID token the provider issued.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Metadata      Metadata
+}
+
+// AuthRequest represents the OpenID Connect authorization request. The user
+// is redirected to the URL, while the state, nonce and PKCE code verifier
+// are kept by the user agent until the provider redirects it back.
+type AuthRequest struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// IdentityProvider authenticates users using the OpenID Connect
+// authorization code flow.
+type IdentityProvider interface {
+	// AuthURL returns the URL of the provider's authorization endpoint
+	// the user is redirected to. The nonce is bound to the issued ID token,
+	// while the code verifier is sent as the PKCE challenge.
+	AuthURL(state, nonce, verifier string) string
+
+	// Exchange exchanges the authorization code for the ID token, returning
+	// the identity it asserts once the token is verified.
+	Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error)
+}
+
+func newAuthRequest() (AuthRequest, error) {
+	var req AuthRequest
+	for _, v := range []*string{&req.State, &req.Nonce, &req.Verifier} {
+		b := make([]byte, randomSize)
+		if _, err := rand.Read(b); err != nil {
+			return AuthRequest{}, err
+		}
+		*v = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return req, nil
+}
@@ -5,34 +5,74 @@ package mocks
 
 import (
 	"context"
+	"strings"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/auth"
 	"github.com/mainflux/mainflux/users"
 	"google.golang.org/grpc"
 )
 
+const (
+	// RefreshPrefix is prepended to the user token to form the mocked refresh token.
+	RefreshPrefix = "refresh-"
+
+	// PreAuthPrefix is prepended to the user token to form the mocked pre-auth token.
+	PreAuthPrefix = "pre-auth-"
+)
+
 var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
 type authServiceMock struct {
 	users map[string]string
+
+	mu       sync.Mutex
+	preAuth  map[string]*mainflux.UserIdentity
+	policies map[string]map[string]bool
 }
 
 // NewAuthService creates mock of users service.
 func NewAuthService(users map[string]string) mainflux.AuthServiceClient {
-	return &authServiceMock{users}
+	return &authServiceMock{
+		users:    users,
+		preAuth:  make(map[string]*mainflux.UserIdentity),
+		policies: make(map[string]map[string]bool),
+	}
 }
 
-func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+func (svc *authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if id, ok := svc.users[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: id, Email: id}, nil
 	}
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+func (svc *authServiceMock) CanAccess(ctx context.Context, req *mainflux.AccessReq, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return svc.Identify(ctx, &mainflux.Token{Value: req.GetToken()})
+}
+
+func (svc *authServiceMock) IdentifyPreAuth(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if idt, ok := svc.preAuth[in.GetValue()]; ok {
+		return idt, nil
+	}
+	return nil, users.ErrUnauthorizedAccess
+}
+
+func (svc *authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
+		case auth.PreAuthKey:
+			svc.mu.Lock()
+			defer svc.mu.Unlock()
+
+			token := PreAuthPrefix + id
+			svc.preAuth[token] = &mainflux.UserIdentity{Id: in.GetId(), Email: in.GetEmail()}
+			return &mainflux.Token{Value: token}, nil
 		default:
 			return &mainflux.Token{Value: id}, nil
 		}
@@ -40,14 +80,73 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 	return nil, users.ErrUnauthorizedAccess
 }
 
-func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+func (svc *authServiceMock) Login(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	if id, ok := svc.users[in.GetEmail()]; ok {
+		return &mainflux.Tokens{AccessToken: id, RefreshToken: RefreshPrefix + id}, nil
+	}
+	return nil, users.ErrUnauthorizedAccess
+}
+
+func (svc *authServiceMock) Refresh(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.Tokens, error) {
+	if !strings.HasPrefix(in.GetValue(), RefreshPrefix) {
+		return nil, users.ErrUnauthorizedAccess
+	}
+	if id, ok := svc.users[strings.TrimPrefix(in.GetValue(), RefreshPrefix)]; ok {
+		return &mainflux.Tokens{AccessToken: id, RefreshToken: RefreshPrefix + id}, nil
+	}
+	return nil, users.ErrUnauthorizedAccess
+}
+
+func (svc *authServiceMock) Logout(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if _, ok := svc.users[strings.TrimPrefix(in.GetValue(), RefreshPrefix)]; ok {
+		return &empty.Empty{}, nil
+	}
+	return nil, users.ErrUnauthorizedAccess
+}
+
+func (svc *authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *mainflux.AuthorizeRes, err error) {
+	sub, ok := svc.users[req.GetToken()]
+	if !ok {
+		return nil, users.ErrUnauthorizedAccess
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	return &mainflux.AuthorizeRes{Authorized: svc.policies[sub][req.GetObj()+"/"+req.GetAct()]}, nil
+}
+
+func (svc *authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceMock) AddPolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	if _, ok := svc.policies[req.GetSub()]; !ok {
+		svc.policies[req.GetSub()] = make(map[string]bool)
+	}
+	svc.policies[req.GetSub()][req.GetObj()+"/"+req.GetAct()] = true
+	return &empty.Empty{}, nil
+}
+
+func (svc *authServiceMock) DeletePolicy(ctx context.Context, req *mainflux.PolicyReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc *authServiceMock) DeleteObjectPolicies(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
 
-func (svc authServiceMock) Members(ctx context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (r *mainflux.MembersRes, err error) {
+func (svc *authServiceMock) ClaimObject(ctx context.Context, req *mainflux.ObjectPoliciesReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
 	panic("not implemented")
 }
 
-func (svc authServiceMock) Assign(ctx context.Context, req *mainflux.Assignment, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+func (svc *authServiceMock) ListObjects(ctx context.Context, req *mainflux.ObjectsReq, _ ...grpc.CallOption) (r *mainflux.ObjectsRes, err error) {
 	panic("not implemented")
 }
@@ -0,0 +1,32 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"bytes"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.Cipher = (*cipherMock)(nil)
+
+type cipherMock struct{}
+
+// NewCipher creates "no-op" cipher for test purposes. This implementation
+// prepends the additional data to the plaintext instead of encrypting it.
+func NewCipher() users.Cipher {
+	return &cipherMock{}
+}
+
+func (cm *cipherMock) Encrypt(plaintext, data []byte) ([]byte, error) {
+	return append(append([]byte{}, data...), plaintext...), nil
+}
+
+func (cm *cipherMock) Decrypt(ciphertext, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, data) {
+		return nil, users.ErrMalformedEntity
+	}
+
+	return ciphertext[len(data):], nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.IdentityProvider = (*identityProviderMock)(nil)
+
+type identityProviderMock struct {
+	url        string
+	identities map[string]users.Identity
+}
+
+// NewIdentityProvider creates mock of the OpenID Connect provider. Each code
+// of the identities map is exchanged for the corresponding identity,
+// provided that the code verifier and nonce match the code.
+func NewIdentityProvider(url string, identities map[string]users.Identity) users.IdentityProvider {
+	return &identityProviderMock{
+		url:        url,
+		identities: identities,
+	}
+}
+
+func (ipm *identityProviderMock) AuthURL(state, nonce, verifier string) string {
+	q := url.Values{}
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", verifier)
+	return fmt.Sprintf("%s?%s", ipm.url, q.Encode())
+}
+
+func (ipm *identityProviderMock) Exchange(_ context.Context, code, verifier, nonce string) (users.Identity, error) {
+	idt, ok := ipm.identities[code]
+	if !ok || verifier == "" || nonce == "" {
+		return users.Identity{}, users.ErrUnauthorizedAccess
+	}
+	return idt, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/users"
+)
+
+var _ users.TOTPRepository = (*totpRepositoryMock)(nil)
+
+type totpRepositoryMock struct {
+	mu    sync.Mutex
+	totp  map[string]users.TOTP
+	codes map[string]map[string]bool
+}
+
+// NewTOTPRepository creates in-memory TOTP repository.
+func NewTOTPRepository() users.TOTPRepository {
+	return &totpRepositoryMock{
+		totp:  make(map[string]users.TOTP),
+		codes: make(map[string]map[string]bool),
+	}
+}
+
+func (trm *totpRepositoryMock) Save(ctx context.Context, t users.TOTP) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if saved, ok := trm.totp[t.UserID]; ok && saved.Enabled {
+		return users.ErrTOTPEnabled
+	}
+
+	trm.totp[t.UserID] = users.TOTP{UserID: t.UserID, Secret: t.Secret}
+	return nil
+}
+
+func (trm *totpRepositoryMock) Retrieve(ctx context.Context, userID string) (users.TOTP, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totp[userID]
+	if !ok {
+		return users.TOTP{}, users.ErrNotFound
+	}
+	return t, nil
+}
+
+func (trm *totpRepositoryMock) Enable(ctx context.Context, userID string, step int64) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totp[userID]
+	if !ok || t.Enabled || t.LastStep >= step {
+		return users.ErrNotFound
+	}
+
+	t.Enabled = true
+	t.LastStep = step
+	trm.totp[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) UseStep(ctx context.Context, userID string, step int64) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totp[userID]
+	if !ok || t.LastStep >= step {
+		return users.ErrNotFound
+	}
+
+	t.LastStep = step
+	trm.totp[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) Fail(ctx context.Context, userID string) (int, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totp[userID]
+	if !ok {
+		return 0, users.ErrNotFound
+	}
+
+	t.Failures++
+	trm.totp[userID] = t
+	return t.Failures, nil
+}
+
+func (trm *totpRepositoryMock) Lock(ctx context.Context, userID string, until time.Time) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totp[userID]
+	if !ok {
+		return users.ErrNotFound
+	}
+
+	t.Failures = 0
+	t.LockedUntil = until
+	trm.totp[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) ResetFailures(ctx context.Context, userID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	t, ok := trm.totp[userID]
+	if !ok {
+		return users.ErrNotFound
+	}
+
+	t.Failures = 0
+	trm.totp[userID] = t
+	return nil
+}
+
+func (trm *totpRepositoryMock) Remove(ctx context.Context, userID string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.totp[userID]; !ok {
+		return users.ErrNotFound
+	}
+
+	delete(trm.totp, userID)
+	delete(trm.codes, userID)
+	return nil
+}
+
+func (trm *totpRepositoryMock) SaveRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if _, ok := trm.totp[userID]; !ok {
+		return users.ErrNotFound
+	}
+
+	trm.codes[userID] = make(map[string]bool)
+	for _, h := range hashes {
+		trm.codes[userID][h] = true
+	}
+	return nil
+}
+
+func (trm *totpRepositoryMock) RemoveRecoveryCode(ctx context.Context, userID, hash string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	if !trm.codes[userID][hash] {
+		return users.ErrNotFound
+	}
+
+	delete(trm.codes[userID], hash)
+	return nil
+}
@@ -17,6 +17,7 @@ type userRepositoryMock struct {
 	users          map[string]users.User
 	usersByID      map[string]users.User
 	usersByGroupID map[string]users.User
+	identities     map[string]string
 }
 
 // NewUserRepository creates in-memory user repository
@@ -25,6 +26,7 @@ func NewUserRepository() users.UserRepository {
 		users:          make(map[string]users.User),
 		usersByID:      make(map[string]users.User),
 		usersByGroupID: make(map[string]users.User),
+		identities:     make(map[string]string),
 	}
 }
 
@@ -119,3 +121,35 @@ func (urm *userRepositoryMock) UpdatePassword(_ context.Context, token, password
 	}
 	return nil
 }
+
+func (urm *userRepositoryMock) SaveIdentity(_ context.Context, userID string, idt users.Identity) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	if _, ok := urm.usersByID[userID]; !ok {
+		return users.ErrNotFound
+	}
+	key := identityKey(idt.Provider, idt.Subject)
+	if _, ok := urm.identities[key]; ok {
+		return users.ErrConflict
+	}
+
+	urm.identities[key] = userID
+	return nil
+}
+
+func (urm *userRepositoryMock) RetrieveByIdentity(_ context.Context, provider, subject string) (users.User, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	id, ok := urm.identities[identityKey(provider, subject)]
+	if !ok {
+		return users.User{}, users.ErrNotFound
+	}
+
+	return urm.usersByID[id], nil
+}
+
+func identityKey(provider, subject string) string {
+	return provider + "/" + subject
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mainflux/mainflux/auth"
+	"github.com/mainflux/mainflux/auth/jwt"
+	"github.com/mainflux/mainflux/pkg/errors"
+)
+
+const (
+	ktyRSA = "RSA"
+
+	// minRefresh limits how often the keys are fetched when the token is
+	// signed with an unknown key.
+	minRefresh = time.Minute
+)
+
+var (
+	errFetchKeys  = errors.New("failed to fetch provider keys")
+	errUnknownKey = errors.New("unknown signing key")
+)
+
+// keySet caches the provider signing keys, fetching them again when the
+// provider starts using a new key.
+type keySet struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	keys    []auth.PublicKey
+	fetched time.Time
+}
+
+func newKeySet(url string, client *http.Client) *keySet {
+	return &keySet{
+		url:    url,
+		client: client,
+	}
+}
+
+func (ks *keySet) key(ctx context.Context, id string) (auth.PublicKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if pk, ok := ks.find(id); ok {
+		return pk, nil
+	}
+	if time.Since(ks.fetched) < minRefresh {
+		return auth.PublicKey{}, errUnknownKey
+	}
+
+	if err := ks.fetch(ctx); err != nil {
+		return auth.PublicKey{}, err
+	}
+
+	if pk, ok := ks.find(id); ok {
+		return pk, nil
+	}
+	return auth.PublicKey{}, errUnknownKey
+}
+
+// find returns the key with given ID. Tokens without key ID are accepted
+// only if the provider has a single key.
+func (ks *keySet) find(id string) (auth.PublicKey, bool) {
+	if id == "" {
+		if len(ks.keys) == 1 {
+			return ks.keys[0], true
+		}
+		return auth.PublicKey{}, false
+	}
+
+	for _, pk := range ks.keys {
+		if pk.ID == id {
+			return pk, true
+		}
+	}
+	return auth.PublicKey{}, false
+}
+
+func (ks *keySet) fetch(ctx context.Context) error {
+	var set jwt.JWKS
+	if err := get(ctx, ks.client, ks.url, &set); err != nil {
+		return errors.Wrap(errFetchKeys, err)
+	}
+	ks.fetched = time.Now()
+
+	var keys []auth.PublicKey
+	for _, k := range set.Keys {
+		// Algorithm is optional, but RSA keys are used with RS256.
+		if k.Algorithm == "" && k.KeyType == ktyRSA {
+			k.Algorithm = jwt.AlgRS256
+		}
+		pk, err := k.PublicKey()
+		if err != nil {
+			// Skip keys that are not supported, such as encryption keys.
+			continue
+		}
+		keys = append(keys, pk)
+	}
+	ks.keys = keys
+
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc contains the OpenID Connect identity provider, used to log
+// users in with the authorization code flow.
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+const (
+	scopeOpenID  = "openid"
+	discoveryURL = "/.well-known/openid-configuration"
+
+	// maxBodySize limits the size of the provider responses.
+	maxBodySize = 1 << 20
+)
+
+var (
+	errDiscovery    = errors.New("failed to discover provider configuration")
+	errExchange     = errors.New("failed to exchange authorization code")
+	errInvalidToken = errors.New("invalid ID token")
+)
+
+// Config represents the OpenID Connect client configuration.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+
+	// Claims maps the names of the ID token claims to the user metadata keys.
+	Claims map[string]string
+}
+
+// metadata represents the subset of the provider metadata (OpenID Connect
+// Discovery 1.0, Section 3) used by the client.
+type metadata struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+var _ users.IdentityProvider = (*provider)(nil)
+
+type provider struct {
+	cfg      Config
+	client   *http.Client
+	authURL  string
+	tokenURL string
+	keys     *keySet
+}
+
+// New returns the identity provider for the OpenID Connect provider with
+// the configured issuer, whose endpoints are obtained using discovery.
+func New(ctx context.Context, cfg Config, client *http.Client) (users.IdentityProvider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var md metadata
+	if err := get(ctx, client, strings.TrimSuffix(cfg.Issuer, "/")+discoveryURL, &md); err != nil {
+		return nil, errors.Wrap(errDiscovery, err)
+	}
+	if md.Issuer != cfg.Issuer {
+		return nil, errors.Wrap(errDiscovery, fmt.Errorf("issuer %s does not match %s", md.Issuer, cfg.Issuer))
+	}
+	if md.AuthURL == "" || md.TokenURL == "" || md.JWKSURL == "" {
+		return nil, errors.Wrap(errDiscovery, fmt.Errorf("missing provider endpoints"))
+	}
+
+	return &provider{
+		cfg:      cfg,
+		client:   client,
+		authURL:  md.AuthURL,
+		tokenURL: md.TokenURL,
+		keys:     newKeySet(md.JWKSURL, client),
+	}, nil
+}
+
+func (p *provider) AuthURL(state, nonce, verifier string) string {
+	u, err := url.Parse(p.authURL)
+	if err != nil {
+		return p.authURL
+	}
+
+	scopes := []string{scopeOpenID}
+	for _, s := range p.cfg.Scopes {
+		if s != scopeOpenID {
+			scopes = append(scopes, s)
+		}
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", challenge(verifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
+
+func (p *provider) Exchange(ctx context.Context, code, verifier, nonce string) (users.Identity, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequest(http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return users.Identity{}, errors.Wrap(errExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	res, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return users.Identity{}, errors.Wrap(errExchange, err)
+	}
+	defer res.Body.Close()
+
+	var tr struct {
+		IDToken   string `json:"id_token"`
+		Error     string `json:"error"`
+		ErrorDesc string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxBodySize)).Decode(&tr); err != nil {
+		return users.Identity{}, errors.Wrap(errExchange, err)
+	}
+	if res.StatusCode != http.StatusOK || tr.Error != "" {
+		return users.Identity{}, errors.Wrap(errExchange, fmt.Errorf("status %d: %s %s", res.StatusCode, tr.Error, tr.ErrorDesc))
+	}
+
+	return p.verify(ctx, tr.IDToken, nonce)
+}
+
+// verify validates the ID token as specified by OpenID Connect Core 1.0,
+// Section 3.1.3.7. Since the token is received from the token endpoint
+// directly, the token endpoint TLS server validation is relied on.
+func (p *provider) verify(ctx context.Context, token, nonce string) (users.Identity, error) {
+	claims := jwtgo.MapClaims{}
+	_, err := jwtgo.ParseWithClaims(token, claims, func(t *jwtgo.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		pk, err := p.keys.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if t.Method.Alg() != pk.Algorithm {
+			return nil, errInvalidToken
+		}
+		return pk.Key, nil
+	})
+	if err != nil {
+		return users.Identity{}, errors.Wrap(errInvalidToken, err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != p.cfg.Issuer {
+		return users.Identity{}, errors.Wrap(errInvalidToken, fmt.Errorf("unexpected issuer %s", iss))
+	}
+	if !p.audience(claims) {
+		return users.Identity{}, errors.Wrap(errInvalidToken, fmt.Errorf("unexpected audience"))
+	}
+	if _, ok := claims["exp"]; !ok {
+		return users.Identity{}, errors.Wrap(errInvalidToken, fmt.Errorf("missing expiration time"))
+	}
+	if n, _ := claims["nonce"].(string); nonce == "" || n != nonce {
+		return users.Identity{}, errors.Wrap(errInvalidToken, fmt.Errorf("unexpected nonce"))
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return users.Identity{}, errors.Wrap(errInvalidToken, fmt.Errorf("missing subject"))
+	}
+	email, _ := claims["email"].(string)
+
+	md := users.Metadata{}
+	for claim, key := range p.cfg.Claims {
+		if v, ok := claims[claim]; ok {
+			md[key] = v
+		}
+	}
+
+	return users.Identity{
+		Subject:       sub,
+		Email:         email,
+		EmailVerified: verified(claims["email_verified"]),
+		Metadata:      md,
+	}, nil
+}
+
+// audience checks that the token is issued to the client. If there are
+// multiple audiences, the client must be the authorized party as well.
+func (p *provider) audience(claims jwtgo.MapClaims) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == p.cfg.ClientID
+	case []interface{}:
+		if len(aud) > 1 {
+			if azp, _ := claims["azp"].(string); azp != p.cfg.ClientID {
+				return false
+			}
+		}
+		for _, a := range aud {
+			if a == p.cfg.ClientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verified reads the email_verified claim, which some providers send as
+// a string.
+func verified(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+// challenge returns the S256 PKCE code challenge (RFC 7636).
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func get(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, maxBodySize)).Decode(v)
+}
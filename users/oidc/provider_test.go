@@ -0,0 +1,331 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID     = "client"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost/oidc/mock/callback"
+	keyID        = "key"
+	subject      = "248289761001"
+	email        = "jane.doe@example.com"
+)
+
+// authorization represents the authorization request the mock provider
+// issued the code for.
+type authorization struct {
+	nonce     string
+	challenge string
+	claims    jwtgo.MapClaims
+}
+
+// mockProvider is a local OpenID Connect provider, which issues the code
+// for each authorization request right away.
+type mockProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	next  int
+	codes map[string]authorization
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+
+	mp := &mockProvider{
+		key:   key,
+		codes: make(map[string]authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", mp.discovery)
+	mux.HandleFunc("/keys", mp.jwks)
+	mux.HandleFunc("/token", mp.token)
+	mp.Server = httptest.NewServer(mux)
+
+	return mp
+}
+
+// authorize simulates the user authorizing the request with the given
+// authorization URL, returning the issued code.
+func (mp *mockProvider) authorize(t *testing.T, authURL string, claims jwtgo.MapClaims) string {
+	u, err := url.Parse(authURL)
+	require.Nil(t, err, fmt.Sprintf("parsing authorization URL expected to succeed: %s", err))
+	q := u.Query()
+	require.Equal(t, clientID, q.Get("client_id"), "unexpected client ID")
+	require.Equal(t, "S256", q.Get("code_challenge_method"), "unexpected code challenge method")
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.next++
+	code := fmt.Sprintf("code-%d", mp.next)
+	mp.codes[code] = authorization{
+		nonce:     q.Get("nonce"),
+		challenge: q.Get("code_challenge"),
+		claims:    claims,
+	}
+	return code
+}
+
+func (mp *mockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 mp.URL,
+		"authorization_endpoint": mp.URL + "/authorize",
+		"token_endpoint":         mp.URL + "/token",
+		"jwks_uri":               mp.URL + "/keys",
+	})
+}
+
+func (mp *mockProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := mp.key.PublicKey
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": keyID,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (mp *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != clientID || secret != clientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	mp.mu.Lock()
+	auth, ok := mp.codes[r.PostFormValue("code")]
+	delete(mp.codes, r.PostFormValue("code"))
+	mp.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok || r.PostFormValue("redirect_uri") != redirectURL || base64.RawURLEncoding.EncodeToString(sum[:]) != auth.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwtgo.MapClaims{
+		"iss":   mp.URL,
+		"sub":   subject,
+		"aud":   clientID,
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": auth.nonce,
+	}
+	for k, v := range auth.claims {
+		claims[k] = v
+	}
+	token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(mp.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func newProvider(t *testing.T, mp *mockProvider) users.IdentityProvider {
+	cfg := oidc.Config{
+		Issuer:       mp.URL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"email", "profile"},
+		Claims:       map[string]string{"name": "name", "groups": "roles"},
+	}
+	p, err := oidc.New(context.Background(), cfg, mp.Client())
+	require.Nil(t, err, fmt.Sprintf("creating provider expected to succeed: %s", err))
+	return p
+}
+
+func TestNew(t *testing.T) {
+	mp := newMockProvider(t)
+	defer mp.Close()
+
+	cases := []struct {
+		desc   string
+		issuer string
+		err    bool
+	}{
+		{
+			desc:   "discover provider",
+			issuer: mp.URL,
+			err:    false,
+		},
+		{
+			desc:   "discover provider with mismatched issuer",
+			issuer: mp.URL + "/other",
+			err:    true,
+		},
+		{
+			desc:   "discover unavailable provider",
+			issuer: "http://localhost:1",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := oidc.New(context.Background(), oidc.Config{Issuer: tc.issuer, ClientID: clientID}, mp.Client())
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+	}
+}
+
+func TestExchange(t *testing.T) {
+	mp := newMockProvider(t)
+	defer mp.Close()
+	p := newProvider(t, mp)
+
+	verified := jwtgo.MapClaims{"email": email, "email_verified": true, "name": "Jane Doe", "groups": []interface{}{"admins"}}
+
+	cases := []struct {
+		desc     string
+		claims   jwtgo.MapClaims
+		code     func(code string) string
+		verifier func(verifier string) string
+		nonce    func(nonce string) string
+		idt      users.Identity
+		err      bool
+	}{
+		{
+			desc:   "exchange code",
+			claims: verified,
+			idt: users.Identity{
+				Subject:       subject,
+				Email:         email,
+				EmailVerified: true,
+				Metadata:      users.Metadata{"name": "Jane Doe", "roles": []interface{}{"admins"}},
+			},
+			err: false,
+		},
+		{
+			desc:   "exchange code with unverified email",
+			claims: jwtgo.MapClaims{"email": email, "email_verified": "false"},
+			idt: users.Identity{
+				Subject:  subject,
+				Email:    email,
+				Metadata: users.Metadata{},
+			},
+			err: false,
+		},
+		{
+			desc:   "exchange invalid code",
+			claims: verified,
+			code:   func(string) string { return "invalid" },
+			err:    true,
+		},
+		{
+			desc:     "exchange code with invalid verifier",
+			claims:   verified,
+			verifier: func(string) string { return "invalid" },
+			err:      true,
+		},
+		{
+			desc:   "exchange code with invalid nonce",
+			claims: verified,
+			nonce:  func(string) string { return "invalid" },
+			err:    true,
+		},
+		{
+			desc:   "exchange code for token issued to other client",
+			claims: jwtgo.MapClaims{"aud": "other"},
+			err:    true,
+		},
+		{
+			desc:   "exchange code for token issued to multiple clients",
+			claims: jwtgo.MapClaims{"aud": []interface{}{clientID, "other"}, "azp": "other"},
+			err:    true,
+		},
+		{
+			desc:   "exchange code for token issued by other issuer",
+			claims: jwtgo.MapClaims{"iss": "https://other.example.com"},
+			err:    true,
+		},
+		{
+			desc:   "exchange code for expired token",
+			claims: jwtgo.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()},
+			err:    true,
+		},
+	}
+
+	for i, tc := range cases {
+		state, nonce, verifier := fmt.Sprintf("state-%d", i), fmt.Sprintf("nonce-%d", i), fmt.Sprintf("verifier-%d", i)
+		code := mp.authorize(t, p.AuthURL(state, nonce, verifier), tc.claims)
+		if tc.code != nil {
+			code = tc.code(code)
+		}
+		if tc.verifier != nil {
+			verifier = tc.verifier(verifier)
+		}
+		if tc.nonce != nil {
+			nonce = tc.nonce(nonce)
+		}
+
+		idt, err := p.Exchange(context.Background(), code, verifier, nonce)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if !tc.err {
+			assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.idt, idt))
+		}
+	}
+}
+
+func TestAuthURL(t *testing.T) {
+	mp := newMockProvider(t)
+	defer mp.Close()
+	p := newProvider(t, mp)
+
+	u, err := url.Parse(p.AuthURL("state", "nonce", "verifier"))
+	require.Nil(t, err, fmt.Sprintf("parsing authorization URL expected to succeed: %s", err))
+
+	sum := sha256.Sum256([]byte("verifier"))
+	expected := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {"state"},
+		"nonce":                 {"nonce"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	assert.Equal(t, mp.URL+"/authorize", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path), "unexpected authorization endpoint")
+	assert.Equal(t, expected, u.Query(), fmt.Sprintf("expected query %v got %v", expected, u.Query()))
+}
@@ -85,6 +85,40 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS owner_id`,
 				},
 			},
+			{
+				Id: "users_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS identities (
+					 provider VARCHAR(64)  NOT NULL,
+					 subject  VARCHAR(254) NOT NULL,
+					 user_id  UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+					 PRIMARY KEY (provider, subject)
+					)`,
+				},
+				Down: []string{"DROP TABLE identities"},
+			},
+			{
+				Id: "users_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS totp (
+					 user_id      UUID        PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+					 secret       BYTEA       NOT NULL,
+					 enabled      BOOLEAN     NOT NULL DEFAULT FALSE,
+					 last_step    BIGINT      NOT NULL DEFAULT 0,
+					 failures     INTEGER     NOT NULL DEFAULT 0,
+					 locked_until TIMESTAMPTZ
+					)`,
+					`CREATE TABLE IF NOT EXISTS totp_recovery_codes (
+					 user_id   UUID     NOT NULL REFERENCES totp (user_id) ON DELETE CASCADE,
+					 code_hash CHAR(64) NOT NULL,
+					 PRIMARY KEY (user_id, code_hash)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE totp_recovery_codes",
+					"DROP TABLE totp",
+				},
+			},
 		},
 	}
 
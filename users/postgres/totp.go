@@ -0,0 +1,238 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+)
+
+var (
+	errSaveTOTPDB          = errors.New("Save TOTP to DB failed")
+	errUpdateTOTPDB        = errors.New("Update TOTP in DB failed")
+	errRemoveTOTPDB        = errors.New("Remove TOTP from DB failed")
+	errSaveRecoveryCodesDB = errors.New("Save recovery codes to DB failed")
+)
+
+var _ users.TOTPRepository = (*totpRepository)(nil)
+
+type totpRepository struct {
+	db Database
+}
+
+// NewTOTPRepo instantiates a PostgreSQL implementation of TOTP
+// repository.
+func NewTOTPRepo(db Database) users.TOTPRepository {
+	return &totpRepository{
+		db: db,
+	}
+}
+
+func (tr totpRepository) Save(ctx context.Context, t users.TOTP) error {
+	q := `INSERT INTO totp (user_id, secret) VALUES (:user_id, :secret)
+	      ON CONFLICT (user_id) DO UPDATE SET secret = :secret, last_step = 0, failures = 0, locked_until = NULL
+	      WHERE NOT totp.enabled`
+
+	res, err := tr.db.NamedExecContext(ctx, q, toDBTOTP(t))
+	if err != nil {
+		return errors.Wrap(errSaveTOTPDB, mapError(err))
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errSaveTOTPDB, err)
+	}
+	if cnt == 0 {
+		return users.ErrTOTPEnabled
+	}
+
+	return nil
+}
+
+func (tr totpRepository) Retrieve(ctx context.Context, userID string) (users.TOTP, error) {
+	q := `SELECT user_id, secret, enabled, last_step, failures, locked_until FROM totp WHERE user_id = $1`
+
+	dbt := dbTOTP{}
+	if err := tr.db.QueryRowxContext(ctx, q, userID).StructScan(&dbt); err != nil {
+		if err == sql.ErrNoRows {
+			return users.TOTP{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		return users.TOTP{}, errors.Wrap(errRetrieveDB, mapError(err))
+	}
+
+	return toTOTP(dbt), nil
+}
+
+func (tr totpRepository) Enable(ctx context.Context, userID string, step int64) error {
+	q := `UPDATE totp SET enabled = TRUE, last_step = :last_step
+	      WHERE user_id = :user_id AND NOT enabled AND last_step < :last_step`
+
+	return tr.update(ctx, q, dbTOTP{UserID: userID, LastStep: step})
+}
+
+func (tr totpRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	q := `UPDATE totp SET last_step = :last_step WHERE user_id = :user_id AND last_step < :last_step`
+
+	return tr.update(ctx, q, dbTOTP{UserID: userID, LastStep: step})
+}
+
+func (tr totpRepository) Fail(ctx context.Context, userID string) (int, error) {
+	q := `UPDATE totp SET failures = failures + 1 WHERE user_id = $1 RETURNING failures`
+
+	var failures int
+	if err := tr.db.QueryRowxContext(ctx, q, userID).Scan(&failures); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.Wrap(users.ErrNotFound, err)
+		}
+		return 0, errors.Wrap(errUpdateTOTPDB, mapError(err))
+	}
+
+	return failures, nil
+}
+
+func (tr totpRepository) Lock(ctx context.Context, userID string, until time.Time) error {
+	q := `UPDATE totp SET failures = 0, locked_until = :locked_until WHERE user_id = :user_id`
+
+	dbt := dbTOTP{
+		UserID:      userID,
+		LockedUntil: sql.NullTime{Time: until, Valid: true},
+	}
+	return tr.update(ctx, q, dbt)
+}
+
+func (tr totpRepository) ResetFailures(ctx context.Context, userID string) error {
+	q := `UPDATE totp SET failures = 0 WHERE user_id = :user_id`
+
+	return tr.update(ctx, q, dbTOTP{UserID: userID})
+}
+
+func (tr totpRepository) Remove(ctx context.Context, userID string) error {
+	q := `DELETE FROM totp WHERE user_id = :user_id`
+
+	res, err := tr.db.NamedExecContext(ctx, q, dbTOTP{UserID: userID})
+	if err != nil {
+		return errors.Wrap(errRemoveTOTPDB, mapError(err))
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errRemoveTOTPDB, err)
+	}
+	if cnt == 0 {
+		return users.ErrNotFound
+	}
+
+	return nil
+}
+
+func (tr totpRepository) SaveRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	q := `WITH removed AS (DELETE FROM totp_recovery_codes WHERE user_id = :user_id)
+	      INSERT INTO totp_recovery_codes (user_id, code_hash)
+	      SELECT CAST(:user_id AS UUID), unnest(CAST(:hashes AS TEXT[]))`
+
+	dbc := dbRecoveryCodes{
+		UserID: userID,
+		Hashes: pq.StringArray(hashes),
+	}
+	if _, err := tr.db.NamedExecContext(ctx, q, dbc); err != nil {
+		return errors.Wrap(errSaveRecoveryCodesDB, mapError(err))
+	}
+
+	return nil
+}
+
+func (tr totpRepository) RemoveRecoveryCode(ctx context.Context, userID, hash string) error {
+	q := `DELETE FROM totp_recovery_codes WHERE user_id = $1 AND code_hash = $2 RETURNING code_hash`
+
+	var removed string
+	if err := tr.db.QueryRowxContext(ctx, q, userID, hash).Scan(&removed); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.Wrap(users.ErrNotFound, err)
+		}
+		return errors.Wrap(errRetrieveDB, mapError(err))
+	}
+
+	return nil
+}
+
+// update executes the update query, returning ErrNotFound if no
+// row is updated.
+func (tr totpRepository) update(ctx context.Context, q string, dbt dbTOTP) error {
+	res, err := tr.db.NamedExecContext(ctx, q, dbt)
+	if err != nil {
+		return errors.Wrap(errUpdateTOTPDB, mapError(err))
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errUpdateTOTPDB, err)
+	}
+	if cnt == 0 {
+		return users.ErrNotFound
+	}
+
+	return nil
+}
+
+// mapError maps the database errors caused by the invalid input to the
+// domain errors.
+func mapError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return err
+	}
+	switch pqErr.Code.Name() {
+	case errInvalid, errTruncation:
+		return errors.Wrap(users.ErrMalformedEntity, err)
+	case errFK:
+		return errors.Wrap(users.ErrNotFound, err)
+	}
+	return err
+}
+
+type dbTOTP struct {
+	UserID      string       `db:"user_id"`
+	Secret      []byte       `db:"secret"`
+	Enabled     bool         `db:"enabled"`
+	LastStep    int64        `db:"last_step"`
+	Failures    int          `db:"failures"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+}
+
+func toDBTOTP(t users.TOTP) dbTOTP {
+	return dbTOTP{
+		UserID:      t.UserID,
+		Secret:      t.Secret,
+		Enabled:     t.Enabled,
+		LastStep:    t.LastStep,
+		Failures:    t.Failures,
+		LockedUntil: sql.NullTime{Time: t.LockedUntil, Valid: !t.LockedUntil.IsZero()},
+	}
+}
+
+func toTOTP(dbt dbTOTP) users.TOTP {
+	var lockedUntil time.Time
+	if dbt.LockedUntil.Valid {
+		lockedUntil = dbt.LockedUntil.Time
+	}
+
+	return users.TOTP{
+		UserID:      dbt.UserID,
+		Secret:      dbt.Secret,
+		Enabled:     dbt.Enabled,
+		LastStep:    dbt.LastStep,
+		Failures:    dbt.Failures,
+		LockedUntil: lockedUntil,
+	}
+}
+
+type dbRecoveryCodes struct {
+	UserID string         `db:"user_id"`
+	Hashes pq.StringArray `db:"hashes"`
+}
@@ -0,0 +1,275 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/users"
+	"github.com/mainflux/mainflux/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveUser(t *testing.T, email string) string {
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	repo := postgres.NewUserRepo(postgres.NewDatabase(db))
+	_, err = repo.Save(context.Background(), users.User{ID: uid, Email: email, Password: "pass"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return uid
+}
+
+func TestTOTPSave(t *testing.T) {
+	repo := postgres.NewTOTPRepo(postgres.NewDatabase(db))
+	uid := saveUser(t, "totp-save@example.com")
+
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		totp users.TOTP
+		err  error
+	}{
+		{
+			desc: "save TOTP",
+			totp: users.TOTP{UserID: uid, Secret: []byte("secret")},
+			err:  nil,
+		},
+		{
+			desc: "save pending TOTP again",
+			totp: users.TOTP{UserID: uid, Secret: []byte("other")},
+			err:  nil,
+		},
+		{
+			desc: "save TOTP of non-existent user",
+			totp: users.TOTP{UserID: nonexistentID, Secret: []byte("secret")},
+			err:  users.ErrNotFound,
+		},
+		{
+			desc: "save TOTP with invalid user ID",
+			totp: users.TOTP{UserID: "invalid", Secret: []byte("secret")},
+			err:  users.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.totp)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, []byte("other"), saved.Secret, fmt.Sprintf("expected secret to be replaced, got %s", saved.Secret))
+
+	err = repo.Enable(context.Background(), uid, 1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Save(context.Background(), users.TOTP{UserID: uid, Secret: []byte("secret")})
+	assert.True(t, errors.Contains(err, users.ErrTOTPEnabled), fmt.Sprintf("save enabled TOTP: expected %s got %s\n", users.ErrTOTPEnabled, err))
+}
+
+func TestTOTPEnable(t *testing.T) {
+	repo := postgres.NewTOTPRepo(postgres.NewDatabase(db))
+	uid := saveUser(t, "totp-enable@example.com")
+	err := repo.Save(context.Background(), users.TOTP{UserID: uid, Secret: []byte("secret")})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		step   int64
+		err    error
+	}{
+		{
+			desc:   "enable TOTP",
+			userID: uid,
+			step:   10,
+			err:    nil,
+		},
+		{
+			desc:   "enable enabled TOTP",
+			userID: uid,
+			step:   11,
+			err:    users.ErrNotFound,
+		},
+		{
+			desc:   "enable non-existent TOTP",
+			userID: nonexistentID,
+			step:   10,
+			err:    users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Enable(context.Background(), tc.userID, tc.step)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, saved.Enabled, "expected TOTP to be enabled")
+	assert.Equal(t, int64(10), saved.LastStep, fmt.Sprintf("expected last step 10 got %d", saved.LastStep))
+}
+
+func TestTOTPUseStep(t *testing.T) {
+	repo := postgres.NewTOTPRepo(postgres.NewDatabase(db))
+	uid := saveUser(t, "totp-step@example.com")
+	err := repo.Save(context.Background(), users.TOTP{UserID: uid, Secret: []byte("secret")})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.Enable(context.Background(), uid, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		step int64
+		err  error
+	}{
+		{
+			desc: "use later step",
+			step: 11,
+			err:  nil,
+		},
+		{
+			desc: "use same step",
+			step: 11,
+			err:  users.ErrNotFound,
+		},
+		{
+			desc: "use earlier step",
+			step: 10,
+			err:  users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UseStep(context.Background(), uid, tc.step)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	saved, err := repo.Retrieve(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, int64(11), saved.LastStep, fmt.Sprintf("expected last step 11 got %d", saved.LastStep))
+}
+
+func TestTOTPFailures(t *testing.T) {
+	repo := postgres.NewTOTPRepo(postgres.NewDatabase(db))
+	uid := saveUser(t, "totp-failures@example.com")
+	err := repo.Save(context.Background(), users.TOTP{UserID: uid, Secret: []byte("secret")})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 1; i <= 3; i++ {
+		failures, err := repo.Fail(context.Background(), uid)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, i, failures, fmt.Sprintf("expected %d failures got %d", i, failures))
+	}
+	_, err = repo.Fail(context.Background(), nonexistentID)
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("fail non-existent TOTP: expected %s got %s\n", users.ErrNotFound, err))
+
+	lockedUntil := time.Now().Add(time.Minute).UTC().Round(time.Microsecond)
+	err = repo.Lock(context.Background(), uid, lockedUntil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	saved, err := repo.Retrieve(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 0, saved.Failures, fmt.Sprintf("lock TOTP: expected no failures got %d", saved.Failures))
+	assert.True(t, saved.LockedUntil.Equal(lockedUntil), fmt.Sprintf("lock TOTP: expected locked until %s got %s\n", lockedUntil, saved.LockedUntil))
+
+	_, err = repo.Fail(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.ResetFailures(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	saved, err = repo.Retrieve(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 0, saved.Failures, fmt.Sprintf("reset failures: expected no failures got %d", saved.Failures))
+}
+
+func TestTOTPRemove(t *testing.T) {
+	repo := postgres.NewTOTPRepo(postgres.NewDatabase(db))
+	uid := saveUser(t, "totp-remove@example.com")
+	err := repo.Save(context.Background(), users.TOTP{UserID: uid, Secret: []byte("secret")})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.SaveRecoveryCodes(context.Background(), uid, []string{"code"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		err    error
+	}{
+		{
+			desc:   "remove TOTP",
+			userID: uid,
+			err:    nil,
+		},
+		{
+			desc:   "remove removed TOTP",
+			userID: uid,
+			err:    users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Remove(context.Background(), tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = repo.Retrieve(context.Background(), uid)
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("retrieve removed TOTP: expected %s got %s\n", users.ErrNotFound, err))
+	err = repo.RemoveRecoveryCode(context.Background(), uid, "code")
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("remove recovery code of removed TOTP: expected %s got %s\n", users.ErrNotFound, err))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	repo := postgres.NewTOTPRepo(postgres.NewDatabase(db))
+	uid := saveUser(t, "totp-recovery@example.com")
+	err := repo.Save(context.Background(), users.TOTP{UserID: uid, Secret: []byte("secret")})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.SaveRecoveryCodes(context.Background(), uid, []string{"old"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.SaveRecoveryCodes(context.Background(), uid, []string{"first", "second"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		hash string
+		err  error
+	}{
+		{
+			desc: "remove recovery code",
+			hash: "first",
+			err:  nil,
+		},
+		{
+			desc: "remove used recovery code",
+			hash: "first",
+			err:  users.ErrNotFound,
+		},
+		{
+			desc: "remove replaced recovery code",
+			hash: "old",
+			err:  users.ErrNotFound,
+		},
+		{
+			desc: "remove other recovery code",
+			hash: "second",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemoveRecoveryCode(context.Background(), uid, tc.hash)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
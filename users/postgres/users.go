@@ -20,6 +20,7 @@ import (
 const (
 	errInvalid    = "invalid_text_representation"
 	errTruncation = "string_data_right_truncation"
+	errFK         = "foreign_key_violation"
 )
 
 var (
@@ -29,6 +30,7 @@ var (
 	errUpdateUserDB     = errors.New("Update user metadata to DB failed")
 	errRetrieveDB       = errors.New("Retreiving from DB failed")
 	errUpdatePasswordDB = errors.New("Update password to DB failed")
+	errSaveIdentityDB   = errors.New("Save identity to DB failed")
 	errMarshal          = errors.New("Failed to marshal metadata")
 	errUnmarshal        = errors.New("Failed to unmarshal metadata")
 )
@@ -238,6 +240,49 @@ func (ur userRepository) UpdatePassword(ctx context.Context, email, password str
 	return nil
 }
 
+func (ur userRepository) SaveIdentity(ctx context.Context, userID string, idt users.Identity) error {
+	q := `INSERT INTO identities (provider, subject, user_id) VALUES (:provider, :subject, :user_id)`
+
+	dbi := dbIdentity{
+		Provider: idt.Provider,
+		Subject:  idt.Subject,
+		UserID:   userID,
+	}
+
+	if _, err := ur.db.NamedExecContext(ctx, q, dbi); err != nil {
+		pqErr, ok := err.(*pq.Error)
+		if ok {
+			switch pqErr.Code.Name() {
+			case errInvalid, errTruncation:
+				return errors.Wrap(users.ErrMalformedEntity, err)
+			case errDuplicate:
+				return errors.Wrap(users.ErrConflict, err)
+			case errFK:
+				return errors.Wrap(users.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errSaveIdentityDB, err)
+	}
+
+	return nil
+}
+
+func (ur userRepository) RetrieveByIdentity(ctx context.Context, provider, subject string) (users.User, error) {
+	q := `SELECT u.id, u.email, u.password, u.metadata FROM users u
+	      JOIN identities i ON i.user_id = u.id
+	      WHERE i.provider = $1 AND i.subject = $2`
+
+	dbu := dbUser{}
+	if err := ur.db.QueryRowxContext(ctx, q, provider, subject).StructScan(&dbu); err != nil {
+		if err == sql.ErrNoRows {
+			return users.User{}, errors.Wrap(users.ErrNotFound, err)
+		}
+		return users.User{}, errors.Wrap(errRetrieveDB, err)
+	}
+
+	return toUser(dbu)
+}
+
 // dbMetadata type for handling metadata properly in database/sql
 type dbMetadata map[string]interface{}
 
@@ -280,6 +325,12 @@ type dbUser struct {
 	Groups   []auth.Group `db:"groups"`
 }
 
+type dbIdentity struct {
+	Provider string `db:"provider"`
+	Subject  string `db:"subject"`
+	UserID   string `db:"user_id"`
+}
+
 func toDBUser(u users.User) (dbUser, error) {
 	data := []byte("{}")
 	if len(u.Metadata) > 0 {
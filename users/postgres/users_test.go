@@ -204,3 +204,61 @@ func TestRetrieveAll(t *testing.T) {
 		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %d\n", desc, err))
 	}
 }
+
+func TestIdentity(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	user := users.User{
+		ID:       uid,
+		Email:    "user-identity@example.com",
+		Password: "pass",
+	}
+	_, err = repo.Save(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	unknownID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	idt := users.Identity{Provider: "provider", Subject: "subject"}
+
+	cases := []struct {
+		desc   string
+		userID string
+		idt    users.Identity
+		err    error
+	}{
+		{
+			desc:   "save new identity",
+			userID: uid,
+			idt:    idt,
+			err:    nil,
+		},
+		{
+			desc:   "save existing identity",
+			userID: uid,
+			idt:    idt,
+			err:    users.ErrConflict,
+		},
+		{
+			desc:   "save identity of non-existing user",
+			userID: unknownID,
+			idt:    users.Identity{Provider: "provider", Subject: "other"},
+			err:    users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveIdentity(context.Background(), tc.userID, tc.idt)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	u, err := repo.RetrieveByIdentity(context.Background(), idt.Provider, idt.Subject)
+	assert.Nil(t, err, fmt.Sprintf("retrieve user by identity: unexpected error: %s", err))
+	assert.Equal(t, user.Email, u.Email, fmt.Sprintf("retrieve user by identity: expected %s got %s\n", user.Email, u.Email))
+
+	_, err = repo.RetrieveByIdentity(context.Background(), "unknown", idt.Subject)
+	assert.True(t, errors.Contains(err, users.ErrNotFound), fmt.Sprintf("retrieve user by unknown identity: expected %s got %s\n", users.ErrNotFound, err))
+}
@@ -5,13 +5,18 @@ package users
 
 import (
 	"context"
+	"crypto/subtle"
 	"regexp"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/auth"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/totp"
 )
 
+const readAction = "read"
+
 var (
 	// ErrConflict indicates usage of the existing email during account
 	// registration.
@@ -55,6 +60,28 @@ var (
 
 	// ErrPasswordFormat indicates weak password.
 	ErrPasswordFormat = errors.New("password does not meet the requirements")
+
+	// ErrUnknownProvider indicates login using unknown OpenID Connect provider.
+	ErrUnknownProvider = errors.New("unknown identity provider")
+
+	// ErrUnverifiedEmail indicates that the OpenID Connect provider did not
+	// verify the email of the user logging in for the first time.
+	ErrUnverifiedEmail = errors.New("email is not verified by identity provider")
+
+	// ErrTOTPEnabled indicates TOTP enrolment of the user who already
+	// enabled TOTP.
+	ErrTOTPEnabled = errors.New("TOTP is already enabled")
+
+	// ErrTOTPLocked indicates that the second login step is temporarily
+	// disabled due to too many failed attempts.
+	ErrTOTPLocked = errors.New("too many failed TOTP attempts")
+
+	// ErrTOTPUnavailable indicates that TOTP is not configured, since
+	// the service has no key to encrypt the secrets with.
+	ErrTOTPUnavailable = errors.New("TOTP is not configured")
+
+	errAuthRequest = errors.New("failed to create authorization request")
+	errTOTPSecret  = errors.New("failed to create TOTP secret")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -65,9 +92,17 @@ type Service interface {
 	Register(ctx context.Context, user User) (string, error)
 
 	// Login authenticates the user given its credentials. Successful
-	// authentication generates new access token. Failed invocations are
-	// identified by the non-nil error values in the response.
-	Login(ctx context.Context, user User) (string, error)
+	// authentication starts a new session, generating short-lived access
+	// token and refresh token. Failed invocations are identified by the
+	// non-nil error values in the response.
+	Login(ctx context.Context, user User) (Tokens, error)
+
+	// Refresh exchanges the refresh token for the new pair of tokens of
+	// the same session. Refresh token can be used only once.
+	Refresh(ctx context.Context, refreshToken string) (Tokens, error)
+
+	// Logout revokes the session the access or refresh token belongs to.
+	Logout(ctx context.Context, token string) error
 
 	// ViewUser retrieves user info for a given user ID and an authorized token.
 	ViewUser(ctx context.Context, token, id string) (User, error)
@@ -97,6 +132,36 @@ type Service interface {
 
 	// ListMembers retrieves everything that is assigned to a group identified by groupID.
 	ListMembers(ctx context.Context, token, groupID string, offset, limit uint64, meta Metadata) (UserPage, error)
+
+	// OIDCAuthorize starts the login using the OpenID Connect provider,
+	// returning the authorization request the user is redirected with.
+	OIDCAuthorize(ctx context.Context, provider string) (AuthRequest, error)
+
+	// OIDCLogin completes the login using the OpenID Connect provider,
+	// exchanging the authorization code issued for the request with the given
+	// state. On the first login, the identity is linked to the user with the
+	// same verified email, or a new user is registered with the metadata
+	// mapped from the ID token claims. Successful login starts a new session.
+	OIDCLogin(ctx context.Context, provider, state, code string, req AuthRequest) (Tokens, error)
+
+	// EnrolTOTP starts TOTP enrolment of the user identified by the token,
+	// returning the secret the authenticator app is configured with.
+	EnrolTOTP(ctx context.Context, token string) (TOTPEnrolment, error)
+
+	// ConfirmTOTP enables TOTP of the user identified by the token, once
+	// the user proves the authenticator app is configured by providing
+	// the generated code. One-time recovery codes are returned.
+	ConfirmTOTP(ctx context.Context, token, code string) ([]string, error)
+
+	// VerifyTOTP completes the login of the user with TOTP enabled,
+	// exchanging the pre-auth token issued on the first login step and
+	// the code generated by the authenticator app, or a recovery code,
+	// for the session tokens.
+	VerifyTOTP(ctx context.Context, preAuthToken, code string) (Tokens, error)
+
+	// ResetTOTP disables TOTP of the user with given ID, removing the
+	// recovery codes. Only the platform administrator can reset TOTP.
+	ResetTOTP(ctx context.Context, token, userID string) error
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -123,22 +188,34 @@ var _ Service = (*usersService)(nil)
 
 type usersService struct {
 	users      UserRepository
+	totp       TOTPRepository
 	hasher     Hasher
+	cipher     Cipher
 	email      Emailer
 	auth       mainflux.AuthServiceClient
 	idProvider mainflux.IDProvider
 	passRegex  *regexp.Regexp
+	adminEmail string
+	providers  map[string]IdentityProvider
 }
 
-// New instantiates the users service implementation
-func New(users UserRepository, hasher Hasher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp) Service {
+// New instantiates the users service implementation. Users can log in using
+// the OpenID Connect providers, identified by the keys of the providers map.
+// TOTP secrets are encrypted using the cipher; if it's nil, TOTP enrolment
+// is not available. The user with the admin email is the platform
+// administrator.
+func New(users UserRepository, totp TOTPRepository, hasher Hasher, cipher Cipher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp, providers map[string]IdentityProvider, adminEmail string) Service {
 	return &usersService{
 		users:      users,
+		totp:       totp,
 		hasher:     hasher,
+		cipher:     cipher,
 		auth:       auth,
 		email:      e,
 		idProvider: idp,
 		passRegex:  passRegex,
+		providers:  providers,
+		adminEmail: adminEmail,
 	}
 }
 
@@ -166,21 +243,32 @@ func (svc usersService) Register(ctx context.Context, user User) (string, error)
 	return uid, nil
 }
 
-func (svc usersService) Login(ctx context.Context, user User) (string, error) {
-	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
+func (svc usersService) Login(ctx context.Context, user User) (Tokens, error) {
+	dbUser, err := svc.authenticate(ctx, user)
 	if err != nil {
-		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+		return Tokens{}, err
 	}
-	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
-		return "", errors.Wrap(ErrUnauthorizedAccess, err)
+	return svc.login(ctx, dbUser)
+}
+
+func (svc usersService) Refresh(ctx context.Context, refreshToken string) (Tokens, error) {
+	tokens, err := svc.auth.Refresh(ctx, &mainflux.Token{Value: refreshToken})
+	if err != nil {
+		return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return Tokens{AccessToken: tokens.GetAccessToken(), RefreshToken: tokens.GetRefreshToken()}, nil
+}
+
+func (svc usersService) Logout(ctx context.Context, token string) error {
+	if _, err := svc.auth.Logout(ctx, &mainflux.Token{Value: token}); err != nil {
+		return errors.Wrap(ErrUnauthorizedAccess, err)
 	}
-	return svc.issue(ctx, dbUser.ID, dbUser.Email, auth.UserKey)
+	return nil
 }
 
 func (svc usersService) ViewUser(ctx context.Context, token, id string) (User, error) {
-	_, err := svc.identify(ctx, token)
-	if err != nil {
-		return User{}, err
+	if _, err := svc.auth.CanAccess(ctx, &mainflux.AccessReq{Token: token, Obj: id, Act: readAction}); err != nil {
+		return User{}, errors.Wrap(ErrUnauthorizedAccess, err)
 	}
 
 	dbUser, err := svc.users.RetrieveByID(ctx, id)
@@ -279,7 +367,7 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 		Email:    email,
 		Password: oldPassword,
 	}
-	if _, err := svc.Login(ctx, u); err != nil {
+	if _, err := svc.authenticate(ctx, u); err != nil {
 		return ErrUnauthorizedAccess
 	}
 	u, err = svc.users.RetrieveByEmail(ctx, email)
@@ -312,7 +400,306 @@ func (svc usersService) ListMembers(ctx context.Context, token, groupID string,
 	return svc.users.RetrieveAll(ctx, offset, limit, userIDs, "", m)
 }
 
+func (svc usersService) OIDCAuthorize(ctx context.Context, provider string) (AuthRequest, error) {
+	p, ok := svc.providers[provider]
+	if !ok {
+		return AuthRequest{}, ErrUnknownProvider
+	}
+
+	req, err := newAuthRequest()
+	if err != nil {
+		return AuthRequest{}, errors.Wrap(errAuthRequest, err)
+	}
+	req.URL = p.AuthURL(req.State, req.Nonce, req.Verifier)
+
+	return req, nil
+}
+
+func (svc usersService) OIDCLogin(ctx context.Context, provider, state, code string, req AuthRequest) (Tokens, error) {
+	p, ok := svc.providers[provider]
+	if !ok {
+		return Tokens{}, ErrUnknownProvider
+	}
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(req.State)) != 1 {
+		return Tokens{}, ErrUnauthorizedAccess
+	}
+
+	idt, err := p.Exchange(ctx, code, req.Verifier, req.Nonce)
+	if err != nil {
+		return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	idt.Provider = provider
+
+	user, err := svc.users.RetrieveByIdentity(ctx, idt.Provider, idt.Subject)
+	if errors.Contains(err, ErrNotFound) {
+		user, err = svc.link(ctx, idt)
+	}
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	return svc.login(ctx, user)
+}
+
+func (svc usersService) EnrolTOTP(ctx context.Context, token string) (TOTPEnrolment, error) {
+	if svc.cipher == nil {
+		return TOTPEnrolment{}, ErrTOTPUnavailable
+	}
+	user, err := svc.profile(ctx, token)
+	if err != nil {
+		return TOTPEnrolment{}, err
+	}
+
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return TOTPEnrolment{}, errors.Wrap(errTOTPSecret, err)
+	}
+	encrypted, err := svc.cipher.Encrypt(secret, []byte(user.ID))
+	if err != nil {
+		return TOTPEnrolment{}, errors.Wrap(errTOTPSecret, err)
+	}
+	if err := svc.totp.Save(ctx, TOTP{UserID: user.ID, Secret: encrypted}); err != nil {
+		return TOTPEnrolment{}, err
+	}
+
+	return TOTPEnrolment{
+		Secret: totp.Encoding.EncodeToString(secret),
+		URI:    totp.URI(secret, totpIssuer, user.Email),
+	}, nil
+}
+
+func (svc usersService) ConfirmTOTP(ctx context.Context, token, code string) ([]string, error) {
+	if svc.cipher == nil {
+		return nil, ErrTOTPUnavailable
+	}
+	user, err := svc.profile(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := svc.totp.Retrieve(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Enabled {
+		return nil, ErrTOTPEnabled
+	}
+	step, err := svc.validateTOTP(t, code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, errors.Wrap(errTOTPSecret, err)
+	}
+	hashes := make([]string, len(codes))
+	for i, c := range codes {
+		hashes[i] = hashRecoveryCode(c)
+	}
+
+	// Enabling fails if the concurrent confirmation already used the code,
+	// so only its recovery codes are saved.
+	err = svc.totp.Enable(ctx, user.ID, step)
+	if errors.Contains(err, ErrNotFound) {
+		return nil, ErrUnauthorizedAccess
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.totp.SaveRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (svc usersService) VerifyTOTP(ctx context.Context, preAuthToken, code string) (Tokens, error) {
+	idt, err := svc.auth.IdentifyPreAuth(ctx, &mainflux.Token{Value: preAuthToken})
+	if err != nil {
+		return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+
+	t, err := svc.totp.Retrieve(ctx, idt.GetId())
+	if err != nil {
+		return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if !t.Enabled {
+		return Tokens{}, ErrUnauthorizedAccess
+	}
+	now := time.Now()
+	if t.Locked(now) {
+		return Tokens{}, ErrTOTPLocked
+	}
+
+	err = svc.verifyCode(ctx, t, code, now)
+	switch {
+	case errors.Contains(err, ErrUnauthorizedAccess):
+		failures, err := svc.totp.Fail(ctx, t.UserID)
+		if err != nil {
+			return Tokens{}, err
+		}
+		if failures >= maxTOTPFailures {
+			if err := svc.totp.Lock(ctx, t.UserID, now.Add(totpLockout)); err != nil {
+				return Tokens{}, err
+			}
+		}
+		return Tokens{}, ErrUnauthorizedAccess
+	case err != nil:
+		return Tokens{}, err
+	}
+
+	if err := svc.totp.ResetFailures(ctx, t.UserID); err != nil {
+		return Tokens{}, err
+	}
+
+	return svc.session(ctx, User{ID: idt.GetId(), Email: idt.GetEmail()})
+}
+
+func (svc usersService) ResetTOTP(ctx context.Context, token, userID string) error {
+	// Resetting TOTP disables the second factor of another user, so it's
+	// not granted by the policies, which users can add over their objects.
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+	if svc.adminEmail == "" || email != svc.adminEmail {
+		return ErrUnauthorizedAccess
+	}
+
+	return svc.totp.Remove(ctx, userID)
+}
+
+// link links the identity to the user with the same email, registering
+// the user if there is none.
+func (svc usersService) link(ctx context.Context, idt Identity) (User, error) {
+	// Unverified email could be used to take over the existing account.
+	if !idt.EmailVerified {
+		return User{}, ErrUnverifiedEmail
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, idt.Email)
+	switch {
+	case errors.Contains(err, ErrNotFound):
+		// Registered user has no password, so it can only log in using
+		// the provider, until the password is reset.
+		user = User{
+			Email:    idt.Email,
+			Metadata: idt.Metadata,
+		}
+		if err := user.Validate(); err != nil {
+			return User{}, err
+		}
+		if user.ID, err = svc.idProvider.ID(); err != nil {
+			return User{}, errors.Wrap(ErrCreateUser, err)
+		}
+		if _, err := svc.users.Save(ctx, user); err != nil {
+			return User{}, err
+		}
+	case err != nil:
+		return User{}, err
+	}
+
+	if err := svc.users.SaveIdentity(ctx, user.ID, idt); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
 // Auth helpers
+func (svc usersService) authenticate(ctx context.Context, user User) (User, error) {
+	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
+	if err != nil {
+		return User{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
+		return User{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return dbUser, nil
+}
+
+// login starts a new session of the user who passed the first login step.
+// If the user enabled TOTP, the pre-auth token is issued instead, which is
+// exchanged for the session tokens in the second login step.
+func (svc usersService) login(ctx context.Context, user User) (Tokens, error) {
+	t, err := svc.totp.Retrieve(ctx, user.ID)
+	switch {
+	case err == nil && t.Enabled:
+		token, err := svc.issue(ctx, user.ID, user.Email, auth.PreAuthKey)
+		if err != nil {
+			return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+		}
+		return Tokens{PreAuthToken: token}, nil
+	case err != nil && !errors.Contains(err, ErrNotFound):
+		return Tokens{}, err
+	}
+
+	return svc.session(ctx, user)
+}
+
+func (svc usersService) session(ctx context.Context, user User) (Tokens, error) {
+	tokens, err := svc.auth.Login(ctx, &mainflux.IssueReq{Id: user.ID, Email: user.Email})
+	if err != nil {
+		return Tokens{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return Tokens{AccessToken: tokens.GetAccessToken(), RefreshToken: tokens.GetRefreshToken()}, nil
+}
+
+// profile retrieves the user identified by the token.
+func (svc usersService) profile(ctx context.Context, token string) (User, error) {
+	email, err := svc.identify(ctx, token)
+	if err != nil {
+		return User{}, err
+	}
+	user, err := svc.users.RetrieveByEmail(ctx, email)
+	if err != nil {
+		return User{}, errors.Wrap(ErrUnauthorizedAccess, err)
+	}
+	return user, nil
+}
+
+// verifyCode checks the TOTP code or the recovery code, which are marked
+// as used once accepted. ErrUnauthorizedAccess indicates an invalid code.
+func (svc usersService) verifyCode(ctx context.Context, t TOTP, code string, now time.Time) error {
+	if len(code) == totp.Digits {
+		step, err := svc.validateTOTP(t, code, now)
+		if err != nil {
+			return err
+		}
+		// The step is recorded only if it's later than the last used one,
+		// so the concurrent requests can't both accept the same code.
+		err = svc.totp.UseStep(ctx, t.UserID, step)
+		if errors.Contains(err, ErrNotFound) {
+			return ErrUnauthorizedAccess
+		}
+		return err
+	}
+
+	err := svc.totp.RemoveRecoveryCode(ctx, t.UserID, hashRecoveryCode(code))
+	if errors.Contains(err, ErrNotFound) {
+		return ErrUnauthorizedAccess
+	}
+	return err
+}
+
+// validateTOTP checks the code generated by the authenticator app,
+// returning its time step.
+func (svc usersService) validateTOTP(t TOTP, code string, now time.Time) (int64, error) {
+	if svc.cipher == nil {
+		return 0, ErrTOTPUnavailable
+	}
+	secret, err := svc.cipher.Decrypt(t.Secret, []byte(t.UserID))
+	if err != nil {
+		return 0, err
+	}
+	step, ok := totp.Validate(secret, code, now, t.LastStep)
+	if !ok {
+		return 0, ErrUnauthorizedAccess
+	}
+	return step, nil
+}
+
 func (svc usersService) issue(ctx context.Context, id, email string, keyType uint32) (string, error) {
 	key, err := svc.auth.Issue(ctx, &mainflux.IssueReq{Id: id, Email: email, Type: keyType})
 	if err != nil {
@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mainflux/mainflux"
 	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/totp"
 	"github.com/mainflux/mainflux/pkg/uuid"
 	"github.com/mainflux/mainflux/users"
 
@@ -19,7 +22,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const wrong string = "wrong-value"
+const (
+	wrong    string = "wrong-value"
+	provider string = "mock"
+)
 
 var (
 	user            = users.User{Email: "user@example.com", Password: "password", Metadata: map[string]interface{}{"role": "user"}}
@@ -28,15 +34,24 @@ var (
 
 	idProvider = uuid.New()
 	passRegex  = regexp.MustCompile("^.{8,}$")
+
+	identities = map[string]users.Identity{
+		"existing":   {Subject: "1", Email: user.Email, EmailVerified: true},
+		"new":        {Subject: "2", Email: "oidc-user@example.com", EmailVerified: true, Metadata: users.Metadata{"name": "OIDC User"}},
+		"unverified": {Subject: "3", Email: "unverified@example.com"},
+	}
 )
 
 func newService() users.Service {
 	userRepo := mocks.NewUserRepository()
 	hasher := mocks.NewHasher()
-	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email})
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email, identities["new"].Email: identities["new"].Email})
 	e := mocks.NewEmailer()
+	providers := map[string]users.IdentityProvider{
+		provider: mocks.NewIdentityProvider("http://localhost/authorize", identities),
+	}
 
-	return users.New(userRepo, hasher, auth, e, idProvider, passRegex)
+	return users.New(userRepo, mocks.NewTOTPRepository(), hasher, mocks.NewCipher(), auth, e, idProvider, passRegex, providers, "")
 }
 
 func TestRegister(t *testing.T) {
@@ -117,13 +132,190 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestRefresh(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		token string
+		err   error
+	}{
+		"refresh with valid refresh token": {
+			token: tokens.RefreshToken,
+			err:   nil,
+		},
+		"refresh with access token": {
+			token: tokens.AccessToken,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		"refresh with invalid token": {
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for desc, tc := range cases {
+		_, err := svc.Refresh(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestLogout(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := map[string]struct {
+		token string
+		err   error
+	}{
+		"logout with access token": {
+			token: tokens.AccessToken,
+			err:   nil,
+		},
+		"logout with refresh token": {
+			token: tokens.RefreshToken,
+			err:   nil,
+		},
+		"logout with invalid token": {
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.Logout(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestOIDCAuthorize(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc     string
+		provider string
+		err      error
+	}{
+		{
+			desc:     "authorize with known provider",
+			provider: provider,
+			err:      nil,
+		},
+		{
+			desc:     "authorize with unknown provider",
+			provider: wrong,
+			err:      users.ErrUnknownProvider,
+		},
+	}
+
+	for _, tc := range cases {
+		req, err := svc.OIDCAuthorize(context.Background(), tc.provider)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.NotEmpty(t, req.URL, fmt.Sprintf("%s: expected non-empty authorization URL", tc.desc))
+			assert.NotEmpty(t, req.State, fmt.Sprintf("%s: expected non-empty state", tc.desc))
+			assert.NotEqual(t, req.State, req.Nonce, fmt.Sprintf("%s: expected distinct state and nonce", tc.desc))
+		}
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	req, err := svc.OIDCAuthorize(context.Background(), provider)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		provider string
+		state    string
+		code     string
+		token    string
+		err      error
+	}{
+		{
+			desc:     "login with unknown provider",
+			provider: wrong,
+			state:    req.State,
+			code:     "new",
+			err:      users.ErrUnknownProvider,
+		},
+		{
+			desc:     "login with mismatched state",
+			provider: provider,
+			state:    wrong,
+			code:     "new",
+			err:      users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "login with invalid code",
+			provider: provider,
+			state:    req.State,
+			code:     wrong,
+			err:      users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "login with unverified email",
+			provider: provider,
+			state:    req.State,
+			code:     "unverified",
+			err:      users.ErrUnverifiedEmail,
+		},
+		{
+			desc:     "login linking existing user",
+			provider: provider,
+			state:    req.State,
+			code:     "existing",
+			token:    user.Email,
+			err:      nil,
+		},
+		{
+			desc:     "login registering new user",
+			provider: provider,
+			state:    req.State,
+			code:     "new",
+			token:    identities["new"].Email,
+			err:      nil,
+		},
+		{
+			desc:     "login with linked identity",
+			provider: provider,
+			state:    req.State,
+			code:     "new",
+			token:    identities["new"].Email,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tokens, err := svc.OIDCLogin(context.Background(), tc.provider, tc.state, tc.code, req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.token, tokens.AccessToken, fmt.Sprintf("%s: expected token %s got %s\n", tc.desc, tc.token, tokens.AccessToken))
+	}
+
+	u, err := svc.ViewProfile(context.Background(), identities["new"].Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, identities["new"].Metadata, u.Metadata, fmt.Sprintf("expected metadata %v got %v\n", identities["new"].Metadata, u.Metadata))
+}
+
 func TestViewUser(t *testing.T) {
 	svc := newService()
 	id, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	tokens, err := svc.Login(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokens.AccessToken
 
 	u := user
 	u.Password = ""
@@ -165,8 +357,9 @@ func TestViewProfile(t *testing.T) {
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	tokens, err := svc.Login(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokens.AccessToken
 
 	u := user
 	u.Password = ""
@@ -199,8 +392,9 @@ func TestListUsers(t *testing.T) {
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	tokens, err := svc.Login(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokens.AccessToken
 
 	var nUsers = uint64(10)
 
@@ -254,8 +448,9 @@ func TestUpdateUser(t *testing.T) {
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	token, err := svc.Login(context.Background(), user)
+	tokens, err := svc.Login(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokens.AccessToken
 
 	user.Metadata = map[string]interface{}{"role": "test"}
 
@@ -305,7 +500,8 @@ func TestChangePassword(t *testing.T) {
 	svc := newService()
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
-	token, _ := svc.Login(context.Background(), user)
+	tokens, _ := svc.Login(context.Background(), user)
+	token := tokens.AccessToken
 
 	cases := map[string]struct {
 		token       string
@@ -351,7 +547,8 @@ func TestSendPasswordReset(t *testing.T) {
 	svc := newService()
 	_, err := svc.Register(context.Background(), user)
 	require.Nil(t, err, fmt.Sprintf("register user error: %s", err))
-	token, _ := svc.Login(context.Background(), user)
+	tokens, _ := svc.Login(context.Background(), user)
+	token := tokens.AccessToken
 
 	cases := map[string]struct {
 		token string
@@ -367,3 +564,284 @@ func TestSendPasswordReset(t *testing.T) {
 
 	}
 }
+
+func newTOTPService(auth mainflux.AuthServiceClient, cipher users.Cipher) users.Service {
+	return users.New(mocks.NewUserRepository(), mocks.NewTOTPRepository(), mocks.NewHasher(), cipher, auth, mocks.NewEmailer(), idProvider, passRegex, nil, "")
+}
+
+// enableTOTP enrols and confirms TOTP of the user identified by the token,
+// returning the secret and the recovery codes.
+func enableTOTP(t *testing.T, svc users.Service, token string) ([]byte, []string) {
+	e, err := svc.EnrolTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("enrolling TOTP expected to succeed: %s", err))
+	secret, err := totp.Encoding.DecodeString(e.Secret)
+	require.Nil(t, err, fmt.Sprintf("decoding TOTP secret expected to succeed: %s", err))
+	codes, err := svc.ConfirmTOTP(context.Background(), token, totp.Generate(secret, time.Now()))
+	require.Nil(t, err, fmt.Sprintf("confirming TOTP expected to succeed: %s", err))
+	return secret, codes
+}
+
+func TestEnrolTOTP(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	tokens, err := svc.Login(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	token := tokens.AccessToken
+
+	cases := []struct {
+		desc  string
+		svc   users.Service
+		token string
+		err   error
+	}{
+		{
+			desc:  "enrol TOTP",
+			svc:   svc,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "enrol TOTP again before confirmation",
+			svc:   svc,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "enrol TOTP with invalid token",
+			svc:   svc,
+			token: wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "enrol TOTP without cipher",
+			svc:   newTOTPService(mocks.NewAuthService(map[string]string{user.Email: user.Email}), nil),
+			token: token,
+			err:   users.ErrTOTPUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		e, err := tc.svc.EnrolTOTP(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		secret, err := totp.Encoding.DecodeString(e.Secret)
+		assert.Nil(t, err, fmt.Sprintf("%s: decoding secret expected to succeed: %s", tc.desc, err))
+		uri := totp.URI(secret, "Mainflux", user.Email)
+		assert.Equal(t, uri, e.URI, fmt.Sprintf("%s: expected URI %s got %s\n", tc.desc, uri, e.URI))
+	}
+
+	enableTOTP(t, svc, token)
+	_, err = svc.EnrolTOTP(context.Background(), token)
+	assert.True(t, errors.Contains(err, users.ErrTOTPEnabled), fmt.Sprintf("enrol enabled TOTP: expected %s got %s\n", users.ErrTOTPEnabled, err))
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other := users.User{Email: identities["new"].Email, Password: user.Password}
+	_, err = svc.Register(context.Background(), other)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	token := user.Email
+	e, err := svc.EnrolTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	secret, err := totp.Encoding.DecodeString(e.Secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	code := totp.Generate(secret, time.Now())
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "confirm TOTP with invalid token",
+			token: wrong,
+			code:  code,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "confirm TOTP with invalid code",
+			token: token,
+			code:  wrong,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "confirm TOTP without enrolment",
+			token: other.Email,
+			code:  code,
+			err:   users.ErrNotFound,
+		},
+		{
+			desc:  "confirm TOTP",
+			token: token,
+			code:  code,
+			err:   nil,
+		},
+		{
+			desc:  "confirm enabled TOTP",
+			token: token,
+			code:  code,
+			err:   users.ErrTOTPEnabled,
+		},
+	}
+
+	for _, tc := range cases {
+		codes, err := svc.ConfirmTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, codes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d\n", tc.desc, len(codes)))
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	svc := newService()
+	_, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	secret, codes := enableTOTP(t, svc, user.Email)
+
+	tokens, err := svc.Login(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Empty(t, tokens.AccessToken, "login with TOTP enabled: expected no access token")
+	require.NotEmpty(t, tokens.PreAuthToken, "login with TOTP enabled: expected pre-auth token")
+	preAuth := tokens.PreAuthToken
+
+	// Confirmation used the code of the current time step.
+	code := totp.Generate(secret, time.Now().Add(totp.Period))
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "verify TOTP with invalid pre-auth token",
+			token: wrong,
+			code:  code,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify TOTP with access token",
+			token: user.Email,
+			code:  code,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify TOTP with valid code",
+			token: preAuth,
+			code:  code,
+			err:   nil,
+		},
+		{
+			desc:  "verify TOTP with reused code",
+			token: preAuth,
+			code:  code,
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify TOTP with recovery code",
+			token: preAuth,
+			code:  codes[0],
+			err:   nil,
+		},
+		{
+			desc:  "verify TOTP with used recovery code",
+			token: preAuth,
+			code:  codes[0],
+			err:   users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:  "verify TOTP with upper case recovery code",
+			token: preAuth,
+			code:  strings.ToUpper(codes[1]),
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tokens, err := svc.VerifyTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, user.Email, tokens.AccessToken, fmt.Sprintf("%s: expected access token %s got %s\n", tc.desc, user.Email, tokens.AccessToken))
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		_, err := svc.VerifyTOTP(context.Background(), preAuth, wrong)
+		assert.True(t, errors.Contains(err, users.ErrUnauthorizedAccess), fmt.Sprintf("verify TOTP with wrong code: expected %s got %s\n", users.ErrUnauthorizedAccess, err))
+	}
+	_, err = svc.VerifyTOTP(context.Background(), preAuth, codes[2])
+	assert.True(t, errors.Contains(err, users.ErrTOTPLocked), fmt.Sprintf("verify TOTP after too many failures: expected %s got %s\n", users.ErrTOTPLocked, err))
+}
+
+func TestResetTOTP(t *testing.T) {
+	admin := users.User{Email: "admin@example.com", Password: "password"}
+	other := users.User{Email: "other@example.com", Password: "password"}
+	auth := mocks.NewAuthService(map[string]string{user.Email: user.Email, admin.Email: admin.Email, other.Email: other.Email})
+	svc := users.New(mocks.NewUserRepository(), mocks.NewTOTPRepository(), mocks.NewHasher(), mocks.NewCipher(), auth, mocks.NewEmailer(), idProvider, passRegex, nil, admin.Email)
+
+	userID, err := svc.Register(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), admin)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.Register(context.Background(), other)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	enableTOTP(t, svc, user.Email)
+	// Policy over the user doesn't make the other user platform administrator.
+	_, err = auth.AddPolicy(context.Background(), &mainflux.PolicyReq{Sub: other.Email, Obj: userID, Act: "admin"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		userID string
+		err    error
+	}{
+		{
+			desc:   "reset TOTP with invalid token",
+			token:  wrong,
+			userID: userID,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "reset TOTP without admin rights",
+			token:  user.Email,
+			userID: userID,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "reset TOTP with admin policy over the user",
+			token:  other.Email,
+			userID: userID,
+			err:    users.ErrUnauthorizedAccess,
+		},
+		{
+			desc:   "reset TOTP",
+			token:  admin.Email,
+			userID: userID,
+			err:    nil,
+		},
+		{
+			desc:   "reset disabled TOTP",
+			token:  admin.Email,
+			userID: userID,
+			err:    users.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ResetTOTP(context.Background(), tc.token, tc.userID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	tokens, err := svc.Login(context.Background(), user)
+	assert.Nil(t, err, fmt.Sprintf("login after TOTP reset: got unexpected error: %s", err))
+	assert.Equal(t, user.Email, tokens.AccessToken, fmt.Sprintf("login after TOTP reset: expected access token %s got %s\n", user.Email, tokens.AccessToken))
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// totpIssuer is the name shown by authenticator apps alongside the user
+	// email.
+	totpIssuer = "Mainflux"
+
+	// maxTOTPFailures is the number of consecutive failed second login
+	// steps after which the second factor is locked for the totpLockout.
+	maxTOTPFailures = 5
+	totpLockout     = 5 * time.Minute
+
+	recoveryCodesNum  = 10
+	recoveryCodeSize  = 10
+	recoveryCodeGroup = 4
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP represents the time-based one-time password (RFC 6238) used by the
+// user as the second login factor.
+type TOTP struct {
+	UserID string
+
+	// Secret is the secret shared with the authenticator app, encrypted
+	// using the Cipher.
+	Secret []byte
+
+	// Enabled is set once the user confirms the enrolment using the code
+	// generated by the authenticator app.
+	Enabled bool
+
+	// LastStep is the time step of the last accepted code, so that each
+	// code can be used only once.
+	LastStep int64
+
+	// Failures is the number of consecutive failed second login steps.
+	Failures    int
+	LockedUntil time.Time
+}
+
+// Locked returns true if the second login step is temporarily disabled
+// due to too many failed attempts.
+func (t TOTP) Locked(now time.Time) bool {
+	return now.Before(t.LockedUntil)
+}
+
+// TOTPEnrolment contains the secret the authenticator app is configured
+// with, either manually or by scanning the QR code encoding the URI.
+type TOTPEnrolment struct {
+	Secret string
+	URI    string
+}
+
+// TOTPRepository specifies a TOTP persistence API.
+type TOTPRepository interface {
+	// Save persists the pending enrolment, replacing the previous one.
+	// ErrTOTPEnabled is returned if the user already enabled TOTP.
+	Save(ctx context.Context, t TOTP) error
+
+	// Retrieve retrieves TOTP of the user with given ID.
+	Retrieve(ctx context.Context, userID string) (TOTP, error)
+
+	// Enable enables the pending TOTP of the user, recording the time step
+	// of the confirmation code. ErrNotFound is returned if there is no
+	// pending TOTP or if the same or a later step is already used.
+	Enable(ctx context.Context, userID string, step int64) error
+
+	// UseStep records the time step of the accepted code. ErrNotFound is
+	// returned if the same or a later step is already used, so that the
+	// code can't be accepted twice by the concurrent requests.
+	UseStep(ctx context.Context, userID string, step int64) error
+
+	// Fail increments the number of consecutive failed second login steps
+	// of the user, returning the incremented number.
+	Fail(ctx context.Context, userID string) (int, error)
+
+	// Lock disables the second login step of the user until the given
+	// time, resetting the number of failures.
+	Lock(ctx context.Context, userID string, until time.Time) error
+
+	// ResetFailures resets the number of consecutive failed second login
+	// steps of the user.
+	ResetFailures(ctx context.Context, userID string) error
+
+	// Remove removes TOTP and recovery codes of the user with given ID.
+	Remove(ctx context.Context, userID string) error
+
+	// SaveRecoveryCodes replaces recovery codes of the user with given ID
+	// with the provided code hashes.
+	SaveRecoveryCodes(ctx context.Context, userID string, hashes []string) error
+
+	// RemoveRecoveryCode removes the recovery code with given hash. If there
+	// is no such code, e.g. it's already been used, ErrNotFound is returned.
+	RemoveRecoveryCode(ctx context.Context, userID, hash string) error
+}
+
+// Cipher specifies an API for encrypting the secrets stored by the service.
+type Cipher interface {
+	// Encrypt encrypts the plaintext, authenticating the additional data
+	// alongside, so that the ciphertext can't be used in other context.
+	Encrypt(plaintext, data []byte) ([]byte, error)
+
+	// Decrypt decrypts the ciphertext encrypted with the same additional
+	// data. An error indicates that the ciphertext or the data is modified.
+	Decrypt(ciphertext, data []byte) ([]byte, error)
+}
+
+// newRecoveryCodes generates one-time recovery codes, used to pass the
+// second login step if the authenticator app is not available.
+func newRecoveryCodes() ([]string, error) {
+	codes := make([]string, recoveryCodesNum)
+	for i := range codes {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(b))
+
+		var groups []string
+		for len(code) > recoveryCodeGroup {
+			groups = append(groups, code[:recoveryCodeGroup])
+			code = code[recoveryCodeGroup:]
+		}
+		codes[i] = strings.Join(append(groups, code), "-")
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode returns the hash of the recovery code stored instead of
+// the code. Since the codes are random, a fast hash suffices.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/mainflux/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveTOTPOp           = "save_totp"
+	retrieveTOTPOp       = "retrieve_totp"
+	enableTOTPOp         = "enable_totp"
+	useTOTPStepOp        = "use_totp_step"
+	failTOTPOp           = "fail_totp"
+	lockTOTPOp           = "lock_totp"
+	resetTOTPFailuresOp  = "reset_totp_failures"
+	removeTOTPOp         = "remove_totp"
+	saveRecoveryCodesOp  = "save_recovery_codes"
+	removeRecoveryCodeOp = "remove_recovery_code"
+)
+
+var _ users.TOTPRepository = (*totpRepositoryMiddleware)(nil)
+
+type totpRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.TOTPRepository
+}
+
+// TOTPRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func TOTPRepositoryMiddleware(repo users.TOTPRepository, tracer opentracing.Tracer) users.TOTPRepository {
+	return totpRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (trm totpRepositoryMiddleware) Save(ctx context.Context, t users.TOTP) error {
+	span := createSpan(ctx, trm.tracer, saveTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Save(ctx, t)
+}
+
+func (trm totpRepositoryMiddleware) Retrieve(ctx context.Context, userID string) (users.TOTP, error) {
+	span := createSpan(ctx, trm.tracer, retrieveTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Retrieve(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) Enable(ctx context.Context, userID string, step int64) error {
+	span := createSpan(ctx, trm.tracer, enableTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Enable(ctx, userID, step)
+}
+
+func (trm totpRepositoryMiddleware) UseStep(ctx context.Context, userID string, step int64) error {
+	span := createSpan(ctx, trm.tracer, useTOTPStepOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.UseStep(ctx, userID, step)
+}
+
+func (trm totpRepositoryMiddleware) Fail(ctx context.Context, userID string) (int, error) {
+	span := createSpan(ctx, trm.tracer, failTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Fail(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) Lock(ctx context.Context, userID string, until time.Time) error {
+	span := createSpan(ctx, trm.tracer, lockTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Lock(ctx, userID, until)
+}
+
+func (trm totpRepositoryMiddleware) ResetFailures(ctx context.Context, userID string) error {
+	span := createSpan(ctx, trm.tracer, resetTOTPFailuresOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.ResetFailures(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) Remove(ctx context.Context, userID string) error {
+	span := createSpan(ctx, trm.tracer, removeTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Remove(ctx, userID)
+}
+
+func (trm totpRepositoryMiddleware) SaveRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	span := createSpan(ctx, trm.tracer, saveRecoveryCodesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.SaveRecoveryCodes(ctx, userID, hashes)
+}
+
+func (trm totpRepositoryMiddleware) RemoveRecoveryCode(ctx context.Context, userID, hash string) error {
+	span := createSpan(ctx, trm.tracer, removeRecoveryCodeOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemoveRecoveryCode(ctx, userID, hash)
+}
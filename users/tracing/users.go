@@ -17,6 +17,9 @@ const (
 	retrieveByEmailOp = "retrieve_by_email"
 	updatePassword    = "update_password"
 	members           = "members"
+
+	saveIdentityOp       = "save_identity"
+	retrieveByIdentityOp = "retrieve_by_identity"
 )
 
 var _ users.UserRepository = (*userRepositoryMiddleware)(nil)
@@ -83,6 +86,22 @@ func (urm userRepositoryMiddleware) RetrieveAll(ctx context.Context, offset, lim
 	return urm.repo.RetrieveAll(ctx, offset, limit, ids, email, um)
 }
 
+func (urm userRepositoryMiddleware) SaveIdentity(ctx context.Context, userID string, idt users.Identity) error {
+	span := createSpan(ctx, urm.tracer, saveIdentityOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.SaveIdentity(ctx, userID, idt)
+}
+
+func (urm userRepositoryMiddleware) RetrieveByIdentity(ctx context.Context, provider, subject string) (users.User, error) {
+	span := createSpan(ctx, urm.tracer, retrieveByIdentityOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveByIdentity(ctx, provider, subject)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
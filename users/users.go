@@ -40,6 +40,14 @@ type User struct {
 	Metadata Metadata
 }
 
+// Tokens contains the access and refresh token of a user session. If the
+// user has to pass the second login step, only the pre-auth token is set.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	PreAuthToken string
+}
+
 // Validate returns an error if user representation is invalid.
 func (u User) Validate() error {
 	if !isEmail(u.Email) {
@@ -68,6 +76,14 @@ type UserRepository interface {
 
 	// UpdatePassword updates password for user with given email
 	UpdatePassword(ctx context.Context, email, password string) error
+
+	// SaveIdentity links the identity at the OpenID Connect provider to
+	// the user with given ID.
+	SaveIdentity(ctx context.Context, userID string, idt Identity) error
+
+	// RetrieveByIdentity retrieves user linked to the identity with given
+	// subject at the OpenID Connect provider.
+	RetrieveByIdentity(ctx context.Context, provider, subject string) (User, error)
 }
 
 func isEmail(email string) bool {
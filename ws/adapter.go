@@ -0,0 +1,183 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ws contains the domain concept definitions needed to support
+// Mainflux WebSocket adapter service functionality.
+package ws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const chansPrefix = "channels"
+
+var (
+	// ErrUnauthorized indicates that thing is not allowed to access the channel.
+	ErrUnauthorized = errors.New("unauthorized access")
+
+	// ErrSubscribe indicates an error during subscription to the channel.
+	ErrSubscribe = errors.New("unable to subscribe")
+
+	// ErrUnsubscribe indicates an error during unsubscription from the channel.
+	ErrUnsubscribe = errors.New("unable to unsubscribe")
+
+	// ErrWildcardPublish indicates an attempt to publish to the wildcard subtopic.
+	ErrWildcardPublish = errors.New("unable to publish to wildcard subtopic")
+)
+
+// Service specifies WebSocket service API.
+type Service interface {
+	// Authorize checks if the thing identified by the provided key is
+	// allowed to perform the action over the channel.
+	Authorize(ctx context.Context, key, chanID, action string) error
+
+	// Publish publishes the message to the channel on behalf of the thing
+	// identified by the provided key.
+	Publish(ctx context.Context, key string, msg messaging.Message) error
+
+	// Subscribe subscribes the client to the channel with the specified ID
+	// and subtopic. Messages published to the channel are forwarded to the
+	// client until it unsubscribes.
+	Subscribe(ctx context.Context, key, chanID, subtopic string, c *Client) error
+
+	// Unsubscribe stops forwarding channel messages to the client.
+	Unsubscribe(ctx context.Context, key, chanID, subtopic string, c *Client) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	things  mainflux.ThingsServiceClient
+	pubsub  messaging.PubSub
+	mu      sync.Mutex
+	clients map[string]map[*Client]bool
+}
+
+// New instantiates the WebSocket adapter implementation.
+func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
+	return &adapterService{
+		things:  things,
+		pubsub:  pubsub,
+		clients: make(map[string]map[*Client]bool),
+	}
+}
+
+func (svc *adapterService) Authorize(ctx context.Context, key, chanID, action string) error {
+	_, err := svc.authorize(ctx, key, chanID, action)
+	return err
+}
+
+func (svc *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
+	if strings.ContainsAny(msg.Subtopic, "*>") {
+		return ErrWildcardPublish
+	}
+
+	thid, err := svc.authorize(ctx, key, msg.Channel, messaging.PublishAction)
+	if err != nil {
+		return err
+	}
+	msg.Publisher = thid
+
+	return svc.pubsub.Publish(msg.Channel, msg)
+}
+
+func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c *Client) error {
+	thid, err := svc.authorize(ctx, key, chanID, messaging.SubscribeAction)
+	if err != nil {
+		return err
+	}
+	c.id = thid
+
+	subject := subject(chanID, subtopic)
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	clients, ok := svc.clients[subject]
+	if !ok {
+		// Subscribe to the broker only once per subject and fan
+		// the received messages out to all the subscribed clients.
+		if err := svc.pubsub.Subscribe(subject, svc.handle(subject)); err != nil {
+			return errors.Wrap(ErrSubscribe, err)
+		}
+		clients = make(map[*Client]bool)
+		svc.clients[subject] = clients
+	}
+	clients[c] = true
+
+	return nil
+}
+
+func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic string, c *Client) error {
+	if _, err := svc.authorize(ctx, key, chanID, messaging.SubscribeAction); err != nil {
+		return err
+	}
+
+	subject := subject(chanID, subtopic)
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	clients, ok := svc.clients[subject]
+	if !ok || !clients[c] {
+		return ErrUnsubscribe
+	}
+	delete(clients, c)
+	// If there are no clients left for the subject, stop consuming it.
+	if len(clients) == 0 {
+		delete(svc.clients, subject)
+		if err := svc.pubsub.Unsubscribe(subject); err != nil {
+			return errors.Wrap(ErrUnsubscribe, err)
+		}
+	}
+
+	return nil
+}
+
+func (svc *adapterService) authorize(ctx context.Context, key, chanID, action string) (string, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: chanID,
+		Action: action,
+	}
+	thid, err := svc.things.CanAccessByKey(ctx, ar)
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthorized, err)
+	}
+
+	return thid.GetValue(), nil
+}
+
+func (svc *adapterService) handle(subject string) messaging.MessageHandler {
+	return func(msg messaging.Message) error {
+		svc.mu.Lock()
+		clients := make([]*Client, 0, len(svc.clients[subject]))
+		for c := range svc.clients[subject] {
+			clients = append(clients, c)
+		}
+		svc.mu.Unlock()
+
+		var err error
+		for _, c := range clients {
+			if e := c.Handle(msg); e != nil {
+				err = e
+			}
+		}
+		return err
+	}
+}
+
+func subject(chanID, subtopic string) string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
+	if subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, subtopic)
+	}
+	return subject
+}
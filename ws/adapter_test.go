@@ -0,0 +1,180 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/mainflux/mainflux/ws/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID   = "1"
+	thingID  = "1"
+	thingKey = "thing_key"
+	subtopic = "subtopic"
+	wrong    = "wrong"
+)
+
+func newService() ws.Service {
+	things := mocks.NewThingsClient(map[string]string{thingKey: thingID})
+	return ws.New(things, mocks.NewPubSub())
+}
+
+func TestPublish(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc string
+		key  string
+		msg  messaging.Message
+		err  error
+	}{
+		{
+			desc: "publish message",
+			key:  thingKey,
+			msg:  messaging.Message{Channel: chanID, Payload: []byte("payload")},
+			err:  nil,
+		},
+		{
+			desc: "publish message to subtopic",
+			key:  thingKey,
+			msg:  messaging.Message{Channel: chanID, Subtopic: subtopic, Payload: []byte("payload")},
+			err:  nil,
+		},
+		{
+			desc: "publish message to wildcard subtopic",
+			key:  thingKey,
+			msg:  messaging.Message{Channel: chanID, Subtopic: "sub.*", Payload: []byte("payload")},
+			err:  ws.ErrWildcardPublish,
+		},
+		{
+			desc: "publish message with invalid key",
+			key:  wrong,
+			msg:  messaging.Message{Channel: chanID, Payload: []byte("payload")},
+			err:  ws.ErrUnauthorized,
+		},
+		{
+			desc: "publish message with empty key",
+			key:  "",
+			msg:  messaging.Message{Channel: chanID, Payload: []byte("payload")},
+			err:  ws.ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Publish(context.Background(), tc.key, tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	svc := newService()
+
+	c := ws.NewClient(nil)
+	cases := []struct {
+		desc     string
+		key      string
+		chanID   string
+		subtopic string
+		err      error
+	}{
+		{
+			desc:   "subscribe to channel",
+			key:    thingKey,
+			chanID: chanID,
+			err:    nil,
+		},
+		{
+			desc:     "subscribe to channel with subtopic",
+			key:      thingKey,
+			chanID:   chanID,
+			subtopic: subtopic,
+			err:      nil,
+		},
+		{
+			desc:   "subscribe to channel again",
+			key:    thingKey,
+			chanID: chanID,
+			err:    nil,
+		},
+		{
+			desc:   "subscribe to channel with invalid key",
+			key:    wrong,
+			chanID: chanID,
+			err:    ws.ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Subscribe(context.Background(), tc.key, tc.chanID, tc.subtopic, c)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	svc := newService()
+
+	c := ws.NewClient(nil)
+	err := svc.Subscribe(context.Background(), thingKey, chanID, "", c)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		key      string
+		chanID   string
+		subtopic string
+		client   *ws.Client
+		err      error
+	}{
+		{
+			desc:   "unsubscribe from channel with invalid key",
+			key:    wrong,
+			chanID: chanID,
+			client: c,
+			err:    ws.ErrUnauthorized,
+		},
+		{
+			desc:   "unsubscribe other client from channel",
+			key:    thingKey,
+			chanID: chanID,
+			client: ws.NewClient(nil),
+			err:    ws.ErrUnsubscribe,
+		},
+		{
+			desc:     "unsubscribe from not subscribed subtopic",
+			key:      thingKey,
+			chanID:   chanID,
+			subtopic: subtopic,
+			client:   c,
+			err:      ws.ErrUnsubscribe,
+		},
+		{
+			desc:   "unsubscribe from channel",
+			key:    thingKey,
+			chanID: chanID,
+			client: c,
+			err:    nil,
+		},
+		{
+			desc:   "unsubscribe from channel again",
+			key:    thingKey,
+			chanID: chanID,
+			client: c,
+			err:    ws.ErrUnsubscribe,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Unsubscribe(context.Background(), tc.key, tc.chanID, tc.subtopic, tc.client)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
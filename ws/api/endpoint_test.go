@@ -0,0 +1,268 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/mainflux/mainflux/logger"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/mainflux/mainflux/ws/api"
+	"github.com/mainflux/mainflux/ws/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID  = "1"
+	pubKey  = "publisher_key"
+	subKey  = "subscriber_key"
+	payload = `[{"n":"current","t":-1,"v":1.6}]`
+)
+
+func newService() ws.Service {
+	things := mocks.NewThingsClient(map[string]string{
+		pubKey:                 "1",
+		subKey:                 "2",
+		mocks.PublishOnlyKey:   "3",
+		mocks.SubscribeOnlyKey: "4",
+	})
+	return ws.New(things, mocks.NewPubSub())
+}
+
+func newHTTPServer(svc ws.Service, origins ...string) *httptest.Server {
+	logger, _ := log.New(os.Stdout, log.Info.String())
+	mux := api.MakeHandler(svc, origins, logger)
+	return httptest.NewServer(mux)
+}
+
+func dial(ts *httptest.Server, path, key string) (*websocket.Conn, *http.Response, error) {
+	return dialFrom(ts, path, key, "")
+}
+
+func dialFrom(ts *httptest.Server, path, key, origin string) (*websocket.Conn, *http.Response, error) {
+	url := fmt.Sprintf("ws%s%s", strings.TrimPrefix(ts.URL, "http"), path)
+	header := http.Header{}
+	if key != "" {
+		header.Set("Authorization", key)
+	}
+	if origin != "" {
+		header.Set("Origin", origin)
+	}
+	return websocket.DefaultDialer.Dial(url, header)
+}
+
+func expectClose(t *testing.T, desc string, conn *websocket.Conn, code int) {
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, code), fmt.Sprintf("%s: expected close error %d got %s", desc, code, err))
+}
+
+func TestHandshake(t *testing.T) {
+	svc := newService()
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		path   string
+		key    string
+		status int
+	}{
+		{
+			desc:   "connect to channel",
+			path:   fmt.Sprintf("/channels/%s/messages", chanID),
+			key:    subKey,
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect to channel subtopic",
+			path:   fmt.Sprintf("/channels/%s/messages/subtopic", chanID),
+			key:    subKey,
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect to channel using key query parameter",
+			path:   fmt.Sprintf("/channels/%s/messages?authorization=%s", chanID, subKey),
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect to channel without key",
+			path:   fmt.Sprintf("/channels/%s/messages", chanID),
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "connect to channel with malformed subtopic",
+			path:   fmt.Sprintf("/channels/%s/messages/sub*topic", chanID),
+			key:    subKey,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		conn, res, err := dial(ts, tc.path, tc.key)
+		if conn != nil {
+			conn.Close()
+		}
+		require.NotNil(t, res, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestUnauthorizedSubscribe(t *testing.T) {
+	svc := newService()
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	conn, _, err := dial(ts, fmt.Sprintf("/channels/%s/messages", chanID), "invalid")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), fmt.Sprintf("expected policy violation close error got %s", err))
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	svc := newService()
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	path := fmt.Sprintf("/channels/%s/messages", chanID)
+	sub, _, err := dial(ts, path, subKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer sub.Close()
+	pub, _, err := dial(ts, path, pubKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer pub.Close()
+
+	err = pub.WriteMessage(websocket.TextMessage, []byte(payload))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sub.SetReadDeadline(time.Now().Add(time.Second))
+	mt, data, err := sub.ReadMessage()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, websocket.TextMessage, mt, fmt.Sprintf("expected message type %d got %d", websocket.TextMessage, mt))
+	assert.Equal(t, payload, string(data), fmt.Sprintf("expected payload %s got %s", payload, data))
+
+	// Publisher is subscribed as well, but its own messages are not echoed back.
+	pub.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err = pub.ReadMessage()
+	assert.NotNil(t, err, "expected publisher not to receive its own message")
+}
+
+func TestPublishOnly(t *testing.T) {
+	svc := newService()
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	path := fmt.Sprintf("/channels/%s/messages", chanID)
+	sub, _, err := dial(ts, path, subKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer sub.Close()
+	pub, _, err := dial(ts, path, mocks.PublishOnlyKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer pub.Close()
+
+	err = pub.WriteMessage(websocket.TextMessage, []byte(payload))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	sub.SetReadDeadline(time.Now().Add(time.Second))
+	_, data, err := sub.ReadMessage()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, payload, string(data), fmt.Sprintf("expected payload %s got %s", payload, data))
+}
+
+func TestUnauthorizedPublish(t *testing.T) {
+	svc := newService()
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc  string
+		path  string
+		key   string
+		write bool
+	}{
+		{
+			desc:  "publish with subscribe-only key",
+			path:  fmt.Sprintf("/channels/%s/messages", chanID),
+			key:   mocks.SubscribeOnlyKey,
+			write: true,
+		},
+		{
+			desc:  "publish to wildcard subtopic",
+			path:  fmt.Sprintf("/channels/%s/messages/sub/*", chanID),
+			key:   pubKey,
+			write: true,
+		},
+		{
+			desc: "connect publish-only key to wildcard subtopic",
+			path: fmt.Sprintf("/channels/%s/messages/sub/>", chanID),
+			key:  mocks.PublishOnlyKey,
+		},
+	}
+
+	for _, tc := range cases {
+		conn, _, err := dial(ts, tc.path, tc.key)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		if tc.write {
+			err = conn.WriteMessage(websocket.TextMessage, []byte(payload))
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		}
+		expectClose(t, tc.desc, conn, websocket.ClosePolicyViolation)
+		conn.Close()
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	path := fmt.Sprintf("/channels/%s/messages", chanID)
+
+	cases := []struct {
+		desc    string
+		origins []string
+		origin  string
+		status  int
+	}{
+		{
+			desc:   "connect without origin",
+			status: http.StatusSwitchingProtocols,
+		},
+		{
+			desc:   "connect from other origin",
+			origin: "http://example.com",
+			status: http.StatusForbidden,
+		},
+		{
+			desc:    "connect from allowed origin",
+			origins: []string{"http://example.com"},
+			origin:  "http://example.com",
+			status:  http.StatusSwitchingProtocols,
+		},
+		{
+			desc:    "connect from any origin",
+			origins: []string{"*"},
+			origin:  "http://example.com",
+			status:  http.StatusSwitchingProtocols,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newHTTPServer(newService(), tc.origins...)
+		conn, res, err := dialFrom(ts, path, subKey, tc.origin)
+		if conn != nil {
+			conn.Close()
+		}
+		ts.Close()
+		require.NotNil(t, res, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+type connReq struct {
+	key      string
+	chanID   string
+	subtopic string
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-zoo/bone"
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux"
+	log "github.com/mainflux/mainflux/logger"
+	mferrors "github.com/mainflux/mainflux/pkg/errors"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/ws"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const protocol = "websocket"
+
+var (
+	errMalformedData     = errors.New("malformed request data")
+	errMalformedSubtopic = errors.New("malformed subtopic")
+	errMissingKey        = errors.New("missing thing key")
+)
+
+var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
+
+// MakeHandler returns a HTTP handler for API endpoints. Connections from
+// the browsers are accepted only from the same origin or from one of the
+// allowed origins, where "*" allows any origin.
+func MakeHandler(svc ws.Service, origins []string, logger log.Logger) http.Handler {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin(origins),
+	}
+
+	r := bone.New()
+	r.GetFunc("/channels/:id/messages", handshake(svc, upgrader, logger))
+	r.GetFunc("/channels/:id/messages/*", handshake(svc, upgrader, logger))
+	r.GetFunc("/version", mainflux.Version("websocket"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+// checkOrigin returns the function that accepts requests without the
+// origin, requests from the same origin and requests from allowed origins.
+func checkOrigin(origins []string) func(r *http.Request) bool {
+	allowed := make(map[string]bool)
+	for _, o := range origins {
+		allowed[strings.ToLower(o)] = true
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowed["*"] || allowed[strings.ToLower(origin)] {
+			return true
+		}
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// handshake upgrades the connection, subscribes the client to the channel
+// if the thing is allowed to subscribe, and publishes all the messages
+// received over the connection until it is closed.
+func handshake(svc ws.Service, upgrader websocket.Upgrader, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decodeRequest(r)
+		if err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err))
+			return
+		}
+		defer conn.Close()
+
+		ctx := r.Context()
+		c := ws.NewClient(conn)
+
+		// Thing may be allowed only to publish, in which case the connection
+		// is kept open for publishing, unless it targets wildcard subtopic.
+		subscribed := true
+		if err := svc.Subscribe(ctx, req.key, req.chanID, req.subtopic, c); err != nil {
+			if !mferrors.Contains(err, ws.ErrUnauthorized) {
+				logger.Warn(fmt.Sprintf("Failed to subscribe to channel %s: %s", req.chanID, err))
+				closeConn(conn, websocket.CloseInternalServerErr, ws.ErrSubscribe)
+				return
+			}
+			subscribed = false
+		}
+		if !subscribed {
+			if err := svc.Authorize(ctx, req.key, req.chanID, messaging.PublishAction); err != nil || wildcard(req.subtopic) {
+				closeConn(conn, websocket.ClosePolicyViolation, ws.ErrUnauthorized)
+				return
+			}
+		}
+
+		for {
+			_, payload, err := conn.ReadMessage()
+			if err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					logger.Warn(fmt.Sprintf("Failed to read websocket message: %s", err))
+				}
+				break
+			}
+
+			msg := messaging.Message{
+				Protocol: protocol,
+				Channel:  req.chanID,
+				Subtopic: req.subtopic,
+				Payload:  payload,
+				Created:  time.Now().UnixNano(),
+			}
+			if err := svc.Publish(ctx, req.key, msg); err != nil {
+				if mferrors.Contains(err, ws.ErrUnauthorized) || mferrors.Contains(err, ws.ErrWildcardPublish) {
+					closeConn(conn, websocket.ClosePolicyViolation, err)
+					break
+				}
+				logger.Warn(fmt.Sprintf("Failed to publish message: %s", err))
+			}
+		}
+
+		if !subscribed {
+			return
+		}
+		if err := svc.Unsubscribe(ctx, req.key, req.chanID, req.subtopic, c); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to unsubscribe from channel %s: %s", req.chanID, err))
+		}
+	}
+}
+
+func wildcard(subtopic string) bool {
+	return strings.ContainsAny(subtopic, "*>")
+}
+
+func decodeRequest(r *http.Request) (connReq, error) {
+	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
+	if len(channelParts) < 2 {
+		return connReq{}, errMalformedData
+	}
+
+	subtopic, err := parseSubtopic(channelParts[2])
+	if err != nil {
+		return connReq{}, err
+	}
+
+	// Browsers are not able to set headers of WebSocket requests,
+	// so thing key can be passed as a query parameter as well.
+	key := r.Header.Get("Authorization")
+	if key == "" {
+		key = r.URL.Query().Get("authorization")
+	}
+	if key == "" {
+		return connReq{}, errMissingKey
+	}
+
+	req := connReq{
+		key:      key,
+		chanID:   bone.GetValue(r, "id"),
+		subtopic: subtopic,
+	}
+
+	return req, nil
+}
+
+func parseSubtopic(subtopic string) (string, error) {
+	if subtopic == "" {
+		return subtopic, nil
+	}
+
+	subtopic, err := url.QueryUnescape(subtopic)
+	if err != nil {
+		return "", errMalformedSubtopic
+	}
+	subtopic = strings.Replace(subtopic, "/", ".", -1)
+
+	elems := strings.Split(subtopic, ".")
+	filteredElems := []string{}
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+
+		if len(elem) > 1 && (strings.Contains(elem, "*") || strings.Contains(elem, ">")) {
+			return "", errMalformedSubtopic
+		}
+
+		filteredElems = append(filteredElems, elem)
+	}
+
+	subtopic = strings.Join(filteredElems, ".")
+	return subtopic, nil
+}
+
+func closeConn(conn *websocket.Conn, code int, err error) {
+	msg := websocket.FormatCloseMessage(code, err.Error())
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+}
+
+func encodeError(w http.ResponseWriter, err error) {
+	switch err {
+	case errMalformedData, errMalformedSubtopic:
+		w.WriteHeader(http.StatusBadRequest)
+	case errMissingKey:
+		w.WriteHeader(http.StatusForbidden)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+// Client wraps WebSocket connection of the subscribed thing.
+type Client struct {
+	conn *websocket.Conn
+	id   string
+	mu   sync.Mutex
+}
+
+// NewClient returns a new WebSocket client.
+func NewClient(conn *websocket.Conn) *Client {
+	return &Client{conn: conn}
+}
+
+// Handle forwards the message payload to the WebSocket connection.
+// Messages published by the client itself are not echoed back.
+func (c *Client) Handle(msg messaging.Message) error {
+	if msg.Publisher != "" && msg.Publisher == c.id {
+		return nil
+	}
+
+	// Browsers reject text frames that are not valid UTF-8,
+	// so send such payloads as binary frames.
+	mt := websocket.TextMessage
+	if !utf8.Valid(msg.Payload) {
+		mt = websocket.BinaryMessage
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.WriteMessage(mt, msg.Payload)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mainflux/mainflux/pkg/messaging"
+)
+
+const chansPrefix = "channels"
+
+var errNotSubscribed = errors.New("not subscribed")
+
+var _ messaging.PubSub = (*mockPubSub)(nil)
+
+type mockPubSub struct {
+	mu       sync.Mutex
+	handlers map[string]messaging.MessageHandler
+}
+
+// NewPubSub returns mock message publisher/subscriber which delivers
+// published messages to the handlers subscribed to the exact subject.
+func NewPubSub() messaging.PubSub {
+	return &mockPubSub{
+		handlers: make(map[string]messaging.MessageHandler),
+	}
+}
+
+func (ps *mockPubSub) Publish(topic string, msg messaging.Message) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, topic)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.mu.Lock()
+	h, ok := ps.handlers[subject]
+	ps.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return h(msg)
+}
+
+func (ps *mockPubSub) Subscribe(topic string, handler messaging.MessageHandler) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.handlers[topic] = handler
+	return nil
+}
+
+func (ps *mockPubSub) Unsubscribe(topic string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if _, ok := ps.handlers[topic]; !ok {
+		return errNotSubscribed
+	}
+	delete(ps.handlers, topic)
+	return nil
+}
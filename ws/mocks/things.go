@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/mainflux/mainflux"
+	"github.com/mainflux/mainflux/pkg/messaging"
+	"github.com/mainflux/mainflux/things"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsClient)(nil)
+
+const (
+	// ServiceErrToken is used to simulate internal server error.
+	ServiceErrToken = "unavailable"
+
+	// PublishOnlyKey is the key of the thing allowed only to publish.
+	PublishOnlyKey = "publish_only_key"
+
+	// SubscribeOnlyKey is the key of the thing allowed only to subscribe.
+	SubscribeOnlyKey = "subscribe_only_key"
+)
+
+type thingsClient struct {
+	things map[string]string
+}
+
+// NewThingsClient returns mock implementation of things service client.
+func NewThingsClient(data map[string]string) mainflux.ThingsServiceClient {
+	return &thingsClient{data}
+}
+
+func (tc thingsClient) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	key := req.GetToken()
+
+	// Since there is no appropriate way to simulate internal server error,
+	// we had to use this obscure approach. ErrorToken simulates gRPC
+	// call which returns internal server error.
+	if key == ServiceErrToken {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	if key == "" {
+		return nil, things.ErrUnauthorizedAccess
+	}
+
+	if (key == PublishOnlyKey && req.GetAction() != messaging.PublishAction) ||
+		(key == SubscribeOnlyKey && req.GetAction() != messaging.SubscribeAction) {
+		return nil, status.Error(codes.PermissionDenied, "action not allowed")
+	}
+
+	id, ok := tc.things[key]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "invalid credentials provided")
+	}
+
+	return &mainflux.ThingID{Value: id}, nil
+}
+
+func (tc thingsClient) CanAccessByID(context.Context, *mainflux.AccessByIDReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) IsChannelOwner(context.Context, *mainflux.ChannelOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) IsThingOwner(context.Context, *mainflux.ThingOwnerReq, ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (tc thingsClient) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}